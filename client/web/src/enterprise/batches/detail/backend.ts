@@ -44,6 +44,8 @@ import {
     CloseChangesetsVariables,
     PublishChangesetsResult,
     PublishChangesetsVariables,
+    RevertChangesetsResult,
+    RevertChangesetsVariables,
     AvailableBulkOperationsVariables,
     AvailableBulkOperationsResult,
     BulkOperationType,
@@ -818,6 +820,20 @@ export async function publishChangesets(
     dataOrThrowErrors(result)
 }
 
+export async function revertChangesets(batchChange: Scalars['ID'], changesets: Scalars['ID'][]): Promise<void> {
+    const result = await requestGraphQL<RevertChangesetsResult, RevertChangesetsVariables>(
+        gql`
+            mutation RevertChangesets($batchChange: ID!, $changesets: [ID!]!) {
+                revertChangesets(batchChange: $batchChange, changesets: $changesets) {
+                    id
+                }
+            }
+        `,
+        { batchChange, changesets }
+    ).toPromise()
+    dataOrThrowErrors(result)
+}
+
 export const BULK_OPERATIONS = gql`
     query BatchChangeBulkOperations($batchChange: ID!, $first: Int, $after: String) {
         node(id: $batchChange) {
@@ -1,6 +1,14 @@
 import React from 'react'
 
-import { mdiCommentOutline, mdiLinkVariantRemove, mdiSync, mdiSourceBranch, mdiUpload, mdiOpenInNew } from '@mdi/js'
+import {
+    mdiCommentOutline,
+    mdiLinkVariantRemove,
+    mdiSync,
+    mdiSourceBranch,
+    mdiUpload,
+    mdiOpenInNew,
+    mdiUndoVariant,
+} from '@mdi/js'
 import classNames from 'classnames'
 
 import { Timestamp } from '@sourcegraph/branded/src/components/Timestamp'
@@ -44,6 +52,11 @@ const OPERATION_TITLES: Record<BulkOperationType, JSX.Element> = {
             <Icon aria-hidden={true} className="text-muted" svgPath={mdiUpload} /> Publish changesets
         </>
     ),
+    REVERT: (
+        <>
+            <Icon aria-hidden={true} className="text-muted" svgPath={mdiUndoVariant} /> Revert changesets
+        </>
+    ),
 }
 
 export interface BulkOperationNodeProps {
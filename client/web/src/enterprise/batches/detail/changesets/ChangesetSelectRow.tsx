@@ -21,6 +21,7 @@ import { DetachChangesetsModal } from './DetachChangesetsModal'
 import { MergeChangesetsModal } from './MergeChangesetsModal'
 import { PublishChangesetsModal } from './PublishChangesetsModal'
 import { ReenqueueChangesetsModal } from './ReenqueueChangesetsModal'
+import { RevertChangesetsModal } from './RevertChangesetsModal'
 
 /**
  * Describes a possible action on the changeset list.
@@ -149,6 +150,25 @@ const AVAILABLE_ACTIONS: Record<BulkOperationType, ChangesetListAction> = {
             )
         },
     },
+    [BulkOperationType.REVERT]: {
+        type: 'revert',
+        experimental: true,
+        buttonLabel: 'Revert changesets',
+        dropdownTitle: 'Revert changesets',
+        dropdownDescription:
+            'Create a new batch change with unpublished changesets that revert the changes of all selected merged changesets.',
+        onTrigger: (batchChangeID, changesetIDs, onDone, onCancel) => {
+            eventLogger.log('batch_change_details:bulk_action_revert:clicked')
+            return (
+                <RevertChangesetsModal
+                    batchChangeID={batchChangeID}
+                    changesetIDs={changesetIDs}
+                    afterCreate={onDone}
+                    onCancel={onCancel}
+                />
+            )
+        },
+    },
 }
 
 export interface ChangesetSelectRowProps {
@@ -0,0 +1,72 @@
+import React, { useCallback, useState } from 'react'
+
+import { asError, isErrorLike } from '@sourcegraph/common'
+import { Button, Modal, H3, Text, ErrorAlert } from '@sourcegraph/wildcard'
+
+import { LoaderButton } from '../../../../components/LoaderButton'
+import { Scalars } from '../../../../graphql-operations'
+import { revertChangesets as _revertChangesets } from '../backend'
+
+export interface RevertChangesetsModalProps {
+    onCancel: () => void
+    afterCreate: () => void
+    batchChangeID: Scalars['ID']
+    changesetIDs: Scalars['ID'][]
+
+    /** For testing only. */
+    revertChangesets?: typeof _revertChangesets
+}
+
+export const RevertChangesetsModal: React.FunctionComponent<React.PropsWithChildren<RevertChangesetsModalProps>> = ({
+    onCancel,
+    afterCreate,
+    batchChangeID,
+    changesetIDs,
+    revertChangesets = _revertChangesets,
+}) => {
+    const [isLoading, setIsLoading] = useState<boolean | Error>(false)
+
+    const onSubmit = useCallback<React.FormEventHandler>(async () => {
+        setIsLoading(true)
+        try {
+            await revertChangesets(batchChangeID, changesetIDs)
+            afterCreate()
+        } catch (error) {
+            setIsLoading(asError(error))
+        }
+    }, [changesetIDs, revertChangesets, batchChangeID, afterCreate])
+
+    return (
+        <Modal onDismiss={onCancel} aria-labelledby={LABEL_ID}>
+            <H3 id={LABEL_ID}>Revert changesets</H3>
+            <Text>Are you sure you want to revert the changes of all the selected merged changesets?</Text>
+            <Text className="mb-4">
+                A new batch change named after this batch change with a <code>-revert</code> suffix will be created,
+                with an unpublished changeset per reverted changeset. Changesets whose changes conflict with later
+                changes to their base branch are reported as errors of this bulk operation.
+            </Text>
+            {isErrorLike(isLoading) && <ErrorAlert error={isLoading} />}
+            <div className="d-flex justify-content-end">
+                <Button
+                    disabled={isLoading === true}
+                    className="mr-2"
+                    onClick={onCancel}
+                    outline={true}
+                    variant="secondary"
+                >
+                    Cancel
+                </Button>
+                <LoaderButton
+                    onClick={onSubmit}
+                    disabled={isLoading === true}
+                    variant="primary"
+                    loading={isLoading === true}
+                    alwaysShowLabel={true}
+                    label="Revert"
+                />
+            </div>
+        </Modal>
+    )
+}
+
+const LABEL_ID = 'revert-changesets-modal-title'
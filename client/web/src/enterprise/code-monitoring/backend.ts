@@ -51,6 +51,20 @@ const MonitorWebhookFragment = gql`
     }
 `
 
+const MonitorIssueFragment = gql`
+    fragment MonitorIssueFields on MonitorIssue {
+        __typename
+        id
+        enabled
+        includeResults
+        repository {
+            id
+            name
+        }
+        issueURL
+    }
+`
+
 const MonitorSlackWebhookFragment = gql`
     fragment MonitorSlackWebhookFields on MonitorSlackWebhook {
         __typename
@@ -78,12 +92,14 @@ const CodeMonitorFragment = gql`
                 ...MonitorEmailFields
                 ...MonitorWebhookFields
                 ...MonitorSlackWebhookFields
+                ...MonitorIssueFields
             }
         }
     }
     ${MonitorEmailFragment}
     ${MonitorWebhookFragment}
     ${MonitorSlackWebhookFragment}
+    ${MonitorIssueFragment}
 `
 
 const ListCodeMonitorsFragment = gql`
@@ -227,6 +243,16 @@ export const fetchCodeMonitor = (id: string): Observable<FetchCodeMonitorResult>
                                 includeResults
                                 url
                             }
+                            ... on MonitorIssue {
+                                id
+                                enabled
+                                includeResults
+                                repository {
+                                    id
+                                    name
+                                }
+                                issueURL
+                            }
                         }
                     }
                     trigger {
@@ -28,7 +28,8 @@ interface TriggerAreaProps {
     isSourcegraphDotCom: boolean
 }
 
-const isDiffOrCommit = (value: string): boolean => value === 'diff' || value === 'commit'
+const isSupportedType = (value: string): boolean =>
+    value === 'diff' || value === 'commit' || value === 'file' || value === 'symbol'
 const isLiteralOrRegexp = (value: string): boolean => value === 'literal' || value === 'regexp'
 
 const ValidQueryChecklistItem: React.FunctionComponent<
@@ -110,17 +111,17 @@ export const FormTriggerArea: React.FunctionComponent<React.PropsWithChildren<Tr
     }, [])
 
     const [isValidQuery, setIsValidQuery] = useState(false)
-    const [hasTypeDiffOrCommitFilter, setHasTypeDiffOrCommitFilter] = useState(false)
+    const [hasSupportedTypeFilter, setHasSupportedTypeFilter] = useState(false)
     const [hasRepoFilter, setHasRepoFilter] = useState(false)
     const [hasPatternTypeFilter, setHasPatternTypeFilter] = useState(false)
     const [hasValidPatternTypeFilter, setHasValidPatternTypeFilter] = useState(true)
     const isTriggerQueryComplete = useMemo(
         () =>
             isValidQuery &&
-            hasTypeDiffOrCommitFilter &&
+            hasSupportedTypeFilter &&
             (!isSourcegraphDotCom || hasRepoFilter) &&
             hasValidPatternTypeFilter,
-        [hasRepoFilter, hasTypeDiffOrCommitFilter, hasValidPatternTypeFilter, isValidQuery, isSourcegraphDotCom]
+        [hasRepoFilter, hasSupportedTypeFilter, hasValidPatternTypeFilter, isValidQuery, isSourcegraphDotCom]
     )
 
     const [queryState, setQueryState] = useState<QueryState>({ query: query || '' })
@@ -135,19 +136,19 @@ export const FormTriggerArea: React.FunctionComponent<React.PropsWithChildren<Tr
         const isValidQuery = !!value && tokens.type === 'success'
         setIsValidQuery(isValidQuery)
 
-        let hasTypeDiffOrCommitFilter = false
+        let hasSupportedTypeFilter = false
         let hasRepoFilter = false
         let hasPatternTypeFilter = false
         let hasValidPatternTypeFilter = true
 
         if (tokens.type === 'success') {
             const filters = tokens.term.filter(token => token.type === 'filter')
-            hasTypeDiffOrCommitFilter = filters.some(
+            hasSupportedTypeFilter = filters.some(
                 filter =>
                     filter.type === 'filter' &&
                     resolveFilter(filter.field.value)?.type === FilterType.type &&
                     filter.value &&
-                    isDiffOrCommit(filter.value.value)
+                    isSupportedType(filter.value.value)
             )
 
             hasRepoFilter = filters.some(
@@ -178,7 +179,7 @@ export const FormTriggerArea: React.FunctionComponent<React.PropsWithChildren<Tr
                 )
         }
 
-        setHasTypeDiffOrCommitFilter(hasTypeDiffOrCommitFilter)
+        setHasSupportedTypeFilter(hasSupportedTypeFilter)
         setHasRepoFilter(hasRepoFilter)
         setHasPatternTypeFilter(hasPatternTypeFilter)
         setHasValidPatternTypeFilter(hasValidPatternTypeFilter)
@@ -278,11 +279,12 @@ export const FormTriggerArea: React.FunctionComponent<React.PropsWithChildren<Tr
                             </li>
                             <li>
                                 <ValidQueryChecklistItem
-                                    checked={hasTypeDiffOrCommitFilter}
-                                    hint="type:diff targets code present in new commits, while type:commit targets commit messages"
+                                    checked={hasSupportedTypeFilter}
+                                    hint="type:diff targets code present in new commits and type:commit targets commit messages, while type:file and type:symbol target new matches in the code on the default branch"
                                     dataTestid="type-checkbox"
                                 >
-                                    Contains a <Code>type:diff</Code> or <Code>type:commit</Code> filter
+                                    Contains a <Code>type:diff</Code>, <Code>type:commit</Code>, <Code>type:file</Code>, or{' '}
+                                    <Code>type:symbol</Code> filter
                                 </ValidQueryChecklistItem>
                             </li>
                             {/* Enforce repo filter on sourcegraph.com because otherwise it's too easy to generate a lot of load */}
@@ -153,5 +153,7 @@ function getActionEventTitle(action: MonitorTriggerEventWithActions['actions']['
             return 'Slack'
         case 'MonitorWebhook':
             return 'Webhook'
+        case 'MonitorIssue':
+            return 'Issue'
     }
 }
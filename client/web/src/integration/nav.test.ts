@@ -58,6 +58,7 @@ const notebookFixture = (id: string, title: string, blocks: NotebookFields['bloc
     title,
     createdAt: subDays(now, 5).toISOString(),
     updatedAt: subDays(now, 5).toISOString(),
+    version: 1,
     public: true,
     viewerCanManage: true,
     viewerHasStarred: true,
@@ -3,12 +3,14 @@ import path from 'path'
 
 import { subDays } from 'date-fns'
 import expect from 'expect'
+import { GraphQLError } from 'graphql'
 
 import { SharedGraphQlOperations } from '@sourcegraph/shared/src/graphql-operations'
 import { highlightFileResult, mixedSearchStreamEvents } from '@sourcegraph/shared/src/search/integration'
 import { SearchEvent } from '@sourcegraph/shared/src/search/stream'
 import { accessibilityAudit } from '@sourcegraph/shared/src/testing/accessibility'
 import { Driver, createDriverForTest } from '@sourcegraph/shared/src/testing/driver'
+import { IntegrationTestGraphQlError } from '@sourcegraph/shared/src/testing/integration/context'
 import { afterEachSaveScreenshotIfFailed } from '@sourcegraph/shared/src/testing/screenshotReporter'
 
 import {
@@ -76,6 +78,7 @@ const notebookFixture = (id: string, title: string, blocks: NotebookFields['bloc
     title,
     createdAt: subDays(now, 5).toISOString(),
     updatedAt: subDays(now, 5).toISOString(),
+    version: 1,
     public: true,
     viewerCanManage: true,
     viewerHasStarred: true,
@@ -449,6 +452,27 @@ describe('Search Notebook', () => {
         expect(titleText).toEqual('Notebook Title Edited')
     })
 
+    it('Should send the notebook version and show a conflict if the notebook was updated elsewhere', async () => {
+        const updateVersions: (number | null | undefined)[] = []
+        testContext.overrideGraphQL({
+            UpdateNotebook: ({ version }) => {
+                updateVersions.push(version)
+                throw new IntegrationTestGraphQlError([new GraphQLError('notebook was updated since it was last read')])
+            },
+        })
+
+        await driver.page.goto(driver.sourcegraphBaseUrl + '/notebooks/n1')
+        await driver.page.waitForSelector('[data-block-id]', { visible: true })
+
+        await driver.page.click('[data-testid="notebook-title-button"]')
+        await driver.page.waitForSelector('[data-testid="notebook-title-input"]')
+        await driver.enterText('type', ' Edited')
+        await driver.page.keyboard.press('Enter')
+
+        await driver.page.waitForSelector('[data-testid="notebook-version-conflict"]', { visible: true })
+        expect(updateVersions).toEqual([1])
+    })
+
     it('Should open the share dialog, switch the share option, and close the dialog', async () => {
         await driver.page.goto(driver.sourcegraphBaseUrl + '/notebooks/n1')
         await driver.page.waitForSelector('[data-testid="share-notebook-button"]', { visible: true })
@@ -43,6 +43,7 @@ const notebooksFragment = gql`
         }
         createdAt
         updatedAt
+        version
         public
         viewerCanManage
         viewerHasStarred
@@ -204,14 +205,21 @@ export function createNotebook(variables: CreateNotebookVariables): Observable<N
 }
 
 const updateNotebookMutation = gql`
-    mutation UpdateNotebook($id: ID!, $notebook: NotebookInput!) {
-        updateNotebook(id: $id, notebook: $notebook) {
+    mutation UpdateNotebook($id: ID!, $notebook: NotebookInput!, $version: Int) {
+        updateNotebook(id: $id, notebook: $notebook, version: $version) {
             ...NotebookFields
         }
     }
     ${notebooksFragment}
 `
 
+// The message of the error returned by updateNotebook if the notebook was updated since the
+// version the update is based on.
+const NOTEBOOK_VERSION_CONFLICT_ERROR_MESSAGE = 'notebook was updated since it was last read'
+
+export const isNotebookVersionConflictError = (error: Error): boolean =>
+    error.message.includes(NOTEBOOK_VERSION_CONFLICT_ERROR_MESSAGE)
+
 export function updateNotebook(variables: UpdateNotebookVariables): Observable<NotebookFields> {
     // Remove any null blocks. This is caused by deleted block types.
     variables.notebook.blocks = variables.notebook.blocks.filter(block => block)
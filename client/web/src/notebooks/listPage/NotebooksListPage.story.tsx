@@ -33,6 +33,7 @@ const fetchNotebooks = (): Observable<ListNotebooksResult['notebooks']> =>
                 title: 'Notebook Title 1',
                 createdAt: subDays(now, 5).toISOString(),
                 updatedAt: subDays(now, 2).toISOString(),
+                version: 1,
                 public: true,
                 viewerCanManage: true,
                 viewerHasStarred: true,
@@ -51,6 +52,7 @@ const fetchNotebooks = (): Observable<ListNotebooksResult['notebooks']> =>
                 title: 'Notebook Title 2',
                 createdAt: subDays(now, 5).toISOString(),
                 updatedAt: subDays(now, 1).toISOString(),
+                version: 1,
                 public: true,
                 viewerCanManage: true,
                 viewerHasStarred: true,
@@ -41,6 +41,7 @@ import {
     deleteNotebook as _deleteNotebook,
     createNotebookStar as _createNotebookStar,
     deleteNotebookStar as _deleteNotebookStar,
+    isNotebookVersionConflictError,
 } from '../backend'
 import { NOTEPAD_ENABLED_EVENT } from '../listPage/NotebooksListPageHeader'
 import { copyNotebook as _copyNotebook, CopyNotebookProps } from '../notebook'
@@ -121,10 +122,10 @@ export const NotebookPage: React.FunctionComponent<React.PropsWithChildren<Noteb
 
     const [onUpdateNotebook, updatedNotebookOrError] = useEventObservable(
         useCallback(
-            (update: Observable<NotebookInput>) =>
+            (update: Observable<{ notebook: NotebookInput; version: number }>) =>
                 update.pipe(
-                    switchMap(notebook =>
-                        updateNotebook({ id: notebookId!, notebook }).pipe(delay(300), startWith(LOADING))
+                    switchMap(({ notebook, version }) =>
+                        updateNotebook({ id: notebookId!, notebook, version }).pipe(delay(300), startWith(LOADING))
                     ),
                     catchError(error => [asError(error)])
                 ),
@@ -152,13 +153,17 @@ export const NotebookPage: React.FunctionComponent<React.PropsWithChildren<Noteb
             // Clear the queue for new updates and save the changes to the backend.
             setUpdateQueue([])
             onUpdateNotebook({
-                // Use current notebook state as defaults.
-                title: latestNotebook.title,
-                blocks: latestNotebook.blocks.map(GQLBlockToGQLInput),
-                public: latestNotebook.public,
-                namespace: latestNotebook.namespace.id,
-                // Apply updates.
-                ...updateInput,
+                notebook: {
+                    // Use current notebook state as defaults.
+                    title: latestNotebook.title,
+                    blocks: latestNotebook.blocks.map(GQLBlockToGQLInput),
+                    public: latestNotebook.public,
+                    namespace: latestNotebook.namespace.id,
+                    // Apply updates.
+                    ...updateInput,
+                },
+                // Reject the update instead of overwriting changes made elsewhere since the notebook was loaded.
+                version: latestNotebook.version,
             })
         }
     }, [updateQueue, latestNotebook, onUpdateNotebook, setUpdateQueue])
@@ -213,11 +218,25 @@ export const NotebookPage: React.FunctionComponent<React.PropsWithChildren<Noteb
                             Error while loading the notebook: <strong>{notebookOrError.message}</strong>
                         </Alert>
                     )}
-                    {isErrorLike(updatedNotebookOrError) && (
-                        <Alert variant="danger">
-                            Error while updating the notebook: <strong>{updatedNotebookOrError.message}</strong>
-                        </Alert>
-                    )}
+                    {isErrorLike(updatedNotebookOrError) &&
+                        (isNotebookVersionConflictError(updatedNotebookOrError) ? (
+                            <Alert variant="warning" data-testid="notebook-version-conflict">
+                                This notebook was updated elsewhere since you opened it, so your latest changes were not
+                                saved.{' '}
+                                <Button
+                                    variant="link"
+                                    className="p-0 align-baseline"
+                                    onClick={() => window.location.reload()}
+                                >
+                                    Reload the notebook
+                                </Button>{' '}
+                                to see the latest version.
+                            </Alert>
+                        ) : (
+                            <Alert variant="danger">
+                                Error while updating the notebook: <strong>{updatedNotebookOrError.message}</strong>
+                            </Alert>
+                        ))}
                     {notebookOrError === LOADING && (
                         <div className="d-flex justify-content-center">
                             <LoadingSpinner />
@@ -99,7 +99,7 @@ export const SearchActionsMenu: React.FunctionComponent<SearchActionsMenuProps>
                             <Tooltip
                                 content={
                                     authenticatedUser && !canCreateMonitor
-                                        ? 'Code monitors only support type:diff or type:commit searches.'
+                                        ? 'Code monitors only support type:diff, type:commit, type:file, or type:symbol searches.'
                                         : undefined
                                 }
                                 placement="left"
@@ -84,7 +84,11 @@ export const SearchResultsInfoBar: React.FunctionComponent<
     )
 
     const canCreateMonitorFromQuery = useMemo(
-        () => globalTypeFilter === 'diff' || globalTypeFilter === 'commit',
+        () =>
+            globalTypeFilter === 'diff' ||
+            globalTypeFilter === 'commit' ||
+            globalTypeFilter === 'file' ||
+            globalTypeFilter === 'symbol',
         [globalTypeFilter]
     )
 
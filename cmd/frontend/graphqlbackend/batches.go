@@ -97,6 +97,15 @@ type CreateBatchSpecFromRawArgs struct {
 	BatchChange      graphql.ID
 }
 
+type CreateBatchSpecFromComputeReplaceArgs struct {
+	Query         string
+	Name          string
+	Description   *string
+	Branch        *string
+	CommitMessage *string
+	Namespace     graphql.ID
+}
+
 type ReplaceBatchSpecInputArgs struct {
 	PreviousSpec     graphql.ID
 	BatchSpec        string
@@ -233,6 +242,10 @@ type PublishChangesetsArgs struct {
 	Draft bool
 }
 
+type RevertChangesetsArgs struct {
+	BulkOperationBaseArgs
+}
+
 type ResolveWorkspacesForBatchSpecArgs struct {
 	BatchSpec string
 }
@@ -256,6 +269,7 @@ type BatchChangesResolver interface {
 	CreateEmptyBatchChange(ctx context.Context, args *CreateEmptyBatchChangeArgs) (BatchChangeResolver, error)
 	UpsertEmptyBatchChange(ctx context.Context, args *UpsertEmptyBatchChangeArgs) (BatchChangeResolver, error)
 	CreateBatchSpecFromRaw(ctx context.Context, args *CreateBatchSpecFromRawArgs) (BatchSpecResolver, error)
+	CreateBatchSpecFromComputeReplace(ctx context.Context, args *CreateBatchSpecFromComputeReplaceArgs) (BatchSpecResolver, error)
 	ReplaceBatchSpecInput(ctx context.Context, args *ReplaceBatchSpecInputArgs) (BatchSpecResolver, error)
 	UpsertBatchSpecInput(ctx context.Context, args *UpsertBatchSpecInputArgs) (BatchSpecResolver, error)
 	DeleteBatchSpec(ctx context.Context, args *DeleteBatchSpecArgs) (*EmptyResponse, error)
@@ -284,6 +298,7 @@ type BatchChangesResolver interface {
 	MergeChangesets(ctx context.Context, args *MergeChangesetsArgs) (BulkOperationResolver, error)
 	CloseChangesets(ctx context.Context, args *CloseChangesetsArgs) (BulkOperationResolver, error)
 	PublishChangesets(ctx context.Context, args *PublishChangesetsArgs) (BulkOperationResolver, error)
+	RevertChangesets(ctx context.Context, args *RevertChangesetsArgs) (BulkOperationResolver, error)
 
 	// Queries
 	BatchChange(ctx context.Context, args *BatchChangeArgs) (BatchChangeResolver, error)
@@ -831,6 +846,7 @@ type ChangesetCountsResolver interface {
 	OpenApproved() int32
 	OpenChangesRequested() int32
 	OpenPending() int32
+	Blocked() int32
 }
 
 type BatchSpecWorkspaceResolutionResolver interface {
@@ -17,6 +17,8 @@ type CodeMonitorsResolver interface {
 	// Mutations
 	CreateCodeMonitor(ctx context.Context, args *CreateCodeMonitorArgs) (MonitorResolver, error)
 	ToggleCodeMonitor(ctx context.Context, args *ToggleCodeMonitorArgs) (MonitorResolver, error)
+	SnoozeCodeMonitor(ctx context.Context, args *SnoozeCodeMonitorArgs) (MonitorResolver, error)
+	UnsnoozeCodeMonitor(ctx context.Context, args *UnsnoozeCodeMonitorArgs) (MonitorResolver, error)
 	DeleteCodeMonitor(ctx context.Context, args *DeleteCodeMonitorArgs) (*EmptyResponse, error)
 	UpdateCodeMonitor(ctx context.Context, args *UpdateCodeMonitorArgs) (MonitorResolver, error)
 	ResetTriggerQueryTimestamps(ctx context.Context, args *ResetTriggerQueryTimestampsArgs) (*EmptyResponse, error)
@@ -40,6 +42,7 @@ type MonitorResolver interface {
 	Description() string
 	Owner(ctx context.Context) (NamespaceResolver, error)
 	Enabled() bool
+	SnoozedUntil() *gqlutil.DateTime
 	Trigger(ctx context.Context) (MonitorTrigger, error)
 	Actions(ctx context.Context, args *ListActionArgs) (MonitorActionConnectionResolver, error)
 }
@@ -81,6 +84,7 @@ type MonitorAction interface {
 	ToMonitorEmail() (MonitorEmailResolver, bool)
 	ToMonitorWebhook() (MonitorWebhookResolver, bool)
 	ToMonitorSlackWebhook() (MonitorSlackWebhookResolver, bool)
+	ToMonitorIssue() (MonitorIssueResolver, bool)
 }
 
 type MonitorEmailResolver interface {
@@ -89,6 +93,7 @@ type MonitorEmailResolver interface {
 	IncludeResults() bool
 	Priority() string
 	Header() string
+	DeliverySchedule() string
 	Recipients(ctx context.Context, args *ListRecipientsArgs) (MonitorActionEmailRecipientsConnectionResolver, error)
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
@@ -106,6 +111,16 @@ type MonitorSlackWebhookResolver interface {
 	Enabled() bool
 	IncludeResults() bool
 	URL() string
+	DeliverySchedule() string
+	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
+}
+
+type MonitorIssueResolver interface {
+	ID() graphql.ID
+	Enabled() bool
+	IncludeResults() bool
+	Repository(ctx context.Context) (*RepositoryResolver, error)
+	IssueURL() *string
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
 
@@ -166,6 +181,7 @@ type CreateActionArgs struct {
 	Email        *CreateActionEmailArgs
 	Webhook      *CreateActionWebhookArgs
 	SlackWebhook *CreateActionSlackWebhookArgs
+	Issue        *CreateActionIssueArgs
 }
 
 type CreateActionEmailArgs struct {
@@ -174,6 +190,9 @@ type CreateActionEmailArgs struct {
 	Priority       string
 	Recipients     []graphql.ID
 	Header         string
+
+	// DeliverySchedule is nil if the input doesn't set it.
+	DeliverySchedule *string
 }
 
 type CreateActionWebhookArgs struct {
@@ -186,6 +205,15 @@ type CreateActionSlackWebhookArgs struct {
 	Enabled        bool
 	IncludeResults bool
 	URL            string
+
+	// DeliverySchedule is nil if the input doesn't set it.
+	DeliverySchedule *string
+}
+
+type CreateActionIssueArgs struct {
+	Enabled        bool
+	IncludeResults bool
+	Repository     graphql.ID
 }
 
 type ToggleCodeMonitorArgs struct {
@@ -193,6 +221,15 @@ type ToggleCodeMonitorArgs struct {
 	Enabled bool
 }
 
+type SnoozeCodeMonitorArgs struct {
+	Id      graphql.ID
+	Minutes int32
+}
+
+type UnsnoozeCodeMonitorArgs struct {
+	Id graphql.ID
+}
+
 type DeleteCodeMonitorArgs struct {
 	Id graphql.ID
 }
@@ -240,10 +277,16 @@ type EditActionSlackWebhookArgs struct {
 	Update *CreateActionSlackWebhookArgs
 }
 
+type EditActionIssueArgs struct {
+	Id     *graphql.ID
+	Update *CreateActionIssueArgs
+}
+
 type EditActionArgs struct {
 	Email        *EditActionEmailArgs
 	Webhook      *EditActionWebhookArgs
 	SlackWebhook *EditActionSlackWebhookArgs
+	Issue        *EditActionIssueArgs
 }
 
 type EditTriggerArgs struct {
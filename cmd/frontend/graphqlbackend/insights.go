@@ -41,6 +41,11 @@ type InsightsResolver interface {
 	DeleteInsightView(ctx context.Context, args *DeleteInsightViewArgs) (*EmptyResponse, error)
 	SaveInsightAsNewView(ctx context.Context, args SaveInsightAsNewViewArgs) (InsightViewPayloadResolver, error)
 
+	// Alerts
+	InsightSeriesAlerts(ctx context.Context, args *InsightSeriesAlertsArgs) ([]InsightSeriesAlertResolver, error)
+	CreateInsightSeriesAlert(ctx context.Context, args *CreateInsightSeriesAlertArgs) (InsightSeriesAlertResolver, error)
+	DeleteInsightSeriesAlert(ctx context.Context, args *DeleteInsightSeriesAlertArgs) (*EmptyResponse, error)
+
 	// Admin Management
 	InsightSeriesQueryStatus(ctx context.Context) ([]InsightSeriesQueryStatusResolver, error)
 	InsightViewDebug(ctx context.Context, args InsightViewDebugArgs) (InsightViewDebugResolver, error)
@@ -200,6 +205,7 @@ type InsightViewResolver interface {
 	DataSeries(ctx context.Context) ([]InsightSeriesResolver, error)
 	Presentation(ctx context.Context) (InsightPresentation, error)
 	DataSeriesDefinitions(ctx context.Context) ([]InsightDataSeriesDefinition, error)
+	DerivedSeries(ctx context.Context) ([]DerivedInsightSeriesResolver, error)
 	DashboardReferenceCount(ctx context.Context) (int32, error)
 	IsFrozen(ctx context.Context) (bool, error)
 	DefaultSeriesDisplayOptions(ctx context.Context) (InsightViewSeriesDisplayOptionsResolver, error)
@@ -224,6 +230,13 @@ type PieChartInsightViewPresentation interface {
 	OtherThreshold(ctx context.Context) (float64, error)
 }
 
+type DerivedInsightSeriesResolver interface {
+	SeriesId() string
+	Label() string
+	Expression() string
+	LineColor() string
+}
+
 type LineChartDataSeriesPresentationResolver interface {
 	SeriesId(ctx context.Context) (string, error)
 	Label(ctx context.Context) (string, error)
@@ -310,6 +323,43 @@ type InsightSeriesMetadataPayloadResolver interface {
 	Series(ctx context.Context) InsightSeriesMetadataResolver
 }
 
+type InsightSeriesAlertsArgs struct {
+	InsightViewId graphql.ID
+}
+
+type CreateInsightSeriesAlertArgs struct {
+	Input CreateInsightSeriesAlertInput
+}
+
+type CreateInsightSeriesAlertInput struct {
+	InsightViewId   graphql.ID
+	SeriesId        string
+	Kind            string
+	Threshold       *float64
+	WindowDays      *int32
+	Email           bool
+	SlackWebhookURL *string
+	OutboundWebhook bool
+}
+
+type DeleteInsightSeriesAlertArgs struct {
+	Id graphql.ID
+}
+
+type InsightSeriesAlertResolver interface {
+	ID() graphql.ID
+	SeriesId() string
+	Kind() string
+	Threshold() float64
+	WindowDays() int32
+	Email() bool
+	SlackWebhookURL() *string
+	OutboundWebhook() bool
+	Firing() bool
+	LastFiredAt() *gqlutil.DateTime
+	CreatedAt() gqlutil.DateTime
+}
+
 type InsightSeriesQueryStatusResolver interface {
 	SeriesId(ctx context.Context) (string, error)
 	Query(ctx context.Context) (string, error)
@@ -347,6 +397,7 @@ type CreateLineChartSearchInsightInput struct {
 	ViewControls    *InsightViewControlsInput
 	RepositoryScope *RepositoryScopeInput
 	TimeScope       *TimeScopeInput
+	DerivedSeries   *[]DerivedInsightSeriesInput
 }
 
 type UpdateLineChartSearchInsightArgs struct {
@@ -360,6 +411,7 @@ type UpdateLineChartSearchInsightInput struct {
 	ViewControls        InsightViewControlsInput
 	RepositoryScope     *RepositoryScopeInput
 	TimeScope           *TimeScopeInput
+	DerivedSeries       *[]DerivedInsightSeriesInput
 }
 
 type CreatePieChartSearchInsightArgs struct {
@@ -436,6 +488,13 @@ type LineChartDataSeriesOptionsInput struct {
 	LineColor *string
 }
 
+type DerivedInsightSeriesInput struct {
+	SeriesId   *string
+	Label      string
+	Expression string
+	LineColor  *string
+}
+
 type RepositoryScopeInput struct {
 	Repositories       []string
 	RepositoryCriteria *string
@@ -27,6 +27,10 @@ type NotebooksResolver interface {
 	CreateNotebookStar(ctx context.Context, args CreateNotebookStarInputArgs) (NotebookStarResolver, error)
 	DeleteNotebookStar(ctx context.Context, args DeleteNotebookStarInputArgs) (*EmptyResponse, error)
 
+	RunNotebookBlock(ctx context.Context, args RunNotebookBlockArgs) (NotebookBlockSnapshotResolver, error)
+
+	RestoreNotebookRevision(ctx context.Context, args RestoreNotebookRevisionArgs) (NotebookResolver, error)
+
 	NodeResolvers() map[string]NodeByIDFunc
 }
 
@@ -47,6 +51,34 @@ type NotebookStarConnectionResolver interface {
 	PageInfo() *graphqlutil.PageInfo
 }
 
+type NotebookRevisionResolver interface {
+	Version() int32
+	Title() string
+	Blocks() []NotebookBlockResolver
+	Author(ctx context.Context) (*UserResolver, error)
+	CreatedAt() gqlutil.DateTime
+}
+
+type NotebookRevisionConnectionResolver interface {
+	Nodes() []NotebookRevisionResolver
+	TotalCount() int32
+	PageInfo() *graphqlutil.PageInfo
+}
+
+type NotebookRevisionDiffResolver interface {
+	From() NotebookRevisionResolver
+	To() NotebookRevisionResolver
+	Blocks() []NotebookBlockDiffResolver
+}
+
+type NotebookBlockDiffResolver interface {
+	BlockID() string
+	Kind() string
+	From() NotebookBlockResolver
+	To() NotebookBlockResolver
+	Moved() bool
+}
+
 type NotebookResolver interface {
 	ID() graphql.ID
 	Title(ctx context.Context) string
@@ -57,9 +89,12 @@ type NotebookResolver interface {
 	Public(ctx context.Context) bool
 	UpdatedAt(ctx context.Context) gqlutil.DateTime
 	CreatedAt(ctx context.Context) gqlutil.DateTime
+	Version() int32
 	ViewerCanManage(ctx context.Context) (bool, error)
 	ViewerHasStarred(ctx context.Context) (bool, error)
 	Stars(ctx context.Context, args ListNotebookStarsArgs) (NotebookStarConnectionResolver, error)
+	Revisions(ctx context.Context, args ListNotebookRevisionsArgs) (NotebookRevisionConnectionResolver, error)
+	RevisionDiff(ctx context.Context, args NotebookRevisionDiffArgs) (NotebookRevisionDiffResolver, error)
 }
 
 type NotebookBlockResolver interface {
@@ -67,6 +102,9 @@ type NotebookBlockResolver interface {
 	ToQueryBlock() (QueryBlockResolver, bool)
 	ToFileBlock() (FileBlockResolver, bool)
 	ToSymbolBlock() (SymbolBlockResolver, bool)
+	ToComputeBlock() (ComputeBlockResolver, bool)
+	ToInsightBlock() (InsightBlockResolver, bool)
+	ToReferencesBlock() (ReferencesBlockResolver, bool)
 }
 
 type MarkdownBlockResolver interface {
@@ -106,6 +144,45 @@ type SymbolBlockInputResolver interface {
 	SymbolKind() string
 }
 
+type ComputeBlockResolver interface {
+	ID() string
+	ComputeInput() string
+	Snapshot(ctx context.Context) (NotebookBlockSnapshotResolver, error)
+}
+
+type InsightBlockResolver interface {
+	ID() string
+	InsightInput() InsightBlockInputResolver
+	Snapshot(ctx context.Context) (NotebookBlockSnapshotResolver, error)
+}
+
+type InsightBlockInputResolver interface {
+	Query() string
+	PatternType() string
+	Mode() *string
+}
+
+type ReferencesBlockResolver interface {
+	ID() string
+	ReferencesInput() ReferencesBlockInputResolver
+	Snapshot(ctx context.Context) (NotebookBlockSnapshotResolver, error)
+}
+
+type ReferencesBlockInputResolver interface {
+	RepositoryName() string
+	FilePath() string
+	Revision() *string
+	Line() int32
+	Character() int32
+	SymbolName() string
+}
+
+type NotebookBlockSnapshotResolver interface {
+	Output() JSONValue
+	Creator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() gqlutil.DateTime
+}
+
 type FileBlockLineRangeResolver interface {
 	StartLine() int32
 	EndLine() int32
@@ -118,6 +195,10 @@ const (
 	NotebookQueryBlockType    NotebookBlockType = "QUERY"
 	NotebookFileBlockType     NotebookBlockType = "FILE"
 	NotebookSymbolBlockType   NotebookBlockType = "SYMBOL"
+
+	NotebookComputeBlockType    NotebookBlockType = "COMPUTE"
+	NotebookInsightBlockType    NotebookBlockType = "INSIGHT"
+	NotebookReferencesBlockType NotebookBlockType = "REFERENCES"
 )
 
 type CreateNotebookInputArgs struct {
@@ -127,6 +208,7 @@ type CreateNotebookInputArgs struct {
 type UpdateNotebookInputArgs struct {
 	ID       graphql.ID        `json:"id"`
 	Notebook NotebookInputArgs `json:"notebook"`
+	Version  *int32            `json:"version"`
 }
 
 type DeleteNotebookArgs struct {
@@ -141,12 +223,15 @@ type NotebookInputArgs struct {
 }
 
 type CreateNotebookBlockInputArgs struct {
-	ID            string                  `json:"id"`
-	Type          NotebookBlockType       `json:"type"`
-	MarkdownInput *string                 `json:"markdownInput"`
-	QueryInput    *string                 `json:"queryInput"`
-	FileInput     *CreateFileBlockInput   `json:"fileInput"`
-	SymbolInput   *CreateSymbolBlockInput `json:"symbolInput"`
+	ID              string                      `json:"id"`
+	Type            NotebookBlockType           `json:"type"`
+	MarkdownInput   *string                     `json:"markdownInput"`
+	QueryInput      *string                     `json:"queryInput"`
+	FileInput       *CreateFileBlockInput       `json:"fileInput"`
+	SymbolInput     *CreateSymbolBlockInput     `json:"symbolInput"`
+	ComputeInput    *string                     `json:"computeInput"`
+	InsightInput    *CreateInsightBlockInput    `json:"insightInput"`
+	ReferencesInput *CreateReferencesBlockInput `json:"referencesInput"`
 }
 
 type CreateFileBlockInput struct {
@@ -166,6 +251,21 @@ type CreateSymbolBlockInput struct {
 	SymbolKind          string  `json:"symbolKind"`
 }
 
+type CreateInsightBlockInput struct {
+	Query       string  `json:"query"`
+	PatternType string  `json:"patternType"`
+	Mode        *string `json:"mode"`
+}
+
+type CreateReferencesBlockInput struct {
+	RepositoryName string  `json:"repositoryName"`
+	FilePath       string  `json:"filePath"`
+	Revision       *string `json:"revision"`
+	Line           int32   `json:"line"`
+	Character      int32   `json:"character"`
+	SymbolName     string  `json:"symbolName"`
+}
+
 type CreateFileBlockLineRangeInput struct {
 	StartLine int32 `json:"startLine"`
 	EndLine   int32 `json:"endLine"`
@@ -194,3 +294,24 @@ type CreateNotebookStarInputArgs struct {
 type DeleteNotebookStarInputArgs struct {
 	NotebookID graphql.ID
 }
+
+type RunNotebookBlockArgs struct {
+	Notebook graphql.ID
+	BlockID  string
+}
+
+type ListNotebookRevisionsArgs struct {
+	First int32   `json:"first"`
+	After *string `json:"after"`
+}
+
+type NotebookRevisionDiffArgs struct {
+	From int32 `json:"from"`
+	To   int32 `json:"to"`
+}
+
+type RestoreNotebookRevisionArgs struct {
+	Notebook        graphql.ID `json:"notebook"`
+	Version         int32      `json:"version"`
+	ExpectedVersion *int32     `json:"expectedVersion"`
+}
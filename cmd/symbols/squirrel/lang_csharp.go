@@ -0,0 +1,598 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+func (s *SquirrelService) getDefCsharp(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		ident := node.Content(node.Contents)
+
+		parent := node.Parent()
+		if parent != nil && parent.Type() == "member_access_expression" {
+			expression := parent.ChildByFieldName("expression")
+			name := parent.ChildByFieldName("name")
+			if expression != nil && name != nil && nodeId(name) == nodeId(node.Node) {
+				return s.getFieldCsharp(ctx, swapNode(node, expression), ident)
+			}
+		}
+
+		return s.getDefInScopeCsharp(ctx, node, ident)
+
+	case "this_expression":
+		class := enclosingTypeDeclarationCsharp(node.Node)
+		if class == nil {
+			return nil, nil
+		}
+		name := class.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return swapNodePtr(node, name), nil
+
+	case "base_expression":
+		class := enclosingTypeDeclarationCsharp(node.Node)
+		if class == nil {
+			return nil, nil
+		}
+		for _, base := range getBaseTypesCsharp(swapNode(node, class)) {
+			return s.getDefCsharp(ctx, base)
+		}
+		return nil, nil
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInScopeCsharp walks up the tree from the given node looking for a declaration of ident,
+// falling back to types in the enclosing and imported namespaces.
+func (s *SquirrelService) getDefInScopeCsharp(ctx context.Context, node Node, ident string) (ret *Node, err error) {
+	defer s.onCall(node, &Tuple{String(node.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	findIn := func(names []*sitter.Node) *Node {
+		for _, name := range names {
+			if name != nil && name.Content(node.Contents) == ident {
+				return swapNodePtr(node, name)
+			}
+		}
+		return nil
+	}
+
+	cur := node.Node
+	for {
+		prev := cur
+		cur = cur.Parent()
+		if cur == nil {
+			s.breadcrumb(node, "getDefInScopeCsharp: ran out of parents")
+			return nil, nil
+		}
+
+		switch cur.Type() {
+		case "compilation_unit":
+			for _, child := range children(cur) {
+				if found := findIn(typeDeclarationNamesCsharp(child)); found != nil {
+					return found, nil
+				}
+			}
+			return s.getDefInNamespacesCsharp(ctx, node, ident)
+
+		case "declaration_list":
+			// Types declared in a namespace
+			if namespace := cur.Parent(); namespace != nil && namespace.Type() == "namespace_declaration" {
+				for _, child := range children(cur) {
+					if found := findIn(typeDeclarationNamesCsharp(child)); found != nil {
+						return found, nil
+					}
+				}
+			}
+
+		case "block":
+			// Only local variables declared before the reference are in scope, but local functions
+			// can be called from anywhere in the block
+			for _, child := range children(cur) {
+				if child.Type() == "local_function_statement" {
+					if found := findIn([]*sitter.Node{child.ChildByFieldName("name")}); found != nil {
+						return found, nil
+					}
+				}
+			}
+			for child := prev.PrevNamedSibling(); child != nil; child = child.PrevNamedSibling() {
+				if child.Type() != "local_declaration_statement" {
+					continue
+				}
+				for _, decl := range children(child) {
+					if found := findIn(variableNamesCsharp(decl)); found != nil {
+						return found, nil
+					}
+				}
+			}
+
+		case "method_declaration", "constructor_declaration", "local_function_statement", "lambda_expression":
+			for _, child := range children(cur) {
+				switch child.Type() {
+				case "parameter_list":
+					if found := findIn(parameterNamesCsharp(child)); found != nil {
+						return found, nil
+					}
+				case "identifier":
+					// x => ...
+					if cur.Type() == "lambda_expression" && nodeId(child) != nodeId(cur.ChildByFieldName("body")) {
+						if found := findIn([]*sitter.Node{child}); found != nil {
+							return found, nil
+						}
+					}
+				case "type_parameter_list":
+					if found := findIn(typeParameterNamesCsharp(child)); found != nil {
+						return found, nil
+					}
+				}
+			}
+
+		case "for_statement", "using_statement", "fixed_statement":
+			for _, child := range children(cur) {
+				if found := findIn(variableNamesCsharp(child)); found != nil {
+					return found, nil
+				}
+			}
+
+		case "for_each_statement":
+			if found := findIn([]*sitter.Node{cur.ChildByFieldName("left")}); found != nil {
+				return found, nil
+			}
+
+		case "catch_clause":
+			for _, child := range children(cur) {
+				if child.Type() == "catch_declaration" {
+					if found := findIn([]*sitter.Node{child.ChildByFieldName("name")}); found != nil {
+						return found, nil
+					}
+				}
+			}
+
+		case "class_declaration", "struct_declaration", "interface_declaration", "record_declaration", "enum_declaration":
+			if found := findIn([]*sitter.Node{cur.ChildByFieldName("name")}); found != nil {
+				return found, nil
+			}
+			for _, child := range children(cur) {
+				if child.Type() == "type_parameter_list" {
+					if found := findIn(typeParameterNamesCsharp(child)); found != nil {
+						return found, nil
+					}
+				}
+			}
+			// Members are only in scope within the body (and not e.g. in the base list)
+			body := cur.ChildByFieldName("body")
+			if body == nil || nodeId(body) != nodeId(prev) {
+				continue
+			}
+			found, err := s.lookupFieldCsharp(ctx, ClassTypeCsharp{def: swapNode(node, cur)}, ident)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+	}
+}
+
+// getDefInNamespacesCsharp searches the repository for a type named ident declared in the
+// namespace enclosing the given node, in one of its parent namespaces or in a namespace imported
+// by a using directive.
+func (s *SquirrelService) getDefInNamespacesCsharp(ctx context.Context, node Node, ident string) (ret *Node, err error) {
+	defer s.onCall(node, &Tuple{String(node.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	namespaces := map[string]struct{}{"": {}}
+	if namespace := enclosingNamespaceCsharp(node); namespace != "" {
+		components := strings.Split(namespace, ".")
+		for i := range components {
+			namespaces[strings.Join(components[:i+1], ".")] = struct{}{}
+		}
+	}
+
+	for _, directive := range children(getRoot(node.Node)) {
+		if directive.Type() != "using_directive" {
+			continue
+		}
+		var alias, name *sitter.Node
+		for _, child := range children(directive) {
+			switch child.Type() {
+			case "name_equals":
+				alias = child.NamedChild(0)
+			case "identifier", "qualified_name":
+				name = child
+			}
+		}
+		if name == nil {
+			continue
+		}
+		if alias == nil {
+			namespaces[name.Content(node.Contents)] = struct{}{}
+			continue
+		}
+		if alias.Content(node.Contents) != ident || name.Type() != "qualified_name" {
+			continue
+		}
+
+		// using Alias = Namespace.Type;
+		aliased := name.NamedChild(int(name.NamedChildCount()) - 1)
+		qualifier := name.NamedChild(0)
+		if aliased == nil || qualifier == nil {
+			return nil, nil
+		}
+		return s.findTypeInNamespacesCsharp(ctx, node, aliased.Content(node.Contents), map[string]struct{}{
+			qualifier.Content(node.Contents): {},
+		})
+	}
+
+	return s.findTypeInNamespacesCsharp(ctx, node, ident, namespaces)
+}
+
+func (s *SquirrelService) findTypeInNamespacesCsharp(ctx context.Context, node Node, name string, namespaces map[string]struct{}) (*Node, error) {
+	return s.symbolSearchFirstMatching(
+		ctx,
+		node.RepoCommitPath.Repo,
+		node.RepoCommitPath.Commit,
+		[]string{`\.(cs|csx)$`},
+		name,
+		maxSymbolSearchCandidates,
+		func(found Node) bool {
+			parent := found.Parent()
+			if parent == nil {
+				return false
+			}
+			if declName := parent.ChildByFieldName("name"); declName == nil || nodeId(declName) != nodeId(found.Node) {
+				return false
+			}
+			switch parent.Type() {
+			case "class_declaration", "struct_declaration", "interface_declaration", "record_declaration",
+				"enum_declaration", "delegate_declaration":
+			default:
+				return false
+			}
+			_, ok := namespaces[enclosingNamespaceCsharp(found)]
+			return ok
+		},
+	)
+}
+
+func (s *SquirrelService) getFieldCsharp(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer s.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	ty, err := s.getTypeDefCsharp(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return s.lookupFieldCsharp(ctx, ty, field)
+}
+
+func (s *SquirrelService) lookupFieldCsharp(ctx context.Context, ty TypeCsharp, field string) (ret *Node, err error) {
+	defer s.onCall(ty.node(), &Tuple{String(ty.variant()), String(field)}, lazyNodeStringer(&ret))()
+
+	switch ty2 := ty.(type) {
+	case ClassTypeCsharp:
+		body := ty2.def.ChildByFieldName("body")
+		if body == nil {
+			return nil, nil
+		}
+		for _, member := range children(body) {
+			switch member.Type() {
+			case "field_declaration", "event_field_declaration":
+				for _, decl := range children(member) {
+					for _, name := range variableNamesCsharp(decl) {
+						if name.Content(ty2.def.Contents) == field {
+							return swapNodePtr(ty2.def, name), nil
+						}
+					}
+				}
+			default:
+				// Methods, properties, events, nested types and enum members
+				name := member.ChildByFieldName("name")
+				if name != nil && name.Content(ty2.def.Contents) == field {
+					return swapNodePtr(ty2.def, name), nil
+				}
+			}
+		}
+		for _, base := range getBaseTypesCsharp(ty2.def) {
+			found, err := s.getFieldCsharp(ctx, base, field)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+		return nil, nil
+
+	case FnTypeCsharp:
+		s.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldCsharp: unexpected object type %s", ty.variant()))
+		return nil, nil
+
+	default:
+		s.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldCsharp: unrecognized type variant %q", ty.variant()))
+		return nil, nil
+	}
+}
+
+func (s *SquirrelService) getTypeDefCsharp(ctx context.Context, node Node) (ret TypeCsharp, err error) {
+	defer s.onCall(node, String(node.Type()), lazyTypeCsharpStringer(&ret))()
+
+	onIdent := func() (TypeCsharp, error) {
+		found, err := s.getDefCsharp(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		if isRecursiveDefinitionCsharp(node, *found) {
+			return nil, nil
+		}
+		return s.defToTypeCsharp(ctx, *found)
+	}
+
+	switch node.Type() {
+	case "identifier", "this_expression", "base_expression":
+		return onIdent()
+	case "generic_name", "nullable_type", "parenthesized_expression":
+		if node.NamedChildCount() == 0 {
+			return nil, nil
+		}
+		return s.getTypeDefCsharp(ctx, swapNode(node, node.NamedChild(0)))
+	case "qualified_name":
+		if node.NamedChildCount() == 0 {
+			return nil, nil
+		}
+		return s.getTypeDefCsharp(ctx, swapNode(node, node.NamedChild(int(node.NamedChildCount())-1)))
+	case "member_access_expression":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return s.getTypeDefCsharp(ctx, swapNode(node, name))
+	case "object_creation_expression", "cast_expression":
+		ty := node.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return s.getTypeDefCsharp(ctx, swapNode(node, ty))
+	case "invocation_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		ty, err := s.getTypeDefCsharp(ctx, swapNode(node, fn))
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			return nil, nil
+		}
+		switch ty2 := ty.(type) {
+		case FnTypeCsharp:
+			return ty2.ret, nil
+		default:
+			s.breadcrumb(ty.node(), fmt.Sprintf("getTypeDefCsharp: expected method, got %q", ty.variant()))
+			return nil, nil
+		}
+	default:
+		s.breadcrumb(node, fmt.Sprintf("getTypeDefCsharp: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+func (s *SquirrelService) defToTypeCsharp(ctx context.Context, def Node) (TypeCsharp, error) {
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	typeOf := func(decl *sitter.Node) (TypeCsharp, error) {
+		ty := decl.ChildByFieldName("type")
+		if ty == nil {
+			s.breadcrumb(swapNode(def, decl), "defToTypeCsharp: could not find type")
+			return nil, nil
+		}
+		return s.getTypeDefCsharp(ctx, swapNode(def, ty))
+	}
+
+	switch parent.Type() {
+	case "class_declaration", "struct_declaration", "interface_declaration", "record_declaration", "enum_declaration":
+		return (TypeCsharp)(ClassTypeCsharp{def: swapNode(def, parent)}), nil
+	case "method_declaration", "local_function_statement":
+		retTyNode := parent.ChildByFieldName("type")
+		if retTyNode == nil {
+			return (TypeCsharp)(FnTypeCsharp{ret: nil, noad: swapNode(def, parent)}), nil
+		}
+		retTy, err := s.getTypeDefCsharp(ctx, swapNode(def, retTyNode))
+		if err != nil {
+			return nil, err
+		}
+		return (TypeCsharp)(FnTypeCsharp{ret: retTy, noad: swapNode(def, parent)}), nil
+	case "property_declaration", "parameter", "catch_declaration":
+		return typeOf(parent)
+	case "for_each_statement":
+		if ty := parent.ChildByFieldName("type"); ty != nil && ty.Type() == "implicit_type" {
+			return nil, nil
+		}
+		return typeOf(parent)
+	case "variable_declarator":
+		decl := parent.Parent()
+		if decl == nil || decl.Type() != "variable_declaration" {
+			return nil, nil
+		}
+		if ty := decl.ChildByFieldName("type"); ty != nil && ty.Type() == "implicit_type" {
+			// var x = ...
+			for _, child := range children(parent) {
+				if child.Type() == "equals_value_clause" && child.NamedChildCount() > 0 {
+					return s.getTypeDefCsharp(ctx, swapNode(def, child.NamedChild(0)))
+				}
+			}
+			return nil, nil
+		}
+		return typeOf(decl)
+	default:
+		s.breadcrumb(swapNode(def, parent), fmt.Sprintf("unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+// typeDeclarationNamesCsharp returns the name of the given type declaration, if it is one.
+func typeDeclarationNamesCsharp(node *sitter.Node) []*sitter.Node {
+	switch node.Type() {
+	case "class_declaration", "struct_declaration", "interface_declaration", "record_declaration",
+		"enum_declaration", "delegate_declaration":
+		if name := node.ChildByFieldName("name"); name != nil {
+			return []*sitter.Node{name}
+		}
+	}
+	return nil
+}
+
+// variableNamesCsharp returns the names declared by a variable_declaration.
+func variableNamesCsharp(decl *sitter.Node) []*sitter.Node {
+	names := []*sitter.Node{}
+	if decl == nil || decl.Type() != "variable_declaration" {
+		return names
+	}
+	for _, declarator := range children(decl) {
+		if declarator.Type() != "variable_declarator" || declarator.NamedChildCount() == 0 {
+			continue
+		}
+		if name := declarator.NamedChild(0); name.Type() == "identifier" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func parameterNamesCsharp(list *sitter.Node) []*sitter.Node {
+	names := []*sitter.Node{}
+	for _, param := range children(list) {
+		if name := param.ChildByFieldName("name"); name != nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func typeParameterNamesCsharp(list *sitter.Node) []*sitter.Node {
+	names := []*sitter.Node{}
+	for _, param := range children(list) {
+		for _, child := range children(param) {
+			if child.Type() == "identifier" {
+				names = append(names, child)
+			}
+		}
+	}
+	return names
+}
+
+// getBaseTypesCsharp returns the base class and interfaces of a type declaration.
+func getBaseTypesCsharp(def Node) []Node {
+	bases := []Node{}
+	for _, child := range children(def.Node) {
+		if child.Type() != "base_list" {
+			continue
+		}
+		for _, base := range children(child) {
+			bases = append(bases, swapNode(def, base))
+		}
+	}
+	return bases
+}
+
+func enclosingTypeDeclarationCsharp(node *sitter.Node) *sitter.Node {
+	for cur := node; cur != nil; cur = cur.Parent() {
+		switch cur.Type() {
+		case "class_declaration", "struct_declaration", "interface_declaration", "record_declaration":
+			return cur
+		}
+	}
+	return nil
+}
+
+// enclosingNamespaceCsharp returns the fully qualified name of the namespace enclosing the given
+// node, or the empty string for the global namespace.
+func enclosingNamespaceCsharp(node Node) string {
+	components := []string{}
+	for cur := node.Parent(); cur != nil; cur = cur.Parent() {
+		if cur.Type() != "namespace_declaration" {
+			continue
+		}
+		if name := cur.ChildByFieldName("name"); name != nil {
+			components = append([]string{name.Content(node.Contents)}, components...)
+		}
+	}
+	return strings.Join(components, ".")
+}
+
+// isRecursiveDefinitionCsharp detects cases like `var x = x.Foo` that would cause infinite recursion
+// when attempting to determine the type of `x`.
+func isRecursiveDefinitionCsharp(node Node, def Node) bool {
+	if node.RepoCommitPath != def.RepoCommitPath {
+		return false
+	}
+	declarator := def.Parent()
+	if declarator == nil || declarator.Type() != "variable_declarator" {
+		return false
+	}
+	for cur := node.Parent(); cur != nil; cur = cur.Parent() {
+		if nodeId(cur) == nodeId(declarator) {
+			return true
+		}
+	}
+	return false
+}
+
+type TypeCsharp interface {
+	variant() string
+	node() Node
+}
+
+type FnTypeCsharp struct {
+	ret  TypeCsharp
+	noad Node
+}
+
+func (t FnTypeCsharp) variant() string {
+	return "fn"
+}
+
+func (t FnTypeCsharp) node() Node {
+	return t.noad
+}
+
+type ClassTypeCsharp struct {
+	def Node
+}
+
+func (t ClassTypeCsharp) variant() string {
+	return "class"
+}
+
+func (t ClassTypeCsharp) node() Node {
+	return t.def
+}
+
+func lazyTypeCsharpStringer(ty *TypeCsharp) func() fmt.Stringer {
+	return func() fmt.Stringer {
+		if ty != nil && *ty != nil {
+			return String((*ty).variant())
+		} else {
+			return String("<nil>")
+		}
+	}
+}
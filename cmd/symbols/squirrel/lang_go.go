@@ -0,0 +1,813 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/regexp"
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (s *SquirrelService) getDefGo(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	ident := node.Content(node.Contents)
+	parent := node.Parent()
+
+	switch node.Type() {
+	case "identifier":
+		return s.getDefInScopeGo(ctx, node, ident)
+
+	case "package_identifier":
+		if parent != nil && parent.Type() == "package_clause" {
+			return nil, nil
+		}
+		return s.getDefInScopeGo(ctx, node, ident)
+
+	case "type_identifier":
+		if parent != nil && parent.Type() == "qualified_type" {
+			pkg := parent.ChildByFieldName("package")
+			if pkg == nil {
+				return nil, nil
+			}
+			return s.getFieldGo(ctx, swapNode(node, pkg), ident)
+		}
+		return s.getDefInScopeGo(ctx, node, ident)
+
+	case "field_identifier":
+		if parent == nil {
+			return nil, nil
+		}
+		switch parent.Type() {
+		case "selector_expression":
+			operand := parent.ChildByFieldName("operand")
+			if operand == nil {
+				return nil, nil
+			}
+			return s.getFieldGo(ctx, swapNode(node, operand), ident)
+
+		case "keyed_element":
+			// T{Field: ...}
+			literalValue := parent.Parent()
+			if literalValue == nil || literalValue.Type() != "literal_value" {
+				return nil, nil
+			}
+			compositeLiteral := literalValue.Parent()
+			if compositeLiteral == nil || compositeLiteral.Type() != "composite_literal" {
+				return nil, nil
+			}
+			ty := compositeLiteral.ChildByFieldName("type")
+			if ty == nil {
+				return nil, nil
+			}
+			return s.getFieldGo(ctx, swapNode(node, ty), ident)
+
+		case "method_declaration", "method_spec", "field_declaration":
+			return swapNodePtr(node, node.Node), nil
+
+		default:
+			return nil, nil
+		}
+
+	case "interpreted_string_literal", "raw_string_literal":
+		if parent == nil || parent.Type() != "import_spec" {
+			return nil, nil
+		}
+		return s.resolveImportGo(ctx, node, getImportPathGo(swapNode(node, node.Node))), nil
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInScopeGo walks up the tree from the given node looking for a declaration of ident that
+// is visible from the node, falling back to package-level declarations and imports.
+func (s *SquirrelService) getDefInScopeGo(ctx context.Context, node Node, ident string) (ret *Node, err error) {
+	defer s.onCall(node, &Tuple{String(node.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	findIn := func(names []*sitter.Node) *Node {
+		for _, name := range names {
+			if name.Content(node.Contents) == ident {
+				return swapNodePtr(node, name)
+			}
+		}
+		return nil
+	}
+
+	cur := node.Node
+	for {
+		prev := cur
+		cur = cur.Parent()
+		if cur == nil {
+			s.breadcrumb(node, "getDefInScopeGo: ran out of parents")
+			return nil, nil
+		}
+
+		switch cur.Type() {
+		case "source_file":
+			return s.getDefInPackageGo(ctx, swapNode(node, cur), ident)
+
+		case "block":
+			// Only declarations that come before the reference are in scope
+			for child := prev.PrevNamedSibling(); child != nil; child = child.PrevNamedSibling() {
+				if found := findIn(declaredNamesGo(child)); found != nil {
+					return found, nil
+				}
+			}
+
+		case "function_declaration", "method_declaration", "func_literal":
+			for _, field := range []string{"receiver", "parameters", "result"} {
+				if found := findIn(parameterNamesGo(cur.ChildByFieldName(field))); found != nil {
+					return found, nil
+				}
+			}
+
+		case "for_statement":
+			for _, child := range children(cur) {
+				switch child.Type() {
+				case "for_clause":
+					if found := findIn(declaredNamesGo(child.ChildByFieldName("initializer"))); found != nil {
+						return found, nil
+					}
+				case "range_clause":
+					if found := findIn(expressionListIdentsGo(child.ChildByFieldName("left"))); found != nil {
+						return found, nil
+					}
+				}
+			}
+
+		case "if_statement", "expression_switch_statement":
+			if found := findIn(declaredNamesGo(cur.ChildByFieldName("initializer"))); found != nil {
+				return found, nil
+			}
+
+		case "type_switch_statement":
+			if found := findIn(expressionListIdentsGo(cur.ChildByFieldName("alias"))); found != nil {
+				return found, nil
+			}
+			if found := findIn(declaredNamesGo(cur.ChildByFieldName("initializer"))); found != nil {
+				return found, nil
+			}
+
+		case "communication_case":
+			communication := cur.ChildByFieldName("communication")
+			if communication != nil && communication.Type() == "receive_statement" {
+				if found := findIn(expressionListIdentsGo(communication.ChildByFieldName("left"))); found != nil {
+					return found, nil
+				}
+			}
+		}
+	}
+}
+
+// getDefInPackageGo looks for a package-level declaration of ident in the given file, then in the
+// file's imports, and finally in the other files of the same package.
+func (s *SquirrelService) getDefInPackageGo(ctx context.Context, file Node, ident string) (ret *Node, err error) {
+	defer s.onCall(file, &Tuple{String(file.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	// Check declarations in the current file
+	for _, child := range children(file.Node) {
+		for _, name := range declaredNamesGo(child) {
+			if name.Content(file.Contents) == ident {
+				return swapNodePtr(file, name), nil
+			}
+		}
+	}
+
+	// Check imports
+	for _, spec := range importSpecsGo(file) {
+		if spec.name != ident {
+			continue
+		}
+		// Imports of packages outside of this repository are not followed
+		return s.resolveImportGo(ctx, file, spec.path), nil
+	}
+
+	// Search the rest of the package
+	return s.symbolSearchFirstMatching(
+		ctx,
+		file.RepoCommitPath.Repo,
+		file.RepoCommitPath.Commit,
+		[]string{dirIncludePattern(filepath.Dir(file.RepoCommitPath.Path), "go")},
+		ident,
+		maxSymbolSearchCandidates,
+		isPackageLevelDefGo,
+	)
+}
+
+func (s *SquirrelService) getFieldGo(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer s.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	ty, err := s.getTypeDefGo(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return s.lookupFieldGo(ctx, ty, field)
+}
+
+func (s *SquirrelService) lookupFieldGo(ctx context.Context, ty TypeGo, field string) (ret *Node, err error) {
+	defer s.onCall(ty.node(), &Tuple{String(ty.variant()), String(field)}, lazyNodeStringer(&ret))()
+
+	switch ty2 := ty.(type) {
+	case PkgTypeGo:
+		return s.symbolSearchFirstMatching(
+			ctx,
+			ty2.dir.Repo,
+			ty2.dir.Commit,
+			[]string{dirIncludePattern(ty2.dir.Path, "go")},
+			field,
+			maxSymbolSearchCandidates,
+			isPackageLevelDefGo,
+		)
+
+	case NamedTypeGo:
+		name := ty2.def.ChildByFieldName("name")
+		underlying := ty2.def.ChildByFieldName("type")
+		if name == nil || underlying == nil {
+			return nil, nil
+		}
+
+		// Check fields and interface methods
+		embedded := []Node{}
+		switch underlying.Type() {
+		case "struct_type":
+			for _, list := range children(underlying) {
+				for _, decl := range children(list) {
+					if decl.Type() != "field_declaration" {
+						continue
+					}
+					names := 0
+					for _, child := range children(decl) {
+						if child.Type() != "field_identifier" {
+							continue
+						}
+						names++
+						if child.Content(ty2.def.Contents) == field {
+							return swapNodePtr(ty2.def, child), nil
+						}
+					}
+					if names == 0 {
+						embeddedType := decl.ChildByFieldName("type")
+						if embeddedType == nil {
+							continue
+						}
+						embeddedName := embeddedTypeNameGo(embeddedType)
+						if embeddedName != nil && embeddedName.Content(ty2.def.Contents) == field {
+							return swapNodePtr(ty2.def, embeddedName), nil
+						}
+						embedded = append(embedded, swapNode(ty2.def, embeddedType))
+					}
+				}
+			}
+
+		case "interface_type":
+			for _, list := range children(underlying) {
+				for _, spec := range children(list) {
+					switch spec.Type() {
+					case "method_spec":
+						specName := spec.ChildByFieldName("name")
+						if specName != nil && specName.Content(ty2.def.Contents) == field {
+							return swapNodePtr(ty2.def, specName), nil
+						}
+					case "type_identifier", "qualified_type":
+						embedded = append(embedded, swapNode(ty2.def, spec))
+					}
+				}
+			}
+
+		case "type_identifier", "qualified_type", "pointer_type":
+			// type T U has the fields (but not the methods) of U
+			embedded = append(embedded, swapNode(ty2.def, underlying))
+		}
+
+		// Check methods
+		found, err := s.findMethodGo(ctx, ty2.def, name.Content(ty2.def.Contents), field)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+
+		// Check promoted fields and methods of embedded types
+		for _, embeddedType := range embedded {
+			found, err := s.getFieldGo(ctx, embeddedType, field)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+		return nil, nil
+
+	case FnTypeGo:
+		s.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldGo: unexpected object type %s", ty.variant()))
+		return nil, nil
+
+	default:
+		s.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldGo: unrecognized type variant %q", ty.variant()))
+		return nil, nil
+	}
+}
+
+// findMethodGo finds the method with the given name whose receiver is the type declared by the
+// given type_spec, first in the file containing the type and then in the rest of its package.
+func (s *SquirrelService) findMethodGo(ctx context.Context, typeSpec Node, typeName string, method string) (ret *Node, err error) {
+	defer s.onCall(typeSpec, &Tuple{String(typeName), String(method)}, lazyNodeStringer(&ret))()
+
+	isMethod := func(name Node) bool {
+		decl := name.Parent()
+		if decl == nil || decl.Type() != "method_declaration" {
+			return false
+		}
+		declName := decl.ChildByFieldName("name")
+		if declName == nil || nodeId(declName) != nodeId(name.Node) {
+			return false
+		}
+		return receiverTypeNameGo(swapNode(name, decl)) == typeName
+	}
+
+	for _, child := range children(getRoot(typeSpec.Node)) {
+		if child.Type() != "method_declaration" {
+			continue
+		}
+		name := child.ChildByFieldName("name")
+		if name == nil || name.Content(typeSpec.Contents) != method {
+			continue
+		}
+		if isMethod(swapNode(typeSpec, name)) {
+			return swapNodePtr(typeSpec, name), nil
+		}
+	}
+
+	return s.symbolSearchFirstMatching(
+		ctx,
+		typeSpec.RepoCommitPath.Repo,
+		typeSpec.RepoCommitPath.Commit,
+		[]string{dirIncludePattern(filepath.Dir(typeSpec.RepoCommitPath.Path), "go")},
+		method,
+		maxSymbolSearchCandidates,
+		isMethod,
+	)
+}
+
+func (s *SquirrelService) getTypeDefGo(ctx context.Context, node Node) (ret TypeGo, err error) {
+	defer s.onCall(node, String(node.Type()), lazyTypeGoStringer(&ret))()
+
+	onIdent := func() (TypeGo, error) {
+		found, err := s.getDefGo(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		if found.Node == nil {
+			// Imported packages resolve to directories
+			return PkgTypeGo{dir: found.RepoCommitPath, noad: node}, nil
+		}
+		if isRecursiveDefinitionGo(node, *found) {
+			return nil, nil
+		}
+		return s.defToTypeGo(ctx, *found)
+	}
+
+	firstNamedChild := func() (TypeGo, error) {
+		if node.NamedChildCount() == 0 {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(node, node.NamedChild(0)))
+	}
+
+	switch node.Type() {
+	case "identifier", "type_identifier", "package_identifier", "field_identifier":
+		return onIdent()
+	case "pointer_type", "parenthesized_expression", "parenthesized_type":
+		return firstNamedChild()
+	case "unary_expression":
+		operand := node.ChildByFieldName("operand")
+		if operand == nil {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(node, operand))
+	case "qualified_type":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(node, name))
+	case "selector_expression":
+		field := node.ChildByFieldName("field")
+		if field == nil {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(node, field))
+	case "composite_literal", "type_assertion_expression":
+		ty := node.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(node, ty))
+	case "call_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		ty, err := s.getTypeDefGo(ctx, swapNode(node, fn))
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			return nil, nil
+		}
+		switch ty2 := ty.(type) {
+		case FnTypeGo:
+			return ty2.ret, nil
+		case NamedTypeGo:
+			// Conversion, e.g. T(x)
+			return ty2, nil
+		default:
+			s.breadcrumb(ty.node(), fmt.Sprintf("getTypeDefGo: expected function, got %q", ty.variant()))
+			return nil, nil
+		}
+	default:
+		s.breadcrumb(node, fmt.Sprintf("getTypeDefGo: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+func (s *SquirrelService) defToTypeGo(ctx context.Context, def Node) (TypeGo, error) {
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "type_spec":
+		return (TypeGo)(NamedTypeGo{def: swapNode(def, parent)}), nil
+	case "function_declaration", "method_declaration", "method_spec":
+		result := parent.ChildByFieldName("result")
+		if result == nil {
+			return (TypeGo)(FnTypeGo{ret: nil, noad: swapNode(def, parent)}), nil
+		}
+		if result.Type() == "parameter_list" {
+			// The first of multiple return values, e.g. (T, error)
+			result = nil
+			for _, param := range children(parent.ChildByFieldName("result")) {
+				result = param.ChildByFieldName("type")
+				break
+			}
+			if result == nil {
+				return (TypeGo)(FnTypeGo{ret: nil, noad: swapNode(def, parent)}), nil
+			}
+		}
+		retTy, err := s.getTypeDefGo(ctx, swapNode(def, result))
+		if err != nil {
+			return nil, err
+		}
+		return (TypeGo)(FnTypeGo{ret: retTy, noad: swapNode(def, parent)}), nil
+	case "parameter_declaration", "field_declaration":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			s.breadcrumb(swapNode(def, parent), "defToTypeGo: could not find type")
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(def, ty))
+	case "var_spec":
+		if ty := parent.ChildByFieldName("type"); ty != nil {
+			return s.getTypeDefGo(ctx, swapNode(def, ty))
+		}
+		names := []*sitter.Node{}
+		for _, child := range children(parent) {
+			if child.Type() == "identifier" {
+				names = append(names, child)
+			}
+		}
+		value := correspondingValueGo(def.Node, names, parent.ChildByFieldName("value"))
+		if value == nil {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(def, value))
+	case "expression_list":
+		decl := parent.Parent()
+		if decl == nil || decl.Type() != "short_var_declaration" {
+			return nil, nil
+		}
+		value := correspondingValueGo(def.Node, children(parent), decl.ChildByFieldName("right"))
+		if value == nil {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(def, value))
+	default:
+		s.breadcrumb(swapNode(def, parent), fmt.Sprintf("unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+// resolveImportGo returns the directory of the imported package if it belongs to a module in the
+// same repository as the importing file, otherwise nil.
+func (s *SquirrelService) resolveImportGo(ctx context.Context, from Node, importPath string) *Node {
+	for dir := filepath.Dir(from.RepoCommitPath.Path); ; dir = filepath.Dir(dir) {
+		contents, err := s.readFile(ctx, types.RepoCommitPath{
+			Repo:   from.RepoCommitPath.Repo,
+			Commit: from.RepoCommitPath.Commit,
+			Path:   filepath.Join(dir, "go.mod"),
+		})
+		if err == nil {
+			match := goModModuleRegex.FindSubmatch(contents)
+			if match == nil {
+				return nil
+			}
+			module := string(match[1])
+			if importPath != module && !strings.HasPrefix(importPath, module+"/") {
+				return nil
+			}
+			return &Node{
+				RepoCommitPath: types.RepoCommitPath{
+					Repo:   from.RepoCommitPath.Repo,
+					Commit: from.RepoCommitPath.Commit,
+					Path:   filepath.Join(dir, strings.TrimPrefix(importPath, module)),
+				},
+				Node:     nil,
+				Contents: from.Contents,
+				LangSpec: from.LangSpec,
+			}
+		}
+		if dir == "." || dir == "/" {
+			return nil
+		}
+	}
+}
+
+var goModModuleRegex = regexp.MustCompile(`(?m)^module\s+"?([^"\s]+)"?`)
+
+var goMajorVersionRegex = regexp.MustCompile(`^v[0-9]+$`)
+
+type importSpecGo struct {
+	name string
+	path string
+}
+
+// importSpecsGo returns the imports of the given file along with the names they are bound to.
+// Dot and blank imports are skipped.
+func importSpecsGo(file Node) []importSpecGo {
+	specs := []importSpecGo{}
+	captures, _ := allCaptures(`(import_spec) @spec`, file)
+	for _, capture := range captures {
+		pathNode := capture.ChildByFieldName("path")
+		if pathNode == nil {
+			continue
+		}
+		path := getImportPathGo(swapNode(file, pathNode))
+
+		var name string
+		if nameNode := capture.ChildByFieldName("name"); nameNode != nil {
+			if nameNode.Type() != "package_identifier" {
+				continue
+			}
+			name = nameNode.Content(file.Contents)
+		} else {
+			// Assume the package name matches the last path component, ignoring major version
+			// suffixes and the common go- prefix
+			components := strings.Split(path, "/")
+			name = components[len(components)-1]
+			if len(components) > 1 && goMajorVersionRegex.MatchString(name) {
+				name = components[len(components)-2]
+			}
+			name = strings.TrimPrefix(name, "go-")
+		}
+
+		specs = append(specs, importSpecGo{name: name, path: path})
+	}
+	return specs
+}
+
+func getImportPathGo(path Node) string {
+	content := path.Content(path.Contents)
+	if unquoted, err := strconv.Unquote(content); err == nil {
+		return unquoted
+	}
+	return strings.Trim(content, "\"`")
+}
+
+// declaredNamesGo returns the names declared by the given statement or top-level declaration.
+func declaredNamesGo(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	names := []*sitter.Node{}
+	switch node.Type() {
+	case "var_declaration", "const_declaration":
+		for _, spec := range children(node) {
+			for _, child := range children(spec) {
+				if child.Type() == "identifier" {
+					names = append(names, child)
+				}
+			}
+		}
+	case "type_declaration":
+		for _, spec := range children(node) {
+			if name := spec.ChildByFieldName("name"); name != nil {
+				names = append(names, name)
+			}
+		}
+	case "short_var_declaration":
+		names = append(names, expressionListIdentsGo(node.ChildByFieldName("left"))...)
+	case "function_declaration":
+		if name := node.ChildByFieldName("name"); name != nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// parameterNamesGo returns the names of the parameters in the given parameter_list.
+func parameterNamesGo(list *sitter.Node) []*sitter.Node {
+	names := []*sitter.Node{}
+	if list == nil || list.Type() != "parameter_list" {
+		return names
+	}
+	for _, param := range children(list) {
+		for _, child := range children(param) {
+			if child.Type() == "identifier" {
+				names = append(names, child)
+			}
+		}
+	}
+	return names
+}
+
+func expressionListIdentsGo(list *sitter.Node) []*sitter.Node {
+	idents := []*sitter.Node{}
+	for _, child := range children(list) {
+		if child.Type() == "identifier" {
+			idents = append(idents, child)
+		}
+	}
+	return idents
+}
+
+// correspondingValueGo returns the value assigned to name in a declaration like `a, b := x, y`.
+// When a single value is assigned to multiple names (e.g. `v, err := f()`), the value is returned
+// for the first name only.
+func correspondingValueGo(name *sitter.Node, names []*sitter.Node, values *sitter.Node) *sitter.Node {
+	if values == nil {
+		return nil
+	}
+	for i, candidate := range names {
+		if nodeId(candidate) != nodeId(name) {
+			continue
+		}
+		if int(values.NamedChildCount()) == len(names) {
+			return values.NamedChild(i)
+		}
+		if i == 0 && values.NamedChildCount() == 1 {
+			return values.NamedChild(0)
+		}
+		return nil
+	}
+	return nil
+}
+
+// receiverTypeNameGo returns the name of the receiver type of the given method_declaration.
+func receiverTypeNameGo(method Node) string {
+	receiver := method.ChildByFieldName("receiver")
+	if receiver == nil || receiver.NamedChildCount() == 0 {
+		return ""
+	}
+	ty := receiver.NamedChild(0).ChildByFieldName("type")
+	if ty == nil {
+		return ""
+	}
+	name := embeddedTypeNameGo(ty)
+	if name == nil {
+		return ""
+	}
+	return name.Content(method.Contents)
+}
+
+// embeddedTypeNameGo returns the type_identifier that names the given (possibly pointer or
+// qualified) type.
+func embeddedTypeNameGo(ty *sitter.Node) *sitter.Node {
+	switch ty.Type() {
+	case "type_identifier":
+		return ty
+	case "pointer_type":
+		if ty.NamedChildCount() == 0 {
+			return nil
+		}
+		return embeddedTypeNameGo(ty.NamedChild(0))
+	case "qualified_type":
+		return ty.ChildByFieldName("name")
+	default:
+		return nil
+	}
+}
+
+// isPackageLevelDefGo returns true if the given node is the name of a package-level declaration
+// (as opposed to e.g. a method, a struct field or a local variable).
+func isPackageLevelDefGo(name Node) bool {
+	parent := name.Parent()
+	if parent == nil {
+		return false
+	}
+	switch parent.Type() {
+	case "function_declaration", "type_spec", "var_spec", "const_spec":
+	default:
+		return false
+	}
+	for cur := parent.Parent(); cur != nil; cur = cur.Parent() {
+		switch cur.Type() {
+		case "source_file":
+			return true
+		case "block":
+			return false
+		}
+	}
+	return false
+}
+
+// isRecursiveDefinitionGo detects cases like `var x = x.foo` that would cause infinite recursion
+// when attempting to determine the type of `x`.
+func isRecursiveDefinitionGo(node Node, def Node) bool {
+	if node.RepoCommitPath != def.RepoCommitPath {
+		return false
+	}
+	decl := def.Parent()
+	if decl != nil && decl.Type() == "expression_list" {
+		decl = decl.Parent()
+	}
+	if decl == nil || (decl.Type() != "var_spec" && decl.Type() != "short_var_declaration") {
+		return false
+	}
+	for cur := node.Parent(); cur != nil; cur = cur.Parent() {
+		if nodeId(cur) == nodeId(decl) {
+			return true
+		}
+	}
+	return false
+}
+
+type TypeGo interface {
+	variant() string
+	node() Node
+}
+
+type FnTypeGo struct {
+	ret  TypeGo
+	noad Node
+}
+
+func (t FnTypeGo) variant() string {
+	return "fn"
+}
+
+func (t FnTypeGo) node() Node {
+	return t.noad
+}
+
+type NamedTypeGo struct {
+	def Node
+}
+
+func (t NamedTypeGo) variant() string {
+	return "named"
+}
+
+func (t NamedTypeGo) node() Node {
+	return t.def
+}
+
+type PkgTypeGo struct {
+	dir  types.RepoCommitPath
+	noad Node
+}
+
+func (t PkgTypeGo) variant() string {
+	return "pkg"
+}
+
+func (t PkgTypeGo) node() Node {
+	return t.noad
+}
+
+func lazyTypeGoStringer(ty *TypeGo) func() fmt.Stringer {
+	return func() fmt.Stringer {
+		if ty != nil && *ty != nil {
+			return String((*ty).variant())
+		} else {
+			return String("<nil>")
+		}
+	}
+}
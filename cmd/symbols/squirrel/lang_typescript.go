@@ -0,0 +1,856 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// getDefTypeScript finds definitions in both TypeScript and JavaScript files, which have nearly
+// identical syntax trees.
+func (s *SquirrelService) getDefTypeScript(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	ident := node.Content(node.Contents)
+	parent := node.Parent()
+
+	switch node.Type() {
+	case "identifier", "type_identifier", "shorthand_property_identifier":
+		if parent != nil && parent.Type() == "nested_type_identifier" {
+			module := parent.ChildByFieldName("module")
+			if module != nil && nodeId(module) != nodeId(node.Node) {
+				return s.getFieldTypeScript(ctx, swapNode(node, module), ident)
+			}
+		}
+		return s.getDefInScopeTypeScript(ctx, node, ident)
+
+	case "property_identifier":
+		if parent == nil {
+			return nil, nil
+		}
+		switch parent.Type() {
+		case "member_expression":
+			object := parent.ChildByFieldName("object")
+			if object == nil {
+				return nil, nil
+			}
+			return s.getFieldTypeScript(ctx, swapNode(node, object), ident)
+		case "method_definition", "method_signature", "abstract_method_signature", "public_field_definition", "property_signature":
+			return swapNodePtr(node, node.Node), nil
+		default:
+			return nil, nil
+		}
+
+	case "this":
+		class := enclosingClassTypeScript(node.Node)
+		if class == nil {
+			return nil, nil
+		}
+		name := class.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return swapNodePtr(node, name), nil
+
+	case "string":
+		if parent == nil || (parent.Type() != "import_statement" && parent.Type() != "export_statement") {
+			return nil, nil
+		}
+		return s.resolveModuleTypeScript(ctx, node, getStringContentsTypeScript(node)), nil
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInScopeTypeScript walks up the tree from the given node looking for a declaration of
+// ident, falling back to imports at the top level.
+func (s *SquirrelService) getDefInScopeTypeScript(ctx context.Context, node Node, ident string) (ret *Node, err error) {
+	defer s.onCall(node, &Tuple{String(node.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	findIn := func(names []*sitter.Node) *Node {
+		for _, name := range names {
+			if name.Content(node.Contents) == ident {
+				return swapNodePtr(node, name)
+			}
+		}
+		return nil
+	}
+
+	cur := node.Node
+	for {
+		cur = cur.Parent()
+		if cur == nil {
+			s.breadcrumb(node, "getDefInScopeTypeScript: ran out of parents")
+			return nil, nil
+		}
+
+		switch cur.Type() {
+		case "program":
+			for _, child := range children(cur) {
+				if found := findIn(declaredNamesTypeScript(child)); found != nil {
+					return found, nil
+				}
+			}
+			return s.getDefInImportsTypeScript(ctx, swapNode(node, cur), ident)
+
+		case "statement_block", "switch_case", "switch_default":
+			// Function declarations are hoisted, so declarations after the reference count too
+			for _, child := range children(cur) {
+				if found := findIn(declaredNamesTypeScript(child)); found != nil {
+					return found, nil
+				}
+			}
+
+		case "function", "function_expression", "function_declaration", "generator_function",
+			"generator_function_declaration", "method_definition", "arrow_function":
+			if name := cur.ChildByFieldName("name"); name != nil && cur.Type() != "method_definition" {
+				if found := findIn([]*sitter.Node{name}); found != nil {
+					return found, nil
+				}
+			}
+			if param := cur.ChildByFieldName("parameter"); param != nil {
+				if found := findIn([]*sitter.Node{param}); found != nil {
+					return found, nil
+				}
+			}
+			if found := findIn(parameterNamesTypeScript(cur.ChildByFieldName("parameters"))); found != nil {
+				return found, nil
+			}
+			if found := findIn(typeParameterNamesTypeScript(cur.ChildByFieldName("type_parameters"))); found != nil {
+				return found, nil
+			}
+
+		case "class", "class_declaration", "abstract_class_declaration", "interface_declaration", "type_alias_declaration":
+			if name := cur.ChildByFieldName("name"); name != nil {
+				if found := findIn([]*sitter.Node{name}); found != nil {
+					return found, nil
+				}
+			}
+			if found := findIn(typeParameterNamesTypeScript(cur.ChildByFieldName("type_parameters"))); found != nil {
+				return found, nil
+			}
+
+		case "for_statement":
+			if found := findIn(declaredNamesTypeScript(cur.ChildByFieldName("initializer"))); found != nil {
+				return found, nil
+			}
+
+		case "for_in_statement":
+			if found := findIn(patternNamesTypeScript(cur.ChildByFieldName("left"))); found != nil {
+				return found, nil
+			}
+
+		case "catch_clause":
+			if found := findIn(patternNamesTypeScript(cur.ChildByFieldName("parameter"))); found != nil {
+				return found, nil
+			}
+		}
+	}
+}
+
+// getDefInImportsTypeScript follows the import that binds ident in the given program, if any.
+func (s *SquirrelService) getDefInImportsTypeScript(ctx context.Context, program Node, ident string) (ret *Node, err error) {
+	defer s.onCall(program, &Tuple{String(program.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	for _, stmt := range children(program.Node) {
+		if stmt.Type() != "import_statement" {
+			continue
+		}
+		source := moduleSourceTypeScript(stmt)
+		if source == nil {
+			continue
+		}
+
+		// The name exported by the imported module, or "*" for namespace imports
+		exported := ""
+		for _, clause := range children(stmt) {
+			if clause.Type() != "import_clause" {
+				continue
+			}
+			for _, child := range children(clause) {
+				switch child.Type() {
+				case "identifier":
+					if child.Content(program.Contents) == ident {
+						exported = "default"
+					}
+				case "namespace_import":
+					for _, name := range children(child) {
+						if name.Content(program.Contents) == ident {
+							exported = "*"
+						}
+					}
+				case "named_imports":
+					for _, specifier := range children(child) {
+						name := specifier.ChildByFieldName("name")
+						if name == nil {
+							continue
+						}
+						local := name
+						if alias := specifier.ChildByFieldName("alias"); alias != nil {
+							local = alias
+						}
+						if local.Content(program.Contents) == ident {
+							exported = name.Content(program.Contents)
+						}
+					}
+				}
+			}
+		}
+		if exported == "" {
+			continue
+		}
+
+		// Imports of packages outside of this repository are not followed
+		module := s.resolveModuleTypeScript(ctx, program, getStringContentsTypeScript(swapNode(program, source)))
+		if module == nil {
+			return nil, nil
+		}
+		if exported == "*" {
+			return module, nil
+		}
+		return s.findExportTypeScript(ctx, *module, exported)
+	}
+
+	return nil, nil
+}
+
+// findExportTypeScript finds the declaration of the given export of a module, following
+// re-exports. Top-level declarations that are not exported are also considered as a fallback.
+func (s *SquirrelService) findExportTypeScript(ctx context.Context, module Node, exported string) (ret *Node, err error) {
+	defer s.onCall(module, &Tuple{String(module.RepoCommitPath.Path), String(exported)}, lazyNodeStringer(&ret))()
+
+	reexports := []*sitter.Node{}
+	for _, stmt := range children(module.Node) {
+		if stmt.Type() != "export_statement" {
+			continue
+		}
+		source := moduleSourceTypeScript(stmt)
+
+		if exported == "default" && hasChildOfTypeTypeScript(stmt, "default") {
+			value := stmt.ChildByFieldName("value")
+			if value == nil {
+				value = stmt.ChildByFieldName("declaration")
+			}
+			if value == nil {
+				continue
+			}
+			if name := value.ChildByFieldName("name"); name != nil {
+				return swapNodePtr(module, name), nil
+			}
+			if value.Type() == "identifier" {
+				return s.getDefInScopeTypeScript(ctx, swapNode(module, value), value.Content(module.Contents))
+			}
+			return swapNodePtr(module, value), nil
+		}
+
+		if decl := stmt.ChildByFieldName("declaration"); decl != nil {
+			for _, name := range declaredNamesTypeScript(decl) {
+				if name.Content(module.Contents) == exported {
+					return swapNodePtr(module, name), nil
+				}
+			}
+		}
+
+		clauses := 0
+		for _, clause := range children(stmt) {
+			if clause.Type() != "export_clause" {
+				continue
+			}
+			clauses++
+			for _, specifier := range children(clause) {
+				name := specifier.ChildByFieldName("name")
+				if name == nil {
+					continue
+				}
+				public := name
+				if alias := specifier.ChildByFieldName("alias"); alias != nil {
+					public = alias
+				}
+				if public.Content(module.Contents) != exported {
+					continue
+				}
+				if source == nil {
+					// export { x }
+					return s.getDefInScopeTypeScript(ctx, swapNode(module, name), name.Content(module.Contents))
+				}
+				// export { x } from './y'
+				other := s.resolveModuleTypeScript(ctx, module, getStringContentsTypeScript(swapNode(module, source)))
+				if other == nil {
+					return nil, nil
+				}
+				return s.findExportTypeScript(ctx, *other, name.Content(module.Contents))
+			}
+		}
+
+		if clauses == 0 && source != nil {
+			// export * from './y'
+			reexports = append(reexports, source)
+		}
+	}
+
+	for _, child := range children(module.Node) {
+		for _, name := range declaredNamesTypeScript(child) {
+			if name.Content(module.Contents) == exported {
+				return swapNodePtr(module, name), nil
+			}
+		}
+	}
+
+	for _, source := range reexports {
+		other := s.resolveModuleTypeScript(ctx, module, getStringContentsTypeScript(swapNode(module, source)))
+		if other == nil {
+			continue
+		}
+		found, err := s.findExportTypeScript(ctx, *other, exported)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// typeScriptModuleSuffixes are appended in order to an import path to find the imported file.
+var typeScriptModuleSuffixes = []string{
+	"",
+	".ts", ".tsx", ".d.ts", ".js", ".jsx",
+	"/index.ts", "/index.tsx", "/index.d.ts", "/index.js", "/index.jsx",
+}
+
+// resolveModuleTypeScript returns the root of the file imported by the given relative import path,
+// or nil if the import path does not refer to a file in the same repository.
+func (s *SquirrelService) resolveModuleTypeScript(ctx context.Context, from Node, importPath string) *Node {
+	if !strings.HasPrefix(importPath, "./") && !strings.HasPrefix(importPath, "../") {
+		return nil
+	}
+
+	base := filepath.Join(filepath.Dir(from.RepoCommitPath.Path), importPath)
+	candidates := []string{}
+	for _, suffix := range typeScriptModuleSuffixes {
+		candidates = append(candidates, base+suffix)
+	}
+	// TypeScript allows importing ./foo.js to refer to ./foo.ts
+	if ext := filepath.Ext(base); ext == ".js" || ext == ".jsx" {
+		candidates = append(candidates, strings.TrimSuffix(base, ext)+".ts", strings.TrimSuffix(base, ext)+".tsx")
+	}
+
+	for _, candidate := range candidates {
+		if filepath.Ext(candidate) == "" {
+			continue
+		}
+		module, err := s.parse(ctx, types.RepoCommitPath{
+			Repo:   from.RepoCommitPath.Repo,
+			Commit: from.RepoCommitPath.Commit,
+			Path:   candidate,
+		})
+		if err == nil {
+			return module
+		}
+	}
+	return nil
+}
+
+func (s *SquirrelService) getFieldTypeScript(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer s.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	ty, err := s.getTypeDefTypeScript(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return s.lookupFieldTypeScript(ctx, ty, field)
+}
+
+func (s *SquirrelService) lookupFieldTypeScript(ctx context.Context, ty TypeTypeScript, field string) (ret *Node, err error) {
+	defer s.onCall(ty.node(), &Tuple{String(ty.variant()), String(field)}, lazyNodeStringer(&ret))()
+
+	switch ty2 := ty.(type) {
+	case ModuleTypeTypeScript:
+		return s.findExportTypeScript(ctx, ty2.module, field)
+
+	case ClassTypeTypeScript:
+		body := ty2.def.ChildByFieldName("body")
+		if body == nil {
+			return nil, nil
+		}
+		for _, member := range children(body) {
+			switch member.Type() {
+			case "method_definition", "method_signature", "abstract_method_signature", "public_field_definition", "property_signature":
+				name := member.ChildByFieldName("name")
+				if name == nil {
+					// JavaScript class fields
+					name = member.ChildByFieldName("property")
+				}
+				if name == nil {
+					continue
+				}
+				if name.Content(ty2.def.Contents) == field {
+					return swapNodePtr(ty2.def, name), nil
+				}
+				if member.Type() == "method_definition" && name.Content(ty2.def.Contents) == "constructor" {
+					if found := findConstructorFieldTypeScript(swapNode(ty2.def, member), field); found != nil {
+						return found, nil
+					}
+				}
+			case "property_identifier", "enum_assignment":
+				// Enum members
+				name := member
+				if member.Type() == "enum_assignment" {
+					name = member.ChildByFieldName("name")
+				}
+				if name != nil && name.Content(ty2.def.Contents) == field {
+					return swapNodePtr(ty2.def, name), nil
+				}
+			}
+		}
+		for _, super := range getSuperclassesTypeScript(ty2.def) {
+			found, err := s.getFieldTypeScript(ctx, super, field)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+		return nil, nil
+
+	case FnTypeTypeScript:
+		s.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldTypeScript: unexpected object type %s", ty.variant()))
+		return nil, nil
+
+	default:
+		s.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldTypeScript: unrecognized type variant %q", ty.variant()))
+		return nil, nil
+	}
+}
+
+// findConstructorFieldTypeScript finds fields declared by a constructor, either as TypeScript
+// parameter properties (constructor(private x: number)) or as assignments to this.x.
+func findConstructorFieldTypeScript(constructor Node, field string) *Node {
+	for _, param := range children(constructor.ChildByFieldName("parameters")) {
+		if !hasChildOfTypeTypeScript(param, "accessibility_modifier") && !hasChildOfTypeTypeScript(param, "readonly") {
+			continue
+		}
+		for _, name := range children(param) {
+			if name.Type() == "identifier" && name.Content(constructor.Contents) == field {
+				return swapNodePtr(constructor, name)
+			}
+		}
+	}
+
+	query := `(assignment_expression left: (member_expression object: (this) property: (property_identifier) @property))`
+	captures, _ := allCaptures(query, constructor)
+	for _, capture := range captures {
+		if capture.Content(capture.Contents) == field {
+			return &capture
+		}
+	}
+	return nil
+}
+
+func (s *SquirrelService) getTypeDefTypeScript(ctx context.Context, node Node) (ret TypeTypeScript, err error) {
+	defer s.onCall(node, String(node.Type()), lazyTypeTypeScriptStringer(&ret))()
+
+	onIdent := func() (TypeTypeScript, error) {
+		found, err := s.getDefTypeScript(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		if isRecursiveDefinitionTypeScript(node, *found) {
+			return nil, nil
+		}
+		return s.defToTypeTypeScript(ctx, *found)
+	}
+
+	switch node.Type() {
+	case "identifier", "type_identifier", "property_identifier", "shorthand_property_identifier", "this":
+		return onIdent()
+	case "super":
+		class := enclosingClassTypeScript(node.Node)
+		if class == nil {
+			return nil, nil
+		}
+		for _, super := range getSuperclassesTypeScript(swapNode(node, class)) {
+			return s.getTypeDefTypeScript(ctx, super)
+		}
+		return nil, nil
+	case "type_annotation", "parenthesized_expression", "non_null_expression", "await_expression":
+		if node.NamedChildCount() == 0 {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(node, node.NamedChild(0)))
+	case "as_expression":
+		if node.NamedChildCount() == 0 {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(node, node.NamedChild(int(node.NamedChildCount())-1)))
+	case "generic_type", "nested_type_identifier":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(node, name))
+	case "member_expression":
+		property := node.ChildByFieldName("property")
+		if property == nil {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(node, property))
+	case "new_expression":
+		constructor := node.ChildByFieldName("constructor")
+		if constructor == nil {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(node, constructor))
+	case "call_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		ty, err := s.getTypeDefTypeScript(ctx, swapNode(node, fn))
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			return nil, nil
+		}
+		switch ty2 := ty.(type) {
+		case FnTypeTypeScript:
+			return ty2.ret, nil
+		default:
+			s.breadcrumb(ty.node(), fmt.Sprintf("getTypeDefTypeScript: expected function, got %q", ty.variant()))
+			return nil, nil
+		}
+	default:
+		s.breadcrumb(node, fmt.Sprintf("getTypeDefTypeScript: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+func (s *SquirrelService) defToTypeTypeScript(ctx context.Context, def Node) (TypeTypeScript, error) {
+	if def.Node.Type() == "program" {
+		return (TypeTypeScript)(ModuleTypeTypeScript{module: def}), nil
+	}
+
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	fnType := func(fn *sitter.Node) (TypeTypeScript, error) {
+		retTyNode := fn.ChildByFieldName("return_type")
+		if retTyNode == nil {
+			return (TypeTypeScript)(FnTypeTypeScript{ret: nil, noad: swapNode(def, fn)}), nil
+		}
+		retTy, err := s.getTypeDefTypeScript(ctx, swapNode(def, retTyNode))
+		if err != nil {
+			return nil, err
+		}
+		return (TypeTypeScript)(FnTypeTypeScript{ret: retTy, noad: swapNode(def, fn)}), nil
+	}
+
+	switch parent.Type() {
+	case "class", "class_declaration", "abstract_class_declaration", "interface_declaration", "enum_declaration":
+		return (TypeTypeScript)(ClassTypeTypeScript{def: swapNode(def, parent)}), nil
+	case "function", "function_expression", "generator_function", "function_declaration", "generator_function_declaration",
+		"method_definition", "method_signature", "abstract_method_signature", "function_signature":
+		return fnType(parent)
+	case "type_alias_declaration":
+		value := parent.ChildByFieldName("value")
+		if value == nil {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(def, value))
+	case "variable_declarator", "public_field_definition", "property_signature", "required_parameter", "optional_parameter":
+		if ty := typeAnnotationTypeScript(parent); ty != nil {
+			return s.getTypeDefTypeScript(ctx, swapNode(def, ty))
+		}
+		value := parent.ChildByFieldName("value")
+		if value == nil {
+			return nil, nil
+		}
+		switch value.Type() {
+		case "arrow_function", "function", "function_expression":
+			return fnType(value)
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(def, value))
+	default:
+		s.breadcrumb(swapNode(def, parent), fmt.Sprintf("unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+// declaredNamesTypeScript returns the names declared by the given statement.
+func declaredNamesTypeScript(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	names := []*sitter.Node{}
+	switch node.Type() {
+	case "lexical_declaration", "variable_declaration":
+		for _, declarator := range children(node) {
+			if declarator.Type() == "variable_declarator" {
+				names = append(names, patternNamesTypeScript(declarator.ChildByFieldName("name"))...)
+			}
+		}
+	case "function_declaration", "generator_function_declaration", "class_declaration", "abstract_class_declaration",
+		"interface_declaration", "type_alias_declaration", "enum_declaration", "internal_module", "module":
+		if name := node.ChildByFieldName("name"); name != nil {
+			names = append(names, name)
+		}
+	case "export_statement":
+		if decl := node.ChildByFieldName("declaration"); decl != nil {
+			names = append(names, declaredNamesTypeScript(decl)...)
+		}
+		if value := node.ChildByFieldName("value"); value != nil {
+			if name := value.ChildByFieldName("name"); name != nil {
+				names = append(names, name)
+			}
+		}
+	case "ambient_declaration", "expression_statement":
+		// declare ..., and namespaces which are parsed as expression statements
+		for _, child := range children(node) {
+			names = append(names, declaredNamesTypeScript(child)...)
+		}
+	}
+	return names
+}
+
+// patternNamesTypeScript returns the identifiers bound by a (possibly destructuring) pattern.
+func patternNamesTypeScript(pattern *sitter.Node) []*sitter.Node {
+	names := []*sitter.Node{}
+	if pattern == nil {
+		return names
+	}
+	walkFilter(pattern, func(n *sitter.Node) bool {
+		switch n.Type() {
+		case "identifier", "shorthand_property_identifier_pattern":
+			names = append(names, n)
+			return false
+		case "pair_pattern":
+			// Only the value of { key: value } is bound
+			if value := n.ChildByFieldName("value"); value != nil {
+				names = append(names, patternNamesTypeScript(value)...)
+			}
+			return false
+		case "assignment_pattern", "object_assignment_pattern":
+			if left := n.ChildByFieldName("left"); left != nil {
+				names = append(names, patternNamesTypeScript(left)...)
+			}
+			return false
+		case "type_annotation":
+			return false
+		}
+		return true
+	})
+	return names
+}
+
+// parameterNamesTypeScript returns the names of the parameters in the given formal_parameters.
+func parameterNamesTypeScript(params *sitter.Node) []*sitter.Node {
+	names := []*sitter.Node{}
+	for _, param := range children(params) {
+		switch param.Type() {
+		case "required_parameter", "optional_parameter":
+			for _, child := range children(param) {
+				if child.Type() == "accessibility_modifier" || child.Type() == "type_annotation" {
+					continue
+				}
+				names = append(names, patternNamesTypeScript(child)...)
+				break
+			}
+		default:
+			names = append(names, patternNamesTypeScript(param)...)
+		}
+	}
+	return names
+}
+
+func typeParameterNamesTypeScript(params *sitter.Node) []*sitter.Node {
+	names := []*sitter.Node{}
+	for _, param := range children(params) {
+		if name := param.ChildByFieldName("name"); name != nil {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func typeAnnotationTypeScript(node *sitter.Node) *sitter.Node {
+	if ty := node.ChildByFieldName("type"); ty != nil {
+		return ty
+	}
+	for _, child := range children(node) {
+		if child.Type() == "type_annotation" {
+			return child
+		}
+	}
+	return nil
+}
+
+// getSuperclassesTypeScript returns the classes extended by a class or the interfaces extended by
+// an interface.
+func getSuperclassesTypeScript(def Node) []Node {
+	supers := []Node{}
+	for _, child := range children(def.Node) {
+		switch child.Type() {
+		case "class_heritage":
+			for _, heritage := range children(child) {
+				switch heritage.Type() {
+				case "extends_clause":
+					for _, super := range children(heritage) {
+						if super.Type() == "type_arguments" {
+							continue
+						}
+						supers = append(supers, swapNode(def, super))
+						break
+					}
+				case "implements_clause":
+					continue
+				default:
+					// JavaScript's class_heritage contains the superclass directly
+					supers = append(supers, swapNode(def, heritage))
+				}
+			}
+		case "extends_clause", "extends_type_clause":
+			for _, super := range children(child) {
+				supers = append(supers, swapNode(def, super))
+			}
+		}
+	}
+	return supers
+}
+
+// moduleSourceTypeScript returns the module specifier of an import or export statement. The source
+// field can't be looked up by name reliably, so this looks for the string child instead.
+func moduleSourceTypeScript(stmt *sitter.Node) *sitter.Node {
+	for _, child := range children(stmt) {
+		if child.Type() == "string" {
+			return child
+		}
+	}
+	return nil
+}
+
+// getStringContentsTypeScript returns the contents of a string literal, which may use either
+// single or double quotes.
+func getStringContentsTypeScript(node Node) string {
+	str := node.Node.Content(node.Contents)
+	if len(str) >= 2 && (str[0] == '"' || str[0] == '\'') && str[len(str)-1] == str[0] {
+		return str[1 : len(str)-1]
+	}
+	return str
+}
+
+func enclosingClassTypeScript(node *sitter.Node) *sitter.Node {
+	for cur := node; cur != nil; cur = cur.Parent() {
+		switch cur.Type() {
+		case "class", "class_declaration", "abstract_class_declaration":
+			return cur
+		}
+	}
+	return nil
+}
+
+func hasChildOfTypeTypeScript(node *sitter.Node, ty string) bool {
+	for i := 0; i < int(node.ChildCount()); i++ {
+		if node.Child(i).Type() == ty {
+			return true
+		}
+	}
+	return false
+}
+
+// isRecursiveDefinitionTypeScript detects cases like `const x = x.foo` that would cause infinite
+// recursion when attempting to determine the type of `x`.
+func isRecursiveDefinitionTypeScript(node Node, def Node) bool {
+	if node.RepoCommitPath != def.RepoCommitPath {
+		return false
+	}
+	declarator := def.Parent()
+	for declarator != nil && declarator.Type() != "variable_declarator" {
+		switch declarator.Type() {
+		case "object_pattern", "array_pattern", "pair_pattern", "shorthand_property_identifier_pattern":
+			declarator = declarator.Parent()
+		default:
+			return false
+		}
+	}
+	if declarator == nil {
+		return false
+	}
+	for cur := node.Parent(); cur != nil; cur = cur.Parent() {
+		if nodeId(cur) == nodeId(declarator) {
+			return true
+		}
+	}
+	return false
+}
+
+type TypeTypeScript interface {
+	variant() string
+	node() Node
+}
+
+type FnTypeTypeScript struct {
+	ret  TypeTypeScript
+	noad Node
+}
+
+func (t FnTypeTypeScript) variant() string {
+	return "fn"
+}
+
+func (t FnTypeTypeScript) node() Node {
+	return t.noad
+}
+
+type ClassTypeTypeScript struct {
+	def Node
+}
+
+func (t ClassTypeTypeScript) variant() string {
+	return "class"
+}
+
+func (t ClassTypeTypeScript) node() Node {
+	return t.def
+}
+
+type ModuleTypeTypeScript struct {
+	module Node
+}
+
+func (t ModuleTypeTypeScript) variant() string {
+	return "module"
+}
+
+func (t ModuleTypeTypeScript) node() Node {
+	return t.module
+}
+
+func lazyTypeTypeScriptStringer(ty *TypeTypeScript) func() fmt.Stringer {
+	return func() fmt.Stringer {
+		if ty != nil && *ty != nil {
+			return String((*ty).variant())
+		} else {
+			return String("<nil>")
+		}
+	}
+}
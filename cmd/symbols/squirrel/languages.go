@@ -124,6 +124,13 @@ var langToLangSpec = map[string]LangSpec{
 (short_var_declaration left: (expression_list (identifier) @definition)) ; x, y := ...
 (range_clause          left: (expression_list (identifier) @definition)) ; for i := range ... { ... }
 (receive_statement     left: (expression_list (identifier) @definition)) ; case x := <-ch: ...
+`,
+		topLevelSymbolsQuery: `
+(source_file (function_declaration name: (identifier) @symbol))
+(source_file (method_declaration   name: (field_identifier) @symbol))
+(source_file (type_declaration     (type_spec  name: (type_identifier) @symbol)))
+(source_file (var_declaration      (var_spec   name: (identifier) @symbol)))
+(source_file (const_declaration    (const_spec name: (identifier) @symbol)))
 `,
 	},
 	"csharp": {
@@ -149,6 +156,13 @@ var langToLangSpec = map[string]LangSpec{
 (variable_declarator (identifier) @definition)       ; int x = ...
 (for_each_statement  left: (identifier) @definition) ; foreach (int x in xs) ...
 (catch_declaration   name: (identifier) @definition) ; catch (Exception e) { ... }
+`,
+		topLevelSymbolsQuery: `
+(class_declaration     name: (identifier) @symbol)
+(struct_declaration    name: (identifier) @symbol)
+(interface_declaration name: (identifier) @symbol)
+(record_declaration    name: (identifier) @symbol)
+(enum_declaration      name: (identifier) @symbol)
 `,
 	},
 	"python": {
@@ -148,11 +148,14 @@ func (s *SquirrelService) getDef(ctx context.Context, node Node) (*Node, error)
 		return s.getDefStarlark(ctx, node)
 	case "python":
 		return s.getDefPython(ctx, node)
-	// case "go":
-	// case "csharp":
-	// case "python":
-	// case "javascript":
-	// case "typescript":
+	case "go":
+		return s.getDefGo(ctx, node)
+	case "csharp":
+		return s.getDefCsharp(ctx, node)
+	case "javascript":
+		fallthrough
+	case "typescript":
+		return s.getDefTypeScript(ctx, node)
 	// case "cpp":
 	// case "ruby":
 	default:
@@ -0,0 +1,49 @@
+namespace Squirrel.Models
+{
+    //           vvvvv cs.Shape def
+    public class Shape
+    {
+        //            vvvv cs.Shape.Name def
+        public string Name { get; set; }
+
+        //                    vvvv cs.Shape.Area def
+        public virtual double Area()
+        {
+            return 0;
+        }
+    }
+
+    //           vvvvvv cs.Square def
+    //                    vvvvv cs.Shape ref
+    public class Square : Shape
+    {
+        //             vvvv cs.Square.side def
+        private double side;
+
+        //     vvvvvv cs.Square.ctor def
+        //                   vvvvvv cs.Square.ctor.length def
+        public Square(double length)
+        {
+//          vvvv cs.Square.side ref
+            //     vvvvvv cs.Square.ctor.length ref
+            side = length;
+        }
+
+        //     vvvvvv cs.Square ref
+        //            vvvvv cs.Square.Scale def
+        //                         vvvvvv cs.Square.Scale.factor def
+        public Square Scale(double factor)
+        {
+            //                     vvvv cs.Square.side ref
+            //                            vvvvvv cs.Square.Scale.factor ref
+            return new Square(this.side * factor);
+        }
+
+        //                     vvvv cs.Square.Area def
+        public override double Area()
+        {
+            //            vvvv cs.Square.side ref
+            return side * side;
+        }
+    }
+}
@@ -0,0 +1,37 @@
+using System;
+using Squirrel.Models;
+
+namespace Squirrel
+{
+    class Program
+    {
+        static void Main(string[] args)
+        {
+            //  vvvvvv cs.square def
+            //               vvvvvv cs.Square ref
+            var square = new Square(2);
+
+            //        vvvvvv cs.square ref
+            //               vvvvv cs.Square.Scale ref
+            //                        vvvvv cs.Square.Scale ref
+            var big = square.Scale(2).Scale(3);
+
+            //                    vvvv cs.Square.Area ref
+            Console.WriteLine(big.Area());
+
+            //                       vvvv cs.Shape.Name ref
+            Console.WriteLine(square.Name);
+
+//          vvvvv cs.Shape ref
+            //    vvvvv cs.shape def
+            //            vvvvvv cs.square ref
+            Shape shape = square;
+
+            //                      vvvv cs.Shape.Area ref
+            Console.WriteLine(shape.Area());
+
+            //      vvvvvvvvv cs.WriteLine nodef
+            Console.WriteLine(args);
+        }
+    }
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	//vvv fmt ref,nodef
+	"fmt"
+
+	//vvvvvvvvvvvvvvvvvvvvvvvv sub path
+	"example.com/squirrel/sub"
+)
+
+//   v go.T def
+type T struct {
+	F int // < "F" go.T.F def
+	*Embedded
+	sub.Thing
+}
+
+//   vvvvvvvv go.Embedded def
+type Embedded struct {
+	E string // < "E" go.Embedded.E def
+}
+
+//          v go.T.M def
+func (t *T) M() int {
+	//       v go.T.F ref
+	return t.F
+}
+
+//   v go.f def
+func f() *T {
+	//      v go.T ref
+	return &T{}
+}
+
+func main() {
+	//  v go.main.a def
+	//    v go.T ref
+	var a T
+
+	//   v go.f ref
+	b := f() // < "b" go.main.b def
+
+	//v go.T.M ref
+	a.M() // < "a" go.main.a ref
+
+	//v go.T.N ref
+	b.N()
+
+	//    v go.Embedded.E ref
+	_ = b.E
+
+	//      vvvvv go.sub.Thing.Count ref
+	_ = a.Count
+
+	//   vvv sub path
+	//       vvv go.sub.New ref
+	c := sub.New()
+
+	//      vvvvv go.sub.Thing.Count ref
+	_ = c.Count
+
+	v, err := sub.Make() // < "v" go.main.v def
+
+	//  v go.main.v ref
+	//      vvvvv go.sub.Thing.Count ref
+	_ = v.Count
+	_ = err
+
+	//vvvvvv go.helper ref
+	helper()
+
+	//    v go.T.F ref
+	_ = T{F: 1}
+
+	//  vvvvvvv Println ref,nodef
+	fmt.Println(a, b)
+
+	x := 1 // < "x" go.main.x def
+	{
+		x := 2 // < "x" go.main.inner.x def
+
+		//  v go.main.inner.x ref
+		_ = x
+	}
+
+	//  v go.main.x ref
+	_ = x
+
+	//        v go.main.p def
+	g := func(p int) int {
+		//     v go.main.p ref
+		return p
+	}
+	_ = g
+
+	//  v go.main.i def
+	for i := range []int{} {
+		//  v go.main.i ref
+		_ = i
+	}
+}
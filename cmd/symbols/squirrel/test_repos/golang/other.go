@@ -0,0 +1,7 @@
+package main
+
+//          v go.T.N def
+func (t *T) N() {}
+
+//   vvvvvv go.helper def
+func helper() {}
@@ -0,0 +1,16 @@
+package sub
+
+//   vvvvv go.sub.Thing def
+type Thing struct {
+	Count int // < "Count" go.sub.Thing.Count def
+}
+
+//   vvv go.sub.New def
+func New() *Thing {
+	return &Thing{}
+}
+
+//   vvvv go.sub.Make def
+func Make() (Thing, error) {
+	return Thing{}, nil
+}
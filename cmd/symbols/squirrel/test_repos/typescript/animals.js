@@ -0,0 +1,30 @@
+//    vvvvvv js.Animal def
+class Animal {
+  //          vvvv js.Animal.constructor.name def
+  constructor(name) {
+    //   vvvv js.Animal.name def
+    //          vvvv js.Animal.constructor.name ref
+    this.name = name
+  }
+
+//vvvvv js.Animal.speak def
+  speak() {
+    //          vvvv js.Animal.name ref
+    return this.name
+  }
+}
+
+//                vvvvvv js.Animal ref
+class Dog extends Animal {
+  bark() {
+    //          vvvv js.Animal.name ref
+    //                          vvvvv js.Animal.speak ref
+    return this.name + this.speak()
+  }
+}
+
+//    vvv js.dog def
+const dog = new Dog('rex')
+
+//  vvvv js.Animal.speak ref
+dog.speak()
@@ -0,0 +1,30 @@
+//               vvvvv ts.Shape def
+export interface Shape {
+//vvvv ts.Shape.area def
+  area(): number
+}
+
+//           vvvvvv ts.Circle def
+//                             vvvvv ts.Shape ref
+export class Circle implements Shape {
+  //                  vvvvvv ts.Circle.radius def
+  constructor(private radius: number) {}
+
+//vvvv ts.Circle.area def
+  area(): number {
+    //                     vvvvvv ts.Circle.radius ref
+    return Math.PI * this.radius * this.radius
+  }
+
+//vvvvv ts.Circle.scale def
+  //                     vvvvvv ts.Circle ref
+  scale(factor: number): Circle { // < "factor" ts.Circle.scale.factor def
+    //                              vvvvvv ts.Circle.scale.factor ref
+    return new Circle(this.radius * factor)
+  }
+}
+
+//                      vvvvvvvv ts.makeUnit def
+export default function makeUnit(): Circle {
+  return new Circle(1)
+}
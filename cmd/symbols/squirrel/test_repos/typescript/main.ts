@@ -0,0 +1,50 @@
+//       vvvvvv ts.Circle ref
+//               vvvvv ts.Shape ref
+import { Circle, Shape } from './lib'
+//     vvvvvvvv ts.makeUnit ref
+import makeUnit from './lib/shapes'
+import * as shapes from './lib/shapes'
+
+//       vvvvv ts.total def
+//             vvvvv ts.total.items def
+function total(items: Shape[]): number {
+  //  vvv ts.total.sum def
+  let sum = 0
+  //         v ts.total.item def
+  for (const item of items) {
+    sum += item.area() // < "sum" ts.total.sum ref < "item" ts.total.item ref
+  }
+  //     vvv ts.total.sum ref
+  return sum
+}
+
+//    v ts.c def
+const c = new Circle(2)
+
+//    v ts.d def
+//          vvvvv ts.Circle.scale ref
+const d = c.scale(2)
+
+//          vvvvv ts.Circle.scale ref
+const e = d.scale(3)
+
+//                    vvvv ts.Circle.area ref
+const u = makeUnit().area()
+
+//               v ts.c ref
+const s: Shape = c
+
+//vvv ts.Shape.area ref
+s.area()
+
+//                   vvvvvv ts.Circle ref
+const f = new shapes.Circle(1)
+
+//vvvv ts.Circle.scale ref
+f.scale(1)
+
+//                vvvvvvvv ts.makeUnit ref
+const g = shapes.makeUnit()
+
+//                 vvvvv ts.total ref
+console.log(e, u, total([g]))
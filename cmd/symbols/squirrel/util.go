@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/grafana/regexp"
 	sitter "github.com/smacker/go-tree-sitter"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -392,6 +393,19 @@ func lazyNodeStringer(node **Node) func() fmt.Stringer {
 }
 
 func (s *SquirrelService) symbolSearchOne(ctx context.Context, repo string, commit string, include []string, ident string) (*Node, error) {
+	return s.symbolSearchFirstMatching(ctx, repo, commit, include, ident, 1, nil)
+}
+
+// maxSymbolSearchCandidates is the number of symbols to consider when looking for a symbol that
+// satisfies some syntactic predicate (e.g. a method on a particular receiver type).
+const maxSymbolSearchCandidates = 25
+
+// symbolSearchFirstMatching runs a symbol search for ident and returns the first of at most first
+// results for which accept returns true. A nil accept function accepts all results.
+func (s *SquirrelService) symbolSearchFirstMatching(ctx context.Context, repo string, commit string, include []string, ident string, first int, accept func(Node) bool) (*Node, error) {
+	if s.symbolSearch == nil {
+		return nil, nil
+	}
 	symbols, err := s.symbolSearch(ctx, search.SymbolsParameters{
 		Repo:            api.RepoName(repo),
 		CommitID:        api.CommitID(commit),
@@ -400,34 +414,49 @@ func (s *SquirrelService) symbolSearchOne(ctx context.Context, repo string, comm
 		IsCaseSensitive: true,
 		IncludePatterns: include,
 		ExcludePattern:  "",
-		First:           1,
+		First:           first,
 	})
 	if err != nil {
 		return nil, err
 	}
-	if len(symbols) == 0 {
-		return nil, nil
-	}
-	symbol := symbols[0]
-	file, err := s.parse(ctx, types.RepoCommitPath{
-		Repo:   repo,
-		Commit: commit,
-		Path:   symbol.Path,
-	})
-	if errors.Is(err, UnsupportedLanguageError) || errors.Is(err, unrecognizedFileExtensionError) {
-		return nil, nil
-	}
-	if err != nil {
-		return nil, err
+	for _, symbol := range symbols {
+		file, err := s.parse(ctx, types.RepoCommitPath{
+			Repo:   repo,
+			Commit: commit,
+			Path:   symbol.Path,
+		})
+		if errors.Is(err, UnsupportedLanguageError) || errors.Is(err, unrecognizedFileExtensionError) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		point := sitter.Point{
+			Row:    uint32(symbol.Line),
+			Column: uint32(symbol.Character),
+		}
+		symbolNode := file.NamedDescendantForPointRange(point, point)
+		if symbolNode == nil {
+			continue
+		}
+		ret := swapNode(*file, symbolNode)
+		if accept == nil || accept(ret) {
+			return &ret, nil
+		}
 	}
-	point := sitter.Point{
-		Row:    uint32(symbol.Line),
-		Column: uint32(symbol.Character),
+	return nil, nil
+}
+
+// dirIncludePattern returns a symbol search include pattern that matches files with one of the
+// given extensions directly inside dir (not in subdirectories).
+func dirIncludePattern(dir string, exts ...string) string {
+	quoted := make([]string, 0, len(exts))
+	for _, ext := range exts {
+		quoted = append(quoted, regexp.QuoteMeta(ext))
 	}
-	symbolNode := file.NamedDescendantForPointRange(point, point)
-	if symbolNode == nil {
-		return nil, nil
+	prefix := "^"
+	if dir != "" && dir != "." {
+		prefix += regexp.QuoteMeta(dir) + "/"
 	}
-	ret := swapNode(*file, symbolNode)
-	return &ret, nil
+	return fmt.Sprintf("%s[^/]+\\.(%s)$", prefix, strings.Join(quoted, "|"))
 }
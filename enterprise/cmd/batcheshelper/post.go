@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 	"time"
 
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
@@ -92,22 +93,28 @@ func execPost(ctx context.Context, stepIdx int, executionInput batcheslib.Worksp
 		return errors.Wrap(err, "failed to write step result file")
 	}
 
-	key := cache.KeyForWorkspace(
-		&executionInput.BatchChangeAttributes,
-		batcheslib.Repository{
+	treeHash, err := workspaceTreeHash(ctx, executionInput)
+	if err != nil {
+		return err
+	}
+
+	key := cache.StepKey{
+		Repository: batcheslib.Repository{
 			ID:          executionInput.Repository.ID,
 			Name:        executionInput.Repository.Name,
 			BaseRef:     executionInput.Branch.Name,
 			BaseRev:     executionInput.Branch.Target.OID,
 			FileMatches: executionInput.SearchResultPaths,
 		},
-		executionInput.Path,
-		os.Environ(),
-		executionInput.OnlyFetchWorkspace,
-		executionInput.Steps,
-		stepIdx,
-		nil, // todo: should not be nil.
-	)
+		Path:                  executionInput.Path,
+		OnlyFetchWorkspace:    executionInput.OnlyFetchWorkspace,
+		TreeHash:              treeHash,
+		Step:                  step,
+		PreviousResult:        previousResult,
+		BatchChangeAttributes: &executionInput.BatchChangeAttributes,
+		GlobalEnv:             os.Environ(),
+		MetadataRetriever:     nil, // todo: should not be nil.
+	}
 
 	k, err := key.Key()
 	if err != nil {
@@ -128,6 +135,21 @@ func execPost(ctx context.Context, stepIdx int, executionInput batcheslib.Worksp
 	return nil
 }
 
+// workspaceTreeHash returns the hash of the git tree the steps run in, which is
+// the tree of the workspace path if only the workspace is fetched, and the root
+// tree of the repository otherwise.
+func workspaceTreeHash(ctx context.Context, executionInput batcheslib.WorkspacesExecutionInput) (string, error) {
+	rev := "HEAD^{tree}"
+	if executionInput.OnlyFetchWorkspace {
+		rev = "HEAD:" + executionInput.Path
+	}
+	out, err := runGitCmd(ctx, "git", "rev-parse", rev)
+	if err != nil {
+		return "", errors.Wrapf(err, "git rev-parse %s failed", rev)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 func runGitCmd(ctx context.Context, args ...string) ([]byte, error) {
 	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
 	cmd.Dir = "repository"
@@ -10,6 +10,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/embeddings"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -38,6 +39,7 @@ func searchRepoEmbeddingIndex(
 	opts := embeddings.SearchOptions{
 		Debug:            params.Debug,
 		UseDocumentRanks: params.UseDocumentRanks,
+		ANNProbes:        annProbes(params),
 	}
 
 	var codeResults, textResults []embeddings.EmbeddingSearchResult
@@ -52,6 +54,16 @@ func searchRepoEmbeddingIndex(
 	return &embeddings.EmbeddingSearchResults{CodeResults: codeResults, TextResults: textResults}, nil
 }
 
+// annProbes returns the number of clusters of the approximate nearest neighbor index to search
+// for the request, or 0 if every row should be searched.
+func annProbes(params embeddings.EmbeddingsSearchParameters) int {
+	probes := params.ANNProbes
+	if probes == 0 {
+		probes = conf.EmbeddingsANNProbes()
+	}
+	return max(0, probes)
+}
+
 const SIMILARITY_SEARCH_MIN_ROWS_TO_SPLIT = 1000
 
 func searchEmbeddingIndex(
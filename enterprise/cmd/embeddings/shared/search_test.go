@@ -0,0 +1,36 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/embeddings"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestANNProbes(t *testing.T) {
+	testCases := []struct {
+		name             string
+		configuredProbes int
+		requestedProbes  int
+		want             int
+	}{
+		{name: "default", want: 0},
+		{name: "default requested", requestedProbes: 64, want: 64},
+		{name: "configured", configuredProbes: 8, want: 8},
+		{name: "configured exhaustive", configuredProbes: -1, want: 0},
+		{name: "requested", configuredProbes: 8, requestedProbes: 64, want: 64},
+		{name: "requested exhaustive", configuredProbes: 8, requestedProbes: -1, want: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{Embeddings: &schema.Embeddings{AnnProbes: tc.configuredProbes}}})
+			t.Cleanup(func() { conf.Mock(nil) })
+
+			if got := annProbes(embeddings.EmbeddingsSearchParameters{ANNProbes: tc.requestedProbes}); got != tc.want {
+				t.Errorf("expected %d probes, got %d", tc.want, got)
+			}
+		})
+	}
+}
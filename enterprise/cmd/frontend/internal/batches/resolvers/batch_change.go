@@ -253,7 +253,16 @@ func (r *batchChangeResolver) ChangesetCountsOverTime(
 		end = args.To.Time.UTC()
 	}
 
-	counts, err := state.CalcCounts(start, end, cs, es...)
+	batchSpec, err := r.computeBatchSpec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	order, err := state.LoadChangesetOrder(ctx, r.store, r.batchChange.ID, batchSpec.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := state.CalcCounts(start, end, cs, order, es...)
 	if err != nil {
 		return nil, err
 	}
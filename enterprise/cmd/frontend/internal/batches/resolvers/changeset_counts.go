@@ -20,3 +20,4 @@ func (r *changesetCountsResolver) Open() int32                 { return r.counts
 func (r *changesetCountsResolver) OpenApproved() int32         { return r.counts.OpenApproved }
 func (r *changesetCountsResolver) OpenChangesRequested() int32 { return r.counts.OpenChangesRequested }
 func (r *changesetCountsResolver) OpenPending() int32          { return r.counts.OpenPending }
+func (r *changesetCountsResolver) Blocked() int32              { return r.counts.Blocked }
@@ -1249,7 +1249,7 @@ func (r *Resolver) generateAuthenticatorForCredential(ctx context.Context, exter
 			PublicKey:  keypair.PublicKey,
 			Passphrase: keypair.Passphrase,
 		}
-	} else if externalServiceType == extsvc.TypeAzureDevOps {
+	} else if externalServiceType == extsvc.TypeAzureDevOps || externalServiceType == extsvc.TypeGerrit {
 		a = &extsvcauth.BasicAuthWithSSH{
 			BasicAuth:  extsvcauth.BasicAuth{Username: *username, Password: credential},
 			PrivateKey: keypair.PrivateKey,
@@ -1577,6 +1577,35 @@ func (r *Resolver) PublishChangesets(ctx context.Context, args *graphqlbackend.P
 	return r.bulkOperationByIDString(ctx, bulkGroupID)
 }
 
+func (r *Resolver) RevertChangesets(ctx context.Context, args *graphqlbackend.RevertChangesetsArgs) (_ graphqlbackend.BulkOperationResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.RevertChangesets", fmt.Sprintf("BatchChange: %q, len(Changesets): %d", args.BatchChange, len(args.Changesets)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, changesetIDs, err := unmarshalBulkOperationBaseArgs(args.BulkOperationBaseArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: RevertChangesets checks whether current user is authorized.
+	svc := service.New(r.store)
+	_, bulkGroupID, err := svc.RevertChangesets(ctx, batchChangeID, changesetIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.bulkOperationByIDString(ctx, bulkGroupID)
+}
+
 func (r *Resolver) BatchSpecs(ctx context.Context, args *graphqlbackend.ListBatchSpecArgs) (_ graphqlbackend.BatchSpecConnectionResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.BatchSpecs", fmt.Sprintf("First: %d, After: %v", args.First, args.After))
 	defer func() {
@@ -1740,6 +1769,59 @@ func (r *Resolver) CreateBatchSpecFromRaw(ctx context.Context, args *graphqlback
 	return &batchSpecResolver{store: r.store, batchSpec: batchSpec}, nil
 }
 
+func (r *Resolver) CreateBatchSpecFromComputeReplace(ctx context.Context, args *graphqlbackend.CreateBatchSpecFromComputeReplaceArgs) (_ graphqlbackend.BatchSpecResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateBatchSpecFromComputeReplace", fmt.Sprintf("Namespace: %+v, Query: %q", args.Namespace, args.Query))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := batchChangesCreateAccess(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+		return nil, err
+	}
+
+	opts := service.CreateBatchSpecFromComputeReplaceOpts{
+		Query: args.Query,
+		Name:  args.Name,
+	}
+	if args.Description != nil {
+		opts.Description = *args.Description
+	}
+	if args.Branch != nil {
+		opts.Branch = *args.Branch
+	}
+	if args.CommitMessage != nil {
+		opts.CommitMessage = *args.CommitMessage
+	}
+
+	batchChangesFeature, err := checkLicense()
+	if err != nil {
+		return nil, ErrBatchChangesUnlicensed{err}
+	}
+	if !batchChangesFeature.Unrestricted {
+		opts.MaxChangesets = batchChangesFeature.MaxNumChangesets
+	}
+
+	if err := graphqlbackend.UnmarshalNamespaceID(args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID); err != nil {
+		return nil, err
+	}
+
+	svc := service.New(r.store)
+	batchSpec, err := svc.CreateBatchSpecFromComputeReplace(ctx, opts)
+	if err != nil {
+		if errors.Is(err, service.ErrTooManyComputeReplacements) {
+			return nil, ErrBatchChangesOverLimit{errors.Newf("maximum number of changesets per batch change (%d) exceeded", opts.MaxChangesets)}
+		}
+		return nil, err
+	}
+
+	return &batchSpecResolver{store: r.store, batchSpec: batchSpec}, nil
+}
+
 func (r *Resolver) ExecuteBatchSpec(ctx context.Context, args *graphqlbackend.ExecuteBatchSpecArgs) (_ graphqlbackend.BatchSpecResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.ExecuteBatchSpec", fmt.Sprintf("BatchSpec: %+v", args.BatchSpec))
 	defer func() {
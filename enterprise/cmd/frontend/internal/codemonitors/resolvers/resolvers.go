@@ -14,9 +14,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/background"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
@@ -199,6 +202,44 @@ func (r *Resolver) ToggleCodeMonitor(ctx context.Context, args *graphqlbackend.T
 	return &monitor{r, mo}, nil
 }
 
+func (r *Resolver) SnoozeCodeMonitor(ctx context.Context, args *graphqlbackend.SnoozeCodeMonitorArgs) (graphqlbackend.MonitorResolver, error) {
+	if args.Minutes <= 0 {
+		return nil, errors.New("minutes must be positive")
+	}
+	err := r.isAllowedToEdit(ctx, args.Id)
+	if err != nil {
+		return nil, errors.Errorf("SnoozeMonitor: %w", err)
+	}
+	monitorID, err := unmarshalMonitorID(args.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	until := r.Now().Add(time.Duration(args.Minutes) * time.Minute)
+	mo, err := r.db.CodeMonitors().SnoozeMonitor(ctx, monitorID, &until)
+	if err != nil {
+		return nil, err
+	}
+	return &monitor{r, mo}, nil
+}
+
+func (r *Resolver) UnsnoozeCodeMonitor(ctx context.Context, args *graphqlbackend.UnsnoozeCodeMonitorArgs) (graphqlbackend.MonitorResolver, error) {
+	err := r.isAllowedToEdit(ctx, args.Id)
+	if err != nil {
+		return nil, errors.Errorf("UnsnoozeMonitor: %w", err)
+	}
+	monitorID, err := unmarshalMonitorID(args.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	mo, err := r.db.CodeMonitors().SnoozeMonitor(ctx, monitorID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &monitor{r, mo}, nil
+}
+
 func (r *Resolver) DeleteCodeMonitor(ctx context.Context, args *graphqlbackend.DeleteCodeMonitorArgs) (*graphqlbackend.EmptyResponse, error) {
 	err := r.isAllowedToEdit(ctx, args.Id)
 	if err != nil {
@@ -274,10 +315,11 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 		switch {
 		case a.Email != nil:
 			e, err := r.db.CodeMonitors().CreateEmailAction(ctx, monitorID, &edb.EmailActionArgs{
-				Enabled:        a.Email.Enabled,
-				IncludeResults: a.Email.IncludeResults,
-				Priority:       a.Email.Priority,
-				Header:         a.Email.Header,
+				Enabled:          a.Email.Enabled,
+				IncludeResults:   a.Email.IncludeResults,
+				Priority:         a.Email.Priority,
+				Header:           a.Email.Header,
+				DeliverySchedule: deliverySchedule(a.Email.DeliverySchedule, edb.DeliveryScheduleImmediate),
 			})
 			if err != nil {
 				return err
@@ -295,19 +337,28 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 			if err := validateSlackURL(a.SlackWebhook.URL); err != nil {
 				return err
 			}
-			_, err := r.db.CodeMonitors().CreateSlackWebhookAction(ctx, monitorID, a.SlackWebhook.Enabled, a.SlackWebhook.IncludeResults, a.SlackWebhook.URL)
+			_, err := r.db.CodeMonitors().CreateSlackWebhookAction(ctx, monitorID, a.SlackWebhook.Enabled, a.SlackWebhook.IncludeResults, a.SlackWebhook.URL, deliverySchedule(a.SlackWebhook.DeliverySchedule, edb.DeliveryScheduleImmediate))
+			if err != nil {
+				return err
+			}
+		case a.Issue != nil:
+			repoID, err := r.validateIssueRepository(ctx, a.Issue.Repository)
+			if err != nil {
+				return err
+			}
+			_, err = r.db.CodeMonitors().CreateIssueAction(ctx, monitorID, a.Issue.Enabled, a.Issue.IncludeResults, repoID)
 			if err != nil {
 				return err
 			}
 		default:
-			return errors.New("exactly one of Email, Webhook, or SlackWebhook must be set")
+			return errors.New("exactly one of Email, Webhook, SlackWebhook, or Issue must be set")
 		}
 	}
 	return nil
 }
 
 func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []graphql.ID) error {
-	var email, webhook, slackWebhook []int64
+	var email, webhook, slackWebhook, issue []int64
 	for _, id := range ids {
 		var intID int64
 		err := relay.UnmarshalSpec(id, &intID)
@@ -322,8 +373,10 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 			webhook = append(webhook, intID)
 		case monitorActionSlackWebhookKind:
 			slackWebhook = append(slackWebhook, intID)
+		case monitorActionIssueKind:
+			issue = append(issue, intID)
 		default:
-			return errors.New("action IDs must be exactly one of email, webhook, or slack webhook")
+			return errors.New("action IDs must be exactly one of email, webhook, slack webhook, or issue")
 		}
 	}
 
@@ -339,6 +392,10 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 		return err
 	}
 
+	if err := r.db.CodeMonitors().DeleteIssueActions(ctx, monitorID, issue...); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -450,7 +507,11 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	if err != nil {
 		return nil, err
 	}
-	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions))
+	issueActions, err := r.db.CodeMonitors().ListIssueActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions)+len(issueActions))
 	for _, emailAction := range emailActions {
 		ids = append(ids, (&monitorEmail{EmailAction: emailAction}).ID())
 	}
@@ -460,6 +521,9 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	for _, slackWebhookAction := range slackWebhookActions {
 		ids = append(ids, (&monitorSlackWebhook{SlackWebhookAction: slackWebhookAction}).ID())
 	}
+	for _, issueAction := range issueActions {
+		ids = append(ids, (&monitorIssue{IssueAction: issueAction}).ID())
+	}
 	return ids, nil
 }
 
@@ -504,6 +568,16 @@ func splitActionIDs(args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []grap
 			}
 			toUpdateActions = append(toUpdateActions, a)
 			delete(aMap, *a.SlackWebhook.Id)
+		case a.Issue != nil:
+			if a.Issue.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{Issue: a.Issue.Update})
+				continue
+			}
+			if _, ok := aMap[*a.Issue.Id]; !ok {
+				return nil, nil, errors.Errorf("unknown ID=%s for action", *a.Issue.Id)
+			}
+			toUpdateActions = append(toUpdateActions, a)
+			delete(aMap, *a.Issue.Id)
 		}
 	}
 
@@ -588,8 +662,10 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 				return nil, err
 			}
 			err = r.updateSlackWebhookAction(ctx, *action.SlackWebhook)
+		case action.Issue != nil:
+			err = r.updateIssueAction(ctx, *action.Issue)
 		default:
-			err = errors.New("action must be one of email, webhook, or slack webhook")
+			err = errors.New("action must be one of email, webhook, slack webhook, or issue")
 		}
 		if err != nil {
 			return nil, err
@@ -611,11 +687,17 @@ func (r *Resolver) updateEmailAction(ctx context.Context, args graphqlbackend.Ed
 		return err
 	}
 
+	current, err := r.db.CodeMonitors().GetEmailAction(ctx, emailID)
+	if err != nil {
+		return err
+	}
+
 	e, err := r.db.CodeMonitors().UpdateEmailAction(ctx, emailID, &edb.EmailActionArgs{
-		Enabled:        args.Update.Enabled,
-		IncludeResults: args.Update.IncludeResults,
-		Priority:       args.Update.Priority,
-		Header:         args.Update.Header,
+		Enabled:          args.Update.Enabled,
+		IncludeResults:   args.Update.IncludeResults,
+		Priority:         args.Update.Priority,
+		Header:           args.Update.Header,
+		DeliverySchedule: deliverySchedule(args.Update.DeliverySchedule, current.DeliverySchedule),
 	})
 	if err != nil {
 		return err
@@ -641,7 +723,37 @@ func (r *Resolver) updateSlackWebhookAction(ctx context.Context, args graphqlbac
 		return err
 	}
 
-	_, err = r.db.CodeMonitors().UpdateSlackWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL)
+	current, err := r.db.CodeMonitors().GetSlackWebhookAction(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateSlackWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL, deliverySchedule(args.Update.DeliverySchedule, current.DeliverySchedule))
+	return err
+}
+
+// deliverySchedule returns the delivery schedule set by the input of an action,
+// or current if the input doesn't set one.
+func deliverySchedule(input *string, current string) string {
+	if input == nil {
+		return current
+	}
+	return *input
+}
+
+func (r *Resolver) updateIssueAction(ctx context.Context, args graphqlbackend.EditActionIssueArgs) error {
+	var id int64
+	err := relay.UnmarshalSpec(*args.Id, &id)
+	if err != nil {
+		return err
+	}
+
+	repoID, err := r.validateIssueRepository(ctx, args.Update.Repository)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateIssueAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, repoID)
 	return err
 }
 
@@ -729,9 +841,11 @@ const (
 	monitorActionEmailKind             = "CodeMonitorActionEmail"
 	monitorActionWebhookKind           = "CodeMonitorActionWebhook"
 	monitorActionSlackWebhookKind      = "CodeMonitorActionSlackWebhook"
+	monitorActionIssueKind             = "CodeMonitorActionIssue"
 	monitorActionEmailEventKind        = "CodeMonitorActionEmailEvent"
 	monitorActionWebhookEventKind      = "CodeMonitorActionWebhookEvent"
 	monitorActionSlackWebhookEventKind = "CodeMonitorActionSlackWebhookEvent"
+	monitorActionIssueEventKind        = "CodeMonitorActionIssueEvent"
 	monitorActionEmailRecipientKind    = "CodeMonitorActionEmailRecipient"
 )
 
@@ -789,6 +903,10 @@ func (m *monitor) Enabled() bool {
 	return m.Monitor.Enabled
 }
 
+func (m *monitor) SnoozedUntil() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(m.Monitor.SnoozedUntil)
+}
+
 func (m *monitor) Owner(ctx context.Context) (graphqlbackend.NamespaceResolver, error) {
 	n, err := graphqlbackend.UserByIDInt32(ctx, m.db, m.UserID)
 	return graphqlbackend.NamespaceResolver{Namespace: n}, err
@@ -824,7 +942,12 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 		return nil, err
 	}
 
-	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws))
+	is, err := r.db.CodeMonitors().ListIssueActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws)+len(is))
 	for _, e := range es {
 		actions = append(actions, &action{
 			email: &monitorEmail{
@@ -852,6 +975,15 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 			},
 		})
 	}
+	for _, i := range is {
+		actions = append(actions, &action{
+			issue: &monitorIssue{
+				Resolver:       r,
+				IssueAction:    i,
+				triggerEventID: triggerEventID,
+			},
+		})
+	}
 
 	totalCount := len(actions)
 	if args.After != nil {
@@ -980,6 +1112,9 @@ func (m *monitorTriggerEvent) ResultCount() int32 {
 	for _, cm := range m.TriggerJob.SearchResults {
 		count += cm.ResultCount()
 	}
+	for _, fm := range m.TriggerJob.FileResults {
+		count += fm.ResultCount()
+	}
 	return int32(count)
 }
 
@@ -1028,6 +1163,7 @@ type action struct {
 	email        graphqlbackend.MonitorEmailResolver
 	webhook      graphqlbackend.MonitorWebhookResolver
 	slackWebhook graphqlbackend.MonitorSlackWebhookResolver
+	issue        graphqlbackend.MonitorIssueResolver
 }
 
 func (a *action) ID() graphql.ID {
@@ -1038,6 +1174,8 @@ func (a *action) ID() graphql.ID {
 		return a.webhook.ID()
 	case a.slackWebhook != nil:
 		return a.slackWebhook.ID()
+	case a.issue != nil:
+		return a.issue.ID()
 	default:
 		panic("action must have a type")
 	}
@@ -1055,6 +1193,10 @@ func (a *action) ToMonitorSlackWebhook() (graphqlbackend.MonitorSlackWebhookReso
 	return a.slackWebhook, a.slackWebhook != nil
 }
 
+func (a *action) ToMonitorIssue() (graphqlbackend.MonitorIssueResolver, bool) {
+	return a.issue, a.issue != nil
+}
+
 // Email
 type monitorEmail struct {
 	*Resolver
@@ -1124,6 +1266,10 @@ func (m *monitorEmail) Header() string {
 	return m.EmailAction.Header
 }
 
+func (m *monitorEmail) DeliverySchedule() string {
+	return m.EmailAction.DeliverySchedule
+}
+
 func (m *monitorEmail) ID() graphql.ID {
 	return relay.MarshalID(monitorActionEmailKind, m.EmailAction.ID)
 }
@@ -1240,6 +1386,10 @@ func (m *monitorSlackWebhook) URL() string {
 	return m.SlackWebhookAction.URL
 }
 
+func (m *monitorSlackWebhook) DeliverySchedule() string {
+	return m.SlackWebhookAction.DeliverySchedule
+}
+
 func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
 	after, err := unmarshalAfter(args.After)
 	if err != nil {
@@ -1270,6 +1420,70 @@ func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.L
 	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
 }
 
+type monitorIssue struct {
+	*Resolver
+	*edb.IssueAction
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int32
+}
+
+func (m *monitorIssue) ID() graphql.ID {
+	return relay.MarshalID(monitorActionIssueKind, m.IssueAction.ID)
+}
+
+func (m *monitorIssue) Enabled() bool {
+	return m.IssueAction.Enabled
+}
+
+func (m *monitorIssue) IncludeResults() bool {
+	return m.IssueAction.IncludeResults
+}
+
+func (m *monitorIssue) Repository(ctx context.Context) (*graphqlbackend.RepositoryResolver, error) {
+	repo, err := m.db.Repos().Get(ctx, m.IssueAction.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlbackend.NewRepositoryResolver(m.db, gitserver.NewClient(), repo), nil
+}
+
+func (m *monitorIssue) IssueURL() *string {
+	return m.IssueAction.IssueURL
+}
+
+func (m *monitorIssue) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	ajs, err := m.db.CodeMonitors().ListActionJobs(ctx, edb.ListActionJobsOpts{
+		IssueActionID:  intPtr(int(m.IssueAction.ID)),
+		TriggerEventID: m.triggerEventID,
+		First:          intPtr(int(args.First)),
+		After:          after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := m.db.CodeMonitors().CountActionJobs(ctx, edb.ListActionJobsOpts{
+		IssueActionID:  intPtr(int(m.IssueAction.ID)),
+		TriggerEventID: m.triggerEventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
+}
+
 func intPtr(i int) *int { return &i }
 func intPtrToInt64Ptr(i *int) *int64 {
 	if i == nil {
@@ -1363,3 +1577,22 @@ func validateSlackURL(urlString string) error {
 	}
 	return nil
 }
+
+// validateIssueRepository checks that the repository of an issue action exists
+// and is hosted on a code host we can file issues on.
+func (r *Resolver) validateIssueRepository(ctx context.Context, id graphql.ID) (api.RepoID, error) {
+	repoID, err := graphqlbackend.UnmarshalRepositoryID(id)
+	if err != nil {
+		return 0, err
+	}
+	repo, err := r.db.Repos().Get(ctx, repoID)
+	if err != nil {
+		return 0, err
+	}
+	switch repo.ExternalRepo.ServiceType {
+	case extsvc.TypeGitHub, extsvc.TypeGitLab:
+		return repoID, nil
+	default:
+		return 0, errors.Errorf("issues can only be filed in GitHub and GitLab repositories, but %s is hosted on %s", repo.Name, repo.ExternalRepo.ServiceType)
+	}
+}
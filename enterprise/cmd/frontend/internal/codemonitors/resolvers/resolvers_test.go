@@ -64,6 +64,14 @@ func TestCreateCodeMonitor(t *testing.T) {
 		require.NoError(t, err)
 		require.False(t, got.(*monitor).Monitor.Enabled)
 
+		// Snooze and unsnooze the code monitor.
+		got, err = r.SnoozeCodeMonitor(ctx, &graphqlbackend.SnoozeCodeMonitorArgs{Id: got.ID(), Minutes: 60})
+		require.NoError(t, err)
+		require.True(t, r.Now().Add(time.Hour).Equal(got.SnoozedUntil().Time))
+		got, err = r.UnsnoozeCodeMonitor(ctx, &graphqlbackend.UnsnoozeCodeMonitorArgs{Id: got.ID()})
+		require.NoError(t, err)
+		require.Nil(t, got.SnoozedUntil())
+
 		// Delete code monitor.
 		_, err = r.DeleteCodeMonitor(ctx, &graphqlbackend.DeleteCodeMonitorArgs{Id: got.ID()})
 		require.NoError(t, err)
@@ -6,7 +6,9 @@ import (
 	"context"
 	"encoding/csv"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -49,43 +51,89 @@ func NewExportHandler(db database.DB, insightsDB edb.InsightsDB) *ExportHandler
 	}
 }
 
+// Export formats accepted by the format query parameter. The zip archive is returned by default.
+const (
+	exportFormatZip         = "zip"
+	exportFormatCSV         = "csv"
+	exportFormatOpenMetrics = "openmetrics"
+)
+
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
 func (h *ExportHandler) ExportFunc() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := mux.Vars(r)["id"]
 
-		archive, err := h.exportCodeInsightData(r.Context(), id)
-		if err != nil {
-			if errors.Is(err, notFoundError) {
-				http.Error(w, err.Error(), http.StatusNotFound)
-			} else if errors.Is(err, authenticationError) {
-				http.Error(w, err.Error(), http.StatusUnauthorized)
-			} else if errors.Is(err, invalidLicenseError) {
-				http.Error(w, err.Error(), http.StatusForbidden)
-			} else {
-				http.Error(w, fmt.Sprintf("failed to export data: %v", err), http.StatusInternalServerError)
-			}
+		format := r.URL.Query().Get("format")
+		switch format {
+		case "", exportFormatZip, exportFormatCSV, exportFormatOpenMetrics:
+		default:
+			http.Error(w, fmt.Sprintf("unsupported export format %q", format), http.StatusBadRequest)
 			return
 		}
-		w.Header().Set("Content-Type", "application/zip")
-		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.zip\"", archive.name))
 
-		_, err = w.Write(archive.data)
+		export, err := h.prepareExport(r.Context(), id)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("failed to write data: %v", err), http.StatusInternalServerError)
+			writeExportError(w, err)
+			return
+		}
+
+		switch format {
+		case exportFormatCSV:
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.csv\"", export.name))
+			// The status has been written with the first byte of the response, so errors can only
+			// end the response early.
+			_ = h.writeCSV(r.Context(), w, export)
+		case exportFormatOpenMetrics:
+			w.Header().Set("Content-Type", openMetricsContentType)
+			// A response that ends early is missing the final # EOF, so clients will not accept it.
+			_ = h.writeOpenMetrics(r.Context(), w, export)
+		default:
+			archive, err := h.exportCodeInsightData(r.Context(), export)
+			if err != nil {
+				writeExportError(w, err)
+				return
+			}
+			w.Header().Set("Content-Type", "application/zip")
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.zip\"", archive.name))
+
+			_, err = w.Write(archive.data)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("failed to write data: %v", err), http.StatusInternalServerError)
+			}
 		}
 	}
 }
 
+func writeExportError(w http.ResponseWriter, err error) {
+	if errors.Is(err, notFoundError) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+	} else if errors.Is(err, authenticationError) {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	} else if errors.Is(err, invalidLicenseError) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+	} else {
+		http.Error(w, fmt.Sprintf("failed to export data: %v", err), http.StatusInternalServerError)
+	}
+}
+
 type codeInsightsDataArchive struct {
 	name string
 	data []byte
 }
 
+// insightExport is an export of the data of an insight view that the current user is allowed to see.
+type insightExport struct {
+	name string
+	opts store.ExportOpts
+}
+
 var notFoundError = errors.New("insight not found")
 var authenticationError = errors.New("authentication error")
 var invalidLicenseError = errors.New("invalid license for code insights")
 
-func (h *ExportHandler) exportCodeInsightData(ctx context.Context, id string) (*codeInsightsDataArchive, error) {
+func (h *ExportHandler) prepareExport(ctx context.Context, id string) (*insightExport, error) {
 	currentActor := actor.FromContext(ctx)
 	if !currentActor.IsAuthenticated() {
 		return nil, authenticationError
@@ -130,7 +178,7 @@ func (h *ExportHandler) exportCodeInsightData(ctx context.Context, id string) (*
 		return nil, notFoundError
 	}
 
-	opts := store.ExportOpts{}
+	opts := store.ExportOpts{InsightViewUniqueID: insightViewId}
 	includeRepo := func(regex ...string) {
 		opts.IncludeRepoRegex = append(opts.IncludeRepoRegex, regex...)
 	}
@@ -142,7 +190,7 @@ func (h *ExportHandler) exportCodeInsightData(ctx context.Context, id string) (*
 		includeRepo(*visibleViewSeries[0].DefaultFilterIncludeRepoRegex)
 	}
 	if visibleViewSeries[0].DefaultFilterExcludeRepoRegex != nil {
-		includeRepo(*visibleViewSeries[0].DefaultFilterExcludeRepoRegex)
+		excludeRepo(*visibleViewSeries[0].DefaultFilterExcludeRepoRegex)
 	}
 
 	inc, exc, err := h.searchContextHandler.UnwrapSearchContexts(ctx, visibleViewSeries[0].DefaultFilterSearchContexts)
@@ -152,22 +200,77 @@ func (h *ExportHandler) exportCodeInsightData(ctx context.Context, id string) (*
 	includeRepo(inc...)
 	excludeRepo(exc...)
 
-	var buf bytes.Buffer
-	zw := zip.NewWriter(&buf)
-
 	timestamp := time.Now().Format(time.RFC3339)
 	escapedInsightViewTitle := regexp.MustCompile(`\W+`).ReplaceAllString(visibleViewSeries[0].Title, "-")
-	name := fmt.Sprintf("%s-%s", escapedInsightViewTitle, timestamp)
+	return &insightExport{
+		name: fmt.Sprintf("%s-%s", escapedInsightViewTitle, timestamp),
+		opts: opts,
+	}, nil
+}
+
+func (h *ExportHandler) exportCodeInsightData(ctx context.Context, export *insightExport) (*codeInsightsDataArchive, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
 
-	dataFile, err := zw.Create(fmt.Sprintf("%s.csv", name))
+	dataFile, err := zw.Create(fmt.Sprintf("%s.csv", export.name))
 	if err != nil {
 		return nil, err
 	}
+	if err := h.writeCSV(ctx, dataFile, export); err != nil {
+		return nil, err
+	}
 
-	dataWriter := csv.NewWriter(dataFile)
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return &codeInsightsDataArchive{
+		name: export.name,
+		data: buf.Bytes(),
+	}, nil
+}
+
+func (h *ExportHandler) writeCSV(ctx context.Context, w io.Writer, export *insightExport) error {
+	pw := newCSVPointWriter(w)
+	if err := pw.writeHeader(); err != nil {
+		return errors.Wrap(err, "failed to write csv header")
+	}
+	if err := h.seriesStore.StreamDataForInsightViewID(ctx, export.opts, pw.writePoint); err != nil {
+		return errors.Wrap(err, "failed to fetch all data for insight")
+	}
+	return pw.flush()
+}
 
-	// this needs to be the same number of elements as the number of columns in store.GetAllDataForInsightViewID
-	dataPoint := []string{
+func (h *ExportHandler) writeOpenMetrics(ctx context.Context, w io.Writer, export *insightExport) error {
+	opts := export.opts
+	// Samples of the same series, repository and capture have to be contiguous and in increasing order of time.
+	opts.OrderBySeries = true
+
+	pw := newOpenMetricsPointWriter(w)
+	if err := pw.writeHeader(); err != nil {
+		return err
+	}
+	if err := h.seriesStore.StreamDataForInsightViewID(ctx, opts, pw.writePoint); err != nil {
+		return errors.Wrap(err, "failed to fetch all data for insight")
+	}
+	return pw.writeEOF()
+}
+
+// csvPointWriter writes exported points as CSV records.
+type csvPointWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+func newCSVPointWriter(w io.Writer) *csvPointWriter {
+	return &csvPointWriter{
+		w: csv.NewWriter(w),
+		// this needs to be the same number of elements as the number of columns in store.GetAllDataForInsightViewID
+		record: make([]string, 7),
+	}
+}
+
+func (c *csvPointWriter) writeHeader() error {
+	return c.w.Write([]string{
 		"title",
 		"label",
 		"query",
@@ -175,40 +278,67 @@ func (h *ExportHandler) exportCodeInsightData(ctx context.Context, id string) (*
 		"repository",
 		"value",
 		"capture",
-	}
+	})
+}
 
-	if err := dataWriter.Write(dataPoint); err != nil {
-		return nil, errors.Wrap(err, "failed to write csv header")
-	}
+func (c *csvPointWriter) writePoint(d store.SeriesPointForExport) error {
+	c.record[0] = d.InsightViewTitle
+	c.record[1] = d.SeriesLabel
+	c.record[2] = d.SeriesQuery
+	c.record[3] = d.RecordingTime.String()
+	c.record[4] = emptyStringIfNil(d.RepoName)
+	c.record[5] = fmt.Sprintf("%d", d.Value)
+	c.record[6] = emptyStringIfNil(d.Capture)
+	return c.w.Write(c.record)
+}
 
-	opts.InsightViewUniqueID = insightViewId
-	dataPoints, err := h.seriesStore.GetAllDataForInsightViewID(ctx, opts)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to fetch all data for insight")
-	}
+func (c *csvPointWriter) flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
 
-	for _, d := range dataPoints {
-		dataPoint[0] = d.InsightViewTitle
-		dataPoint[1] = d.SeriesLabel
-		dataPoint[2] = d.SeriesQuery
-		dataPoint[3] = d.RecordingTime.String()
-		dataPoint[4] = emptyStringIfNil(d.RepoName)
-		dataPoint[5] = fmt.Sprintf("%d", d.Value)
-		dataPoint[6] = emptyStringIfNil(d.Capture)
+const openMetricsMetricName = "src_insights_series_value"
 
-		if err := dataWriter.Write(dataPoint); err != nil {
-			return nil, err
-		}
-	}
-	dataWriter.Flush()
+// openMetricsPointWriter writes exported points as samples of an OpenMetrics gauge, with the recording
+// time of each point as the timestamp of its sample.
+type openMetricsPointWriter struct {
+	w io.Writer
+}
 
-	if err := zw.Close(); err != nil {
-		return nil, err
+func newOpenMetricsPointWriter(w io.Writer) *openMetricsPointWriter {
+	return &openMetricsPointWriter{w: w}
+}
+
+func (o *openMetricsPointWriter) writeHeader() error {
+	_, err := fmt.Fprintf(o.w, "# TYPE %[1]s gauge\n# HELP %[1]s Number of results of a code insight series.\n", openMetricsMetricName)
+	return err
+}
+
+func (o *openMetricsPointWriter) writePoint(d store.SeriesPointForExport) error {
+	labels := []string{
+		openMetricsLabel("insight", d.InsightViewTitle),
+		openMetricsLabel("series_id", d.SeriesID),
+		openMetricsLabel("series", d.SeriesLabel),
 	}
-	return &codeInsightsDataArchive{
-		name: name,
-		data: buf.Bytes(),
-	}, nil
+	if d.RepoName != nil {
+		labels = append(labels, openMetricsLabel("repository", *d.RepoName))
+	}
+	if d.Capture != nil {
+		labels = append(labels, openMetricsLabel("capture", *d.Capture))
+	}
+	_, err := fmt.Fprintf(o.w, "%s{%s} %d %d\n", openMetricsMetricName, strings.Join(labels, ","), d.Value, d.RecordingTime.Unix())
+	return err
+}
+
+func (o *openMetricsPointWriter) writeEOF() error {
+	_, err := io.WriteString(o.w, "# EOF\n")
+	return err
+}
+
+var openMetricsLabelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func openMetricsLabel(name, value string) string {
+	return fmt.Sprintf(`%s="%s"`, name, openMetricsLabelValueEscaper.Replace(value))
 }
 
 func emptyStringIfNil(s *string) string {
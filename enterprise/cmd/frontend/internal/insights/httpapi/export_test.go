@@ -0,0 +1,96 @@
+package httpapi
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+)
+
+func TestPointWriters(t *testing.T) {
+	recordingTime := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+	repo := "github.com/sourcegraph/sourcegraph"
+	capture := "go 1.20\n\"quoted\""
+	points := []store.SeriesPointForExport{
+		{
+			InsightViewTitle: "Go versions",
+			SeriesID:         "s1",
+			SeriesLabel:      capture,
+			SeriesQuery:      `go\s(\d+\.\d+)`,
+			RecordingTime:    recordingTime,
+			RepoName:         &repo,
+			Value:            3,
+			Capture:          &capture,
+		},
+		{
+			InsightViewTitle: "Go versions",
+			SeriesID:         "s2",
+			SeriesLabel:      `C:\go`,
+			SeriesQuery:      "lang:go",
+			RecordingTime:    recordingTime.AddDate(0, 1, 0),
+		},
+	}
+
+	tests := []struct {
+		name  string
+		write func(*bytes.Buffer) error
+		want  string
+	}{
+		{
+			name: "csv",
+			write: func(buf *bytes.Buffer) error {
+				pw := newCSVPointWriter(buf)
+				if err := pw.writeHeader(); err != nil {
+					return err
+				}
+				for _, p := range points {
+					if err := pw.writePoint(p); err != nil {
+						return err
+					}
+				}
+				return pw.flush()
+			},
+			want: `title,label,query,recording_time,repository,value,capture
+Go versions,"go 1.20
+""quoted""",go\s(\d+\.\d+),2023-04-01 00:00:00 +0000 UTC,github.com/sourcegraph/sourcegraph,3,"go 1.20
+""quoted"""
+Go versions,C:\go,lang:go,2023-05-01 00:00:00 +0000 UTC,,0,
+`,
+		},
+		{
+			name: "openmetrics",
+			write: func(buf *bytes.Buffer) error {
+				pw := newOpenMetricsPointWriter(buf)
+				if err := pw.writeHeader(); err != nil {
+					return err
+				}
+				for _, p := range points {
+					if err := pw.writePoint(p); err != nil {
+						return err
+					}
+				}
+				return pw.writeEOF()
+			},
+			want: `# TYPE src_insights_series_value gauge
+# HELP src_insights_series_value Number of results of a code insight series.
+src_insights_series_value{insight="Go versions",series_id="s1",series="go 1.20\n\"quoted\"",repository="github.com/sourcegraph/sourcegraph",capture="go 1.20\n\"quoted\""} 3 1680307200
+src_insights_series_value{insight="Go versions",series_id="s2",series="C:\\go"} 0 1682899200
+# EOF
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := test.write(&buf); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, buf.String()); diff != "" {
+				t.Errorf("unexpected output (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/insights/resolvers"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel"
 	internalinsights "github.com/sourcegraph/sourcegraph/enterprise/internal/insights"
+	_ "github.com/sourcegraph/sourcegraph/enterprise/internal/insights/alerts" // registers the alert outbound webhook event type
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/conf/deploy"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -128,6 +128,39 @@ func Test_canAggregateByPath(t *testing.T) {
 	suite.Test_canAggregateBy()
 }
 
+func Test_canAggregateByOwner(t *testing.T) {
+	testCases := []canAggregateTestCase{
+		{
+			name:         "can aggregate for query without parameters",
+			query:        "func(t *testing.T)",
+			canAggregate: true,
+		},
+		{
+			name:         "can aggregate for symbol query",
+			query:        "insights type:symbol",
+			canAggregate: true,
+		},
+		{
+			name:         "cannot aggregate for query with select:repo parameter",
+			query:        "repo:contains.path(README) select:repo",
+			reason:       fmt.Sprintf(ownerUnsupportedFieldValueFmt, "select", "repo"),
+			canAggregate: false,
+		},
+		{
+			name:         "cannot aggregate for query with type:diff parameter",
+			query:        "insights type:diff",
+			reason:       fmt.Sprintf(ownerUnsupportedFieldValueFmt, "type", "diff"),
+			canAggregate: false,
+		},
+	}
+	suite := canAggregateBySuite{
+		canAggregateByFunc: canAggregateByOwner,
+		testCases:          testCases,
+		t:                  t,
+	}
+	suite.Test_canAggregateBy()
+}
+
 func Test_canAggregateByAuthor(t *testing.T) {
 	testCases := []canAggregateTestCase{
 		{
@@ -456,6 +489,13 @@ func Test_buildDrilldownQuery(t *testing.T) {
 			patternType: "standard",
 			mode:        types.PATH_AGGREGATION_MODE,
 		},
+		{
+			want:        autogold.Expect("file:has.owner(@sourcegraph/search) findme"),
+			query:       "findme",
+			drilldown:   "@sourcegraph/search",
+			patternType: "standard",
+			mode:        types.OWNER_AGGREGATION_MODE,
+		},
 		{
 			want:        autogold.Expect("case:yes /fin(?:d m)e/"),
 			query:       "/fin(.*)e/",
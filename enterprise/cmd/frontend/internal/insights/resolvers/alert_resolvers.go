@@ -0,0 +1,222 @@
+package resolvers
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const insightSeriesAlertKind = "InsightSeriesAlert"
+
+func (r *Resolver) InsightSeriesAlerts(ctx context.Context, args *graphqlbackend.InsightSeriesAlertsArgs) ([]graphqlbackend.InsightSeriesAlertResolver, error) {
+	userID := actor.FromContext(ctx).UID
+	if userID == 0 {
+		return nil, auth.ErrNotAuthenticated
+	}
+
+	seriesByID, err := r.loadViewSeries(ctx, args.InsightViewId)
+	if err != nil {
+		return nil, err
+	}
+	if len(seriesByID) == 0 {
+		return []graphqlbackend.InsightSeriesAlertResolver{}, nil
+	}
+	seriesIDs := make([]int, 0, len(seriesByID))
+	for id := range seriesByID {
+		seriesIDs = append(seriesIDs, id)
+	}
+
+	alerts, err := r.alertStore.ListAlerts(ctx, store.ListAlertsArgs{SeriesIDs: seriesIDs, UserID: int(userID)})
+	if err != nil {
+		return nil, errors.Wrap(err, "ListAlerts")
+	}
+	resolvers := make([]graphqlbackend.InsightSeriesAlertResolver, 0, len(alerts))
+	for _, alert := range alerts {
+		resolvers = append(resolvers, &insightSeriesAlertResolver{alert: alert, seriesID: seriesByID[alert.SeriesID].SeriesID})
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) CreateInsightSeriesAlert(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertArgs) (graphqlbackend.InsightSeriesAlertResolver, error) {
+	userID := actor.FromContext(ctx).UID
+	if userID == 0 {
+		return nil, auth.ErrNotAuthenticated
+	}
+
+	input := args.Input
+	alert, err := alertFromInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	seriesByID, err := r.loadViewSeries(ctx, input.InsightViewId)
+	if err != nil {
+		return nil, err
+	}
+	var series types.InsightViewSeries
+	var found bool
+	for _, s := range seriesByID {
+		if s.SeriesID == input.SeriesId {
+			series, found = s, true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.Newf("series %q is not part of this insight", input.SeriesId)
+	}
+	if series.JustInTime {
+		return nil, errors.New("alerts are only supported on series that are recorded in the background")
+	}
+
+	alert.SeriesID = series.InsightSeriesID
+	alert.UserID = int(userID)
+	created, err := r.alertStore.CreateAlert(ctx, alert)
+	if err != nil {
+		return nil, err
+	}
+	return &insightSeriesAlertResolver{alert: created, seriesID: series.SeriesID}, nil
+}
+
+func (r *Resolver) DeleteInsightSeriesAlert(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertArgs) (*graphqlbackend.EmptyResponse, error) {
+	userID := actor.FromContext(ctx).UID
+	if userID == 0 {
+		return nil, auth.ErrNotAuthenticated
+	}
+
+	var alertID int
+	if err := relay.UnmarshalSpec(args.Id, &alertID); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the insight series alert id")
+	}
+
+	// 🚨 SECURITY: users can only delete their own alerts. We return a generic not found error to prevent
+	// leaking the existence of alerts created by other users.
+	alerts, err := r.alertStore.ListAlerts(ctx, store.ListAlertsArgs{ID: alertID, UserID: int(userID)})
+	if err != nil {
+		return nil, errors.Wrap(err, "ListAlerts")
+	}
+	if len(alerts) == 0 {
+		return nil, errors.New("alert not found")
+	}
+
+	if err := r.alertStore.DeleteAlert(ctx, alertID); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+// loadViewSeries returns the series of an insight view keyed by their insight_series ID, after checking that
+// the current user can see the view.
+func (r *Resolver) loadViewSeries(ctx context.Context, id graphql.ID) (map[int]types.InsightViewSeries, error) {
+	var viewID string
+	if err := relay.UnmarshalSpec(id, &viewID); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the insight view id")
+	}
+
+	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
+	if err := permissionsValidator.validateUserAccessForView(ctx, viewID); err != nil {
+		return nil, err
+	}
+
+	insights, err := r.insightStore.GetMapped(ctx, store.InsightQueryArgs{WithoutAuthorization: true, UniqueID: viewID})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetMapped")
+	}
+	if len(insights) != 1 {
+		return nil, errors.New("insight not found")
+	}
+
+	seriesByID := make(map[int]types.InsightViewSeries, len(insights[0].Series))
+	for _, series := range insights[0].Series {
+		seriesByID[series.InsightSeriesID] = series
+	}
+	return seriesByID, nil
+}
+
+func alertFromInput(input graphqlbackend.CreateInsightSeriesAlertInput) (types.InsightSeriesAlert, error) {
+	alert := types.InsightSeriesAlert{
+		Kind:            types.AlertKind(input.Kind),
+		Email:           input.Email,
+		OutboundWebhook: input.OutboundWebhook,
+	}
+	if input.Threshold != nil {
+		alert.Threshold = *input.Threshold
+	}
+	if input.WindowDays != nil {
+		alert.WindowDays = int(*input.WindowDays)
+	}
+
+	switch alert.Kind {
+	case types.ThresholdAlert:
+		if input.Threshold == nil {
+			return alert, errors.New("a threshold is required for THRESHOLD alerts")
+		}
+	case types.PercentChangeAlert:
+		if alert.Threshold <= 0 {
+			return alert, errors.New("a positive threshold is required for PERCENT_CHANGE alerts")
+		}
+		if alert.WindowDays <= 0 {
+			return alert, errors.New("a positive windowDays is required for PERCENT_CHANGE alerts")
+		}
+	case types.NewRepositoryAlert:
+	default:
+		return alert, errors.Newf("unsupported alert kind %q", input.Kind)
+	}
+
+	if input.SlackWebhookURL != nil && *input.SlackWebhookURL != "" {
+		u, err := url.Parse(*input.SlackWebhookURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+			return alert, errors.New("slackWebhookURL must be an HTTP(S) URL")
+		}
+		alert.SlackWebhookURL = input.SlackWebhookURL
+	}
+
+	if !alert.Email && alert.SlackWebhookURL == nil && !alert.OutboundWebhook {
+		return alert, errors.New("at least one of email, slackWebhookURL or outboundWebhook must be enabled")
+	}
+	return alert, nil
+}
+
+var _ graphqlbackend.InsightSeriesAlertResolver = &insightSeriesAlertResolver{}
+
+type insightSeriesAlertResolver struct {
+	alert    types.InsightSeriesAlert
+	seriesID string
+}
+
+func (r *insightSeriesAlertResolver) ID() graphql.ID {
+	return relay.MarshalID(insightSeriesAlertKind, r.alert.ID)
+}
+
+func (r *insightSeriesAlertResolver) SeriesId() string { return r.seriesID }
+
+func (r *insightSeriesAlertResolver) Kind() string { return string(r.alert.Kind) }
+
+func (r *insightSeriesAlertResolver) Threshold() float64 { return r.alert.Threshold }
+
+func (r *insightSeriesAlertResolver) WindowDays() int32 { return int32(r.alert.WindowDays) }
+
+func (r *insightSeriesAlertResolver) Email() bool { return r.alert.Email }
+
+func (r *insightSeriesAlertResolver) SlackWebhookURL() *string { return r.alert.SlackWebhookURL }
+
+func (r *insightSeriesAlertResolver) OutboundWebhook() bool { return r.alert.OutboundWebhook }
+
+func (r *insightSeriesAlertResolver) Firing() bool { return r.alert.Firing }
+
+func (r *insightSeriesAlertResolver) LastFiredAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.alert.LastFiredAt)
+}
+
+func (r *insightSeriesAlertResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.alert.CreatedAt}
+}
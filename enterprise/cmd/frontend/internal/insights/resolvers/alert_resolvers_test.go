@@ -0,0 +1,82 @@
+package resolvers
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestAlertFromInput(t *testing.T) {
+	float := func(v float64) *float64 { return &v }
+	int32Ptr := func(v int32) *int32 { return &v }
+	str := func(v string) *string { return &v }
+
+	testCases := []struct {
+		name    string
+		input   graphqlbackend.CreateInsightSeriesAlertInput
+		want    types.InsightSeriesAlert
+		wantErr string
+	}{
+		{
+			name:  "threshold",
+			input: graphqlbackend.CreateInsightSeriesAlertInput{Kind: "THRESHOLD", Threshold: float(10), Email: true},
+			want:  types.InsightSeriesAlert{Kind: types.ThresholdAlert, Threshold: 10, Email: true},
+		},
+		{
+			name:    "threshold without value",
+			input:   graphqlbackend.CreateInsightSeriesAlertInput{Kind: "THRESHOLD", Email: true},
+			wantErr: "a threshold is required for THRESHOLD alerts",
+		},
+		{
+			name:  "percent change",
+			input: graphqlbackend.CreateInsightSeriesAlertInput{Kind: "PERCENT_CHANGE", Threshold: float(25), WindowDays: int32Ptr(7), OutboundWebhook: true},
+			want:  types.InsightSeriesAlert{Kind: types.PercentChangeAlert, Threshold: 25, WindowDays: 7, OutboundWebhook: true},
+		},
+		{
+			name:    "percent change without window",
+			input:   graphqlbackend.CreateInsightSeriesAlertInput{Kind: "PERCENT_CHANGE", Threshold: float(25), Email: true},
+			wantErr: "a positive windowDays is required for PERCENT_CHANGE alerts",
+		},
+		{
+			name:  "new repository",
+			input: graphqlbackend.CreateInsightSeriesAlertInput{Kind: "NEW_REPOSITORY", SlackWebhookURL: str("https://hooks.slack.com/services/abc")},
+			want:  types.InsightSeriesAlert{Kind: types.NewRepositoryAlert, SlackWebhookURL: str("https://hooks.slack.com/services/abc")},
+		},
+		{
+			name:    "invalid slack webhook",
+			input:   graphqlbackend.CreateInsightSeriesAlertInput{Kind: "NEW_REPOSITORY", SlackWebhookURL: str("hooks.slack.com")},
+			wantErr: "slackWebhookURL must be an HTTP(S) URL",
+		},
+		{
+			name:    "no notification channel",
+			input:   graphqlbackend.CreateInsightSeriesAlertInput{Kind: "NEW_REPOSITORY"},
+			wantErr: "at least one of email, slackWebhookURL or outboundWebhook must be enabled",
+		},
+		{
+			name:    "unknown kind",
+			input:   graphqlbackend.CreateInsightSeriesAlertInput{Kind: "SOMETIMES", Email: true},
+			wantErr: `unsupported alert kind "SOMETIMES"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := alertFromInput(tc.input)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Kind != tc.want.Kind || got.Threshold != tc.want.Threshold || got.WindowDays != tc.want.WindowDays ||
+				got.Email != tc.want.Email || got.OutboundWebhook != tc.want.OutboundWebhook ||
+				(got.SlackWebhookURL == nil) != (tc.want.SlackWebhookURL == nil) {
+				t.Errorf("unexpected alert: want %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/segmentio/ksuid"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/derived"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var _ graphqlbackend.DerivedInsightSeriesResolver = &derivedInsightSeriesResolver{}
+var _ graphqlbackend.InsightSeriesResolver = &derivedSeriesResolver{}
+var _ graphqlbackend.InsightStatusResolver = &derivedStatusResolver{}
+
+// derivedSeriesFromInput validates the derived series of a line chart and converts them to their stored
+// representation. The labels of capture group series are only known once the series has been recorded,
+// so references can only be checked for insights with a fixed set of series.
+func derivedSeriesFromInput(inputs []graphqlbackend.DerivedInsightSeriesInput, dataSeries []graphqlbackend.LineChartSearchInsightDataSeriesInput) ([]types.DerivedInsightSeries, error) {
+	labels := make([]string, 0, len(dataSeries))
+	seen := make(map[string]struct{}, len(dataSeries)+len(inputs))
+	captureGroups := false
+	for _, series := range dataSeries {
+		if isCaptureGroupSeries(series.GeneratedFromCaptureGroups) {
+			captureGroups = true
+		}
+		if label := emptyIfNil(series.Options.Label); label != "" {
+			labels = append(labels, label)
+			seen[label] = struct{}{}
+		}
+	}
+
+	result := make([]types.DerivedInsightSeries, 0, len(inputs))
+	for _, input := range inputs {
+		if input.Label == "" {
+			return nil, errors.New("derived series require a label")
+		}
+		if _, ok := seen[input.Label]; ok {
+			return nil, errors.Newf("series label %q is used more than once", input.Label)
+		}
+		seen[input.Label] = struct{}{}
+
+		expr, err := derived.Parse(input.Expression)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid expression for derived series %q", input.Label)
+		}
+		if !captureGroups {
+			if err := expr.Validate(labels); err != nil {
+				return nil, errors.Wrapf(err, "invalid expression for derived series %q", input.Label)
+			}
+		}
+
+		seriesID := emptyIfNil(input.SeriesId)
+		if seriesID == "" {
+			seriesID = ksuid.New().String()
+		}
+		result = append(result, types.DerivedInsightSeries{
+			SeriesID:   seriesID,
+			Label:      input.Label,
+			Stroke:     emptyIfNil(input.LineColor),
+			Expression: input.Expression,
+		})
+	}
+	return result, nil
+}
+
+func (i *insightViewResolver) loadDerivedSeries(ctx context.Context) ([]types.DerivedInsightSeries, error) {
+	i.derivedOnce.Do(func() {
+		i.derivedSeries, i.derivedErr = i.insightStore.GetDerivedSeries(ctx, i.view.ViewID)
+	})
+	return i.derivedSeries, i.derivedErr
+}
+
+func (i *insightViewResolver) DerivedSeries(ctx context.Context) ([]graphqlbackend.DerivedInsightSeriesResolver, error) {
+	derivedSeries, err := i.loadDerivedSeries(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDerivedSeries")
+	}
+	resolvers := make([]graphqlbackend.DerivedInsightSeriesResolver, 0, len(derivedSeries))
+	for _, series := range derivedSeries {
+		resolvers = append(resolvers, &derivedInsightSeriesResolver{series: series})
+	}
+	return resolvers, nil
+}
+
+// computeDerivedSeries computes the derived series of the view from the points of the given series resolvers,
+// which are matched to the series referenced by each expression by their label.
+func (i *insightViewResolver) computeDerivedSeries(ctx context.Context, resolvers []graphqlbackend.InsightSeriesResolver) ([]graphqlbackend.InsightSeriesResolver, error) {
+	derivedSeries, err := i.loadDerivedSeries(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDerivedSeries")
+	}
+	if len(derivedSeries) == 0 {
+		return nil, nil
+	}
+
+	byLabel := make(map[string]graphqlbackend.InsightSeriesResolver, len(resolvers))
+	for _, resolver := range resolvers {
+		byLabel[resolver.Label()] = resolver
+	}
+
+	derivedResolvers := make([]graphqlbackend.InsightSeriesResolver, 0, len(derivedSeries))
+	for _, series := range derivedSeries {
+		expr, err := derived.Parse(series.Expression)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing expression of derived series %s", series.SeriesID)
+		}
+
+		points := make(map[string][]store.SeriesPoint, len(expr.References()))
+		var statuses []graphqlbackend.InsightStatusResolver
+		for _, label := range expr.References() {
+			resolver, ok := byLabel[label]
+			if !ok {
+				// The referenced series may have been renamed or removed. The derived series is still returned
+				// so that it can be edited, but it has no data.
+				continue
+			}
+			resolverPoints, err := resolver.Points(ctx, nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, point := range resolverPoints {
+				points[label] = append(points[label], store.SeriesPoint{Time: point.DateTime().Time, Value: point.Value()})
+			}
+			status, err := resolver.Status(ctx)
+			if err != nil {
+				return nil, err
+			}
+			statuses = append(statuses, status)
+		}
+
+		computed := derived.Compute(series.SeriesID, expr, points)
+		derivedResolvers = append(derivedResolvers, &derivedSeriesResolver{
+			series: series,
+			points: computed,
+			status: &derivedStatusResolver{sources: statuses, totalPoints: int32(len(computed))},
+		})
+	}
+	return derivedResolvers, nil
+}
+
+type derivedInsightSeriesResolver struct {
+	series types.DerivedInsightSeries
+}
+
+func (d *derivedInsightSeriesResolver) SeriesId() string { return d.series.SeriesID }
+
+func (d *derivedInsightSeriesResolver) Label() string { return d.series.Label }
+
+func (d *derivedInsightSeriesResolver) Expression() string { return d.series.Expression }
+
+func (d *derivedInsightSeriesResolver) LineColor() string { return d.series.Stroke }
+
+type derivedSeriesResolver struct {
+	series types.DerivedInsightSeries
+	points []store.SeriesPoint
+	status *derivedStatusResolver
+}
+
+func (d *derivedSeriesResolver) SeriesId() string { return d.series.SeriesID }
+
+func (d *derivedSeriesResolver) Label() string { return d.series.Label }
+
+func (d *derivedSeriesResolver) Points(ctx context.Context, args *graphqlbackend.InsightsPointsArgs) ([]graphqlbackend.InsightsDataPointResolver, error) {
+	resolvers := make([]graphqlbackend.InsightsDataPointResolver, 0, len(d.points))
+	for _, point := range d.points {
+		resolvers = append(resolvers, insightsDataPointResolver{p: point})
+	}
+	return resolvers, nil
+}
+
+func (d *derivedSeriesResolver) Status(ctx context.Context) (graphqlbackend.InsightStatusResolver, error) {
+	return d.status, nil
+}
+
+// derivedStatusResolver combines the statuses of the series a derived series is computed from.
+type derivedStatusResolver struct {
+	sources     []graphqlbackend.InsightStatusResolver
+	totalPoints int32
+}
+
+func (d *derivedStatusResolver) TotalPoints(ctx context.Context) (int32, error) {
+	return d.totalPoints, nil
+}
+
+func (d *derivedStatusResolver) PendingJobs(ctx context.Context) (int32, error) {
+	return d.sum(ctx, graphqlbackend.InsightStatusResolver.PendingJobs)
+}
+
+func (d *derivedStatusResolver) CompletedJobs(ctx context.Context) (int32, error) {
+	return d.sum(ctx, graphqlbackend.InsightStatusResolver.CompletedJobs)
+}
+
+func (d *derivedStatusResolver) FailedJobs(ctx context.Context) (int32, error) {
+	return d.sum(ctx, graphqlbackend.InsightStatusResolver.FailedJobs)
+}
+
+func (d *derivedStatusResolver) sum(ctx context.Context, get func(graphqlbackend.InsightStatusResolver, context.Context) (int32, error)) (int32, error) {
+	var total int32
+	for _, source := range d.sources {
+		count, err := get(source, ctx)
+		if err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// BackfillQueuedAt returns the latest time any of the source series was queued for backfill.
+func (d *derivedStatusResolver) BackfillQueuedAt(ctx context.Context) *gqlutil.DateTime {
+	var latest *gqlutil.DateTime
+	for _, source := range d.sources {
+		if queuedAt := source.BackfillQueuedAt(ctx); queuedAt != nil && (latest == nil || queuedAt.After(latest.Time)) {
+			latest = queuedAt
+		}
+	}
+	return latest
+}
+
+func (d *derivedStatusResolver) IsLoadingData(ctx context.Context) (*bool, error) {
+	loading := false
+	for _, source := range d.sources {
+		sourceLoading, err := source.IsLoadingData(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if sourceLoading != nil && *sourceLoading {
+			loading = true
+			break
+		}
+	}
+	return &loading, nil
+}
+
+func (d *derivedStatusResolver) IncompleteDatapoints(ctx context.Context) ([]graphqlbackend.IncompleteDatapointAlert, error) {
+	var alerts []graphqlbackend.IncompleteDatapointAlert
+	for _, source := range d.sources {
+		sourceAlerts, err := source.IncompleteDatapoints(ctx)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, sourceAlerts...)
+	}
+	return alerts, nil
+}
@@ -0,0 +1,77 @@
+package resolvers
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+)
+
+func TestDerivedSeriesFromInput(t *testing.T) {
+	str := func(v string) *string { return &v }
+	boolPtr := func(v bool) *bool { return &v }
+	dataSeries := []graphqlbackend.LineChartSearchInsightDataSeriesInput{
+		{Query: "migrated", Options: graphqlbackend.LineChartDataSeriesOptionsInput{Label: str("migrated")}},
+		{Query: "legacy", Options: graphqlbackend.LineChartDataSeriesOptionsInput{Label: str("legacy API")}},
+	}
+
+	testCases := []struct {
+		name       string
+		input      graphqlbackend.DerivedInsightSeriesInput
+		dataSeries []graphqlbackend.LineChartSearchInsightDataSeriesInput
+		wantErr    string
+	}{
+		{
+			name:  "valid",
+			input: graphqlbackend.DerivedInsightSeriesInput{Label: "progress", Expression: `migrated / (migrated + "legacy API") * 100`, LineColor: str("blue")},
+		},
+		{
+			name:    "missing label",
+			input:   graphqlbackend.DerivedInsightSeriesInput{Expression: "migrated * 2"},
+			wantErr: "derived series require a label",
+		},
+		{
+			name:    "duplicate label",
+			input:   graphqlbackend.DerivedInsightSeriesInput{Label: "migrated", Expression: "migrated * 2"},
+			wantErr: `series label "migrated" is used more than once`,
+		},
+		{
+			name:    "syntax error",
+			input:   graphqlbackend.DerivedInsightSeriesInput{Label: "progress", Expression: "migrated /"},
+			wantErr: `invalid expression for derived series "progress": unexpected end of expression`,
+		},
+		{
+			name:    "unknown series",
+			input:   graphqlbackend.DerivedInsightSeriesInput{Label: "progress", Expression: "migrated / legacy"},
+			wantErr: `invalid expression for derived series "progress": expression references unknown series "legacy"`,
+		},
+		{
+			name:  "capture group series",
+			input: graphqlbackend.DerivedInsightSeriesInput{Label: "newer", Expression: `"1.2" + "1.3"`},
+			dataSeries: []graphqlbackend.LineChartSearchInsightDataSeriesInput{
+				{Query: "version: (\\d\\.\\d)", GeneratedFromCaptureGroups: boolPtr(true)},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			series := tc.dataSeries
+			if series == nil {
+				series = dataSeries
+			}
+			got, err := derivedSeriesFromInput([]graphqlbackend.DerivedInsightSeriesInput{tc.input}, series)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 || got[0].SeriesID == "" || got[0].Label != tc.input.Label || got[0].Expression != tc.input.Expression {
+				t.Errorf("unexpected derived series: %+v", got)
+			}
+		})
+	}
+}
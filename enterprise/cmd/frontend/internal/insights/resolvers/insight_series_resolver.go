@@ -198,7 +198,11 @@ func (p *precalculatedInsightSeriesResolver) Points(ctx context.Context, _ *grap
 	// Replacing capture group values if present
 	// Ignoring errors so it falls back to the entered query
 	query := p.series.Query
-	if p.series.GeneratedFromCaptureGroups && len(modifiedPoints) > 0 {
+	if isOwnerGroupBy(p.series.GroupBy) && len(modifiedPoints) > 0 {
+		if filtered, err := querybuilder.AddOwnerFilter(querybuilder.BasicQuery(query), *modifiedPoints[0].Capture); err == nil {
+			query = filtered.String()
+		}
+	} else if p.series.GeneratedFromCaptureGroups && len(modifiedPoints) > 0 {
 		replacer, _ := querybuilder.NewPatternReplacer(querybuilder.BasicQuery(query), searchquery.SearchTypeRegex)
 		if replacer != nil {
 			replaced, err := replacer.Replace(*modifiedPoints[0].Capture)
@@ -55,6 +55,10 @@ type insightViewResolver struct {
 	seriesErr       error
 	totalSeries     int
 	seriesResolvers []graphqlbackend.InsightSeriesResolver
+
+	derivedOnce   sync.Once
+	derivedErr    error
+	derivedSeries []types.DerivedInsightSeries
 }
 
 const insightKind = "insight_view"
@@ -203,14 +207,21 @@ func (i *insightViewResolver) computeDataSeries(ctx context.Context) ([]graphqlb
 			}
 			resolvers = append(resolvers, seriesResolvers...)
 		}
-		i.totalSeries = len(resolvers)
+
+		// Derived series are computed from all series before they are limited, and are always returned.
+		derivedResolvers, err := i.computeDerivedSeries(ctx, resolvers)
+		if err != nil {
+			i.seriesErr = errors.Wrapf(err, "computeDerivedSeries for insightViewID: %s", i.view.UniqueID)
+			return
+		}
+		i.totalSeries = len(resolvers) + len(derivedResolvers)
 
 		sortedAndLimitedResolvers, err := sortSeriesResolvers(ctx, seriesOptions, resolvers)
 		if err != nil {
 			i.seriesErr = errors.Wrapf(err, "sortSeriesResolvers for insightViewID: %s", i.view.UniqueID)
 			return
 		}
-		i.seriesResolvers = sortedAndLimitedResolvers
+		i.seriesResolvers = append(sortedAndLimitedResolvers, derivedResolvers...)
 	})
 
 	return i.seriesResolvers, i.seriesErr
@@ -262,7 +273,11 @@ func (i *insightViewResolver) Presentation(ctx context.Context) (graphqlbackend.
 		pieChartPresentation := &pieChartInsightViewPresentation{view: i.view}
 		return &insightPresentationUnionResolver{resolver: pieChartPresentation}, nil
 	} else {
-		lineChartPresentation := &lineChartInsightViewPresentation{view: i.view}
+		derivedSeries, err := i.loadDerivedSeries(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "GetDerivedSeries")
+		}
+		lineChartPresentation := &lineChartInsightViewPresentation{view: i.view, derivedSeries: derivedSeries}
 		return &insightPresentationUnionResolver{resolver: lineChartPresentation}, nil
 	}
 }
@@ -402,7 +417,8 @@ func (r *reposSearchScope) Search() string        { return r.search }
 func (r *reposSearchScope) AllRepositories() bool { return r.allRepos }
 
 type lineChartInsightViewPresentation struct {
-	view *types.Insight
+	view          *types.Insight
+	derivedSeries []types.DerivedInsightSeries
 }
 
 func (l *lineChartInsightViewPresentation) Title(ctx context.Context) (string, error) {
@@ -415,6 +431,13 @@ func (l *lineChartInsightViewPresentation) SeriesPresentation(ctx context.Contex
 	for i := range l.view.Series {
 		resolvers = append(resolvers, &lineChartDataSeriesPresentationResolver{series: &l.view.Series[i]})
 	}
+	for _, derived := range l.derivedSeries {
+		resolvers = append(resolvers, &lineChartDataSeriesPresentationResolver{series: &types.InsightViewSeries{
+			SeriesID:  derived.SeriesID,
+			Label:     derived.Label,
+			LineColor: derived.Stroke,
+		}})
+	}
 
 	return resolvers, nil
 }
@@ -456,6 +479,14 @@ func (r *Resolver) CreateLineChartSearchInsight(ctx context.Context, args *graph
 		}
 	}
 
+	var derivedSeries []types.DerivedInsightSeries
+	if args.Input.DerivedSeries != nil {
+		derivedSeries, err = derivedSeriesFromInput(*args.Input.DerivedSeries, args.Input.DataSeries)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	uid := actor.FromContext(ctx).UID
 	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
 
@@ -506,6 +537,11 @@ func (r *Resolver) CreateLineChartSearchInsight(ctx context.Context, args *graph
 			return nil, errors.Wrap(err, "createAndAttachSeries")
 		}
 	}
+	if len(derivedSeries) > 0 {
+		if err := insightTx.ReplaceDerivedSeries(ctx, view.ID, derivedSeries); err != nil {
+			return nil, errors.Wrap(err, "ReplaceDerivedSeries")
+		}
+	}
 
 	if len(dashboardIds) > 0 {
 		if args.Input.Dashboards != nil {
@@ -545,6 +581,14 @@ func (r *Resolver) UpdateLineChartSearchInsight(ctx context.Context, args *graph
 		}
 	}
 
+	var derivedSeries []types.DerivedInsightSeries
+	if args.Input.DerivedSeries != nil {
+		derivedSeries, err = derivedSeriesFromInput(*args.Input.DerivedSeries, args.Input.DataSeries)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	tx, err := r.insightStore.Transact(ctx)
 	if err != nil {
 		return nil, err
@@ -613,6 +657,11 @@ func (r *Resolver) UpdateLineChartSearchInsight(ctx context.Context, args *graph
 			return nil, errors.Wrap(err, "updateSearchOrComputeInsight")
 		}
 	}
+	if args.Input.DerivedSeries != nil {
+		if err := tx.ReplaceDerivedSeries(ctx, view.ID, derivedSeries); err != nil {
+			return nil, errors.Wrap(err, "ReplaceDerivedSeries")
+		}
+	}
 
 	return &insightPayloadResolver{baseInsightResolver: r.baseInsightResolver, validator: permissionsValidator, viewId: insightViewId}, nil
 }
@@ -806,6 +855,20 @@ func (r *Resolver) SaveInsightAsNewView(ctx context.Context, args graphqlbackend
 		}
 	}
 
+	derivedSeries, err := insightTx.GetDerivedSeries(ctx, views[0].ViewID)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDerivedSeries")
+	}
+	if len(derivedSeries) > 0 {
+		// Derived series IDs are globally unique, so the copies need new ones.
+		for i := range derivedSeries {
+			derivedSeries[i].SeriesID = ksuid.New().String()
+		}
+		if err := insightTx.ReplaceDerivedSeries(ctx, view.ID, derivedSeries); err != nil {
+			return nil, errors.Wrap(err, "ReplaceDerivedSeries")
+		}
+	}
+
 	if len(dashboardIds) > 0 {
 		if args.Input.Dashboard != nil {
 			err := validateUserDashboardPermissions(ctx, dashboardTx, []graphql.ID{*args.Input.Dashboard}, r.postgresDB.Orgs())
@@ -1234,7 +1297,7 @@ type fillSeriesStrategy func(context.Context, types.InsightSeries) error
 
 func makeFillSeriesStrategy(tx *store.InsightStore, scheduler *scheduler.Scheduler, insightEnqueuer *background.InsightEnqueuer) fillSeriesStrategy {
 	return func(ctx context.Context, series types.InsightSeries) error {
-		if series.GroupBy != nil {
+		if series.GroupBy != nil && !isOwnerGroupBy(series.GroupBy) {
 			return groupBySeriesFill(ctx, series, tx, insightEnqueuer)
 		}
 		return historicFill(ctx, series, tx, scheduler)
@@ -1291,7 +1354,7 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 	groupBy := lowercaseGroupBy(series.GroupBy)
 	var nextRecordingAfter time.Time
 	var oldestHistoricalAt time.Time
-	if series.GroupBy != nil {
+	if series.GroupBy != nil && !isOwnerGroupBy(series.GroupBy) {
 		// We want to disable interval recording for compute types.
 		// December 31, 9999 is the maximum possible date in postgres.
 		nextRecordingAfter = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
@@ -1358,6 +1421,9 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 
 func searchGenerationMethod(series graphqlbackend.LineChartSearchInsightDataSeriesInput) types.GenerationMethod {
 	if series.GeneratedFromCaptureGroups != nil && *series.GeneratedFromCaptureGroups {
+		if isOwnerGroupBy(series.GroupBy) {
+			return types.MappingOwners
+		}
 		if series.GroupBy != nil {
 			return types.MappingCompute
 		}
@@ -1570,6 +1636,13 @@ func lowercaseGroupBy(groupBy *string) *string {
 	return groupBy
 }
 
+// isOwnerGroupBy returns true for series broken down by code owner. Unlike the other group by values these
+// are not computed: they are recorded from regular searches, so they are backfilled and snapshotted like
+// capture group series.
+func isOwnerGroupBy(groupBy *string) bool {
+	return groupBy != nil && querybuilder.MapType(strings.ToLower(*groupBy)) == querybuilder.Owner
+}
+
 func isValidSeriesInput(seriesInput graphqlbackend.LineChartSearchInsightDataSeriesInput) error {
 	if seriesInput.RepositoryScope == nil {
 		return errors.New("a repository scope is required")
@@ -1582,7 +1655,11 @@ func isValidSeriesInput(seriesInput graphqlbackend.LineChartSearchInsightDataSer
 	if repoListSpecified && repoCriteriaSpecified {
 		return errors.New("series can not specify both a repository list and repository critieria")
 	}
-	if !repoListSpecified && seriesInput.GroupBy != nil {
+	if isOwnerGroupBy(seriesInput.GroupBy) {
+		if !isCaptureGroupSeries(seriesInput.GeneratedFromCaptureGroups) {
+			return errors.New("series grouped by owner must be generated from capture groups.")
+		}
+	} else if !repoListSpecified && seriesInput.GroupBy != nil {
 		return errors.New("group by series require a list of repositories to be specified.")
 	}
 
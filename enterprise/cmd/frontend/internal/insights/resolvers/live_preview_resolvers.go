@@ -72,7 +72,13 @@ func (r *Resolver) SearchInsightPreview(ctx context.Context, args graphqlbackend
 		var series []query.GeneratedTimeSeries
 		var err error
 		if seriesArgs.GeneratedFromCaptureGroups {
-			if seriesArgs.GroupBy != nil {
+			if isOwnerGroupBy(seriesArgs.GroupBy) {
+				executor := query.NewOwnerExecutor(r.postgresDB, clock)
+				series, err = executor.Execute(ctx, seriesArgs.Query, repos, interval)
+				if err != nil {
+					return nil, err
+				}
+			} else if seriesArgs.GroupBy != nil {
 				executor := query.NewComputeExecutor(r.postgresDB, clock)
 				series, err = executor.Execute(ctx, seriesArgs.Query, *seriesArgs.GroupBy, repos)
 				if err != nil {
@@ -97,7 +103,11 @@ func (r *Resolver) SearchInsightPreview(ctx context.Context, args graphqlbackend
 			// Replacing capture group values if present
 			// Ignoring errors so it falls back to the entered query
 			seriesQuery := seriesArgs.Query
-			if seriesArgs.GeneratedFromCaptureGroups && len(series[i].Points) > 0 {
+			if isOwnerGroupBy(seriesArgs.GroupBy) {
+				if filtered, err := querybuilder.AddOwnerFilter(querybuilder.BasicQuery(seriesQuery), series[i].Label); err == nil {
+					seriesQuery = filtered.String()
+				}
+			} else if seriesArgs.GeneratedFromCaptureGroups && len(series[i].Points) > 0 {
 				replacer, _ := querybuilder.NewPatternReplacer(querybuilder.BasicQuery(seriesQuery), searchquery.SearchTypeRegex)
 				if replacer != nil {
 					replaced, err := replacer.Replace(series[i].Label)
@@ -205,7 +215,7 @@ func isValidPreviewArgs(args graphqlbackend.SearchInsightPreviewArgs) error {
 
 	if hasRepoCriteria {
 		for i := 0; i < len(args.Input.Series); i++ {
-			if args.Input.Series[i].GroupBy != nil {
+			if args.Input.Series[i].GroupBy != nil && !isOwnerGroupBy(args.Input.Series[i].GroupBy) {
 				return &livePreviewError{Code: invalidArgsErrorCode, Message: "group by insights do not support selecting repositories using a search"}
 			}
 		}
@@ -34,6 +34,7 @@ type baseInsightResolver struct {
 	insightStore    *store.InsightStore
 	timeSeriesStore *store.Store
 	dashboardStore  *store.DBDashboardStore
+	alertStore      *store.AlertStore
 	workerBaseStore *basestore.Store
 	scheduler       *scheduler.Scheduler
 
@@ -46,6 +47,7 @@ func WithBase(insightsDB edb.InsightsDB, primaryDB database.DB, clock func() tim
 	insightStore := store.NewInsightStore(insightsDB)
 	timeSeriesStore := store.NewWithClock(insightsDB, store.NewInsightPermissionStore(primaryDB), clock)
 	dashboardStore := store.NewDashboardStore(insightsDB)
+	alertStore := store.NewAlertStore(insightsDB)
 	insightsScheduler := scheduler.NewScheduler(insightsDB)
 	workerBaseStore := basestore.NewWithHandle(primaryDB.Handle())
 
@@ -53,6 +55,7 @@ func WithBase(insightsDB edb.InsightsDB, primaryDB database.DB, clock func() tim
 		insightStore:    insightStore,
 		timeSeriesStore: timeSeriesStore,
 		dashboardStore:  dashboardStore,
+		alertStore:      alertStore,
 		workerBaseStore: workerBaseStore,
 		scheduler:       insightsScheduler,
 		insightsDB:      insightsDB,
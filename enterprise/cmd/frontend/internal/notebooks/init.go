@@ -16,10 +16,14 @@ func Init(
 	ctx context.Context,
 	observationCtx *observation.Context,
 	db database.DB,
-	_ codeintel.Services,
+	codeIntelServices codeintel.Services,
 	_ conftypes.UnifiedWatchable,
 	enterpriseServices *enterprise.Services,
 ) error {
-	enterpriseServices.NotebooksResolver = resolvers.NewResolver(db)
+	executor, err := resolvers.NewBlockExecutor(db, codeIntelServices.GitserverClient, codeIntelServices.CodenavService, enterpriseServices)
+	if err != nil {
+		return err
+	}
+	enterpriseServices.NotebooksResolver = resolvers.NewResolver(db, executor)
 	return nil
 }
@@ -30,6 +30,27 @@ func BlockToAPIResponse(block notebooks.NotebookBlock) NotebookBlock {
 			SymbolContainerName: block.SymbolInput.SymbolContainerName,
 			SymbolKind:          block.SymbolInput.SymbolKind,
 		}}
+	case notebooks.NotebookComputeBlockType:
+		return NotebookBlock{Typename: "ComputeBlock", ID: block.ID, ComputeInput: block.ComputeInput.Expression}
+	case notebooks.NotebookInsightBlockType:
+		var mode *string
+		if block.InsightInput.Mode != "" {
+			mode = &block.InsightInput.Mode
+		}
+		return NotebookBlock{Typename: "InsightBlock", ID: block.ID, InsightInput: InsightInput{
+			Query:       block.InsightInput.Query,
+			PatternType: block.InsightInput.PatternType,
+			Mode:        mode,
+		}}
+	case notebooks.NotebookReferencesBlockType:
+		return NotebookBlock{Typename: "ReferencesBlock", ID: block.ID, ReferencesInput: ReferencesInput{
+			RepositoryName: block.ReferencesInput.RepositoryName,
+			FilePath:       block.ReferencesInput.FilePath,
+			Revision:       block.ReferencesInput.Revision,
+			Line:           block.ReferencesInput.Line,
+			Character:      block.ReferencesInput.Character,
+			SymbolName:     block.ReferencesInput.SymbolName,
+		}}
 	}
 	panic("unknown block type")
 }
@@ -75,6 +96,27 @@ func BlockToAPIInput(block notebooks.NotebookBlock) graphqlbackend.CreateNoteboo
 			SymbolContainerName: block.SymbolInput.SymbolContainerName,
 			SymbolKind:          block.SymbolInput.SymbolKind,
 		}}
+	case notebooks.NotebookComputeBlockType:
+		return graphqlbackend.CreateNotebookBlockInputArgs{ID: block.ID, Type: graphqlbackend.NotebookComputeBlockType, ComputeInput: &block.ComputeInput.Expression}
+	case notebooks.NotebookInsightBlockType:
+		var mode *string
+		if block.InsightInput.Mode != "" {
+			mode = &block.InsightInput.Mode
+		}
+		return graphqlbackend.CreateNotebookBlockInputArgs{ID: block.ID, Type: graphqlbackend.NotebookInsightBlockType, InsightInput: &graphqlbackend.CreateInsightBlockInput{
+			Query:       block.InsightInput.Query,
+			PatternType: block.InsightInput.PatternType,
+			Mode:        mode,
+		}}
+	case notebooks.NotebookReferencesBlockType:
+		return graphqlbackend.CreateNotebookBlockInputArgs{ID: block.ID, Type: graphqlbackend.NotebookReferencesBlockType, ReferencesInput: &graphqlbackend.CreateReferencesBlockInput{
+			RepositoryName: block.ReferencesInput.RepositoryName,
+			FilePath:       block.ReferencesInput.FilePath,
+			Revision:       block.ReferencesInput.Revision,
+			Line:           block.ReferencesInput.Line,
+			Character:      block.ReferencesInput.Character,
+			SymbolName:     block.ReferencesInput.SymbolName,
+		}}
 	}
 	panic("unknown block type")
 }
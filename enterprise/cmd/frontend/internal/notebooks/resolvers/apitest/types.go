@@ -17,12 +17,15 @@ type NotebookUser struct {
 }
 
 type NotebookBlock struct {
-	Typename      string `json:"__typename"`
-	ID            string
-	MarkdownInput string
-	QueryInput    string
-	FileInput     FileInput
-	SymbolInput   SymbolInput
+	Typename        string `json:"__typename"`
+	ID              string
+	MarkdownInput   string
+	QueryInput      string
+	FileInput       FileInput
+	SymbolInput     SymbolInput
+	ComputeInput    string
+	InsightInput    InsightInput
+	ReferencesInput ReferencesInput
 }
 
 type FileInput struct {
@@ -42,6 +45,21 @@ type SymbolInput struct {
 	SymbolKind          string
 }
 
+type InsightInput struct {
+	Query       string
+	PatternType string
+	Mode        *string
+}
+
+type ReferencesInput struct {
+	RepositoryName string
+	FilePath       string
+	Revision       *string
+	Line           int32
+	Character      int32
+	SymbolName     string
+}
+
 type LineRange struct {
 	StartLine int32
 	EndLine   int32
@@ -55,3 +73,16 @@ type NotebookStar struct {
 type NotebookStarUser struct {
 	Username string
 }
+
+type NotebookRevision struct {
+	Version   int32
+	Title     string
+	Author    NotebookUser
+	CreatedAt string
+}
+
+type NotebookBlockDiff struct {
+	BlockID string
+	Kind    string
+	Moved   bool
+}
@@ -0,0 +1,309 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// BlockExecutor runs executable notebook blocks. The output of a block is stored as JSON in the
+// snapshot of the block.
+type BlockExecutor interface {
+	Execute(ctx context.Context, block notebooks.NotebookBlock) (any, error)
+}
+
+const (
+	// maxComputeBlockResults is the maximum number of compute results stored in a snapshot.
+	maxComputeBlockResults = 1000
+	// insightBlockGroupLimit is the maximum number of aggregation groups stored in a snapshot.
+	insightBlockGroupLimit = 50
+	// maxReferencesBlockLocations is the maximum number of references stored in a snapshot.
+	maxReferencesBlockLocations = 500
+
+	referencesBlockMaxIndexesPerMonikerSearch = 500
+	referencesBlockHunkCacheSize              = 100
+)
+
+type computeBlockOutput struct {
+	Results  []computeBlockResult `json:"results"`
+	LimitHit bool                 `json:"limitHit"`
+}
+
+type computeBlockResult struct {
+	Repository string   `json:"repository"`
+	Commit     string   `json:"commit,omitempty"`
+	Path       string   `json:"path,omitempty"`
+	Kind       string   `json:"kind,omitempty"`
+	Values     []string `json:"values"`
+}
+
+type insightBlockOutput struct {
+	Mode             string              `json:"mode"`
+	Groups           []insightBlockGroup `json:"groups"`
+	OtherResultCount *int32              `json:"otherResultCount,omitempty"`
+	OtherGroupCount  *int32              `json:"otherGroupCount,omitempty"`
+	Exhaustive       bool                `json:"exhaustive"`
+}
+
+type insightBlockGroup struct {
+	Label string  `json:"label"`
+	Count int32   `json:"count"`
+	Query *string `json:"query,omitempty"`
+}
+
+type referencesBlockOutput struct {
+	Commit    string                    `json:"commit"`
+	Locations []referencesBlockLocation `json:"locations"`
+	LimitHit  bool                      `json:"limitHit"`
+}
+
+type referencesBlockLocation struct {
+	Repository     string `json:"repository"`
+	Commit         string `json:"commit"`
+	Path           string `json:"path"`
+	StartLine      int    `json:"startLine"`
+	StartCharacter int    `json:"startCharacter"`
+	EndLine        int    `json:"endLine"`
+	EndCharacter   int    `json:"endCharacter"`
+}
+
+// NewBlockExecutor returns a BlockExecutor that runs compute and insight blocks with the compute
+// and search aggregation resolvers, and references blocks with the code navigation service.
+//
+// The enterprise services are read when a block is executed, as the compute and insights
+// resolvers may be initialized after the notebooks resolver.
+func NewBlockExecutor(db database.DB, gitserverClient gitserver.Client, codenavSvc *codenav.Service, enterpriseServices *enterprise.Services) (BlockExecutor, error) {
+	hunkCache, err := codenav.NewHunkCache(referencesBlockHunkCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return &blockExecutor{
+		db:                 db,
+		gitserverClient:    gitserverClient,
+		codenavSvc:         codenavSvc,
+		hunkCache:          hunkCache,
+		enterpriseServices: enterpriseServices,
+	}, nil
+}
+
+type blockExecutor struct {
+	db                 database.DB
+	gitserverClient    gitserver.Client
+	codenavSvc         *codenav.Service
+	hunkCache          codenav.HunkCache
+	enterpriseServices *enterprise.Services
+}
+
+// 🚨 SECURITY: Blocks are executed as the current user: searches and code navigation only
+// return results from repositories the user has access to.
+func (e *blockExecutor) Execute(ctx context.Context, block notebooks.NotebookBlock) (any, error) {
+	switch block.Type {
+	case notebooks.NotebookComputeBlockType:
+		return e.executeComputeBlock(ctx, *block.ComputeInput)
+	case notebooks.NotebookInsightBlockType:
+		return e.executeInsightBlock(ctx, *block.InsightInput)
+	case notebooks.NotebookReferencesBlockType:
+		return e.executeReferencesBlock(ctx, *block.ReferencesInput)
+	default:
+		return nil, errors.Errorf("block of type %s cannot be executed", block.Type)
+	}
+}
+
+func (e *blockExecutor) executeComputeBlock(ctx context.Context, input notebooks.NotebookComputeBlockInput) (*computeBlockOutput, error) {
+	computeResolver := e.enterpriseServices.ComputeResolver
+	if computeResolver == nil {
+		return nil, errors.New("compute is not available")
+	}
+
+	results, err := computeResolver.Compute(ctx, &graphqlbackend.ComputeArgs{Query: input.Expression})
+	if err != nil {
+		return nil, err
+	}
+
+	output := &computeBlockOutput{Results: []computeBlockResult{}}
+	for _, result := range results {
+		if len(output.Results) == maxComputeBlockResults {
+			output.LimitHit = true
+			break
+		}
+		if matchContext, ok := result.ToComputeMatchContext(); ok {
+			matches := matchContext.Matches()
+			values := make([]string, 0, len(matches))
+			for _, match := range matches {
+				values = append(values, match.Value())
+			}
+			output.Results = append(output.Results, computeBlockResult{
+				Repository: matchContext.Repository().Name(),
+				Commit:     matchContext.Commit(),
+				Path:       matchContext.Path(),
+				Values:     values,
+			})
+		} else if text, ok := result.ToComputeText(); ok {
+			r := computeBlockResult{Values: []string{text.Value()}}
+			if repository := text.Repository(); repository != nil {
+				r.Repository = repository.Name()
+			}
+			if commit := text.Commit(); commit != nil {
+				r.Commit = *commit
+			}
+			if path := text.Path(); path != nil {
+				r.Path = *path
+			}
+			if kind := text.Kind(); kind != nil {
+				r.Kind = *kind
+			}
+			output.Results = append(output.Results, r)
+		}
+	}
+	return output, nil
+}
+
+func (e *blockExecutor) executeInsightBlock(ctx context.Context, input notebooks.NotebookInsightBlockInput) (*insightBlockOutput, error) {
+	aggregationResolver := e.enterpriseServices.InsightsAggregationResolver
+	if aggregationResolver == nil {
+		return nil, errors.New("search aggregations are not available")
+	}
+
+	aggregate, err := aggregationResolver.SearchQueryAggregate(ctx, graphqlbackend.SearchQueryArgs{Query: input.Query, PatternType: input.PatternType})
+	if err != nil {
+		return nil, err
+	}
+	var mode *string
+	if input.Mode != "" {
+		mode = &input.Mode
+	}
+	result, err := aggregate.Aggregations(ctx, graphqlbackend.AggregationsArgs{Mode: mode, Limit: insightBlockGroupLimit})
+	if err != nil {
+		return nil, err
+	}
+
+	if notAvailable, ok := result.ToSearchAggregationNotAvailable(); ok {
+		return nil, errors.Newf("aggregation by %s is not available: %s", notAvailable.Mode(), notAvailable.Reason())
+	}
+
+	output := &insightBlockOutput{}
+	var groups []graphqlbackend.AggregationGroup
+	if exhaustive, ok := result.ToExhaustiveSearchAggregationResult(); ok {
+		output.Exhaustive = true
+		if output.Mode, err = exhaustive.Mode(); err != nil {
+			return nil, err
+		}
+		if groups, err = exhaustive.Groups(); err != nil {
+			return nil, err
+		}
+		if output.OtherResultCount, err = exhaustive.OtherResultCount(); err != nil {
+			return nil, err
+		}
+		if output.OtherGroupCount, err = exhaustive.OtherGroupCount(); err != nil {
+			return nil, err
+		}
+	} else if nonExhaustive, ok := result.ToNonExhaustiveSearchAggregationResult(); ok {
+		if output.Mode, err = nonExhaustive.Mode(); err != nil {
+			return nil, err
+		}
+		if groups, err = nonExhaustive.Groups(); err != nil {
+			return nil, err
+		}
+		if output.OtherResultCount, err = nonExhaustive.OtherResultCount(); err != nil {
+			return nil, err
+		}
+		if output.OtherGroupCount, err = nonExhaustive.ApproximateOtherGroupCount(); err != nil {
+			return nil, err
+		}
+	}
+
+	output.Groups = make([]insightBlockGroup, 0, len(groups))
+	for _, group := range groups {
+		query, err := group.Query()
+		if err != nil {
+			return nil, err
+		}
+		output.Groups = append(output.Groups, insightBlockGroup{Label: group.Label(), Count: group.Count(), Query: query})
+	}
+	return output, nil
+}
+
+func (e *blockExecutor) executeReferencesBlock(ctx context.Context, input notebooks.NotebookReferencesBlockInput) (*referencesBlockOutput, error) {
+	if e.codenavSvc == nil {
+		return nil, errors.New("code navigation is not available")
+	}
+
+	repo, err := e.db.Repos().GetByName(ctx, api.RepoName(input.RepositoryName))
+	if err != nil {
+		return nil, err
+	}
+	revision := "HEAD"
+	if input.Revision != nil && *input.Revision != "" {
+		revision = *input.Revision
+	}
+	commitID, err := e.gitserverClient.ResolveRevision(ctx, repo.Name, revision, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	output := &referencesBlockOutput{Commit: string(commitID), Locations: []referencesBlockLocation{}}
+
+	uploads, err := e.codenavSvc.GetClosestDumpsForBlob(ctx, int(repo.ID), string(commitID), input.FilePath, true, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(uploads) == 0 {
+		// There is no precise code intelligence data for the file.
+		return output, nil
+	}
+
+	requestState := codenav.NewRequestState(
+		uploads,
+		e.db.Repos(),
+		authz.DefaultSubRepoPermsChecker,
+		e.gitserverClient,
+		repo,
+		string(commitID),
+		input.FilePath,
+		referencesBlockMaxIndexesPerMonikerSearch,
+		e.hunkCache,
+	)
+	args := codenav.RequestArgs{
+		RepositoryID: int(repo.ID),
+		Commit:       string(commitID),
+		Path:         input.FilePath,
+		Line:         int(input.Line),
+		Character:    int(input.Character),
+		Limit:        maxReferencesBlockLocations,
+	}
+
+	cursor := codenav.ReferencesCursor{Phase: "local"}
+	for {
+		locations, nextCursor, err := e.codenavSvc.GetReferences(ctx, args, requestState, cursor)
+		if err != nil {
+			return nil, errors.Wrap(err, "GetReferences")
+		}
+		for _, location := range locations {
+			if len(output.Locations) == maxReferencesBlockLocations {
+				output.LimitHit = true
+				return output, nil
+			}
+			output.Locations = append(output.Locations, referencesBlockLocation{
+				Repository:     location.Dump.RepositoryName,
+				Commit:         location.TargetCommit,
+				Path:           location.Path,
+				StartLine:      location.TargetRange.Start.Line,
+				StartCharacter: location.TargetRange.Start.Character,
+				EndLine:        location.TargetRange.End.Line,
+				EndCharacter:   location.TargetRange.End.Character,
+			})
+		}
+		if nextCursor.Phase == "done" {
+			return output, nil
+		}
+		cursor = nextCursor
+	}
+}
@@ -16,12 +16,13 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-func NewResolver(db database.DB) graphqlbackend.NotebooksResolver {
-	return &Resolver{db: db}
+func NewResolver(db database.DB, executor BlockExecutor) graphqlbackend.NotebooksResolver {
+	return &Resolver{db: db, executor: executor}
 }
 
 type Resolver struct {
-	db database.DB
+	db       database.DB
+	executor BlockExecutor
 }
 
 func (r *Resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
@@ -108,6 +109,37 @@ func convertNotebookBlockInput(inputBlock graphqlbackend.CreateNotebookBlockInpu
 			SymbolContainerName: inputBlock.SymbolInput.SymbolContainerName,
 			SymbolKind:          inputBlock.SymbolInput.SymbolKind,
 		}
+	case graphqlbackend.NotebookComputeBlockType:
+		if inputBlock.ComputeInput == nil {
+			return nil, errors.Errorf("compute block with id %s is missing input", inputBlock.ID)
+		}
+		block.Type = notebooks.NotebookComputeBlockType
+		block.ComputeInput = &notebooks.NotebookComputeBlockInput{Expression: *inputBlock.ComputeInput}
+	case graphqlbackend.NotebookInsightBlockType:
+		if inputBlock.InsightInput == nil {
+			return nil, errors.Errorf("insight block with id %s is missing input", inputBlock.ID)
+		}
+		block.Type = notebooks.NotebookInsightBlockType
+		block.InsightInput = &notebooks.NotebookInsightBlockInput{
+			Query:       inputBlock.InsightInput.Query,
+			PatternType: inputBlock.InsightInput.PatternType,
+		}
+		if inputBlock.InsightInput.Mode != nil {
+			block.InsightInput.Mode = *inputBlock.InsightInput.Mode
+		}
+	case graphqlbackend.NotebookReferencesBlockType:
+		if inputBlock.ReferencesInput == nil {
+			return nil, errors.Errorf("references block with id %s is missing input", inputBlock.ID)
+		}
+		block.Type = notebooks.NotebookReferencesBlockType
+		block.ReferencesInput = &notebooks.NotebookReferencesBlockInput{
+			RepositoryName: inputBlock.ReferencesInput.RepositoryName,
+			FilePath:       inputBlock.ReferencesInput.FilePath,
+			Revision:       inputBlock.ReferencesInput.Revision,
+			Line:           inputBlock.ReferencesInput.Line,
+			Character:      inputBlock.ReferencesInput.Character,
+			SymbolName:     inputBlock.ReferencesInput.SymbolName,
+		}
 	default:
 		return nil, errors.Newf("invalid block type: %s", inputBlock.Type)
 	}
@@ -196,6 +228,10 @@ func (r *Resolver) UpdateNotebook(ctx context.Context, args graphqlbackend.Updat
 	}
 	notebook.NamespaceUserID = namespaceUserID
 	notebook.NamespaceOrgID = namespaceOrgID
+	// Without a version precondition the update is based on the version read above.
+	if args.Version != nil {
+		notebook.Version = *args.Version
+	}
 	// Current user has to have write permissions for both the old and the new namespace.
 	err = validateNotebookWritePermissionsForUser(ctx, r.db, notebook, user.ID)
 	if err != nil {
@@ -378,7 +414,7 @@ func (r *notebookResolver) Title(ctx context.Context) string {
 func (r *notebookResolver) Blocks(ctx context.Context) []graphqlbackend.NotebookBlockResolver {
 	blockResolvers := make([]graphqlbackend.NotebookBlockResolver, 0, len(r.notebook.Blocks))
 	for _, block := range r.notebook.Blocks {
-		blockResolvers = append(blockResolvers, &notebookBlockResolver{block})
+		blockResolvers = append(blockResolvers, &notebookBlockResolver{block: block, notebookID: r.notebook.ID, db: r.db})
 	}
 	return blockResolvers
 }
@@ -453,6 +489,10 @@ func (r *notebookResolver) CreatedAt(ctx context.Context) gqlutil.DateTime {
 	return gqlutil.DateTime{Time: r.notebook.CreatedAt}
 }
 
+func (r *notebookResolver) Version() int32 {
+	return r.notebook.Version
+}
+
 func (r *notebookResolver) ViewerCanManage(ctx context.Context) (bool, error) {
 	user, err := r.db.Users().GetByCurrentAuthUser(ctx)
 	if errors.Is(err, database.ErrNoCurrentUser) {
@@ -482,7 +522,9 @@ func (r *notebookResolver) ViewerHasStarred(ctx context.Context) (bool, error) {
 }
 
 type notebookBlockResolver struct {
-	block notebooks.NotebookBlock
+	block      notebooks.NotebookBlock
+	notebookID int64
+	db         database.DB
 }
 
 func (r *notebookBlockResolver) ToMarkdownBlock() (graphqlbackend.MarkdownBlockResolver, bool) {
@@ -513,6 +555,55 @@ func (r *notebookBlockResolver) ToSymbolBlock() (graphqlbackend.SymbolBlockResol
 	return nil, false
 }
 
+func (r *notebookBlockResolver) ToComputeBlock() (graphqlbackend.ComputeBlockResolver, bool) {
+	if r.block.Type == notebooks.NotebookComputeBlockType {
+		return &computeBlockResolver{r}, true
+	}
+	return nil, false
+}
+
+func (r *notebookBlockResolver) ToInsightBlock() (graphqlbackend.InsightBlockResolver, bool) {
+	if r.block.Type == notebooks.NotebookInsightBlockType {
+		return &insightBlockResolver{r}, true
+	}
+	return nil, false
+}
+
+func (r *notebookBlockResolver) ToReferencesBlock() (graphqlbackend.ReferencesBlockResolver, bool) {
+	if r.block.Type == notebooks.NotebookReferencesBlockType {
+		return &referencesBlockResolver{r}, true
+	}
+	return nil, false
+}
+
+// Snapshot returns the snapshot of an executable block, if it was executed with the current input
+// of the block.
+func (r *notebookBlockResolver) Snapshot(ctx context.Context) (graphqlbackend.NotebookBlockSnapshotResolver, error) {
+	snapshot, err := notebooks.Notebooks(r.db).GetNotebookBlockSnapshot(ctx, r.notebookID, r.block.ID)
+	if errors.Is(err, notebooks.ErrNotebookBlockSnapshotNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	inputHash, err := r.block.InputHash()
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.InputHash != inputHash {
+		// The block was edited since it was executed.
+		return nil, nil
+	}
+
+	// 🚨 SECURITY: The snapshot was computed with the permissions of the user who ran the block,
+	// so it is only shown to viewers who can access every repository that user can access.
+	canView, err := canViewNotebookBlockSnapshot(ctx, r.db, snapshot)
+	if err != nil || !canView {
+		return nil, err
+	}
+	return &notebookBlockSnapshotResolver{snapshot: snapshot, db: r.db}, nil
+}
+
 type markdownBlockResolver struct {
 	// block.type == NotebookMarkdownBlockType
 	block notebooks.NotebookBlock
@@ -631,3 +722,89 @@ func (r *symbolBlockInputResolver) SymbolContainerName() string {
 func (r *symbolBlockInputResolver) SymbolKind() string {
 	return r.input.SymbolKind
 }
+
+type computeBlockResolver struct {
+	// block.type == NotebookComputeBlockType
+	*notebookBlockResolver
+}
+
+func (r *computeBlockResolver) ID() string {
+	return r.block.ID
+}
+
+func (r *computeBlockResolver) ComputeInput() string {
+	return r.block.ComputeInput.Expression
+}
+
+type insightBlockResolver struct {
+	// block.type == NotebookInsightBlockType
+	*notebookBlockResolver
+}
+
+func (r *insightBlockResolver) ID() string {
+	return r.block.ID
+}
+
+func (r *insightBlockResolver) InsightInput() graphqlbackend.InsightBlockInputResolver {
+	return &insightBlockInputResolver{*r.block.InsightInput}
+}
+
+type insightBlockInputResolver struct {
+	input notebooks.NotebookInsightBlockInput
+}
+
+func (r *insightBlockInputResolver) Query() string {
+	return r.input.Query
+}
+
+func (r *insightBlockInputResolver) PatternType() string {
+	return r.input.PatternType
+}
+
+func (r *insightBlockInputResolver) Mode() *string {
+	if r.input.Mode == "" {
+		return nil
+	}
+	return &r.input.Mode
+}
+
+type referencesBlockResolver struct {
+	// block.type == NotebookReferencesBlockType
+	*notebookBlockResolver
+}
+
+func (r *referencesBlockResolver) ID() string {
+	return r.block.ID
+}
+
+func (r *referencesBlockResolver) ReferencesInput() graphqlbackend.ReferencesBlockInputResolver {
+	return &referencesBlockInputResolver{*r.block.ReferencesInput}
+}
+
+type referencesBlockInputResolver struct {
+	input notebooks.NotebookReferencesBlockInput
+}
+
+func (r *referencesBlockInputResolver) RepositoryName() string {
+	return r.input.RepositoryName
+}
+
+func (r *referencesBlockInputResolver) FilePath() string {
+	return r.input.FilePath
+}
+
+func (r *referencesBlockInputResolver) Revision() *string {
+	return r.input.Revision
+}
+
+func (r *referencesBlockInputResolver) Line() int32 {
+	return r.input.Line
+}
+
+func (r *referencesBlockInputResolver) Character() int32 {
+	return r.input.Character
+}
+
+func (r *referencesBlockInputResolver) SymbolName() string {
+	return r.input.SymbolName
+}
@@ -16,6 +16,7 @@ import (
 	notebooksapitest "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/notebooks/resolvers/apitest"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -73,6 +74,32 @@ const notebookFields = `
 				symbolKind
 			}
 		}
+		... on ComputeBlock {
+			__typename
+			id
+			computeInput
+		}
+		... on InsightBlock {
+			__typename
+			id
+			insightInput {
+				query
+				patternType
+				mode
+			}
+		}
+		... on ReferencesBlock {
+			__typename
+			id
+			referencesInput {
+				repositoryName
+				filePath
+				revision
+				line
+				character
+				symbolName
+			}
+		}
 	}
 `
 
@@ -145,6 +172,16 @@ func notebookFixture(creatorID int32, namespaceUserID int32, namespaceOrgID int3
 			SymbolContainerName: "container",
 			SymbolKind:          "FUNCTION",
 		}},
+		{ID: "5", Type: notebooks.NotebookComputeBlockType, ComputeInput: &notebooks.NotebookComputeBlockInput{Expression: "content:output((\\w+) -> $1) lang:go"}},
+		{ID: "6", Type: notebooks.NotebookInsightBlockType, InsightInput: &notebooks.NotebookInsightBlockInput{Query: "TODO", PatternType: "literal", Mode: "REPO"}},
+		{ID: "7", Type: notebooks.NotebookReferencesBlockType, ReferencesInput: &notebooks.NotebookReferencesBlockInput{
+			RepositoryName: "github.com/sourcegraph/sourcegraph",
+			FilePath:       "cmd/frontend/main.go",
+			Revision:       &revision,
+			Line:           10,
+			Character:      5,
+			SymbolName:     "main",
+		}},
 	}
 	return &notebooks.Notebook{Title: "Notebook Title", Blocks: blocks, Public: public, CreatorUserID: creatorID, UpdaterUserID: creatorID, NamespaceUserID: namespaceUserID, NamespaceOrgID: namespaceOrgID}
 }
@@ -199,7 +236,7 @@ func TestSingleNotebookCRUD(t *testing.T) {
 		t.Fatalf("Expected no error, got %s", err)
 	}
 
-	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db))
+	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db, nil))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -592,7 +629,7 @@ func TestListNotebooks(t *testing.T) {
 		return ids
 	}
 
-	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db))
+	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db, nil))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -729,7 +766,7 @@ func TestGetNotebookWithSoftDeletedUserColumns(t *testing.T) {
 		t.Fatalf("Expected no error, got %s", err)
 	}
 
-	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db))
+	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db, nil))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -738,3 +775,159 @@ func TestGetNotebookWithSoftDeletedUserColumns(t *testing.T) {
 	var response struct{ Node notebooksapitest.Notebook }
 	apitest.MustExec(actor.WithActor(context.Background(), actor.FromUser(user1.ID)), t, schema, input, &response, queryNotebook)
 }
+
+const runNotebookBlockMutation = `
+mutation RunNotebookBlock($notebook: ID!, $blockID: String!) {
+	runNotebookBlock(notebook: $notebook, blockID: $blockID) {
+		output
+		creator {
+			username
+		}
+	}
+}
+`
+
+const queryNotebookBlockSnapshots = `
+query NotebookBlockSnapshots($id: ID!) {
+	node(id: $id) {
+		... on Notebook {
+			blocks {
+				... on ComputeBlock {
+					id
+					snapshot {
+						output
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+type fakeBlockExecutor struct{}
+
+func (fakeBlockExecutor) Execute(_ context.Context, block notebooks.NotebookBlock) (any, error) {
+	return map[string]string{"expression": block.ComputeInput.Expression}, nil
+}
+
+func TestRunNotebookBlock(t *testing.T) {
+	logger := logtest.Scoped(t)
+	internalCtx := actor.WithInternalActor(context.Background())
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	u := db.Users()
+	n := notebooks.Notebooks(db)
+
+	user1, err := u.Create(internalCtx, database.NewUser{Username: "u1", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	user2, err := u.Create(internalCtx, database.NewUser{Username: "u2", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	createdNotebook, err := n.CreateNotebook(internalCtx, userNotebookFixture(user1.ID, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	notebookGQLID := marshalNotebookID(createdNotebook.ID)
+
+	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db, fakeBlockExecutor{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getComputeBlockSnapshot := func(t *testing.T, viewerID int32) map[string]string {
+		t.Helper()
+		var response struct {
+			Node struct {
+				Blocks []struct {
+					ID       string
+					Snapshot *struct{ Output map[string]string }
+				}
+			}
+		}
+		apitest.MustExec(actor.WithActor(context.Background(), actor.FromUser(viewerID)), t, schema, map[string]any{"id": notebookGQLID}, &response, queryNotebookBlockSnapshots)
+		for _, block := range response.Node.Blocks {
+			if block.ID == "5" && block.Snapshot != nil {
+				return block.Snapshot.Output
+			}
+		}
+		return nil
+	}
+
+	if snapshot := getComputeBlockSnapshot(t, user1.ID); snapshot != nil {
+		t.Fatalf("expected no snapshot before the block is run, got %v", snapshot)
+	}
+
+	t.Run("user cannot run blocks of other users notebooks", func(t *testing.T) {
+		input := map[string]any{"notebook": notebookGQLID, "blockID": "5"}
+		var response struct{ RunNotebookBlock struct{} }
+		gotErrors := apitest.Exec(actor.WithActor(context.Background(), actor.FromUser(user2.ID)), t, schema, input, &response, runNotebookBlockMutation)
+		if len(gotErrors) == 0 || !strings.Contains(gotErrors[0].Message, "user does not match the notebook user namespace") {
+			t.Fatalf("expected permission error, got %v", gotErrors)
+		}
+	})
+
+	t.Run("blocks that are not executable cannot be run", func(t *testing.T) {
+		input := map[string]any{"notebook": notebookGQLID, "blockID": "2"}
+		var response struct{ RunNotebookBlock struct{} }
+		gotErrors := apitest.Exec(actor.WithActor(context.Background(), actor.FromUser(user1.ID)), t, schema, input, &response, runNotebookBlockMutation)
+		if len(gotErrors) == 0 || !strings.Contains(gotErrors[0].Message, "block of type md cannot be executed") {
+			t.Fatalf("expected block type error, got %v", gotErrors)
+		}
+	})
+
+	t.Run("run block and view snapshot", func(t *testing.T) {
+		input := map[string]any{"notebook": notebookGQLID, "blockID": "5"}
+		var response struct {
+			RunNotebookBlock struct {
+				Output  map[string]string
+				Creator notebooksapitest.NotebookUser
+			}
+		}
+		apitest.MustExec(actor.WithActor(context.Background(), actor.FromUser(user1.ID)), t, schema, input, &response, runNotebookBlockMutation)
+
+		want := map[string]string{"expression": createdNotebook.Blocks[4].ComputeInput.Expression}
+		if diff := cmp.Diff(want, response.RunNotebookBlock.Output); diff != "" {
+			t.Fatalf("wrong snapshot output (-want +got):\n%s", diff)
+		}
+		if response.RunNotebookBlock.Creator.Username != user1.Username {
+			t.Fatalf("wrong snapshot creator %q", response.RunNotebookBlock.Creator.Username)
+		}
+
+		// Viewers of the public notebook see the snapshot.
+		if diff := cmp.Diff(want, getComputeBlockSnapshot(t, user2.ID)); diff != "" {
+			t.Fatalf("wrong snapshot output (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("snapshot is not shown to viewers without access to the repositories of its creator", func(t *testing.T) {
+		if err := db.Users().SetIsSiteAdmin(internalCtx, user1.ID, true); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Repos().Create(internalCtx, &types.Repo{Name: "github.com/sourcegraph/private", Private: true}); err != nil {
+			t.Fatal(err)
+		}
+		authz.SetProviders(false, nil)
+		t.Cleanup(func() { authz.SetProviders(true, nil) })
+
+		want := map[string]string{"expression": createdNotebook.Blocks[4].ComputeInput.Expression}
+		if diff := cmp.Diff(want, getComputeBlockSnapshot(t, user1.ID)); diff != "" {
+			t.Fatalf("wrong snapshot output (-want +got):\n%s", diff)
+		}
+		if snapshot := getComputeBlockSnapshot(t, user2.ID); snapshot != nil {
+			t.Fatalf("expected no snapshot for a viewer without access to the private repository, got %v", snapshot)
+		}
+	})
+
+	t.Run("snapshot is not shown once the block is edited", func(t *testing.T) {
+		createdNotebook.Blocks[4].ComputeInput.Expression = "content:output(a -> b)"
+		if _, err := n.UpdateNotebook(internalCtx, createdNotebook); err != nil {
+			t.Fatal(err)
+		}
+		if snapshot := getComputeBlockSnapshot(t, user1.ID); snapshot != nil {
+			t.Fatalf("expected no snapshot for the edited block, got %v", snapshot)
+		}
+	})
+}
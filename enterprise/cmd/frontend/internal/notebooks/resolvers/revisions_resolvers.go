@@ -0,0 +1,245 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
+
+func marshalNotebookRevisionCursor(cursor int64) string {
+	return string(relay.MarshalID("NotebookRevisionCursor", cursor))
+}
+
+func unmarshalNotebookRevisionCursor(cursor *string) (int64, error) {
+	if cursor == nil {
+		return 0, nil
+	}
+	var after int64
+	err := relay.UnmarshalSpec(graphql.ID(*cursor), &after)
+	if err != nil {
+		return -1, err
+	}
+	return after, nil
+}
+
+type notebookRevisionConnectionResolver struct {
+	afterCursor int64
+	revisions   []graphqlbackend.NotebookRevisionResolver
+	totalCount  int32
+	hasNextPage bool
+}
+
+func (n *notebookRevisionConnectionResolver) Nodes() []graphqlbackend.NotebookRevisionResolver {
+	return n.revisions
+}
+
+func (n *notebookRevisionConnectionResolver) TotalCount() int32 {
+	return n.totalCount
+}
+
+func (n *notebookRevisionConnectionResolver) PageInfo() *graphqlutil.PageInfo {
+	if len(n.revisions) == 0 || !n.hasNextPage {
+		return graphqlutil.HasNextPage(false)
+	}
+	// The after value (offset) for the next page is computed from the current after value + the number of retrieved notebook revisions
+	return graphqlutil.NextPageCursor(marshalNotebookRevisionCursor(n.afterCursor + int64(len(n.revisions))))
+}
+
+func (r *notebookResolver) Revisions(ctx context.Context, args graphqlbackend.ListNotebookRevisionsArgs) (graphqlbackend.NotebookRevisionConnectionResolver, error) {
+	afterCursor, err := unmarshalNotebookRevisionCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	// Request one extra to determine if there are more pages
+	pageOpts := notebooks.ListNotebookRevisionsPageOptions{First: args.First + 1, After: afterCursor}
+	store := notebooks.Notebooks(r.db)
+	revisions, err := store.ListNotebookRevisions(ctx, pageOpts, r.notebook.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := store.CountNotebookRevisions(ctx, r.notebook.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasNextPage := false
+	if len(revisions) == int(args.First)+1 {
+		hasNextPage = true
+		revisions = revisions[:len(revisions)-1]
+	}
+
+	revisionResolvers := make([]graphqlbackend.NotebookRevisionResolver, 0, len(revisions))
+	for _, revision := range revisions {
+		revisionResolvers = append(revisionResolvers, &notebookRevisionResolver{revision, r.db})
+	}
+	return &notebookRevisionConnectionResolver{
+		afterCursor: afterCursor,
+		revisions:   revisionResolvers,
+		totalCount:  int32(count),
+		hasNextPage: hasNextPage,
+	}, nil
+}
+
+func (r *notebookResolver) RevisionDiff(ctx context.Context, args graphqlbackend.NotebookRevisionDiffArgs) (graphqlbackend.NotebookRevisionDiffResolver, error) {
+	store := notebooks.Notebooks(r.db)
+	from, err := store.GetNotebookRevision(ctx, r.notebook.ID, args.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := store.GetNotebookRevision(ctx, r.notebook.ID, args.To)
+	if err != nil {
+		return nil, err
+	}
+	return &notebookRevisionDiffResolver{
+		from: &notebookRevisionResolver{from, r.db},
+		to:   &notebookRevisionResolver{to, r.db},
+		diff: notebooks.DiffNotebookBlocks(from.Blocks, to.Blocks),
+	}, nil
+}
+
+func (r *Resolver) RestoreNotebookRevision(ctx context.Context, args graphqlbackend.RestoreNotebookRevisionArgs) (graphqlbackend.NotebookResolver, error) {
+	user, err := r.db.Users().GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalNotebookID(args.Notebook)
+	if err != nil {
+		return nil, err
+	}
+
+	store := notebooks.Notebooks(r.db)
+	notebook, err := store.GetNotebook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateNotebookWritePermissionsForUser(ctx, r.db, notebook, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := store.GetNotebookRevision(ctx, notebook.ID, args.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	// Restoring a revision only changes the title and the blocks, the visibility and the
+	// namespace of the notebook are kept.
+	notebook.Title = revision.Title
+	notebook.Blocks = revision.Blocks
+	notebook.UpdaterUserID = user.ID
+	if args.ExpectedVersion != nil {
+		notebook.Version = *args.ExpectedVersion
+	}
+
+	restoredNotebook, err := store.UpdateNotebook(ctx, notebook)
+	if err != nil {
+		return nil, err
+	}
+	return &notebookResolver{restoredNotebook, r.db}, nil
+}
+
+type notebookRevisionResolver struct {
+	revision *notebooks.NotebookRevision
+	db       database.DB
+}
+
+func (r *notebookRevisionResolver) Version() int32 {
+	return r.revision.Version
+}
+
+func (r *notebookRevisionResolver) Title() string {
+	return r.revision.Title
+}
+
+func (r *notebookRevisionResolver) Blocks() []graphqlbackend.NotebookBlockResolver {
+	blockResolvers := make([]graphqlbackend.NotebookBlockResolver, 0, len(r.revision.Blocks))
+	for _, block := range r.revision.Blocks {
+		blockResolvers = append(blockResolvers, &notebookBlockResolver{block: block, notebookID: r.revision.NotebookID, db: r.db})
+	}
+	return blockResolvers
+}
+
+func (r *notebookRevisionResolver) Author(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.revision.AuthorUserID == 0 {
+		return nil, nil
+	}
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.db, r.revision.AuthorUserID)
+	if err != nil {
+		// Handle soft-deleted users
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *notebookRevisionResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.revision.CreatedAt}
+}
+
+type notebookRevisionDiffResolver struct {
+	from *notebookRevisionResolver
+	to   *notebookRevisionResolver
+	diff []notebooks.NotebookBlockDiff
+}
+
+func (r *notebookRevisionDiffResolver) From() graphqlbackend.NotebookRevisionResolver {
+	return r.from
+}
+
+func (r *notebookRevisionDiffResolver) To() graphqlbackend.NotebookRevisionResolver {
+	return r.to
+}
+
+func (r *notebookRevisionDiffResolver) Blocks() []graphqlbackend.NotebookBlockDiffResolver {
+	blockDiffResolvers := make([]graphqlbackend.NotebookBlockDiffResolver, 0, len(r.diff))
+	for _, blockDiff := range r.diff {
+		blockDiffResolvers = append(blockDiffResolvers, &notebookBlockDiffResolver{blockDiff, r.from.revision.NotebookID, r.from.db})
+	}
+	return blockDiffResolvers
+}
+
+type notebookBlockDiffResolver struct {
+	blockDiff  notebooks.NotebookBlockDiff
+	notebookID int64
+	db         database.DB
+}
+
+func (r *notebookBlockDiffResolver) BlockID() string {
+	return r.blockDiff.BlockID
+}
+
+func (r *notebookBlockDiffResolver) Kind() string {
+	return string(r.blockDiff.Kind)
+}
+
+func (r *notebookBlockDiffResolver) From() graphqlbackend.NotebookBlockResolver {
+	if r.blockDiff.From == nil {
+		return nil
+	}
+	return &notebookBlockResolver{block: *r.blockDiff.From, notebookID: r.notebookID, db: r.db}
+}
+
+func (r *notebookBlockDiffResolver) To() graphqlbackend.NotebookBlockResolver {
+	if r.blockDiff.To == nil {
+		return nil
+	}
+	return &notebookBlockResolver{block: *r.blockDiff.To, notebookID: r.notebookID, db: r.db}
+}
+
+func (r *notebookBlockDiffResolver) Moved() bool {
+	return r.blockDiff.Moved
+}
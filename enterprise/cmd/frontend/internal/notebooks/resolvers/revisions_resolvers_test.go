@@ -0,0 +1,209 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/resolvers/apitest"
+	notebooksapitest "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/notebooks/resolvers/apitest"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+const notebookRevisionFields = `
+	version
+	title
+	author {
+		username
+	}
+	createdAt
+`
+
+const updateNotebookWithVersionMutation = `
+mutation UpdateNotebook($id: ID!, $notebook: NotebookInput!, $version: Int) {
+	updateNotebook(id: $id, notebook: $notebook, version: $version) {
+		version
+		title
+	}
+}
+`
+
+const restoreNotebookRevisionMutation = `
+mutation RestoreNotebookRevision($notebook: ID!, $version: Int!, $expectedVersion: Int) {
+	restoreNotebookRevision(notebook: $notebook, version: $version, expectedVersion: $expectedVersion) {
+		version
+		title
+	}
+}
+`
+
+var listNotebookRevisionsQuery = fmt.Sprintf(`
+query NotebookRevisions($id: ID!, $first: Int!, $after: String) {
+	node(id: $id) {
+		... on Notebook {
+			revisions(first: $first, after: $after) {
+				nodes {
+					%s
+				}
+				pageInfo {
+					endCursor
+					hasNextPage
+				}
+				totalCount
+			}
+		}
+	}
+}
+`, notebookRevisionFields)
+
+const notebookRevisionDiffQuery = `
+query NotebookRevisionDiff($id: ID!, $from: Int!, $to: Int!) {
+	node(id: $id) {
+		... on Notebook {
+			revisionDiff(from: $from, to: $to) {
+				blocks {
+					blockID
+					kind
+					moved
+				}
+			}
+		}
+	}
+}
+`
+
+type updatedNotebookResponse struct {
+	Version int32
+	Title   string
+}
+
+func TestNotebookRevisions(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	internalCtx := actor.WithInternalActor(context.Background())
+	u := db.Users()
+
+	user1, err := u.Create(internalCtx, database.NewUser{Username: "u1", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	user1Ctx := actor.WithActor(context.Background(), actor.FromUser(user1.ID))
+
+	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createdNotebook := createNotebooks(t, db, []*notebooks.Notebook{userNotebookFixture(user1.ID, true)})[0]
+	notebookID := marshalNotebookID(createdNotebook.ID)
+
+	updateNotebook := func(t *testing.T, title string, blocks notebooks.NotebookBlocks, version *int32) (updatedNotebookResponse, []string) {
+		t.Helper()
+		notebook := *createdNotebook
+		notebook.Title = title
+		notebook.Blocks = blocks
+		input := map[string]any{"id": notebookID, "notebook": notebooksapitest.NotebookToAPIInput(&notebook), "version": version}
+		var response struct{ UpdateNotebook updatedNotebookResponse }
+		errs := apitest.Exec(user1Ctx, t, schema, input, &response, updateNotebookWithVersionMutation)
+		messages := make([]string, 0, len(errs))
+		for _, err := range errs {
+			messages = append(messages, err.Message)
+		}
+		return response.UpdateNotebook, messages
+	}
+
+	version := func(v int32) *int32 { return &v }
+
+	// Version 2 removes the second block and moves the first block to the end.
+	blocks := createdNotebook.Blocks
+	v2Blocks := append(append(notebooks.NotebookBlocks{}, blocks[2:]...), blocks[0])
+	updated, errs := updateNotebook(t, "Version 2", v2Blocks, version(1))
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	if updated.Version != 2 {
+		t.Fatalf("expected version 2, got %d", updated.Version)
+	}
+
+	// An update based on the first version is rejected.
+	_, errs = updateNotebook(t, "Stale update", blocks, version(1))
+	if len(errs) != 1 || !strings.Contains(errs[0], notebooks.ErrNotebookVersionConflict.Error()) {
+		t.Fatalf("expected version conflict error, got %v", errs)
+	}
+
+	// Without a version the update is based on the current version.
+	updated, errs = updateNotebook(t, "Version 3", v2Blocks, nil)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	if updated.Version != 3 {
+		t.Fatalf("expected version 3, got %d", updated.Version)
+	}
+
+	var diffResponse struct {
+		Node struct {
+			RevisionDiff struct {
+				Blocks []notebooksapitest.NotebookBlockDiff
+			}
+		}
+	}
+	apitest.MustExec(user1Ctx, t, schema, map[string]any{"id": notebookID, "from": 1, "to": 2}, &diffResponse, notebookRevisionDiffQuery)
+	wantDiff := []notebooksapitest.NotebookBlockDiff{
+		{BlockID: "3", Kind: "UNCHANGED"},
+		{BlockID: "4", Kind: "UNCHANGED"},
+		{BlockID: "5", Kind: "UNCHANGED"},
+		{BlockID: "6", Kind: "UNCHANGED"},
+		{BlockID: "7", Kind: "UNCHANGED"},
+		{BlockID: "1", Kind: "UNCHANGED", Moved: true},
+		// Removed blocks follow the block that preceded them in the old revision.
+		{BlockID: "2", Kind: "REMOVED"},
+	}
+	if diff := cmp.Diff(wantDiff, diffResponse.Node.RevisionDiff.Blocks); diff != "" {
+		t.Fatalf("wrong revision diff (-want +got):\n%s", diff)
+	}
+
+	// Restoring a revision with a stale expected version is rejected.
+	input := map[string]any{"notebook": notebookID, "version": 1, "expectedVersion": 2}
+	var restoreResponse struct{ RestoreNotebookRevision updatedNotebookResponse }
+	if errs := apitest.Exec(user1Ctx, t, schema, input, &restoreResponse, restoreNotebookRevisionMutation); len(errs) == 0 {
+		t.Fatal("expected error when restoring a revision with a stale version, got none")
+	}
+
+	input["expectedVersion"] = 3
+	apitest.MustExec(user1Ctx, t, schema, input, &restoreResponse, restoreNotebookRevisionMutation)
+	if want := (updatedNotebookResponse{Version: 4, Title: createdNotebook.Title}); restoreResponse.RestoreNotebookRevision != want {
+		t.Fatalf("expected restored notebook %+v, got %+v", want, restoreResponse.RestoreNotebookRevision)
+	}
+
+	var listResponse struct {
+		Node struct {
+			Revisions struct {
+				Nodes      []notebooksapitest.NotebookRevision
+				TotalCount int32
+				PageInfo   apitest.PageInfo
+			}
+		}
+	}
+	apitest.MustExec(user1Ctx, t, schema, map[string]any{"id": notebookID, "first": 3}, &listResponse, listNotebookRevisionsQuery)
+	revisions := listResponse.Node.Revisions
+	if revisions.TotalCount != 4 || !revisions.PageInfo.HasNextPage {
+		t.Fatalf("expected 4 revisions with a next page, got %d revisions, has next page %t", revisions.TotalCount, revisions.PageInfo.HasNextPage)
+	}
+	gotRevisions := make([]string, 0, len(revisions.Nodes))
+	for _, revision := range revisions.Nodes {
+		gotRevisions = append(gotRevisions, fmt.Sprintf("%d:%s:%s", revision.Version, revision.Title, revision.Author.Username))
+	}
+	wantRevisions := []string{"4:" + createdNotebook.Title + ":u1", "3:Version 3:u1", "2:Version 2:u1"}
+	if diff := cmp.Diff(wantRevisions, gotRevisions); diff != "" {
+		t.Fatalf("wrong revisions (-want +got):\n%s", diff)
+	}
+}
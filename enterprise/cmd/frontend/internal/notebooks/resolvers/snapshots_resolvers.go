@@ -0,0 +1,150 @@
+package resolvers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func (r *Resolver) RunNotebookBlock(ctx context.Context, args graphqlbackend.RunNotebookBlockArgs) (graphqlbackend.NotebookBlockSnapshotResolver, error) {
+	user, err := r.db.Users().GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalNotebookID(args.Notebook)
+	if err != nil {
+		return nil, err
+	}
+
+	store := notebooks.Notebooks(r.db)
+	notebook, err := store.GetNotebook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only users who can update the notebook can replace the output shown to its viewers.
+	err = validateNotebookWritePermissionsForUser(ctx, r.db, notebook, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var block *notebooks.NotebookBlock
+	for i := range notebook.Blocks {
+		if notebook.Blocks[i].ID == args.BlockID {
+			block = &notebook.Blocks[i]
+			break
+		}
+	}
+	if block == nil {
+		return nil, errors.Errorf("notebook has no block with id %s", args.BlockID)
+	}
+	if !block.Type.IsExecutable() {
+		return nil, errors.Errorf("block of type %s cannot be executed", block.Type)
+	}
+	if r.executor == nil {
+		return nil, errors.New("notebook blocks cannot be executed")
+	}
+
+	inputHash, err := block.InputHash()
+	if err != nil {
+		return nil, err
+	}
+	output, err := r.executor.Execute(ctx, *block)
+	if err != nil {
+		return nil, errors.Wrapf(err, "executing block %s", block.ID)
+	}
+	rawOutput, err := json.Marshal(output)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := store.UpsertNotebookBlockSnapshot(ctx, &notebooks.NotebookBlockSnapshot{
+		NotebookID:    notebook.ID,
+		BlockID:       block.ID,
+		InputHash:     inputHash,
+		Output:        rawOutput,
+		CreatorUserID: user.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &notebookBlockSnapshotResolver{snapshot: snapshot, db: r.db}, nil
+}
+
+const creatorOnlyRepoExistsFmtStr = `
+SELECT EXISTS (
+	SELECT 1
+	FROM repo
+	WHERE
+		repo.deleted_at IS NULL
+		AND %s -- creator authz conds
+		AND NOT %s -- viewer authz conds
+)
+`
+
+// canViewNotebookBlockSnapshot returns true if the current user can access every repository that
+// the creator of the snapshot can access. Snapshots can include results from any of these
+// repositories, but don't record which ones, so other viewers must not see them.
+func canViewNotebookBlockSnapshot(ctx context.Context, db database.DB, snapshot *notebooks.NotebookBlockSnapshot) (bool, error) {
+	viewer, err := database.GetAuthzQueryParameters(ctx, db)
+	if err != nil {
+		return false, err
+	}
+	if viewer.BypassAuthz || (viewer.AuthenticatedUserID != 0 && viewer.AuthenticatedUserID == snapshot.CreatorUserID) {
+		return true, nil
+	}
+	if snapshot.CreatorUserID == 0 {
+		// The permissions of a deleted creator are unknown.
+		return false, nil
+	}
+
+	creatorConds, err := database.AuthzQueryConds(actor.WithActor(ctx, actor.FromUser(snapshot.CreatorUserID)), db)
+	if err != nil {
+		return false, err
+	}
+	q := sqlf.Sprintf(creatorOnlyRepoExistsFmtStr, creatorConds, viewer.ToAuthzQuery())
+
+	var exists bool
+	if err := db.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(&exists); err != nil {
+		return false, err
+	}
+	return !exists, nil
+}
+
+type notebookBlockSnapshotResolver struct {
+	snapshot *notebooks.NotebookBlockSnapshot
+	db       database.DB
+}
+
+func (r *notebookBlockSnapshotResolver) Output() graphqlbackend.JSONValue {
+	return graphqlbackend.JSONValue{Value: json.RawMessage(r.snapshot.Output)}
+}
+
+func (r *notebookBlockSnapshotResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.snapshot.CreatorUserID == 0 {
+		return nil, nil
+	}
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.db, r.snapshot.CreatorUserID)
+	if err != nil {
+		// Handle soft-deleted users
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *notebookBlockSnapshotResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.snapshot.CreatedAt}
+}
@@ -5,9 +5,12 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/scheduler"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
@@ -35,6 +38,15 @@ func (j *schedulerJob) Routines(_ context.Context, observationCtx *observation.C
 
 	routines := []goroutine.BackgroundRoutine{
 		scheduler.NewScheduler(workCtx, bstore),
+		scheduler.NewAutoMerger(
+			workCtx,
+			observationCtx.Logger.Scoped("auto-merger", "merges changesets that satisfy their batch change's auto-merge policy"),
+			bstore,
+			gitserver.NewClient(),
+			sources.NewSourcer(httpcli.NewExternalClientFactory(
+				httpcli.NewLoggingMiddleware(observationCtx.Logger.Scoped("sourcer", "batches sourcer")),
+			)),
+		),
 	}
 
 	return routines, nil
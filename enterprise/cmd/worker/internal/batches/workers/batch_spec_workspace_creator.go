@@ -18,8 +18,11 @@ import (
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
@@ -31,8 +34,9 @@ import (
 // batchSpecWorkspaceCreator takes in BatchSpecs, resolves them into
 // RepoWorkspaces and then persists those as pending BatchSpecWorkspaces.
 type batchSpecWorkspaceCreator struct {
-	store  *store.Store
-	logger log.Logger
+	store           *store.Store
+	gitserverClient gitserver.Client
+	logger          log.Logger
 }
 
 // HandlerFunc returns a workerutil.HandlerFunc that can be passed to a
@@ -57,6 +61,15 @@ type workspaceCacheKey struct {
 	repo          batcheslib.Repository
 	stepCacheKeys []stepCacheKey
 	skippedSteps  map[int]struct{}
+	// treeHash is the hash of the git tree the steps run in, which is empty if
+	// it couldn't be determined.
+	treeHash string
+
+	// cachedSteps is the number of steps, from the start of stepCacheKeys, for
+	// which a cached result has been found.
+	cachedSteps int
+	// previousResult is the cached result of the last of those steps.
+	previousResult execution.AfterStepResult
 }
 
 // process runs one workspace creation run for the given job utilizing the given
@@ -115,7 +128,7 @@ func (r *batchSpecWorkspaceCreator) process(
 	// Build DB workspaces and check for cache entries.
 	ws := make([]*btypes.BatchSpecWorkspace, 0, len(workspaces))
 	// Collect all cache keys so we can look them up in a single query.
-	cacheKeyWorkspaces := make([]workspaceCacheKey, 0, len(workspaces))
+	cacheKeyWorkspaces := make([]*workspaceCacheKey, 0, len(workspaces))
 	allStepCacheKeys := make([]string, 0, len(workspaces))
 	// load the mounts from the DB up front to avoid duplicate calls with no difference in data
 	mounts, err := listBatchSpecMounts(ctx, r.store, spec.ID)
@@ -193,11 +206,12 @@ func (r *batchSpecWorkspaceCreator) process(
 			allStepCacheKeys = append(allStepCacheKeys, rawStepKey)
 		}
 
-		cacheKeyWorkspaces = append(cacheKeyWorkspaces, workspaceCacheKey{
+		cacheKeyWorkspaces = append(cacheKeyWorkspaces, &workspaceCacheKey{
 			dbWorkspace:   workspace,
 			repo:          repo,
 			stepCacheKeys: stepCacheKeys,
 			skippedSteps:  skippedSteps,
+			treeHash:      r.workspaceTreeHash(ctx, w),
 		})
 	}
 
@@ -217,8 +231,6 @@ func (r *batchSpecWorkspaceCreator) process(
 
 	// All changeset specs to be created.
 	cs := []*btypes.ChangesetSpec{}
-	// Collect all IDs of used cache entries to mark them as recently used later.
-	usedCacheEntries := []int64{}
 	changesetsByWorkspace := make(map[*btypes.BatchSpecWorkspace][]*btypes.ChangesetSpec)
 
 	changesetAuthor, err := author.GetChangesetAuthorForUser(ctx, database.UsersWith(r.logger, r.store), spec.UserID)
@@ -226,27 +238,14 @@ func (r *batchSpecWorkspaceCreator) process(
 		return err
 	}
 
-	// Check for an existing cache entry for each of the workspaces.
-	for _, workspace := range cacheKeyWorkspaces {
-		for _, ck := range workspace.stepCacheKeys {
-			key := ck.key
-			idx := ck.index
-			if c, ok := stepEntriesByCacheKey[key]; ok {
-				var res execution.AfterStepResult
-				if err := json.Unmarshal([]byte(c.Value), &res); err != nil {
-					return err
-				}
-				workspace.dbWorkspace.SetStepCacheResult(idx+1, btypes.StepCacheResult{Key: key, Value: &res})
-
-				// Mark the cache entry as used.
-				usedCacheEntries = append(usedCacheEntries, c.ID)
-			} else {
-				// Only add cache entries up until we don't have the cache entry
-				// for the previous step anymore.
-				break
-			}
-		}
+	// Check for an existing cache entry for each of the workspaces, and collect
+	// the IDs of the used cache entries to mark them as recently used later.
+	usedCacheEntries, err := r.findCachedSteps(ctx, spec, envVars, retriever, cacheKeyWorkspaces, stepEntriesByCacheKey)
+	if err != nil {
+		return err
+	}
 
+	for _, workspace := range cacheKeyWorkspaces {
 		// Validate there is anything to run. If not, we skip execution.
 		// TODO: In the future, move this to a separate field, so we can
 		// tell the two cases apart.
@@ -336,6 +335,134 @@ func (r *batchSpecWorkspaceCreator) process(
 	return tx.CreateBatchSpecWorkspace(ctx, ws...)
 }
 
+// findCachedSteps finds the cached results of the steps of the given
+// workspaces, and sets them on the workspaces. Results are only used up until
+// the first step without a cached result. For every step, the results cached
+// under the content-addressed step key, which can be shared across batch specs
+// and users, are used if there are any. Otherwise, the results cached under the
+// given cache keys of the user are used. The IDs of the used cache entries are
+// returned.
+func (r *batchSpecWorkspaceCreator) findCachedSteps(
+	ctx context.Context,
+	spec *btypes.BatchSpec,
+	envVars []string,
+	retriever cache.MetadataRetriever,
+	workspaces []*workspaceCacheKey,
+	entriesByCacheKey map[string]*btypes.BatchSpecExecutionCacheEntry,
+) (usedCacheEntries []int64, err error) {
+	useEntry := func(workspace *workspaceCacheKey, key string, entry *btypes.BatchSpecExecutionCacheEntry) error {
+		idx := workspace.stepCacheKeys[workspace.cachedSteps].index
+
+		var res execution.AfterStepResult
+		if err := json.Unmarshal([]byte(entry.Value), &res); err != nil {
+			return err
+		}
+		// Step results can be reused for steps at a different index in
+		// another batch spec.
+		res.StepIndex = idx
+		workspace.dbWorkspace.SetStepCacheResult(idx+1, btypes.StepCacheResult{Key: key, Value: &res})
+
+		workspace.cachedSteps++
+		workspace.previousResult = res
+		// Mark the cache entry as used.
+		usedCacheEntries = append(usedCacheEntries, entry.ID)
+		return nil
+	}
+
+	// The step key of a step depends on the result of the step before it, so
+	// step keys are looked up one step at a time, for all workspaces at once.
+	pending := workspaces
+	for len(pending) > 0 {
+		stepKeys := make([]string, len(pending))
+		allStepKeys := make([]string, 0, len(pending))
+		for i, workspace := range pending {
+			if workspace.treeHash == "" || workspace.cachedSteps == len(workspace.stepCacheKeys) {
+				continue
+			}
+			key := cache.StepKey{
+				Repository:         workspace.repo,
+				Path:               workspace.dbWorkspace.Path,
+				OnlyFetchWorkspace: workspace.dbWorkspace.OnlyFetchWorkspace,
+				TreeHash:           workspace.treeHash,
+				Step:               spec.Spec.Steps[workspace.stepCacheKeys[workspace.cachedSteps].index],
+				PreviousResult:     workspace.previousResult,
+				BatchChangeAttributes: &template.BatchChangeAttributes{
+					Name:        spec.Spec.Name,
+					Description: spec.Spec.Description,
+				},
+				GlobalEnv:         envVars,
+				MetadataRetriever: retriever,
+			}
+			rawKey, err := key.Key()
+			if err != nil {
+				return nil, err
+			}
+			stepKeys[i] = rawKey
+			allStepKeys = append(allStepKeys, rawKey)
+		}
+
+		stepEntries := make(map[string]*btypes.BatchSpecExecutionCacheEntry, len(allStepKeys))
+		if len(allStepKeys) > 0 {
+			entries, err := r.store.ListBatchSpecExecutionCacheEntries(ctx, store.ListBatchSpecExecutionCacheEntriesOpts{
+				AnyUser: true,
+				Keys:    allStepKeys,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, entry := range entries {
+				stepEntries[entry.Key] = entry
+			}
+		}
+
+		next := pending[:0]
+		for i, workspace := range pending {
+			if workspace.cachedSteps == len(workspace.stepCacheKeys) {
+				continue
+			}
+			if entry, ok := stepEntries[stepKeys[i]]; ok {
+				if err := useEntry(workspace, stepKeys[i], entry); err != nil {
+					return nil, err
+				}
+			} else if entry, ok := entriesByCacheKey[workspace.stepCacheKeys[workspace.cachedSteps].key]; ok {
+				if err := useEntry(workspace, workspace.stepCacheKeys[workspace.cachedSteps].key, entry); err != nil {
+					return nil, err
+				}
+			} else {
+				// Only add cache entries up until we don't have the cache entry
+				// for the previous step anymore.
+				continue
+			}
+			next = append(next, workspace)
+		}
+		pending = next
+	}
+
+	return usedCacheEntries, nil
+}
+
+// workspaceTreeHash returns the hash of the git tree the steps of the given
+// workspace run in, which is the tree of the workspace path if only the
+// workspace is fetched, and the root tree of the repository otherwise. If the
+// hash can't be determined, an empty string is returned and only the cache
+// keys of the user are used for the workspace.
+func (r *batchSpecWorkspaceCreator) workspaceTreeHash(ctx context.Context, w *service.RepoWorkspace) string {
+	path := ""
+	if w.OnlyFetchWorkspace {
+		path = w.Path
+	}
+	fi, err := r.gitserverClient.Stat(ctx, authz.DefaultSubRepoPermsChecker, w.Repo.Name, w.Commit, path)
+	if err != nil {
+		r.logger.Warn("failed to get tree hash of workspace", log.String("repo", string(w.Repo.Name)), log.String("path", path), log.Error(err))
+		return ""
+	}
+	oi, ok := fi.Sys().(gitdomain.ObjectInfo)
+	if !ok || !fi.IsDir() {
+		return ""
+	}
+	return oi.OID().String()
+}
+
 func listBatchSpecMounts(ctx context.Context, s *store.Store, batchSpecID int64) ([]*btypes.BatchSpecWorkspaceFile, error) {
 	mounts, _, err := s.ListBatchSpecWorkspaceFiles(ctx, store.ListBatchSpecWorkspaceFileOpts{BatchSpecID: batchSpecID})
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
 	"testing"
 	"time"
 
@@ -21,6 +22,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/fileutil"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
@@ -109,7 +113,7 @@ func TestBatchSpecWorkspaceCreatorProcess(t *testing.T) {
 		},
 	}
 
-	creator := &batchSpecWorkspaceCreator{store: s, logger: logtest.Scoped(t)}
+	creator := &batchSpecWorkspaceCreator{store: s, gitserverClient: newTreeHashGitserverClient(), logger: logtest.Scoped(t)}
 	if err := creator.process(context.Background(), resolver.DummyBuilder, job); err != nil {
 		t.Fatalf("proces failed: %s", err)
 	}
@@ -198,7 +202,7 @@ func TestBatchSpecWorkspaceCreatorProcess_Caching(t *testing.T) {
 	clock := func() time.Time { return now }
 	s := store.NewWithClock(db, &observation.TestContext, nil, clock)
 
-	creator := &batchSpecWorkspaceCreator{store: s, logger: logtest.Scoped(t)}
+	creator := &batchSpecWorkspaceCreator{store: s, gitserverClient: newTreeHashGitserverClient(), logger: logtest.Scoped(t)}
 
 	buildWorkspace := func(commit string) *service.RepoWorkspace {
 		return &service.RepoWorkspace{
@@ -607,6 +611,109 @@ changesetTemplate:
 		}
 	})
 
+	t.Run("step result shared across batch specs and users", func(t *testing.T) {
+		workspace := buildWorkspace("step-result-shared")
+
+		// The result was cached by another user, for a batch spec with a
+		// different name, an additional step and an older commit that didn't
+		// change the workspace.
+		otherUser := bt.CreateTestUser(t, db, false)
+		otherSpec, err := btypes.NewBatchSpecFromRaw(`
+name: other-name
+steps:
+- run: echo 'foobar'
+  container: alpine
+  env:
+    - PATH: "/work/foobar:$PATH"
+    - FOO
+- run: echo 'another step'
+  container: alpine
+changesetTemplate:
+  title: Other title
+  body: Other body
+  branch: other-branch
+  commit:
+    message: Other commit message
+`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key := cache.StepKey{
+			Repository: batcheslib.Repository{
+				ID:          string(relay.MarshalID("Repository", workspace.Repo.ID)),
+				Name:        string(workspace.Repo.Name),
+				BaseRef:     workspace.Branch,
+				BaseRev:     "older-commit",
+				FileMatches: workspace.FileMatches,
+			},
+			Path:                  workspace.Path,
+			OnlyFetchWorkspace:    workspace.OnlyFetchWorkspace,
+			TreeHash:              testTreeHash.String(),
+			Step:                  otherSpec.Spec.Steps[0],
+			BatchChangeAttributes: &template.BatchChangeAttributes{Name: otherSpec.Spec.Name},
+			GlobalEnv:             []string{fmt.Sprintf("FOO=%s", secretValue)},
+		}
+		rawKey, err := key.Key()
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry, err := btypes.NewCacheEntryFromResult(rawKey, executionResult)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry.UserID = otherUser.ID
+		if err := s.CreateBatchSpecExecutionCacheEntry(context.Background(), entry); err != nil {
+			t.Fatal(err)
+		}
+
+		batchSpec := createBatchSpec(t, false, bt.TestRawBatchSpecYAML)
+
+		resolver := &dummyWorkspaceResolver{workspaces: []*service.RepoWorkspace{workspace}}
+		job := &btypes.BatchSpecResolutionJob{BatchSpecID: batchSpec.ID}
+		if err := creator.process(userCtx, resolver.DummyBuilder, job); err != nil {
+			t.Fatalf("proces failed: %s", err)
+		}
+
+		have, _, err := s.ListBatchSpecWorkspaces(context.Background(), store.ListBatchSpecWorkspacesOpts{BatchSpecID: batchSpec.ID})
+		if err != nil {
+			t.Fatalf("listing workspaces failed: %s", err)
+		}
+
+		assertWorkspacesEqual(t, have, []*btypes.BatchSpecWorkspace{
+			{
+				RepoID:             repos[0].ID,
+				BatchSpecID:        batchSpec.ID,
+				ChangesetSpecIDs:   have[0].ChangesetSpecIDs,
+				Branch:             "refs/heads/main",
+				Commit:             "step-result-shared",
+				FileMatches:        []string{},
+				Path:               "",
+				OnlyFetchWorkspace: true,
+				CachedResultFound:  true,
+				StepCacheResults: map[int]btypes.StepCacheResult{
+					1: {
+						Key:   rawKey,
+						Value: executionResult,
+					},
+				},
+			},
+		})
+
+		reloadedEntries, err := s.ListBatchSpecExecutionCacheEntries(context.Background(), store.ListBatchSpecExecutionCacheEntriesOpts{
+			UserID: otherUser.ID,
+			Keys:   []string{rawKey},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reloadedEntries) != 1 {
+			t.Fatal("cache entry not found")
+		}
+		if !reloadedEntries[0].LastUsedAt.Equal(now) {
+			t.Fatalf("cache entry LastUsedAt not updated. want=%s, have=%s", now, reloadedEntries[0].LastUsedAt)
+		}
+	})
+
 	t.Run("caching found with mount file", func(t *testing.T) {
 		workspace := buildWorkspace("caching-enabled-mount")
 
@@ -828,7 +935,7 @@ importChangesets:
 
 	resolver := &dummyWorkspaceResolver{}
 
-	creator := &batchSpecWorkspaceCreator{store: s, logger: logtest.Scoped(t)}
+	creator := &batchSpecWorkspaceCreator{store: s, gitserverClient: newTreeHashGitserverClient(), logger: logtest.Scoped(t)}
 	if err := creator.process(context.Background(), resolver.DummyBuilder, job); err != nil {
 		t.Fatalf("proces failed: %s", err)
 	}
@@ -886,7 +993,7 @@ importChangesets:
 
 	resolver := &dummyWorkspaceResolver{}
 
-	creator := &batchSpecWorkspaceCreator{store: s, logger: logtest.Scoped(t)}
+	creator := &batchSpecWorkspaceCreator{store: s, gitserverClient: newTreeHashGitserverClient(), logger: logtest.Scoped(t)}
 	if err := creator.process(context.Background(), resolver.DummyBuilder, job); err != nil {
 		t.Fatalf("proces failed: %s", err)
 	}
@@ -963,7 +1070,7 @@ func TestBatchSpecWorkspaceCreatorProcess_Secrets(t *testing.T) {
 		},
 	}
 
-	creator := &batchSpecWorkspaceCreator{store: s, logger: logtest.Scoped(t)}
+	creator := &batchSpecWorkspaceCreator{store: s, gitserverClient: newTreeHashGitserverClient(), logger: logtest.Scoped(t)}
 	if err := creator.process(userCtx, resolver.DummyBuilder, job); err != nil {
 		t.Fatalf("proces failed: %s", err)
 	}
@@ -1011,6 +1118,20 @@ func (d *dummyWorkspaceResolver) ResolveWorkspacesForBatchSpec(context.Context,
 	return d.workspaces, d.err
 }
 
+var testTreeHash = gitdomain.OID{0xde, 0xad, 0xbe, 0xef}
+
+// newTreeHashGitserverClient returns a gitserver client that returns
+// testTreeHash as the tree hash of all workspaces.
+func newTreeHashGitserverClient() gitserver.Client {
+	client := gitserver.NewMockClient()
+	client.StatFunc.SetDefaultReturn(&fileutil.FileInfo{Mode_: os.ModeDir, Sys_: treeObject(testTreeHash)}, nil)
+	return client
+}
+
+type treeObject gitdomain.OID
+
+func (o treeObject) OID() gitdomain.OID { return gitdomain.OID(o) }
+
 var testDiff = []byte(`diff README.md README.md
 index 671e50a..851b23a 100644
 --- README.md
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"io"
 
 	"github.com/sourcegraph/log"
 
@@ -14,6 +15,7 @@ import (
 	repoembeddingsbg "github.com/sourcegraph/sourcegraph/enterprise/internal/embeddings/background/repo"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/embeddings/embed"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/embeddings/split"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
@@ -56,45 +58,160 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, record *repoemb
 		return err
 	}
 
-	files, err := h.gitserverClient.ListFiles(ctx, nil, repo.Name, record.Revision, matchEverythingRegexp)
-	if err != nil {
-		return err
+	embeddingsClient := embed.NewEmbeddingsClient()
+
+	config := conf.Get().Embeddings
+	excludedGlobPatterns := embed.GetDefaultExcludedFilePathPatterns()
+	excludedGlobPatterns = append(excludedGlobPatterns, embed.CompileGlobPatterns(config.ExcludedFilePathPatterns)...)
+
+	readFile := func(fileName string) ([]byte, error) {
+		return h.gitserverClient.ReadFile(ctx, nil, repo.Name, record.Revision, fileName)
 	}
 
-	validFiles := []string{}
-	for _, file := range files {
-		stat, err := h.gitserverClient.Stat(ctx, nil, repo.Name, record.Revision, file)
+	indexName := string(embeddings.GetRepoEmbeddingIndexName(repo.Name))
+
+	var repoEmbeddingIndex *embeddings.RepoEmbeddingIndex
+	if previousIndex := h.getUpdatableIndex(ctx, logger, indexName, embeddingsClient); previousIndex != nil {
+		modifiedFiles, deletedFiles, err := h.getChangedFiles(ctx, repo.Name, previousIndex.Revision, record.Revision)
+		if err != nil {
+			// The previous revision may no longer exist, for example after a force push.
+			logger.Warn("failed to diff against the revision of the previous embeddings index, embedding the entire repository",
+				log.String("previousRevision", string(previousIndex.Revision)), log.Error(err))
+		} else {
+			repoEmbeddingIndex, err = embed.UpdateRepoEmbeddingIndex(
+				ctx,
+				previousIndex,
+				record.Revision,
+				modifiedFiles,
+				deletedFiles,
+				excludedGlobPatterns,
+				embeddingsClient,
+				splitOptions,
+				readFile,
+				getDocumentRanks,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if repoEmbeddingIndex == nil {
+		files, err := h.gitserverClient.ListFiles(ctx, nil, repo.Name, record.Revision, matchEverythingRegexp)
 		if err != nil {
 			return err
 		}
 
-		if !stat.IsDir() && stat.Size() <= MAX_FILE_SIZE {
-			validFiles = append(validFiles, file)
+		validFiles, _, err := h.filterValidFiles(ctx, repo.Name, record.Revision, files)
+		if err != nil {
+			return err
+		}
+
+		repoEmbeddingIndex, err = embed.EmbedRepo(
+			ctx,
+			repo.Name,
+			record.Revision,
+			validFiles,
+			excludedGlobPatterns,
+			embeddingsClient,
+			splitOptions,
+			readFile,
+			getDocumentRanks,
+		)
+		if err != nil {
+			return err
 		}
 	}
 
-	embeddingsClient := embed.NewEmbeddingsClient()
+	return embeddings.UploadRepoEmbeddingIndex(ctx, h.uploadStore, indexName, repoEmbeddingIndex)
+}
 
-	config := conf.Get().Embeddings
-	excludedGlobPatterns := embed.GetDefaultExcludedFilePathPatterns()
-	excludedGlobPatterns = append(excludedGlobPatterns, embed.CompileGlobPatterns(config.ExcludedFilePathPatterns)...)
+// getUpdatableIndex returns the current embeddings index of the repository, if it can be updated
+// incrementally. It returns nil if there is no index yet, or if the index was created with an
+// embeddings model with different dimensions.
+func (h *handler) getUpdatableIndex(ctx context.Context, logger log.Logger, indexName string, embeddingsClient embed.EmbeddingsClient) *embeddings.RepoEmbeddingIndex {
+	previousIndex, err := embeddings.DownloadRepoEmbeddingIndex(ctx, h.uploadStore, indexName)
+	if err != nil {
+		logger.Info("no previous embeddings index, embedding the entire repository", log.Error(err))
+		return nil
+	}
 
-	repoEmbeddingIndex, err := embed.EmbedRepo(
-		ctx,
-		repo.Name,
-		record.Revision,
-		validFiles,
-		excludedGlobPatterns,
-		embeddingsClient,
-		splitOptions,
-		func(fileName string) ([]byte, error) {
-			return h.gitserverClient.ReadFile(ctx, nil, repo.Name, record.Revision, fileName)
-		},
-		getDocumentRanks,
-	)
+	dimensions, err := embeddingsClient.GetDimensions()
 	if err != nil {
-		return err
+		return nil
+	}
+	for _, index := range []embeddings.EmbeddingIndex{previousIndex.CodeIndex, previousIndex.TextIndex} {
+		if len(index.RowMetadata) > 0 && index.ColumnDimension != dimensions {
+			logger.Info("embeddings dimensions changed, embedding the entire repository",
+				log.Int("previousDimensions", index.ColumnDimension), log.Int("dimensions", dimensions))
+			return nil
+		}
+	}
+	return previousIndex
+}
+
+// getChangedFiles returns the files that were added or modified between the base and head
+// revisions, and the files that were deleted or should otherwise be removed from the index.
+// Renamed files are deleted under their old name and modified under their new name.
+func (h *handler) getChangedFiles(ctx context.Context, repoName api.RepoName, base, head api.CommitID) (modifiedFiles, deletedFiles []string, err error) {
+	if base == head {
+		return nil, nil, nil
+	}
+
+	iter, err := h.gitserverClient.Diff(ctx, nil, gitserver.DiffOptions{
+		Repo: repoName,
+		Base: string(base),
+		Head: string(head),
+		// Compare the revisions directly instead of comparing head with the merge base.
+		RangeType: "..",
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { err = errors.Append(err, iter.Close()) }()
+
+	changedFiles := []string{}
+	for {
+		fileDiff, err := iter.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		if fileDiff.OrigName != devNullPath && fileDiff.OrigName != fileDiff.NewName {
+			deletedFiles = append(deletedFiles, fileDiff.OrigName)
+		}
+		if fileDiff.NewName != devNullPath {
+			changedFiles = append(changedFiles, fileDiff.NewName)
+		}
+	}
+
+	// Changed files that can no longer be embedded, for example because they are too large, are
+	// removed from the index.
+	modifiedFiles, invalidFiles, err := h.filterValidFiles(ctx, repoName, head, changedFiles)
+	if err != nil {
+		return nil, nil, err
 	}
+	return modifiedFiles, append(deletedFiles, invalidFiles...), nil
+}
+
+const devNullPath = "/dev/null"
+
+// filterValidFiles separates the files that can be embedded from directories and files that are too large.
+func (h *handler) filterValidFiles(ctx context.Context, repoName api.RepoName, revision api.CommitID, files []string) (validFiles, invalidFiles []string, err error) {
+	validFiles = []string{}
+	for _, file := range files {
+		stat, err := h.gitserverClient.Stat(ctx, nil, repoName, revision, file)
+		if err != nil {
+			return nil, nil, err
+		}
 
-	return embeddings.UploadRepoEmbeddingIndex(ctx, h.uploadStore, string(embeddings.GetRepoEmbeddingIndexName(repo.Name)), repoEmbeddingIndex)
+		if !stat.IsDir() && stat.Size() <= MAX_FILE_SIZE {
+			validFiles = append(validFiles, file)
+		} else {
+			invalidFiles = append(invalidFiles, file)
+		}
+	}
+	return validFiles, invalidFiles, nil
 }
@@ -0,0 +1,189 @@
+// Package guardrails implements the site-level policies that batch changes
+// must comply with, as configured by site admins in batchChanges.guardrails.
+package guardrails
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gobwas/glob"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/audit"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Names of the guardrails, as used in the site configuration.
+const (
+	ForbiddenPaths         = "forbiddenPaths"
+	MaxChangesets          = "maxChangesets"
+	DraftFirstRepositories = "draftFirstRepositories"
+	PublicationWindows     = "publicationWindows"
+)
+
+// Violation is returned when an operation violates a guardrail.
+type Violation struct {
+	// Guardrail is the name of the violated guardrail.
+	Guardrail string
+	// Reason is a human readable description of the violation.
+	Reason string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("violates the %s guardrail for batch changes: %s", v.Guardrail, v.Reason)
+}
+
+// PublicationDeferred is returned when a changeset can't be published yet,
+// because none of the publication windows is open. Unlike a *Violation, the
+// changeset can be published once the next window opens.
+type PublicationDeferred struct {
+	// Until is the time the next publication window opens, or nil if no window
+	// opens within the next week.
+	Until *time.Time
+}
+
+func (d *PublicationDeferred) Error() string {
+	if d.Until == nil {
+		return "changesets can't be published outside of the publication windows for batch changes"
+	}
+	return fmt.Sprintf("changesets can't be published until the next publication window for batch changes opens at %s", d.Until.UTC().Format(time.RFC1123))
+}
+
+// NonRetryable marks violations as non-retryable, since retrying an operation
+// that violates a guardrail doesn't change the outcome until the
+// configuration changes.
+func (v *Violation) NonRetryable() bool { return true }
+
+// Audit records the violation, and the operation that caused it, described by
+// the given fields, in the audit log.
+func (v *Violation) Audit(ctx context.Context, logger log.Logger, fields ...log.Field) {
+	audit.Log(ctx, logger, audit.Record{
+		Entity: "batch change guardrail",
+		Action: "violated",
+		Fields: append([]log.Field{
+			log.String("guardrail", v.Guardrail),
+			log.String("reason", v.Reason),
+		}, fields...),
+	})
+}
+
+// Guardrails is the parsed form of the batchChanges.guardrails site
+// configuration.
+type Guardrails struct {
+	forbiddenPaths     []pattern
+	maxChangesets      int
+	draftFirstRepos    []pattern
+	publicationWindows *window.Configuration
+}
+
+type pattern struct {
+	raw  string
+	glob glob.Glob
+}
+
+// Current returns the guardrails in the current site configuration.
+func Current() (*Guardrails, error) {
+	return New(conf.Get().BatchChangesGuardrails)
+}
+
+// New parses the given guardrails configuration. A nil configuration results
+// in guardrails that allow everything.
+func New(raw *schema.BatchChangesGuardrails) (*Guardrails, error) {
+	g := &Guardrails{}
+	if raw == nil {
+		raw = &schema.BatchChangesGuardrails{}
+	}
+
+	var err error
+	if g.forbiddenPaths, err = compilePatterns(raw.ForbiddenPaths); err != nil {
+		return nil, errors.Wrap(err, ForbiddenPaths)
+	}
+	if g.draftFirstRepos, err = compilePatterns(raw.DraftFirstRepositories); err != nil {
+		return nil, errors.Wrap(err, DraftFirstRepositories)
+	}
+	g.maxChangesets = raw.MaxChangesets
+
+	windows := make([]*schema.BatchChangeRolloutWindow, 0, len(raw.PublicationWindows))
+	for _, w := range raw.PublicationWindows {
+		windows = append(windows, &schema.BatchChangeRolloutWindow{
+			Days:  w.Days,
+			Start: w.Start,
+			End:   w.End,
+			Rate:  "unlimited",
+		})
+	}
+	if g.publicationWindows, err = window.NewConfiguration(&windows); err != nil {
+		return nil, errors.Wrap(err, PublicationWindows)
+	}
+
+	return g, nil
+}
+
+func compilePatterns(raw []string) ([]pattern, error) {
+	patterns := make([]pattern, 0, len(raw))
+	for _, r := range raw {
+		g, err := glob.Compile(r, '/')
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %q", r)
+		}
+		patterns = append(patterns, pattern{raw: r, glob: g})
+	}
+	return patterns, nil
+}
+
+func match(patterns []pattern, s string) (pattern, bool) {
+	for _, p := range patterns {
+		if p.glob.Match(s) {
+			return p, true
+		}
+	}
+	return pattern{}, false
+}
+
+// CheckChangesetCount returns a *Violation if a batch change with the given
+// number of changesets isn't allowed.
+func (g *Guardrails) CheckChangesetCount(n int) error {
+	if g.maxChangesets > 0 && n > g.maxChangesets {
+		return &Violation{
+			Guardrail: MaxChangesets,
+			Reason:    fmt.Sprintf("the batch change would have %d changesets, but at most %d are allowed", n, g.maxChangesets),
+		}
+	}
+	return nil
+}
+
+// CheckChangedFiles returns a *Violation if a changeset in the given
+// repository isn't allowed to change any of the given files.
+func (g *Guardrails) CheckChangedFiles(repo api.RepoName, files []string) error {
+	for _, file := range files {
+		if p, ok := match(g.forbiddenPaths, file); ok {
+			return &Violation{
+				Guardrail: ForbiddenPaths,
+				Reason:    fmt.Sprintf("the changeset in %s changes %s, which matches the forbidden path %q", repo, file, p.raw),
+			}
+		}
+	}
+	return nil
+}
+
+// CheckPublication returns a *PublicationDeferred if changesets can't be
+// published at the given time, and a *Violation if a changeset in the given
+// repository can't be published at all. draft is true if the changeset is
+// published as a draft, or has been published as a draft before.
+func (g *Guardrails) CheckPublication(now time.Time, repo api.RepoName, draft bool) error {
+	if !g.publicationWindows.IsOpen(now) {
+		return &PublicationDeferred{Until: g.publicationWindows.NextOpen(now)}
+	}
+	if p, ok := match(g.draftFirstRepos, string(repo)); ok && !draft {
+		return &Violation{
+			Guardrail: DraftFirstRepositories,
+			Reason:    fmt.Sprintf("changesets in %s must be published as drafts first, because it matches %q", repo, p.raw),
+		}
+	}
+	return nil
+}
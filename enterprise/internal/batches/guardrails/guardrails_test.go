@@ -0,0 +1,81 @@
+package guardrails
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGuardrails(t *testing.T) {
+	g, err := New(&schema.BatchChangesGuardrails{
+		ForbiddenPaths:         []string{".github/workflows/**", "**/*.lock"},
+		MaxChangesets:          2,
+		DraftFirstRepositories: []string{"github.com/sourcegraph/*"},
+		PublicationWindows: []*schema.BatchChangesPublicationWindow{
+			{Days: []string{"monday"}, Start: "09:00", End: "17:00"},
+		},
+	})
+	require.NoError(t, err)
+
+	// 2023-04-10 is a Monday.
+	open := time.Date(2023, 4, 10, 10, 0, 0, 0, time.UTC)
+	closed := time.Date(2023, 4, 11, 10, 0, 0, 0, time.UTC)
+
+	t.Run("CheckChangesetCount", func(t *testing.T) {
+		assert.NoError(t, g.CheckChangesetCount(2))
+		assertViolation(t, MaxChangesets, g.CheckChangesetCount(3))
+	})
+
+	t.Run("CheckChangedFiles", func(t *testing.T) {
+		assert.NoError(t, g.CheckChangedFiles("github.com/a/b", []string{"README.md", "go.mod"}))
+		assertViolation(t, ForbiddenPaths, g.CheckChangedFiles("github.com/a/b", []string{"README.md", ".github/workflows/ci.yml"}))
+		assertViolation(t, ForbiddenPaths, g.CheckChangedFiles("github.com/a/b", []string{"web/yarn.lock"}))
+	})
+
+	t.Run("CheckPublication", func(t *testing.T) {
+		assert.NoError(t, g.CheckPublication(open, "github.com/a/b", false))
+		assert.NoError(t, g.CheckPublication(open, "github.com/sourcegraph/sourcegraph", true))
+		assertViolation(t, DraftFirstRepositories, g.CheckPublication(open, "github.com/sourcegraph/sourcegraph", false))
+
+		// Publication outside of the windows is deferred until the next window opens.
+		var deferred *PublicationDeferred
+		require.True(t, errors.As(g.CheckPublication(closed, "github.com/a/b", true), &deferred))
+		// 2023-04-17 is the following Monday.
+		require.NotNil(t, deferred.Until)
+		assert.Equal(t, time.Date(2023, 4, 17, 9, 0, 0, 0, time.UTC), deferred.Until.UTC())
+	})
+
+	t.Run("no configuration", func(t *testing.T) {
+		g, err := New(nil)
+		require.NoError(t, err)
+
+		assert.NoError(t, g.CheckChangesetCount(10000))
+		assert.NoError(t, g.CheckChangedFiles("github.com/a/b", []string{".github/workflows/ci.yml"}))
+		assert.NoError(t, g.CheckPublication(closed, "github.com/sourcegraph/sourcegraph", false))
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := New(&schema.BatchChangesGuardrails{ForbiddenPaths: []string{"[a-"}})
+		assert.Error(t, err)
+
+		_, err = New(&schema.BatchChangesGuardrails{
+			PublicationWindows: []*schema.BatchChangesPublicationWindow{{Start: "25:00", End: "26:00"}},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func assertViolation(t *testing.T, guardrail string, err error) {
+	t.Helper()
+
+	var v *Violation
+	if !errors.As(err, &v) {
+		t.Fatalf("expected violation, got %v", err)
+	}
+	assert.Equal(t, guardrail, v.Guardrail)
+}
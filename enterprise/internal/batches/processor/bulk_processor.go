@@ -3,6 +3,7 @@ package processor
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/sourcegraph/log"
 
@@ -15,7 +16,9 @@ import (
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/webhooks"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -35,13 +38,29 @@ func (e unknownJobTypeErr) NonRetryable() bool {
 	return true
 }
 
+// revertConflictErr is returned when the changes of a merged changeset can't be
+// reverted on top of the current base branch of its repository.
+type revertConflictErr struct {
+	repo   api.RepoName
+	output string
+}
+
+func (e revertConflictErr) Error() string {
+	return fmt.Sprintf("the changes can't be reverted on top of the current base branch of %s, because they conflict with later changes:\n\n%s", e.repo, strings.TrimSpace(e.output))
+}
+
+func (e revertConflictErr) NonRetryable() bool {
+	return true
+}
+
 var changesetIsProcessingErr = errors.New("cannot update a changeset that is currently being processed; will retry")
 
 func New(logger log.Logger, tx *store.Store, sourcer sources.Sourcer) BulkProcessor {
 	return &bulkProcessor{
-		tx:      tx,
-		sourcer: sourcer,
-		logger:  logger,
+		tx:              tx,
+		sourcer:         sourcer,
+		logger:          logger,
+		gitserverClient: gitserver.NewClient(),
 	}
 }
 
@@ -50,9 +69,10 @@ type BulkProcessor interface {
 }
 
 type bulkProcessor struct {
-	tx      *store.Store
-	sourcer sources.Sourcer
-	logger  log.Logger
+	tx              *store.Store
+	sourcer         sources.Sourcer
+	logger          log.Logger
+	gitserverClient gitserver.Client
 
 	css  sources.ChangesetSource
 	repo *types.Repo
@@ -102,6 +122,8 @@ func (b *bulkProcessor) Process(ctx context.Context, job *btypes.ChangesetJob) (
 		return b.closeChangeset(ctx)
 	case btypes.ChangesetJobTypePublish:
 		return nil, b.publishChangeset(ctx, job)
+	case btypes.ChangesetJobTypeRevert:
+		return nil, b.revertChangeset(ctx, job)
 
 	default:
 		return nil, &unknownJobTypeErr{jobType: string(job.JobType)}
@@ -289,6 +311,106 @@ func (b *bulkProcessor) publishChangeset(ctx context.Context, job *btypes.Change
 	return nil
 }
 
+func (b *bulkProcessor) revertChangeset(ctx context.Context, job *btypes.ChangesetJob) error {
+	typedPayload, ok := job.Payload.(*btypes.ChangesetJobRevertPayload)
+	if !ok {
+		return errors.Errorf("invalid payload type for changeset_job, want=%T have=%T", &btypes.ChangesetJobRevertPayload{}, job.Payload)
+	}
+
+	if b.ch.ExternalState != btypes.ChangesetExternalStateMerged {
+		return errcode.MakeNonRetryable(errors.New("only merged changesets can be reverted"))
+	}
+
+	// We can't revert an imported changeset, since it wasn't created from a
+	// changeset spec.
+	if b.ch.CurrentSpecID == 0 {
+		return errcode.MakeNonRetryable(errors.New("cannot revert an imported changeset"))
+	}
+
+	spec, err := b.tx.GetChangesetSpecByID(ctx, b.ch.CurrentSpecID)
+	if err != nil {
+		return errors.Wrapf(err, "getting changeset spec for changeset %d", b.ch.ID)
+	}
+
+	revertBatchChange, err := b.tx.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: typedPayload.RevertBatchChangeID})
+	if err != nil {
+		return errors.Wrap(err, "loading revert batch change")
+	}
+
+	baseRev, err := b.gitserverClient.ResolveRevision(ctx, b.repo.Name, spec.BaseRef, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return errors.Wrap(err, "resolving base branch")
+	}
+
+	// The changes are reverted as they were merged into the base branch, which
+	// can differ from the diff of the changeset spec, for example when the
+	// changeset was updated on the code host or conflicts were resolved when
+	// merging it.
+	mergeCommit, err := b.mergeCommit(ctx, baseRev)
+	if err != nil {
+		return err
+	}
+	mergeDiff, err := mergeCommitDiff(ctx, b.gitserverClient, b.repo.Name, mergeCommit)
+	if err != nil {
+		return err
+	}
+
+	revertSpec, err := spec.Revert(string(baseRev), mergeDiff)
+	if err != nil {
+		return errcode.MakeNonRetryable(err)
+	}
+	revertSpec.BatchSpecID = revertBatchChange.BatchSpecID
+	revertSpec.UserID = job.UserID
+
+	// Check that the inverse diff applies on top of the current base branch,
+	// so that conflicts are reported here instead of when the reverting
+	// changeset is published.
+	if err := checkPatchApplies(ctx, b.gitserverClient, b.repo.Name, baseRev, revertSpec.Diff); err != nil {
+		return err
+	}
+
+	if err := b.tx.CreateChangesetSpec(ctx, revertSpec); err != nil {
+		return errors.Wrap(err, "creating changeset spec")
+	}
+
+	// The reverting changeset is created unpublished, so that it can be
+	// published like any other changeset of the revert batch change.
+	revert := &btypes.Changeset{
+		RepoID:              b.repo.ID,
+		ExternalServiceType: b.repo.ExternalRepo.ServiceType,
+
+		BatchChanges:         []btypes.BatchChangeAssoc{{BatchChangeID: revertBatchChange.ID}},
+		OwnedByBatchChangeID: revertBatchChange.ID,
+
+		PublicationState: btypes.ChangesetPublicationStateUnpublished,
+	}
+	revert.SetCurrentSpec(revertSpec)
+	revert.ResetReconcilerState(global.DefaultReconcilerEnqueueState())
+
+	return b.tx.CreateChangeset(ctx, revert)
+}
+
+// mergeCommit returns the commit that merged the changeset into its base
+// branch at baseRev. GitHub records the merge commit on the merged event of the
+// pull request, which also covers squash and rebase merges. Otherwise, the merge
+// commit is looked up on the base branch.
+func (b *bulkProcessor) mergeCommit(ctx context.Context, baseRev api.CommitID) (api.CommitID, error) {
+	events, _, err := b.tx.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
+		ChangesetIDs: []int64{b.ch.ID},
+		Kinds:        []btypes.ChangesetEventKind{btypes.ChangesetEventKindGitHubMerged},
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "loading merged event")
+	}
+	for _, e := range events {
+		if merged, ok := e.Metadata.(*github.MergedEvent); ok && merged.Commit.OID != "" {
+			return api.CommitID(merged.Commit.OID), nil
+		}
+	}
+
+	return findMergeCommit(ctx, b.gitserverClient, b.repo.Name, baseRev, b.ch.SyncState.HeadRefOid)
+}
+
 func (b *bulkProcessor) enqueueWebhook(ctx context.Context, store *store.Store, eventType string) {
 	webhooks.EnqueueChangeset(ctx, b.logger, store, eventType, bgql.MarshalChangesetID(b.ch.ID))
 }
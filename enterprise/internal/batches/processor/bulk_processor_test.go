@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/sourcegraph/log/logtest"
@@ -18,14 +19,17 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func mockDoer(req *http.Request) (*http.Response, error) {
@@ -310,6 +314,155 @@ func TestBulkProcessor(t *testing.T) {
 		}
 	})
 
+	t.Run("Revert job", func(t *testing.T) {
+		revertBatchSpec := bt.CreateBatchSpec(t, ctx, bstore, "test-bulk-revert", user.ID, 0)
+		revertBatchChange := bt.CreateBatchChange(t, ctx, bstore, "test-bulk-revert", user.ID, revertBatchSpec.ID)
+
+		diff := []byte(`diff --git README.md README.md
+--- README.md
++++ README.md
+@@ -1 +1 @@
+-Hello
++Hello world
+`)
+		mergedSpec := bt.CreateChangesetSpec(t, ctx, bstore, bt.TestSpecOpts{
+			User:       user.ID,
+			Repo:       repo.ID,
+			BatchSpec:  batchSpec.ID,
+			Typ:        btypes.ChangesetSpecTypeBranch,
+			HeadRef:    "refs/heads/my-branch",
+			BaseRef:    "refs/heads/main",
+			Title:      "Update README",
+			CommitDiff: diff,
+		})
+		merged := bt.CreateChangeset(t, ctx, bstore, bt.TestChangesetOpts{
+			Repo:               repo.ID,
+			BatchChange:        batchChange.ID,
+			OwnedByBatchChange: batchChange.ID,
+			CurrentSpec:        mergedSpec.ID,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateMerged,
+		})
+		if err := bstore.UpsertChangesetEvents(ctx, &btypes.ChangesetEvent{
+			ChangesetID: merged.ID,
+			Kind:        btypes.ChangesetEventKindGitHubMerged,
+			Key:         "merged",
+			Metadata:    &github.MergedEvent{Commit: github.Commit{OID: "m3rg3"}},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		// The changes of the merge commit differ from the diff of the
+		// changeset spec, and are the ones that are reverted.
+		mergeDiff := `diff --git README.md README.md
+--- README.md
++++ README.md
+@@ -1 +1 @@
+-Hello
++Hello merged world
+`
+		newClient := func(content string) *gitserver.MockClient {
+			client := gitserver.NewMockClient()
+			client.ResolveRevisionFunc.SetDefaultReturn("c0ff33", nil)
+			client.DiffFunc.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, gitserver.DiffOptions) (*gitserver.DiffFileIterator, error) {
+				return gitserver.NewDiffFileIterator(io.NopCloser(strings.NewReader(mergeDiff))), nil
+			})
+			client.ReadFileFunc.SetDefaultReturn([]byte(content), nil)
+			return client
+		}
+
+		newJob := func(changesetID int64) *types.ChangesetJob {
+			return &types.ChangesetJob{
+				JobType:       types.ChangesetJobTypeRevert,
+				BatchChangeID: batchChange.ID,
+				ChangesetID:   changesetID,
+				UserID:        user.ID,
+				Payload:       &types.ChangesetJobRevertPayload{RevertBatchChangeID: revertBatchChange.ID},
+			}
+		}
+
+		newProcessor := func(client gitserver.Client) *bulkProcessor {
+			return &bulkProcessor{
+				tx:              bstore,
+				sourcer:         stesting.NewFakeSourcer(nil, &stesting.FakeChangesetSource{}),
+				logger:          logtest.Scoped(t),
+				gitserverClient: client,
+			}
+		}
+
+		t.Run("open changeset", func(t *testing.T) {
+			open := bt.CreateChangeset(t, ctx, bstore, bt.TestChangesetOpts{
+				Repo:               repo.ID,
+				BatchChange:        batchChange.ID,
+				OwnedByBatchChange: batchChange.ID,
+				CurrentSpec:        mergedSpec.ID,
+				ReconcilerState:    btypes.ReconcilerStateCompleted,
+				PublicationState:   btypes.ChangesetPublicationStatePublished,
+				ExternalState:      btypes.ChangesetExternalStateOpen,
+			})
+
+			_, err := newProcessor(gitserver.NewMockClient()).Process(ctx, newJob(open.ID))
+			if err == nil || !errcode.IsNonRetryable(err) {
+				t.Fatalf("expected non-retryable error, got %v", err)
+			}
+		})
+
+		t.Run("conflict", func(t *testing.T) {
+			client := newClient("Goodbye\n")
+
+			_, err := newProcessor(client).Process(ctx, newJob(merged.ID))
+			var conflict revertConflictErr
+			if !errors.As(err, &conflict) {
+				t.Fatalf("expected conflict error, got %v", err)
+			}
+			if !errcode.IsNonRetryable(err) {
+				t.Fatal("conflict error is retryable")
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			client := newClient("Hello merged world\n")
+
+			if _, err := newProcessor(client).Process(ctx, newJob(merged.ID)); err != nil {
+				t.Fatal(err)
+			}
+
+			// The conflict check must not write to gitserver.
+			if len(client.CreateCommitFromPatchFunc.History()) != 0 {
+				t.Fatal("unexpected call to CreateCommitFromPatch")
+			}
+			if call := client.ReadFileFunc.History()[0]; call.Arg3 != "c0ff33" || call.Arg4 != "README.md" {
+				t.Fatalf("unexpected file read: %s@%s", call.Arg4, call.Arg3)
+			}
+			if opts := client.DiffFunc.History()[0].Arg2; opts.Base != "m3rg3^" || opts.Head != "m3rg3" {
+				t.Fatalf("unexpected diff: %s..%s", opts.Base, opts.Head)
+			}
+
+			reverts, _, err := bstore.ListChangesets(ctx, store.ListChangesetsOpts{OwnedByBatchChangeID: revertBatchChange.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(reverts) != 1 {
+				t.Fatalf("wrong number of reverting changesets. want=1, have=%d", len(reverts))
+			}
+			if have := reverts[0].PublicationState; have != btypes.ChangesetPublicationStateUnpublished {
+				t.Fatalf("wrong publication state. want=%s, have=%s", btypes.ChangesetPublicationStateUnpublished, have)
+			}
+
+			spec, err := bstore.GetChangesetSpecByID(ctx, reverts[0].CurrentSpecID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if spec.BaseRev != "c0ff33" || spec.BatchSpecID != revertBatchSpec.ID {
+				t.Fatalf("unexpected changeset spec: %+v", spec)
+			}
+			if !strings.Contains(string(spec.Diff), "-Hello merged world\n+Hello\n") {
+				t.Fatalf("changeset spec doesn't revert the merge commit:\n%s", spec.Diff)
+			}
+		})
+	})
+
 	t.Run("Publish job", func(t *testing.T) {
 		fake := &stesting.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
 		bp := &bulkProcessor{
@@ -0,0 +1,76 @@
+package processor
+
+import (
+	"context"
+	"io"
+
+	godiff "github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// findMergeCommit returns the commit on the base branch at baseRev that merged
+// the head commit of a changeset: the commit whose parents include it.
+//
+// Code hosts that squash or rebase changesets when merging them don't create
+// such a commit, so their merge commit has to be known from the code host.
+func findMergeCommit(ctx context.Context, client gitserver.Client, repo api.RepoName, baseRev api.CommitID, headRev string) (api.CommitID, error) {
+	if headRev == "" {
+		return "", errcode.MakeNonRetryable(errors.New("the head commit of the changeset is unknown"))
+	}
+
+	commits, err := client.Commits(ctx, authz.DefaultSubRepoPermsChecker, repo, gitserver.CommitsOptions{
+		Range: headRev + ".." + string(baseRev),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "listing commits of base branch")
+	}
+
+	for _, commit := range commits {
+		for _, parent := range commit.Parents {
+			if string(parent) == headRev {
+				return commit.ID, nil
+			}
+		}
+	}
+
+	return "", errcode.MakeNonRetryable(errors.Newf("no merge commit of %s found on the base branch", headRev))
+}
+
+// mergeCommitDiff returns the changes of the given merge commit relative to
+// its first parent, which are the changes that were merged into the base
+// branch. Like the diffs of changeset specs, the filenames of the diff don't
+// have the `a/` and `b/` prefixes.
+func mergeCommitDiff(ctx context.Context, client gitserver.Client, repo api.RepoName, commit api.CommitID) ([]byte, error) {
+	iter, err := client.Diff(ctx, authz.DefaultSubRepoPermsChecker, gitserver.DiffOptions{
+		Repo:      repo,
+		Base:      string(commit) + "^",
+		Head:      string(commit),
+		RangeType: "..",
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "computing diff of merge commit")
+	}
+	defer iter.Close()
+
+	var fileDiffs []*godiff.FileDiff
+	for {
+		fileDiff, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "reading diff of merge commit")
+		}
+		fileDiffs = append(fileDiffs, fileDiff)
+	}
+	if len(fileDiffs) == 0 {
+		return nil, errcode.MakeNonRetryable(errors.Newf("merge commit %s has no changes to revert", commit))
+	}
+
+	return godiff.PrintMultiFileDiff(fileDiffs)
+}
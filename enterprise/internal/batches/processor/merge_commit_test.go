@@ -0,0 +1,86 @@
+package processor
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+)
+
+func TestFindMergeCommit(t *testing.T) {
+	ctx := context.Background()
+
+	client := gitserver.NewMockClient()
+	client.CommitsFunc.SetDefaultReturn([]*gitdomain.Commit{
+		{ID: "c0ff33", Parents: []api.CommitID{"m3rg3"}},
+		{ID: "m3rg3", Parents: []api.CommitID{"b4s3", "h34d"}},
+		{ID: "b4s3", Parents: []api.CommitID{"0ld"}},
+	}, nil)
+
+	t.Run("found", func(t *testing.T) {
+		commit, err := findMergeCommit(ctx, client, "repo", "c0ff33", "h34d")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if commit != "m3rg3" {
+			t.Fatalf("unexpected merge commit: %q", commit)
+		}
+		if opts := client.CommitsFunc.History()[0].Arg3; opts.Range != "h34d..c0ff33" {
+			t.Fatalf("unexpected range: %q", opts.Range)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := findMergeCommit(ctx, client, "repo", "c0ff33", "squ45h")
+		if err == nil || !errcode.IsNonRetryable(err) {
+			t.Fatalf("expected non-retryable error, got %v", err)
+		}
+	})
+
+	t.Run("unknown head", func(t *testing.T) {
+		_, err := findMergeCommit(ctx, client, "repo", "c0ff33", "")
+		if err == nil || !errcode.IsNonRetryable(err) {
+			t.Fatalf("expected non-retryable error, got %v", err)
+		}
+	})
+}
+
+func TestMergeCommitDiff(t *testing.T) {
+	ctx := context.Background()
+
+	newClient := func(diff string) *gitserver.MockClient {
+		client := gitserver.NewMockClient()
+		client.DiffFunc.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, gitserver.DiffOptions) (*gitserver.DiffFileIterator, error) {
+			return gitserver.NewDiffFileIterator(io.NopCloser(strings.NewReader(diff))), nil
+		})
+		return client
+	}
+
+	t.Run("changes", func(t *testing.T) {
+		client := newClient("diff --git README.md README.md\n--- README.md\n+++ README.md\n@@ -1 +1 @@\n-Hello\n+Hello world\n")
+
+		diff, err := mergeCommitDiff(ctx, client, "repo", "m3rg3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(diff), "-Hello\n+Hello world\n") {
+			t.Fatalf("unexpected diff:\n%s", diff)
+		}
+		if opts := client.DiffFunc.History()[0].Arg2; opts.Base != "m3rg3^" || opts.Head != "m3rg3" {
+			t.Fatalf("unexpected diff range: %s..%s", opts.Base, opts.Head)
+		}
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		_, err := mergeCommitDiff(ctx, newClient(""), "repo", "m3rg3")
+		if err == nil || !errcode.IsNonRetryable(err) {
+			t.Fatalf("expected non-retryable error, got %v", err)
+		}
+	})
+}
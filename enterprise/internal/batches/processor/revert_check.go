@@ -0,0 +1,114 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	godiff "github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// checkPatchApplies returns a revertConflictErr if the given unified diff,
+// which uses filenames without the `a/` and `b/` prefixes, doesn't apply to the
+// files at the given commit. Like `git apply`, hunks may apply at an offset
+// from the line numbers in their header, but no fuzz is allowed.
+//
+// Unlike applying the diff with CreateCommitFromPatch, the check only reads
+// from gitserver and doesn't create any refs.
+func checkPatchApplies(ctx context.Context, client gitserver.Client, repo api.RepoName, commit api.CommitID, patch []byte) error {
+	fileDiffs, err := godiff.ParseMultiFileDiff(patch)
+	if err != nil {
+		return errors.Wrap(err, "parsing diff")
+	}
+
+	conflict := func(format string, args ...any) error {
+		return revertConflictErr{repo: repo, output: fmt.Sprintf(format, args...)}
+	}
+
+	for _, fd := range fileDiffs {
+		// Added files must not exist yet.
+		if fd.OrigName == "/dev/null" {
+			_, err := client.ReadFile(ctx, authz.DefaultSubRepoPermsChecker, repo, commit, fd.NewName)
+			if err == nil {
+				return conflict("error: %s: already exists in working directory", fd.NewName)
+			}
+			if !os.IsNotExist(err) {
+				return errors.Wrapf(err, "reading %s", fd.NewName)
+			}
+			continue
+		}
+
+		content, err := client.ReadFile(ctx, authz.DefaultSubRepoPermsChecker, repo, commit, fd.OrigName)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return conflict("error: %s: does not exist in index", fd.OrigName)
+			}
+			return errors.Wrapf(err, "reading %s", fd.OrigName)
+		}
+
+		lines := strings.SplitAfter(string(content), "\n")
+		offset := 0
+		for _, hunk := range fd.Hunks {
+			pos, ok := findHunk(lines, hunkOrigLines(hunk), int(hunk.OrigStartLine)-1+offset)
+			if !ok {
+				return conflict("error: patch failed: %s:%d\nerror: %s: patch does not apply", fd.OrigName, hunk.OrigStartLine, fd.OrigName)
+			}
+			offset = pos - (int(hunk.OrigStartLine) - 1)
+		}
+	}
+
+	return nil
+}
+
+// hunkOrigLines returns the context and removed lines of the given hunk, which
+// have to be present in the file for the hunk to apply.
+func hunkOrigLines(hunk *godiff.Hunk) []string {
+	var lines []string
+	for _, line := range strings.SplitAfter(string(hunk.Body), "\n") {
+		if line == "" {
+			continue
+		}
+		switch line[0] {
+		case ' ', '-':
+			lines = append(lines, line[1:])
+		case '\\':
+			// "\ No newline at end of file" applies to the previous line.
+			if n := len(lines); n > 0 {
+				lines[n-1] = strings.TrimSuffix(lines[n-1], "\n")
+			}
+		}
+	}
+	return lines
+}
+
+// findHunk returns the position of want in lines that is closest to the given
+// position.
+func findHunk(lines, want []string, pos int) (int, bool) {
+	matches := func(pos int) bool {
+		if pos < 0 || pos+len(want) > len(lines) {
+			return false
+		}
+		for i, line := range want {
+			if lines[pos+i] != line {
+				return false
+			}
+		}
+		return true
+	}
+
+	for delta := 0; pos-delta >= 0 || pos+delta <= len(lines); delta++ {
+		if matches(pos - delta) {
+			return pos - delta, true
+		}
+		if matches(pos + delta) {
+			return pos + delta, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,72 @@
+package processor
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestCheckPatchApplies(t *testing.T) {
+	files := map[string]string{
+		"README.md": "# Title\n\nHello world\n",
+		"main.go":   "package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n",
+	}
+
+	client := gitserver.NewMockClient()
+	client.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, name string) ([]byte, error) {
+		content, ok := files[name]
+		if !ok {
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+		}
+		return []byte(content), nil
+	})
+
+	for name, tc := range map[string]struct {
+		patch    string
+		conflict bool
+	}{
+		"applies": {
+			patch: "diff --git README.md README.md\n--- README.md\n+++ README.md\n@@ -3 +3 @@\n-Hello world\n+Hello\n",
+		},
+		"applies at an offset": {
+			patch: "diff --git README.md README.md\n--- README.md\n+++ README.md\n@@ -1 +1 @@\n-Hello world\n+Hello\n",
+		},
+		"multiple hunks": {
+			patch: "diff --git main.go main.go\n--- main.go\n+++ main.go\n@@ -1,2 +1,2 @@\n-package main\n+package other\n \n@@ -4 +4 @@\n-\tprintln(\"hello\")\n+\tprintln(\"bye\")\n",
+		},
+		"added file": {
+			patch: "diff --git new.go new.go\n--- /dev/null\n+++ new.go\n@@ -0,0 +1 @@\n+package main\n",
+		},
+		"deleted file": {
+			patch: "diff --git README.md README.md\n--- README.md\n+++ /dev/null\n@@ -1,3 +0,0 @@\n-# Title\n-\n-Hello world\n",
+		},
+		"changed line": {
+			patch:    "diff --git README.md README.md\n--- README.md\n+++ README.md\n@@ -3 +3 @@\n-Goodbye world\n+Hello\n",
+			conflict: true,
+		},
+		"added file exists": {
+			patch:    "diff --git main.go main.go\n--- /dev/null\n+++ main.go\n@@ -0,0 +1 @@\n+package main\n",
+			conflict: true,
+		},
+		"missing file": {
+			patch:    "diff --git gone.go gone.go\n--- gone.go\n+++ gone.go\n@@ -1 +1 @@\n-package gone\n+package main\n",
+			conflict: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := checkPatchApplies(context.Background(), client, "github.com/sourcegraph/sourcegraph", "c0ff33", []byte(tc.patch))
+			var conflict revertConflictErr
+			if have := errors.As(err, &conflict); have != tc.conflict {
+				t.Fatalf("unexpected result. want conflict=%v, have err=%v", tc.conflict, err)
+			}
+			if !tc.conflict && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
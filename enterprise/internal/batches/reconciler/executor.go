@@ -17,15 +17,20 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/syncer"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/webhooks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/own"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/repos"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -100,12 +105,21 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (afterDone func(store *s
 
 		case btypes.ReconcilerOperationPush:
 			afterDone, err = e.pushChangesetPatch(ctx, triggerUpdateWebhook)
+			if err == nil && plan.Delta != nil && plan.Delta.Rebased {
+				err = e.commentRebased(ctx)
+			}
 
 		case btypes.ReconcilerOperationPublish:
 			afterDone, err = e.publishChangeset(ctx, false)
+			if err == nil {
+				e.requestOwnershipReviews(ctx)
+			}
 
 		case btypes.ReconcilerOperationPublishDraft:
 			afterDone, err = e.publishChangeset(ctx, true)
+			if err == nil {
+				e.requestOwnershipReviews(ctx)
+			}
 
 		case btypes.ReconcilerOperationReopen:
 			afterDone, err = e.reopenChangeset(ctx)
@@ -131,6 +145,9 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (afterDone func(store *s
 		case btypes.ReconcilerOperationReattach:
 			e.reattachChangeset()
 
+		case btypes.ReconcilerOperationRebase:
+			err = e.rebaseChangeset(ctx)
+
 		default:
 			err = errors.Errorf("executor operation %q not implemented", op)
 		}
@@ -154,7 +171,19 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (afterDone func(store *s
 
 	e.ch.PreviousFailureMessage = nil
 
-	return afterDone, e.tx.UpdateChangeset(ctx, e.ch)
+	if err := e.tx.UpdateChangeset(ctx, e.ch); err != nil {
+		return afterDone, err
+	}
+
+	// A changeset that was closed without being merged no longer blocks the
+	// changesets of later ordering groups of its batch changes.
+	if plan.Ops.Contains(btypes.ReconcilerOperationClose) && state.UnblocksDownstream(e.ch.ExternalState) {
+		if err := syncer.EnqueueUnblockedChangesets(ctx, e.tx, e.ch); err != nil {
+			return afterDone, errors.Wrap(err, "enqueueing unblocked changesets")
+		}
+	}
+
+	return afterDone, nil
 }
 
 var errCannotPushToArchivedRepo = errcode.MakeNonRetryable(errors.New("cannot push to an archived repo"))
@@ -202,6 +231,10 @@ func (e *executor) pushChangesetPatch(ctx context.Context, triggerUpdateWebhook
 		return afterDone, err
 	}
 	opts := buildCommitOpts(e.targetRepo, e.spec, pushConf)
+	crcss, isChangeRefSource := css.(sources.ChangeRefChangesetSource)
+	if isChangeRefSource {
+		opts = crcss.BuildCommitOpts(e.targetRepo, e.ch, e.spec, opts)
+	}
 
 	err = e.pushCommit(ctx, opts)
 	var pce pushCommitError
@@ -214,6 +247,13 @@ func (e *executor) pushChangesetPatch(ctx context.Context, triggerUpdateWebhook
 				return afterDone, errCannotPushToArchivedRepo
 			}
 		}
+
+		// Pushing a commit that is already the current patch set of a change
+		// is rejected, but it means that the change is already up to date,
+		// for example because we're retrying after a later step failed.
+		if isChangeRefSource && crcss.IsNoChangesPushError(pce.CombinedOutput) {
+			err = nil
+		}
 	}
 
 	if triggerUpdateWebhook && err == nil {
@@ -540,6 +580,157 @@ func (e *executor) undraftChangeset(ctx context.Context) (afterDone func(store *
 	return afterDone, nil
 }
 
+// rebaseChangeset re-executes the workspace that produced the current spec of
+// the changeset against the latest commit of its base branch. Once the
+// execution completes, the changeset is enqueued with the new spec and the
+// rebased commit is pushed.
+func (e *executor) rebaseChangeset(ctx context.Context) error {
+	workspace, err := e.tx.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ChangesetSpecID: e.spec.ID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			// The batch spec has been executed locally, so there is no
+			// workspace we could re-execute.
+			e.logger.Info("Not rebasing changeset without workspace", log.Int64("changeset", e.ch.ID))
+			return nil
+		}
+		return errors.Wrap(err, "loading batch spec workspace")
+	}
+
+	head, err := e.client.ResolveRevision(ctx, e.targetRepo.Name, e.spec.BaseRef, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return errors.Wrap(err, "resolving base branch")
+	}
+
+	// If the changeset is already based on the latest commit of its base
+	// branch, re-executing the steps won't resolve the conflict.
+	if string(head) == e.spec.BaseRev {
+		return nil
+	}
+
+	rebased, err := e.tx.RebaseBatchSpecWorkspace(ctx, workspace.ID, string(head))
+	if err != nil {
+		return errors.Wrap(err, "rebasing batch spec workspace")
+	}
+	if rebased {
+		e.logger.Info("Rebasing changeset", log.Int64("changeset", e.ch.ID), log.String("commit", string(head)))
+	}
+	return nil
+}
+
+// commentRebased leaves a comment on the changeset to let its reviewers know
+// that the changeset has been rebased.
+func (e *executor) commentRebased(ctx context.Context) error {
+	css, err := e.changesetSource(ctx)
+	if err != nil {
+		return err
+	}
+
+	remoteRepo, err := e.remoteRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	cs := &sources.Changeset{
+		RemoteRepo: remoteRepo,
+		TargetRepo: e.targetRepo,
+		Changeset:  e.ch,
+	}
+	return errors.Wrap(css.CreateComment(ctx, cs, rebasedComment(e.spec)), "commenting on rebased changeset")
+}
+
+func rebasedComment(spec *btypes.ChangesetSpec) string {
+	rev := spec.BaseRev
+	if len(rev) > 7 {
+		rev = rev[:7]
+	}
+	return fmt.Sprintf(
+		"This changeset conflicted with its base branch `%s`, so it has been rebased automatically: "+
+			"the steps of its batch change were executed again against the latest commit of the base branch (%s) and the result was force-pushed.",
+		gitdomain.AbbreviateRef(spec.BaseRef),
+		rev,
+	)
+}
+
+// requestOwnershipReviews requests reviews of the newly published changeset
+// from the code owners of the files it changes, if the batch spec of the
+// changeset asks for it. Since the changeset has been published at this point,
+// failing to request reviews is logged, but doesn't fail the reconciliation.
+func (e *executor) requestOwnershipReviews(ctx context.Context) {
+	if err := e.doRequestOwnershipReviews(ctx); err != nil {
+		e.logger.Warn("Requesting reviews from code owners failed", log.Int64("changeset", e.ch.ID), log.Error(err))
+	}
+}
+
+func (e *executor) doRequestOwnershipReviews(ctx context.Context) error {
+	batchSpec, err := e.tx.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: e.spec.BatchSpecID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch spec")
+	}
+	if batchSpec.Spec == nil || batchSpec.Spec.Reviewers != batches.ReviewersFromOwnership {
+		return nil
+	}
+
+	css, err := e.changesetSource(ctx)
+	if err != nil {
+		return err
+	}
+	rcss, ok := css.(sources.ReviewerRequestingChangesetSource)
+	if !ok {
+		e.logger.Info("Changeset source doesn't support requesting reviewers", log.Int64("changeset", e.ch.ID))
+		return nil
+	}
+
+	files, err := e.spec.ChangedFiles()
+	if err != nil {
+		return errors.Wrap(err, "parsing diff")
+	}
+
+	db := e.tx.DatabaseDB()
+	owners, err := codeOwners(ctx, own.NewService(e.client, db), db.Teams(), e.targetRepo, api.CommitID(e.spec.BaseRev), files)
+	if err != nil {
+		return errors.Wrap(err, "determining code owners")
+	}
+
+	var accounts []*extsvc.Account
+	for _, userID := range owners {
+		if len(accounts) == maxOwnershipReviewers {
+			break
+		}
+		// Code hosts don't allow requesting a review from the author of a
+		// changeset.
+		if userID == batchSpec.UserID {
+			continue
+		}
+		userAccounts, err := db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+			UserID:         userID,
+			ServiceType:    e.targetRepo.ExternalRepo.ServiceType,
+			ServiceID:      e.targetRepo.ExternalRepo.ServiceID,
+			ExcludeExpired: true,
+		})
+		if err != nil {
+			return errors.Wrap(err, "listing external accounts")
+		}
+		if len(userAccounts) > 0 {
+			accounts = append(accounts, userAccounts[0])
+		}
+	}
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	remoteRepo, err := e.remoteRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	cs := &sources.Changeset{
+		RemoteRepo: remoteRepo,
+		TargetRepo: e.targetRepo,
+		Changeset:  e.ch,
+	}
+	return errors.Wrap(rcss.RequestReviewers(ctx, cs, accounts), "requesting reviewers")
+}
+
 // sleep sleeps for 3 seconds.
 func (e *executor) sleep() {
 	if !e.noSleepBeforeSync {
@@ -15,6 +15,7 @@ var operationPrecedence = map[btypes.ReconcilerOperation]int{
 	btypes.ReconcilerOperationDetach:       0,
 	btypes.ReconcilerOperationArchive:      0,
 	btypes.ReconcilerOperationReattach:     0,
+	btypes.ReconcilerOperationRebase:       0,
 	btypes.ReconcilerOperationImport:       1,
 	btypes.ReconcilerOperationPublish:      1,
 	btypes.ReconcilerOperationPublishDraft: 1,
@@ -308,6 +309,14 @@ func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicatio
 		delta.Undraft = true
 	}
 
+	// A spec of the same batch spec that is based on a different commit was
+	// created by re-executing the workspace to rebase the changeset onto a
+	// newer commit of its base branch. Even if the diff is the same, the
+	// commit needs to be pushed to resolve the conflict.
+	if previous.BatchSpecID == current.BatchSpecID && previous.BaseRev != current.BaseRev {
+		delta.Rebased = true
+	}
+
 	// Diff
 	currentDiff := current.Diff
 	previousDiff := previous.Diff
@@ -348,12 +357,13 @@ type ChangesetSpecDelta struct {
 	CommitMessageChanged bool
 	AuthorNameChanged    bool
 	AuthorEmailChanged   bool
+	Rebased              bool
 }
 
 func (d *ChangesetSpecDelta) String() string { return fmt.Sprintf("%#v", d) }
 
 func (d *ChangesetSpecDelta) NeedCommitUpdate() bool {
-	return d.DiffChanged || d.CommitMessageChanged || d.AuthorNameChanged || d.AuthorEmailChanged
+	return d.DiffChanged || d.CommitMessageChanged || d.AuthorNameChanged || d.AuthorEmailChanged || d.Rebased
 }
 
 func (d *ChangesetSpecDelta) NeedCodeHostUpdate() bool {
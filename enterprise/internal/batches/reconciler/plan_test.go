@@ -255,6 +255,28 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 				btypes.ReconcilerOperationSync,
 			},
 		},
+		{
+			name:         "base rev changed by rebase on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, BatchSpec: 1, BaseRev: "d34db33f"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, BatchSpec: 1, BaseRev: "c0ffee00"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
+		{
+			name:         "base rev changed by new batch spec on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, BatchSpec: 1, BaseRev: "d34db33f"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, BatchSpec: 2, BaseRev: "c0ffee00"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{},
+		},
 		{
 			name:         "commit diff changed on merge changeset",
 			previousSpec: &bt.TestSpecOpts{Published: true, CommitDiff: []byte("testDiff")},
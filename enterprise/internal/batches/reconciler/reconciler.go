@@ -3,15 +3,19 @@ package reconciler
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/guardrails"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // Reconciler processes changesets and reconciles their current state — in
@@ -97,6 +101,51 @@ func (r *Reconciler) process(ctx context.Context, logger log.Logger, tx *store.S
 		return nil, err
 	}
 
+	// Changesets that are blocked by the ordering groups of their batch change
+	// are not published until they are unblocked.
+	if plan.Ops.Contains(btypes.ReconcilerOperationPublish) ||
+		plan.Ops.Contains(btypes.ReconcilerOperationPublishDraft) ||
+		plan.Ops.Contains(btypes.ReconcilerOperationUndraft) {
+		blocked, err := publicationBlocked(ctx, tx, ch, curr)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			logger.Info("Holding publication of changeset blocked by ordering group", log.Int64("changeset", ch.ID))
+			return nil, nil
+		}
+
+		if err := checkPublicationGuardrails(ctx, logger, tx, ch, curr, plan); err != nil {
+			var deferred *guardrails.PublicationDeferred
+			if !errors.As(err, &deferred) {
+				return nil, err
+			}
+
+			// Changesets outside of the publication windows are processed
+			// again once the next window opens. We check back at least every
+			// publicationWindowRecheckInterval in case the windows changed.
+			now := tx.Clock()()
+			after := now.Add(publicationWindowRecheckInterval)
+			if deferred.Until != nil && deferred.Until.Before(after) {
+				after = *deferred.Until
+			}
+			logger.Info("Deferring publication of changeset outside of the publication windows", log.Int64("changeset", ch.ID), log.Time("after", after))
+			return nil, tx.RequeueChangeset(ctx, ch, after)
+		}
+	}
+
+	// Changesets that conflict with their base branch are rebased, if their
+	// batch change opted into it and there is nothing else to do.
+	if plan.Ops.IsNone() {
+		rebase, err := wantsRebase(ctx, tx, ch, curr)
+		if err != nil {
+			return nil, err
+		}
+		if rebase {
+			plan.AddOp(btypes.ReconcilerOperationRebase)
+		}
+	}
+
 	logger.Info("Reconciler processing changeset", log.Int64("changeset", ch.ID), log.String("operations", fmt.Sprintf("%+v", plan.Ops)))
 
 	return executePlan(
@@ -125,3 +174,88 @@ func loadChangesetSpecs(ctx context.Context, tx *store.Store, ch *btypes.Changes
 	}
 	return
 }
+
+// publicationBlocked returns true if the changeset must not be published yet,
+// because the ordering groups of its batch spec hold its publication until the
+// changesets of an earlier group have been merged.
+func publicationBlocked(ctx context.Context, tx *store.Store, ch *btypes.Changeset, spec *btypes.ChangesetSpec) (bool, error) {
+	if spec == nil || ch.OwnedByBatchChangeID == 0 {
+		return false, nil
+	}
+
+	batchSpec, err := tx.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: spec.BatchSpecID})
+	if err != nil {
+		return false, errors.Wrap(err, "loading batch spec")
+	}
+
+	order, err := state.LoadChangesetOrder(ctx, tx, ch.OwnedByBatchChangeID, batchSpec.Spec)
+	if err != nil {
+		return false, errors.Wrap(err, "loading changeset order")
+	}
+	return order.HoldsPublication(ch.ID) && order.Blocked(ch.ID), nil
+}
+
+// publicationWindowRecheckInterval is the maximum time a changeset that can't be
+// published outside of the publication windows waits before it is processed
+// again.
+const publicationWindowRecheckInterval = time.Hour
+
+// checkPublicationGuardrails returns a non-retryable error if publishing the
+// changeset as planned violates the guardrails in the site configuration, and
+// a *guardrails.PublicationDeferred if the changeset can't be published until
+// the next publication window opens. Violations are recorded in the audit log.
+func checkPublicationGuardrails(ctx context.Context, logger log.Logger, tx *store.Store, ch *btypes.Changeset, spec *btypes.ChangesetSpec, plan *Plan) error {
+	if spec == nil {
+		return nil
+	}
+
+	g, err := guardrails.Current()
+	if err != nil {
+		return errors.Wrap(err, "parsing batch changes guardrails")
+	}
+
+	repo, err := tx.Repos().Get(ctx, ch.RepoID)
+	if err != nil {
+		return errors.Wrap(err, "loading repo")
+	}
+
+	violated := func(err error) error {
+		var v *guardrails.Violation
+		if errors.As(err, &v) {
+			v.Audit(ctx, logger, log.Int64("changeset", ch.ID), log.String("repo", string(repo.Name)))
+		}
+		return err
+	}
+
+	// Changesets that are undrafted have been published as a draft before.
+	draft := plan.Ops.Contains(btypes.ReconcilerOperationPublishDraft) || plan.Ops.Contains(btypes.ReconcilerOperationUndraft)
+	if err := g.CheckPublication(tx.Clock()(), repo.Name, draft); err != nil {
+		return violated(err)
+	}
+
+	files, err := spec.ChangedFiles()
+	if err != nil {
+		return errors.Wrap(err, "parsing changeset diff")
+	}
+	return violated(g.CheckChangedFiles(repo.Name, files))
+}
+
+// wantsRebase returns true if the open changeset conflicts with its base branch
+// and the batch spec that created it opted into automatic rebases.
+func wantsRebase(ctx context.Context, tx *store.Store, ch *btypes.Changeset, spec *btypes.ChangesetSpec) (bool, error) {
+	if spec == nil || ch.OwnedByBatchChangeID == 0 || !ch.Published() {
+		return false, nil
+	}
+	if ch.ExternalState != btypes.ChangesetExternalStateOpen && ch.ExternalState != btypes.ChangesetExternalStateDraft {
+		return false, nil
+	}
+	if !state.HasMergeConflict(ch) {
+		return false, nil
+	}
+
+	batchSpec, err := tx.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: spec.BatchSpecID})
+	if err != nil {
+		return false, errors.Wrap(err, "loading batch spec")
+	}
+	return batchSpec.Spec != nil && batchSpec.Spec.AutoRebase, nil
+}
@@ -12,6 +12,7 @@ import (
 	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
@@ -19,6 +20,8 @@ import (
 	gitprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestReconcilerProcess_IntegrationTest(t *testing.T) {
@@ -175,3 +178,75 @@ func TestReconcilerProcess_IntegrationTest(t *testing.T) {
 		bt.TruncateTables(t, db, "changeset_events", "changesets", "batch_changes", "batch_specs", "changeset_specs")
 	}
 }
+
+func TestReconcilerProcess_PublicationWindows(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	// 2023-04-11 10:00 UTC is a Tuesday.
+	now := time.Date(2023, 4, 11, 10, 0, 0, 0, time.UTC)
+	store := bstore.NewWithClock(db, &observation.TestContext, nil, func() time.Time { return now })
+
+	admin := bt.CreateTestUser(t, db, true)
+	repo, _ := bt.CreateTestRepo(t, ctx, db)
+
+	bt.MockConfig(t, &conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		BatchChangesGuardrails: &schema.BatchChangesGuardrails{
+			PublicationWindows: []*schema.BatchChangesPublicationWindow{
+				{Days: []string{"monday"}, Start: "09:00", End: "17:00"},
+			},
+		},
+	}})
+
+	batchSpec := bt.CreateBatchSpec(t, ctx, store, "reconciler-test-batch-change", admin.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, store, "reconciler-test-batch-change", admin.ID, batchSpec.ID)
+	changesetSpec := bt.CreateChangesetSpec(t, ctx, store, bt.TestSpecOpts{
+		User:      admin.ID,
+		Repo:      repo.ID,
+		BatchSpec: batchSpec.ID,
+		HeadRef:   "refs/heads/head-ref",
+		Typ:       btypes.ChangesetSpecTypeBranch,
+		Published: true,
+	})
+	changeset := bt.CreateChangeset(t, ctx, store, bt.TestChangesetOpts{
+		Repo:               repo.ID,
+		BatchChanges:       []btypes.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+		OwnedByBatchChange: batchChange.ID,
+		CurrentSpec:        changesetSpec.ID,
+		PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+		ReconcilerState:    btypes.ReconcilerStateProcessing,
+	})
+
+	// The reconciler must not reach out to the code host.
+	rec := Reconciler{
+		noSleepBeforeSync: true,
+		sourcer:           stesting.NewFakeSourcer(errors.New("unexpected call to sourcer"), nil),
+		store:             store,
+	}
+	if _, err := rec.process(ctx, logger, store, changeset); err != nil {
+		t.Fatalf("reconciler process failed: %s", err)
+	}
+
+	// The changeset is not failed, but requeued to be published once the
+	// window opens, which is at most an hour from now.
+	bt.ReloadAndAssertChangeset(t, ctx, store, changeset, bt.ChangesetAssertions{
+		Repo:               repo.ID,
+		OwnedByBatchChange: batchChange.ID,
+		AttachedTo:         []int64{batchChange.ID},
+		CurrentSpec:        changesetSpec.ID,
+		ReconcilerState:    btypes.ReconcilerStateQueued,
+		PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+	})
+	reloaded, err := store.GetChangesetByID(ctx, changeset.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := now.Add(publicationWindowRecheckInterval); !reloaded.ProcessAfter.Equal(want) {
+		t.Fatalf("unexpected process after. want=%s have=%s", want, reloaded.ProcessAfter)
+	}
+}
@@ -0,0 +1,101 @@
+package reconciler
+
+import (
+	"context"
+	"sort"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/own"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners"
+	codeownerspb "github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners/v1"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxOwnershipReviewers is the maximum number of code owners that reviews of a
+// changeset are requested from. Most code hosts limit the number of reviewers
+// of a changeset, and requesting reviews from a large team isn't useful.
+const maxOwnershipReviewers = 10
+
+// codeOwners returns the IDs of the users that own the given files, according
+// to the CODEOWNERS ruleset of the repository at the given commit. Teams that
+// own files are expanded into their members.
+func codeOwners(ctx context.Context, svc own.Service, teams database.TeamStore, repo *types.Repo, commit api.CommitID, files []string) ([]int32, error) {
+	ruleset, err := svc.RulesetForRepo(ctx, repo.Name, repo.ID, commit)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading CODEOWNERS ruleset")
+	}
+	if ruleset == nil {
+		return nil, nil
+	}
+
+	type ownerKey struct{ handle, email string }
+	seen := map[ownerKey]struct{}{}
+	var owners []*codeownerspb.Owner
+	for _, file := range files {
+		for _, owner := range ruleset.Match(file).GetOwner() {
+			key := ownerKey{owner.Handle, owner.Email}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			owners = append(owners, owner)
+		}
+	}
+	if len(owners) == 0 {
+		return nil, nil
+	}
+
+	resolved, err := svc.ResolveOwnersWithType(ctx, owners)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving owners")
+	}
+
+	userIDs := map[int32]struct{}{}
+	for _, owner := range resolved {
+		switch o := owner.(type) {
+		case *codeowners.Person:
+			if o.User != nil {
+				userIDs[o.User.ID] = struct{}{}
+			}
+		case *codeowners.Team:
+			if o.Team == nil {
+				continue
+			}
+			members, err := listTeamMembers(ctx, teams, o.Team.ID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "listing members of team %q", o.Team.Name)
+			}
+			for _, id := range members {
+				userIDs[id] = struct{}{}
+			}
+		}
+	}
+
+	ids := make([]int32, 0, len(userIDs))
+	for id := range userIDs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+func listTeamMembers(ctx context.Context, teams database.TeamStore, teamID int32) ([]int32, error) {
+	opts := database.ListTeamMembersOpts{TeamID: teamID}
+
+	var ids []int32
+	for {
+		members, next, err := teams.ListTeamMembers(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			ids = append(ids, m.UserID)
+		}
+		if next == nil {
+			return ids, nil
+		}
+		opts.Cursor = *next
+	}
+}
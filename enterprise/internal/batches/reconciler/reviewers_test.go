@@ -0,0 +1,90 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners"
+	codeownerspb "github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners/v1"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCodeOwners(t *testing.T) {
+	ctx := context.Background()
+	repo := &types.Repo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	files := []string{"README.md", "cmd/main.go", "cmd/app.go", "web/index.js"}
+
+	svc := fakeOwnService{
+		ruleset: codeowners.NewRuleset(
+			codeowners.GitRulesetSource{Repo: repo.ID, Commit: "deadbeef", Path: "CODEOWNERS"},
+			&codeownerspb.File{
+				Rule: []*codeownerspb.Rule{
+					{Pattern: "*", Owner: []*codeownerspb.Owner{{Handle: "alice"}}, LineNumber: 1},
+					{Pattern: "/cmd/", Owner: []*codeownerspb.Owner{{Handle: "bob"}, {Email: "unknown@example.com"}}, LineNumber: 2},
+					{Pattern: "*.js", Owner: []*codeownerspb.Owner{{Handle: "frontend"}}, LineNumber: 3},
+					{Pattern: "/docs/", Owner: []*codeownerspb.Owner{{Handle: "carol"}}, LineNumber: 4},
+				},
+			},
+		),
+		users: map[string]int32{"alice": 1, "bob": 2, "carol": 3},
+		teams: map[string]int32{"frontend": 10},
+	}
+
+	teams := database.NewMockTeamStore()
+	teams.ListTeamMembersFunc.SetDefaultHook(func(_ context.Context, opts database.ListTeamMembersOpts) ([]*types.TeamMember, *database.TeamMemberListCursor, error) {
+		if opts.TeamID != 10 {
+			t.Fatalf("unexpected team %d", opts.TeamID)
+		}
+		return []*types.TeamMember{{TeamID: 10, UserID: 4}, {TeamID: 10, UserID: 1}}, nil, nil
+	})
+
+	t.Run("owners of changed files", func(t *testing.T) {
+		have, err := codeOwners(ctx, svc, teams, repo, "deadbeef", files)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int32{1, 2, 4}, have); diff != "" {
+			t.Errorf("unexpected owners (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("no CODEOWNERS", func(t *testing.T) {
+		have, err := codeOwners(ctx, fakeOwnService{}, teams, repo, "deadbeef", files)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Errorf("unexpected owners: %v", have)
+		}
+	})
+}
+
+// fakeOwnService returns the given ruleset and resolves the handles of owners
+// to the given users and teams.
+type fakeOwnService struct {
+	ruleset *codeowners.Ruleset
+	users   map[string]int32
+	teams   map[string]int32
+}
+
+func (s fakeOwnService) RulesetForRepo(context.Context, api.RepoName, api.RepoID, api.CommitID) (*codeowners.Ruleset, error) {
+	return s.ruleset, nil
+}
+
+func (s fakeOwnService) ResolveOwnersWithType(_ context.Context, owners []*codeownerspb.Owner) ([]codeowners.ResolvedOwner, error) {
+	var resolved []codeowners.ResolvedOwner
+	for _, o := range owners {
+		if id, ok := s.users[o.Handle]; ok {
+			resolved = append(resolved, &codeowners.Person{User: &types.User{ID: id, Username: o.Handle}, Handle: o.Handle})
+		} else if id, ok := s.teams[o.Handle]; ok {
+			resolved = append(resolved, &codeowners.Team{Team: &types.Team{ID: id, Name: o.Handle}, Handle: o.Handle})
+		} else {
+			resolved = append(resolved, &codeowners.Person{Handle: o.Handle, Email: o.Email})
+		}
+	}
+	return resolved, nil
+}
@@ -0,0 +1,291 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	bgql "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/graphql"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/syncer"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const autoMergeInterval = 1 * time.Minute
+
+// NewAutoMerger creates a new goroutine.PeriodicGoroutine that evaluates the
+// auto-merge policies of open batch changes against their changesets, and
+// merges the changesets that satisfy them.
+func NewAutoMerger(ctx context.Context, logger log.Logger, bstore *store.Store, client gitserver.Client, sourcer sources.Sourcer) goroutine.BackgroundRoutine {
+	m := &autoMerger{
+		logger:  logger,
+		store:   bstore,
+		client:  client,
+		sourcer: sourcer,
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		"batchchanges.auto-merger", "merges changesets that satisfy the auto-merge policy of their batch change",
+		autoMergeInterval,
+		goroutine.HandlerFunc(m.run),
+	)
+}
+
+type autoMerger struct {
+	logger  log.Logger
+	store   *store.Store
+	client  gitserver.Client
+	sourcer sources.Sourcer
+}
+
+func (m *autoMerger) run(ctx context.Context) error {
+	batchChanges, _, err := m.store.ListBatchChanges(ctx, store.ListBatchChangesOpts{
+		States:                  []btypes.BatchChangeState{btypes.BatchChangeStateOpen},
+		OnlyWithAutoMergePolicy: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing batch changes")
+	}
+	if len(batchChanges) == 0 {
+		return nil
+	}
+
+	now := m.store.Clock()().UTC()
+
+	// The number of changesets merged by any auto-merge policy in the last
+	// hour, by the code host they're on. The limit on the number of merges per
+	// hour applies to the code host, so the merges of all batch changes count
+	// towards it.
+	merges, err := m.store.CountChangesetEventsByCodeHost(ctx, store.CountChangesetEventsOpts{
+		Kind:         btypes.ChangesetEventKindAutoMergeMerged,
+		CreatedAfter: now.Add(-1 * time.Hour),
+	})
+	if err != nil {
+		return errors.Wrap(err, "counting merges")
+	}
+
+	var errs error
+	for _, batchChange := range batchChanges {
+		if err := m.processBatchChange(ctx, batchChange, now, merges); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "batch change %d", batchChange.ID))
+		}
+	}
+
+	return errs
+}
+
+// processBatchChange evaluates the auto-merge policy of the batch change against
+// its open changesets at the given time. merges is the number of changesets
+// merged in the last hour by code host, and is updated with the changesets the
+// policy merges.
+func (m *autoMerger) processBatchChange(ctx context.Context, batchChange *btypes.BatchChange, now time.Time, merges map[string]int) error {
+	batchSpec, err := m.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch spec")
+	}
+	if batchSpec.Spec == nil || batchSpec.Spec.AutoMerge == nil {
+		return nil
+	}
+
+	policy, err := newAutoMergePolicy(batchSpec.Spec.AutoMerge)
+	if err != nil {
+		return errors.Wrap(err, "parsing auto-merge policy")
+	}
+
+	order, err := state.LoadChangesetOrder(ctx, m.store, batchChange.ID, batchSpec.Spec)
+	if err != nil {
+		return errors.Wrap(err, "loading changeset order")
+	}
+
+	published := btypes.ChangesetPublicationStatePublished
+	changesets, _, err := m.store.ListChangesets(ctx, store.ListChangesetsOpts{
+		BatchChangeID:        batchChange.ID,
+		OwnedByBatchChangeID: batchChange.ID,
+		PublicationState:     &published,
+		ReconcilerStates:     []btypes.ReconcilerState{btypes.ReconcilerStateCompleted},
+		ExternalStates:       []btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen},
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changesets")
+	}
+	if len(changesets) == 0 {
+		return nil
+	}
+
+	repos, err := m.store.Repos().GetReposSetByIDs(ctx, changesets.RepoIDs()...)
+	if err != nil {
+		return errors.Wrap(err, "loading repos")
+	}
+
+	es, _, err := m.store.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{ChangesetIDs: changesets.IDs()})
+	if err != nil {
+		return errors.Wrap(err, "loading changeset events")
+	}
+	eventsByChangeset := make(map[int64]state.ChangesetEvents, len(changesets))
+	for _, e := range es {
+		eventsByChangeset[e.ChangesetID] = append(eventsByChangeset[e.ChangesetID], e)
+	}
+
+	var errs error
+	for _, ch := range changesets {
+		repo, ok := repos[ch.RepoID]
+		if !ok {
+			errs = errors.Append(errs, errors.Newf("repo of changeset %d not found", ch.ID))
+			continue
+		}
+		codeHost := repo.ExternalRepo.ServiceID
+		events := eventsByChangeset[ch.ID]
+
+		decision := &btypes.AutoMergeDecision{BatchChangeID: batchChange.ID, DecidedAt: now}
+		event := &btypes.ChangesetEvent{ChangesetID: ch.ID, Metadata: decision}
+
+		if key, reason := policy.holdReason(now, ch, order.Blocked(ch.ID), state.CountApprovals(events), merges[codeHost]); key != "" {
+			event.Kind = btypes.ChangesetEventKindAutoMergeHeld
+			event.Key = key
+			decision.Reason = reason
+		} else if err := m.merge(ctx, ch, repo, policy.squash); err != nil {
+			m.logger.Warn("auto-merging changeset failed", log.Int64("changeset", ch.ID), log.Error(err))
+			event.Kind = btypes.ChangesetEventKindAutoMergeFailed
+			decision.Reason = err.Error()
+		} else {
+			merges[codeHost]++
+			event.Kind = btypes.ChangesetEventKindAutoMergeMerged
+		}
+
+		if err := m.store.UpsertChangesetEvents(ctx, event); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "recording auto-merge decision for changeset %d", ch.ID))
+		}
+	}
+
+	return errs
+}
+
+// merge merges the changeset on the code host through the same path the merge
+// bulk operation uses, and updates the changeset with the resulting state.
+func (m *autoMerger) merge(ctx context.Context, ch *btypes.Changeset, repo *types.Repo, squash bool) (err error) {
+	css, err := m.sourcer.ForChangeset(ctx, m.store, ch)
+	if err != nil {
+		return errors.Wrap(err, "loading ChangesetSource")
+	}
+
+	remoteRepo, err := sources.GetRemoteRepo(ctx, css, repo, ch, nil)
+	if err != nil {
+		return errors.Wrap(err, "loading remote repo")
+	}
+
+	cs := &sources.Changeset{
+		Changeset:  ch,
+		TargetRepo: repo,
+		RemoteRepo: remoteRepo,
+	}
+	if err := css.MergeChangeset(ctx, cs, squash); err != nil {
+		return err
+	}
+
+	tx, err := m.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	events, err := cs.Changeset.Events()
+	if err != nil {
+		return errors.Wrap(err, "loading changeset events")
+	}
+	state.SetDerivedState(ctx, tx.Repos(), m.client, cs.Changeset, events)
+
+	if err := tx.UpsertChangesetEvents(ctx, events...); err != nil {
+		return errors.Wrap(err, "upserting changeset events")
+	}
+	if err := tx.UpdateChangesetCodeHostState(ctx, cs.Changeset); err != nil {
+		return errors.Wrap(err, "updating changeset")
+	}
+	if err := syncer.EnqueueUnblockedChangesets(ctx, tx, cs.Changeset); err != nil {
+		return errors.Wrap(err, "enqueueing unblocked changesets")
+	}
+
+	webhooks.EnqueueChangeset(ctx, m.logger, tx, webhooks.ChangesetClose, bgql.MarshalChangesetID(ch.ID))
+	return nil
+}
+
+// Keys of the ChangesetEvents recorded when a changeset is held back by an
+// auto-merge policy, one per criterion of the policy.
+const (
+	autoMergeHoldWindow    = "window"
+	autoMergeHoldRateLimit = "rate_limit"
+	autoMergeHoldOrder     = "order"
+	autoMergeHoldChecks    = "checks"
+	autoMergeHoldReview    = "review"
+	autoMergeHoldConflicts = "conflicts"
+)
+
+// autoMergePolicy is the parsed form of a batches.AutoMergePolicy.
+type autoMergePolicy struct {
+	requirePassingChecks bool
+	minApprovals         int
+	window               *window.Configuration
+	maxMergesPerHour     int
+	squash               bool
+}
+
+func newAutoMergePolicy(raw *batches.AutoMergePolicy) (*autoMergePolicy, error) {
+	var windows []*schema.BatchChangeRolloutWindow
+	if raw.Window != nil {
+		windows = append(windows, &schema.BatchChangeRolloutWindow{
+			Days:  raw.Window.Days,
+			Start: raw.Window.Start,
+			End:   raw.Window.End,
+			Rate:  "unlimited",
+		})
+	}
+	cfg, err := window.NewConfiguration(&windows)
+	if err != nil {
+		return nil, errors.Wrap(err, "merge window")
+	}
+
+	return &autoMergePolicy{
+		requirePassingChecks: raw.CheckState != "any",
+		minApprovals:         raw.MinApprovals,
+		window:               cfg,
+		maxMergesPerHour:     raw.MaxMergesPerHour,
+		squash:               raw.Squash,
+	}, nil
+}
+
+// holdReason returns the key and a human readable description of the first
+// criterion of the policy that the changeset doesn't satisfy, given whether
+// it's blocked by an ordering group, how many approvals it has and how many
+// changesets were merged on its code host in the last hour. If the changeset
+// satisfies the policy, the key is empty.
+func (p *autoMergePolicy) holdReason(now time.Time, ch *btypes.Changeset, blocked bool, approvals, merges int) (key, reason string) {
+	switch {
+	case !p.window.IsOpen(now):
+		return autoMergeHoldWindow, "Outside of the merge window."
+	case p.maxMergesPerHour > 0 && merges >= p.maxMergesPerHour:
+		return autoMergeHoldRateLimit, "The maximum number of merges per hour on the code host has been reached."
+	case blocked:
+		return autoMergeHoldOrder, "Changesets of earlier ordering groups have not been merged yet."
+	case p.requirePassingChecks && ch.ExternalCheckState != btypes.ChangesetCheckStatePassed:
+		return autoMergeHoldChecks, "Checks have not passed."
+	case ch.ExternalReviewState == btypes.ChangesetReviewStateChangesRequested:
+		return autoMergeHoldReview, "Changes have been requested."
+	case approvals < p.minApprovals:
+		return autoMergeHoldReview, "Not enough approvals."
+	case state.HasMergeConflict(ch):
+		return autoMergeHoldConflicts, "The changeset conflicts with its base branch."
+	default:
+		return "", ""
+	}
+}
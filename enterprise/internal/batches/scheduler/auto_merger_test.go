@@ -0,0 +1,222 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+
+	stesting "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/testing"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestAutoMerger_MergesPerHourAcrossBatchChanges(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	s := store.New(db, &observation.TestContext, nil)
+
+	user := bt.CreateTestUser(t, db, true)
+	repo, _ := bt.CreateTestRepo(t, ctx, db)
+
+	createBatchChange := func(name string) *btypes.BatchChange {
+		batchSpec := bt.CreateBatchSpec(t, ctx, s, name, user.ID, 0)
+		batchSpec.Spec.AutoMerge = &batches.AutoMergePolicy{CheckState: "any", MaxMergesPerHour: 1}
+		if err := s.UpdateBatchSpec(ctx, batchSpec); err != nil {
+			t.Fatal(err)
+		}
+		return bt.CreateBatchChange(t, ctx, s, name, user.ID, batchSpec.ID)
+	}
+	createChangeset := func(batchChange *btypes.BatchChange, externalID string, externalState btypes.ChangesetExternalState) *btypes.Changeset {
+		return bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               repo.ID,
+			BatchChange:        batchChange.ID,
+			OwnedByBatchChange: batchChange.ID,
+			ExternalID:         externalID,
+			ExternalState:      externalState,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			Metadata:           &github.PullRequest{Mergeable: "MERGEABLE"},
+		})
+	}
+
+	// The first batch change merged a changeset on the code host in the last
+	// hour, which exhausts the limit of the second batch change on the same
+	// code host.
+	first := createBatchChange("first")
+	merged := createChangeset(first, "1", btypes.ChangesetExternalStateMerged)
+	if err := s.UpsertChangesetEvents(ctx, &btypes.ChangesetEvent{
+		ChangesetID: merged.ID,
+		Kind:        btypes.ChangesetEventKindAutoMergeMerged,
+		Metadata:    &btypes.AutoMergeDecision{BatchChangeID: first.ID, DecidedAt: s.Clock()()},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	second := createBatchChange("second")
+	open := createChangeset(second, "2", btypes.ChangesetExternalStateOpen)
+
+	source := &stesting.FakeChangesetSource{}
+	m := &autoMerger{
+		logger:  logger,
+		store:   s,
+		client:  gitserver.NewMockClient(),
+		sourcer: stesting.NewFakeSourcer(nil, source),
+	}
+	if err := m.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if source.MergeChangesetCalled {
+		t.Fatal("changeset merged despite the limit of merges per hour on the code host")
+	}
+
+	events, _, err := s.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
+		ChangesetIDs: []int64{open.ID},
+		Kinds:        []btypes.ChangesetEventKind{btypes.ChangesetEventKindAutoMergeHeld},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Key != autoMergeHoldRateLimit {
+		t.Fatalf("unexpected auto-merge hold events: %+v", events)
+	}
+}
+
+func TestAutoMergePolicy_HoldReason(t *testing.T) {
+	// Monday 2021-04-05 10:00 UTC.
+	monday := time.Date(2021, 4, 5, 10, 0, 0, 0, time.UTC)
+
+	mergeable := func() *btypes.Changeset {
+		return &btypes.Changeset{
+			ExternalCheckState:  btypes.ChangesetCheckStatePassed,
+			ExternalReviewState: btypes.ChangesetReviewStateApproved,
+			Metadata:            &github.PullRequest{Mergeable: "MERGEABLE"},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		policy    batches.AutoMergePolicy
+		now       time.Time
+		changeset func() *btypes.Changeset
+		blocked   bool
+		approvals int
+		merges    int
+		wantKey   string
+	}{
+		"empty policy": {
+			policy:    batches.AutoMergePolicy{},
+			now:       monday,
+			changeset: mergeable,
+		},
+		"all criteria satisfied": {
+			policy: batches.AutoMergePolicy{
+				CheckState:       "passed",
+				MinApprovals:     2,
+				Window:           &batches.AutoMergeWindow{Days: []string{"monday"}, Start: "09:00", End: "16:00"},
+				MaxMergesPerHour: 5,
+			},
+			now:       monday,
+			changeset: mergeable,
+			approvals: 2,
+			merges:    4,
+		},
+		"outside window": {
+			policy: batches.AutoMergePolicy{
+				Window: &batches.AutoMergeWindow{Days: []string{"monday"}, Start: "09:00", End: "16:00"},
+			},
+			now:       monday.Add(-2 * time.Hour),
+			changeset: mergeable,
+			wantKey:   autoMergeHoldWindow,
+		},
+		"rate limited": {
+			policy:    batches.AutoMergePolicy{MaxMergesPerHour: 5},
+			now:       monday,
+			changeset: mergeable,
+			merges:    5,
+			wantKey:   autoMergeHoldRateLimit,
+		},
+		"blocked by ordering group": {
+			policy:    batches.AutoMergePolicy{},
+			now:       monday,
+			changeset: mergeable,
+			blocked:   true,
+			wantKey:   autoMergeHoldOrder,
+		},
+		"checks pending": {
+			policy: batches.AutoMergePolicy{},
+			now:    monday,
+			changeset: func() *btypes.Changeset {
+				ch := mergeable()
+				ch.ExternalCheckState = btypes.ChangesetCheckStatePending
+				return ch
+			},
+			wantKey: autoMergeHoldChecks,
+		},
+		"checks ignored": {
+			policy: batches.AutoMergePolicy{CheckState: "any"},
+			now:    monday,
+			changeset: func() *btypes.Changeset {
+				ch := mergeable()
+				ch.ExternalCheckState = btypes.ChangesetCheckStateFailed
+				return ch
+			},
+		},
+		"changes requested": {
+			policy: batches.AutoMergePolicy{},
+			now:    monday,
+			changeset: func() *btypes.Changeset {
+				ch := mergeable()
+				ch.ExternalReviewState = btypes.ChangesetReviewStateChangesRequested
+				return ch
+			},
+			wantKey: autoMergeHoldReview,
+		},
+		"not enough approvals": {
+			policy:    batches.AutoMergePolicy{MinApprovals: 2},
+			now:       monday,
+			changeset: mergeable,
+			approvals: 1,
+			wantKey:   autoMergeHoldReview,
+		},
+		"merge conflict": {
+			policy: batches.AutoMergePolicy{},
+			now:    monday,
+			changeset: func() *btypes.Changeset {
+				ch := mergeable()
+				ch.Metadata = &github.PullRequest{Mergeable: "CONFLICTING"}
+				return ch
+			},
+			wantKey: autoMergeHoldConflicts,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			policy, err := newAutoMergePolicy(&tc.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			key, reason := policy.holdReason(tc.now, tc.changeset(), tc.blocked, tc.approvals, tc.merges)
+			if key != tc.wantKey {
+				t.Errorf("unexpected hold key: have=%q want=%q", key, tc.wantKey)
+			}
+			if (key == "") != (reason == "") {
+				t.Errorf("unexpected hold reason %q for key %q", reason, key)
+			}
+		})
+	}
+}
@@ -72,6 +72,7 @@ type Service struct {
 type operations struct {
 	createBatchSpec                      *observation.Operation
 	createBatchSpecFromRaw               *observation.Operation
+	createBatchSpecFromComputeReplace    *observation.Operation
 	executeBatchSpec                     *observation.Operation
 	cancelBatchSpec                      *observation.Operation
 	replaceBatchSpecInput                *observation.Operation
@@ -90,6 +91,7 @@ type operations struct {
 	fetchUsernameForBitbucketServerToken *observation.Operation
 	validateAuthenticator                *observation.Operation
 	createChangesetJobs                  *observation.Operation
+	revertChangesets                     *observation.Operation
 	applyBatchChange                     *observation.Operation
 	reconcileBatchChange                 *observation.Operation
 	validateChangesetSpecs               *observation.Operation
@@ -122,6 +124,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		singletonOperations = &operations{
 			createBatchSpec:                      op("CreateBatchSpec"),
 			createBatchSpecFromRaw:               op("CreateBatchSpecFromRaw"),
+			createBatchSpecFromComputeReplace:    op("CreateBatchSpecFromComputeReplace"),
 			executeBatchSpec:                     op("ExecuteBatchSpec"),
 			cancelBatchSpec:                      op("CancelBatchSpec"),
 			replaceBatchSpecInput:                op("ReplaceBatchSpecInput"),
@@ -140,6 +143,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 			fetchUsernameForBitbucketServerToken: op("FetchUsernameForBitbucketServerToken"),
 			validateAuthenticator:                op("ValidateAuthenticator"),
 			createChangesetJobs:                  op("CreateChangesetJobs"),
+			revertChangesets:                     op("RevertChangesets"),
 			applyBatchChange:                     op("ApplyBatchChange"),
 			reconcileBatchChange:                 op("ReconcileBatchChange"),
 			validateChangesetSpecs:               op("ValidateChangesetSpecs"),
@@ -1332,6 +1336,114 @@ func (s *Service) CreateChangesetJobs(ctx context.Context, batchChangeID int64,
 	return bulkGroupID, nil
 }
 
+// RevertChangesets creates a new batch change in the namespace of the given
+// batch change and a changeset job for each of the given merged changesets,
+// which adds a changeset to the new batch change that reverts the changes of
+// the merged changeset. The new batch change and the ID of the bulk operation
+// are returned.
+func (s *Service) RevertChangesets(ctx context.Context, batchChangeID int64, ids []int64) (revertBatchChange *btypes.BatchChange, bulkGroupID string, err error) {
+	ctx, _, endObservation := s.operations.revertChangesets.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChangeID})
+	if err != nil {
+		return nil, "", errors.Wrap(err, "loading batch change")
+	}
+
+	// 🚨 SECURITY: Only the author of the batch change can revert it. This is
+	// checked again by CreateChangesetJobs, but we need to check it before we
+	// create the new batch change.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+		return nil, "", err
+	}
+
+	// Every revert creates a new batch change, so the name is suffixed with a
+	// counter once the batch change has been reverted before.
+	var batchSpec *btypes.BatchSpec
+	var spec *batcheslib.BatchSpec
+	for i := 1; batchSpec == nil; i++ {
+		name := batchChange.Name + "-revert"
+		if i > 1 {
+			name = fmt.Sprintf("%s-%d", name, i)
+		}
+
+		rawSpec, err := yaml.Marshal(struct {
+			Name        string `yaml:"name"`
+			Description string `yaml:"description"`
+		}{
+			Name:        name,
+			Description: fmt.Sprintf("Reverts the merged changesets of the batch change %s.", batchChange.Name),
+		})
+		if err != nil {
+			return nil, "", errors.Wrap(err, "marshalling batch spec")
+		}
+		spec, err = batcheslib.ParseBatchSpec(rawSpec)
+		if err != nil {
+			return nil, "", err
+		}
+
+		candidate := &btypes.BatchSpec{
+			RawSpec:         string(rawSpec),
+			Spec:            spec,
+			NamespaceUserID: batchChange.NamespaceUserID,
+			NamespaceOrgID:  batchChange.NamespaceOrgID,
+			UserID:          sgactor.FromContext(ctx).UID,
+			CreatedFromRaw:  true,
+		}
+		existing, err := s.GetBatchChangeMatchingBatchSpec(ctx, candidate)
+		if err != nil {
+			return nil, "", err
+		}
+		if existing == nil {
+			batchSpec = candidate
+		}
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.CreateBatchSpec(ctx, batchSpec); err != nil {
+		return nil, "", err
+	}
+
+	// The batch change is applied right away, so that the reverting changesets
+	// can be published like the changesets of any other batch change.
+	a := sgactor.FromContext(ctx)
+	revertBatchChange = &btypes.BatchChange{
+		Name:            spec.Name,
+		Description:     spec.Description,
+		NamespaceUserID: batchChange.NamespaceUserID,
+		NamespaceOrgID:  batchChange.NamespaceOrgID,
+		BatchSpecID:     batchSpec.ID,
+		CreatorID:       a.UID,
+		LastApplierID:   a.UID,
+		LastAppliedAt:   s.clock(),
+	}
+	if err := tx.CreateBatchChange(ctx, revertBatchChange); err != nil {
+		return nil, "", err
+	}
+
+	bulkGroupID, err = s.WithStore(tx).CreateChangesetJobs(
+		ctx,
+		batchChangeID,
+		ids,
+		btypes.ChangesetJobTypeRevert,
+		&btypes.ChangesetJobRevertPayload{RevertBatchChangeID: revertBatchChange.ID},
+		store.ListChangesetsOpts{
+			// Only merged changesets can be reverted.
+			ExternalStates: []btypes.ChangesetExternalState{btypes.ChangesetExternalStateMerged},
+		},
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return revertBatchChange, bulkGroupID, nil
+}
+
 // ValidateChangesetSpecs checks whether the given BachSpec has ChangesetSpecs
 // that would publish to the same branch in the same repository.
 // If the return value is nil, then the BatchSpec is valid.
@@ -1638,6 +1750,7 @@ func (s *Service) GetAvailableBulkOperations(ctx context.Context, opts GetAvaila
 		btypes.ChangesetJobTypeMerge:     0,
 		btypes.ChangesetJobTypePublish:   0,
 		btypes.ChangesetJobTypeReenqueue: 0,
+		btypes.ChangesetJobTypeRevert:    0,
 	}
 
 	changesets, _, err := s.store.ListChangesets(ctx, store.ListChangesetsOpts{
@@ -1694,6 +1807,11 @@ func (s *Service) GetAvailableBulkOperations(ctx context.Context, opts GetAvaila
 			bulkOperationsCounter[btypes.ChangesetJobTypeMerge] += 1
 		}
 
+		// REVERT
+		if !isChangesetArchived && isChangesetMerged && !changeset.IsImported() {
+			bulkOperationsCounter[btypes.ChangesetJobTypeRevert] += 1
+		}
+
 		// COMMENT
 		if isChangesetCommentable {
 			bulkOperationsCounter[btypes.ChangesetJobTypeComment] += 1
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	sglog "github.com/sourcegraph/log"
+
 	bgql "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/graphql"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/guardrails"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/rewirer"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
@@ -76,6 +79,12 @@ func (s *Service) ApplyBatchChange(
 		return nil, err
 	}
 
+	// Check the changeset specs against the guardrails defined by site admins
+	// before anything is written to the database.
+	if err := s.checkGuardrails(ctx, batchSpec); err != nil {
+		return nil, err
+	}
+
 	batchChange, previousSpecID, err := s.ReconcileBatchChange(ctx, batchSpec)
 	if err != nil {
 		return nil, err
@@ -225,3 +234,56 @@ func (s *Service) ReconcileBatchChange(
 	batchChange.Description = batchSpec.Spec.Description
 	return batchChange, previousSpecID, nil
 }
+
+// checkGuardrails checks the changeset specs of the given batch spec against the
+// guardrails in the site configuration. Violations are recorded in the audit log
+// and returned as errors.
+func (s *Service) checkGuardrails(ctx context.Context, batchSpec *btypes.BatchSpec) error {
+	g, err := guardrails.Current()
+	if err != nil {
+		return errors.Wrap(err, "parsing batch changes guardrails")
+	}
+
+	specs, _, err := s.store.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{BatchSpecID: batchSpec.ID})
+	if err != nil {
+		return err
+	}
+
+	violated := func(err error, fields ...sglog.Field) error {
+		var v *guardrails.Violation
+		if errors.As(err, &v) {
+			v.Audit(ctx, s.logger, append([]sglog.Field{sglog.Int64("batchSpecID", batchSpec.ID)}, fields...)...)
+		}
+		return err
+	}
+
+	if err := g.CheckChangesetCount(len(specs)); err != nil {
+		return violated(err)
+	}
+
+	// 🚨 SECURITY: database.Repos.GetReposSetByIDs uses the authzFilter under the
+	// hood and filters out repositories that the user doesn't have access to.
+	repos, err := s.store.Repos().GetReposSetByIDs(ctx, specs.RepoIDs()...)
+	if err != nil {
+		return err
+	}
+
+	for _, spec := range specs {
+		if spec.Type != btypes.ChangesetSpecTypeBranch {
+			continue
+		}
+		repo, ok := repos[spec.BaseRepoID]
+		if !ok {
+			continue
+		}
+		files, err := spec.ChangedFiles()
+		if err != nil {
+			return err
+		}
+		if err := g.CheckChangedFiles(repo.Name, files); err != nil {
+			return violated(err, sglog.String("repo", string(repo.Name)))
+		}
+	}
+
+	return nil
+}
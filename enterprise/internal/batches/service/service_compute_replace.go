@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/conc/pool"
+	"gopkg.in/yaml.v2"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store/author"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/compute"
+	sgactor "github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrNoComputeReplacements is returned by CreateBatchSpecFromComputeReplace if the
+// replacement of the compute query doesn't change any file.
+var ErrNoComputeReplacements = errors.New("the compute query doesn't change any files")
+
+// ErrTooManyComputeReplacements is returned by CreateBatchSpecFromComputeReplace
+// if the search of the compute query matches more repositories than the given
+// maximum number of changesets.
+var ErrTooManyComputeReplacements = errors.New("the compute query matches more repositories than the maximum number of changesets")
+
+// ErrTooManyComputeReplacementFiles is returned by
+// CreateBatchSpecFromComputeReplace if the search of the compute query matches
+// more files than maxComputeReplaceFiles.
+var ErrTooManyComputeReplacementFiles = errors.Newf("the compute query matches more than %d files", maxComputeReplaceFiles)
+
+const (
+	// maxComputeReplaceFiles is the maximum number of files that are read to
+	// compute the replacements of a compute query, since they're all read
+	// while the request is handled.
+	maxComputeReplaceFiles = 10000
+	// computeReplaceConcurrency is the number of files read from gitserver at
+	// the same time.
+	computeReplaceConcurrency = 8
+)
+
+type CreateBatchSpecFromComputeReplaceOpts struct {
+	// Query is a compute query with a replace command, for example
+	// `content:replace(ioutil.ReadFile -> os.ReadFile) lang:go`.
+	Query string
+
+	Name        string
+	Description string
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	// Branch is the branch the changesets are pushed to. It defaults to the
+	// name of the batch spec.
+	Branch string
+	// CommitMessage is the message of the commit of each changeset. It defaults
+	// to the description of the batch spec, or its name if there is none.
+	CommitMessage string
+
+	// MaxChangesets is the maximum number of changeset specs that can be
+	// created, and so the maximum number of repositories the search of the
+	// query can match. Zero means there is no limit.
+	MaxChangesets int
+}
+
+// CreateBatchSpecFromComputeReplace runs the search of a compute query with a
+// replace command and creates a batch spec with a changeset spec per
+// repository, containing the replacements in the matching files at the head of
+// its default branch. The changeset specs are computed right away, so the batch
+// spec doesn't need to be executed and can be applied like a batch spec
+// created with src-cli.
+func (s *Service) CreateBatchSpecFromComputeReplace(ctx context.Context, opts CreateBatchSpecFromComputeReplaceOpts) (batchSpec *btypes.BatchSpec, err error) {
+	ctx, _, endObservation := s.operations.createBatchSpecFromComputeReplace.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("query", opts.Query),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.createBatchSpecFromComputeReplace(ctx, newWorkspaceResolver(s.store), opts)
+}
+
+func (s *Service) createBatchSpecFromComputeReplace(ctx context.Context, wr *workspaceResolver, opts CreateBatchSpecFromComputeReplaceOpts) (_ *btypes.BatchSpec, err error) {
+	computeQuery, err := compute.Parse(opts.Query)
+	if err != nil {
+		return nil, err
+	}
+	replace, ok := computeQuery.Command.(*compute.Replace)
+	if !ok {
+		return nil, errors.New("the compute query must use the replace command")
+	}
+	searchQuery, err := computeQuery.ToSearchQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	rawSpec, err := yaml.Marshal(struct {
+		Name        string `yaml:"name"`
+		Description string `yaml:"description,omitempty"`
+	}{
+		Name:        opts.Name,
+		Description: opts.Description,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling batch spec")
+	}
+	spec, err := batcheslib.ParseBatchSpec(rawSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check whether the current user has access to either one of the namespaces.
+	if err := s.CheckNamespaceAccess(ctx, opts.NamespaceUserID, opts.NamespaceOrgID); err != nil {
+		return nil, err
+	}
+
+	a := sgactor.FromContext(ctx)
+	changesetAuthor, err := author.GetChangesetAuthorForUser(ctx, database.UsersWith(s.logger, s.store), a.UID)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating changeset author")
+	}
+
+	branch := opts.Branch
+	if branch == "" {
+		branch = spec.Name
+	}
+	commitMessage := opts.CommitMessage
+	if commitMessage == "" {
+		commitMessage = spec.Description
+	}
+	if commitMessage == "" {
+		commitMessage = spec.Name
+	}
+
+	// 🚨 SECURITY: The search is run as the current user, and the matching
+	// repositories are filtered by their permissions.
+	// Compute queries are regular expression searches.
+	revs, err := wr.resolveRepositoriesMatchingQuery(ctx, "patterntype:regexp "+searchQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every matching repository can get a changeset, so the limits are checked
+	// before any file is read.
+	if opts.MaxChangesets > 0 && len(revs) > opts.MaxChangesets {
+		return nil, ErrTooManyComputeReplacements
+	}
+	fileCount := 0
+	for _, rev := range revs {
+		fileCount += len(rev.FileMatches)
+	}
+	if fileCount > maxComputeReplaceFiles {
+		return nil, ErrTooManyComputeReplacementFiles
+	}
+
+	// The diffs of the files are collected by index, so that they keep the
+	// order of the search results.
+	fileDiffs := make([][]string, len(revs))
+	p := pool.New().WithContext(ctx).WithMaxGoroutines(computeReplaceConcurrency).WithCancelOnError()
+	for i, rev := range revs {
+		fileDiffs[i] = make([]string, len(rev.FileMatches))
+		for j, path := range rev.FileMatches {
+			i, j, rev, path := i, j, rev, path
+			p.Go(func(ctx context.Context) error {
+				content, err := wr.gitserverClient.ReadFile(ctx, authz.DefaultSubRepoPermsChecker, rev.Repo.Name, rev.Commit, path)
+				if err != nil {
+					return errors.Wrapf(err, "reading %s in %s", path, rev.Repo.Name)
+				}
+				fileDiff, err := replace.Diff(ctx, path, content)
+				if err != nil {
+					return errors.Wrapf(err, "replacing in %s in %s", path, rev.Repo.Name)
+				}
+				fileDiffs[i][j] = fileDiff
+				return nil
+			})
+		}
+	}
+	if err := p.Wait(); err != nil {
+		return nil, err
+	}
+
+	var changesetSpecs []*btypes.ChangesetSpec
+	for i, rev := range revs {
+		diff := strings.Join(fileDiffs[i], "")
+		if diff == "" {
+			continue
+		}
+
+		repoID := string(relay.MarshalID("Repository", rev.Repo.ID))
+		commit := batcheslib.GitCommitDescription{
+			Version: 2,
+			Message: commitMessage,
+			Diff:    []byte(diff),
+		}
+		if changesetAuthor != nil {
+			commit.AuthorName = changesetAuthor.Name
+			commit.AuthorEmail = changesetAuthor.Email
+		}
+		changesetSpec, err := btypes.NewChangesetSpecFromSpec(&batcheslib.ChangesetSpec{
+			BaseRepository: repoID,
+			HeadRepository: repoID,
+			BaseRef:        rev.Branch,
+			BaseRev:        string(rev.Commit),
+			HeadRef:        gitdomain.EnsureRefPrefix(branch),
+			Title:          spec.Name,
+			Body:           spec.Description,
+			Commits:        []batcheslib.GitCommitDescription{commit},
+		})
+		if err != nil {
+			return nil, err
+		}
+		changesetSpec.UserID = a.UID
+		changesetSpecs = append(changesetSpecs, changesetSpec)
+	}
+	if len(changesetSpecs) == 0 {
+		return nil, ErrNoComputeReplacements
+	}
+
+	batchSpec := &btypes.BatchSpec{
+		RawSpec:         string(rawSpec),
+		Spec:            spec,
+		NamespaceUserID: opts.NamespaceUserID,
+		NamespaceOrgID:  opts.NamespaceOrgID,
+		UserID:          a.UID,
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.CreateBatchSpec(ctx, batchSpec); err != nil {
+		return nil, err
+	}
+	for _, changesetSpec := range changesetSpecs {
+		changesetSpec.BatchSpecID = batchSpec.ID
+	}
+	if err := tx.CreateChangesetSpec(ctx, changesetSpecs...); err != nil {
+		return nil, err
+	}
+
+	return batchSpec, nil
+}
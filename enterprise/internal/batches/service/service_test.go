@@ -23,14 +23,17 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	extsvcauth "github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -1267,6 +1270,181 @@ index e5af166..d44c3fc 100644
 		})
 	})
 
+	t.Run("RevertChangesets", func(t *testing.T) {
+		spec := testBatchSpec(admin.ID)
+		if err := s.CreateBatchSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+
+		batchChange := testBatchChange(admin.ID, spec)
+		if err := s.CreateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		merged := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               rs[0].ID,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateMerged,
+			BatchChange:        batchChange.ID,
+			OwnedByBatchChange: batchChange.ID,
+		})
+		open := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               rs[1].ID,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateOpen,
+			BatchChange:        batchChange.ID,
+			OwnedByBatchChange: batchChange.ID,
+		})
+
+		t.Run("unauthorized user", func(t *testing.T) {
+			_, _, err := svc.RevertChangesets(userCtx, batchChange.ID, []int64{merged.ID})
+			if !errors.HasType(err, &auth.InsufficientAuthorizationError{}) {
+				t.Fatalf("expected unauthorized error, got %+v", err)
+			}
+		})
+
+		t.Run("unmerged changeset", func(t *testing.T) {
+			_, _, err := svc.RevertChangesets(adminCtx, batchChange.ID, []int64{open.ID})
+			if err != ErrChangesetsForJobNotFound {
+				t.Fatalf("wrong error. want=%s, got=%s", ErrChangesetsForJobNotFound, err)
+			}
+
+			// The revert batch change must not be left behind.
+			_, err = s.GetBatchChange(ctx, store.GetBatchChangeOpts{Name: batchChange.Name + "-revert", NamespaceUserID: admin.ID})
+			if err != store.ErrNoResults {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+		})
+
+		t.Run("merged changeset", func(t *testing.T) {
+			revert, bulkGroupID, err := svc.RevertChangesets(adminCtx, batchChange.ID, []int64{merged.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := revert.Name, batchChange.Name+"-revert"; have != want {
+				t.Fatalf("wrong name. want=%q, have=%q", want, have)
+			}
+			if revert.IsDraft() {
+				t.Fatal("revert batch change is not applied")
+			}
+
+			op, err := s.GetBulkOperation(ctx, store.GetBulkOperationOpts{ID: bulkGroupID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if op.Type != btypes.ChangesetJobTypeRevert || op.ChangesetCount != 1 {
+				t.Fatalf("wrong bulk operation: %+v", op)
+			}
+
+			// Reverting again creates another batch change with a unique name.
+			again, _, err := svc.RevertChangesets(adminCtx, batchChange.ID, []int64{merged.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := again.Name, batchChange.Name+"-revert-2"; have != want {
+				t.Fatalf("wrong name. want=%q, have=%q", want, have)
+			}
+		})
+	})
+
+	t.Run("CreateBatchSpecFromComputeReplace", func(t *testing.T) {
+		gitserverClient := gitserver.NewMockClient()
+		gitserverClient.GetDefaultBranchFunc.SetDefaultReturn("refs/heads/main", "d34db33f", nil)
+		gitserverClient.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, repo api.RepoName, _ api.CommitID, path string) ([]byte, error) {
+			if repo == rs[1].Name {
+				// The index is stale, the file no longer contains the pattern.
+				return []byte("package main\n"), nil
+			}
+			return []byte("package main\n\nvar _ = ioutil.ReadFile\n"), nil
+		})
+		wr := &workspaceResolver{
+			store:           s,
+			gitserverClient: gitserverClient,
+			frontendInternalURL: newStreamSearchTestServer(t, map[string][]streamhttp.EventMatch{
+				`patterntype:regexp lang:go ioutil\.ReadFile count:all`: {
+					&streamhttp.EventContentMatch{Type: streamhttp.ContentMatchType, Path: "main.go", RepositoryID: int32(rs[0].ID)},
+					&streamhttp.EventContentMatch{Type: streamhttp.ContentMatchType, Path: "main.go", RepositoryID: int32(rs[1].ID)},
+				},
+				`patterntype:regexp lang:go ioutil\.WriteFile count:all`: {
+					&streamhttp.EventContentMatch{Type: streamhttp.ContentMatchType, Path: "main.go", RepositoryID: int32(rs[1].ID)},
+				},
+			}),
+		}
+		opts := CreateBatchSpecFromComputeReplaceOpts{
+			Query:           `content:replace(ioutil\.ReadFile -> os.ReadFile) lang:go`,
+			Name:            "replace-ioutil",
+			NamespaceUserID: admin.ID,
+		}
+
+		t.Run("not a replace command", func(t *testing.T) {
+			opts := opts
+			opts.Query = `content:output(ioutil\.ReadFile -> os.ReadFile) lang:go`
+			if _, err := svc.createBatchSpecFromComputeReplace(adminCtx, wr, opts); err == nil {
+				t.Fatal("expected error, got none")
+			}
+		})
+
+		t.Run("other namespace", func(t *testing.T) {
+			if _, err := svc.createBatchSpecFromComputeReplace(userCtx, wr, opts); !errors.HasType(err, &auth.InsufficientAuthorizationError{}) {
+				t.Fatalf("expected unauthorized error, got %+v", err)
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			batchSpec, err := svc.createBatchSpecFromComputeReplace(adminCtx, wr, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if batchSpec.CreatedFromRaw {
+				t.Fatal("batch spec is created from raw")
+			}
+
+			specs, _, err := s.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{BatchSpecID: batchSpec.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(specs) != 1 {
+				t.Fatalf("wrong number of changeset specs. want=1, have=%d", len(specs))
+			}
+			spec := specs[0]
+			if spec.BaseRepoID != rs[0].ID || spec.BaseRef != "refs/heads/main" || spec.BaseRev != "d34db33f" || spec.HeadRef != "refs/heads/replace-ioutil" {
+				t.Fatalf("wrong changeset spec: %+v", spec)
+			}
+			wantDiff := `diff --git main.go main.go
+--- main.go
++++ main.go
+@@ -1,3 +1,3 @@
+ package main
+ 
+-var _ = ioutil.ReadFile
++var _ = os.ReadFile
+`
+			if diff := cmp.Diff(wantDiff, string(spec.Diff)); diff != "" {
+				t.Fatalf("wrong diff (-want +have):\n%s", diff)
+			}
+		})
+
+		t.Run("no replacements", func(t *testing.T) {
+			opts := opts
+			opts.Query = `content:replace(ioutil\.WriteFile -> os.WriteFile) lang:go`
+			if _, err := svc.createBatchSpecFromComputeReplace(adminCtx, wr, opts); err != ErrNoComputeReplacements {
+				t.Fatalf("wrong error. want=%s, got=%s", ErrNoComputeReplacements, err)
+			}
+		})
+
+		t.Run("too many repositories", func(t *testing.T) {
+			opts := opts
+			opts.MaxChangesets = 1
+			reads := len(gitserverClient.ReadFileFunc.History())
+			if _, err := svc.createBatchSpecFromComputeReplace(adminCtx, wr, opts); err != ErrTooManyComputeReplacements {
+				t.Fatalf("wrong error. want=%s, got=%s", ErrTooManyComputeReplacements, err)
+			}
+			if have := len(gitserverClient.ReadFileFunc.History()); have != reads {
+				t.Fatalf("files were read before checking the number of repositories: %d", have-reads)
+			}
+		})
+	})
+
 	t.Run("ExecuteBatchSpec", func(t *testing.T) {
 		adminCtx := actor.WithActor(ctx, actor.FromUser(admin.ID))
 		t.Run("success", func(t *testing.T) {
@@ -2791,7 +2969,7 @@ changesetTemplate:
 				t.Fatal(err)
 			}
 
-			expectedBulkOperations := []string{"COMMENT", "PUBLISH"}
+			expectedBulkOperations := []string{"COMMENT", "PUBLISH", "REVERT"}
 			if !assert.ElementsMatch(t, expectedBulkOperations, bulkOperations) {
 				t.Errorf("wrong bulk operation type returned. want=%q, have=%q", expectedBulkOperations, bulkOperations)
 			}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 
 	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
@@ -25,6 +26,7 @@ type AzureDevOpsSource struct {
 }
 
 var _ ForkableChangesetSource = AzureDevOpsSource{}
+var _ ReviewerRequestingChangesetSource = AzureDevOpsSource{}
 
 func NewAzureDevOpsSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
 	rawConfig, err := svc.Config.Decrypt(ctx)
@@ -440,6 +442,36 @@ func (s AzureDevOpsSource) changesetToUpdatePullRequestInput(cs *Changeset, targ
 	}
 }
 
+// RequestReviewers adds the Azure DevOps users with the given external accounts
+// as reviewers of the Changeset.
+func (s AzureDevOpsSource) RequestReviewers(ctx context.Context, cs *Changeset, accounts []*extsvc.Account) error {
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	args, err := s.createCommonPullRequestArgs(*repo, *cs)
+	if err != nil {
+		return err
+	}
+
+	reviewerIDs := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		profile, _, err := azuredevops.GetExternalAccountData(ctx, &account.AccountData)
+		if err != nil {
+			return errors.Wrapf(err, "loading data of external account %d", account.ID)
+		}
+		if profile != nil {
+			reviewerIDs = append(reviewerIDs, profile.ID)
+		}
+	}
+	if len(reviewerIDs) == 0 {
+		return nil
+	}
+
+	if _, err := s.client.AddPullRequestReviewers(ctx, args, reviewerIDs); err != nil {
+		return err
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
 func (s AzureDevOpsSource) createCommonPullRequestArgs(repo azuredevops.Repository, cs Changeset) (azuredevops.PullRequestCommonArgs, error) {
 	org, err := repo.GetOrganization()
 	if err != nil {
@@ -7,6 +7,8 @@ import (
 
 	"github.com/inconshreveable/log15"
 
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
@@ -24,6 +26,7 @@ type BitbucketServerSource struct {
 }
 
 var _ ForkableChangesetSource = BitbucketServerSource{}
+var _ ReviewerRequestingChangesetSource = BitbucketServerSource{}
 
 // NewBitbucketServerSource returns a new BitbucketServerSource from the given external service.
 func NewBitbucketServerSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*BitbucketServerSource, error) {
@@ -280,6 +283,30 @@ func (s BitbucketServerSource) MergeChangeset(ctx context.Context, c *Changeset,
 	return c.Changeset.SetMetadata(merged)
 }
 
+// RequestReviewers adds the Bitbucket Server users with the given external
+// accounts as reviewers of the Changeset.
+func (s BitbucketServerSource) RequestReviewers(ctx context.Context, c *Changeset, accounts []*extsvc.Account) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	for _, account := range accounts {
+		if account.Data == nil {
+			continue
+		}
+		user, err := encryption.DecryptJSON[bitbucketserver.User](ctx, account.Data)
+		if err != nil {
+			return errors.Wrapf(err, "loading data of external account %d", account.ID)
+		}
+		if err := s.client.AddPullRequestReviewer(ctx, pr, user.Name); err != nil {
+			return errors.Wrapf(err, "adding reviewer %q", user.Name)
+		}
+	}
+
+	return s.LoadChangeset(ctx, c)
+}
+
 type bitbucketClientFunc func(context.Context, *bitbucketserver.PullRequest) error
 
 func (s BitbucketServerSource) callAndRetryIfOutdated(ctx context.Context, c *Changeset, fn bitbucketClientFunc) (*bitbucketserver.PullRequest, error) {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
@@ -36,6 +37,32 @@ type ArchivableChangesetSource interface {
 	IsArchivedPushError(output string) bool
 }
 
+// ChangeRefChangesetSource represents a changeset source for a code host that
+// creates and updates changes from pushes to special refs, such as Gerrit,
+// rather than opening changesets for pushed branches.
+type ChangeRefChangesetSource interface {
+	ChangesetSource
+
+	// BuildCommitOpts adjusts the options used to create the commit of the
+	// given changeset spec and push it to the code host.
+	BuildCommitOpts(repo *types.Repo, changeset *btypes.Changeset, spec *btypes.ChangesetSpec, opts protocol.CreateCommitFromPatchRequest) protocol.CreateCommitFromPatchRequest
+	// IsNoChangesPushError parses the given error output from `git push` to
+	// detect whether the push was rejected because the change already has a
+	// patch set with the pushed commit.
+	IsNoChangesPushError(output string) bool
+}
+
+// A ReviewerRequestingChangesetSource can request reviews of changesets from
+// users of the code host.
+type ReviewerRequestingChangesetSource interface {
+	ChangesetSource
+
+	// RequestReviewers requests reviews of the Changeset from the code host
+	// users with the given external accounts, which must belong to the code
+	// host of the Changeset.
+	RequestReviewers(ctx context.Context, c *Changeset, accounts []*extsvc.Account) error
+}
+
 // A DraftChangesetSource can create draft changesets and undraft them.
 type DraftChangesetSource interface {
 	ChangesetSource
@@ -0,0 +1,342 @@
+package sources
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GerritSource is a ChangesetSource for Gerrit. Gerrit doesn't open changes for
+// pushed branches: a change is created or updated by pushing a commit with a
+// Change-Id trailer to the magic refs/for/<branch> ref. Its title and body are
+// the commit message of the change.
+type GerritSource struct {
+	client *gerrit.Client
+}
+
+var (
+	_ DraftChangesetSource     = GerritSource{}
+	_ ChangeRefChangesetSource = GerritSource{}
+)
+
+func NewGerritSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	u, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing Gerrit URL")
+	}
+
+	client, err := gerrit.NewClient(svc.URN(), u, &gerrit.AccountCredentials{Username: c.Username, Password: c.Password}, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Gerrit client")
+	}
+
+	return &GerritSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GerritSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GerritSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GerritSource", a)
+	}
+
+	return &GerritSource{client: s.client.WithAuthenticator(a)}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GerritSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedUserAccount(ctx)
+	return err
+}
+
+// BuildCommitOpts makes the commit of the changeset create or update a change:
+// the commit message is the title and body of the changeset followed by a
+// Change-Id trailer, and it is pushed to refs/for/<base branch>, using the
+// head branch of the changeset spec as topic of the change.
+func (s GerritSource) BuildCommitOpts(repo *types.Repo, changeset *btypes.Changeset, spec *btypes.ChangesetSpec, opts protocol.CreateCommitFromPatchRequest) protocol.CreateCommitFromPatchRequest {
+	changeID := gerritChangeID(repo, spec.HeadRef)
+	message := gerritbatches.CommitMessage(spec.Title, spec.Body, changeID)
+
+	// Once the change exists, we keep its Change-Id and its current commit
+	// message, which may have been decorated when it was published. Changes
+	// to the title and body are applied by UpdateChangeset.
+	if m, ok := changeset.Metadata.(*gerritbatches.AnnotatedChange); ok {
+		changeID = m.ChangeID
+		message = gerritbatches.CommitMessage(spec.Title, spec.Body, changeID)
+		if commit := m.CurrentCommit(); commit != nil {
+			message = commit.Message
+		}
+	}
+	opts.CommitInfo.Message = message
+
+	pushRef := "refs/for/" + strings.TrimPrefix(gitdomain.EnsureRefPrefix(spec.BaseRef), "refs/heads/") +
+		"%topic=" + strings.TrimPrefix(spec.HeadRef, "refs/heads/")
+	opts.PushRef = &pushRef
+
+	return opts
+}
+
+// IsNoChangesPushError parses the given error output from `git push` to detect
+// whether the push was rejected because the change already has a patch set
+// with the pushed commit.
+func (s GerritSource) IsNoChangesPushError(output string) bool {
+	return strings.Contains(output, "no new changes")
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GerritSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	change, err := s.client.GetChange(ctx, cs.ExternalID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting change")
+	}
+
+	return errors.Wrap(s.setChangesetMetadata(change, cs), "setting Gerrit changeset metadata")
+}
+
+// CreateChangeset will create the Changeset on the source. The change itself
+// is created when its commit is pushed, so this marks it as ready for review
+// and makes sure its commit message reflects the changeset.
+func (s GerritSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	return s.createChangeset(ctx, cs, false)
+}
+
+// CreateDraftChangeset creates the given changeset on the code host in draft
+// mode, which is a work in progress change on Gerrit.
+func (s GerritSource) CreateDraftChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	return s.createChangeset(ctx, cs, true)
+}
+
+func (s GerritSource) createChangeset(ctx context.Context, cs *Changeset, asDraft bool) (bool, error) {
+	project, err := gerritProject(cs.TargetRepo)
+	if err != nil {
+		return false, err
+	}
+
+	change, err := s.client.GetChange(ctx, gerrit.ChangeIdentifier(project, cs.BaseRef, s.changeID(cs)))
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return false, errors.Wrap(err, "change not found, it should have been created by pushing its commit")
+		}
+		return false, errors.Wrap(err, "getting change")
+	}
+
+	id := strconv.Itoa(change.Number)
+
+	if asDraft && !change.WorkInProgress {
+		if err := s.client.SetWorkInProgress(ctx, id); err != nil {
+			return false, errors.Wrap(err, "marking change as work in progress")
+		}
+	} else if !asDraft && change.WorkInProgress {
+		if err := s.client.SetReadyForReview(ctx, id); err != nil {
+			return false, errors.Wrap(err, "marking change as ready for review")
+		}
+	}
+
+	if err := s.updateCommitMessage(ctx, change, cs); err != nil {
+		return false, err
+	}
+
+	if err := s.reloadChangeset(ctx, id, cs); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// UndraftChangeset will update the Changeset on the source to be not in draft mode anymore.
+func (s GerritSource) UndraftChangeset(ctx context.Context, cs *Changeset) error {
+	if err := s.client.SetReadyForReview(ctx, cs.ExternalID); err != nil {
+		return errors.Wrap(err, "marking change as ready for review")
+	}
+
+	return s.reloadChangeset(ctx, cs.ExternalID, cs)
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "abandoned" on
+// Gerrit).
+func (s GerritSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	if _, err := s.client.AbandonChange(ctx, cs.ExternalID); err != nil {
+		return errors.Wrap(err, "abandoning change")
+	}
+
+	return s.reloadChangeset(ctx, cs.ExternalID, cs)
+}
+
+// UpdateChangeset can update Changesets.
+func (s GerritSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	change, err := s.client.GetChange(ctx, cs.ExternalID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting change")
+	}
+
+	if gitdomain.EnsureRefPrefix(change.Branch) != gitdomain.EnsureRefPrefix(cs.BaseRef) {
+		if _, err := s.client.MoveChange(ctx, cs.ExternalID, cs.BaseRef); err != nil {
+			return errors.Wrap(err, "moving change")
+		}
+	}
+
+	if err := s.updateCommitMessage(ctx, change, cs); err != nil {
+		return err
+	}
+
+	return s.reloadChangeset(ctx, cs.ExternalID, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s GerritSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	change, err := s.client.GetChange(ctx, cs.ExternalID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting change")
+	}
+
+	if change.Status == gerrit.ChangeStatusAbandoned {
+		if _, err := s.client.RestoreChange(ctx, cs.ExternalID); err != nil {
+			return errors.Wrap(err, "restoring change")
+		}
+	}
+
+	return s.reloadChangeset(ctx, cs.ExternalID, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GerritSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	return s.client.WriteReviewComment(ctx, cs.ExternalID, comment)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// Gerrit changes consist of a single commit, so squash has no effect. If the
+// change cannot be submitted, for example because it is missing required
+// votes, ChangesetNotMergeableError is returned.
+func (s GerritSource) MergeChangeset(ctx context.Context, cs *Changeset, _ bool) error {
+	if _, err := s.client.SubmitChange(ctx, cs.ExternalID); err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Wrap(err, "submitting change")
+		}
+		return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+	}
+
+	return s.reloadChangeset(ctx, cs.ExternalID, cs)
+}
+
+// updateCommitMessage creates a new patch set of the change if its commit
+// message doesn't match the title and body of the changeset.
+func (s GerritSource) updateCommitMessage(ctx context.Context, change *gerrit.Change, cs *Changeset) error {
+	message := gerritbatches.CommitMessage(cs.Title, cs.Body, change.ChangeID)
+	if commit := change.CurrentCommit(); commit != nil && strings.TrimSpace(commit.Message) == strings.TrimSpace(message) {
+		return nil
+	}
+
+	return errors.Wrap(s.client.SetCommitMessage(ctx, strconv.Itoa(change.Number), message), "updating commit message")
+}
+
+func (s GerritSource) reloadChangeset(ctx context.Context, id string, cs *Changeset) error {
+	change, err := s.client.GetChange(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "getting change")
+	}
+
+	return errors.Wrap(s.setChangesetMetadata(change, cs), "setting Gerrit changeset metadata")
+}
+
+func (s GerritSource) setChangesetMetadata(change *gerrit.Change, cs *Changeset) error {
+	if err := cs.SetMetadata(&gerritbatches.AnnotatedChange{
+		Change:      change,
+		CodeHostURL: s.client.URL.String(),
+	}); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+// changeID returns the Change-Id of the change for the given changeset, which
+// is only known once the changeset has been published.
+func (s GerritSource) changeID(cs *Changeset) string {
+	if m, ok := cs.Metadata.(*gerritbatches.AnnotatedChange); ok {
+		return m.ChangeID
+	}
+	return gerritChangeID(cs.TargetRepo, cs.HeadRef)
+}
+
+// gerritChangeID derives a Change-Id from the repository and the head ref of a
+// changeset, so that pushing the commit of a changeset again updates the same
+// change instead of creating a new one.
+func gerritChangeID(repo *types.Repo, headRef string) string {
+	h := sha1.New()
+	h.Write([]byte(repo.ExternalRepo.ServiceID))
+	h.Write([]byte{0})
+	h.Write([]byte(repo.ExternalRepo.ID))
+	h.Write([]byte{0})
+	h.Write([]byte(gitdomain.EnsureRefPrefix(headRef)))
+	return "I" + hex.EncodeToString(h.Sum(nil))
+}
+
+// gerritProject returns the name of the Gerrit project of the given repo.
+func gerritProject(repo *types.Repo) (string, error) {
+	project, ok := repo.Metadata.(*gerrit.Project)
+	if !ok {
+		return "", errors.Errorf("unexpected repo metadata type %T", repo.Metadata)
+	}
+
+	// The ID of a project is its URL encoded name.
+	return url.PathUnescape(project.ID)
+}
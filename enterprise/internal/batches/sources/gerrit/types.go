@@ -0,0 +1,69 @@
+package gerrit
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+)
+
+// AnnotatedChange adds metadata we need that lives outside the main Change
+// type returned by the Gerrit API alongside the change. This type is used as
+// the primary metadata type for Gerrit changesets.
+type AnnotatedChange struct {
+	*gerrit.Change
+	// CodeHostURL is the base URL of the Gerrit instance, which the change
+	// itself doesn't know about but is needed to link to it.
+	CodeHostURL string `json:"code_host_url"`
+}
+
+// URL returns the URL of the change in the Gerrit web UI.
+func (c *AnnotatedChange) URL() (string, error) {
+	u, err := url.Parse(c.CodeHostURL)
+	if err != nil {
+		return "", err
+	}
+	return u.JoinPath("c", c.Project, "+", strconv.Itoa(c.Number)).String(), nil
+}
+
+// Body returns the commit message of the current patch set of the change,
+// without the subject line and the Change-Id trailer: Gerrit has no separate
+// description for changes.
+func (c *AnnotatedChange) Body() string {
+	commit := c.CurrentCommit()
+	if commit == nil {
+		return ""
+	}
+	return MessageBody(commit.Message)
+}
+
+// MessageBody returns the given commit message without its subject line and
+// without a Change-Id trailer.
+func MessageBody(message string) string {
+	_, body, _ := strings.Cut(message, "\n")
+
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.HasPrefix(lines[i], "Change-Id: ") {
+			lines = append(lines[:i], lines[i+1:]...)
+			break
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// CommitMessage builds the commit message of a change with the given title and
+// body, ending with the Change-Id trailer that identifies the change.
+func CommitMessage(title, body, changeID string) string {
+	var sb strings.Builder
+	sb.WriteString(strings.TrimSpace(title))
+	if body := strings.TrimSpace(body); body != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(body)
+	}
+	sb.WriteString("\n\nChange-Id: ")
+	sb.WriteString(changeID)
+	sb.WriteString("\n")
+	return sb.String()
+}
@@ -0,0 +1,208 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestGerritSource_WithAuthenticator(t *testing.T) {
+	s := mockGerritSource(t, nil)
+
+	t.Run("supports BasicAuth", func(t *testing.T) {
+		newSource, err := s.WithAuthenticator(&auth.BasicAuth{Username: "user", Password: "pass"})
+		assert.Nil(t, err)
+		assert.NotNil(t, newSource)
+	})
+
+	t.Run("does not support OAuthBearerToken", func(t *testing.T) {
+		_, err := s.WithAuthenticator(&auth.OAuthBearerToken{Token: "token"})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGerritSource_BuildCommitOpts(t *testing.T) {
+	s := mockGerritSource(t, nil)
+	repo := mockGerritRepo()
+	spec := &btypes.ChangesetSpec{
+		Title:   "Update dependencies",
+		Body:    "Bumps all the things.",
+		BaseRef: "refs/heads/main",
+		HeadRef: "refs/heads/batch/update-deps",
+	}
+	changeID := gerritChangeID(repo, spec.HeadRef)
+
+	t.Run("unpublished changeset", func(t *testing.T) {
+		opts := s.BuildCommitOpts(repo, &btypes.Changeset{}, spec, protocol.CreateCommitFromPatchRequest{})
+
+		require.NotNil(t, opts.PushRef)
+		assert.Equal(t, "refs/for/main%topic=batch/update-deps", *opts.PushRef)
+		assert.Equal(t, "Update dependencies\n\nBumps all the things.\n\nChange-Id: "+changeID+"\n", opts.CommitInfo.Message)
+	})
+
+	t.Run("published changeset", func(t *testing.T) {
+		change := mockGerritChange()
+		opts := s.BuildCommitOpts(repo, &btypes.Changeset{Metadata: &gerritbatches.AnnotatedChange{Change: change}}, spec, protocol.CreateCommitFromPatchRequest{})
+
+		assert.Equal(t, change.CurrentCommit().Message, opts.CommitInfo.Message)
+	})
+
+	t.Run("change ID is stable", func(t *testing.T) {
+		assert.Equal(t, changeID, gerritChangeID(repo, "batch/update-deps"))
+		assert.NotEqual(t, changeID, gerritChangeID(repo, "batch/other"))
+		assert.Len(t, changeID, 41)
+	})
+}
+
+func TestGerritSource_IsNoChangesPushError(t *testing.T) {
+	s := mockGerritSource(t, nil)
+
+	assert.True(t, s.IsNoChangesPushError(" ! [remote rejected] HEAD -> refs/for/main%topic=batch (no new changes)"))
+	assert.False(t, s.IsNoChangesPushError(" ! [remote rejected] HEAD -> refs/for/main (prohibited by Gerrit)"))
+}
+
+func TestGerritSource_CreateChangeset(t *testing.T) {
+	ctx := context.Background()
+	repo := mockGerritRepo()
+	cs := &Changeset{
+		Title:      "Update dependencies",
+		Body:       "Bumps all the things.",
+		BaseRef:    "refs/heads/main",
+		HeadRef:    "refs/heads/batch/update-deps",
+		Changeset:  &btypes.Changeset{},
+		TargetRepo: repo,
+	}
+	changeID := gerritChangeID(repo, cs.HeadRef)
+
+	change := mockGerritChange()
+	change.ChangeID = changeID
+	change.WorkInProgress = true
+
+	var requests []string
+	s := mockGerritSource(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		switch r.Method {
+		case "GET":
+			writeGerritChange(t, w, change)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	exists, err := s.CreateChangeset(ctx, cs)
+	assert.Nil(t, err)
+	assert.False(t, exists)
+	assert.Equal(t, []string{
+		"GET /a/changes/foo%2Fbar~main~" + changeID,
+		"POST /a/changes/1234/ready",
+		"PUT /a/changes/1234/message",
+		"GET /a/changes/1234",
+	}, requests)
+	assert.Equal(t, "1234", cs.ExternalID)
+	assert.Equal(t, extsvc.TypeGerrit, cs.ExternalServiceType)
+}
+
+func TestGerritSource_MergeChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not mergeable", func(t *testing.T) {
+		s := mockGerritSource(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Change 1234: needs Code-Review", http.StatusConflict)
+		})
+		cs := &Changeset{Changeset: &btypes.Changeset{ExternalID: "1234"}}
+
+		err := s.MergeChangeset(ctx, cs, false)
+		var want ChangesetNotMergeableError
+		assert.ErrorAs(t, err, &want)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		change := mockGerritChange()
+		change.Status = gerrit.ChangeStatusMerged
+		s := mockGerritSource(t, func(w http.ResponseWriter, r *http.Request) {
+			writeGerritChange(t, w, change)
+		})
+		cs := &Changeset{Changeset: &btypes.Changeset{ExternalID: "1234"}}
+
+		assert.Nil(t, s.MergeChangeset(ctx, cs, false))
+		assert.Equal(t, change.Number, cs.Metadata.(*gerritbatches.AnnotatedChange).Number)
+	})
+}
+
+func mockGerritSource(t *testing.T, handler http.HandlerFunc) *GerritSource {
+	t.Helper()
+
+	if handler == nil {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	client, err := gerrit.NewClient("urn", u, &gerrit.AccountCredentials{Username: "user", Password: "pass"}, http.DefaultClient)
+	require.NoError(t, err)
+
+	return &GerritSource{client: client}
+}
+
+func mockGerritRepo() *types.Repo {
+	return &types.Repo{
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "foo%2Fbar",
+			ServiceType: extsvc.TypeGerrit,
+			ServiceID:   "https://gerrit.example.com/",
+		},
+		Metadata: &gerrit.Project{ID: "foo%2Fbar", Name: "foo/bar"},
+	}
+}
+
+func mockGerritChange() *gerrit.Change {
+	return &gerrit.Change{
+		ID:              "foo%2Fbar~main~I8473b95934b5732ac55d26311a706c9c2bde9940",
+		Project:         "foo/bar",
+		Branch:          "main",
+		Topic:           "batch/update-deps",
+		ChangeID:        "I8473b95934b5732ac55d26311a706c9c2bde9940",
+		Subject:         "Update dependencies",
+		Status:          gerrit.ChangeStatusNew,
+		CurrentRevision: "184ebe53805e102605d11f6b143486d15c23a09c",
+		Revisions: map[string]gerrit.RevisionInfo{
+			"184ebe53805e102605d11f6b143486d15c23a09c": {
+				Number: 1,
+				Ref:    "refs/changes/34/1234/1",
+				Commit: &gerrit.CommitInfo{
+					Subject: "Update dependencies",
+					Message: "Update dependencies\n\nChange-Id: I8473b95934b5732ac55d26311a706c9c2bde9940\n",
+				},
+			},
+		},
+		Number: 1234,
+	}
+}
+
+func writeGerritChange(t *testing.T, w http.ResponseWriter, change *gerrit.Change) {
+	t.Helper()
+
+	bs, err := json.Marshal(change)
+	require.NoError(t, err)
+	_, _ = w.Write(append([]byte(")]}'\n"), bs...))
+}
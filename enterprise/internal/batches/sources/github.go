@@ -25,6 +25,7 @@ type GithubSource struct {
 }
 
 var _ ForkableChangesetSource = GithubSource{}
+var _ ReviewerRequestingChangesetSource = GithubSource{}
 
 func NewGithubSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GithubSource, error) {
 	rawConfig, err := svc.Config.Decrypt(ctx)
@@ -293,6 +294,31 @@ func (s GithubSource) MergeChangeset(ctx context.Context, c *Changeset, squash b
 	return c.Changeset.SetMetadata(pr)
 }
 
+// RequestReviewers requests reviews of the Changeset from the GitHub users
+// with the given external accounts.
+func (s GithubSource) RequestReviewers(ctx context.Context, c *Changeset, accounts []*extsvc.Account) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	userIDs := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		user, _, err := github.GetExternalAccountData(ctx, &account.AccountData)
+		if err != nil {
+			return errors.Wrapf(err, "loading data of external account %d", account.ID)
+		}
+		if user != nil && user.GetNodeID() != "" {
+			userIDs = append(userIDs, user.GetNodeID())
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	return s.client.RequestPullRequestReviews(ctx, pr, userIDs)
+}
+
 func (GithubSource) IsPushResponseArchived(s string) bool {
 	return strings.Contains(s, "This repository was archived so it is read-only.")
 }
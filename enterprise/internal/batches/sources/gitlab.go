@@ -28,6 +28,7 @@ type GitLabSource struct {
 
 var _ ChangesetSource = &GitLabSource{}
 var _ DraftChangesetSource = &GitLabSource{}
+var _ ReviewerRequestingChangesetSource = &GitLabSource{}
 var _ ForkableChangesetSource = &GitLabSource{}
 
 // NewGitLabSource returns a new GitLabSource from the given external service.
@@ -558,6 +559,41 @@ func (s GitLabSource) GetFork(ctx context.Context, targetRepo *types.Repo, names
 	return getGitLabForkInternal(ctx, targetRepo, s.client, namespace, n)
 }
 
+// RequestReviewers requests reviews of the Changeset from the GitLab users with
+// the given external accounts.
+func (s *GitLabSource) RequestReviewers(ctx context.Context, c *Changeset, accounts []*extsvc.Account) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	reviewerIDs := make([]int32, 0, len(accounts))
+	for _, account := range accounts {
+		user, _, err := gitlab.GetExternalAccountData(ctx, &account.AccountData)
+		if err != nil {
+			return errors.Wrapf(err, "loading data of external account %d", account.ID)
+		}
+		if user != nil {
+			reviewerIDs = append(reviewerIDs, user.ID)
+		}
+	}
+	if len(reviewerIDs) == 0 {
+		return nil
+	}
+
+	updated, err := s.client.UpdateMergeRequest(ctx, project, mr, gitlab.UpdateMergeRequestOpts{ReviewerIDs: reviewerIDs})
+	if err != nil {
+		return errors.Wrap(err, "requesting reviewers")
+	}
+
+	if err := s.decorateMergeRequestData(ctx, project, updated); err != nil {
+		return errors.Wrapf(err, "retrieving additional data for merge request %d", updated.IID)
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
 type gitlabClientFork interface {
 	ForkProject(ctx context.Context, project *gitlab.Project, namespace *string, name string) (*gitlab.Project, error)
 }
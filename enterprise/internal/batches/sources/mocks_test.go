@@ -5688,6 +5688,9 @@ type MockAzureDevOpsClient struct {
 	// AbandonPullRequestFunc is an instance of a mock function object
 	// controlling the behavior of the method AbandonPullRequest.
 	AbandonPullRequestFunc *AzureDevOpsClientAbandonPullRequestFunc
+	// AddPullRequestReviewersFunc is an instance of a mock function object
+	// controlling the behavior of the method AddPullRequestReviewers.
+	AddPullRequestReviewersFunc *AzureDevOpsClientAddPullRequestReviewersFunc
 	// AuthenticatorFunc is an instance of a mock function object
 	// controlling the behavior of the method Authenticator.
 	AuthenticatorFunc *AzureDevOpsClientAuthenticatorFunc
@@ -5756,6 +5759,11 @@ func NewMockAzureDevOpsClient() *MockAzureDevOpsClient {
 				return
 			},
 		},
+		AddPullRequestReviewersFunc: &AzureDevOpsClientAddPullRequestReviewersFunc{
+			defaultHook: func(context.Context, azuredevops.PullRequestCommonArgs, []string) (r0 []azuredevops.Reviewer, r1 error) {
+				return
+			},
+		},
 		AuthenticatorFunc: &AzureDevOpsClientAuthenticatorFunc{
 			defaultHook: func() (r0 auth.Authenticator) {
 				return
@@ -5858,6 +5866,11 @@ func NewStrictMockAzureDevOpsClient() *MockAzureDevOpsClient {
 				panic("unexpected invocation of MockAzureDevOpsClient.AbandonPullRequest")
 			},
 		},
+		AddPullRequestReviewersFunc: &AzureDevOpsClientAddPullRequestReviewersFunc{
+			defaultHook: func(context.Context, azuredevops.PullRequestCommonArgs, []string) ([]azuredevops.Reviewer, error) {
+				panic("unexpected invocation of MockAzureDevOpsClient.AddPullRequestReviewers")
+			},
+		},
 		AuthenticatorFunc: &AzureDevOpsClientAuthenticatorFunc{
 			defaultHook: func() auth.Authenticator {
 				panic("unexpected invocation of MockAzureDevOpsClient.Authenticator")
@@ -5959,6 +5972,9 @@ func NewMockAzureDevOpsClientFrom(i azuredevops.Client) *MockAzureDevOpsClient {
 		AbandonPullRequestFunc: &AzureDevOpsClientAbandonPullRequestFunc{
 			defaultHook: i.AbandonPullRequest,
 		},
+		AddPullRequestReviewersFunc: &AzureDevOpsClientAddPullRequestReviewersFunc{
+			defaultHook: i.AddPullRequestReviewers,
+		},
 		AuthenticatorFunc: &AzureDevOpsClientAuthenticatorFunc{
 			defaultHook: i.Authenticator,
 		},
@@ -6127,6 +6143,121 @@ func (c AzureDevOpsClientAbandonPullRequestFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// AzureDevOpsClientAddPullRequestReviewersFunc describes the behavior when
+// the AddPullRequestReviewers method of the parent MockAzureDevOpsClient
+// instance is invoked.
+type AzureDevOpsClientAddPullRequestReviewersFunc struct {
+	defaultHook func(context.Context, azuredevops.PullRequestCommonArgs, []string) ([]azuredevops.Reviewer, error)
+	hooks       []func(context.Context, azuredevops.PullRequestCommonArgs, []string) ([]azuredevops.Reviewer, error)
+	history     []AzureDevOpsClientAddPullRequestReviewersFuncCall
+	mutex       sync.Mutex
+}
+
+// AddPullRequestReviewers delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockAzureDevOpsClient) AddPullRequestReviewers(v0 context.Context, v1 azuredevops.PullRequestCommonArgs, v2 []string) ([]azuredevops.Reviewer, error) {
+	r0, r1 := m.AddPullRequestReviewersFunc.nextHook()(v0, v1, v2)
+	m.AddPullRequestReviewersFunc.appendCall(AzureDevOpsClientAddPullRequestReviewersFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// AddPullRequestReviewers method of the parent MockAzureDevOpsClient
+// instance is invoked and the hook queue is empty.
+func (f *AzureDevOpsClientAddPullRequestReviewersFunc) SetDefaultHook(hook func(context.Context, azuredevops.PullRequestCommonArgs, []string) ([]azuredevops.Reviewer, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddPullRequestReviewers method of the parent MockAzureDevOpsClient
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *AzureDevOpsClientAddPullRequestReviewersFunc) PushHook(hook func(context.Context, azuredevops.PullRequestCommonArgs, []string) ([]azuredevops.Reviewer, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureDevOpsClientAddPullRequestReviewersFunc) SetDefaultReturn(r0 []azuredevops.Reviewer, r1 error) {
+	f.SetDefaultHook(func(context.Context, azuredevops.PullRequestCommonArgs, []string) ([]azuredevops.Reviewer, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureDevOpsClientAddPullRequestReviewersFunc) PushReturn(r0 []azuredevops.Reviewer, r1 error) {
+	f.PushHook(func(context.Context, azuredevops.PullRequestCommonArgs, []string) ([]azuredevops.Reviewer, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureDevOpsClientAddPullRequestReviewersFunc) nextHook() func(context.Context, azuredevops.PullRequestCommonArgs, []string) ([]azuredevops.Reviewer, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureDevOpsClientAddPullRequestReviewersFunc) appendCall(r0 AzureDevOpsClientAddPullRequestReviewersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// AzureDevOpsClientAddPullRequestReviewersFuncCall objects describing the
+// invocations of this function.
+func (f *AzureDevOpsClientAddPullRequestReviewersFunc) History() []AzureDevOpsClientAddPullRequestReviewersFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureDevOpsClientAddPullRequestReviewersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureDevOpsClientAddPullRequestReviewersFuncCall is an object that
+// describes an invocation of method AddPullRequestReviewers on an instance
+// of MockAzureDevOpsClient.
+type AzureDevOpsClientAddPullRequestReviewersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 azuredevops.PullRequestCommonArgs
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []azuredevops.Reviewer
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureDevOpsClientAddPullRequestReviewersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureDevOpsClientAddPullRequestReviewersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // AzureDevOpsClientAuthenticatorFunc describes the behavior when the
 // Authenticator method of the parent MockAzureDevOpsClient instance is
 // invoked.
@@ -85,6 +85,7 @@ var RequiredEventTypesForHistory = []btypes.ChangesetEventKind{
 	btypes.ChangesetEventKindGitLabApproved,
 	btypes.ChangesetEventKindAzureDevOpsPullRequestApproved,
 	btypes.ChangesetEventKindAzureDevOpsPullRequestApprovedWithSuggestions,
+	btypes.ChangesetEventKindGerritChangeApproved,
 
 	// Reviewed, not approved.
 	btypes.ChangesetEventKindBitbucketCloudPullRequestChangesRequestRemoved,
@@ -94,6 +95,8 @@ var RequiredEventTypesForHistory = []btypes.ChangesetEventKind{
 	btypes.ChangesetEventKindGitLabUnapproved,
 	btypes.ChangesetEventKindAzureDevOpsPullRequestWaitingForAuthor,
 	btypes.ChangesetEventKindAzureDevOpsPullRequestRejected,
+	btypes.ChangesetEventKindGerritChangeNeedsChanges,
+	btypes.ChangesetEventKindGerritChangeRejected,
 }
 
 type changesetStatesAtTime struct {
@@ -211,7 +214,10 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 			btypes.ChangesetEventKindGitLabApproved,
 			btypes.ChangesetEventKindBitbucketCloudApproved,
 			btypes.ChangesetEventKindBitbucketCloudPullRequestApproved,
-			btypes.ChangesetEventKindAzureDevOpsPullRequestApproved:
+			btypes.ChangesetEventKindAzureDevOpsPullRequestApproved,
+			btypes.ChangesetEventKindGerritChangeApproved,
+			btypes.ChangesetEventKindGerritChangeNeedsChanges,
+			btypes.ChangesetEventKindGerritChangeRejected:
 			s, err := e.ReviewState()
 			if err != nil {
 				return nil, err
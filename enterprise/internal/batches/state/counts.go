@@ -22,10 +22,14 @@ type ChangesetCounts struct {
 	OpenApproved         int32
 	OpenChangesRequested int32
 	OpenPending          int32
+	// Blocked is the number of draft, open and held unpublished changesets
+	// that are blocked by a changeset of an earlier ordering group that wasn't
+	// merged yet.
+	Blocked int32
 }
 
 func (cc *ChangesetCounts) String() string {
-	return fmt.Sprintf("%s (Total: %d, Merged: %d, Closed: %d, Draft: %d, Open: %d, OpenApproved: %d, OpenChangesRequested: %d, OpenPending: %d)",
+	return fmt.Sprintf("%s (Total: %d, Merged: %d, Closed: %d, Draft: %d, Open: %d, OpenApproved: %d, OpenChangesRequested: %d, OpenPending: %d, Blocked: %d)",
 		cc.Time.String(),
 		cc.Total,
 		cc.Merged,
@@ -35,6 +39,7 @@ func (cc *ChangesetCounts) String() string {
 		cc.OpenApproved,
 		cc.OpenChangesRequested,
 		cc.OpenPending,
+		cc.Blocked,
 	)
 }
 
@@ -42,8 +47,11 @@ func (cc *ChangesetCounts) String() string {
 // ChangesetEvents in the timeframe specified by the start and end parameters.
 // The number of ChangesetCounts returned is always `timestampCount`. Between
 // start and end, it generates `timestampCount` datapoints with each ChangesetCounts
-// representing a point in time. `es` are expected to be pre-sorted.
-func CalcCounts(start, end time.Time, cs []*btypes.Changeset, es ...*btypes.ChangesetEvent) ([]*ChangesetCounts, error) {
+// representing a point in time. `es` are expected to be pre-sorted. If order is
+// not nil, it is used to count the changesets that were blocked by the
+// ordering groups of their batch change, including the unpublished changesets
+// of the batch change whose publication is held.
+func CalcCounts(start, end time.Time, cs []*btypes.Changeset, order *ChangesetOrder, es ...*btypes.ChangesetEvent) ([]*ChangesetCounts, error) {
 	ts := GenerateTimestamps(start, end)
 	counts := make([]*ChangesetCounts, len(ts))
 	for i, t := range ts {
@@ -63,12 +71,18 @@ func CalcCounts(start, end time.Time, cs []*btypes.Changeset, es ...*btypes.Chan
 		byChangeset[c] = byChangesetID[c.ID]
 	}
 
+	histories := make(map[int64]changesetHistory, len(byChangeset))
 	for changeset, csEvents := range byChangeset {
 		// Compute history of changeset
 		history, err := computeHistory(changeset, csEvents)
 		if err != nil {
 			return counts, err
 		}
+		histories[changeset.ID] = history
+	}
+
+	for _, changeset := range cs {
+		history := histories[changeset.ID]
 
 		// Go through every point in time we want to record and check the
 		// states of the changeset at that point in time
@@ -102,12 +116,50 @@ func CalcCounts(start, end time.Time, cs []*btypes.Changeset, es ...*btypes.Chan
 				// state.
 				c.Closed++
 			}
+
+			if order != nil && (states.externalState == btypes.ChangesetExternalStateDraft ||
+				states.externalState == btypes.ChangesetExternalStateOpen) {
+				if len(order.upstream(changeset.ID, unblockedAt(histories, c.Time))) > 0 {
+					c.Blocked++
+				}
+			}
+		}
+	}
+
+	if order == nil {
+		return counts, nil
+	}
+
+	// Unpublished changesets don't have a history, but they are blocked from
+	// their creation onwards for as long as their publication is held.
+	for _, changeset := range order.heldUnpublished() {
+		if _, ok := histories[changeset.ID]; ok {
+			continue
+		}
+
+		for _, c := range counts {
+			if c.Time.Before(changeset.CreatedAt) {
+				continue
+			}
+			if len(order.upstream(changeset.ID, unblockedAt(histories, c.Time))) > 0 {
+				c.Blocked++
+			}
 		}
 	}
 
 	return counts, nil
 }
 
+// unblockedAt returns a function that reports whether the changeset with the
+// given ID no longer blocked the changesets of later ordering groups at the
+// given point in time.
+func unblockedAt(histories map[int64]changesetHistory, t time.Time) func(int64) bool {
+	return func(id int64) bool {
+		states, ok := histories[id].StatesAtTime(t)
+		return ok && UnblocksDownstream(states.externalState)
+	}
+}
+
 func GenerateTimestamps(start, end time.Time) []time.Time {
 	timeStep := end.Sub(start) / timestampCount
 	// Walk backwards from `end` to >= `start` in equal intervals.
@@ -8,11 +8,14 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/batches"
 )
 
 func TestCalcCounts(t *testing.T) {
@@ -28,7 +31,11 @@ func TestCalcCounts(t *testing.T) {
 		start      time.Time
 		end        time.Time
 		events     []*btypes.ChangesetEvent
-		want       []*ChangesetCounts
+		spec       *batches.BatchSpec
+		// unpublished are the unpublished changesets of the batch change,
+		// which are only passed to the ChangesetOrder.
+		unpublished []*btypes.Changeset
+		want        []*ChangesetCounts
 	}{
 		{
 			codehosts: extsvc.TypeGitHub,
@@ -1425,6 +1432,80 @@ func TestCalcCounts(t *testing.T) {
 				{Time: daysAgo(0), Total: 1, Draft: 1},
 			},
 		},
+		{
+			codehosts: extsvc.TypeGitHub,
+			name:      "changeset blocked by ordering group",
+			changesets: []*btypes.Changeset{
+				setRepo(ghChangeset(1, daysAgo(3)), 1),
+				setRepo(ghChangeset(2, daysAgo(2)), 2),
+			},
+			start: daysAgo(3),
+			events: []*btypes.ChangesetEvent{
+				event(t, daysAgo(1), btypes.ChangesetEventKindGitHubMerged, 1),
+			},
+			spec: &batches.BatchSpec{
+				OrderingGroups: []batches.OrderingGroup{
+					{Repositories: []string{"github.com/sourcegraph/lib"}},
+					{Repositories: []string{"github.com/sourcegraph/app"}, Hold: batches.OrderingGroupHoldMerge},
+				},
+			},
+			want: []*ChangesetCounts{
+				{Time: daysAgo(3), Total: 1, Open: 1, OpenPending: 1},
+				{Time: daysAgo(2), Total: 2, Open: 2, OpenPending: 2, Blocked: 1},
+				{Time: daysAgo(1), Total: 2, Open: 1, OpenPending: 1, Merged: 1},
+				{Time: daysAgo(0), Total: 2, Open: 1, OpenPending: 1, Merged: 1},
+			},
+		},
+		{
+			codehosts: extsvc.TypeGitHub,
+			name:      "unpublished changeset held by ordering group",
+			changesets: []*btypes.Changeset{
+				setRepo(ghChangeset(1, daysAgo(3)), 1),
+			},
+			unpublished: []*btypes.Changeset{
+				{ID: 2, RepoID: 2, CreatedAt: daysAgo(2), PublicationState: btypes.ChangesetPublicationStateUnpublished},
+			},
+			start: daysAgo(3),
+			events: []*btypes.ChangesetEvent{
+				event(t, daysAgo(1), btypes.ChangesetEventKindGitHubMerged, 1),
+			},
+			spec: &batches.BatchSpec{
+				OrderingGroups: []batches.OrderingGroup{
+					{Repositories: []string{"github.com/sourcegraph/lib"}},
+					{Repositories: []string{"github.com/sourcegraph/app"}},
+				},
+			},
+			want: []*ChangesetCounts{
+				{Time: daysAgo(3), Total: 1, Open: 1, OpenPending: 1},
+				{Time: daysAgo(2), Total: 1, Open: 1, OpenPending: 1, Blocked: 1},
+				{Time: daysAgo(1), Total: 1, Merged: 1},
+				{Time: daysAgo(0), Total: 1, Merged: 1},
+			},
+		},
+		{
+			codehosts: extsvc.TypeGitHub,
+			name:      "changeset released by ordering group closed without merge",
+			changesets: []*btypes.Changeset{
+				setRepo(ghChangeset(1, daysAgo(3)), 1),
+				setRepo(ghChangeset(2, daysAgo(2)), 2),
+			},
+			start: daysAgo(3),
+			events: []*btypes.ChangesetEvent{
+				event(t, daysAgo(1), btypes.ChangesetEventKindGitHubClosed, 1),
+			},
+			spec: &batches.BatchSpec{
+				OrderingGroups: []batches.OrderingGroup{
+					{Repositories: []string{"github.com/sourcegraph/lib"}},
+					{Repositories: []string{"github.com/sourcegraph/app"}, Hold: batches.OrderingGroupHoldMerge},
+				},
+			},
+			want: []*ChangesetCounts{
+				{Time: daysAgo(3), Total: 1, Open: 1, OpenPending: 1},
+				{Time: daysAgo(2), Total: 2, Open: 2, OpenPending: 2, Blocked: 1},
+				{Time: daysAgo(1), Total: 2, Open: 1, OpenPending: 1, Closed: 1},
+				{Time: daysAgo(0), Total: 2, Open: 1, OpenPending: 1, Closed: 1},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -1438,7 +1519,15 @@ func TestCalcCounts(t *testing.T) {
 
 			sort.Sort(ChangesetEvents(tc.events))
 
-			have, err := CalcCounts(tc.start, tc.end, tc.changesets, tc.events...)
+			var order *ChangesetOrder
+			if tc.spec != nil {
+				order = NewChangesetOrder(tc.spec, append(tc.unpublished, tc.changesets...), map[api.RepoID]*types.Repo{
+					1: {ID: 1, Name: "github.com/sourcegraph/lib"},
+					2: {ID: 2, Name: "github.com/sourcegraph/app"},
+				})
+			}
+
+			have, err := CalcCounts(tc.start, tc.end, tc.changesets, order, tc.events...)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -1482,6 +1571,11 @@ func glChangeset(id int64, t time.Time) *btypes.Changeset {
 	}
 }
 
+func setRepo(c *btypes.Changeset, repoID api.RepoID) *btypes.Changeset {
+	c.RepoID = repoID
+	return c
+}
+
 func setExternalDeletedAt(c *btypes.Changeset, t time.Time) *btypes.Changeset {
 	c.SetDeleted()
 	c.ExternalDeletedAt = t
@@ -0,0 +1,165 @@
+package state
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ChangesetOrder assigns the changesets of a batch change to the ordering
+// groups defined in its batch spec, and determines which changesets are
+// blocked because the changesets of an earlier group haven't been merged yet.
+//
+// A changeset that was closed without being merged doesn't block the
+// changesets of later groups: it will never be merged, and holding back the
+// changesets that depend on it forever would leave them unpublished or
+// unmerged without any way to unblock them short of changing the batch spec.
+type ChangesetOrder struct {
+	groups     []batches.OrderingGroup
+	changesets map[int64]*btypes.Changeset
+	// groupOf maps the IDs of the changesets that are part of an ordering
+	// group to the index of that group.
+	groupOf map[int64]int
+}
+
+// NewChangesetOrder returns the ChangesetOrder of the given changesets of a
+// batch change, whose repositories are given in repos.
+func NewChangesetOrder(spec *batches.BatchSpec, cs []*btypes.Changeset, repos map[api.RepoID]*types.Repo) *ChangesetOrder {
+	o := &ChangesetOrder{
+		changesets: make(map[int64]*btypes.Changeset, len(cs)),
+		groupOf:    make(map[int64]int),
+	}
+	if spec != nil {
+		o.groups = spec.OrderingGroups
+	}
+
+	for _, c := range cs {
+		o.changesets[c.ID] = c
+
+		repo, ok := repos[c.RepoID]
+		if !ok || spec == nil {
+			continue
+		}
+		if group := spec.OrderingGroupFor(string(repo.Name)); group != -1 {
+			o.groupOf[c.ID] = group
+		}
+	}
+
+	return o
+}
+
+// ChangesetOrderStore is the subset of the store that's needed to load a
+// ChangesetOrder.
+type ChangesetOrderStore interface {
+	ListChangesets(context.Context, store.ListChangesetsOpts) (btypes.Changesets, int64, error)
+	Repos() database.RepoStore
+}
+
+// LoadChangesetOrder loads the ChangesetOrder of the changesets of the given
+// batch change. If the batch spec doesn't define any ordering groups, an
+// empty ChangesetOrder is returned without loading anything.
+func LoadChangesetOrder(ctx context.Context, s ChangesetOrderStore, batchChangeID int64, spec *batches.BatchSpec) (*ChangesetOrder, error) {
+	if spec == nil || len(spec.OrderingGroups) == 0 {
+		return NewChangesetOrder(spec, nil, nil), nil
+	}
+
+	cs, _, err := s.ListChangesets(ctx, store.ListChangesetsOpts{BatchChangeID: batchChangeID})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changesets")
+	}
+
+	repos, err := s.Repos().GetReposSetByIDs(ctx, cs.RepoIDs()...)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading repos")
+	}
+
+	return NewChangesetOrder(spec, cs, repos), nil
+}
+
+// HoldsPublication returns true if the changeset with the given ID must not
+// be published while it's blocked. Otherwise, only merging it is held.
+func (o *ChangesetOrder) HoldsPublication(id int64) bool {
+	group, ok := o.groupOf[id]
+	return ok && o.groups[group].HoldsPublication()
+}
+
+// Blocked returns true if the changeset with the given ID is part of an
+// ordering group, and any changeset of an earlier group is still open.
+func (o *ChangesetOrder) Blocked(id int64) bool {
+	return len(o.Upstream(id)) > 0
+}
+
+// Upstream returns the IDs of the changesets of earlier ordering groups than
+// the changeset with the given ID that still block it.
+func (o *ChangesetOrder) Upstream(id int64) []int64 {
+	return o.upstream(id, func(other int64) bool {
+		return UnblocksDownstream(o.changesets[other].ExternalState)
+	})
+}
+
+// Downstream returns the changesets of later ordering groups than the
+// changeset with the given ID.
+func (o *ChangesetOrder) Downstream(id int64) []*btypes.Changeset {
+	group, ok := o.groupOf[id]
+	if !ok {
+		return nil
+	}
+
+	var cs []*btypes.Changeset
+	for other, otherGroup := range o.groupOf {
+		if otherGroup > group {
+			cs = append(cs, o.changesets[other])
+		}
+	}
+	return cs
+}
+
+// heldUnpublished returns the unpublished changesets whose publication is held
+// by their ordering group.
+func (o *ChangesetOrder) heldUnpublished() []*btypes.Changeset {
+	var cs []*btypes.Changeset
+	for id := range o.groupOf {
+		if c := o.changesets[id]; c.PublicationState.Unpublished() && o.HoldsPublication(id) {
+			cs = append(cs, c)
+		}
+	}
+	return cs
+}
+
+// upstream returns the IDs of the changesets of earlier ordering groups than
+// the changeset with the given ID for which unblocked returns false.
+func (o *ChangesetOrder) upstream(id int64, unblocked func(int64) bool) []int64 {
+	group, ok := o.groupOf[id]
+	if !ok {
+		return nil
+	}
+
+	var ids []int64
+	for other, otherGroup := range o.groupOf {
+		if otherGroup < group && !unblocked(other) {
+			ids = append(ids, other)
+		}
+	}
+	return ids
+}
+
+// UnblocksDownstream returns true if a changeset in the given external state
+// no longer blocks the changesets of later ordering groups, because it was
+// either merged or closed without being merged.
+func UnblocksDownstream(s btypes.ChangesetExternalState) bool {
+	switch s {
+	case btypes.ChangesetExternalStateMerged,
+		btypes.ChangesetExternalStateClosed,
+		btypes.ChangesetExternalStateReadOnly,
+		btypes.ChangesetExternalStateDeleted:
+		return true
+	default:
+		return false
+	}
+}
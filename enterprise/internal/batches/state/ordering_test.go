@@ -0,0 +1,117 @@
+package state
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestChangesetOrder(t *testing.T) {
+	spec := &batches.BatchSpec{
+		OrderingGroups: []batches.OrderingGroup{
+			{Repositories: []string{"github.com/sourcegraph/lib"}},
+			{Repositories: []string{"github.com/sourcegraph/app*"}},
+			{Repositories: []string{"github.com/sourcegraph/deploy"}, Hold: batches.OrderingGroupHoldMerge},
+		},
+	}
+	repos := map[api.RepoID]*types.Repo{
+		1: {ID: 1, Name: "github.com/sourcegraph/lib"},
+		2: {ID: 2, Name: "github.com/sourcegraph/app-web"},
+		3: {ID: 3, Name: "github.com/sourcegraph/app-cli"},
+		4: {ID: 4, Name: "github.com/sourcegraph/deploy"},
+		5: {ID: 5, Name: "github.com/sourcegraph/docs"},
+	}
+	changeset := func(id int64, state btypes.ChangesetExternalState) *btypes.Changeset {
+		return &btypes.Changeset{ID: id, RepoID: api.RepoID(id), ExternalState: state}
+	}
+
+	t.Run("nothing merged", func(t *testing.T) {
+		order := NewChangesetOrder(spec, []*btypes.Changeset{
+			changeset(1, btypes.ChangesetExternalStateOpen),
+			changeset(2, btypes.ChangesetExternalStateOpen),
+			changeset(3, btypes.ChangesetExternalStateOpen),
+			changeset(4, btypes.ChangesetExternalStateOpen),
+			changeset(5, btypes.ChangesetExternalStateOpen),
+		}, repos)
+
+		for id, want := range map[int64]bool{1: false, 2: true, 3: true, 4: true, 5: false} {
+			if have := order.Blocked(id); have != want {
+				t.Errorf("changeset %d: unexpected blocked: have=%t want=%t", id, have, want)
+			}
+		}
+		for id, want := range map[int64]bool{1: true, 2: true, 3: true, 4: false, 5: false} {
+			if have := order.HoldsPublication(id); have != want {
+				t.Errorf("changeset %d: unexpected holds publication: have=%t want=%t", id, have, want)
+			}
+		}
+
+		if diff := cmp.Diff([]int64{1, 2, 3}, sortedIDs(order.Upstream(4))); diff != "" {
+			t.Errorf("unexpected upstream changesets (-want +got):\n%s", diff)
+		}
+
+		var downstream []int64
+		for _, c := range order.Downstream(1) {
+			downstream = append(downstream, c.ID)
+		}
+		if diff := cmp.Diff([]int64{2, 3, 4}, sortedIDs(downstream)); diff != "" {
+			t.Errorf("unexpected downstream changesets (-want +got):\n%s", diff)
+		}
+		if have := order.Downstream(5); len(have) != 0 {
+			t.Errorf("unexpected downstream changesets of ungrouped changeset: %v", have)
+		}
+	})
+
+	t.Run("earlier groups merged", func(t *testing.T) {
+		order := NewChangesetOrder(spec, []*btypes.Changeset{
+			changeset(1, btypes.ChangesetExternalStateMerged),
+			changeset(2, btypes.ChangesetExternalStateMerged),
+			changeset(3, btypes.ChangesetExternalStateOpen),
+			changeset(4, btypes.ChangesetExternalStateOpen),
+		}, repos)
+
+		for id, want := range map[int64]bool{1: false, 2: false, 3: false, 4: true} {
+			if have := order.Blocked(id); have != want {
+				t.Errorf("changeset %d: unexpected blocked: have=%t want=%t", id, have, want)
+			}
+		}
+		if diff := cmp.Diff([]int64{3}, order.Upstream(4)); diff != "" {
+			t.Errorf("unexpected upstream changesets (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("earlier group closed without merge", func(t *testing.T) {
+		order := NewChangesetOrder(spec, []*btypes.Changeset{
+			changeset(1, btypes.ChangesetExternalStateClosed),
+			changeset(2, btypes.ChangesetExternalStateMerged),
+			changeset(3, btypes.ChangesetExternalStateOpen),
+		}, repos)
+
+		for id, want := range map[int64]bool{2: false, 3: false} {
+			if have := order.Blocked(id); have != want {
+				t.Errorf("changeset %d: unexpected blocked: have=%t want=%t", id, have, want)
+			}
+		}
+	})
+
+	t.Run("no ordering groups", func(t *testing.T) {
+		order := NewChangesetOrder(&batches.BatchSpec{}, []*btypes.Changeset{
+			changeset(1, btypes.ChangesetExternalStateOpen),
+			changeset(2, btypes.ChangesetExternalStateOpen),
+		}, repos)
+
+		if order.Blocked(2) || order.HoldsPublication(2) {
+			t.Error("changeset unexpectedly part of an ordering group")
+		}
+	})
+}
+
+func sortedIDs(ids []int64) []int64 {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -24,6 +25,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -118,6 +120,8 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 		return computeBitbucketCloudBuildState(c.UpdatedAt, m, events)
 	case *azuredevops.AnnotatedPullRequest:
 		return computeAzureDevOpsBuildState(m)
+	case *gerritbatches.AnnotatedChange:
+		return computeGerritBuildState(m)
 	}
 
 	return btypes.ChangesetCheckStateUnknown
@@ -285,6 +289,27 @@ func parseAzureDevOpsBuildState(s adobatches.PullRequestStatusState) btypes.Chan
 	}
 }
 
+// computeGerritBuildState computes the check state of a Gerrit change from the
+// votes on its Verified label, which is where CI systems report their results.
+func computeGerritBuildState(c *gerritbatches.AnnotatedChange) btypes.ChangesetCheckState {
+	states := []btypes.ChangesetCheckState{}
+	for _, reviewer := range c.Reviewers(gerrit.LabelVerified) {
+		states = append(states, parseGerritVerifiedVote(reviewer.Value))
+	}
+	return combineCheckStates(states)
+}
+
+func parseGerritVerifiedVote(value int) btypes.ChangesetCheckState {
+	switch {
+	case value > 0:
+		return btypes.ChangesetCheckStatePassed
+	case value < 0:
+		return btypes.ChangesetCheckStateFailed
+	default:
+		return btypes.ChangesetCheckStatePending
+	}
+}
+
 func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
 	// We should only consider the latest commit. This could be from a sync or a webhook that
 	// has occurred later
@@ -564,6 +589,21 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown Azure DevOps pull request state: %s", m.Status)
 		}
+	case *gerritbatches.AnnotatedChange:
+		switch m.Status {
+		case gerrit.ChangeStatusAbandoned:
+			s = btypes.ChangesetExternalStateClosed
+		case gerrit.ChangeStatusMerged:
+			s = btypes.ChangesetExternalStateMerged
+		case gerrit.ChangeStatusNew:
+			if m.WorkInProgress {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Gerrit change status: %s", m.Status)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -575,6 +615,64 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 	return s, nil
 }
 
+// HasMergeConflict reports whether the code host reported that the changeset
+// can't be merged into its base branch, because the two have diverged in
+// conflicting ways. Code hosts that don't report conflicts never have one:
+// Bitbucket Cloud doesn't expose conflicts in its API, Bitbucket Server only
+// once it has run a merge check, and Gerrit only if the instance computes the
+// mergeability of changes.
+func HasMergeConflict(c *btypes.Changeset) bool {
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		return m.Mergeable == "CONFLICTING"
+	case *bitbucketserver.PullRequest:
+		return m.Properties != nil && m.Properties.MergeResult != nil &&
+			m.Properties.MergeResult.Outcome == "CONFLICTED"
+	case *gitlab.MergeRequest:
+		return m.HasConflicts
+	case *azuredevops.AnnotatedPullRequest:
+		return m.MergeStatus == adobatches.PullRequestMergeStatusConflicts
+	case *gerritbatches.AnnotatedChange:
+		return m.Change != nil && m.Mergeable != nil && !*m.Mergeable
+	default:
+		return false
+	}
+}
+
+// CountApprovals returns the number of reviewers whose latest review of the
+// changeset approved it, based on the given changeset events.
+func CountApprovals(events ChangesetEvents) int {
+	events = append(ChangesetEvents{}, events...)
+	sort.Sort(events)
+
+	approved := map[string]bool{}
+	for _, e := range events {
+		// We only care about "Approved", "ChangesRequested" or "Dismissed"
+		// reviews: comments don't change whether a reviewer approved.
+		s, err := e.ReviewState()
+		if err != nil || (s != btypes.ChangesetReviewStateApproved &&
+			s != btypes.ChangesetReviewStateChangesRequested &&
+			s != btypes.ChangesetReviewStateDismissed) {
+			continue
+		}
+
+		// If the user has been deleted, skip their reviews, as they don't
+		// count towards the approvals anymore.
+		author := e.ReviewAuthor()
+		if author == "" {
+			continue
+		}
+
+		if s == btypes.ChangesetReviewStateApproved {
+			approved[author] = true
+		} else {
+			delete(approved, author)
+		}
+	}
+
+	return len(approved)
+}
+
 // computeSingleChangesetReviewState computes the review state of a Changeset.
 // GitHub doesn't keep the review state on a changeset, so a GitHub Changeset
 // will always return ChangesetReviewStatePending.
@@ -650,6 +748,20 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 				states[btypes.ChangesetReviewStatePending] = true
 			}
 		}
+	case *gerritbatches.AnnotatedChange:
+		for _, reviewer := range m.Reviewers(gerrit.LabelCodeReview) {
+			// Code-Review votes range from -2 to +2 on a default Gerrit
+			// installation. Only +2 approves a change, while +1 means that
+			// someone else must approve it.
+			switch {
+			case reviewer.Value >= 2:
+				states[btypes.ChangesetReviewStateApproved] = true
+			case reviewer.Value < 0:
+				states[btypes.ChangesetReviewStateChangesRequested] = true
+			default:
+				states[btypes.ChangesetReviewStatePending] = true
+			}
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -9,9 +9,11 @@ import (
 	azuredevops2 "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -391,6 +393,59 @@ func TestComputeAzureDevOpsBuildStatus(t *testing.T) {
 	}
 }
 
+func TestComputeGerritBuildState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		votes []gerrit.Approval
+		want  btypes.ChangesetCheckState
+	}{
+		{
+			name:  "no votes",
+			votes: nil,
+			want:  btypes.ChangesetCheckStateUnknown,
+		},
+		{
+			name:  "verified",
+			votes: []gerrit.Approval{gerritVote(1, 1)},
+			want:  btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name:  "failed",
+			votes: []gerrit.Approval{gerritVote(1, -1)},
+			want:  btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name:  "verified + failed",
+			votes: []gerrit.Approval{gerritVote(1, 1), gerritVote(2, -1)},
+			want:  btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name:  "verified + vote removed",
+			votes: []gerrit.Approval{gerritVote(1, 1), gerritVote(2, 0)},
+			want:  btypes.ChangesetCheckStatePending,
+		},
+		{
+			name:  "reviewer without vote",
+			votes: []gerrit.Approval{{Account: gerrit.Account{ID: 1}}},
+			want:  btypes.ChangesetCheckStateUnknown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			change := gerritChange(gerrit.ChangeStatusNew, false)
+			change.Labels[gerrit.LabelVerified] = gerrit.ChangeLabel{All: tc.votes}
+
+			have := computeGerritBuildState(change)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
 func TestComputeGitLabCheckState(t *testing.T) {
 	t.Parallel()
 
@@ -650,6 +705,30 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "gerrit - no votes",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, false),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name:      "gerrit - approved",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, false, gerritVote(1, 2)),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name:      "gerrit - looks good, but someone else must approve",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, false, gerritVote(1, 1)),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name:      "gerrit - approved and vetoed",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, false, gerritVote(1, 2), gerritVote(2, -2)),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStateChangesRequested,
+		},
 	}
 
 	for i, tc := range tests {
@@ -858,6 +937,30 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: btypes.ChangesetExternalStateReadOnly,
 		},
+		{
+			name:      "gerrit - new",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, false),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "gerrit - work in progress",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, true),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateDraft,
+		},
+		{
+			name:      "gerrit - abandoned",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusAbandoned, false),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "gerrit - merged",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusMerged, false),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
 	}
 
 	for i, tc := range tests {
@@ -881,6 +984,139 @@ func TestComputeExternalState(t *testing.T) {
 	}
 }
 
+func TestHasMergeConflict(t *testing.T) {
+	t.Parallel()
+
+	notMergeable := false
+
+	tests := []struct {
+		name      string
+		changeset *btypes.Changeset
+		want      bool
+	}{
+		{
+			name:      "github conflicting",
+			changeset: &btypes.Changeset{Metadata: &github.PullRequest{Mergeable: "CONFLICTING"}},
+			want:      true,
+		},
+		{
+			name:      "github mergeable",
+			changeset: &btypes.Changeset{Metadata: &github.PullRequest{Mergeable: "MERGEABLE"}},
+			want:      false,
+		},
+		{
+			name:      "gitlab conflicting",
+			changeset: &btypes.Changeset{Metadata: &gitlab.MergeRequest{HasConflicts: true}},
+			want:      true,
+		},
+		{
+			name:      "gitlab mergeable",
+			changeset: &btypes.Changeset{Metadata: &gitlab.MergeRequest{}},
+			want:      false,
+		},
+		{
+			name: "azure devops conflicting",
+			changeset: &btypes.Changeset{Metadata: &azuredevops2.AnnotatedPullRequest{
+				PullRequest: &azuredevops.PullRequest{MergeStatus: azuredevops.PullRequestMergeStatusConflicts},
+			}},
+			want: true,
+		},
+		{
+			name: "bitbucket server conflicting",
+			changeset: &btypes.Changeset{Metadata: &bitbucketserver.PullRequest{
+				Properties: &bitbucketserver.PullRequestProperties{MergeResult: &bitbucketserver.MergeResult{Outcome: "CONFLICTED"}},
+			}},
+			want: true,
+		},
+		{
+			name:      "bitbucket server without merge check",
+			changeset: &btypes.Changeset{Metadata: &bitbucketserver.PullRequest{}},
+			want:      false,
+		},
+		{
+			name: "gerrit conflicting",
+			changeset: &btypes.Changeset{Metadata: &gerritbatches.AnnotatedChange{
+				Change: &gerrit.Change{Mergeable: &notMergeable},
+			}},
+			want: true,
+		},
+		{
+			name: "gerrit without mergeability",
+			changeset: &btypes.Changeset{Metadata: &gerritbatches.AnnotatedChange{
+				Change: &gerrit.Change{},
+			}},
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if have, want := HasMergeConflict(tc.changeset), tc.want; have != want {
+				t.Errorf("wrong merge conflict state. have=%t, want=%t", have, want)
+			}
+		})
+	}
+}
+
+func TestCountApprovals(t *testing.T) {
+	t.Parallel()
+
+	daysAgo := func(days int) time.Time { return timeutil.Now().AddDate(0, 0, -days) }
+
+	tests := []struct {
+		name   string
+		events ChangesetEvents
+		want   int
+	}{
+		{
+			name:   "no events",
+			events: ChangesetEvents{},
+			want:   0,
+		},
+		{
+			name: "approvals by different reviewers",
+			events: ChangesetEvents{
+				ghReview(1, daysAgo(2), "alice", "APPROVED"),
+				ghReview(1, daysAgo(1), "bob", "APPROVED"),
+			},
+			want: 2,
+		},
+		{
+			name: "comment after approval",
+			events: ChangesetEvents{
+				ghReview(1, daysAgo(2), "alice", "APPROVED"),
+				ghReview(1, daysAgo(1), "alice", "COMMENTED"),
+			},
+			want: 1,
+		},
+		{
+			name: "changes requested after approval",
+			events: ChangesetEvents{
+				ghReview(1, daysAgo(1), "alice", "CHANGES_REQUESTED"),
+				ghReview(1, daysAgo(2), "alice", "APPROVED"),
+				ghReview(1, daysAgo(2), "bob", "APPROVED"),
+			},
+			want: 1,
+		},
+		{
+			name: "dismissed approval",
+			events: ChangesetEvents{
+				ghReview(1, daysAgo(2), "alice", "APPROVED"),
+				ghReviewDismissed(1, daysAgo(1), "bob", "alice"),
+			},
+			want: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if have, want := CountApprovals(tc.events), tc.want; have != want {
+				t.Errorf("wrong number of approvals. have=%d, want=%d", have, want)
+			}
+		})
+	}
+}
+
 func TestComputeLabels(t *testing.T) {
 	t.Parallel()
 
@@ -1013,6 +1249,34 @@ func gitLabChangeset(updatedAt time.Time, state gitlab.MergeRequestState, notes
 	}
 }
 
+func gerritChange(status gerrit.ChangeStatus, wip bool, codeReviewVotes ...gerrit.Approval) *gerritbatches.AnnotatedChange {
+	return &gerritbatches.AnnotatedChange{
+		Change: &gerrit.Change{
+			Status:         status,
+			WorkInProgress: wip,
+			Labels: map[string]gerrit.ChangeLabel{
+				gerrit.LabelCodeReview: {All: codeReviewVotes},
+			},
+		},
+	}
+}
+
+func gerritChangeset(updatedAt time.Time, status gerrit.ChangeStatus, wip bool, codeReviewVotes ...gerrit.Approval) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeGerrit,
+		UpdatedAt:           updatedAt,
+		Metadata:            gerritChange(status, wip, codeReviewVotes...),
+	}
+}
+
+func gerritVote(accountID int32, value int) gerrit.Approval {
+	return gerrit.Approval{
+		Account: gerrit.Account{ID: accountID},
+		Value:   value,
+		Date:    &gerrit.Timestamp{Time: timeutil.Now()},
+	}
+}
+
 func setDeletedAt(c *btypes.Changeset, deletedAt time.Time) *btypes.Changeset {
 	c.ExternalDeletedAt = deletedAt
 	return c
@@ -571,6 +571,10 @@ type ListBatchChangesOpts struct {
 	RepoID api.RepoID
 
 	ExcludeDraftsNotOwnedByUserID int32
+
+	// OnlyWithAutoMergePolicy filters the batch changes to those whose
+	// current batch spec defines an auto-merge policy.
+	OnlyWithAutoMergePolicy bool
 }
 
 // ListBatchChanges lists batch changes with the given filters.
@@ -671,6 +675,10 @@ func listBatchChangesQuery(opts *ListBatchChangesOpts, repoAuthzConds *sqlf.Quer
 		preds = append(preds, sqlf.Sprintf("batch_changes.namespace_org_id = %s", opts.NamespaceOrgID))
 	}
 
+	if opts.OnlyWithAutoMergePolicy {
+		preds = append(preds, sqlf.Sprintf("EXISTS (SELECT 1 FROM batch_specs WHERE batch_specs.id = batch_changes.batch_spec_id AND batch_specs.spec ? 'autoMerge')"))
+	}
+
 	if opts.RepoID != 0 {
 		preds = append(preds, sqlf.Sprintf(`EXISTS(
 			SELECT * FROM changesets
@@ -95,6 +95,9 @@ RETURNING %s
 type ListBatchSpecExecutionCacheEntriesOpts struct {
 	Keys   []string
 	UserID int32
+	// If true, return the entries with the given keys of all users. This must
+	// only be used for content-addressed keys, that can be shared across users.
+	AnyUser bool
 	// If true, explicitly return all entires.
 	All bool
 }
@@ -106,7 +109,7 @@ func (s *Store) ListBatchSpecExecutionCacheEntries(ctx context.Context, opts Lis
 	}})
 	defer endObservation(1, observation.Args{})
 
-	if !opts.All && opts.UserID == 0 {
+	if !opts.All && !opts.AnyUser && opts.UserID == 0 {
 		return nil, errors.New("cannot query cache entries without specifying UserID")
 	}
 
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/keegancsmith/sqlf"
 
 	"github.com/sourcegraph/log/logtest"
@@ -76,6 +77,29 @@ func testStoreBatchSpecExecutionCacheEntries(t *testing.T, ctx context.Context,
 				})
 			}
 		})
+
+		t.Run("ListByKeysOfAnyUser", func(t *testing.T) {
+			cs, err := s.ListBatchSpecExecutionCacheEntries(ctx, ListBatchSpecExecutionCacheEntriesOpts{
+				AnyUser: true,
+				Keys:    []string{entries[0].Key, entries[1].Key},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			byID := cmpopts.SortSlices(func(a, b *btypes.BatchSpecExecutionCacheEntry) bool { return a.ID < b.ID })
+			if diff := cmp.Diff(cs, entries[:2], byID); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("ListByKeysWithoutUser", func(t *testing.T) {
+			_, err := s.ListBatchSpecExecutionCacheEntries(ctx, ListBatchSpecExecutionCacheEntriesOpts{
+				Keys: []string{entries[0].Key},
+			})
+			if err == nil {
+				t.Fatal("no error returned")
+			}
+		})
 	})
 
 	t.Run("CreateWithConflictingKey", func(t *testing.T) {
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"sort"
+	"strconv"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
@@ -145,7 +146,8 @@ func (s *Store) CreateBatchSpecWorkspace(ctx context.Context, ws ...*btypes.Batc
 
 // GetBatchSpecWorkspaceOpts captures the query options needed for getting a BatchSpecWorkspace
 type GetBatchSpecWorkspaceOpts struct {
-	ID int64
+	ID              int64
+	ChangesetSpecID int64
 }
 
 // GetBatchSpecWorkspace gets a BatchSpecWorkspace matching the given options.
@@ -181,7 +183,14 @@ LIMIT 1
 func getBatchSpecWorkspaceQuery(opts *GetBatchSpecWorkspaceOpts) *sqlf.Query {
 	preds := []*sqlf.Query{
 		sqlf.Sprintf("repo.deleted_at IS NULL"),
-		sqlf.Sprintf("batch_spec_workspaces.id = %s", opts.ID),
+	}
+
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspaces.id = %s", opts.ID))
+	}
+
+	if opts.ChangesetSpecID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspaces.changeset_spec_ids ? %s", strconv.FormatInt(opts.ChangesetSpecID, 10)))
 	}
 
 	return sqlf.Sprintf(
@@ -395,6 +404,77 @@ func (s *Store) MarkSkippedBatchSpecWorkspaces(ctx context.Context, batchSpecID
 	return s.Exec(ctx, q)
 }
 
+const rebaseBatchSpecWorkspaceQueryFmtstr = `
+WITH running_jobs AS (
+	SELECT
+		id
+	FROM
+		batch_spec_workspace_execution_jobs
+	WHERE
+		batch_spec_workspace_id = %s
+		AND
+		state IN ('queued', 'processing')
+),
+removed_jobs AS (
+	DELETE FROM batch_spec_workspace_execution_jobs
+	WHERE
+		batch_spec_workspace_id = %s
+		AND
+		NOT EXISTS (SELECT 1 FROM running_jobs)
+),
+rebased_workspace AS (
+	UPDATE
+		batch_spec_workspaces
+	SET
+		commit = %s,
+		cached_result_found = FALSE,
+		step_cache_results = '{}',
+		updated_at = %s
+	WHERE
+		id = %s
+		AND
+		NOT EXISTS (SELECT 1 FROM running_jobs)
+	RETURNING
+		id, batch_spec_id
+)
+INSERT INTO
+	batch_spec_workspace_execution_jobs (batch_spec_workspace_id, user_id, version)
+SELECT
+	rebased_workspace.id,
+	batch_specs.user_id,
+	%s
+FROM
+	rebased_workspace
+JOIN
+	batch_specs ON batch_specs.id = rebased_workspace.batch_spec_id
+RETURNING
+	id
+`
+
+// RebaseBatchSpecWorkspace moves the given workspace to the given commit of its
+// branch and creates a new execution job for it, so that its steps are
+// re-executed on top of that commit. If the workspace is already being
+// executed, nothing happens and false is returned.
+func (s *Store) RebaseBatchSpecWorkspace(ctx context.Context, id int64, commit string) (rebased bool, err error) {
+	ctx, _, endObservation := s.operations.rebaseBatchSpecWorkspace.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+		log.String("commit", commit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		rebaseBatchSpecWorkspaceQueryFmtstr,
+		id,
+		id,
+		commit,
+		s.now(),
+		id,
+		versionForExecution(ctx, s),
+	)
+	_, rebased, err = basestore.ScanFirstInt(s.Query(ctx, q))
+	return rebased, err
+}
+
 // ListRetryBatchSpecWorkspacesOpts options to determine which btypes.BatchSpecWorkspace to retrieve for retrying.
 type ListRetryBatchSpecWorkspacesOpts struct {
 	BatchSpecID      int64
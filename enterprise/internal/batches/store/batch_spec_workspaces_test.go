@@ -455,6 +455,60 @@ func testStoreBatchSpecWorkspaces(t *testing.T, ctx context.Context, s *Store, c
 		})
 	})
 
+	t.Run("RebaseBatchSpecWorkspace", func(t *testing.T) {
+		spec := &btypes.BatchSpec{UserID: user.ID, NamespaceUserID: user.ID}
+		require.NoError(t, s.CreateBatchSpec(ctx, spec))
+
+		workspace := &btypes.BatchSpecWorkspace{
+			BatchSpecID:       spec.ID,
+			RepoID:            repos[0].ID,
+			Branch:            "master",
+			Commit:            "d34db33f",
+			CachedResultFound: true,
+			StepCacheResults: map[int]btypes.StepCacheResult{
+				1: {Key: "asdf", Value: &execution.AfterStepResult{StepIndex: 1}},
+			},
+		}
+		require.NoError(t, s.CreateBatchSpecWorkspace(ctx, workspace))
+		require.NoError(t, s.Exec(ctx, sqlf.Sprintf("INSERT INTO batch_spec_workspace_execution_jobs (batch_spec_workspace_id, user_id, state) VALUES (%s, %s, %s)", workspace.ID, user.ID, btypes.BatchSpecWorkspaceExecutionJobStateCompleted)))
+
+		rebased, err := s.RebaseBatchSpecWorkspace(ctx, workspace.ID, "c0ff33")
+		require.NoError(t, err)
+		assert.True(t, rebased)
+
+		have, err := s.GetBatchSpecWorkspace(ctx, GetBatchSpecWorkspaceOpts{ID: workspace.ID})
+		require.NoError(t, err)
+		assert.Equal(t, "c0ff33", have.Commit)
+		assert.False(t, have.CachedResultFound)
+		assert.Empty(t, have.StepCacheResults)
+
+		// The completed job is replaced with a queued one.
+		jobs, err := s.ListBatchSpecWorkspaceExecutionJobs(ctx, ListBatchSpecWorkspaceExecutionJobsOpts{
+			BatchSpecWorkspaceIDs: []int64{workspace.ID},
+			ExcludeRank:           true,
+		})
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		assert.Equal(t, btypes.BatchSpecWorkspaceExecutionJobStateQueued, jobs[0].State)
+		assert.Equal(t, user.ID, jobs[0].UserID)
+
+		// While the workspace is being executed, it isn't rebased again.
+		rebased, err = s.RebaseBatchSpecWorkspace(ctx, workspace.ID, "b33f")
+		require.NoError(t, err)
+		assert.False(t, rebased)
+
+		have, err = s.GetBatchSpecWorkspace(ctx, GetBatchSpecWorkspaceOpts{ID: workspace.ID})
+		require.NoError(t, err)
+		assert.Equal(t, "c0ff33", have.Commit)
+
+		jobs, err = s.ListBatchSpecWorkspaceExecutionJobs(ctx, ListBatchSpecWorkspaceExecutionJobsOpts{
+			BatchSpecWorkspaceIDs: []int64{workspace.ID},
+			ExcludeRank:           true,
+		})
+		require.NoError(t, err)
+		assert.Len(t, jobs, 1)
+	})
+
 	t.Run("DisableBatchSpecWorkspaceExecutionCache", func(t *testing.T) {
 		cs := &btypes.ChangesetSpec{}
 		require.NoError(t, s.CreateChangesetSpec(ctx, cs))
@@ -155,6 +155,9 @@ func listChangesetEventsQuery(opts *ListChangesetEventsOpts) *sqlf.Query {
 // counting changeset events.
 type CountChangesetEventsOpts struct {
 	ChangesetID int64
+
+	Kind         btypes.ChangesetEventKind
+	CreatedAfter time.Time
 }
 
 // CountChangesetEvents returns the number of changeset events in the database.
@@ -168,22 +171,68 @@ func (s *Store) CountChangesetEvents(ctx context.Context, opts CountChangesetEve
 }
 
 var countChangesetEventsQueryFmtstr = `
-SELECT COUNT(id)
+SELECT COUNT(changeset_events.id)
 FROM changeset_events
 WHERE %s
 `
 
 func countChangesetEventsQuery(opts *CountChangesetEventsOpts) *sqlf.Query {
+	return sqlf.Sprintf(countChangesetEventsQueryFmtstr, sqlf.Join(countChangesetEventsPreds(opts), "\n AND "))
+}
+
+// CountChangesetEventsByCodeHost returns the number of changeset events in the
+// database, by the ID of the code host of the repositories of their changesets.
+func (s *Store) CountChangesetEventsByCodeHost(ctx context.Context, opts CountChangesetEventsOpts) (counts map[string]int, err error) {
+	ctx, _, endObservation := s.operations.countChangesetEventsByCodeHost.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("kind", string(opts.Kind)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	counts = map[string]int{}
+	q := sqlf.Sprintf(countChangesetEventsByCodeHostQueryFmtstr, sqlf.Join(countChangesetEventsPreds(&opts), "\n AND "))
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var (
+			codeHost string
+			count    int
+		)
+		if err := sc.Scan(&codeHost, &count); err != nil {
+			return err
+		}
+		counts[codeHost] = count
+		return nil
+	})
+
+	return counts, err
+}
+
+var countChangesetEventsByCodeHostQueryFmtstr = `
+SELECT repo.external_service_id, COUNT(changeset_events.id)
+FROM changeset_events
+JOIN changesets ON changesets.id = changeset_events.changeset_id
+JOIN repo ON repo.id = changesets.repo_id
+WHERE %s
+GROUP BY repo.external_service_id
+`
+
+func countChangesetEventsPreds(opts *CountChangesetEventsOpts) []*sqlf.Query {
 	var preds []*sqlf.Query
 	if opts.ChangesetID != 0 {
-		preds = append(preds, sqlf.Sprintf("changeset_id = %s", opts.ChangesetID))
+		preds = append(preds, sqlf.Sprintf("changeset_events.changeset_id = %s", opts.ChangesetID))
+	}
+
+	if opts.Kind != "" {
+		preds = append(preds, sqlf.Sprintf("changeset_events.kind = %s", opts.Kind))
+	}
+
+	if !opts.CreatedAfter.IsZero() {
+		preds = append(preds, sqlf.Sprintf("changeset_events.created_at > %s", opts.CreatedAfter))
 	}
 
 	if len(preds) == 0 {
 		preds = append(preds, sqlf.Sprintf("TRUE"))
 	}
 
-	return sqlf.Sprintf(countChangesetEventsQueryFmtstr, sqlf.Join(preds, "\n AND "))
+	return preds
 }
 
 // UpsertChangesetEvents creates or updates the given ChangesetEvents.
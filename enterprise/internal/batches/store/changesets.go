@@ -19,6 +19,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/search"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -28,6 +29,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -822,6 +824,97 @@ func (s *Store) enqueueChangesetQuery(cs *btypes.Changeset, resetState, currentS
 	)
 }
 
+// RequeueChangeset sets the reconciler_state of the given changeset, which is
+// being processed by the reconciler, back to queued, so that the reconciler
+// processes it again once the given time has passed.
+func (s *Store) RequeueChangeset(ctx context.Context, cs *btypes.Changeset, after time.Time) (err error) {
+	ctx, _, endObservation := s.operations.requeueChangeset.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+		log.String("after", after.String()),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	cs.ReconcilerState = btypes.ReconcilerStateQueued
+	cs.ProcessAfter = after
+	cs.UpdatedAt = s.now()
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		requeueChangesetQueryFmtstr,
+		btypes.ReconcilerStateQueued.ToDB(),
+		cs.ProcessAfter,
+		cs.UpdatedAt,
+		cs.ID,
+	))
+}
+
+var requeueChangesetQueryFmtstr = `
+UPDATE changesets
+SET
+	reconciler_state = %s,
+	process_after = %s,
+	started_at = NULL,
+	updated_at = %s
+WHERE id = %s
+`
+
+// ReplaceRebasedChangesetSpecs replaces the current spec of every changeset
+// whose current spec is one of oldSpecIDs with the spec out of newSpecIDs that
+// targets the same branch, and enqueues the changesets, so that the reconciler
+// pushes the rebased commit. The spec it replaces becomes the previous spec of
+// the changeset.
+//
+// This is used once the workspace of an applied batch spec has been
+// re-executed against a newer commit of its base branch.
+func (s *Store) ReplaceRebasedChangesetSpecs(ctx context.Context, oldSpecIDs, newSpecIDs []int64) (err error) {
+	ctx, _, endObservation := s.operations.replaceRebasedChangesetSpecs.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("count", len(newSpecIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(oldSpecIDs) == 0 || len(newSpecIDs) == 0 {
+		return nil
+	}
+
+	q := sqlf.Sprintf(
+		replaceRebasedChangesetSpecsQueryFmtstr,
+		btypes.ReconcilerStateQueued.ToDB(),
+		s.now(),
+		pq.Array(oldSpecIDs),
+		pq.Array(newSpecIDs),
+	)
+	return s.Exec(ctx, q)
+}
+
+var replaceRebasedChangesetSpecsQueryFmtstr = `
+UPDATE changesets
+SET
+	previous_spec_id = changesets.current_spec_id,
+	current_spec_id = new_specs.id,
+	reconciler_state = %s,
+	num_resets = 0,
+	num_failures = 0,
+	-- Copy over and reset the previous failure message
+	previous_failure_message = changesets.failure_message,
+	failure_message = NULL,
+	updated_at = %s
+FROM
+	changeset_specs old_specs
+JOIN
+	changeset_specs new_specs
+	ON
+		new_specs.repo_id = old_specs.repo_id
+		AND
+		new_specs.head_ref = old_specs.head_ref
+WHERE
+	changesets.current_spec_id = old_specs.id
+	AND
+	changesets.owned_by_batch_change_id IS NOT NULL
+	AND
+	old_specs.id = ANY (%s)
+	AND
+	new_specs.id = ANY (%s)
+`
+
 // UpdateChangeset updates the given Changeset.
 func (s *Store) UpdateChangeset(ctx context.Context, cs *btypes.Changeset) (err error) {
 	ctx, _, endObservation := s.operations.updateChangeset.With(ctx, &err, observation.Args{LogFields: []log.Field{
@@ -1468,6 +1561,11 @@ func ScanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		// Ensure the inner PR is initialized, it should never be nil.
 		m.PullRequest = &azuredevops.PullRequest{}
 		t.Metadata = m
+	case extsvc.TypeGerrit:
+		m := new(gerritbatches.AnnotatedChange)
+		// Ensure the inner change is initialized, it should never be nil.
+		m.Change = &gerrit.Change{}
+		t.Metadata = m
 	default:
 		return errors.New("unknown external service type")
 	}
@@ -1537,6 +1537,68 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock bt.C
 		})
 	})
 
+	t.Run("ReplaceRebasedChangesetSpecs", func(t *testing.T) {
+		newSpec := func(headRef string) *btypes.ChangesetSpec {
+			return bt.CreateChangesetSpec(t, ctx, s, bt.TestSpecOpts{
+				User:    user.ID,
+				Repo:    repo.ID,
+				HeadRef: headRef,
+				Typ:     btypes.ChangesetSpecTypeBranch,
+			})
+		}
+		oldSpec := newSpec("refs/heads/rebase")
+		rebasedSpec := newSpec("refs/heads/rebase")
+		otherOldSpec := newSpec("refs/heads/other")
+		otherRebasedSpec := newSpec("refs/heads/another")
+
+		owned := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               repo.ID,
+			CurrentSpec:        oldSpec.ID,
+			OwnedByBatchChange: 123,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateOpen,
+			FailureMessage:     "horse was here",
+		})
+		// The spec of this changeset has no rebased spec for its branch.
+		unmatched := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               repo.ID,
+			CurrentSpec:        otherOldSpec.ID,
+			OwnedByBatchChange: 123,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateOpen,
+		})
+
+		err := s.ReplaceRebasedChangesetSpecs(
+			ctx,
+			[]int64{oldSpec.ID, otherOldSpec.ID},
+			[]int64{rebasedSpec.ID, otherRebasedSpec.ID},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bt.ReloadAndAssertChangeset(t, ctx, s, owned, bt.ChangesetAssertions{
+			Repo:                   repo.ID,
+			CurrentSpec:            rebasedSpec.ID,
+			PreviousSpec:           oldSpec.ID,
+			OwnedByBatchChange:     123,
+			ReconcilerState:        btypes.ReconcilerStateQueued,
+			PublicationState:       btypes.ChangesetPublicationStatePublished,
+			ExternalState:          btypes.ChangesetExternalStateOpen,
+			PreviousFailureMessage: strPtr("horse was here"),
+		})
+		bt.ReloadAndAssertChangeset(t, ctx, s, unmatched, bt.ChangesetAssertions{
+			Repo:               repo.ID,
+			CurrentSpec:        otherOldSpec.ID,
+			OwnedByBatchChange: 123,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateOpen,
+		})
+	})
+
 	t.Run("UpdateChangesetBatchChanges", func(t *testing.T) {
 		c1 := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
 			ReconcilerState:  btypes.ReconcilerStateCompleted,
@@ -207,10 +207,11 @@ type operations struct {
 	countBulkOperations     *observation.Operation
 	listBulkOperationErrors *observation.Operation
 
-	getChangesetEvent     *observation.Operation
-	listChangesetEvents   *observation.Operation
-	countChangesetEvents  *observation.Operation
-	upsertChangesetEvents *observation.Operation
+	getChangesetEvent              *observation.Operation
+	listChangesetEvents            *observation.Operation
+	countChangesetEvents           *observation.Operation
+	countChangesetEventsByCodeHost *observation.Operation
+	upsertChangesetEvents          *observation.Operation
 
 	createChangesetJob *observation.Operation
 	getChangesetJob    *observation.Operation
@@ -234,6 +235,7 @@ type operations struct {
 	listChangesetSyncData             *observation.Operation
 	listChangesets                    *observation.Operation
 	enqueueChangeset                  *observation.Operation
+	requeueChangeset                  *observation.Operation
 	updateChangeset                   *observation.Operation
 	updateChangesetBatchChanges       *observation.Operation
 	updateChangesetUIPublicationState *observation.Operation
@@ -247,6 +249,7 @@ type operations struct {
 	enqueueNextScheduledChangeset     *observation.Operation
 	getChangesetPlaceInSchedulerQueue *observation.Operation
 	cleanDetachedChangesets           *observation.Operation
+	replaceRebasedChangesetSpecs      *observation.Operation
 
 	listCodeHosts         *observation.Operation
 	getExternalServiceIDs *observation.Operation
@@ -263,6 +266,7 @@ type operations struct {
 	countBatchSpecWorkspaces       *observation.Operation
 	markSkippedBatchSpecWorkspaces *observation.Operation
 	listRetryBatchSpecWorkspaces   *observation.Operation
+	rebaseBatchSpecWorkspace       *observation.Operation
 
 	createBatchSpecWorkspaceExecutionJobs              *observation.Operation
 	createBatchSpecWorkspaceExecutionJobsForWorkspaces *observation.Operation
@@ -351,10 +355,11 @@ func newOperations(observationCtx *observation.Context) *operations {
 			countBulkOperations:     op("CountBulkOperations"),
 			listBulkOperationErrors: op("ListBulkOperationErrors"),
 
-			getChangesetEvent:     op("GetChangesetEvent"),
-			listChangesetEvents:   op("ListChangesetEvents"),
-			countChangesetEvents:  op("CountChangesetEvents"),
-			upsertChangesetEvents: op("UpsertChangesetEvents"),
+			getChangesetEvent:              op("GetChangesetEvent"),
+			listChangesetEvents:            op("ListChangesetEvents"),
+			countChangesetEvents:           op("CountChangesetEvents"),
+			countChangesetEventsByCodeHost: op("CountChangesetEventsByCodeHost"),
+			upsertChangesetEvents:          op("UpsertChangesetEvents"),
 
 			createChangesetJob: op("CreateChangesetJob"),
 			getChangesetJob:    op("GetChangesetJob"),
@@ -378,6 +383,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 			listChangesetSyncData:             op("ListChangesetSyncData"),
 			listChangesets:                    op("ListChangesets"),
 			enqueueChangeset:                  op("EnqueueChangeset"),
+			requeueChangeset:                  op("RequeueChangeset"),
 			updateChangeset:                   op("UpdateChangeset"),
 			updateChangesetBatchChanges:       op("UpdateChangesetBatchChanges"),
 			updateChangesetUIPublicationState: op("UpdateChangesetUIPublicationState"),
@@ -391,6 +397,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 			enqueueNextScheduledChangeset:     op("EnqueueNextScheduledChangeset"),
 			getChangesetPlaceInSchedulerQueue: op("GetChangesetPlaceInSchedulerQueue"),
 			cleanDetachedChangesets:           op("CleanDetachedChangesets"),
+			replaceRebasedChangesetSpecs:      op("ReplaceRebasedChangesetSpecs"),
 
 			listCodeHosts:         op("ListCodeHosts"),
 			getExternalServiceIDs: op("GetExternalServiceIDs"),
@@ -407,6 +414,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 			countBatchSpecWorkspaces:       op("CountBatchSpecWorkspaces"),
 			markSkippedBatchSpecWorkspaces: op("MarkSkippedBatchSpecWorkspaces"),
 			listRetryBatchSpecWorkspaces:   op("ListRetryBatchSpecWorkspaces"),
+			rebaseBatchSpecWorkspace:       op("RebaseBatchSpecWorkspace"),
 
 			createBatchSpecWorkspaceExecutionJobs:              op("CreateBatchSpecWorkspaceExecutionJobs"),
 			createBatchSpecWorkspaceExecutionJobsForWorkspaces: op("CreateBatchSpecWorkspaceExecutionJobsForWorkspaces"),
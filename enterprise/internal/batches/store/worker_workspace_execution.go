@@ -258,6 +258,15 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 		}
 	}
 
+	// If the workspace already had changeset specs, it has been re-executed
+	// to rebase the changesets of an applied batch spec onto a newer commit
+	// of their base branch, so they need to use the new changeset specs.
+	if len(workspace.ChangesetSpecIDs) > 0 {
+		if err := tx.ReplaceRebasedChangesetSpecs(ctx, workspace.ChangesetSpecIDs, changesetSpecIDs); err != nil {
+			return false, errors.Wrap(err, "replacing rebased changeset specs")
+		}
+	}
+
 	if err = s.setChangesetSpecIDs(ctx, tx, job.BatchSpecWorkspaceID, changesetSpecIDs); err != nil {
 		return false, errors.Wrap(err, "setChangesetSpecIDs")
 	}
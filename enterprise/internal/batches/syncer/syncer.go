@@ -501,6 +501,9 @@ func (s *changesetSyncer) SyncChangeset(ctx context.Context, id int64) error {
 // SyncChangeset refreshes the metadata of the given changeset and
 // updates them in the database.
 func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Client, source sources.ChangesetSource, repo *types.Repo, c *btypes.Changeset) (err error) {
+	hadMergeConflict := state.HasMergeConflict(c)
+	unblockedDownstream := state.UnblocksDownstream(c.ExternalState)
+
 	repoChangeset := &sources.Changeset{TargetRepo: repo, Changeset: c}
 	if err := source.LoadChangeset(ctx, repoChangeset); err != nil {
 		if !errors.HasType(err, sources.ChangesetNotFoundError{}) {
@@ -538,5 +541,61 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Cl
 		return err
 	}
 
+	// If the changeset started to conflict with its base branch, we let the
+	// reconciler decide whether it should be rebased.
+	if !hadMergeConflict && state.HasMergeConflict(c) && c.OwnedByBatchChangeID != 0 && c.ReconcilerState == btypes.ReconcilerStateCompleted {
+		if err := tx.EnqueueChangeset(ctx, c, btypes.ReconcilerStateQueued, btypes.ReconcilerStateCompleted); err != nil {
+			return errors.Wrap(err, "enqueueing conflicting changeset")
+		}
+	}
+
+	// If the changeset was merged or closed, changesets of later ordering
+	// groups of its batch changes may no longer be blocked, so we let the
+	// reconciler publish them.
+	if !unblockedDownstream && state.UnblocksDownstream(c.ExternalState) {
+		if err := EnqueueUnblockedChangesets(ctx, tx, c); err != nil {
+			return errors.Wrap(err, "enqueueing unblocked changesets")
+		}
+	}
+
 	return tx.UpsertChangesetEvents(ctx, events...)
 }
+
+// EnqueueUnblockedChangesets enqueues the unpublished changesets whose
+// publication was held by the ordering groups of the batch changes of the
+// given changeset, and that are no longer blocked now that it was merged or
+// closed.
+func EnqueueUnblockedChangesets(ctx context.Context, tx *store.Store, c *btypes.Changeset) error {
+	for _, assoc := range c.BatchChanges {
+		if assoc.Detach || assoc.IsArchived {
+			continue
+		}
+
+		batchChange, err := tx.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: assoc.BatchChangeID})
+		if err != nil {
+			return errors.Wrap(err, "loading batch change")
+		}
+		batchSpec, err := tx.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+		if err != nil {
+			return errors.Wrap(err, "loading batch spec")
+		}
+		order, err := state.LoadChangesetOrder(ctx, tx, batchChange.ID, batchSpec.Spec)
+		if err != nil {
+			return errors.Wrap(err, "loading changeset order")
+		}
+
+		for _, downstream := range order.Downstream(c.ID) {
+			if downstream.PublicationState != btypes.ChangesetPublicationStateUnpublished ||
+				downstream.ReconcilerState != btypes.ReconcilerStateCompleted ||
+				!order.HoldsPublication(downstream.ID) ||
+				order.Blocked(downstream.ID) {
+				continue
+			}
+			if err := tx.EnqueueChangeset(ctx, downstream, btypes.ReconcilerStateQueued, btypes.ReconcilerStateCompleted); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -15,10 +15,12 @@ import (
 
 	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
@@ -451,6 +453,21 @@ func (c *Changeset) SetMetadata(meta any) error {
 			c.ExternalForkNamespace = ""
 			c.ExternalForkName = ""
 		}
+	case *gerritbatches.AnnotatedChange:
+		c.Metadata = pr
+		c.ExternalID = strconv.Itoa(pr.Number)
+		c.ExternalServiceType = extsvc.TypeGerrit
+		// Gerrit changes don't have a head branch. Batch changes pushes them
+		// with the name of the changeset spec's branch as topic instead.
+		if pr.Topic != "" {
+			c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.Topic)
+		} else {
+			c.ExternalBranch = ""
+		}
+		c.ExternalUpdatedAt = pr.Updated.Time
+		// Gerrit has no concept of forks.
+		c.ExternalForkNamespace = ""
+		c.ExternalForkName = ""
 
 	default:
 		return errors.New("unknown changeset type")
@@ -481,6 +498,8 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *adobatches.AnnotatedPullRequest:
 		return m.Title, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Subject, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -504,6 +523,8 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.Author.Username, nil
 	case *adobatches.AnnotatedPullRequest:
 		return m.CreatedBy.UniqueName, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Owner.Username, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -535,6 +556,8 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		return "", nil
 	case *adobatches.AnnotatedPullRequest:
 		return m.CreatedBy.UniqueName, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Owner.Email, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -555,6 +578,8 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedOn
 	case *adobatches.AnnotatedPullRequest:
 		return m.CreationDate
+	case *gerritbatches.AnnotatedChange:
+		return m.Created.Time
 	default:
 		return time.Time{}
 	}
@@ -573,6 +598,8 @@ func (c *Changeset) Body() (string, error) {
 		return m.Rendered.Description.Raw, nil
 	case *adobatches.AnnotatedPullRequest:
 		return m.Description, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Body(), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -637,6 +664,8 @@ func (c *Changeset) URL() (s string, err error) {
 		}
 
 		return returnURL.String(), nil
+	case *gerritbatches.AnnotatedChange:
+		return m.URL()
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -839,6 +868,26 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 				Metadata:    status,
 			})
 		}
+	case *gerritbatches.AnnotatedChange:
+		// Gerrit doesn't have separate review and check entities: both are
+		// votes on labels of the change. We create review events from votes
+		// on Code-Review, and check events from votes on Verified.
+
+		var kind ChangesetEventKind
+
+		for _, reviewer := range m.Reviewers(gerrit.LabelCodeReview, gerrit.LabelVerified) {
+			reviewer := reviewer
+			if kind, err = ChangesetEventKindFor(&reviewer); err != nil {
+				return
+			}
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				// Each account has at most one vote per label on a change.
+				Key:      m.ChangeID + ":" + reviewer.Label + ":" + strconv.Itoa(int(reviewer.ID)),
+				Kind:     kind,
+				Metadata: &reviewer,
+			})
+		}
 	}
 	return events, nil
 }
@@ -858,6 +907,11 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.Source.Commit.Hash, nil
 	case *adobatches.AnnotatedPullRequest:
 		return "", nil
+	case *gerritbatches.AnnotatedChange:
+		// The current patch set of a change may have been created on Gerrit,
+		// for example by editing the commit message, and is then unknown to
+		// gitserver. We rely on HeadRef instead.
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -877,6 +931,14 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.Source.Branch.Name, nil
 	case *adobatches.AnnotatedPullRequest:
 		return m.SourceRefName, nil
+	case *gerritbatches.AnnotatedChange:
+		if m.Topic != "" {
+			return "refs/heads/" + m.Topic, nil
+		}
+		if rev, ok := m.Revisions[m.CurrentRevision]; ok {
+			return rev.Ref, nil
+		}
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -897,6 +959,11 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.Destination.Commit.Hash, nil
 	case *adobatches.AnnotatedPullRequest:
 		return "", nil
+	case *gerritbatches.AnnotatedChange:
+		if commit := m.CurrentCommit(); commit != nil && len(commit.Parents) > 0 {
+			return commit.Parents[0].Commit, nil
+		}
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -916,6 +983,8 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.Destination.Branch.Name, nil
 	case *adobatches.AnnotatedPullRequest:
 		return m.TargetRefName, nil
+	case *gerritbatches.AnnotatedChange:
+		return "refs/heads/" + m.Branch, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -1278,6 +1347,32 @@ func ChangesetEventKindFor(e any) (ChangesetEventKind, error) {
 		default:
 			return ChangesetEventKindAzureDevOpsPullRequestBuildPending, nil
 		}
+
+	case *gerrit.Reviewer:
+		switch e.Label {
+		case gerrit.LabelCodeReview:
+			switch {
+			case e.Value >= 2:
+				return ChangesetEventKindGerritChangeApproved, nil
+			case e.Value == 1:
+				return ChangesetEventKindGerritChangeApprovedWithSuggestions, nil
+			case e.Value == -1:
+				return ChangesetEventKindGerritChangeNeedsChanges, nil
+			case e.Value <= -2:
+				return ChangesetEventKindGerritChangeRejected, nil
+			default:
+				return ChangesetEventKindGerritChangeReviewed, nil
+			}
+		case gerrit.LabelVerified:
+			switch {
+			case e.Value > 0:
+				return ChangesetEventKindGerritChangeBuildSucceeded, nil
+			case e.Value < 0:
+				return ChangesetEventKindGerritChangeBuildFailed, nil
+			default:
+				return ChangesetEventKindGerritChangeBuildPending, nil
+			}
+		}
 	}
 	return ChangesetEventKindInvalid, errors.Errorf("unknown changeset event kind for %T", e)
 }
@@ -1414,6 +1509,10 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (any, error) {
 		default:
 			return new(azuredevops.PullRequestUpdatedEvent), nil
 		}
+	case strings.HasPrefix(string(k), "gerrit"):
+		return new(gerrit.Reviewer), nil
+	case strings.HasPrefix(string(k), "batches"):
+		return new(AutoMergeDecision), nil
 	}
 	return nil, errors.Errorf("unknown changeset event kind %q", k)
 }
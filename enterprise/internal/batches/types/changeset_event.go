@@ -12,6 +12,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
@@ -117,6 +118,22 @@ const (
 	ChangesetEventKindAzureDevOpsPullRequestBuildError              ChangesetEventKind = "azuredevops:pullrequest:build_error"
 	ChangesetEventKindAzureDevOpsPullRequestBuildPending            ChangesetEventKind = "azuredevops:pullrequest:build_pending"
 
+	ChangesetEventKindGerritChangeApproved                ChangesetEventKind = "gerrit:change:approved"
+	ChangesetEventKindGerritChangeApprovedWithSuggestions ChangesetEventKind = "gerrit:change:approved_with_suggestions"
+	ChangesetEventKindGerritChangeReviewed                ChangesetEventKind = "gerrit:change:reviewed"
+	ChangesetEventKindGerritChangeNeedsChanges            ChangesetEventKind = "gerrit:change:needs_changes"
+	ChangesetEventKindGerritChangeRejected                ChangesetEventKind = "gerrit:change:rejected"
+	ChangesetEventKindGerritChangeBuildSucceeded          ChangesetEventKind = "gerrit:change:build_succeeded"
+	ChangesetEventKindGerritChangeBuildFailed             ChangesetEventKind = "gerrit:change:build_failed"
+	ChangesetEventKindGerritChangeBuildPending            ChangesetEventKind = "gerrit:change:build_pending"
+
+	// These changeset events are created by Sourcegraph itself when the
+	// auto-merge policy of the batch change that owns a changeset is evaluated
+	// against it.
+	ChangesetEventKindAutoMergeMerged ChangesetEventKind = "batches:auto_merge:merged"
+	ChangesetEventKindAutoMergeHeld   ChangesetEventKind = "batches:auto_merge:held"
+	ChangesetEventKindAutoMergeFailed ChangesetEventKind = "batches:auto_merge:failed"
+
 	ChangesetEventKindInvalid ChangesetEventKind = "invalid"
 )
 
@@ -132,6 +149,14 @@ type ChangesetEvent struct {
 	Metadata    any
 }
 
+// AutoMergeDecision is the metadata of the ChangesetEvents recorded when the
+// auto-merge policy of a batch change is evaluated against a changeset.
+type AutoMergeDecision struct {
+	BatchChangeID int64     `json:"batchChangeID"`
+	Reason        string    `json:"reason,omitempty"`
+	DecidedAt     time.Time `json:"decidedAt"`
+}
+
 // Clone returns a clone of a ChangesetEvent.
 func (e *ChangesetEvent) Clone() *ChangesetEvent {
 	ee := *e
@@ -199,6 +224,8 @@ func (e *ChangesetEvent) ReviewAuthor() string {
 		return meta.PullRequest.Reviewers[len(meta.PullRequest.Reviewers)-1].UniqueName
 	case *azuredevops.PullRequestUpdatedEvent:
 		return meta.PullRequest.CreatedBy.UniqueName
+	case *gerrit.Reviewer:
+		return meta.Username
 	default:
 		return ""
 	}
@@ -211,7 +238,8 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 		ChangesetEventKindGitLabApproved,
 		ChangesetEventKindBitbucketCloudApproved,
 		ChangesetEventKindBitbucketCloudPullRequestApproved,
-		ChangesetEventKindAzureDevOpsPullRequestApproved:
+		ChangesetEventKindAzureDevOpsPullRequestApproved,
+		ChangesetEventKindGerritChangeApproved:
 		return ChangesetReviewStateApproved, nil
 
 	// BitbucketServer's "REVIEWED" activity is created when someone clicks
@@ -220,7 +248,9 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 		ChangesetEventKindBitbucketCloudChangesRequested,
 		ChangesetEventKindBitbucketCloudPullRequestChangesRequestCreated,
 		ChangesetEventKindAzureDevOpsPullRequestWaitingForAuthor,
-		ChangesetEventKindAzureDevOpsPullRequestApprovedWithSuggestions:
+		ChangesetEventKindAzureDevOpsPullRequestApprovedWithSuggestions,
+		ChangesetEventKindGerritChangeNeedsChanges,
+		ChangesetEventKindGerritChangeRejected:
 		return ChangesetReviewStateChangesRequested, nil
 
 	case ChangesetEventKindGitHubReviewed:
@@ -367,6 +397,12 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = ev.CreatedDate
 	case *azuredevops.PullRequestMergedEvent:
 		t = ev.CreatedDate
+	case *gerrit.Reviewer:
+		if ev.Date != nil {
+			t = ev.Date.Time
+		}
+	case *AutoMergeDecision:
+		t = ev.DecidedAt
 	}
 
 	return t
@@ -929,6 +965,14 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 		o := o.Metadata.(*azuredevops.PullRequestRejectedEvent)
 		*e = *o
 
+	case *gerrit.Reviewer:
+		o := o.Metadata.(*gerrit.Reviewer)
+		*e = *o
+
+	case *AutoMergeDecision:
+		o := o.Metadata.(*AutoMergeDecision)
+		*e = *o
+
 	default:
 		return errors.Errorf("unknown changeset event metadata %T", e)
 	}
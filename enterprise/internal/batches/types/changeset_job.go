@@ -47,6 +47,7 @@ var (
 	ChangesetJobTypeMerge     ChangesetJobType = "merge"
 	ChangesetJobTypeClose     ChangesetJobType = "close"
 	ChangesetJobTypePublish   ChangesetJobType = "publish"
+	ChangesetJobTypeRevert    ChangesetJobType = "revert"
 )
 
 type ChangesetJobCommentPayload struct {
@@ -67,6 +68,12 @@ type ChangesetJobPublishPayload struct {
 	Draft bool `json:"draft"`
 }
 
+type ChangesetJobRevertPayload struct {
+	// RevertBatchChangeID is the ID of the batch change that the changesets
+	// reverting the merged changesets are added to.
+	RevertBatchChangeID int64 `json:"revertBatchChangeID"`
+}
+
 // ChangesetJob describes a one-time action to be taken on a changeset.
 type ChangesetJob struct {
 	ID int64
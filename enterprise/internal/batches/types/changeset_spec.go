@@ -3,6 +3,7 @@ package types
 import (
 	"bytes"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/graph-gophers/graphql-go"
@@ -155,6 +156,32 @@ func (cs *ChangesetSpec) computeDiffStat() error {
 	return nil
 }
 
+// ChangedFiles returns the paths of the files changed by the Diff of the
+// ChangesetSpec. For renamed files, both the old and the new path are
+// returned.
+func (cs *ChangesetSpec) ChangedFiles() ([]string, error) {
+	var files []string
+	reader := godiff.NewMultiFileDiffReader(bytes.NewReader(cs.Diff))
+	for {
+		fileDiff, err := reader.ReadFile()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		origName := strings.TrimPrefix(fileDiff.OrigName, "a/")
+		newName := strings.TrimPrefix(fileDiff.NewName, "b/")
+		if fileDiff.OrigName != "/dev/null" && origName != "" {
+			files = append(files, origName)
+		}
+		if fileDiff.NewName != "/dev/null" && newName != "" && newName != origName {
+			files = append(files, newName)
+		}
+	}
+}
+
 // computeForkNamespace calculates the namespace that the changeset spec will be
 // forked into, if any.
 func (cs *ChangesetSpec) computeForkNamespace() {
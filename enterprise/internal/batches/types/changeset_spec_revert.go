@@ -0,0 +1,210 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Revert returns a new ChangesetSpec that reverts the changes merged by the
+// changeset of the ChangesetSpec when applied on top of the given base
+// revision. mergeDiff is the diff of the merge commit of the changeset against
+// its first parent. Only specs that create a branch can be reverted, and
+// changes to binary files can't be reverted.
+func (cs *ChangesetSpec) Revert(baseRev string, mergeDiff []byte) (*ChangesetSpec, error) {
+	if cs.Type != ChangesetSpecTypeBranch {
+		return nil, errors.New("only changesets created by a batch change can be reverted")
+	}
+
+	diff, err := invertDiff(mergeDiff)
+	if err != nil {
+		return nil, err
+	}
+
+	title := fmt.Sprintf("Revert \"%s\"", cs.Title)
+	c := &ChangesetSpec{
+		Type:              ChangesetSpecTypeBranch,
+		BaseRepoID:        cs.BaseRepoID,
+		BaseRev:           baseRev,
+		BaseRef:           cs.BaseRef,
+		HeadRef:           revertHeadRef(cs.HeadRef),
+		Title:             title,
+		Body:              fmt.Sprintf("This reverts the changes of \"%s\".", cs.Title),
+		Diff:              diff,
+		CommitMessage:     fmt.Sprintf("%s\n\nThis reverts the changes of \"%s\".", title, cs.Title),
+		CommitAuthorName:  cs.CommitAuthorName,
+		CommitAuthorEmail: cs.CommitAuthorEmail,
+		ForkNamespace:     cs.ForkNamespace,
+	}
+	return c, c.computeDiffStat()
+}
+
+// revertHeadRef returns the name of the branch that reverts the changes
+// of the given branch.
+func revertHeadRef(headRef string) string {
+	return "refs/heads/revert-" + strings.TrimPrefix(headRef, "refs/heads/")
+}
+
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+(?:,(\d+))?) \+(\d+(?:,(\d+))?) @@(.*)$`)
+
+// invertDiff returns the inverse of the given git diff: a diff that undoes
+// the changes of the given diff when applied on top of them.
+func invertDiff(diff []byte) ([]byte, error) {
+	var (
+		out bytes.Buffer
+		// origLeft and newLeft are the number of lines of the original and
+		// the new file that are left in the current hunk.
+		origLeft, newLeft int
+		// minusLine is the "--- " line of the current file, which is
+		// written once the "+++ " line is read.
+		minusLine string
+		// removed and added are the inverted lines of the current block of
+		// changes in a hunk. Like git, we write removed lines before added
+		// lines.
+		removed, added []string
+		last           *[]string
+	)
+	flush := func() {
+		for _, l := range removed {
+			out.WriteString(l)
+		}
+		for _, l := range added {
+			out.WriteString(l)
+		}
+		removed, added, last = nil, nil, nil
+	}
+
+	lines := strings.SplitAfter(string(diff), "\n")
+	for i, line := range lines {
+		content := strings.TrimSuffix(line, "\n")
+		eol := line[len(content):]
+
+		// A "No newline at end of file" marker belongs to the line before it,
+		// which can be the last line of a hunk.
+		if strings.HasPrefix(content, `\`) && last != nil {
+			*last = append(*last, line)
+			continue
+		}
+
+		if origLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(content, "+"):
+				newLeft--
+				removed = append(removed, "-"+content[1:]+eol)
+				last = &removed
+			case strings.HasPrefix(content, "-"):
+				origLeft--
+				added = append(added, "+"+content[1:]+eol)
+				last = &added
+			default:
+				flush()
+				origLeft--
+				newLeft--
+				out.WriteString(line)
+			}
+			continue
+		}
+		flush()
+
+		switch {
+		case strings.HasPrefix(content, "diff --git "):
+			out.WriteString(invertGitHeader(content, lines[i+1:]) + eol)
+
+		case strings.HasPrefix(content, "--- "):
+			minusLine = content
+		case strings.HasPrefix(content, "+++ "):
+			out.WriteString("--- " + strings.TrimPrefix(content, "+++ ") + "\n")
+			out.WriteString("+++ " + strings.TrimPrefix(minusLine, "--- ") + eol)
+
+		case strings.HasPrefix(content, "@@ "):
+			m := hunkHeaderPattern.FindStringSubmatch(content)
+			if m == nil {
+				return nil, errors.Newf("invalid hunk header %q", content)
+			}
+			origLeft, newLeft = hunkLength(m[2]), hunkLength(m[4])
+			out.WriteString(fmt.Sprintf("@@ -%s +%s @@%s", m[3], m[1], m[5]) + eol)
+
+		case strings.HasPrefix(content, "new file mode "):
+			out.WriteString("deleted file mode " + strings.TrimPrefix(content, "new file mode ") + eol)
+		case strings.HasPrefix(content, "deleted file mode "):
+			out.WriteString("new file mode " + strings.TrimPrefix(content, "deleted file mode ") + eol)
+		case strings.HasPrefix(content, "old mode "):
+			out.WriteString("new mode " + strings.TrimPrefix(content, "old mode ") + eol)
+		case strings.HasPrefix(content, "new mode "):
+			out.WriteString("old mode " + strings.TrimPrefix(content, "new mode ") + eol)
+		case strings.HasPrefix(content, "rename from "):
+			out.WriteString("rename to " + strings.TrimPrefix(content, "rename from ") + eol)
+		case strings.HasPrefix(content, "rename to "):
+			out.WriteString("rename from " + strings.TrimPrefix(content, "rename to ") + eol)
+		case strings.HasPrefix(content, "index "):
+			out.WriteString(invertIndexLine(content) + eol)
+
+		case strings.HasPrefix(content, "GIT binary patch"), strings.HasPrefix(content, "Binary files "):
+			return nil, errors.New("changes to binary files can't be reverted")
+		case strings.HasPrefix(content, "copy from "), strings.HasPrefix(content, "copy to "):
+			return nil, errors.New("copied files can't be reverted")
+
+		default:
+			out.WriteString(line)
+		}
+	}
+	flush()
+
+	return out.Bytes(), nil
+}
+
+// hunkLength returns the number of lines of a hunk range, which is 1 if the
+// length is omitted.
+func hunkLength(s string) int {
+	if s == "" {
+		return 1
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// invertGitHeader inverts the "diff --git <old> <new>" line of a file diff.
+// The names are taken from the rename lines of the extended header that
+// follows the line, if there are any, since names containing spaces can't be
+// split reliably. The prefixes of the names, if any, are retained.
+func invertGitHeader(header string, rest []string) string {
+	var from, to string
+	for _, line := range rest {
+		line = strings.TrimSuffix(line, "\n")
+		if strings.HasPrefix(line, "rename from ") {
+			from = strings.TrimPrefix(line, "rename from ")
+		} else if strings.HasPrefix(line, "rename to ") {
+			to = strings.TrimPrefix(line, "rename to ")
+		} else if strings.HasPrefix(line, "diff --git ") || strings.HasPrefix(line, "--- ") || strings.HasPrefix(line, "@@ ") {
+			break
+		}
+	}
+	if from == "" || to == "" {
+		// Without a rename, the old and the new name are the same.
+		return header
+	}
+
+	names := strings.TrimPrefix(header, "diff --git ")
+	i := strings.Index(names, from+" ")
+	j := len(names) - len(to)
+	if i < 0 || j < i+len(from)+1 || names[j:] != to {
+		return header
+	}
+	oldPrefix, newPrefix := names[:i], names[i+len(from)+1:j]
+	return "diff --git " + oldPrefix + to + " " + newPrefix + from
+}
+
+// invertIndexLine inverts an "index <old>..<new> [<mode>]" line.
+func invertIndexLine(line string) string {
+	fields := strings.SplitN(strings.TrimPrefix(line, "index "), " ", 2)
+	hashes := strings.SplitN(fields[0], "..", 2)
+	if len(hashes) != 2 {
+		return line
+	}
+	fields[0] = hashes[1] + ".." + hashes[0]
+	return "index " + strings.Join(fields, " ")
+}
@@ -49,3 +49,176 @@ func TestChangesetSpec_SetForkToUser(t *testing.T) {
 }
 
 func strPtr(s string) *string { return &s }
+
+func TestChangesetSpec_ChangedFiles(t *testing.T) {
+	spec := &ChangesetSpec{Diff: []byte(`diff --git a/README.md b/README.md
+index 671e50a..851b23a 100644
+--- a/README.md
++++ b/README.md
+@@ -1 +1,2 @@
+ # README
++Hello
+diff --git a/cmd/main.go b/cmd/app.go
+similarity index 90%
+rename from cmd/main.go
+rename to cmd/app.go
+index 671e50a..851b23a 100644
+--- a/cmd/main.go
++++ b/cmd/app.go
+@@ -1 +1 @@
+-package main
++package app
+diff --git a/web/index.js b/web/index.js
+new file mode 100644
+index 0000000..851b23a
+--- /dev/null
++++ b/web/index.js
+@@ -0,0 +1 @@
++console.log("hello")
+`)}
+
+	have, err := spec.ChangedFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"README.md", "cmd/main.go", "cmd/app.go", "web/index.js"}
+	assert.Equal(t, want, have)
+}
+
+func TestChangesetSpec_Revert(t *testing.T) {
+	spec := &ChangesetSpec{
+		Type:              ChangesetSpecTypeBranch,
+		BaseRepoID:        1,
+		BaseRev:           "d34db33f",
+		BaseRef:           "refs/heads/main",
+		HeadRef:           "refs/heads/my-batch-change",
+		Title:             "Update README",
+		CommitAuthorName:  "Mary McButtons",
+		CommitAuthorEmail: "mary@example.com",
+		// The diff of the changeset spec differs from the changes that were
+		// merged, which are the ones that are reverted.
+		Diff: []byte("diff --git a/README.md b/README.md\n"),
+	}
+	// The changes of the merge commit, relative to its first parent.
+	mergeDiff := []byte(`diff --git a/README.md b/README.md
+old mode 100644
+new mode 100755
+index 671e50a..851b23a
+--- a/README.md
++++ b/README.md
+@@ -1,2 +1,2 @@ Section
+ # README
+-Hello
++Hello world
+diff --git a/cmd/main.go b/cmd/app.go
+similarity index 90%
+rename from cmd/main.go
+rename to cmd/app.go
+index 671e50a..851b23a 100644
+--- a/cmd/main.go
++++ b/cmd/app.go
+@@ -1 +1 @@
+-package main
+\ No newline at end of file
++package app
+diff --git a/web/index.js b/web/index.js
+new file mode 100644
+index 0000000..851b23a
+--- /dev/null
++++ b/web/index.js
+@@ -0,0 +1 @@
++--- not a header
+`)
+
+	revert, err := spec.Revert("c0ff33", mergeDiff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, `diff --git a/README.md b/README.md
+new mode 100644
+old mode 100755
+index 851b23a..671e50a
+--- b/README.md
++++ a/README.md
+@@ -1,2 +1,2 @@ Section
+ # README
+-Hello world
++Hello
+diff --git a/cmd/app.go b/cmd/main.go
+similarity index 90%
+rename to cmd/main.go
+rename from cmd/app.go
+index 851b23a..671e50a 100644
+--- b/cmd/app.go
++++ a/cmd/main.go
+@@ -1 +1 @@
+-package app
++package main
+\ No newline at end of file
+diff --git a/web/index.js b/web/index.js
+deleted file mode 100644
+index 851b23a..0000000
+--- b/web/index.js
++++ /dev/null
+@@ -1 +0,0 @@
+---- not a header
+`, string(revert.Diff))
+	assert.Equal(t, "c0ff33", revert.BaseRev)
+	assert.Equal(t, "refs/heads/main", revert.BaseRef)
+	assert.Equal(t, "refs/heads/revert-my-batch-change", revert.HeadRef)
+	assert.Equal(t, `Revert "Update README"`, revert.Title)
+	assert.Equal(t, int32(2), revert.DiffStatAdded)
+	assert.Equal(t, int32(3), revert.DiffStatDeleted)
+
+	// Reverting the revert results in the original diff.
+	original, err := revert.Revert(spec.BaseRev, revert.Diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(mergeDiff), string(original.Diff))
+
+	t.Run("diff without prefixes", func(t *testing.T) {
+		spec := &ChangesetSpec{Type: ChangesetSpecTypeBranch}
+		mergeDiff := []byte(`diff --git cmd/main.go cmd/app.go
+similarity index 90%
+rename from cmd/main.go
+rename to cmd/app.go
+--- cmd/main.go
++++ cmd/app.go
+@@ -1 +1 @@
+-package main
++package app
+`)
+		revert, err := spec.Revert("c0ff33", mergeDiff)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, `diff --git cmd/app.go cmd/main.go
+similarity index 90%
+rename to cmd/main.go
+rename from cmd/app.go
+--- cmd/app.go
++++ cmd/main.go
+@@ -1 +1 @@
+-package app
++package main
+`, string(revert.Diff))
+	})
+
+	t.Run("binary files", func(t *testing.T) {
+		spec := &ChangesetSpec{Type: ChangesetSpecTypeBranch}
+		_, err := spec.Revert("c0ff33", []byte(`diff --git a/logo.png b/logo.png
+index 671e50a..851b23a 100644
+Binary files a/logo.png and b/logo.png differ
+`))
+		assert.Error(t, err)
+	})
+
+	t.Run("existing changesets", func(t *testing.T) {
+		spec := &ChangesetSpec{Type: ChangesetSpecTypeExisting, ExternalID: "123"}
+		_, err := spec.Revert("c0ff33", mergeDiff)
+		assert.Error(t, err)
+	})
+}
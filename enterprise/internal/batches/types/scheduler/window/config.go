@@ -82,6 +82,40 @@ func (cfg *Configuration) HasRolloutWindows() bool {
 	return len(cfg.windows) != 0
 }
 
+// IsOpen returns true if changesets may be processed at the given time: that
+// is, if there are no windows defined, or the window that is active at that
+// time doesn't have a zero rate.
+func (cfg *Configuration) IsOpen(at time.Time) bool {
+	if !cfg.HasRolloutWindows() {
+		return true
+	}
+
+	window, _ := cfg.windowFor(at)
+	return window != nil && window.rate.n != 0
+}
+
+// NextOpen returns the first time at or after the given time at which
+// changesets may be processed. nil indicates that no window opens within the
+// next week.
+func (cfg *Configuration) NextOpen(after time.Time) *time.Time {
+	at := after
+	until := at.Add(7 * 24 * time.Hour)
+	for !at.After(until) {
+		if cfg.IsOpen(at) {
+			return &at
+		}
+
+		// Skip to the end of the closed or zero rate window in effect.
+		_, validity := cfg.windowFor(at)
+		if validity == nil || *validity <= 0 {
+			return nil
+		}
+		at = at.Add(*validity)
+	}
+
+	return nil
+}
+
 // Schedule returns the currently active schedule.
 func (cfg *Configuration) Schedule() *Schedule {
 	// If there are no rollout windows, then we return an unlimited schedule and
@@ -197,6 +197,105 @@ func TestConfiguration_Schedule(t *testing.T) {
 	}
 }
 
+func TestConfiguration_IsOpen(t *testing.T) {
+	// Monday 2021-04-05 10:00 UTC.
+	monday := time.Date(2021, 4, 5, 10, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		cfg  *Configuration
+		at   time.Time
+		want bool
+	}{
+		"no windows": {
+			cfg:  &Configuration{},
+			at:   monday,
+			want: true,
+		},
+		"inside window": {
+			cfg: &Configuration{windows: []Window{
+				{days: newWeekdaySet(time.Monday), start: timeOfDayPtr(9, 0), end: timeOfDayPtr(16, 0), rate: rate{n: -1}},
+			}},
+			at:   monday,
+			want: true,
+		},
+		"outside window time": {
+			cfg: &Configuration{windows: []Window{
+				{days: newWeekdaySet(time.Monday), start: timeOfDayPtr(9, 0), end: timeOfDayPtr(16, 0), rate: rate{n: -1}},
+			}},
+			at:   monday.Add(8 * time.Hour),
+			want: false,
+		},
+		"outside window day": {
+			cfg: &Configuration{windows: []Window{
+				{days: newWeekdaySet(time.Monday), start: timeOfDayPtr(9, 0), end: timeOfDayPtr(16, 0), rate: rate{n: -1}},
+			}},
+			at:   monday.Add(24 * time.Hour),
+			want: false,
+		},
+		"zero rate": {
+			cfg: &Configuration{windows: []Window{
+				{days: newWeekdaySet(), rate: rate{n: 0}},
+			}},
+			at:   monday,
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := tc.cfg.IsOpen(tc.at); have != tc.want {
+				t.Errorf("unexpected result: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestConfiguration_NextOpen(t *testing.T) {
+	// Monday 2021-04-05 10:00 UTC.
+	monday := time.Date(2021, 4, 5, 10, 0, 0, 0, time.UTC)
+	businessHours := Window{days: newWeekdaySet(time.Monday, time.Tuesday), start: timeOfDayPtr(9, 0), end: timeOfDayPtr(16, 0), rate: rate{n: -1}}
+
+	timePtr := func(t time.Time) *time.Time { return &t }
+
+	for name, tc := range map[string]struct {
+		cfg  *Configuration
+		at   time.Time
+		want *time.Time
+	}{
+		"no windows": {
+			cfg:  &Configuration{},
+			at:   monday,
+			want: timePtr(monday),
+		},
+		"inside window": {
+			cfg:  &Configuration{windows: []Window{businessHours}},
+			at:   monday,
+			want: timePtr(monday),
+		},
+		"after window closes": {
+			cfg:  &Configuration{windows: []Window{businessHours}},
+			at:   monday.Add(8 * time.Hour),
+			want: timePtr(time.Date(2021, 4, 6, 9, 0, 0, 0, time.UTC)),
+		},
+		"on a day without window": {
+			cfg:  &Configuration{windows: []Window{businessHours}},
+			at:   monday.Add(2 * 24 * time.Hour),
+			want: timePtr(time.Date(2021, 4, 12, 9, 0, 0, 0, time.UTC)),
+		},
+		"zero rate": {
+			cfg: &Configuration{windows: []Window{
+				{days: newWeekdaySet(), rate: rate{n: 0}},
+			}},
+			at:   monday,
+			want: nil,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.cfg.NextOpen(tc.at)); diff != "" {
+				t.Errorf("unexpected result (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestConfiguration_currentFor(t *testing.T) {
 	// Let's set up some common windows to simplify defining the test cases.
 
@@ -0,0 +1,54 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/internal/inference/libs"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestDartGenerator(t *testing.T) {
+	expectedIndexerImage, _ := libs.DefaultIndexerForLang("dart")
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "scip-dart",
+			repositoryContents: map[string]string{
+				"pubspec.yaml":                    "name: server\ndependencies:\n  shelf: ^1.4.0\n",
+				"app/pubspec.yaml":                "name: app\ndependencies:\n  flutter:\n    sdk: flutter\n",
+				"app/.dart_tool/pub/pubspec.yaml": "",
+				"example/pubspec.yaml":            "name: example\n",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    expectedIndexerImage,
+							Commands: []string{"dart pub get"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-dart", "./"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "app",
+							Image:    expectedIndexerImage,
+							Commands: []string{"flutter pub get"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "app",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-dart", "./"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,83 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/internal/inference/libs"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestDotNetGenerator(t *testing.T) {
+	expectedIndexerImage, _ := libs.DefaultIndexerForLang("dotnet")
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "scip-dotnet solution",
+			repositoryContents: map[string]string{
+				"App.sln":                 "",
+				"src/App/App.csproj":      "",
+				"src/Lib/Lib.csproj":      "",
+				"src/Lib/bin/Gen.csproj":  "",
+				"test/App.Tests.csproj":   "",
+				"tools/Tool/Tool.csproj":  "",
+				"tools/Tool/Other.csproj": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    expectedIndexerImage,
+							Commands: []string{"dotnet restore App.sln"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-dotnet", "index", "App.sln"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "scip-dotnet projects",
+			repositoryContents: map[string]string{
+				"services/api/Api.sln":                "",
+				"services/api/Api/Api.csproj":         "",
+				"services/worker/Worker.csproj":       "",
+				"services/worker/Worker.Jobs.csproj":  "",
+				"services/worker/obj/Worker.g.csproj": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "services/api",
+							Image:    expectedIndexerImage,
+							Commands: []string{"dotnet restore Api.sln"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "services/api",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-dotnet", "index", "Api.sln"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "services/worker",
+							Image:    expectedIndexerImage,
+							Commands: []string{"dotnet restore Worker.Jobs.csproj", "dotnet restore Worker.csproj"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "services/worker",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-dotnet", "index", "Worker.Jobs.csproj", "Worker.csproj"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+	)
+}
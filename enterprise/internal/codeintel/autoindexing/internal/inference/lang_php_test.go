@@ -0,0 +1,57 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindexing/internal/inference/libs"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestPHPGenerator(t *testing.T) {
+	expectedIndexerImage, _ := libs.DefaultIndexerForLang("php")
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "scip-php",
+			repositoryContents: map[string]string{
+				"composer.json":                        "",
+				"composer.lock":                        "",
+				"packages/billing/composer.json":       "",
+				"vendor/monolog/monolog/composer.json": "",
+				"tests/fixtures/composer.json":         "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    expectedIndexerImage,
+							Commands: []string{"composer install --no-interaction --no-scripts --no-progress"},
+						},
+					},
+					LocalSteps:       nil,
+					Root:             "",
+					Indexer:          expectedIndexerImage,
+					IndexerArgs:      []string{"scip-php"},
+					Outfile:          "index.scip",
+					RequestedEnvVars: []string{"COMPOSER_AUTH"},
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "packages/billing",
+							Image:    expectedIndexerImage,
+							Commands: []string{"composer install --no-interaction --no-scripts --no-progress"},
+						},
+					},
+					LocalSteps:       nil,
+					Root:             "packages/billing",
+					Indexer:          expectedIndexerImage,
+					IndexerArgs:      []string{"scip-php"},
+					Outfile:          "index.scip",
+					RequestedEnvVars: []string{"COMPOSER_AUTH"},
+				},
+			},
+		},
+	)
+}
@@ -2,6 +2,7 @@ package libs
 
 import (
 	"fmt"
+	"strings"
 
 	lua "github.com/yuin/gopher-lua"
 	luar "layeh.com/gopher-luar"
@@ -17,8 +18,11 @@ type indexesAPI struct{}
 
 var defaultIndexers = map[string]string{
 	"clang":      "sourcegraph/lsif-clang",
+	"dart":       "workiva/scip-dart",
+	"dotnet":     "sourcegraph/scip-dotnet",
 	"go":         "sourcegraph/lsif-go",
 	"java":       "sourcegraph/scip-java",
+	"php":        "davidrjenni/scip-php",
 	"python":     "sourcegraph/scip-python",
 	"rust":       "sourcegraph/scip-rust",
 	"typescript": "sourcegraph/scip-typescript",
@@ -26,6 +30,8 @@ var defaultIndexers = map[string]string{
 }
 
 // To update, run `DOCKER_USER=... DOCKER_PASS=... ./update-shas.sh`
+//
+// Indexers that have not yet been pinned to a digest are referenced by tag.
 var defaultIndexerSHAs = map[string]string{
 	"sourcegraph/lsif-clang":      "sha256:ea814e5ab5c6e1e6ab4d001e4f4afddcc7b44128edbeeedf1d97da553813a4c8",
 	"sourcegraph/lsif-go":         "sha256:2194d2652862966f022b537ed81bccf5a9a535ab763534cb4e98a3083c8a1bc6",
@@ -35,6 +41,9 @@ var defaultIndexerSHAs = map[string]string{
 	"sourcegraph/scip-python":     "sha256:4cb64c4f62cfa611fcb217581073c2831fb9350bbb1c8e855f152cc4b3428a00",
 	"sourcegraph/scip-typescript": "sha256:1851ad42b3b47c8fb240c5060b5757cf51ebeece5e360013e41ab8a1dd05d52c",
 	"sourcegraph/scip-ruby":       "sha256:e553fee039973cda8726d4c8c13cdbb851f82a6fca5daa15798a595ee4042906",
+	"sourcegraph/scip-dotnet":     "latest",
+	"davidrjenni/scip-php":        "latest",
+	"workiva/scip-dart":           "latest",
 }
 
 func DefaultIndexerForLang(language string) (string, bool) {
@@ -48,6 +57,10 @@ func DefaultIndexerForLang(language string) (string, bool) {
 		panic(fmt.Sprintf("no SHA set for indexer %q", indexer))
 	}
 
+	if !strings.HasPrefix(sha, "sha256:") {
+		return fmt.Sprintf("%s:%s", indexer, sha), true
+	}
+
 	return fmt.Sprintf("%s@%s", indexer, sha), true
 }
 
@@ -0,0 +1,67 @@
+local path = require "path"
+local recognizer = require "sg.autoindex.recognizer"
+local pattern = require "sg.autoindex.patterns"
+
+local shared = require "sg.autoindex.shared"
+
+local indexer = require("sg.autoindex.indexes").get "dart"
+local outfile = "index.scip"
+
+local exclude_segments = { ".dart_tool", ".pub-cache" }
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment ".dart_tool",
+  pattern.new_path_segment ".pub-cache",
+})
+
+-- Flutter packages declare a dependency on the Flutter SDK:
+--
+-- dependencies:
+--   flutter:
+--     sdk: flutter
+local is_flutter_package = function(content)
+  return content ~= nil and content:find "sdk:%s*flutter" ~= nil
+end
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_basename "pubspec.yaml",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  patterns_for_content = {
+    pattern.new_path_basename "pubspec.yaml",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when pubspec.yaml files exist
+  generate = function(_, paths, contents_by_path)
+    paths = shared.filter_excluded_paths(paths, exclude_segments)
+
+    local jobs = {}
+    for i = 1, #paths do
+      local root = path.dirname(paths[i])
+
+      local install_command = "dart pub get"
+      if is_flutter_package(contents_by_path[paths[i]]) then
+        install_command = "flutter pub get"
+      end
+
+      table.insert(jobs, {
+        steps = {
+          {
+            root = root,
+            image = indexer,
+            commands = { install_command },
+          },
+        },
+        root = root,
+        indexer = indexer,
+        indexer_args = { "scip-dart", "./" },
+        outfile = outfile,
+      })
+    end
+
+    return jobs
+  end,
+}
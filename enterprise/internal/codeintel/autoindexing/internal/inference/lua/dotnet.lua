@@ -0,0 +1,122 @@
+local path = require "path"
+local recognizer = require "sg.autoindex.recognizer"
+local pattern = require "sg.autoindex.patterns"
+
+local shared = require "sg.autoindex.shared"
+
+local indexer = require("sg.autoindex.indexes").get "dotnet"
+local outfile = "index.scip"
+
+local exclude_segments = { "bin", "obj" }
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "bin",
+  pattern.new_path_segment "obj",
+})
+
+-- Groups the basenames of the given paths by their containing directory
+local group_by_dirname = function(paths)
+  local groups = {}
+  for i = 1, #paths do
+    local root = path.dirname(paths[i])
+    if groups[root] == nil then
+      groups[root] = {}
+    end
+
+    table.insert(groups[root], path.basename(paths[i]))
+  end
+
+  -- Sort basenames so the generated jobs are stable
+  for _, basenames in pairs(groups) do
+    table.sort(basenames)
+  end
+
+  return groups
+end
+
+-- Returns the keys of the given table in sorted order
+local sorted_keys = function(t)
+  local keys = {}
+  for key in pairs(t) do
+    table.insert(keys, key)
+  end
+  table.sort(keys)
+
+  return keys
+end
+
+local make_job = function(root, project_files)
+  local commands = {}
+  for _, project_file in ipairs(project_files) do
+    table.insert(commands, "dotnet restore " .. project_file)
+  end
+
+  return {
+    steps = {
+      {
+        root = root,
+        image = indexer,
+        commands = commands,
+      },
+    },
+    root = root,
+    indexer = indexer,
+    indexer_args = { "scip-dotnet", "index", unpack(project_files) },
+    outfile = outfile,
+  }
+end
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_extension "sln",
+    pattern.new_path_extension "csproj",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when .sln or .csproj files exist
+  generate = function(_, paths)
+    paths = shared.filter_excluded_paths(paths, exclude_segments)
+
+    local solutions, projects = {}, {}
+    for i = 1, #paths do
+      if path.basename(paths[i]):match "%.sln$" then
+        table.insert(solutions, paths[i])
+      else
+        table.insert(projects, paths[i])
+      end
+    end
+
+    local solutions_by_root = group_by_dirname(solutions)
+
+    -- Projects nested under a directory containing a solution file are
+    -- assumed to be part of that solution and are indexed along with it.
+    local standalone_projects = {}
+    for _, project in ipairs(projects) do
+      local ancestors = path.ancestors(project)
+
+      local in_solution = false
+      for i = 1, #ancestors do
+        if solutions_by_root[ancestors[i]] ~= nil then
+          in_solution = true
+          break
+        end
+      end
+
+      if not in_solution then
+        table.insert(standalone_projects, project)
+      end
+    end
+
+    local jobs = {}
+    for _, root in ipairs(sorted_keys(solutions_by_root)) do
+      table.insert(jobs, make_job(root, solutions_by_root[root]))
+    end
+
+    local projects_by_root = group_by_dirname(standalone_projects)
+    for _, root in ipairs(sorted_keys(projects_by_root)) do
+      table.insert(jobs, make_job(root, projects_by_root[root]))
+    end
+
+    return jobs
+  end,
+}
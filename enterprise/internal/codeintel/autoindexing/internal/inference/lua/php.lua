@@ -0,0 +1,49 @@
+local path = require "path"
+local recognizer = require "sg.autoindex.recognizer"
+local pattern = require "sg.autoindex.patterns"
+
+local shared = require "sg.autoindex.shared"
+
+local indexer = require("sg.autoindex.indexes").get "php"
+local outfile = "index.scip"
+
+local exclude_segments = { "vendor" }
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "vendor",
+})
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_basename "composer.json",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when composer.json files exist
+  generate = function(_, paths)
+    paths = shared.filter_excluded_paths(paths, exclude_segments)
+
+    local jobs = {}
+    for i = 1, #paths do
+      local root = path.dirname(paths[i])
+
+      table.insert(jobs, {
+        steps = {
+          {
+            root = root,
+            image = indexer,
+            -- scip-php reads the installed dependencies from the vendor directory
+            commands = { "composer install --no-interaction --no-scripts --no-progress" },
+          },
+        },
+        root = root,
+        indexer = indexer,
+        indexer_args = { "scip-php" },
+        outfile = outfile,
+        requested_envvars = { "COMPOSER_AUTH" },
+      })
+    end
+
+    return jobs
+  end,
+}
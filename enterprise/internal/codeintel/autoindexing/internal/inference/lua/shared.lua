@@ -1,14 +1,49 @@
 local pattern = require "sg.autoindex.patterns"
 
-local exclude_paths = pattern.new_path_combine {
-  pattern.new_path_segment "example",
-  pattern.new_path_segment "examples",
-  pattern.new_path_segment "integration",
-  pattern.new_path_segment "test",
-  pattern.new_path_segment "testdata",
-  pattern.new_path_segment "tests",
+local exclude_segments = {
+  "example",
+  "examples",
+  "integration",
+  "test",
+  "testdata",
+  "tests",
 }
 
+local exclude_path_segments = {}
+for _, segment in ipairs(exclude_segments) do
+  table.insert(exclude_path_segments, pattern.new_path_segment(segment))
+end
+
+local exclude_paths = pattern.new_path_combine(exclude_path_segments)
+
+-- Returns true if any directory of the given path is named by one of the
+-- given segments.
+local has_segment = function(path, segments)
+  for _, segment in ipairs(segments) do
+    if ("/" .. path):find("/" .. segment .. "/", 1, true) then
+      return true
+    end
+  end
+
+  return false
+end
+
+-- Returns the given paths that are not within a shared excluded directory or
+-- a directory named by one of the given extra segments. Recognizers call this
+-- from generate as path_exclude patterns only narrow the set of requested
+-- paths when they are not nested within a combined pattern.
+local filter_excluded_paths = function(paths, extra_segments)
+  local filtered = {}
+  for i = 1, #paths do
+    if not has_segment(paths[i], exclude_segments) and not has_segment(paths[i], extra_segments or {}) then
+      table.insert(filtered, paths[i])
+    end
+  end
+
+  return filtered
+end
+
 return {
   exclude_paths = exclude_paths,
+  filter_excluded_paths = filter_excluded_paths,
 }
@@ -97,13 +97,21 @@ func NewMapper(observationCtx *observation.Context, rankingService *Service) []g
 	}
 }
 
-func NewReducer(observationCtx *observation.Context, rankingService *Service) goroutine.BackgroundRoutine {
-	return background.NewReducer(
-		observationCtx,
-		rankingService.store,
-		ConfigInst.SymbolExporterInterval,
-		ConfigInst.ReducerBatchSize,
-	)
+func NewReducer(observationCtx *observation.Context, rankingService *Service) []goroutine.BackgroundRoutine {
+	return []goroutine.BackgroundRoutine{
+		background.NewReducer(
+			observationCtx,
+			rankingService.store,
+			ConfigInst.SymbolExporterInterval,
+			ConfigInst.ReducerBatchSize,
+		),
+		background.NewSymbolReducer(
+			observationCtx,
+			rankingService.store,
+			ConfigInst.SymbolExporterInterval,
+			ConfigInst.ReducerBatchSize,
+		),
+	}
 }
 
 func scopedContext(component string, observationCtx *observation.Context) *observation.Context {
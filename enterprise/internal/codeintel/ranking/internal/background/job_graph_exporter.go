@@ -264,3 +264,23 @@ func NewReducer(
 		},
 	})
 }
+
+func NewSymbolReducer(
+	observationCtx *observation.Context,
+	store store.Store,
+	interval time.Duration,
+	batchSize int,
+) goroutine.BackgroundRoutine {
+	name := "codeintel.ranking.symbol-reference-count-reducer"
+
+	return background.NewPipelineJob(context.Background(), background.PipelineOptions{
+		Name:        name,
+		Description: "Aggregates records from `codeintel_ranking_symbol_counts_inputs` into `codeintel_symbol_ranks`.",
+		Interval:    interval,
+		Metrics:     background.NewPipelineMetrics(observationCtx, name, recordTypeName),
+		ProcessFunc: func(ctx context.Context) (numRecordsProcessed int, numRecordsAltered background.TaggedCounts, err error) {
+			numSymbolRanksInserted, numSymbolCountInputsProcessed, err := reduceSymbolRankingGraph(ctx, store, batchSize)
+			return numSymbolCountInputsProcessed, background.NewSingleCount(numSymbolRanksInserted), err
+		},
+	})
+}
@@ -298,3 +298,19 @@ func reduceRankingGraph(
 
 	return numPathRanksInserted, numPathCountInputsProcessed, nil
 }
+
+func reduceSymbolRankingGraph(
+	ctx context.Context,
+	store store.Store,
+	batchSize int,
+) (numSymbolRanksInserted int, numSymbolCountInputsProcessed int, err error) {
+	if enabled := conf.CodeIntelRankingDocumentReferenceCountsEnabled(); !enabled {
+		return 0, 0, nil
+	}
+
+	return store.InsertSymbolRanks(
+		ctx,
+		rankingshared.DerivativeGraphKeyFromTime(time.Now()),
+		batchSize,
+	)
+}
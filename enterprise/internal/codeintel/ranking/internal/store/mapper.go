@@ -38,6 +38,7 @@ func (s *store) InsertPathCountInputs(
 		derivativeGraphKey,
 		graphKey,
 		derivativeGraphKey,
+		derivativeGraphKey,
 	))
 	if err != nil {
 		return 0, 0, err
@@ -83,7 +84,7 @@ locked_refs AS (
 	RETURNING codeintel_ranking_reference_id
 ),
 processable_symbols AS (
-	SELECT r.symbol_names
+	SELECT r.id, r.symbol_names
 	FROM locked_refs lr
 	JOIN refs r ON r.id = lr.codeintel_ranking_reference_id
 	JOIN lsif_uploads u ON u.id = r.upload_id
@@ -119,20 +120,27 @@ processable_symbols AS (
 		)
 ),
 referenced_symbols AS (
-	SELECT unnest(r.symbol_names) AS symbol_name
+	SELECT
+		r.id AS reference_id,
+		unnest(r.symbol_names) AS symbol_name
 	FROM processable_symbols r
 ),
 referenced_definitions AS (
 	SELECT
 		u.repository_id,
 		rd.document_path,
+		rd.symbol_name,
 		rd.graph_key,
-		COUNT(*) AS count
+		COUNT(*) AS count,
+		-- Each reference record holds a batch of the symbols referenced from a single document. A
+		-- document with more references than fit into one batch can reference the same symbol
+		-- from several records, so this over-approximates the number of referencing documents.
+		COUNT(DISTINCT rs.reference_id) AS num_referencing_records
 	FROM codeintel_ranking_definitions rd
 	JOIN referenced_symbols rs ON rs.symbol_name = rd.symbol_name
 	JOIN lsif_uploads u ON u.id = rd.upload_id
 	WHERE rd.graph_key = %s
-	GROUP BY u.repository_id, rd.document_path, rd.graph_key
+	GROUP BY u.repository_id, rd.document_path, rd.symbol_name, rd.graph_key
 ),
 ins AS (
 	INSERT INTO codeintel_ranking_path_counts_inputs (repository_id, document_path, count, graph_key)
@@ -144,6 +152,17 @@ ins AS (
 	FROM referenced_definitions rx
 	GROUP BY rx.repository_id, rx.document_path
 	RETURNING 1
+),
+ins_symbols AS (
+	INSERT INTO codeintel_ranking_symbol_counts_inputs (repository_id, document_path, symbol_name, count, graph_key)
+	SELECT
+		rx.repository_id,
+		rx.document_path,
+		rx.symbol_name,
+		rx.num_referencing_records,
+		%s
+	FROM referenced_definitions rx
+	RETURNING 1
 )
 SELECT
 	(SELECT COUNT(*) FROM locked_refs),
@@ -248,7 +267,11 @@ func (s *store) VacuumStaleGraphs(ctx context.Context, derivativeGraphKey string
 	ctx, _, endObservation := s.operations.vacuumStaleGraphs.With(ctx, &err, observation.Args{LogFields: []otlog.Field{}})
 	defer endObservation(1, observation.Args{})
 
-	count, _, err := basestore.ScanFirstInt(s.db.Query(ctx, sqlf.Sprintf(vacuumStaleGraphsQuery, derivativeGraphKey, derivativeGraphKey, batchSize)))
+	count, _, err := basestore.ScanFirstInt(s.db.Query(ctx, sqlf.Sprintf(
+		vacuumStaleGraphsQuery,
+		derivativeGraphKey, derivativeGraphKey, batchSize,
+		derivativeGraphKey, derivativeGraphKey, batchSize,
+	)))
 	return count, err
 }
 
@@ -266,6 +289,21 @@ deleted_path_counts_inputs AS (
 	DELETE FROM codeintel_ranking_path_counts_inputs
 	WHERE id IN (SELECT id FROM locked_path_counts_inputs)
 	RETURNING 1
+),
+locked_symbol_counts_inputs AS (
+	SELECT id
+	FROM codeintel_ranking_symbol_counts_inputs
+	WHERE (graph_key < %s OR graph_key > %s)
+	ORDER BY graph_key, id
+	FOR UPDATE SKIP LOCKED
+	LIMIT %s
+),
+deleted_symbol_counts_inputs AS (
+	DELETE FROM codeintel_ranking_symbol_counts_inputs
+	WHERE id IN (SELECT id FROM locked_symbol_counts_inputs)
+	RETURNING 1
 )
-SELECT COUNT(*) FROM deleted_path_counts_inputs
+SELECT
+	(SELECT COUNT(*) FROM deleted_path_counts_inputs) +
+	(SELECT COUNT(*) FROM deleted_symbol_counts_inputs)
 `
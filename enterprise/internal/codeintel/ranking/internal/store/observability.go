@@ -10,6 +10,7 @@ import (
 type operations struct {
 	getStarRank                      *observation.Operation
 	getDocumentRanks                 *observation.Operation
+	getSymbolRanks                   *observation.Operation
 	getReferenceCountStatistics      *observation.Operation
 	lastUpdatedAt                    *observation.Operation
 	getUploadsForRanking             *observation.Operation
@@ -27,6 +28,7 @@ type operations struct {
 	insertInitialPathCounts          *observation.Operation
 	vacuumStaleGraphs                *observation.Operation
 	insertPathRanks                  *observation.Operation
+	insertSymbolRanks                *observation.Operation
 	vacuumStaleRanks                 *observation.Operation
 }
 
@@ -53,6 +55,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 	return &operations{
 		getStarRank:                      op("GetStarRank"),
 		getDocumentRanks:                 op("GetDocumentRanks"),
+		getSymbolRanks:                   op("GetSymbolRanks"),
 		getReferenceCountStatistics:      op("GetReferenceCountStatistics"),
 		lastUpdatedAt:                    op("LastUpdatedAt"),
 		getUploadsForRanking:             op("GetUploadsForRanking"),
@@ -70,6 +73,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		insertInitialPathCounts:          op("InsertInitialPathCounts"),
 		vacuumStaleGraphs:                op("VacuumStaleGraphs"),
 		insertPathRanks:                  op("InsertPathRanks"),
+		insertSymbolRanks:                op("InsertSymbolRanks"),
 		vacuumStaleRanks:                 op("VacuumStaleRanks"),
 	}
 }
@@ -119,6 +119,98 @@ SELECT
 	(SELECT COUNT(*) FROM inserted) AS num_inserted
 `
 
+func (s *store) InsertSymbolRanks(
+	ctx context.Context,
+	derivativeGraphKey string,
+	batchSize int,
+) (numSymbolRanksInserted int, numInputsProcessed int, err error) {
+	ctx, _, endObservation := s.operations.insertSymbolRanks.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.String("derivativeGraphKey", derivativeGraphKey),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	_, ok := rankingshared.GraphKeyFromDerivativeGraphKey(derivativeGraphKey)
+	if !ok {
+		return 0, 0, errors.Newf("unexpected derivative graph key %q", derivativeGraphKey)
+	}
+
+	rows, err := s.db.Query(ctx, sqlf.Sprintf(
+		insertSymbolRanksQuery,
+		derivativeGraphKey,
+		batchSize,
+		derivativeGraphKey,
+	))
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	if !rows.Next() {
+		return 0, 0, errors.New("no rows from count")
+	}
+
+	if err = rows.Scan(&numSymbolRanksInserted, &numInputsProcessed); err != nil {
+		return 0, 0, err
+	}
+
+	return numSymbolRanksInserted, numInputsProcessed, nil
+}
+
+const insertSymbolRanksQuery = `
+WITH
+input_ranks AS (
+	SELECT
+		sci.id,
+		sci.repository_id,
+		sci.document_path,
+		sci.symbol_name,
+		sci.count
+	FROM codeintel_ranking_symbol_counts_inputs sci
+	WHERE
+		sci.graph_key = %s AND
+		NOT sci.processed AND
+		EXISTS (
+			SELECT 1 FROM repo r
+			WHERE
+				r.id = sci.repository_id AND
+				r.deleted_at IS NULL AND
+				r.blocked IS NULL
+		)
+	ORDER BY sci.graph_key, sci.repository_id, sci.id
+	LIMIT %s
+	FOR UPDATE SKIP LOCKED
+),
+processed AS (
+	UPDATE codeintel_ranking_symbol_counts_inputs
+	SET processed = true
+	WHERE id IN (SELECT ir.id FROM input_ranks ir)
+	RETURNING 1
+),
+inserted AS (
+	INSERT INTO codeintel_symbol_ranks AS sr (repository_id, document_path, symbol_name, count, graph_key)
+	SELECT
+		ir.repository_id,
+		ir.document_path,
+		ir.symbol_name,
+		SUM(ir.count),
+		%s
+	FROM input_ranks ir
+	GROUP BY ir.repository_id, ir.document_path, ir.symbol_name
+	ON CONFLICT (repository_id, document_path, symbol_name) DO UPDATE SET
+		graph_key = EXCLUDED.graph_key,
+		updated_at = NOW(),
+		count = CASE
+			WHEN sr.graph_key != EXCLUDED.graph_key
+				THEN EXCLUDED.count
+				ELSE sr.count + EXCLUDED.count
+			END
+	RETURNING 1
+)
+SELECT
+	(SELECT COUNT(*) FROM processed) AS num_processed,
+	(SELECT COUNT(*) FROM inserted) AS num_inserted
+`
+
 func (s *store) VacuumStaleRanks(ctx context.Context, derivativeGraphKey string) (rankRecordsDeleted, rankRecordsScanned int, err error) {
 	ctx, _, endObservation := s.operations.vacuumStaleRanks.With(ctx, &err, observation.Args{LogFields: []otlog.Field{}})
 	defer endObservation(1, observation.Args{})
@@ -183,8 +275,23 @@ del AS (
 	DELETE FROM codeintel_path_ranks
 	WHERE repository_id IN (SELECT repository_id FROM locked_records)
 	RETURNING 1
+),
+locked_symbol_records AS (
+	-- Lock all symbol rank records that don't have a recent graph key. Symbol ranks
+	-- are reduced from the same derivative graph as path ranks, so they share the
+	-- same set of valid graph keys.
+	SELECT id
+	FROM codeintel_symbol_ranks
+	WHERE graph_key NOT IN (SELECT graph_key FROM valid_graph_keys)
+	ORDER BY id
+	FOR UPDATE
+),
+del_symbols AS (
+	DELETE FROM codeintel_symbol_ranks
+	WHERE id IN (SELECT id FROM locked_symbol_records)
+	RETURNING 1
 )
 SELECT
-	(SELECT COUNT(*) FROM locked_records),
-	(SELECT COUNT(*) FROM del)
+	(SELECT COUNT(*) FROM locked_records) + (SELECT COUNT(*) FROM locked_symbol_records),
+	(SELECT COUNT(*) FROM del) + (SELECT COUNT(*) FROM del_symbols)
 `
@@ -89,6 +89,86 @@ func TestInsertPathRanks(t *testing.T) {
 	}
 }
 
+func TestInsertSymbolRanks(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	insertUploads(t, db, uploadsshared.Upload{ID: 1})
+
+	// Insert definitions
+	mockDefinitions := make(chan shared.RankingDefinitions, 2)
+	mockDefinitions <- shared.RankingDefinitions{
+		UploadID:     1,
+		SymbolName:   "foo",
+		DocumentPath: "foo.go",
+	}
+	mockDefinitions <- shared.RankingDefinitions{
+		UploadID:     1,
+		SymbolName:   "bar",
+		DocumentPath: "bar.go",
+	}
+	close(mockDefinitions)
+	if err := store.InsertDefinitionsForRanking(ctx, mockRankingGraphKey, mockDefinitions); err != nil {
+		t.Fatalf("unexpected error inserting definitions: %s", err)
+	}
+
+	// Insert references from two documents
+	for _, symbolNames := range [][]string{{"foo", "bar", "foo"}, {"foo"}} {
+		mockReferences := make(chan string, len(symbolNames))
+		for _, symbolName := range symbolNames {
+			mockReferences <- symbolName
+		}
+		close(mockReferences)
+		if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey, mockRankingBatchSize, 1, mockReferences); err != nil {
+			t.Fatalf("unexpected error inserting references: %s", err)
+		}
+	}
+
+	// Test InsertPathCountInputs
+	if _, _, err := store.InsertPathCountInputs(ctx, rankingshared.NewDerivativeGraphKeyKey(mockRankingGraphKey, "", 123), 1000); err != nil {
+		t.Fatalf("unexpected error inserting path count inputs: %s", err)
+	}
+
+	// Insert repos
+	if _, err := db.ExecContext(ctx, `INSERT INTO repo (id, name) VALUES (1, 'deadbeef')`); err != nil {
+		t.Fatalf("failed to insert repos: %s", err)
+	}
+
+	// Test InsertSymbolRanks
+	numSymbolRanksInserted, numInputsProcessed, err := store.InsertSymbolRanks(ctx, rankingshared.NewDerivativeGraphKeyKey(mockRankingGraphKey, "", 123), 10)
+	if err != nil {
+		t.Fatalf("unexpected error inserting symbol ranks: %s", err)
+	}
+
+	if numSymbolRanksInserted != 2 {
+		t.Errorf("unexpected number of symbol ranks inserted. want=%d have=%d", 2, numSymbolRanksInserted)
+	}
+
+	if numInputsProcessed != 2 {
+		t.Errorf("unexpected number of inputs processed. want=%d have=%d", 2, numInputsProcessed)
+	}
+
+	// Test GetSymbolRanks
+	symbolRanks, err := store.GetSymbolRanks(ctx, api.RepoName("deadbeef"))
+	if err != nil {
+		t.Fatalf("unexpected error getting symbol ranks: %s", err)
+	}
+
+	expected := map[string]map[string]float64{
+		"foo.go": {"foo": 2},
+		"bar.go": {"bar": 1},
+	}
+	if diff := cmp.Diff(expected, symbolRanks); diff != "" {
+		t.Errorf("unexpected symbol ranks (-want +got):\n%s", diff)
+	}
+}
+
 func TestVacuumStaleRanks(t *testing.T) {
 	logger := logtest.Scoped(t)
 	ctx := context.Background()
@@ -73,6 +73,48 @@ WHERE
 	r.blocked IS NULL
 `
 
+func (s *store) GetSymbolRanks(ctx context.Context, repoName api.RepoName) (_ map[string]map[string]float64, err error) {
+	ctx, _, endObservation := s.operations.getSymbolRanks.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	symbolRanks := map[string]map[string]float64{}
+	scanner := func(s dbutil.Scanner) (bool, error) {
+		var (
+			path       string
+			symbolName string
+			count      float64
+		)
+		if err := s.Scan(&path, &symbolName, &count); err != nil {
+			return false, err
+		}
+
+		if _, ok := symbolRanks[path]; !ok {
+			symbolRanks[path] = map[string]float64{}
+		}
+		symbolRanks[path][symbolName] = count
+
+		return true, nil
+	}
+
+	if err := basestore.NewCallbackScanner(scanner)(s.db.Query(ctx, sqlf.Sprintf(getSymbolRanksQuery, repoName))); err != nil {
+		return nil, err
+	}
+	return symbolRanks, nil
+}
+
+const getSymbolRanksQuery = `
+SELECT
+	sr.document_path,
+	sr.symbol_name,
+	sr.count
+FROM codeintel_symbol_ranks sr
+JOIN repo r ON r.id = sr.repository_id
+WHERE
+	r.name = %s AND
+	r.deleted_at IS NULL AND
+	r.blocked IS NULL
+`
+
 func (s *store) GetReferenceCountStatistics(ctx context.Context) (logmean float64, err error) {
 	ctx, _, endObservation := s.operations.getReferenceCountStatistics.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
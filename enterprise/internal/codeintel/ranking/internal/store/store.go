@@ -18,6 +18,7 @@ type Store interface {
 	// Retrieval
 	GetStarRank(ctx context.Context, repoName api.RepoName) (float64, error)
 	GetDocumentRanks(ctx context.Context, repoName api.RepoName) (map[string]float64, bool, error)
+	GetSymbolRanks(ctx context.Context, repoName api.RepoName) (map[string]map[string]float64, error)
 	GetReferenceCountStatistics(ctx context.Context) (logmean float64, _ error)
 	LastUpdatedAt(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]time.Time, error)
 
@@ -47,6 +48,7 @@ type Store interface {
 
 	// Reducer behavior + cleanup
 	InsertPathRanks(ctx context.Context, graphKey string, batchSize int) (numPathRanksInserted int, numInputsProcessed int, _ error)
+	InsertSymbolRanks(ctx context.Context, graphKey string, batchSize int) (numSymbolRanksInserted int, numInputsProcessed int, _ error)
 	VacuumStaleRanks(ctx context.Context, derivativeGraphKey string) (rankRecordsScanned int, rankRecordsSDeleted int, _ error)
 }
 
@@ -34,6 +34,9 @@ type MockStore struct {
 	// GetStarRankFunc is an instance of a mock function object controlling
 	// the behavior of the method GetStarRank.
 	GetStarRankFunc *StoreGetStarRankFunc
+	// GetSymbolRanksFunc is an instance of a mock function object
+	// controlling the behavior of the method GetSymbolRanks.
+	GetSymbolRanksFunc *StoreGetSymbolRanksFunc
 	// GetUploadsForRankingFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadsForRanking.
 	GetUploadsForRankingFunc *StoreGetUploadsForRankingFunc
@@ -57,6 +60,9 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// InsertReferencesForRanking.
 	InsertReferencesForRankingFunc *StoreInsertReferencesForRankingFunc
+	// InsertSymbolRanksFunc is an instance of a mock function object
+	// controlling the behavior of the method InsertSymbolRanks.
+	InsertSymbolRanksFunc *StoreInsertSymbolRanksFunc
 	// LastUpdatedAtFunc is an instance of a mock function object
 	// controlling the behavior of the method LastUpdatedAt.
 	LastUpdatedAtFunc *StoreLastUpdatedAtFunc
@@ -112,6 +118,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetSymbolRanksFunc: &StoreGetSymbolRanksFunc{
+			defaultHook: func(context.Context, api.RepoName) (r0 map[string]map[string]float64, r1 error) {
+				return
+			},
+		},
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
 			defaultHook: func(context.Context, string, string, int) (r0 []shared.ExportedUpload, r1 error) {
 				return
@@ -147,6 +158,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		InsertSymbolRanksFunc: &StoreInsertSymbolRanksFunc{
+			defaultHook: func(context.Context, string, int) (r0 int, r1 int, r2 error) {
+				return
+			},
+		},
 		LastUpdatedAtFunc: &StoreLastUpdatedAtFunc{
 			defaultHook: func(context.Context, []api.RepoID) (r0 map[api.RepoID]time.Time, r1 error) {
 				return
@@ -219,6 +235,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetStarRank")
 			},
 		},
+		GetSymbolRanksFunc: &StoreGetSymbolRanksFunc{
+			defaultHook: func(context.Context, api.RepoName) (map[string]map[string]float64, error) {
+				panic("unexpected invocation of MockStore.GetSymbolRanks")
+			},
+		},
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
 			defaultHook: func(context.Context, string, string, int) ([]shared.ExportedUpload, error) {
 				panic("unexpected invocation of MockStore.GetUploadsForRanking")
@@ -254,6 +275,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.InsertReferencesForRanking")
 			},
 		},
+		InsertSymbolRanksFunc: &StoreInsertSymbolRanksFunc{
+			defaultHook: func(context.Context, string, int) (int, int, error) {
+				panic("unexpected invocation of MockStore.InsertSymbolRanks")
+			},
+		},
 		LastUpdatedAtFunc: &StoreLastUpdatedAtFunc{
 			defaultHook: func(context.Context, []api.RepoID) (map[api.RepoID]time.Time, error) {
 				panic("unexpected invocation of MockStore.LastUpdatedAt")
@@ -320,6 +346,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetStarRankFunc: &StoreGetStarRankFunc{
 			defaultHook: i.GetStarRank,
 		},
+		GetSymbolRanksFunc: &StoreGetSymbolRanksFunc{
+			defaultHook: i.GetSymbolRanks,
+		},
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
 			defaultHook: i.GetUploadsForRanking,
 		},
@@ -341,6 +370,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		InsertReferencesForRankingFunc: &StoreInsertReferencesForRankingFunc{
 			defaultHook: i.InsertReferencesForRanking,
 		},
+		InsertSymbolRanksFunc: &StoreInsertSymbolRanksFunc{
+			defaultHook: i.InsertSymbolRanks,
+		},
 		LastUpdatedAtFunc: &StoreLastUpdatedAtFunc{
 			defaultHook: i.LastUpdatedAt,
 		},
@@ -700,6 +732,114 @@ func (c StoreGetStarRankFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreGetSymbolRanksFunc describes the behavior when the GetSymbolRanks
+// method of the parent MockStore instance is invoked.
+type StoreGetSymbolRanksFunc struct {
+	defaultHook func(context.Context, api.RepoName) (map[string]map[string]float64, error)
+	hooks       []func(context.Context, api.RepoName) (map[string]map[string]float64, error)
+	history     []StoreGetSymbolRanksFuncCall
+	mutex       sync.Mutex
+}
+
+// GetSymbolRanks delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetSymbolRanks(v0 context.Context, v1 api.RepoName) (map[string]map[string]float64, error) {
+	r0, r1 := m.GetSymbolRanksFunc.nextHook()(v0, v1)
+	m.GetSymbolRanksFunc.appendCall(StoreGetSymbolRanksFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetSymbolRanks
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetSymbolRanksFunc) SetDefaultHook(hook func(context.Context, api.RepoName) (map[string]map[string]float64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetSymbolRanks method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreGetSymbolRanksFunc) PushHook(hook func(context.Context, api.RepoName) (map[string]map[string]float64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetSymbolRanksFunc) SetDefaultReturn(r0 map[string]map[string]float64, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName) (map[string]map[string]float64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetSymbolRanksFunc) PushReturn(r0 map[string]map[string]float64, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName) (map[string]map[string]float64, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetSymbolRanksFunc) nextHook() func(context.Context, api.RepoName) (map[string]map[string]float64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetSymbolRanksFunc) appendCall(r0 StoreGetSymbolRanksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetSymbolRanksFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetSymbolRanksFunc) History() []StoreGetSymbolRanksFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetSymbolRanksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetSymbolRanksFuncCall is an object that describes an invocation of
+// method GetSymbolRanks on an instance of MockStore.
+type StoreGetSymbolRanksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]map[string]float64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetSymbolRanksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetSymbolRanksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreGetUploadsForRankingFunc describes the behavior when the
 // GetUploadsForRanking method of the parent MockStore instance is invoked.
 type StoreGetUploadsForRankingFunc struct {
@@ -1500,6 +1640,120 @@ func (c StoreInsertReferencesForRankingFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// StoreInsertSymbolRanksFunc describes the behavior when the
+// InsertSymbolRanks method of the parent MockStore instance is invoked.
+type StoreInsertSymbolRanksFunc struct {
+	defaultHook func(context.Context, string, int) (int, int, error)
+	hooks       []func(context.Context, string, int) (int, int, error)
+	history     []StoreInsertSymbolRanksFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertSymbolRanks delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) InsertSymbolRanks(v0 context.Context, v1 string, v2 int) (int, int, error) {
+	r0, r1, r2 := m.InsertSymbolRanksFunc.nextHook()(v0, v1, v2)
+	m.InsertSymbolRanksFunc.appendCall(StoreInsertSymbolRanksFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the InsertSymbolRanks
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreInsertSymbolRanksFunc) SetDefaultHook(hook func(context.Context, string, int) (int, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertSymbolRanks method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreInsertSymbolRanksFunc) PushHook(hook func(context.Context, string, int) (int, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertSymbolRanksFunc) SetDefaultReturn(r0 int, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, int) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertSymbolRanksFunc) PushReturn(r0 int, r1 int, r2 error) {
+	f.PushHook(func(context.Context, string, int) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreInsertSymbolRanksFunc) nextHook() func(context.Context, string, int) (int, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertSymbolRanksFunc) appendCall(r0 StoreInsertSymbolRanksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertSymbolRanksFuncCall objects
+// describing the invocations of this function.
+func (f *StoreInsertSymbolRanksFunc) History() []StoreInsertSymbolRanksFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertSymbolRanksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertSymbolRanksFuncCall is an object that describes an invocation
+// of method InsertSymbolRanks on an instance of MockStore.
+type StoreInsertSymbolRanksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertSymbolRanksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertSymbolRanksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreLastUpdatedAtFunc describes the behavior when the LastUpdatedAt
 // method of the parent MockStore instance is invoked.
 type StoreLastUpdatedAtFunc struct {
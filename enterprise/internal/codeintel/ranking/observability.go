@@ -10,6 +10,7 @@ import (
 type operations struct {
 	getRepoRank      *observation.Operation
 	getDocumentRanks *observation.Operation
+	getSymbolRanks   *observation.Operation
 }
 
 var (
@@ -37,5 +38,6 @@ func newOperations(observationCtx *observation.Context) *operations {
 	return &operations{
 		getRepoRank:      op("GetRepoRank"),
 		getDocumentRanks: op("GetDocumentRanks"),
+		getSymbolRanks:   op("GetSymbolRanks"),
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/scip/bindings/go/scip"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/internal/lsifstore"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/ranking/internal/store"
@@ -118,6 +119,51 @@ func (s *Service) GetDocumentRanks(ctx context.Context, repoName api.RepoName) (
 	}, nil
 }
 
+// GetSymbolRanks returns a map from paths within the given repo to the normalized number of
+// reference records referencing each symbol defined in that path. Symbols are keyed by their
+// unqualified name so that they can be matched against the results of symbol search.
+func (s *Service) GetSymbolRanks(ctx context.Context, repoName api.RepoName) (_ types.RepoSymbolRanks, err error) {
+	_, _, endObservation := s.operations.getSymbolRanks.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	symbolRanks, err := s.store.GetSymbolRanks(ctx, repoName)
+	if err != nil {
+		return types.RepoSymbolRanks{}, err
+	}
+
+	paths := make(map[string]map[string]float64, len(symbolRanks))
+	for path, symbols := range symbolRanks {
+		names := make(map[string]float64, len(symbols))
+		for symbol, count := range symbols {
+			name := symbolDisplayName(symbol)
+			if name == "" {
+				continue
+			}
+
+			// Multiple definitions in the same file may share an unqualified name
+			// (e.g. overloads); keep the most referenced one.
+			if rank := math.Log2(count + 1); rank > names[name] {
+				names[name] = rank
+			}
+		}
+
+		paths[path] = names
+	}
+
+	return types.RepoSymbolRanks{Paths: paths}, nil
+}
+
+// symbolDisplayName returns the name of the last descriptor of the given SCIP
+// symbol, or an empty string if the symbol cannot be parsed.
+func symbolDisplayName(symbol string) string {
+	parsed, err := scip.ParseSymbol(symbol)
+	if err != nil || len(parsed.Descriptors) == 0 {
+		return ""
+	}
+
+	return parsed.Descriptors[len(parsed.Descriptors)-1].Name
+}
+
 func (s *Service) LastUpdatedAt(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]time.Time, error) {
 	return s.store.LastUpdatedAt(ctx, repoIDs)
 }
@@ -62,6 +62,47 @@ func TestGetRepoRankWithUserBoostedScores(t *testing.T) {
 	}
 }
 
+func TestGetSymbolRanks(t *testing.T) {
+	ctx := context.Background()
+	mockStore := NewMockStore()
+	svc := newService(&observation.TestContext, mockStore, nil, conf.DefaultClient())
+
+	mockStore.GetSymbolRanksFunc.SetDefaultReturn(map[string]map[string]float64{
+		"client.go": {
+			"scip-go gomod github.com/foo/bar v1 `github.com/foo/bar`/Client#":        15,
+			"scip-go gomod github.com/foo/bar v1 `github.com/foo/bar`/Client#Do().":   3,
+			"scip-go gomod github.com/foo/bar v1 `github.com/foo/bar`/NewClient().":   0,
+			"scip-go gomod github.com/foo/bar v1 `github.com/foo/bar`/Client#Do(+1).": 7,
+			"malformed": 100,
+		},
+		"client_test.go": {
+			"scip-go gomod github.com/foo/bar v1 `github.com/foo/bar`/Client#": 1,
+		},
+	}, nil)
+
+	ranks, err := svc.GetSymbolRanks(ctx, "github.com/foo/bar")
+	if err != nil {
+		t.Fatalf("unexpected error getting symbol ranks: %s", err)
+	}
+
+	for _, testCase := range []struct {
+		path     string
+		name     string
+		expected float64
+	}{
+		{"client.go", "Client", 4},
+		{"client.go", "Do", 3},
+		{"client.go", "NewClient", 0},
+		{"client.go", "malformed", 0},
+		{"client_test.go", "Client", 1},
+		{"missing.go", "Client", 0},
+	} {
+		if rank := ranks.Rank(testCase.path, testCase.name); !cmpFloat(rank, testCase.expected) {
+			t.Errorf("unexpected rank for %s in %s. want=%.5f have=%.5f", testCase.name, testCase.path, testCase.expected, rank)
+		}
+	}
+}
+
 const epsilon = 0.00000001
 
 func cmpFloat(x, y float64) bool {
@@ -0,0 +1,12 @@
+package sentinel
+
+import (
+	"context"
+
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+)
+
+type UploadService interface {
+	InferClosestUploads(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) ([]uploadsshared.Dump, error)
+	ReferencesForUpload(ctx context.Context, uploadID int) (uploadsshared.PackageReferenceScanner, error)
+}
@@ -4,7 +4,9 @@ import (
 	"os"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/internal/background"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/internal/lsifstore"
 	sentinelstore "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/internal/store"
+	codeintelshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -13,12 +15,17 @@ import (
 func NewService(
 	observationCtx *observation.Context,
 	db database.DB,
+	codeIntelDB codeintelshared.CodeIntelDB,
+	uploadSvc UploadService,
 ) *Service {
 	store := sentinelstore.New(scopedContext("store", observationCtx), db)
+	lsifStore := lsifstore.New(scopedContext("lsifstore", observationCtx), codeIntelDB)
 
 	return newService(
 		observationCtx,
 		store,
+		lsifStore,
+		uploadSvc,
 	)
 }
 
@@ -36,5 +43,6 @@ func CVEScannerJob(observationCtx *observation.Context, service *Service) []goro
 	return []goroutine.BackgroundRoutine{
 		background.NewCVEDownloader(service.store, metrics, ConfigInst.DownloaderInterval),
 		background.NewCVEMatcher(service.store, metrics, ConfigInst.MatcherInterval, ConfigInst.BatchSize),
+		background.NewCVEReachabilityChecker(service.store, service.lsifstore, metrics, ConfigInst.ReachabilityCheckerInterval, ConfigInst.BatchSize),
 	}
 }
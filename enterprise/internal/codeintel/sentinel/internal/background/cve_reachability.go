@@ -0,0 +1,193 @@
+package background
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/internal/lsifstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/internal/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/shared"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxCallSitesPerMatch is the maximum number of example call sites recorded for a
+// vulnerability match that is found to be reachable.
+const maxCallSitesPerMatch = 10
+
+func NewCVEReachabilityChecker(store store.Store, lsifStore lsifstore.Store, metrics *Metrics, interval time.Duration, batchSize int) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		context.Background(),
+		"codeintel.sentinel-cve-reachability-checker", "Determines whether matched SCIP indexes reference symbols affected by a vulnerability.",
+		interval,
+		goroutine.HandlerFunc(func(ctx context.Context) error {
+			return checkReachabilityBatch(ctx, store, lsifStore, metrics, batchSize)
+		}),
+	)
+}
+
+type reachabilityStore interface {
+	GetReachabilityCandidates(ctx context.Context, batchSize int) ([]shared.ReachabilityCandidate, error)
+	UpdateReachability(ctx context.Context, matchID int, reachability shared.Reachability, callSites []shared.CallSite) error
+}
+
+// checkReachabilityBatch determines the reachability of the next batch of unchecked vulnerability
+// matches. A match whose reachability cannot be checked is recorded with an unknown reachability so
+// that it does not hold back the matches after it; the errors of all matches are returned together.
+func checkReachabilityBatch(ctx context.Context, store reachabilityStore, lsifStore lsifstore.Store, metrics *Metrics, batchSize int) (err error) {
+	candidates, err := store.GetReachabilityCandidates(ctx, batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, candidate := range candidates {
+		reachability, callSites, checkErr := checkReachability(ctx, lsifStore, candidate)
+		if checkErr != nil {
+			err = errors.Append(err, errors.Wrapf(checkErr, "failed to check reachability of match %d", candidate.MatchID))
+			metrics.numReachabilityCheckErrors.Inc()
+			reachability, callSites = shared.ReachabilityUnknown, nil
+		}
+
+		if updateErr := store.UpdateReachability(ctx, candidate.MatchID, reachability, callSites); updateErr != nil {
+			err = errors.Append(err, errors.Wrapf(updateErr, "failed to update reachability of match %d", candidate.MatchID))
+			continue
+		}
+
+		metrics.numMatchesCheckedForReachability.Inc()
+		if reachability == shared.ReachabilityReachable {
+			metrics.numReachableMatches.Inc()
+		}
+	}
+
+	return err
+}
+
+// checkReachability determines whether the upload of the given candidate references any of the
+// symbols affected by the matched vulnerability through any of the package references that caused
+// the match. If the vulnerability does not list any affected symbols, or the upload has no SCIP
+// symbol data to resolve its references against, then the reachability of the match cannot be
+// determined.
+func checkReachability(ctx context.Context, lsifStore lsifstore.Store, candidate shared.ReachabilityCandidate) (shared.Reachability, []shared.CallSite, error) {
+	if len(candidate.AffectedSymbols) == 0 || len(candidate.Packages) == 0 {
+		return shared.ReachabilityUnknown, nil, nil
+	}
+
+	hasSymbolNames, err := lsifStore.HasSymbolNames(ctx, candidate.UploadID)
+	if err != nil {
+		return shared.ReachabilityUnknown, nil, err
+	}
+	if !hasSymbolNames {
+		return shared.ReachabilityUnknown, nil, nil
+	}
+
+	prefixes := make([]string, 0, len(candidate.Packages))
+	for _, pkg := range candidate.Packages {
+		prefixes = append(prefixes, symbolPrefixForPackage(pkg))
+	}
+
+	references, err := lsifStore.GetSymbolReferencesByPrefix(ctx, candidate.UploadID, prefixes)
+	if err != nil {
+		return shared.ReachabilityUnknown, nil, err
+	}
+
+	var callSites []shared.CallSite
+	for _, reference := range references {
+		if !symbolIsAffected(reference.Symbol, candidate.AffectedSymbols) {
+			continue
+		}
+
+		if len(callSites) < maxCallSitesPerMatch {
+			callSites = append(callSites, reference)
+		}
+	}
+
+	if len(callSites) == 0 {
+		return shared.ReachabilityUnreachable, nil, nil
+	}
+
+	return shared.ReachabilityReachable, callSites, nil
+}
+
+// symbolPrefixForPackage returns the prefix shared by the names of all SCIP symbols defined in the
+// given package. See https://github.com/sourcegraph/scip/blob/main/scip.proto
+// for the symbol grammar.
+func symbolPrefixForPackage(pkg shared.ReachabilityPackage) string {
+	parts := []string{
+		pkg.Scheme,
+		pkg.Manager,
+		pkg.Name,
+		pkg.Version,
+	}
+
+	for i, part := range parts {
+		if part == "" {
+			parts[i] = "."
+		} else {
+			parts[i] = strings.ReplaceAll(part, " ", "  ")
+		}
+	}
+
+	return strings.Join(parts, " ") + " "
+}
+
+// symbolIsAffected returns true if the given SCIP symbol name refers to one of the given affected
+// symbols. Affected symbols are given as a path (e.g., a Go import path) and a set of dot-separated
+// names relative to that path (e.g., `Config.Load` for the method `Load` on the type `Config`).
+func symbolIsAffected(symbolName string, affectedSymbols []shared.AffectedSymbol) bool {
+	path, name, ok := splitSymbolName(symbolName)
+	if !ok {
+		return false
+	}
+
+	for _, affectedSymbol := range affectedSymbols {
+		if affectedSymbol.Path != "" && path != affectedSymbol.Path && !strings.HasSuffix(path, "/"+affectedSymbol.Path) {
+			continue
+		}
+
+		for _, symbol := range affectedSymbol.Symbols {
+			if symbol == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// splitSymbolName converts the descriptors of the given SCIP symbol name into a slash-separated path
+// built from its namespace descriptors and a dot-separated name built from its remaining descriptors.
+// Local symbols and symbols nested within parameters do not refer to an addressable entity, and
+// are rejected.
+func splitSymbolName(symbolName string) (path, name string, _ bool) {
+	if scip.IsLocalSymbol(symbolName) {
+		return "", "", false
+	}
+
+	symbol, err := scip.ParseSymbol(symbolName)
+	if err != nil {
+		return "", "", false
+	}
+
+	var namespaces, names []string
+	for _, descriptor := range symbol.Descriptors {
+		switch descriptor.Suffix {
+		case scip.Descriptor_Namespace:
+			namespaces = append(namespaces, descriptor.Name)
+		case scip.Descriptor_Type, scip.Descriptor_Term, scip.Descriptor_Method, scip.Descriptor_Macro:
+			names = append(names, descriptor.Name)
+		case scip.Descriptor_Meta:
+			// Meta descriptors do not contribute to the name of the entity
+		default:
+			return "", "", false
+		}
+	}
+
+	if len(names) == 0 {
+		return "", "", false
+	}
+
+	return strings.Join(namespaces, "/"), strings.Join(names, "."), true
+}
@@ -0,0 +1,153 @@
+package background
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestSymbolPrefixForPackage(t *testing.T) {
+	pkg := shared.ReachabilityPackage{
+		Scheme:  "scip-go",
+		Manager: "gomod",
+		Name:    "github.com/go-nacelle/config",
+		Version: "v1.2.5",
+	}
+	if want, have := "scip-go gomod github.com/go-nacelle/config v1.2.5 ", symbolPrefixForPackage(pkg); have != want {
+		t.Errorf("unexpected prefix. want=%q have=%q", want, have)
+	}
+
+	pkg = shared.ReachabilityPackage{
+		Scheme: "scip-python",
+		Name:   "my package",
+	}
+	if want, have := "scip-python . my  package . ", symbolPrefixForPackage(pkg); have != want {
+		t.Errorf("unexpected prefix. want=%q have=%q", want, have)
+	}
+}
+
+func TestSymbolIsAffected(t *testing.T) {
+	affectedSymbols := []shared.AffectedSymbol{
+		{Path: "github.com/go-nacelle/config", Symbols: []string{"Config.Load", "Parse"}},
+	}
+
+	testCases := []struct {
+		symbolName string
+		expected   bool
+	}{
+		{"scip-go gomod github.com/go-nacelle/config v1.2.5 `github.com/go-nacelle/config`/Config#Load().", true},
+		{"scip-go gomod github.com/go-nacelle/config v1.2.5 `github.com/go-nacelle/config`/Parse().", true},
+		{"scip-go gomod github.com/go-nacelle/config v1.2.5 `github.com/go-nacelle/config`/Config#Dump().", false},
+		{"scip-go gomod github.com/go-nacelle/config v1.2.5 `github.com/go-nacelle/config/internal`/Parse().", false},
+		{"scip-go gomod github.com/go-nacelle/config v1.2.5 `github.com/go-nacelle/config`/Parse().(text)", false},
+		{"local 42", false},
+		{"not a symbol", false},
+	}
+
+	for _, testCase := range testCases {
+		if have := symbolIsAffected(testCase.symbolName, affectedSymbols); have != testCase.expected {
+			t.Errorf("unexpected result for %q. want=%v have=%v", testCase.symbolName, testCase.expected, have)
+		}
+	}
+}
+
+type fakeReachabilityStore struct {
+	candidates []shared.ReachabilityCandidate
+	updates    map[int]shared.Reachability
+}
+
+func (s *fakeReachabilityStore) GetReachabilityCandidates(_ context.Context, _ int) ([]shared.ReachabilityCandidate, error) {
+	return s.candidates, nil
+}
+
+func (s *fakeReachabilityStore) UpdateReachability(_ context.Context, matchID int, reachability shared.Reachability, _ []shared.CallSite) error {
+	s.updates[matchID] = reachability
+	return nil
+}
+
+type fakeLSIFStore struct {
+	symbolNames map[int]bool
+	references  map[int][]shared.CallSite
+	errors      map[int]error
+}
+
+func (s *fakeLSIFStore) HasSymbolNames(_ context.Context, uploadID int) (bool, error) {
+	return s.symbolNames[uploadID], nil
+}
+
+func (s *fakeLSIFStore) GetSymbolReferencesByPrefix(_ context.Context, uploadID int, prefixes []string) ([]shared.CallSite, error) {
+	if err := s.errors[uploadID]; err != nil {
+		return nil, err
+	}
+
+	var callSites []shared.CallSite
+	for _, reference := range s.references[uploadID] {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(reference.Symbol, prefix) {
+				callSites = append(callSites, reference)
+				break
+			}
+		}
+	}
+	return callSites, nil
+}
+
+func TestCheckReachabilityBatch(t *testing.T) {
+	affectedSymbols := []shared.AffectedSymbol{
+		{Path: "github.com/go-nacelle/config", Symbols: []string{"Config.Load"}},
+	}
+	config := shared.ReachabilityPackage{Scheme: "scip-go", Manager: "gomod", Name: "github.com/go-nacelle/config", Version: "v1.2.3"}
+	configV2 := shared.ReachabilityPackage{Scheme: "scip-go", Manager: "gomod", Name: "github.com/go-nacelle/config/v2", Version: "v2.0.0"}
+	reference := func(pkg shared.ReachabilityPackage, descriptors string) shared.CallSite {
+		return shared.CallSite{Path: "main.go", Symbol: symbolPrefixForPackage(pkg) + descriptors}
+	}
+
+	store := &fakeReachabilityStore{
+		candidates: []shared.ReachabilityCandidate{
+			// Only the second package reference of the match references an affected symbol
+			{MatchID: 1, UploadID: 50, Packages: []shared.ReachabilityPackage{configV2, config}, AffectedSymbols: affectedSymbols},
+			{MatchID: 2, UploadID: 51, Packages: []shared.ReachabilityPackage{config}, AffectedSymbols: affectedSymbols},
+			// The references of this upload can't be read
+			{MatchID: 3, UploadID: 52, Packages: []shared.ReachabilityPackage{config}, AffectedSymbols: affectedSymbols},
+			// This upload has no SCIP symbol data
+			{MatchID: 4, UploadID: 53, Packages: []shared.ReachabilityPackage{config}, AffectedSymbols: affectedSymbols},
+			{MatchID: 5, UploadID: 50, Packages: []shared.ReachabilityPackage{config}},
+		},
+		updates: map[int]shared.Reachability{},
+	}
+	lsifStore := &fakeLSIFStore{
+		symbolNames: map[int]bool{50: true, 51: true, 52: true},
+		references: map[int][]shared.CallSite{
+			50: {
+				reference(configV2, "`github.com/go-nacelle/config/v2`/Config#Dump()."),
+				reference(config, "`github.com/go-nacelle/config`/Config#Load()."),
+			},
+			51: {
+				reference(config, "`github.com/go-nacelle/config`/Config#Dump()."),
+			},
+		},
+		errors: map[int]error{52: errors.New("uh-oh")},
+	}
+
+	err := checkReachabilityBatch(context.Background(), store, lsifStore, NewMetrics(&observation.TestContext), 100)
+	if err == nil || !strings.Contains(err.Error(), "uh-oh") {
+		t.Errorf("expected the error of match 3 to be returned, got %v", err)
+	}
+
+	expected := map[int]shared.Reachability{
+		1: shared.ReachabilityReachable,
+		2: shared.ReachabilityUnreachable,
+		3: shared.ReachabilityUnknown,
+		4: shared.ReachabilityUnknown,
+		5: shared.ReachabilityUnknown,
+	}
+	if diff := cmp.Diff(expected, store.updates); diff != "" {
+		t.Errorf("unexpected reachability (-want +got):\n%s", diff)
+	}
+}
@@ -7,9 +7,12 @@ import (
 )
 
 type Metrics struct {
-	numReferencesScanned       prometheus.Counter
-	numVulnerabilityMatches    prometheus.Counter
-	numVulnerabilitiesInserted prometheus.Counter
+	numReferencesScanned             prometheus.Counter
+	numVulnerabilityMatches          prometheus.Counter
+	numVulnerabilitiesInserted       prometheus.Counter
+	numMatchesCheckedForReachability prometheus.Counter
+	numReachableMatches              prometheus.Counter
+	numReachabilityCheckErrors       prometheus.Counter
 }
 
 func NewMetrics(observationCtx *observation.Context) *Metrics {
@@ -35,10 +38,25 @@ func NewMetrics(observationCtx *observation.Context) *Metrics {
 		"src_codeintel_sentinel_num_vulnerability_matches_total",
 		"The total number of vulnerability matches found.",
 	)
+	numMatchesCheckedForReachability := counter(
+		"src_codeintel_sentinel_num_matches_checked_for_reachability_total",
+		"The total number of vulnerability matches checked for references to affected symbols.",
+	)
+	numReachableMatches := counter(
+		"src_codeintel_sentinel_num_reachable_matches_total",
+		"The total number of vulnerability matches found to reference an affected symbol.",
+	)
+	numReachabilityCheckErrors := counter(
+		"src_codeintel_sentinel_num_reachability_check_errors_total",
+		"The total number of vulnerability matches whose reachability could not be checked.",
+	)
 
 	return &Metrics{
-		numReferencesScanned:       numReferencesScanned,
-		numVulnerabilityMatches:    numVulnerabilityMatches,
-		numVulnerabilitiesInserted: numVulnerabilitiesInserted,
+		numReferencesScanned:             numReferencesScanned,
+		numVulnerabilityMatches:          numVulnerabilityMatches,
+		numVulnerabilitiesInserted:       numVulnerabilitiesInserted,
+		numMatchesCheckedForReachability: numMatchesCheckedForReachability,
+		numReachableMatches:              numReachableMatches,
+		numReachabilityCheckErrors:       numReachabilityCheckErrors,
 	}
 }
@@ -0,0 +1,145 @@
+package lsifstore
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/ranges"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// HasSymbolNames determines whether the given upload has any SCIP symbol data. Uploads
+// that were not converted to SCIP have none, and their references cannot be resolved
+// to symbols.
+func (s *store) HasSymbolNames(ctx context.Context, uploadID int) (_ bool, err error) {
+	ctx, _, endObservation := s.operations.hasSymbolNames.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("uploadID", uploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	exists, _, err := basestore.ScanFirstBool(s.db.Query(ctx, sqlf.Sprintf(hasSymbolNamesQuery, uploadID)))
+	return exists, err
+}
+
+const hasSymbolNamesQuery = `
+SELECT EXISTS (
+	SELECT 1
+	FROM codeintel_scip_symbol_names ssn
+	WHERE
+		ssn.upload_id = %s AND
+		ssn.prefix_id IS NULL
+)
+`
+
+// GetSymbolReferencesByPrefix returns the set of locations within the given upload that reference
+// a symbol whose name begins with one of the given prefixes. Definitions of the matching symbols
+// are not included in the result.
+func (s *store) GetSymbolReferencesByPrefix(ctx context.Context, uploadID int, prefixes []string) (_ []shared.CallSite, err error) {
+	ctx, _, endObservation := s.operations.getSymbolReferencesByPrefix.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("uploadID", uploadID),
+		otlog.Int("numPrefixes", len(prefixes)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(prefixes) == 0 {
+		return nil, nil
+	}
+
+	return scanCallSites(s.db.Query(ctx, sqlf.Sprintf(
+		getSymbolReferencesByPrefixQuery,
+		pq.Array(prefixes),
+		uploadID,
+		uploadID,
+		uploadID,
+	)))
+}
+
+const getSymbolReferencesByPrefixQuery = `
+WITH RECURSIVE
+-- Walk the symbol name trie of the given upload starting at its roots. We only
+-- follow trie paths that are compatible with one of the search prefixes, which
+-- is the case while either the reconstructed name is a prefix of the search term
+-- or the search term is a prefix of the reconstructed name. Once the search term
+-- has been exhausted, every descendant of the current node is a match.
+matching_prefixes(id, prefix, search) AS (
+	(
+		SELECT
+			ssn.id,
+			ssn.name_segment,
+			t.search
+		FROM codeintel_scip_symbol_names ssn
+		JOIN unnest(%s::text[]) AS t(search) ON
+			starts_with(t.search, ssn.name_segment) OR
+			starts_with(ssn.name_segment, t.search)
+		WHERE
+			ssn.upload_id = %s AND
+			ssn.prefix_id IS NULL
+	) UNION (
+		SELECT
+			ssn.id,
+			mp.prefix || ssn.name_segment,
+			mp.search
+		FROM matching_prefixes mp
+		JOIN codeintel_scip_symbol_names ssn ON
+			ssn.upload_id = %s AND
+			ssn.prefix_id = mp.id
+		WHERE
+			starts_with(mp.search, mp.prefix || ssn.name_segment) OR
+			starts_with(mp.prefix || ssn.name_segment, mp.search)
+	)
+)
+SELECT
+	mp.prefix,
+	dl.document_path,
+	ss.reference_ranges
+FROM matching_prefixes mp
+JOIN codeintel_scip_symbols ss ON ss.symbol_id = mp.id
+JOIN codeintel_scip_document_lookup dl ON dl.id = ss.document_lookup_id
+WHERE
+	ss.upload_id = %s AND
+	ss.reference_ranges IS NOT NULL AND
+	starts_with(mp.prefix, mp.search)
+ORDER BY dl.document_path, mp.prefix
+`
+
+func scanCallSites(rows basestore.Rows, queryErr error) (_ []shared.CallSite, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var callSites []shared.CallSite
+	for rows.Next() {
+		var (
+			symbolName    string
+			path          string
+			encodedRanges []byte
+		)
+		if err := rows.Scan(&symbolName, &path, &encodedRanges); err != nil {
+			return nil, err
+		}
+
+		decodedRanges, err := ranges.DecodeRanges(encodedRanges)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range decodedRanges {
+			callSites = append(callSites, shared.CallSite{
+				Path:           path,
+				Symbol:         symbolName,
+				StartLine:      int(r.Start.Line),
+				StartCharacter: int(r.Start.Character),
+				EndLine:        int(r.End.Line),
+				EndCharacter:   int(r.End.Character),
+			})
+		}
+	}
+
+	return callSites, nil
+}
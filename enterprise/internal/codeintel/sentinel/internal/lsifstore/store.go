@@ -0,0 +1,28 @@
+package lsifstore
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/shared"
+	codeintelshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type Store interface {
+	// References
+	HasSymbolNames(ctx context.Context, uploadID int) (bool, error)
+	GetSymbolReferencesByPrefix(ctx context.Context, uploadID int, prefixes []string) ([]shared.CallSite, error)
+}
+
+type store struct {
+	db         *basestore.Store
+	operations *operations
+}
+
+func New(observationCtx *observation.Context, db codeintelshared.CodeIntelDB) Store {
+	return &store{
+		db:         basestore.NewWithHandle(db.Handle()),
+		operations: newOperations(observationCtx),
+	}
+}
@@ -0,0 +1,243 @@
+package sbom
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/shared"
+)
+
+// The following types model the subset of the CycloneDX 1.4 JSON format that we emit.
+//
+// See https://cyclonedx.org/docs/1.4/json/.
+
+type cycloneDXDocument struct {
+	BOMFormat       string                   `json:"bomFormat"`
+	SpecVersion     string                   `json:"specVersion"`
+	SerialNumber    string                   `json:"serialNumber"`
+	Version         int                      `json:"version"`
+	Metadata        cycloneDXMetadata        `json:"metadata"`
+	Components      []cycloneDXComponent     `json:"components"`
+	Dependencies    []cycloneDXDependency    `json:"dependencies"`
+	Vulnerabilities []cycloneDXVulnerability `json:"vulnerabilities,omitempty"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string             `json:"timestamp"`
+	Tools     []cycloneDXTool    `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXTool struct {
+	Vendor string `json:"vendor"`
+	Name   string `json:"name"`
+}
+
+type cycloneDXComponent struct {
+	Type    string `json:"type"`
+	BOMRef  string `json:"bom-ref"`
+	Group   string `json:"group,omitempty"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	PURL    string `json:"purl,omitempty"`
+}
+
+type cycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+type cycloneDXVulnerability struct {
+	BOMRef         string              `json:"bom-ref"`
+	ID             string              `json:"id"`
+	Source         *cycloneDXSource    `json:"source,omitempty"`
+	Ratings        []cycloneDXRating   `json:"ratings,omitempty"`
+	CWEs           []int               `json:"cwes,omitempty"`
+	Description    string              `json:"description,omitempty"`
+	Detail         string              `json:"detail,omitempty"`
+	Recommendation string              `json:"recommendation,omitempty"`
+	Advisories     []cycloneDXAdvisory `json:"advisories,omitempty"`
+	Published      string              `json:"published,omitempty"`
+	Updated        string              `json:"updated,omitempty"`
+	Analysis       *cycloneDXAnalysis  `json:"analysis,omitempty"`
+	Affects        []cycloneDXAffect   `json:"affects"`
+}
+
+type cycloneDXSource struct {
+	URL string `json:"url"`
+}
+
+type cycloneDXRating struct {
+	Score    *float64 `json:"score,omitempty"`
+	Severity string   `json:"severity,omitempty"`
+	Method   string   `json:"method,omitempty"`
+	Vector   string   `json:"vector,omitempty"`
+}
+
+type cycloneDXAdvisory struct {
+	URL string `json:"url"`
+}
+
+type cycloneDXAnalysis struct {
+	State         string `json:"state"`
+	Justification string `json:"justification,omitempty"`
+	Detail        string `json:"detail,omitempty"`
+}
+
+type cycloneDXAffect struct {
+	Ref string `json:"ref"`
+}
+
+func newCycloneDXDocument(sbom shared.SBOM) cycloneDXDocument {
+	root := cycloneDXComponent{
+		Type:    "application",
+		BOMRef:  fmt.Sprintf("%s@%s", sbom.RepositoryName, sbom.Commit),
+		Name:    sbom.RepositoryName,
+		Version: sbom.Commit,
+	}
+
+	components := make([]cycloneDXComponent, 0, len(sbom.Packages))
+	dependencies := make([]string, 0, len(sbom.Packages))
+	vulnerabilities := []cycloneDXVulnerability{}
+	vulnerabilityIndexes := map[int]int{}
+	reachabilities := []shared.Reachability{}
+
+	for _, pkg := range sbom.Packages {
+		purl := packageURLFor(pkg)
+		ref := purl.String()
+
+		components = append(components, cycloneDXComponent{
+			Type:    "library",
+			BOMRef:  ref,
+			Group:   purl.Namespace,
+			Name:    purl.Name,
+			Version: pkg.Version,
+			PURL:    ref,
+		})
+		dependencies = append(dependencies, ref)
+
+		for _, vulnerability := range pkg.Vulnerabilities {
+			i, ok := vulnerabilityIndexes[vulnerability.Vulnerability.ID]
+			if !ok {
+				i = len(vulnerabilities)
+				vulnerabilityIndexes[vulnerability.Vulnerability.ID] = i
+				vulnerabilities = append(vulnerabilities, newCycloneDXVulnerability(vulnerability.Vulnerability))
+				reachabilities = append(reachabilities, vulnerability.Reachability)
+			} else {
+				reachabilities[i] = mergeReachability(reachabilities[i], vulnerability.Reachability)
+			}
+
+			vulnerabilities[i].Affects = append(vulnerabilities[i].Affects, cycloneDXAffect{Ref: ref})
+			if vulnerability.FixedIn != nil && vulnerabilities[i].Recommendation == "" {
+				vulnerabilities[i].Recommendation = fmt.Sprintf("Upgrade %s to version %s or later.", pkg.Name, *vulnerability.FixedIn)
+			}
+		}
+	}
+
+	for i := range vulnerabilities {
+		vulnerabilities[i].Analysis = cycloneDXAnalysisFor(reachabilities[i])
+	}
+
+	return cycloneDXDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.4",
+		SerialNumber: "urn:uuid:" + documentID(sbom).String(),
+		Version:      1,
+		Metadata: cycloneDXMetadata{
+			Timestamp: sbom.CreatedAt.UTC().Format(time.RFC3339),
+			Tools:     []cycloneDXTool{{Vendor: "Sourcegraph", Name: "Sourcegraph"}},
+			Component: root,
+		},
+		Components: components,
+		Dependencies: []cycloneDXDependency{
+			{Ref: root.BOMRef, DependsOn: dependencies},
+		},
+		Vulnerabilities: vulnerabilities,
+	}
+}
+
+func newCycloneDXVulnerability(vulnerability shared.Vulnerability) cycloneDXVulnerability {
+	v := cycloneDXVulnerability{
+		BOMRef:      vulnerability.SourceID,
+		ID:          vulnerability.SourceID,
+		Description: vulnerability.Summary,
+		Detail:      vulnerability.Details,
+		Published:   formatTime(&vulnerability.PublishedAt),
+		Updated:     formatTime(vulnerability.ModifiedAt),
+	}
+
+	if vulnerability.DataSource != "" {
+		v.Source = &cycloneDXSource{URL: vulnerability.DataSource}
+	}
+
+	if vulnerability.Severity != "" || vulnerability.CVSSVector != "" {
+		rating := cycloneDXRating{
+			Severity: strings.ToLower(vulnerability.Severity),
+			Method:   cvssMethod(vulnerability.CVSSVector),
+			Vector:   vulnerability.CVSSVector,
+		}
+		if score, err := strconv.ParseFloat(vulnerability.CVSSScore, 64); err == nil {
+			rating.Score = &score
+		}
+
+		v.Ratings = append(v.Ratings, rating)
+	}
+
+	for _, cwe := range vulnerability.CWEs {
+		if id, err := strconv.Atoi(strings.TrimPrefix(cwe, "CWE-")); err == nil {
+			v.CWEs = append(v.CWEs, id)
+		}
+	}
+
+	for _, url := range vulnerability.URLs {
+		v.Advisories = append(v.Advisories, cycloneDXAdvisory{URL: url})
+	}
+
+	return v
+}
+
+// cycloneDXAnalysisFor returns the analysis of a vulnerability with the given reachability.
+func cycloneDXAnalysisFor(reachability shared.Reachability) *cycloneDXAnalysis {
+	switch reachability {
+	case shared.ReachabilityReachable:
+		return &cycloneDXAnalysis{
+			State:  "exploitable",
+			Detail: "Precise code intelligence data shows that an affected symbol is referenced.",
+		}
+
+	case shared.ReachabilityUnreachable:
+		return &cycloneDXAnalysis{
+			State:         "not_affected",
+			Justification: "code_not_reachable",
+			Detail:        "Precise code intelligence data shows that no affected symbol is referenced.",
+		}
+	}
+
+	return nil
+}
+
+// cvssMethod returns the CycloneDX risk scoring methodology for the given CVSS vector.
+func cvssMethod(vector string) string {
+	switch {
+	case vector == "":
+		return ""
+	case strings.HasPrefix(vector, "CVSS:3.1/"):
+		return "CVSSv31"
+	case strings.HasPrefix(vector, "CVSS:3.0/"):
+		return "CVSSv3"
+	case strings.HasPrefix(vector, "CVSS:2.0/"), strings.HasPrefix(vector, "AV:"):
+		return "CVSSv2"
+	default:
+		return "other"
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
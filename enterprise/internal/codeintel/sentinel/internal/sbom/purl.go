@@ -0,0 +1,108 @@
+package sbom
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/shared"
+)
+
+// packageURL is a package identifier following the purl specification.
+//
+// See https://github.com/package-url/purl-spec.
+type packageURL struct {
+	Type      string
+	Namespace string
+	Name      string
+	Version   string
+}
+
+func (p packageURL) String() string {
+	var sb strings.Builder
+	sb.WriteString("pkg:")
+	sb.WriteString(p.Type)
+	sb.WriteString("/")
+
+	if p.Namespace != "" {
+		for _, segment := range strings.Split(p.Namespace, "/") {
+			sb.WriteString(escapePackageURLSegment(segment))
+			sb.WriteString("/")
+		}
+	}
+
+	sb.WriteString(escapePackageURLSegment(p.Name))
+
+	if p.Version != "" {
+		sb.WriteString("@")
+		sb.WriteString(escapePackageURLSegment(p.Version))
+	}
+
+	return sb.String()
+}
+
+// managerToPackageURLType maps the package manager recorded by an indexer (or the scheme,
+// for LSIF indexes that do not record a manager) to the purl type of its packages.
+var managerToPackageURLType = map[string]string{
+	"cargo":    "cargo",
+	"composer": "composer",
+	"gem":      "gem",
+	"gomod":    "golang",
+	"maven":    "maven",
+	"npm":      "npm",
+	"nuget":    "nuget",
+	"pip":      "pypi",
+	"pub":      "pub",
+	"pypi":     "pypi",
+	"python":   "pypi",
+	"rubygems": "gem",
+}
+
+// packageURLFor returns the package URL identifying the given package. Packages managed by
+// a package manager without a corresponding purl type are given the `generic` type.
+func packageURLFor(pkg shared.SBOMPackage) packageURL {
+	typ, ok := managerToPackageURLType[pkg.Manager]
+	if !ok {
+		if typ, ok = managerToPackageURLType[pkg.Scheme]; !ok {
+			typ = "generic"
+		}
+	}
+
+	namespace, name := "", pkg.Name
+	switch typ {
+	case "maven":
+		// Maven packages are named group:artifact
+		if i := strings.LastIndex(name, ":"); i >= 0 {
+			namespace, name = name[:i], name[i+1:]
+		}
+
+	case "npm":
+		// Scoped npm packages are named @scope/name
+		if strings.HasPrefix(name, "@") {
+			if i := strings.Index(name, "/"); i >= 0 {
+				namespace, name = name[:i], name[i+1:]
+			}
+		}
+
+	case "golang", "composer":
+		// Go modules are named by import path and composer packages are named vendor/name
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			namespace, name = name[:i], name[i+1:]
+		}
+
+	case "pypi":
+		// PyPI names are case-insensitive and treat underscores as dashes
+		name = strings.ReplaceAll(strings.ToLower(name), "_", "-")
+	}
+
+	return packageURL{
+		Type:      typ,
+		Namespace: namespace,
+		Name:      name,
+		Version:   pkg.Version,
+	}
+}
+
+func escapePackageURLSegment(s string) string {
+	// PathEscape leaves some characters unescaped that have special meaning within a purl
+	return strings.NewReplacer("@", "%40", ":", "%3A", "+", "%2B").Replace(url.PathEscape(s))
+}
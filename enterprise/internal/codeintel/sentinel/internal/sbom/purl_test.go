@@ -0,0 +1,31 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/shared"
+)
+
+func TestPackageURLFor(t *testing.T) {
+	testCases := []struct {
+		pkg      shared.SBOMPackage
+		expected string
+	}{
+		{shared.SBOMPackage{Scheme: "scip-go", Manager: "gomod", Name: "github.com/go-nacelle/config", Version: "v1.2.5"}, "pkg:golang/github.com/go-nacelle/config@v1.2.5"},
+		{shared.SBOMPackage{Scheme: "gomod", Name: "github.com/go-nacelle/config", Version: "v1.2.5"}, "pkg:golang/github.com/go-nacelle/config@v1.2.5"},
+		{shared.SBOMPackage{Scheme: "gomod", Name: "github.com/docker/docker", Version: "v20.10.7+incompatible"}, "pkg:golang/github.com/docker/docker@v20.10.7%2Bincompatible"},
+		{shared.SBOMPackage{Scheme: "scip-typescript", Manager: "npm", Name: "left-pad", Version: "1.3.0"}, "pkg:npm/left-pad@1.3.0"},
+		{shared.SBOMPackage{Scheme: "scip-typescript", Manager: "npm", Name: "@types/node", Version: "18.0.0"}, "pkg:npm/%40types/node@18.0.0"},
+		{shared.SBOMPackage{Scheme: "semanticdb", Manager: "maven", Name: "com.google.guava:guava", Version: "31.1-jre"}, "pkg:maven/com.google.guava/guava@31.1-jre"},
+		{shared.SBOMPackage{Scheme: "scip-python", Manager: "python", Name: "Typing_Extensions", Version: "4.5.0"}, "pkg:pypi/typing-extensions@4.5.0"},
+		{shared.SBOMPackage{Scheme: "rust-analyzer", Manager: "cargo", Name: "serde", Version: "1.0.158"}, "pkg:cargo/serde@1.0.158"},
+		{shared.SBOMPackage{Scheme: "scip-ruby", Manager: "rubygems", Name: "rails", Version: "7.0.4"}, "pkg:gem/rails@7.0.4"},
+		{shared.SBOMPackage{Scheme: "scip-unknown", Manager: "unknown", Name: "lib", Version: ""}, "pkg:generic/lib"},
+	}
+
+	for _, testCase := range testCases {
+		if purl := packageURLFor(testCase.pkg).String(); purl != testCase.expected {
+			t.Errorf("unexpected package URL for %v. want=%q have=%q", testCase.pkg, testCase.expected, purl)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/shared"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Encode serializes the given SBOM into a JSON document of the given format.
+func Encode(format shared.SBOMFormat, sbom shared.SBOM) ([]byte, error) {
+	sbom.Packages = normalizePackages(sbom.Packages)
+
+	var document any
+	switch format {
+	case shared.SBOMFormatCycloneDX:
+		document = newCycloneDXDocument(sbom)
+	case shared.SBOMFormatSPDX:
+		document = newSPDXDocument(sbom)
+	default:
+		return nil, errors.Newf("unknown SBOM format %q", format)
+	}
+
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// documentID returns a stable identifier for a single generation of the given SBOM.
+func documentID(sbom shared.SBOM) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(fmt.Sprintf(
+		"%s/%s@%s#%d",
+		sbom.ExternalURL,
+		sbom.RepositoryName,
+		sbom.Commit,
+		sbom.CreatedAt.UnixNano(),
+	)))
+}
+
+// normalizePackages returns a copy of the given packages sorted by package URL, with duplicates
+// (the same package referenced by multiple indexes) merged into a single entry.
+func normalizePackages(packages []shared.SBOMPackage) []shared.SBOMPackage {
+	byURL := map[string]shared.SBOMPackage{}
+	for _, pkg := range packages {
+		key := packageURLFor(pkg).String()
+
+		if existing, ok := byURL[key]; ok {
+			pkg.Vulnerabilities = mergeVulnerabilities(existing.Vulnerabilities, pkg.Vulnerabilities)
+		}
+		byURL[key] = pkg
+	}
+
+	keys := make([]string, 0, len(byURL))
+	for key := range byURL {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	normalized := make([]shared.SBOMPackage, 0, len(keys))
+	for _, key := range keys {
+		normalized = append(normalized, byURL[key])
+	}
+
+	return normalized
+}
+
+// mergeVulnerabilities returns the union of the given vulnerability lists. If the same vulnerability
+// occurs in both lists, the entries are combined into one.
+func mergeVulnerabilities(a, b []shared.SBOMVulnerability) []shared.SBOMVulnerability {
+	merged := make([]shared.SBOMVulnerability, 0, len(a)+len(b))
+	indexes := map[int]int{}
+
+	for _, vulnerability := range append(append([]shared.SBOMVulnerability(nil), a...), b...) {
+		i, ok := indexes[vulnerability.Vulnerability.ID]
+		if !ok {
+			indexes[vulnerability.Vulnerability.ID] = len(merged)
+			merged = append(merged, vulnerability)
+			continue
+		}
+
+		merged[i].Reachability = mergeReachability(merged[i].Reachability, vulnerability.Reachability)
+	}
+
+	return merged
+}
+
+// mergeReachability returns the reachability of a vulnerability matched by two indexes. A
+// vulnerability is reachable if either index reaches it, and is unreachable only if it has
+// been determined to be unreachable in both indexes.
+func mergeReachability(a, b shared.Reachability) shared.Reachability {
+	if a == shared.ReachabilityReachable || b == shared.ReachabilityReachable {
+		return shared.ReachabilityReachable
+	}
+	if a == shared.ReachabilityUnreachable && b == shared.ReachabilityUnreachable {
+		return shared.ReachabilityUnreachable
+	}
+
+	return shared.ReachabilityUnknown
+}
@@ -0,0 +1,180 @@
+package sbom
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/shared"
+)
+
+var fixedIn = "v1.2.6"
+
+var testVulnerability = shared.Vulnerability{
+	ID:          1,
+	SourceID:    "GHSA-abcd-efgh-ijkl",
+	Summary:     "Config loading is unsafe",
+	DataSource:  "https://github.com/advisories/GHSA-abcd-efgh-ijkl",
+	Severity:    "HIGH",
+	CVSSVector:  "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:N",
+	CVSSScore:   "9.1",
+	CWEs:        []string{"CWE-94"},
+	PublishedAt: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+}
+
+var testSBOM = shared.SBOM{
+	ExternalURL:    "https://sourcegraph.test",
+	RepositoryName: "github.com/sourcegraph/sourcegraph",
+	Commit:         "deadbeef01deadbeef02deadbeef03deadbeef04",
+	CreatedAt:      time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC),
+	Packages: []shared.SBOMPackage{
+		{Scheme: "scip-typescript", Manager: "npm", Name: "left-pad", Version: "1.3.0"},
+		{
+			Scheme:  "scip-go",
+			Manager: "gomod",
+			Name:    "github.com/go-nacelle/config",
+			Version: "v1.2.5",
+			Vulnerabilities: []shared.SBOMVulnerability{
+				{Vulnerability: testVulnerability, FixedIn: &fixedIn, Reachability: shared.ReachabilityUnreachable},
+			},
+		},
+		// Same package referenced by another index
+		{
+			Scheme:  "scip-go",
+			Manager: "gomod",
+			Name:    "github.com/go-nacelle/config",
+			Version: "v1.2.5",
+			Vulnerabilities: []shared.SBOMVulnerability{
+				{Vulnerability: testVulnerability, FixedIn: &fixedIn, Reachability: shared.ReachabilityReachable},
+			},
+		},
+	},
+}
+
+func TestEncodeCycloneDX(t *testing.T) {
+	serialized, err := Encode(shared.SBOMFormatCycloneDX, testSBOM)
+	if err != nil {
+		t.Fatalf("unexpected error encoding SBOM: %s", err)
+	}
+
+	var document cycloneDXDocument
+	if err := json.Unmarshal(serialized, &document); err != nil {
+		t.Fatalf("unexpected error decoding SBOM: %s", err)
+	}
+
+	score := 9.1
+	expectedDocument := cycloneDXDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.4",
+		SerialNumber: "urn:uuid:" + documentID(testSBOM).String(),
+		Version:      1,
+		Metadata: cycloneDXMetadata{
+			Timestamp: "2023-04-05T06:07:08Z",
+			Tools:     []cycloneDXTool{{Vendor: "Sourcegraph", Name: "Sourcegraph"}},
+			Component: cycloneDXComponent{
+				Type:    "application",
+				BOMRef:  "github.com/sourcegraph/sourcegraph@deadbeef01deadbeef02deadbeef03deadbeef04",
+				Name:    "github.com/sourcegraph/sourcegraph",
+				Version: "deadbeef01deadbeef02deadbeef03deadbeef04",
+			},
+		},
+		Components: []cycloneDXComponent{
+			{Type: "library", BOMRef: "pkg:golang/github.com/go-nacelle/config@v1.2.5", Group: "github.com/go-nacelle", Name: "config", Version: "v1.2.5", PURL: "pkg:golang/github.com/go-nacelle/config@v1.2.5"},
+			{Type: "library", BOMRef: "pkg:npm/left-pad@1.3.0", Name: "left-pad", Version: "1.3.0", PURL: "pkg:npm/left-pad@1.3.0"},
+		},
+		Dependencies: []cycloneDXDependency{
+			{
+				Ref:       "github.com/sourcegraph/sourcegraph@deadbeef01deadbeef02deadbeef03deadbeef04",
+				DependsOn: []string{"pkg:golang/github.com/go-nacelle/config@v1.2.5", "pkg:npm/left-pad@1.3.0"},
+			},
+		},
+		Vulnerabilities: []cycloneDXVulnerability{
+			{
+				BOMRef:         "GHSA-abcd-efgh-ijkl",
+				ID:             "GHSA-abcd-efgh-ijkl",
+				Source:         &cycloneDXSource{URL: "https://github.com/advisories/GHSA-abcd-efgh-ijkl"},
+				Ratings:        []cycloneDXRating{{Score: &score, Severity: "high", Method: "CVSSv31", Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:N"}},
+				CWEs:           []int{94},
+				Description:    "Config loading is unsafe",
+				Recommendation: "Upgrade github.com/go-nacelle/config to version v1.2.6 or later.",
+				Published:      "2023-01-02T03:04:05Z",
+				Analysis: &cycloneDXAnalysis{
+					State:  "exploitable",
+					Detail: "Precise code intelligence data shows that an affected symbol is referenced.",
+				},
+				Affects: []cycloneDXAffect{{Ref: "pkg:golang/github.com/go-nacelle/config@v1.2.5"}},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedDocument, document); diff != "" {
+		t.Errorf("unexpected document (-want +got):\n%s", diff)
+	}
+}
+
+func TestEncodeSPDX(t *testing.T) {
+	serialized, err := Encode(shared.SBOMFormatSPDX, testSBOM)
+	if err != nil {
+		t.Fatalf("unexpected error encoding SBOM: %s", err)
+	}
+
+	var document spdxDocument
+	if err := json.Unmarshal(serialized, &document); err != nil {
+		t.Fatalf("unexpected error decoding SBOM: %s", err)
+	}
+
+	expectedDocument := spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              "github.com/sourcegraph/sourcegraph@deadbeef01deadbeef02deadbeef03deadbeef04",
+		DocumentNamespace: "https://sourcegraph.test/github.com/sourcegraph/sourcegraph/-/sbom/spdx/" + documentID(testSBOM).String(),
+		CreationInfo: spdxCreationInfo{
+			Created:  "2023-04-05T06:07:08Z",
+			Creators: []string{"Tool: Sourcegraph", "Organization: Sourcegraph"},
+		},
+		Packages: []spdxPackage{
+			{
+				Name:                  "github.com/sourcegraph/sourcegraph",
+				SPDXID:                "SPDXRef-Repository",
+				VersionInfo:           "deadbeef01deadbeef02deadbeef03deadbeef04",
+				DownloadLocation:      "NOASSERTION",
+				PrimaryPackagePurpose: "SOURCE",
+			},
+			{
+				Name:             "github.com/go-nacelle/config",
+				SPDXID:           "SPDXRef-Package-1",
+				VersionInfo:      "v1.2.5",
+				DownloadLocation: "NOASSERTION",
+				ExternalRefs: []spdxExternalRef{
+					{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: "pkg:golang/github.com/go-nacelle/config@v1.2.5"},
+					{ReferenceCategory: "SECURITY", ReferenceType: "advisory", ReferenceLocator: "https://github.com/advisories/GHSA-abcd-efgh-ijkl", Comment: "GHSA-abcd-efgh-ijkl; severity: high; fixed in: v1.2.6; affected symbols are referenced"},
+				},
+			},
+			{
+				Name:             "left-pad",
+				SPDXID:           "SPDXRef-Package-2",
+				VersionInfo:      "1.3.0",
+				DownloadLocation: "NOASSERTION",
+				ExternalRefs: []spdxExternalRef{
+					{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: "pkg:npm/left-pad@1.3.0"},
+				},
+			},
+		},
+		Relationships: []spdxRelationship{
+			{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: "SPDXRef-Repository"},
+			{SPDXElementID: "SPDXRef-Repository", RelationshipType: "DEPENDS_ON", RelatedSPDXElement: "SPDXRef-Package-1"},
+			{SPDXElementID: "SPDXRef-Repository", RelationshipType: "DEPENDS_ON", RelatedSPDXElement: "SPDXRef-Package-2"},
+		},
+	}
+	if diff := cmp.Diff(expectedDocument, document); diff != "" {
+		t.Errorf("unexpected document (-want +got):\n%s", diff)
+	}
+}
+
+func TestEncodeUnknownFormat(t *testing.T) {
+	if _, err := Encode(shared.SBOMFormat("XML"), testSBOM); err == nil {
+		t.Fatalf("expected error encoding SBOM in an unknown format")
+	}
+}
@@ -0,0 +1,162 @@
+package sbom
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/shared"
+)
+
+// The following types model the subset of the SPDX 2.3 JSON format that we emit. SPDX
+// has no dedicated vulnerability section, so matched vulnerabilities are attached to the
+// affected packages as security advisory external references.
+//
+// See https://spdx.github.io/spdx-spec/v2.3/.
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name                  string            `json:"name"`
+	SPDXID                string            `json:"SPDXID"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose,omitempty"`
+	ExternalRefs          []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+	Comment           string `json:"comment,omitempty"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+const (
+	spdxDocumentID   = "SPDXRef-DOCUMENT"
+	spdxRepositoryID = "SPDXRef-Repository"
+	spdxNoAssertion  = "NOASSERTION"
+)
+
+func newSPDXDocument(sbom shared.SBOM) spdxDocument {
+	name := fmt.Sprintf("%s@%s", sbom.RepositoryName, sbom.Commit)
+
+	packages := make([]spdxPackage, 0, len(sbom.Packages)+1)
+	packages = append(packages, spdxPackage{
+		Name:                  sbom.RepositoryName,
+		SPDXID:                spdxRepositoryID,
+		VersionInfo:           sbom.Commit,
+		DownloadLocation:      spdxNoAssertion,
+		FilesAnalyzed:         false,
+		PrimaryPackagePurpose: "SOURCE",
+	})
+
+	relationships := make([]spdxRelationship, 0, len(sbom.Packages)+1)
+	relationships = append(relationships, spdxRelationship{
+		SPDXElementID:      spdxDocumentID,
+		RelationshipType:   "DESCRIBES",
+		RelatedSPDXElement: spdxRepositoryID,
+	})
+
+	for i, pkg := range sbom.Packages {
+		id := fmt.Sprintf("SPDXRef-Package-%d", i+1)
+
+		externalRefs := []spdxExternalRef{
+			{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  packageURLFor(pkg).String(),
+			},
+		}
+		for _, vulnerability := range pkg.Vulnerabilities {
+			if locator := advisoryURL(vulnerability.Vulnerability); locator != "" {
+				externalRefs = append(externalRefs, spdxExternalRef{
+					ReferenceCategory: "SECURITY",
+					ReferenceType:     "advisory",
+					ReferenceLocator:  locator,
+					Comment:           spdxAdvisoryComment(vulnerability),
+				})
+			}
+		}
+
+		packages = append(packages, spdxPackage{
+			Name:             pkg.Name,
+			SPDXID:           id,
+			VersionInfo:      pkg.Version,
+			DownloadLocation: spdxNoAssertion,
+			FilesAnalyzed:    false,
+			ExternalRefs:     externalRefs,
+		})
+		relationships = append(relationships, spdxRelationship{
+			SPDXElementID:      spdxRepositoryID,
+			RelationshipType:   "DEPENDS_ON",
+			RelatedSPDXElement: id,
+		})
+	}
+
+	return spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            spdxDocumentID,
+		Name:              name,
+		DocumentNamespace: fmt.Sprintf("%s/%s/-/sbom/spdx/%s", strings.TrimSuffix(sbom.ExternalURL, "/"), sbom.RepositoryName, documentID(sbom)),
+		CreationInfo: spdxCreationInfo{
+			Created:  sbom.CreatedAt.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: Sourcegraph", "Organization: Sourcegraph"},
+		},
+		Packages:      packages,
+		Relationships: relationships,
+	}
+}
+
+// advisoryURL returns a URL describing the given vulnerability, preferring the advisory from
+// the database in which the vulnerability was found.
+func advisoryURL(vulnerability shared.Vulnerability) string {
+	if vulnerability.DataSource != "" {
+		return vulnerability.DataSource
+	}
+	if len(vulnerability.URLs) > 0 {
+		return vulnerability.URLs[0]
+	}
+
+	return ""
+}
+
+func spdxAdvisoryComment(vulnerability shared.SBOMVulnerability) string {
+	parts := []string{vulnerability.Vulnerability.SourceID}
+	if vulnerability.Vulnerability.Severity != "" {
+		parts = append(parts, fmt.Sprintf("severity: %s", strings.ToLower(vulnerability.Vulnerability.Severity)))
+	}
+	if vulnerability.FixedIn != nil {
+		parts = append(parts, fmt.Sprintf("fixed in: %s", *vulnerability.FixedIn))
+	}
+	switch vulnerability.Reachability {
+	case shared.ReachabilityReachable:
+		parts = append(parts, "affected symbols are referenced")
+	case shared.ReachabilityUnreachable:
+		parts = append(parts, "affected symbols are not referenced")
+	}
+
+	return strings.Join(parts, "; ")
+}
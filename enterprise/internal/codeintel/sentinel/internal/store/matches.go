@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"encoding/json"
 	"sort"
 	"strings"
 
@@ -45,6 +46,8 @@ SELECT
 	vas.path,
 	vas.symbols,
 	vul.severity,
+	m.reachable,
+	m.call_sites,
 	0 AS count
 FROM vulnerability_matches m
 LEFT JOIN vulnerability_affected_packages vap ON vap.id = m.vulnerability_affected_package_id
@@ -53,6 +56,45 @@ LEFT JOIN vulnerabilities vul ON vap.vulnerability_id = vul.id
 WHERE m.id = %s
 `
 
+func (s *store) GetVulnerabilityMatchesByUploadIDs(ctx context.Context, uploadIDs ...int) (_ []shared.VulnerabilityMatch, err error) {
+	ctx, _, endObservation := s.operations.getVulnerabilityMatchesByUploadIDs.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("numUploadIDs", len(uploadIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(uploadIDs) == 0 {
+		return nil, nil
+	}
+
+	matches, _, err := scanVulnerabilityMatchesAndCount(s.db.Query(ctx, sqlf.Sprintf(getVulnerabilityMatchesByUploadIDsQuery, pq.Array(uploadIDs))))
+	return matches, err
+}
+
+const getVulnerabilityMatchesByUploadIDsQuery = `
+SELECT
+	m.id,
+	m.upload_id,
+	vap.vulnerability_id,
+	vap.package_name,
+	vap.language,
+	vap.namespace,
+	vap.version_constraint,
+	vap.fixed,
+	vap.fixed_in,
+	vas.path,
+	vas.symbols,
+	vul.severity,
+	m.reachable,
+	m.call_sites,
+	0 AS count
+FROM vulnerability_matches m
+LEFT JOIN vulnerability_affected_packages vap ON vap.id = m.vulnerability_affected_package_id
+LEFT JOIN vulnerability_affected_symbols vas ON vas.vulnerability_affected_package_id = vap.id
+LEFT JOIN vulnerabilities vul ON vap.vulnerability_id = vul.id
+WHERE m.upload_id = ANY(%s)
+ORDER BY m.id, vap.id, vas.id
+`
+
 func (s *store) GetVulnerabilityMatches(ctx context.Context, args shared.GetVulnerabilityMatchesArgs) (_ []shared.VulnerabilityMatch, _ int, err error) {
 	ctx, _, endObservation := s.operations.getVulnerabilityMatches.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
 		otlog.Int("limit", args.Limit),
@@ -60,6 +102,7 @@ func (s *store) GetVulnerabilityMatches(ctx context.Context, args shared.GetVuln
 		otlog.String("severity", args.Severity),
 		otlog.String("language", args.Language),
 		otlog.String("repositoryName", args.RepositoryName),
+		otlog.String("reachability", string(args.Reachability)),
 	}})
 	defer endObservation(1, observation.Args{})
 
@@ -73,6 +116,14 @@ func (s *store) GetVulnerabilityMatches(ctx context.Context, args shared.GetVuln
 	if args.RepositoryName != "" {
 		conds = append(conds, sqlf.Sprintf("r.name = %s", args.RepositoryName))
 	}
+	switch args.Reachability {
+	case shared.ReachabilityReachable:
+		conds = append(conds, sqlf.Sprintf("m.reachable"))
+	case shared.ReachabilityUnreachable:
+		conds = append(conds, sqlf.Sprintf("NOT m.reachable"))
+	case shared.ReachabilityUnknown:
+		conds = append(conds, sqlf.Sprintf("m.reachable IS NULL"))
+	}
 	if len(conds) == 0 {
 		conds = append(conds, sqlf.Sprintf("TRUE"))
 	}
@@ -85,7 +136,9 @@ WITH limited_matches AS (
 	SELECT
 		m.id,
 		m.upload_id,
-		m.vulnerability_affected_package_id
+		m.vulnerability_affected_package_id,
+		m.reachable,
+		m.call_sites
 	FROM vulnerability_matches m
 	ORDER BY id
 )
@@ -102,6 +155,8 @@ SELECT
 	vas.path,
 	vas.symbols,
 	vul.severity,
+	m.reachable,
+	m.call_sites,
 	COUNT(*) OVER() AS count
 FROM limited_matches m
 LEFT JOIN vulnerability_affected_packages vap ON vap.id = m.vulnerability_affected_package_id
@@ -355,13 +410,91 @@ SELECT COUNT(*) FROM ins
 //
 //
 
+func (s *store) GetReachabilityCandidates(ctx context.Context, batchSize int) (_ []shared.ReachabilityCandidate, err error) {
+	ctx, _, endObservation := s.operations.getReachabilityCandidates.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("batchSize", batchSize),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanReachabilityCandidates(s.db.Query(ctx, sqlf.Sprintf(getReachabilityCandidatesQuery, batchSize)))
+}
+
+const getReachabilityCandidatesQuery = `
+WITH candidates AS (
+	SELECT
+		m.id,
+		m.upload_id,
+		m.vulnerability_affected_package_id
+	FROM vulnerability_matches m
+	WHERE m.reachability_checked_at IS NULL
+	ORDER BY m.id
+	LIMIT %s
+)
+SELECT
+	m.id,
+	m.upload_id,
+	r.scheme,
+	r.manager,
+	r.name,
+	r.version,
+	vas.id,
+	vas.path,
+	vas.symbols
+FROM candidates m
+JOIN vulnerability_affected_packages vap ON vap.id = m.vulnerability_affected_package_id
+-- NOTE: This mirrors the (loose) package name condition used in ScanMatches so
+-- that we recover the same package reference that caused the original match.
+JOIN lsif_references r ON r.dump_id = m.upload_id AND r.name LIKE '%%' || vap.package_name || '%%'
+LEFT JOIN vulnerability_affected_symbols vas ON vas.vulnerability_affected_package_id = vap.id
+ORDER BY m.id, r.id, vas.id
+`
+
+func (s *store) UpdateReachability(ctx context.Context, matchID int, reachability shared.Reachability, callSites []shared.CallSite) (err error) {
+	ctx, _, endObservation := s.operations.updateReachability.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("matchID", matchID),
+		otlog.String("reachability", string(reachability)),
+		otlog.Int("numCallSites", len(callSites)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	var reachable *bool
+	if reachability != shared.ReachabilityUnknown {
+		value := reachability == shared.ReachabilityReachable
+		reachable = &value
+	}
+
+	if callSites == nil {
+		callSites = []shared.CallSite{}
+	}
+	serializedCallSites, err := json.Marshal(callSites)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Exec(ctx, sqlf.Sprintf(updateReachabilityQuery, reachable, serializedCallSites, matchID))
+}
+
+const updateReachabilityQuery = `
+UPDATE vulnerability_matches
+SET
+	reachable = %s,
+	call_sites = %s,
+	reachability_checked_at = NOW()
+WHERE id = %s
+`
+
+//
+//
+
 var scanVulnerabilityMatchesAndCount = func(rows basestore.Rows, queryErr error) ([]shared.VulnerabilityMatch, int, error) {
 	matches, totalCount, err := basestore.NewSliceWithCountScanner(func(s dbutil.Scanner) (match shared.VulnerabilityMatch, count int, _ error) {
 		var (
-			vap     shared.AffectedPackage
-			vas     shared.AffectedSymbol
-			vul     shared.Vulnerability
-			fixedIn string
+			vap       shared.AffectedPackage
+			vas       shared.AffectedSymbol
+			vul       shared.Vulnerability
+			fixedIn   string
+			reachable *bool
+			callSites []byte
 		)
 
 		if err := s.Scan(
@@ -378,11 +511,30 @@ var scanVulnerabilityMatchesAndCount = func(rows basestore.Rows, queryErr error)
 			&dbutil.NullString{S: &vas.Path},
 			pq.Array(vas.Symbols),
 			&dbutil.NullString{S: &vul.Severity},
+			&reachable,
+			&callSites,
 			&count,
 		); err != nil {
 			return shared.VulnerabilityMatch{}, 0, err
 		}
 
+		match.Reachability = shared.ReachabilityUnknown
+		if reachable != nil {
+			if *reachable {
+				match.Reachability = shared.ReachabilityReachable
+			} else {
+				match.Reachability = shared.ReachabilityUnreachable
+			}
+		}
+		if len(callSites) > 0 {
+			if err := json.Unmarshal(callSites, &match.CallSites); err != nil {
+				return shared.VulnerabilityMatch{}, 0, err
+			}
+			if len(match.CallSites) == 0 {
+				match.CallSites = nil
+			}
+		}
+
 		if fixedIn != "" {
 			vap.FixedIn = &fixedIn
 		}
@@ -456,3 +608,58 @@ func makeSchemeTtoVulnerabilityLanguageMappingConditions() []*sqlf.Query {
 
 	return mappings
 }
+
+func scanReachabilityCandidates(rows basestore.Rows, queryErr error) (_ []shared.ReachabilityCandidate, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var candidates []shared.ReachabilityCandidate
+	var seenSymbolIDs map[int]struct{}
+	for rows.Next() {
+		var (
+			matchID  int
+			uploadID int
+			pkg      shared.ReachabilityPackage
+			symbolID *int
+			symbol   shared.AffectedSymbol
+		)
+		if err := rows.Scan(
+			&matchID,
+			&uploadID,
+			&pkg.Scheme,
+			&pkg.Manager,
+			&pkg.Name,
+			&dbutil.NullString{S: &pkg.Version},
+			&symbolID,
+			&dbutil.NullString{S: &symbol.Path},
+			pq.Array(&symbol.Symbols),
+		); err != nil {
+			return nil, err
+		}
+
+		// Rows are ordered by match, then by package reference, and each package reference
+		// is repeated for every affected symbol. Fold the rows of a match into a single
+		// candidate with distinct package references and affected symbols.
+		n := len(candidates)
+		if n == 0 || candidates[n-1].MatchID != matchID {
+			candidates = append(candidates, shared.ReachabilityCandidate{MatchID: matchID, UploadID: uploadID})
+			seenSymbolIDs = map[int]struct{}{}
+			n++
+		}
+		candidate := &candidates[n-1]
+
+		if k := len(candidate.Packages); k == 0 || candidate.Packages[k-1] != pkg {
+			candidate.Packages = append(candidate.Packages, pkg)
+		}
+		if symbolID != nil {
+			if _, ok := seenSymbolIDs[*symbolID]; !ok {
+				seenSymbolIDs[*symbolID] = struct{}{}
+				candidate.AffectedSymbols = append(candidate.AffectedSymbols, symbol)
+			}
+		}
+	}
+
+	return candidates, nil
+}
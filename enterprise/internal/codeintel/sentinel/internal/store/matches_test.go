@@ -49,12 +49,43 @@ func TestVulnerabilityMatchByID(t *testing.T) {
 		UploadID:        52,
 		VulnerabilityID: 1,
 		AffectedPackage: badConfig,
+		Reachability:    shared.ReachabilityUnknown,
 	}
 	if diff := cmp.Diff(expectedMatch, match); diff != "" {
 		t.Errorf("unexpected vulnerability match (-want +got):\n%s", diff)
 	}
 }
 
+func TestGetVulnerabilityMatchesByUploadIDs(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	setupReferences(t, db)
+
+	if _, err := store.InsertVulnerabilities(ctx, testVulnerabilities); err != nil {
+		t.Fatalf("unexpected error inserting vulnerabilities: %s", err)
+	}
+
+	if _, _, err := store.ScanMatches(ctx, 100); err != nil {
+		t.Fatalf("unexpected error inserting vulnerabilities: %s", err)
+	}
+
+	matches, err := store.GetVulnerabilityMatchesByUploadIDs(ctx, 51, 52, 53)
+	if err != nil {
+		t.Fatalf("unexpected error getting vulnerability matches: %s", err)
+	}
+
+	expectedMatches := []shared.VulnerabilityMatch{
+		{ID: 2, UploadID: 51, VulnerabilityID: 1, AffectedPackage: badConfig, Reachability: shared.ReachabilityUnknown},
+		{ID: 3, UploadID: 52, VulnerabilityID: 1, AffectedPackage: badConfig, Reachability: shared.ReachabilityUnknown},
+	}
+	if diff := cmp.Diff(expectedMatches, matches); diff != "" {
+		t.Errorf("unexpected vulnerability matches (-want +got):\n%s", diff)
+	}
+}
+
 func TestGetVulnerabilityMatches(t *testing.T) {
 	ctx := context.Background()
 	logger := logtest.Scoped(t)
@@ -389,6 +420,103 @@ func TestGetVulnerabilityMatchesSummaryCount(t *testing.T) {
 	}
 }
 
+func TestReachability(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	setupReferences(t, db)
+
+	affectedSymbols := []shared.AffectedSymbol{
+		{Path: "github.com/go-nacelle/config", Symbols: []string{"Config.Load"}},
+		{Path: "github.com/go-nacelle/config/internal", Symbols: []string{"Parse"}},
+	}
+	badConfigWithSymbols := badConfig
+	badConfigWithSymbols.AffectedSymbols = affectedSymbols
+
+	if _, err := store.InsertVulnerabilities(ctx, []shared.Vulnerability{
+		{ID: 1, SourceID: "CVE-ABC", AffectedPackages: []shared.AffectedPackage{badConfigWithSymbols}},
+	}); err != nil {
+		t.Fatalf("unexpected error inserting vulnerabilities: %s", err)
+	}
+
+	if _, _, err := store.ScanMatches(ctx, 100); err != nil {
+		t.Fatalf("unexpected error scanning matches: %s", err)
+	}
+
+	// A second reference of the first upload also loosely matches the affected package name
+	if err := basestore.NewWithHandle(db.Handle()).Exec(ctx, sqlf.Sprintf(`
+		INSERT INTO lsif_references (scheme, name, version, dump_id)
+		VALUES ('gomod', 'github.com/go-nacelle/config/v2', 'v2.0.0', 50)
+	`)); err != nil {
+		t.Fatalf("failed to insert references: %s", err)
+	}
+
+	candidates, err := store.GetReachabilityCandidates(ctx, 100)
+	if err != nil {
+		t.Fatalf("unexpected error getting reachability candidates: %s", err)
+	}
+
+	pkg := func(name, version string) shared.ReachabilityPackage {
+		return shared.ReachabilityPackage{Scheme: "gomod", Name: name, Version: version}
+	}
+	expectedCandidates := []shared.ReachabilityCandidate{
+		{MatchID: 1, UploadID: 50, Packages: []shared.ReachabilityPackage{pkg("github.com/go-nacelle/config", "v1.2.3"), pkg("github.com/go-nacelle/config/v2", "v2.0.0")}, AffectedSymbols: affectedSymbols},
+		{MatchID: 2, UploadID: 51, Packages: []shared.ReachabilityPackage{pkg("github.com/go-nacelle/config", "v1.2.4")}, AffectedSymbols: affectedSymbols},
+		{MatchID: 3, UploadID: 52, Packages: []shared.ReachabilityPackage{pkg("github.com/go-nacelle/config", "v1.2.5")}, AffectedSymbols: affectedSymbols},
+	}
+	if diff := cmp.Diff(expectedCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected reachability candidates (-want +got):\n%s", diff)
+	}
+
+	callSites := []shared.CallSite{
+		{Path: "main.go", Symbol: "scip-go gomod github.com/go-nacelle/config v1.2.3 `github.com/go-nacelle/config`/Config#Load().", StartLine: 10, StartCharacter: 4, EndLine: 10, EndCharacter: 8},
+	}
+	if err := store.UpdateReachability(ctx, 1, shared.ReachabilityReachable, callSites); err != nil {
+		t.Fatalf("unexpected error updating reachability: %s", err)
+	}
+	if err := store.UpdateReachability(ctx, 2, shared.ReachabilityUnreachable, nil); err != nil {
+		t.Fatalf("unexpected error updating reachability: %s", err)
+	}
+
+	candidates, err = store.GetReachabilityCandidates(ctx, 100)
+	if err != nil {
+		t.Fatalf("unexpected error getting reachability candidates: %s", err)
+	}
+	if diff := cmp.Diff(expectedCandidates[2:], candidates); diff != "" {
+		t.Errorf("unexpected reachability candidates (-want +got):\n%s", diff)
+	}
+
+	for _, testCase := range []struct {
+		reachability      shared.Reachability
+		expectedMatchID   int
+		expectedCallSites []shared.CallSite
+	}{
+		{shared.ReachabilityReachable, 1, callSites},
+		{shared.ReachabilityUnreachable, 2, nil},
+		{shared.ReachabilityUnknown, 3, nil},
+	} {
+		matches, _, err := store.GetVulnerabilityMatches(ctx, shared.GetVulnerabilityMatchesArgs{Limit: 10, Reachability: testCase.reachability})
+		if err != nil {
+			t.Fatalf("unexpected error getting vulnerability matches: %s", err)
+		}
+		if len(matches) != 1 {
+			t.Fatalf("unexpected number of %s matches. want=%d have=%d", testCase.reachability, 1, len(matches))
+		}
+
+		if matches[0].ID != testCase.expectedMatchID {
+			t.Errorf("unexpected %s match. want=%d have=%d", testCase.reachability, testCase.expectedMatchID, matches[0].ID)
+		}
+		if matches[0].Reachability != testCase.reachability {
+			t.Errorf("unexpected reachability. want=%s have=%s", testCase.reachability, matches[0].Reachability)
+		}
+		if diff := cmp.Diff(testCase.expectedCallSites, matches[0].CallSites); diff != "" {
+			t.Errorf("unexpected call sites (-want +got):\n%s", diff)
+		}
+	}
+}
+
 func setupReferences(t *testing.T, db database.DB) {
 	store := basestore.NewWithHandle(db.Handle())
 
@@ -14,9 +14,12 @@ type operations struct {
 	insertVulnerabilities                    *observation.Operation
 	vulnerabilityMatchByID                   *observation.Operation
 	getVulnerabilityMatches                  *observation.Operation
+	getVulnerabilityMatchesByUploadIDs       *observation.Operation
 	getVulnerabilityMatchesSummaryCount      *observation.Operation
 	getVulnerabilityMatchesCountByRepository *observation.Operation
 	scanMatches                              *observation.Operation
+	getReachabilityCandidates                *observation.Operation
+	updateReachability                       *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -46,8 +49,11 @@ func newOperations(observationCtx *observation.Context) *operations {
 		insertVulnerabilities:                    op("InsertVulnerabilities"),
 		vulnerabilityMatchByID:                   op("VulnerabilityMatchByID"),
 		getVulnerabilityMatches:                  op("GetVulnerabilityMatches"),
+		getVulnerabilityMatchesByUploadIDs:       op("GetVulnerabilityMatchesByUploadIDs"),
 		getVulnerabilityMatchesSummaryCount:      op("GetVulnerabilityMatchesSummaryCount"),
 		getVulnerabilityMatchesCountByRepository: op("GetVulnerabilityMatchesCountByRepository"),
 		scanMatches:                              op("ScanMatches"),
+		getReachabilityCandidates:                op("GetReachabilityCandidates"),
+		updateReachability:                       op("UpdateReachability"),
 	}
 }
@@ -21,9 +21,14 @@ type Store interface {
 	// Vulnerability matches
 	VulnerabilityMatchByID(ctx context.Context, id int) (shared.VulnerabilityMatch, bool, error)
 	GetVulnerabilityMatches(ctx context.Context, args shared.GetVulnerabilityMatchesArgs) ([]shared.VulnerabilityMatch, int, error)
+	GetVulnerabilityMatchesByUploadIDs(ctx context.Context, uploadIDs ...int) ([]shared.VulnerabilityMatch, error)
 	GetVulnerabilityMatchesSummaryCount(ctx context.Context) (counts shared.GetVulnerabilityMatchesSummaryCounts, err error)
 	GetVulnerabilityMatchesCountByRepository(ctx context.Context, args shared.GetVulnerabilityMatchesCountByRepositoryArgs) (_ []shared.VulnerabilityMatchesByRepository, _ int, err error)
 	ScanMatches(ctx context.Context, batchSize int) (numReferencesScanned int, numVulnerabilityMatches int, _ error)
+
+	// Vulnerability match reachability
+	GetReachabilityCandidates(ctx context.Context, batchSize int) ([]shared.ReachabilityCandidate, error)
+	UpdateReachability(ctx context.Context, matchID int, reachability shared.Reachability, callSites []shared.CallSite) error
 }
 
 type store struct {
@@ -2,23 +2,37 @@ package sentinel
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/internal/lsifstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/internal/sbom"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/internal/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/sentinel/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
 type Service struct {
 	store      store.Store
+	lsifstore  lsifstore.Store
+	uploadSvc  UploadService
 	operations *operations
 }
 
 func newService(
 	observationCtx *observation.Context,
 	store store.Store,
+	lsifstore lsifstore.Store,
+	uploadSvc UploadService,
 ) *Service {
 	return &Service{
 		store:      store,
+		lsifstore:  lsifstore,
+		uploadSvc:  uploadSvc,
 		operations: newOperations(observationCtx),
 	}
 }
@@ -50,3 +64,143 @@ func (s *Service) GetVulnerabilityMatchesSummaryCounts(ctx context.Context) (sha
 func (s *Service) GetVulnerabilityMatchesCountByRepository(ctx context.Context, args shared.GetVulnerabilityMatchesCountByRepositoryArgs) ([]shared.VulnerabilityMatchesByRepository, int, error) {
 	return s.store.GetVulnerabilityMatchesCountByRepository(ctx, args)
 }
+
+// GetSBOM returns a software bill of materials in the given format describing the packages referenced
+// by the precise code intelligence indexes visible from the given commit. Each package is annotated with
+// the vulnerabilities matched against the index that references it. If there are no indexes visible from
+// the given commit, a false-valued flag is returned.
+func (s *Service) GetSBOM(ctx context.Context, repositoryID int, commit string, format shared.SBOMFormat) (_ []byte, _ bool, err error) {
+	ctx, _, endObservation := s.operations.getSBOM.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.String("commit", commit),
+		log.String("format", string(format)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	dumps, err := s.uploadSvc.InferClosestUploads(ctx, repositoryID, commit, "", false, "")
+	if err != nil || len(dumps) == 0 {
+		return nil, false, err
+	}
+
+	uploadIDs := make([]int, 0, len(dumps))
+	for _, dump := range dumps {
+		uploadIDs = append(uploadIDs, dump.ID)
+	}
+
+	references, err := s.readPackageReferences(ctx, uploadIDs)
+	if err != nil {
+		return nil, false, err
+	}
+
+	vulnerabilitiesByUploadID, err := s.getMatchedVulnerabilitiesByUploadID(ctx, uploadIDs)
+	if err != nil {
+		return nil, false, err
+	}
+
+	packages := make([]shared.SBOMPackage, 0, len(references))
+	for _, reference := range references {
+		pkg := shared.SBOMPackage{
+			Scheme:  reference.Scheme,
+			Manager: reference.Manager,
+			Name:    reference.Name,
+			Version: reference.Version,
+		}
+
+		for _, vulnerability := range vulnerabilitiesByUploadID[reference.DumpID] {
+			// NOTE: This mirrors the (loose) package name condition used when matching
+			// vulnerabilities against the package references of an upload.
+			if strings.Contains(reference.Name, vulnerability.packageName) {
+				pkg.Vulnerabilities = append(pkg.Vulnerabilities, vulnerability.SBOMVulnerability)
+			}
+		}
+
+		packages = append(packages, pkg)
+	}
+
+	serialized, err := sbom.Encode(format, shared.SBOM{
+		ExternalURL:    conf.ExternalURL(),
+		RepositoryName: dumps[0].RepositoryName,
+		Commit:         commit,
+		CreatedAt:      time.Now(),
+		Packages:       packages,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return serialized, true, nil
+}
+
+func (s *Service) readPackageReferences(ctx context.Context, uploadIDs []int) (references []uploadsshared.PackageReference, err error) {
+	for _, uploadID := range uploadIDs {
+		scanner, err := s.uploadSvc.ReferencesForUpload(ctx, uploadID)
+		if err != nil {
+			return nil, err
+		}
+
+		for {
+			reference, exists, err := scanner.Next()
+			if err != nil {
+				_ = scanner.Close()
+				return nil, err
+			}
+			if !exists {
+				break
+			}
+
+			references = append(references, reference)
+		}
+
+		if err := scanner.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	return references, nil
+}
+
+type matchedVulnerability struct {
+	shared.SBOMVulnerability
+	packageName string
+}
+
+func (s *Service) getMatchedVulnerabilitiesByUploadID(ctx context.Context, uploadIDs []int) (map[int][]matchedVulnerability, error) {
+	matches, err := s.store.GetVulnerabilityMatchesByUploadIDs(ctx, uploadIDs...)
+	if err != nil || len(matches) == 0 {
+		return nil, err
+	}
+
+	vulnerabilityIDs := make([]int, 0, len(matches))
+	for _, match := range matches {
+		vulnerabilityIDs = append(vulnerabilityIDs, match.VulnerabilityID)
+	}
+
+	vulnerabilities, err := s.store.GetVulnerabilitiesByIDs(ctx, vulnerabilityIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	vulnerabilitiesByID := make(map[int]shared.Vulnerability, len(vulnerabilities))
+	for _, vulnerability := range vulnerabilities {
+		vulnerabilitiesByID[vulnerability.ID] = vulnerability
+	}
+
+	vulnerabilitiesByUploadID := map[int][]matchedVulnerability{}
+	for _, match := range matches {
+		vulnerability, ok := vulnerabilitiesByID[match.VulnerabilityID]
+		if !ok {
+			continue
+		}
+
+		vulnerabilitiesByUploadID[match.UploadID] = append(vulnerabilitiesByUploadID[match.UploadID], matchedVulnerability{
+			SBOMVulnerability: shared.SBOMVulnerability{
+				Vulnerability: vulnerability,
+				FixedIn:       match.AffectedPackage.FixedIn,
+				Reachability:  match.Reachability,
+			},
+			packageName: match.AffectedPackage.PackageName,
+		})
+	}
+
+	return vulnerabilitiesByUploadID, nil
+}
@@ -49,6 +49,46 @@ type VulnerabilityMatch struct {
 	UploadID        int
 	VulnerabilityID int
 	AffectedPackage AffectedPackage
+	Reachability    Reachability
+	CallSites       []CallSite
+}
+
+// Reachability describes whether or not an upload references any of the symbols
+// affected by a vulnerability it has been matched against by package name and version.
+type Reachability string
+
+const (
+	ReachabilityUnknown     Reachability = "UNKNOWN"
+	ReachabilityReachable   Reachability = "REACHABLE"
+	ReachabilityUnreachable Reachability = "UNREACHABLE"
+)
+
+// CallSite is a location within an upload that references a symbol affected by a vulnerability.
+type CallSite struct {
+	Path           string `json:"path"`
+	Symbol         string `json:"symbol"`
+	StartLine      int    `json:"startLine"`
+	StartCharacter int    `json:"startCharacter"`
+	EndLine        int    `json:"endLine"`
+	EndCharacter   int    `json:"endCharacter"`
+}
+
+// ReachabilityCandidate is a vulnerability match whose reachability has not yet been
+// determined, along with the package references that caused the match.
+type ReachabilityCandidate struct {
+	MatchID         int
+	UploadID        int
+	Packages        []ReachabilityPackage
+	AffectedSymbols []AffectedSymbol
+}
+
+// ReachabilityPackage is a package referenced by an upload that matches the name of
+// the package affected by a vulnerability.
+type ReachabilityPackage struct {
+	Scheme  string
+	Manager string
+	Name    string
+	Version string
 }
 
 type GetVulnerabilitiesArgs struct {
@@ -62,6 +102,7 @@ type GetVulnerabilityMatchesArgs struct {
 	Severity       string
 	Language       string
 	RepositoryName string
+	Reachability   Reachability
 }
 
 type GetVulnerabilityMatchesSummaryCounts struct {
@@ -83,3 +124,37 @@ type VulnerabilityMatchesByRepository struct {
 	RepositoryName string
 	MatchCount     int32
 }
+
+// SBOMFormat is the serialization format of a software bill of materials.
+type SBOMFormat string
+
+const (
+	SBOMFormatCycloneDX SBOMFormat = "CYCLONEDX"
+	SBOMFormatSPDX      SBOMFormat = "SPDX"
+)
+
+// SBOM describes the set of packages referenced by the precise code intelligence
+// indexes visible from a particular commit of a repository.
+type SBOM struct {
+	ExternalURL    string
+	RepositoryName string
+	Commit         string
+	CreatedAt      time.Time
+	Packages       []SBOMPackage
+}
+
+// SBOMPackage is a package referenced by an index, along with the vulnerabilities
+// that have been matched against the index by the package's name and version.
+type SBOMPackage struct {
+	Scheme          string
+	Manager         string
+	Name            string
+	Version         string
+	Vulnerabilities []SBOMVulnerability
+}
+
+type SBOMVulnerability struct {
+	Vulnerability Vulnerability
+	FixedIn       *string
+	Reachability  Reachability
+}
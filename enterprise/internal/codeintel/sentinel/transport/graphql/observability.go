@@ -14,6 +14,7 @@ type operations struct {
 	vulnerabilityMatchByID                *observation.Operation
 	vulnerabilityMatchesSummaryCounts     *observation.Operation
 	vulnerabilityMatchesCountByRepository *observation.Operation
+	sbom                                  *observation.Operation
 }
 
 func newOperations(observationCtx *observation.Context) *operations {
@@ -39,5 +40,6 @@ func newOperations(observationCtx *observation.Context) *operations {
 		vulnerabilityMatchByID:                op("VulnerabilityMatchByID"),
 		vulnerabilityMatchesSummaryCounts:     op("VulnerabilityMatchesSummaryCounts"),
 		vulnerabilityMatchesCountByRepository: op("VulnerabilityMatchesCountByRepository"),
+		sbom:                                  op("SBOM"),
 	}
 }
@@ -10,9 +10,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/resolvers/dataloader"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/resolvers/gitresolvers"
 	uploadsgraphql "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/transport/graphql"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type rootResolver struct {
@@ -96,12 +98,18 @@ func (r *rootResolver) VulnerabilityMatches(ctx context.Context, args resolverst
 		repositoryName = *args.RepositoryName
 	}
 
+	var reachability shared.Reachability
+	if args.Reachability != nil {
+		reachability = shared.Reachability(*args.Reachability)
+	}
+
 	matches, totalCount, err := r.sentinelSvc.GetVulnerabilityMatches(ctx, shared.GetVulnerabilityMatchesArgs{
 		Limit:          int(limit),
 		Offset:         int(offset),
 		Language:       language,
 		Severity:       severity,
 		RepositoryName: repositoryName,
+		Reachability:   reachability,
 	})
 	if err != nil {
 		return nil, err
@@ -163,6 +171,33 @@ func (r *rootResolver) VulnerabilityMatchesCountByRepository(ctx context.Context
 	return resolverstubs.NewTotalCountConnectionResolver(resolvers, offset, int32(totalCount)), nil
 }
 
+func (r *rootResolver) SBOM(ctx context.Context, args resolverstubs.SBOMArgs) (_ *string, err error) {
+	ctx, _, endObservation := r.operations.sbom.WithErrors(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("repository", string(args.Repository)),
+		log.String("commit", args.Commit),
+		log.String("format", args.Format),
+	}})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	repositoryID, err := resolverstubs.UnmarshalID[api.RepoID](args.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	format := shared.SBOMFormat(args.Format)
+	if format != shared.SBOMFormatCycloneDX && format != shared.SBOMFormatSPDX {
+		return nil, errors.Newf("unknown SBOM format %q", args.Format)
+	}
+
+	document, ok, err := r.sentinelSvc.GetSBOM(ctx, int(repositoryID), args.Commit, format)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	serialized := string(document)
+	return &serialized, nil
+}
+
 func (r *rootResolver) VulnerabilityByID(ctx context.Context, vulnerabilityID graphql.ID) (_ resolverstubs.VulnerabilityResolver, err error) {
 	ctx, _, endObservation := r.operations.vulnerabilityByID.WithErrors(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.String("vulnerabilityID", string(vulnerabilityID)),
@@ -369,6 +404,30 @@ func (r *vulnerabilityMatchResolver) PreciseIndex(ctx context.Context) (resolver
 	return r.preciseIndexResolverFactory.Create(ctx, r.prefetcher, r.locationResolver, r.errTracer, &upload, nil)
 }
 
+func (r *vulnerabilityMatchResolver) Reachability() string {
+	return string(r.m.Reachability)
+}
+
+func (r *vulnerabilityMatchResolver) CallSites() []resolverstubs.VulnerabilityCallSiteResolver {
+	var resolvers []resolverstubs.VulnerabilityCallSiteResolver
+	for _, callSite := range r.m.CallSites {
+		resolvers = append(resolvers, &vulnerabilityCallSiteResolver{callSite})
+	}
+
+	return resolvers
+}
+
+type vulnerabilityCallSiteResolver struct {
+	c shared.CallSite
+}
+
+func (r *vulnerabilityCallSiteResolver) Path() string          { return r.c.Path }
+func (r *vulnerabilityCallSiteResolver) Symbol() string        { return r.c.Symbol }
+func (r *vulnerabilityCallSiteResolver) StartLine() int32      { return int32(r.c.StartLine) }
+func (r *vulnerabilityCallSiteResolver) StartCharacter() int32 { return int32(r.c.StartCharacter) }
+func (r *vulnerabilityCallSiteResolver) EndLine() int32        { return int32(r.c.EndLine) }
+func (r *vulnerabilityCallSiteResolver) EndCharacter() int32   { return int32(r.c.EndCharacter) }
+
 //
 //
 
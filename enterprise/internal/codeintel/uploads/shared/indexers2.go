@@ -20,6 +20,7 @@ var allIndexers = []CodeIntelIndexer{
 	makeInternalIndexer("C++", "lsif-cpp"),
 
 	// Dart
+	makeIndexer("Dart", "scip-dart", "github.com/Workiva/scip-dart", "workiva/scip-dart"),
 	makeInternalIndexer("Dart", "lsif-dart"),
 	makeIndexer("Dart", "lsif_indexer", "github.com/Workiva/lsif_indexer"),
 
@@ -45,6 +46,7 @@ var allIndexers = []CodeIntelIndexer{
 	makeIndexer("OCaml", "lsif-ocaml", "github.com/rvantonder/lsif-ocaml"),
 
 	// PHP
+	makeIndexer("PHP", "scip-php", "github.com/davidrjenni/scip-php", "davidrjenni/scip-php"),
 	makeIndexer("PHP", "lsif-php", "github.com/davidrjenni/lsif-php", "davidrjenni/lsif-php"),
 
 	// Python
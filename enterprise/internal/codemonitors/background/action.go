@@ -1,8 +1,11 @@
 package background
 
 import (
+	"fmt"
 	"net/url"
+	"strings"
 
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -17,5 +20,92 @@ type actionArgs struct {
 
 	Query          string
 	Results        []*result.CommitMatch
+	FileResults    []*result.FileMatch
 	IncludeResults bool
+
+	// DigestPeriod is "hour" or "day" if the results are a digest of the
+	// trigger events of the past hour or day, and empty otherwise.
+	DigestPeriod string
+}
+
+// digestPeriod returns the period that a digest of an action with the given
+// delivery schedule covers, or an empty string if the action isn't a digest.
+func digestPeriod(deliverySchedule string) string {
+	switch deliverySchedule {
+	case edb.DeliveryScheduleHourly:
+		return "hour"
+	case edb.DeliveryScheduleDaily:
+		return "day"
+	default:
+		return ""
+	}
+}
+
+// matches returns the commit and file results of a code monitor as a single list
+// of matches.
+func (a actionArgs) matches() result.Matches {
+	matches := make(result.Matches, 0, len(a.Results)+len(a.FileResults))
+	for _, res := range a.Results {
+		matches = append(matches, res)
+	}
+	for _, res := range a.FileResults {
+		matches = append(matches, res)
+	}
+	return matches
+}
+
+// matchType returns the kind of a match for display in notifications.
+func matchType(match result.Match) string {
+	switch m := match.(type) {
+	case *result.CommitMatch:
+		if m.DiffPreview != nil {
+			return "Diff"
+		}
+		return "Message"
+	case *result.FileMatch:
+		switch {
+		case len(m.ChunkMatches) > 0:
+			return "Content"
+		case len(m.Symbols) > 0:
+			return "Symbol"
+		default:
+			return "Path"
+		}
+	default:
+		panic(fmt.Sprintf("unexpected match type %T", match))
+	}
+}
+
+// matchURL returns the URL of the commit or file of a match.
+func matchURL(externalURL *url.URL, match result.Match, utmSource string) string {
+	switch m := match.(type) {
+	case *result.CommitMatch:
+		return getCommitURL(externalURL, string(m.Repo.Name), string(m.Commit.ID), utmSource)
+	case *result.FileMatch:
+		return getFileURL(externalURL, string(m.Repo.Name), string(m.CommitID), m.Path, utmSource)
+	default:
+		panic(fmt.Sprintf("unexpected match type %T", match))
+	}
+}
+
+// fileMatchContent returns the matched lines of a file match, or the matched
+// symbols if it is a symbol match. Chunks of matched lines are separated by a
+// newline.
+func fileMatchContent(fm *result.FileMatch) string {
+	switch {
+	case len(fm.ChunkMatches) > 0:
+		contents := make([]string, 0, len(fm.ChunkMatches))
+		for _, chunk := range fm.ChunkMatches {
+			contents = append(contents, chunk.Content)
+		}
+		return strings.Join(contents, "\n")
+	case len(fm.Symbols) > 0:
+		symbols := make([]string, 0, len(fm.Symbols))
+		for _, sym := range fm.Symbols {
+			symbols = append(symbols, fmt.Sprintf("%s %s", sym.Symbol.Kind, sym.Symbol.Name))
+		}
+		return strings.Join(symbols, "\n")
+	default:
+		return fm.Path
+	}
 }
@@ -32,6 +32,9 @@ func SendEmailForNewSearchResult(ctx context.Context, db database.DB, userID int
 	if MockSendEmailForNewSearchResult != nil {
 		return MockSendEmailForNewSearchResult(ctx, db, userID, data)
 	}
+	if data.DigestPeriod != "" {
+		return sendEmail(ctx, db, userID, digestEmailTemplates, data)
+	}
 	return sendEmail(ctx, db, userID, newSearchResultsEmailTemplates, data)
 }
 
@@ -41,6 +44,12 @@ var (
 
 	//go:embed email_template.txt.tmpl
 	textTemplate string
+
+	//go:embed email_digest_template.html.tmpl
+	digestHTMLTemplate string
+
+	//go:embed email_digest_template.txt.tmpl
+	digestTextTemplate string
 )
 
 var newSearchResultsEmailTemplates = txemail.MustValidate(txtypes.Templates{
@@ -49,6 +58,12 @@ var newSearchResultsEmailTemplates = txemail.MustValidate(txtypes.Templates{
 	HTML:    htmlTemplate,
 })
 
+var digestEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `{{.Priority}}Sourcegraph code monitor {{.Description}} detected {{.TotalCount}} new {{.ResultPluralized}} in the past {{.DigestPeriod}}`,
+	Text:    digestTextTemplate,
+	HTML:    digestHTMLTemplate,
+})
+
 type TemplateDataNewSearchResults struct {
 	Priority                  string
 	CodeMonitorURL            string
@@ -62,6 +77,10 @@ type TemplateDataNewSearchResults struct {
 	TruncatedResultPluralized string
 	DisplayMoreLink           bool
 	IsTest                    bool
+
+	// DigestPeriod is "hour" or "day" if the email is a digest of the
+	// trigger events of the past hour or day.
+	DigestPeriod string
 }
 
 func NewTemplateDataForNewSearchResults(args actionArgs, email *edb.EmailAction) (d *TemplateDataNewSearchResults, err error) {
@@ -78,7 +97,7 @@ func NewTemplateDataForNewSearchResults(args actionArgs, email *edb.EmailAction)
 		priority = ""
 	}
 
-	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.matches(), 5)
 
 	displayResults := make([]*DisplayResult, len(truncatedResults))
 	for i, result := range truncatedResults {
@@ -97,6 +116,7 @@ func NewTemplateDataForNewSearchResults(args actionArgs, email *edb.EmailAction)
 		ResultPluralized:          pluralize("result", totalCount),
 		TruncatedResultPluralized: pluralize("result", truncatedCount),
 		DisplayMoreLink:           args.IncludeResults && truncatedCount > 0,
+		DigestPeriod:              args.DigestPeriod,
 	}, nil
 }
 
@@ -155,6 +175,10 @@ func getCommitURL(externalURL *url.URL, repoName, oid, utmSource string) string
 	return sourcegraphURL(externalURL, fmt.Sprintf("%s/-/commit/%s", repoName, oid), "", utmSource)
 }
 
+func getFileURL(externalURL *url.URL, repoName, oid, path, utmSource string) string {
+	return sourcegraphURL(externalURL, fmt.Sprintf("%s@%s/-/blob/%s", repoName, oid, path), "", utmSource)
+}
+
 var (
 	externalURLOnce  sync.Once
 	externalURLValue *url.URL
@@ -202,21 +226,23 @@ type DisplayResult struct {
 	CommitURL  string
 	RepoName   string
 	CommitID   string
+	Path       string
 	Content    string
 }
 
-func toDisplayResult(result *searchresult.CommitMatch, externalURL *url.URL) *DisplayResult {
-	resultType := "Message"
-	if result.DiffPreview != nil {
-		resultType = "Diff"
+func toDisplayResult(result searchresult.Match, externalURL *url.URL) *DisplayResult {
+	d := &DisplayResult{
+		ResultType: matchType(result),
+		CommitURL:  matchURL(externalURL, result, utmSourceEmail),
+		RepoName:   string(result.RepoName().Name),
+		Content:    truncateMatchContent(result),
 	}
-
-	content := truncateMatchContent(result)
-	return &DisplayResult{
-		ResultType: resultType,
-		CommitURL:  getCommitURL(externalURL, string(result.Repo.Name), string(result.Commit.ID), utmSourceEmail),
-		RepoName:   string(result.Repo.Name),
-		CommitID:   result.Commit.ID.Short(),
-		Content:    content,
+	switch m := result.(type) {
+	case *searchresult.CommitMatch:
+		d.CommitID = m.Commit.ID.Short()
+	case *searchresult.FileMatch:
+		d.CommitID = m.CommitID.Short()
+		d.Path = m.Path
 	}
+	return d
 }
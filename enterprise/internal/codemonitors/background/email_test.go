@@ -186,3 +186,43 @@ func TestEmail(t *testing.T) {
 	})
 
 }
+
+func TestDigestEmail(t *testing.T) {
+	template := txemail.MustParseTemplate(digestEmailTemplates)
+
+	templateData := &TemplateDataNewSearchResults{
+		Priority:                  "",
+		CodeMonitorURL:            "https://sourcegraph.com/your/code/monitor",
+		SearchURL:                 "https://sourcegraph.com/search",
+		Description:               "My test monitor",
+		TotalCount:                6,
+		TruncatedCount:            1,
+		ResultPluralized:          "results",
+		IncludeResults:            true,
+		TruncatedResults:          []*DisplayResult{diffDisplayResultMock, commitDisplayResultMock, diffDisplayResultMock},
+		TruncatedResultPluralized: "result",
+		DisplayMoreLink:           true,
+		DigestPeriod:              "hour",
+	}
+
+	t.Run("html", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := template.Html.Execute(&buf, templateData)
+		require.NoError(t, err)
+		autogold.ExpectFile(t, autogold.Raw(buf.String()))
+	})
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := template.Text.Execute(&buf, templateData)
+		require.NoError(t, err)
+		autogold.ExpectFile(t, autogold.Raw(buf.String()))
+	})
+
+	t.Run("subject", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := template.Subj.Execute(&buf, templateData)
+		require.NoError(t, err)
+		require.Equal(t, "Sourcegraph code monitor My test monitor detected 6 new results in the past hour", buf.String())
+	})
+}
@@ -0,0 +1,254 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// trackedIssue is an issue on a code host that tracks the events of a code
+// monitor.
+type trackedIssue struct {
+	ID     string
+	URL    string
+	Closed bool
+}
+
+// errIssueNotFound is returned by issueTrackers when an issue doesn't exist
+// anymore.
+var errIssueNotFound = errors.New("issue not found")
+
+// issueTracker files issues and comments on them in a repository on a code host.
+type issueTracker interface {
+	// GetIssue returns the issue with the given ID, or errIssueNotFound if it
+	// doesn't exist anymore.
+	GetIssue(ctx context.Context, id string) (*trackedIssue, error)
+	CreateIssue(ctx context.Context, title, body string) (*trackedIssue, error)
+	CreateIssueComment(ctx context.Context, issue *trackedIssue, body string) error
+}
+
+// fileIssue comments on the tracking issue of an issue action with the results of
+// a code monitor event. If the action doesn't have a tracking issue yet, or if the
+// tracking issue has been closed or deleted, a new issue is opened instead. It
+// returns the issue that tracks the events of the monitor.
+func fileIssue(ctx context.Context, tracker issueTracker, action *edb.IssueAction, args actionArgs) (*trackedIssue, error) {
+	body := issueBody(args)
+
+	if action.IssueID != nil {
+		issue, err := tracker.GetIssue(ctx, *action.IssueID)
+		if err != nil && !errors.Is(err, errIssueNotFound) {
+			return nil, errors.Wrap(err, "getting tracking issue")
+		}
+		if err == nil && !issue.Closed {
+			if err := tracker.CreateIssueComment(ctx, issue, body); err != nil {
+				return nil, errors.Wrap(err, "commenting on tracking issue")
+			}
+			return issue, nil
+		}
+	}
+
+	issue, err := tracker.CreateIssue(ctx, issueTitle(args), body)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating tracking issue")
+	}
+	return issue, nil
+}
+
+func issueTitle(args actionArgs) string {
+	return fmt.Sprintf("Sourcegraph code monitor: %s", args.MonitorDescription)
+}
+
+// issueBody renders the Markdown body of an issue or issue comment for the
+// results of a code monitor event.
+func issueBody(args actionArgs) string {
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.matches(), 5)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s's Sourcegraph code monitor, **%s**, detected **%d** new matches.\n\n",
+		args.MonitorOwnerName,
+		args.MonitorDescription,
+		totalCount,
+	)
+
+	if args.IncludeResults {
+		for _, result := range truncatedResults {
+			fmt.Fprintf(&b, "%s match: [%s](%s)\n\n",
+				matchType(result),
+				matchLabel(result),
+				matchURL(args.ExternalURL, result, args.UTMSource),
+			)
+			fmt.Fprintf(&b, "```\n%s\n```\n\n", strings.TrimSuffix(truncateMatchContent(result), "\n"))
+		}
+		if truncatedCount > 0 {
+			fmt.Fprintf(&b, "...and [%d more matches](%s).\n\n",
+				truncatedCount,
+				getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+			)
+		}
+	} else {
+		fmt.Fprintf(&b, "[View results](%s)\n\n", getSearchURL(args.ExternalURL, args.Query, args.UTMSource))
+	}
+
+	fmt.Fprintf(&b, "If you are %s, you can [edit your code monitor](%s).",
+		args.MonitorOwnerName,
+		getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+	)
+	return b.String()
+}
+
+// newIssueTracker returns an issueTracker for the repository that is
+// authenticated as the external account of the user on the code host of the
+// repository.
+func newIssueTracker(ctx context.Context, db database.DB, userID int32, repo *types.Repo) (issueTracker, error) {
+	switch repo.ExternalRepo.ServiceType {
+	case extsvc.TypeGitHub, extsvc.TypeGitLab:
+	default:
+		return nil, errcode.MakeNonRetryable(errors.Errorf("issues can only be filed in GitHub and GitLab repositories, but %s is hosted on %s", repo.Name, repo.ExternalRepo.ServiceType))
+	}
+
+	accounts, err := db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+		UserID:         userID,
+		ServiceType:    repo.ExternalRepo.ServiceType,
+		ServiceID:      repo.ExternalRepo.ServiceID,
+		ExcludeExpired: true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing external accounts")
+	}
+	if len(accounts) == 0 {
+		return nil, errcode.MakeNonRetryable(errors.Errorf("the code monitor owner has no account connected on %s", repo.ExternalRepo.ServiceID))
+	}
+	account := accounts[0]
+
+	baseURL, err := url.Parse(repo.ExternalRepo.ServiceID)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing code host URL")
+	}
+
+	switch repo.ExternalRepo.ServiceType {
+	case extsvc.TypeGitHub:
+		_, tok, err := github.GetExternalAccountData(ctx, &account.AccountData)
+		if err != nil {
+			return nil, errors.Wrap(err, "get external account data")
+		} else if tok == nil {
+			return nil, errcode.MakeNonRetryable(errors.New("no token found in the external account data"))
+		}
+		token := &auth.OAuthBearerToken{
+			Token:              tok.AccessToken,
+			RefreshToken:       tok.RefreshToken,
+			Expiry:             tok.Expiry,
+			RefreshFunc:        database.GetAccountRefreshAndStoreOAuthTokenFunc(db, account.ID, github.GetOAuthContext(baseURL.String())),
+			NeedsRefreshBuffer: 5,
+		}
+		apiURL, _ := github.APIRoot(baseURL)
+		client := github.NewV4Client(repo.ExternalRepo.ServiceID, apiURL, token, httpcli.ExternalDoer)
+		return &githubIssueTracker{client: client, repositoryID: repo.ExternalRepo.ID}, nil
+
+	default:
+		_, tok, err := gitlab.GetExternalAccountData(ctx, &account.AccountData)
+		if err != nil {
+			return nil, errors.Wrap(err, "get external account data")
+		} else if tok == nil {
+			return nil, errcode.MakeNonRetryable(errors.New("no token found in the external account data"))
+		}
+		token := &auth.OAuthBearerToken{
+			Token:              tok.AccessToken,
+			RefreshToken:       tok.RefreshToken,
+			Expiry:             tok.Expiry,
+			RefreshFunc:        database.GetAccountRefreshAndStoreOAuthTokenFunc(db, account.ID, gitlab.GetOAuthContext(strings.TrimSuffix(baseURL.String(), "/"))),
+			NeedsRefreshBuffer: 5,
+		}
+		projectID, err := strconv.Atoi(repo.ExternalRepo.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing GitLab project ID")
+		}
+		client := gitlab.NewClientProvider(repo.ExternalRepo.ServiceID, baseURL, httpcli.ExternalDoer).NewClient(token)
+		project := &gitlab.Project{ProjectCommon: gitlab.ProjectCommon{ID: projectID}}
+		return &gitlabIssueTracker{client: client, project: project}, nil
+	}
+}
+
+type githubIssueTracker struct {
+	client       *github.V4Client
+	repositoryID string
+}
+
+func (t *githubIssueTracker) GetIssue(ctx context.Context, id string) (*trackedIssue, error) {
+	issue, err := t.client.GetIssue(ctx, id)
+	if err != nil {
+		if github.IsNotFound(err) {
+			return nil, errIssueNotFound
+		}
+		return nil, err
+	}
+	return &trackedIssue{ID: issue.ID, URL: issue.URL, Closed: issue.Closed}, nil
+}
+
+func (t *githubIssueTracker) CreateIssue(ctx context.Context, title, body string) (*trackedIssue, error) {
+	issue, err := t.client.CreateIssue(ctx, t.repositoryID, title, body)
+	if err != nil {
+		return nil, err
+	}
+	return &trackedIssue{ID: issue.ID, URL: issue.URL, Closed: issue.Closed}, nil
+}
+
+func (t *githubIssueTracker) CreateIssueComment(ctx context.Context, issue *trackedIssue, body string) error {
+	return t.client.CreateIssueComment(ctx, &github.Issue{ID: issue.ID}, body)
+}
+
+type gitlabIssueTracker struct {
+	client  *gitlab.Client
+	project *gitlab.Project
+}
+
+func (t *gitlabIssueTracker) GetIssue(ctx context.Context, id string) (*trackedIssue, error) {
+	iid, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing GitLab issue ID")
+	}
+	issue, err := t.client.GetIssue(ctx, t.project, gitlab.ID(iid))
+	if err != nil {
+		if gitlab.IsNotFound(err) {
+			return nil, errIssueNotFound
+		}
+		return nil, err
+	}
+	return gitlabTrackedIssue(issue), nil
+}
+
+func (t *gitlabIssueTracker) CreateIssue(ctx context.Context, title, body string) (*trackedIssue, error) {
+	issue, err := t.client.CreateIssue(ctx, t.project, gitlab.CreateIssueOpts{Title: title, Description: body})
+	if err != nil {
+		return nil, err
+	}
+	return gitlabTrackedIssue(issue), nil
+}
+
+func (t *gitlabIssueTracker) CreateIssueComment(ctx context.Context, issue *trackedIssue, body string) error {
+	iid, err := strconv.ParseInt(issue.ID, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "parsing GitLab issue ID")
+	}
+	return t.client.CreateIssueNote(ctx, t.project, &gitlab.Issue{IID: gitlab.ID(iid)}, body)
+}
+
+func gitlabTrackedIssue(issue *gitlab.Issue) *trackedIssue {
+	return &trackedIssue{
+		ID:     strconv.FormatInt(int64(issue.IID), 10),
+		URL:    issue.WebURL,
+		Closed: issue.State == gitlab.IssueStateClosed,
+	}
+}
@@ -0,0 +1,122 @@
+package background
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+type fakeIssueTracker struct {
+	issues   map[string]*trackedIssue
+	comments map[string][]string
+}
+
+func newFakeIssueTracker() *fakeIssueTracker {
+	return &fakeIssueTracker{issues: map[string]*trackedIssue{}, comments: map[string][]string{}}
+}
+
+func (t *fakeIssueTracker) GetIssue(_ context.Context, id string) (*trackedIssue, error) {
+	issue, ok := t.issues[id]
+	if !ok {
+		return nil, errIssueNotFound
+	}
+	return issue, nil
+}
+
+func (t *fakeIssueTracker) CreateIssue(_ context.Context, _, _ string) (*trackedIssue, error) {
+	id := "new-" + strconv.Itoa(len(t.issues)+1)
+	issue := &trackedIssue{ID: id, URL: "https://example.com/" + id}
+	t.issues[id] = issue
+	return issue, nil
+}
+
+func (t *fakeIssueTracker) CreateIssueComment(_ context.Context, issue *trackedIssue, body string) error {
+	t.comments[issue.ID] = append(t.comments[issue.ID], body)
+	return nil
+}
+
+func TestFileIssue(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	args := actionArgs{
+		MonitorDescription: "My test monitor",
+		MonitorOwnerName:   "Camden Cheek",
+		ExternalURL:        eu,
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+	}
+
+	t.Run("opens an issue without a tracking issue", func(t *testing.T) {
+		tracker := newFakeIssueTracker()
+		issue, err := fileIssue(ctx, tracker, &edb.IssueAction{}, args)
+		require.NoError(t, err)
+		require.Len(t, tracker.issues, 1)
+		require.Equal(t, tracker.issues[issue.ID], issue)
+	})
+
+	t.Run("comments on an open tracking issue", func(t *testing.T) {
+		tracker := newFakeIssueTracker()
+		tracker.issues["1"] = &trackedIssue{ID: "1"}
+		id := "1"
+		issue, err := fileIssue(ctx, tracker, &edb.IssueAction{IssueID: &id}, args)
+		require.NoError(t, err)
+		require.Equal(t, "1", issue.ID)
+		require.Len(t, tracker.issues, 1)
+		require.Len(t, tracker.comments["1"], 1)
+	})
+
+	t.Run("opens an issue if the tracking issue is closed", func(t *testing.T) {
+		tracker := newFakeIssueTracker()
+		tracker.issues["1"] = &trackedIssue{ID: "1", Closed: true}
+		id := "1"
+		issue, err := fileIssue(ctx, tracker, &edb.IssueAction{IssueID: &id}, args)
+		require.NoError(t, err)
+		require.NotEqual(t, "1", issue.ID)
+		require.Len(t, tracker.issues, 2)
+		require.Empty(t, tracker.comments)
+	})
+
+	t.Run("opens an issue if the tracking issue was deleted", func(t *testing.T) {
+		tracker := newFakeIssueTracker()
+		id := "1"
+		issue, err := fileIssue(ctx, tracker, &edb.IssueAction{IssueID: &id}, args)
+		require.NoError(t, err)
+		require.NotEqual(t, "1", issue.ID)
+		require.Len(t, tracker.issues, 1)
+	})
+}
+
+func TestIssueBody(t *testing.T) {
+	t.Parallel()
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	args := actionArgs{
+		MonitorDescription: "My test monitor",
+		MonitorOwnerName:   "Camden Cheek",
+		ExternalURL:        eu,
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+		FileResults:        []*result.FileMatch{&fileResultMock},
+	}
+
+	t.Run("golden", func(t *testing.T) {
+		autogold.ExpectFile(t, autogold.Raw(issueBody(args)))
+	})
+
+	t.Run("golden with results", func(t *testing.T) {
+		argsCopy := args
+		argsCopy.IncludeResults = true
+		autogold.ExpectFile(t, autogold.Raw(issueBody(argsCopy)))
+	})
+}
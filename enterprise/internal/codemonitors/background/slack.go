@@ -25,29 +25,26 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
 	}
 
-	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.matches(), 5)
 
-	blocks := []slack.Block{
-		newMarkdownSection(fmt.Sprintf(
-			"%s's Sourcegraph Code monitor, *%s*, detected *%d* new matches.",
-			args.MonitorOwnerName,
-			args.MonitorDescription,
-			totalCount,
-		)),
+	header := fmt.Sprintf(
+		"%s's Sourcegraph Code monitor, *%s*, detected *%d* new matches",
+		args.MonitorOwnerName,
+		args.MonitorDescription,
+		totalCount,
+	)
+	if args.DigestPeriod != "" {
+		header += fmt.Sprintf(" in the past %s", args.DigestPeriod)
 	}
+	blocks := []slack.Block{newMarkdownSection(header + ".")}
 
 	if args.IncludeResults {
 		for _, result := range truncatedResults {
-			resultType := "Message"
-			if result.DiffPreview != nil {
-				resultType = "Diff"
-			}
 			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
-				"%s match: <%s|%s@%s>",
-				resultType,
-				getCommitURL(args.ExternalURL, string(result.Repo.Name), string(result.Commit.ID), args.UTMSource),
-				result.Repo.Name,
-				result.Commit.ID.Short(),
+				"%s match: <%s|%s>",
+				matchType(result),
+				matchURL(args.ExternalURL, result, args.UTMSource),
+				matchLabel(result),
 			)))
 
 			contentRaw := truncateMatchContent(result)
@@ -87,21 +84,28 @@ func formatCodeBlock(s string) string {
 // We limit the bytes to ensure we don't hit Slack's max block size of 3000
 // characters. To be conservative, we truncate to 2500 bytes. We also limit
 // the number of lines to 10 to ensure the content is easy to read.
-func truncateMatchContent(result *searchresult.CommitMatch) string {
+func truncateMatchContent(result searchresult.Match) string {
 	const maxBytes = 2500
 	const maxLines = 10
 
-	var matchedString *searchresult.MatchedString
-	switch {
-	case result.DiffPreview != nil:
-		matchedString = result.DiffPreview
-	case result.MessagePreview != nil:
-		matchedString = result.MessagePreview
+	var content string
+	switch m := result.(type) {
+	case *searchresult.CommitMatch:
+		switch {
+		case m.DiffPreview != nil:
+			content = m.DiffPreview.Content
+		case m.MessagePreview != nil:
+			content = m.MessagePreview.Content
+		default:
+			panic("exactly one of DiffPreview or MessagePreview must be set")
+		}
+	case *searchresult.FileMatch:
+		content = fileMatchContent(m)
 	default:
-		panic("exactly one of DiffPreview or MessagePreview must be set")
+		panic(fmt.Sprintf("unexpected match type %T", result))
 	}
 
-	splitLines := strings.SplitAfter(matchedString.Content, "\n")
+	splitLines := strings.SplitAfter(content, "\n")
 	limit := len(splitLines)
 	if limit > maxLines {
 		limit = maxLines
@@ -122,24 +126,25 @@ func truncateMatchContent(result *searchresult.CommitMatch) string {
 	return strings.Join(splitLines, "")
 }
 
-func truncateResults(results []*searchresult.CommitMatch, maxResults int) (_ []*searchresult.CommitMatch, totalCount, truncatedCount int) {
-	// Convert to type result.Matches
-	matches := make(searchresult.Matches, len(results))
-	for i, res := range results {
-		matches[i] = res
-	}
-
+func truncateResults(matches searchresult.Matches, maxResults int) (_ searchresult.Matches, totalCount, truncatedCount int) {
 	totalCount = matches.ResultCount()
 	matches.Limit(maxResults)
 	outputCount := matches.ResultCount()
 
-	// Convert back type []*result.CommitMatch
-	output := make([]*searchresult.CommitMatch, len(matches))
-	for i, match := range matches {
-		output[i] = match.(*searchresult.CommitMatch)
-	}
+	return matches, totalCount, totalCount - outputCount
+}
 
-	return output, totalCount, totalCount - outputCount
+// matchLabel returns the repository and revision of a match, followed by the
+// path for file matches.
+func matchLabel(result searchresult.Match) string {
+	switch m := result.(type) {
+	case *searchresult.CommitMatch:
+		return fmt.Sprintf("%s@%s", m.Repo.Name, m.Commit.ID.Short())
+	case *searchresult.FileMatch:
+		return fmt.Sprintf("%s@%s %s", m.Repo.Name, m.CommitID.Short(), m.Path)
+	default:
+		panic(fmt.Sprintf("unexpected match type %T", result))
+	}
 }
 
 // adapted from slack.PostWebhookCustomHTTPContext
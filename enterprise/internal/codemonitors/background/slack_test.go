@@ -91,6 +91,12 @@ func TestSlackWebhook(t *testing.T) {
 	t.Run("golden without results", func(t *testing.T) {
 		autogold.ExpectFile(t, jsonSlackPayload(action))
 	})
+
+	t.Run("golden digest", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.DigestPeriod = "day"
+		autogold.ExpectFile(t, jsonSlackPayload(actionCopy))
+	})
 }
 
 func TestTriggerTestSlackWebhookAction(t *testing.T) {
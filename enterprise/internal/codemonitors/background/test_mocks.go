@@ -67,3 +67,21 @@ var longCommitResultMock = result.CommitMatch{
 		}},
 	},
 }
+
+var fileResultMock = result.FileMatch{
+	File: result.File{
+		Repo: types.MinimalRepo{
+			Name: api.RepoName("github.com/test/test"),
+		},
+		CommitID: api.CommitID("7815187511872asbasdfgasd"),
+		Path:     "internal/config.go",
+	},
+	ChunkMatches: result.ChunkMatches{{
+		Content:      "\t// TODO: remove deprecated option\n\tDeprecated bool",
+		ContentStart: result.Location{Line: 11, Offset: 240, Column: 0},
+		Ranges: result.Ranges{{
+			Start: result.Location{Line: 11, Offset: 244, Column: 4},
+			End:   result.Location{Line: 11, Offset: 248, Column: 8},
+		}},
+	}},
+}
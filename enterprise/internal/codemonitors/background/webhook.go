@@ -71,7 +71,7 @@ func generateWebhookPayload(args actionArgs) webhookPayload {
 	}
 
 	if args.IncludeResults {
-		p.Results = generateResults(args.Results)
+		p.Results = generateResults(args.matches())
 	}
 
 	return p
@@ -84,28 +84,66 @@ type webhookResult struct {
 	MatchedMessageRanges [][2]int `json:"matchedMessageRanges,omitempty"`
 	Diff                 string   `json:"diff,omitempty"`
 	MatchedDiffRanges    [][2]int `json:"matchedDiffRanges,omitempty"`
+	Path                 string   `json:"path,omitempty"`
+	Content              string   `json:"content,omitempty"`
+	MatchedContentRanges [][2]int `json:"matchedContentRanges,omitempty"`
+	Symbols              []string `json:"symbols,omitempty"`
 }
 
-func generateResults(in []*result.CommitMatch) []webhookResult {
+func generateResults(in result.Matches) []webhookResult {
 	out := make([]webhookResult, len(in))
 	for i, match := range in {
-		res := webhookResult{
-			Repository: string(match.Repo.Name),
-			Commit:     string(match.Commit.ID),
+		switch m := match.(type) {
+		case *result.CommitMatch:
+			out[i] = generateCommitResult(m)
+		case *result.FileMatch:
+			out[i] = generateFileResult(m)
 		}
-		if match.MessagePreview != nil {
-			res.Message = match.MessagePreview.Content
-			res.MatchedMessageRanges = rangesToInts(match.MessagePreview.MatchedRanges)
-		}
-		if match.DiffPreview != nil {
-			res.Diff = match.DiffPreview.Content
-			res.MatchedDiffRanges = rangesToInts(match.DiffPreview.MatchedRanges)
-		}
-		out[i] = res
 	}
 	return out
 }
 
+func generateCommitResult(match *result.CommitMatch) webhookResult {
+	res := webhookResult{
+		Repository: string(match.Repo.Name),
+		Commit:     string(match.Commit.ID),
+	}
+	if match.MessagePreview != nil {
+		res.Message = match.MessagePreview.Content
+		res.MatchedMessageRanges = rangesToInts(match.MessagePreview.MatchedRanges)
+	}
+	if match.DiffPreview != nil {
+		res.Diff = match.DiffPreview.Content
+		res.MatchedDiffRanges = rangesToInts(match.DiffPreview.MatchedRanges)
+	}
+	return res
+}
+
+func generateFileResult(match *result.FileMatch) webhookResult {
+	res := webhookResult{
+		Repository: string(match.Repo.Name),
+		Commit:     string(match.CommitID),
+		Path:       match.Path,
+	}
+	if len(match.ChunkMatches) > 0 {
+		res.Content = fileMatchContent(match)
+
+		// Ranges of chunk matches are relative to the file, so make them
+		// relative to the chunks joined into the content.
+		offset := 0
+		for _, chunk := range match.ChunkMatches {
+			for _, r := range rangesToInts(chunk.Ranges.Sub(chunk.ContentStart)) {
+				res.MatchedContentRanges = append(res.MatchedContentRanges, [2]int{r[0] + offset, r[1] + offset})
+			}
+			offset += len(chunk.Content) + len("\n")
+		}
+	}
+	for _, sym := range match.Symbols {
+		res.Symbols = append(res.Symbols, sym.Symbol.Name)
+	}
+	return res
+}
+
 func rangesToInts(ranges result.Ranges) [][2]int {
 	out := make([][2]int, len(ranges))
 	for i, r := range ranges {
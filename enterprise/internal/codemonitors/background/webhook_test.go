@@ -54,6 +54,18 @@ func TestWebhook(t *testing.T) {
 		autogold.ExpectFile(t, autogold.Raw(j))
 	})
 
+	t.Run("golden with file results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.Results = nil
+		actionCopy.FileResults = []*result.FileMatch{&fileResultMock}
+		actionCopy.IncludeResults = true
+
+		j, err := json.Marshal(generateWebhookPayload(actionCopy))
+		require.NoError(t, err)
+
+		autogold.ExpectFile(t, autogold.Raw(j))
+	})
+
 	t.Run("error is returned", func(t *testing.T) {
 		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			b, err := io.ReadAll(r.Body)
@@ -179,10 +179,25 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, triggerJob
 		return errors.Wrap(err, "query settings")
 	}
 
-	results, searchErr := codemonitors.Search(ctx, logger, r.db, r.enterpriseJobs, q.QueryString, m.ID, settings)
+	// Monitors of file and symbol queries are notified about matches that
+	// weren't found on the previous run rather than about new commits.
+	var (
+		results     []*result.CommitMatch
+		fileResults []*result.FileMatch
+	)
+	isFileQuery, searchErr := codemonitors.IsFileQuery(q.QueryString)
+	if searchErr == nil && isFileQuery {
+		fileResults, searchErr = codemonitors.SearchFiles(ctx, logger, r.db, r.enterpriseJobs, q.QueryString, m.ID, settings)
+	} else if searchErr == nil {
+		results, searchErr = codemonitors.Search(ctx, logger, r.db, r.enterpriseJobs, q.QueryString, m.ID, settings)
+	}
 
 	// Log next_run and latest_result to table cm_queries.
 	newLatestResult := latestResultTime(q.LatestResult, results, searchErr)
+	if searchErr == nil && len(fileResults) > 0 {
+		// File matches have no commit date, so use the time they were found.
+		newLatestResult = cm.Clock()()
+	}
 	err = cm.SetQueryTriggerNextRun(ctx, q.ID, cm.Clock()().Add(5*time.Minute), newLatestResult.UTC())
 	if err != nil {
 		return err
@@ -194,12 +209,26 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, triggerJob
 	}
 
 	// Log the actual query we ran and whether we got any new results.
-	err = cm.UpdateTriggerJobWithResults(ctx, triggerJob.ID, q.QueryString, results)
-	if err != nil {
-		return errors.Wrap(err, "UpdateTriggerJobWithResults")
+	if isFileQuery {
+		err = cm.UpdateTriggerJobWithFileResults(ctx, triggerJob.ID, q.QueryString, fileResults)
+		if err != nil {
+			return errors.Wrap(err, "UpdateTriggerJobWithFileResults")
+		}
+	} else {
+		err = cm.UpdateTriggerJobWithResults(ctx, triggerJob.ID, q.QueryString, results)
+		if err != nil {
+			return errors.Wrap(err, "UpdateTriggerJobWithResults")
+		}
+	}
+
+	if m.Snoozed(cm.Clock()()) {
+		// The results are still recorded on the trigger job so that they show
+		// up in the logs of the monitor, but no notifications are sent, not
+		// even with the next digest after the snooze ends.
+		return errors.Wrap(cm.MarkTriggerJobSnoozed(ctx, triggerJob.ID), "MarkTriggerJobSnoozed")
 	}
 
-	if len(results) > 0 {
+	if len(results) > 0 || len(fileResults) > 0 {
 		_, err := cm.EnqueueActionJobsForMonitor(ctx, m.ID, triggerJob.ID)
 		if err != nil {
 			return errors.Wrap(err, "store.EnqueueActionJobsForQuery")
@@ -227,8 +256,10 @@ func (r *actionRunner) Handle(ctx context.Context, logger log.Logger, j *edb.Act
 		return r.handleWebhook(ctx, j)
 	case j.SlackWebhook != nil:
 		return r.handleSlackWebhook(ctx, j)
+	case j.IssueAction != nil:
+		return r.handleIssue(ctx, j)
 	default:
-		return errors.New("job must be one of type email, webhook, or slack webhook")
+		return errors.New("job must be one of type email, webhook, slack webhook, or issue")
 	}
 }
 
@@ -239,14 +270,14 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *edb.ActionJob) error
 	}
 	defer func() { err = s.Done(err) }()
 
-	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	e, err := s.GetEmailAction(ctx, *j.Email)
 	if err != nil {
-		return errors.Wrap(err, "GetActionJobMetadata")
+		return errors.Wrap(err, "GetEmailAction")
 	}
 
-	e, err := s.GetEmailAction(ctx, *j.Email)
+	m, err := getActionJobMetadata(ctx, s, j, e.DeliverySchedule)
 	if err != nil {
-		return errors.Wrap(err, "GetEmailAction")
+		return err
 	}
 
 	recs, err := s.ListRecipients(ctx, edb.ListRecipientsOpts{EmailID: j.Email})
@@ -267,7 +298,9 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *edb.ActionJob) error
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		FileResults:        m.FileResults,
 		IncludeResults:     e.IncludeResults,
+		DigestPeriod:       digestPeriod(e.DeliverySchedule),
 	}
 
 	data, err := NewTemplateDataForNewSearchResults(args, e)
@@ -290,6 +323,18 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *edb.ActionJob) error
 	return nil
 }
 
+// getActionJobMetadata returns the metadata of an action job. For digest
+// actions, the metadata contains the results of every trigger event since the
+// action job was enqueued.
+func getActionJobMetadata(ctx context.Context, s edb.CodeMonitorStore, j *edb.ActionJob, deliverySchedule string) (*edb.ActionJobMetadata, error) {
+	if digestPeriod(deliverySchedule) != "" {
+		m, err := s.GetActionJobDigestMetadata(ctx, j.ID)
+		return m, errors.Wrap(err, "GetActionJobDigestMetadata")
+	}
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	return m, errors.Wrap(err, "GetActionJobMetadata")
+}
+
 func (r *actionRunner) handleWebhook(ctx context.Context, j *edb.ActionJob) error {
 	s, err := r.CodeMonitorStore.Transact(ctx)
 	if err != nil {
@@ -320,6 +365,7 @@ func (r *actionRunner) handleWebhook(ctx context.Context, j *edb.ActionJob) erro
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		FileResults:        m.FileResults,
 		IncludeResults:     w.IncludeResults,
 	}
 
@@ -333,14 +379,14 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob)
 	}
 	defer func() { err = s.Done(err) }()
 
-	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	w, err := s.GetSlackWebhookAction(ctx, *j.SlackWebhook)
 	if err != nil {
-		return errors.Wrap(err, "GetActionJobMetadata")
+		return errors.Wrap(err, "GetSlackWebhookAction")
 	}
 
-	w, err := s.GetSlackWebhookAction(ctx, *j.SlackWebhook)
+	m, err := getActionJobMetadata(ctx, s, j, w.DeliverySchedule)
 	if err != nil {
-		return errors.Wrap(err, "GetSlackWebhookAction")
+		return err
 	}
 
 	externalURL, err := getExternalURL(ctx)
@@ -356,12 +402,76 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob)
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		FileResults:        m.FileResults,
 		IncludeResults:     w.IncludeResults,
+		DigestPeriod:       digestPeriod(w.DeliverySchedule),
 	}
 
 	return sendSlackNotification(ctx, w.URL, args)
 }
 
+func (r *actionRunner) handleIssue(ctx context.Context, j *edb.ActionJob) error {
+	s, err := r.CodeMonitorStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = s.Done(err) }()
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	a, err := s.GetIssueAction(ctx, *j.IssueAction)
+	if err != nil {
+		return errors.Wrap(err, "GetIssueAction")
+	}
+
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          a.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          "code-monitor-issue",
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		FileResults:        m.FileResults,
+		IncludeResults:     a.IncludeResults,
+	}
+
+	// Issues are filed with the credentials of the monitor owner, so the owner
+	// must be able to see the repository.
+	ctx = actor.WithActor(ctx, actor.FromUser(m.OwnerID))
+	db := database.NewDBWith(log.Scoped("handleIssue", ""), s)
+	repo, err := db.Repos().Get(ctx, a.RepoID)
+	if err != nil {
+		return errors.Wrap(err, "getting repository")
+	}
+
+	tracker, err := newIssueTracker(ctx, db, m.OwnerID, repo)
+	if err != nil {
+		return err
+	}
+
+	issue, err := fileIssue(ctx, tracker, a, args)
+	if err != nil {
+		return err
+	}
+
+	if a.IssueID == nil || *a.IssueID != issue.ID {
+		_, err = s.UpdateIssueActionTrackingIssue(ctx, a.ID, issue.ID, issue.URL)
+		if err != nil {
+			return errors.Wrap(err, "UpdateIssueActionTrackingIssue")
+		}
+	}
+	return nil
+}
+
 type StatusCodeError struct {
 	Code   int
 	Status string
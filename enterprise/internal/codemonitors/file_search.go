@@ -0,0 +1,285 @@
+package codemonitors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"sort"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+var ErrMixedMonitorQuery = errors.New("code monitor query must either search commits with type:commit or type:diff, or search files with type:file or type:symbol. If you have an AND/OR operator in your query, ensure that both sides search the same kind of result.")
+
+// IsFileQuery returns whether a code monitor query searches file contents or
+// symbols rather than commits or diffs. Instead of searching new commits, code
+// monitors of file queries compare the matches at the head of the searched
+// branches with the matches of the previous run.
+func IsFileQuery(q string) (bool, error) {
+	plan, err := query.Pipeline(query.Init(q, query.SearchTypeStandard))
+	if err != nil {
+		return false, err
+	}
+	return isFilePlan(plan)
+}
+
+func isFilePlan(plan query.Plan) (bool, error) {
+	var hasFileType, hasOtherType bool
+	for _, b := range plan {
+		types, _ := b.IncludeExcludeValues(query.FieldType)
+		if len(types) == 0 {
+			hasOtherType = true
+		}
+		for _, t := range types {
+			switch t {
+			case "file", "symbol":
+				hasFileType = true
+			default:
+				hasOtherType = true
+			}
+		}
+	}
+	if hasFileType && hasOtherType {
+		return false, ErrMixedMonitorQuery
+	}
+	return hasFileType, nil
+}
+
+// SearchFiles runs a code monitor query of file contents or symbols and returns
+// the matches that weren't found on the previous run. Matches in repositories that
+// weren't searched before are all new. The matches that were found are stored as
+// the snapshot for the next run.
+func SearchFiles(ctx context.Context, logger log.Logger, db database.DB, enterpriseJobs jobutil.EnterpriseJobs, query string, monitorID int64, settings *schema.Settings) ([]*result.FileMatch, error) {
+	matches, limitHit, err := searchFiles(ctx, logger, db, enterpriseJobs, query, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := edb.NewEnterpriseDB(db).CodeMonitors()
+	snapshot, err := cm.ListLastSearchedMatches(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	newMatches := newFileMatches(matches, snapshot)
+	return newMatches, upsertFileSnapshot(ctx, cm, monitorID, matches, snapshot, limitHit)
+}
+
+// snapshotFiles saves the current matches of a file or symbol query so that the
+// next run of the code monitor only notifies about matches that are new.
+func snapshotFiles(ctx context.Context, logger log.Logger, db database.DB, enterpriseJobs jobutil.EnterpriseJobs, query string, monitorID int64, settings *schema.Settings) error {
+	matches, limitHit, err := searchFiles(ctx, logger, db, enterpriseJobs, query, settings)
+	if err != nil {
+		return err
+	}
+
+	cm := edb.NewEnterpriseDB(db).CodeMonitors()
+	snapshot, err := cm.ListLastSearchedMatches(ctx, monitorID)
+	if err != nil {
+		return err
+	}
+
+	return upsertFileSnapshot(ctx, cm, monitorID, matches, snapshot, limitHit)
+}
+
+func searchFiles(ctx context.Context, logger log.Logger, db database.DB, enterpriseJobs jobutil.EnterpriseJobs, query string, settings *schema.Settings) (_ []*result.FileMatch, limitHit bool, err error) {
+	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs(), enterpriseJobs)
+	inputs, err := searchClient.Plan(
+		ctx,
+		"V3",
+		nil,
+		query,
+		search.Precise,
+		search.Streaming,
+		settings,
+		envvar.SourcegraphDotComMode(),
+	)
+	if err != nil {
+		return nil, false, errcode.MakeNonRetryable(err)
+	}
+
+	if isFile, err := isFilePlan(inputs.Plan); err != nil {
+		return nil, false, errcode.MakeNonRetryable(err)
+	} else if !isFile {
+		return nil, false, errcode.MakeNonRetryable(errors.New("all branches of query must be of type:file or type:symbol"))
+	}
+
+	planJob, err := jobutil.NewPlanJob(inputs, inputs.Plan, enterpriseJobs)
+	if err != nil {
+		return nil, false, errcode.MakeNonRetryable(err)
+	}
+
+	agg := streaming.NewAggregatingStream()
+	_, err = planJob.Run(ctx, searchClient.JobClients(), agg)
+	if err != nil {
+		return nil, false, err
+	}
+
+	matches := make([]*result.FileMatch, len(agg.Results))
+	for i, res := range agg.Results {
+		fm, ok := res.(*result.FileMatch)
+		if !ok {
+			return nil, false, errors.Errorf("expected search to only return file matches, but got type %T", res)
+		}
+		matches[i] = fm
+	}
+
+	return matches, agg.Stats.IsLimitHit, nil
+}
+
+// upsertFileSnapshot stores the keys of matches as the snapshot of each repo they
+// were found in. Repos of the previous snapshot without any matches are reset, so
+// that their matches are new when they reappear. If the search hit a result limit,
+// not all matches were found, so the keys of the previous snapshot are kept.
+func upsertFileSnapshot(ctx context.Context, cm edb.CodeMonitorStore, monitorID int64, matches []*result.FileMatch, snapshot map[api.RepoID][]string, limitHit bool) error {
+	commits := make(map[api.RepoID]string)
+	keys := make(map[api.RepoID]map[string]struct{})
+	for _, fm := range matches {
+		commits[fm.Repo.ID] = string(fm.CommitID)
+		if _, ok := keys[fm.Repo.ID]; !ok {
+			keys[fm.Repo.ID] = make(map[string]struct{})
+		}
+		for _, key := range fileMatchKeys(fm) {
+			keys[fm.Repo.ID][key] = struct{}{}
+		}
+	}
+
+	for repoID, previous := range snapshot {
+		if _, ok := keys[repoID]; !ok {
+			keys[repoID] = make(map[string]struct{})
+		}
+		if limitHit {
+			for _, key := range previous {
+				keys[repoID][key] = struct{}{}
+			}
+		}
+	}
+
+	for repoID, repoKeys := range keys {
+		matchKeys := make([]string, 0, len(repoKeys))
+		for key := range repoKeys {
+			matchKeys = append(matchKeys, key)
+		}
+		sort.Strings(matchKeys)
+
+		var commitOIDs []string
+		if commit, ok := commits[repoID]; ok {
+			commitOIDs = []string{commit}
+		}
+		if err := cm.UpsertLastSearchedMatches(ctx, monitorID, repoID, commitOIDs, matchKeys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newFileMatches returns the matches whose keys are not in the snapshot of the repo
+// they were found in. Each returned match only contains the chunks and symbols
+// that are new.
+func newFileMatches(matches []*result.FileMatch, snapshot map[api.RepoID][]string) []*result.FileMatch {
+	previous := make(map[api.RepoID]map[string]struct{}, len(snapshot))
+	for repoID, keys := range snapshot {
+		previous[repoID] = make(map[string]struct{}, len(keys))
+		for _, key := range keys {
+			previous[repoID][key] = struct{}{}
+		}
+	}
+
+	isNew := func(repoID api.RepoID, keys ...string) bool {
+		for _, key := range keys {
+			if _, ok := previous[repoID][key]; !ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	var newMatches []*result.FileMatch
+	for _, fm := range matches {
+		if fm.IsPathMatch() {
+			if isNew(fm.Repo.ID, pathMatchKey(fm.Path)) {
+				newMatches = append(newMatches, fm)
+			}
+			continue
+		}
+
+		newMatch := &result.FileMatch{
+			File:     fm.File,
+			LimitHit: fm.LimitHit,
+		}
+		for _, chunk := range fm.ChunkMatches {
+			if isNew(fm.Repo.ID, chunkMatchKeys(fm.Path, chunk)...) {
+				newMatch.ChunkMatches = append(newMatch.ChunkMatches, chunk)
+			}
+		}
+		for _, sym := range fm.Symbols {
+			if isNew(fm.Repo.ID, symbolMatchKey(fm.Path, sym)) {
+				newMatch.Symbols = append(newMatch.Symbols, &result.SymbolMatch{Symbol: sym.Symbol, File: &newMatch.File})
+			}
+		}
+		if len(newMatch.ChunkMatches) > 0 || len(newMatch.Symbols) > 0 {
+			newMatches = append(newMatches, newMatch)
+		}
+	}
+	return newMatches
+}
+
+// fileMatchKeys returns the keys that identify the matched lines, symbols, or path
+// of a file match. Keys don't depend on the position of a match in the file, so
+// that unrelated changes to a file don't make its matches new.
+func fileMatchKeys(fm *result.FileMatch) []string {
+	if fm.IsPathMatch() {
+		return []string{pathMatchKey(fm.Path)}
+	}
+
+	var keys []string
+	for _, chunk := range fm.ChunkMatches {
+		keys = append(keys, chunkMatchKeys(fm.Path, chunk)...)
+	}
+	for _, sym := range fm.Symbols {
+		keys = append(keys, symbolMatchKey(fm.Path, sym))
+	}
+	return keys
+}
+
+func chunkMatchKeys(path string, chunk result.ChunkMatch) []string {
+	var keys []string
+	for _, line := range chunk.AsLineMatches() {
+		if len(line.OffsetAndLengths) == 0 {
+			continue
+		}
+		keys = append(keys, matchKey("content", path, line.Preview))
+	}
+	return keys
+}
+
+func symbolMatchKey(path string, sym *result.SymbolMatch) string {
+	return matchKey("symbol", path, sym.Symbol.Kind, sym.Symbol.Parent, sym.Symbol.Name)
+}
+
+func pathMatchKey(path string) string {
+	return matchKey("path", path)
+}
+
+func matchKey(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return base64.RawStdEncoding.EncodeToString(h.Sum(nil)[:16])
+}
@@ -0,0 +1,127 @@
+package codemonitors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestIsFileQuery(t *testing.T) {
+	t.Parallel()
+
+	fileQueries := []string{
+		"type:file TODO",
+		"type:symbol func",
+		"type:file a or type:symbol b",
+		"type:file repo:c a and b",
+	}
+	for _, q := range fileQueries {
+		t.Run(q, func(t *testing.T) {
+			isFile, err := IsFileQuery(q)
+			require.NoError(t, err)
+			require.True(t, isFile)
+		})
+	}
+
+	commitQueries := []string{
+		"type:commit a or b",
+		"type:diff a and b",
+		"TODO",
+	}
+	for _, q := range commitQueries {
+		t.Run(q, func(t *testing.T) {
+			isFile, err := IsFileQuery(q)
+			require.NoError(t, err)
+			require.False(t, isFile)
+		})
+	}
+
+	mixedQueries := []string{
+		"type:file a or type:diff b",
+		"(type:symbol a) or (repo:c b)",
+	}
+	for _, q := range mixedQueries {
+		t.Run(q, func(t *testing.T) {
+			_, err := IsFileQuery(q)
+			require.ErrorIs(t, err, ErrMixedMonitorQuery)
+		})
+	}
+}
+
+func TestNewFileMatches(t *testing.T) {
+	t.Parallel()
+
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/test/test"}
+	chunk := func(content string, line int) result.ChunkMatch {
+		return result.ChunkMatch{
+			Content:      content,
+			ContentStart: result.Location{Line: line},
+			Ranges: result.Ranges{{
+				Start: result.Location{Line: line, Column: 0},
+				End:   result.Location{Line: line, Column: 4},
+			}},
+		}
+	}
+	fileMatch := func(path string, chunks ...result.ChunkMatch) *result.FileMatch {
+		return &result.FileMatch{
+			File:         result.File{Repo: repo, CommitID: "deadbeef", Path: path},
+			ChunkMatches: chunks,
+		}
+	}
+
+	previous := []*result.FileMatch{
+		fileMatch("a.go", chunk("TODO: one", 3)),
+		fileMatch("b.go"),
+	}
+	snapshot := map[api.RepoID][]string{}
+	for _, fm := range previous {
+		snapshot[repo.ID] = append(snapshot[repo.ID], fileMatchKeys(fm)...)
+	}
+
+	t.Run("unchanged matches are not new", func(t *testing.T) {
+		require.Empty(t, newFileMatches(previous, snapshot))
+	})
+
+	t.Run("moved matches are not new", func(t *testing.T) {
+		matches := []*result.FileMatch{fileMatch("a.go", chunk("TODO: one", 10))}
+		require.Empty(t, newFileMatches(matches, snapshot))
+	})
+
+	t.Run("only new chunks are returned", func(t *testing.T) {
+		matches := []*result.FileMatch{
+			fileMatch("a.go", chunk("TODO: one", 3), chunk("TODO: two", 5)),
+			fileMatch("b.go"),
+			fileMatch("c.go"),
+		}
+		got := newFileMatches(matches, snapshot)
+		require.Len(t, got, 2)
+		require.Equal(t, "a.go", got[0].Path)
+		require.Equal(t, result.ChunkMatches{chunk("TODO: two", 5)}, got[0].ChunkMatches)
+		require.Equal(t, "c.go", got[1].Path)
+	})
+
+	t.Run("matches in new repos are new", func(t *testing.T) {
+		otherRepo := *fileMatch("a.go", chunk("TODO: one", 3))
+		otherRepo.Repo = types.MinimalRepo{ID: 2, Name: "github.com/test/other"}
+		require.Len(t, newFileMatches([]*result.FileMatch{&otherRepo}, snapshot), 1)
+	})
+
+	t.Run("new symbols are returned", func(t *testing.T) {
+		fm := fileMatch("a.go")
+		fm.Symbols = []*result.SymbolMatch{
+			{Symbol: result.Symbol{Name: "Old", Kind: "function"}, File: &fm.File},
+			{Symbol: result.Symbol{Name: "New", Kind: "function"}, File: &fm.File},
+		}
+		symbolSnapshot := map[api.RepoID][]string{
+			repo.ID: {symbolMatchKey("a.go", fm.Symbols[0])},
+		}
+		got := newFileMatches([]*result.FileMatch{fm}, symbolSnapshot)
+		require.Len(t, got, 1)
+		require.Len(t, got[0].Symbols, 1)
+		require.Equal(t, "New", got[0].Symbols[0].Symbol.Name)
+	})
+}
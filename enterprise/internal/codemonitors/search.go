@@ -158,8 +158,16 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, enterpriseJo
 
 // Snapshot runs a dummy search that just saves the current state of the searched repos in the database.
 // On subsequent runs, this allows us to treat all new repos or sets of args as something new that should
-// be searched from the beginning.
+// be searched from the beginning. For file and symbol queries, it saves the current matches instead.
 func Snapshot(ctx context.Context, logger log.Logger, db database.DB, enterpriseJobs jobutil.EnterpriseJobs, query string, monitorID int64, settings *schema.Settings) error {
+	isFile, err := IsFileQuery(query)
+	if err != nil {
+		return err
+	}
+	if isFile {
+		return snapshotFiles(ctx, logger, db, enterpriseJobs, query, monitorID, settings)
+	}
+
 	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs(), enterpriseJobs)
 	inputs, err := searchClient.Plan(
 		ctx,
@@ -233,7 +241,7 @@ func addCodeMonitorHook(in job.Job, hook commit.CodeMonitorHook) (_ job.Job, err
 		default:
 			if len(j.Children()) == 0 {
 				if err == nil {
-					err = errors.New("all branches of query must be of type:diff or type:commit. If you have an AND/OR operator in your query, ensure that both sides have type:commit or type:diff.")
+					err = errors.New("all branches of query must be of type:diff or type:commit, or all of type:file or type:symbol. If you have an AND/OR operator in your query, ensure that both sides have the same type.")
 				}
 			}
 			return j
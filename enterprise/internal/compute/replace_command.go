@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hexops/gotextdiff"
+	"github.com/hexops/gotextdiff/myers"
+	"github.com/hexops/gotextdiff/span"
+
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/comby"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -60,3 +64,18 @@ func (c *Replace) Run(ctx context.Context, r result.Match) (Result, error) {
 	}
 	return nil, nil
 }
+
+// Diff returns a diff of the replacement in the given content of the file at path, in the format of
+// `git diff --no-prefix`. The diff is empty if the replacement doesn't change the file.
+func (c *Replace) Diff(ctx context.Context, path string, content []byte) (string, error) {
+	text, err := replace(ctx, content, c.SearchPattern, c.ReplacePattern)
+	if err != nil {
+		return "", err
+	}
+	if text.Value == string(content) {
+		return "", nil
+	}
+	edits := myers.ComputeEdits(span.URIFromPath(path), string(content), text.Value)
+	unified := gotextdiff.ToUnified(path, path, string(content), edits)
+	return fmt.Sprintf("diff --git %[1]s %[1]s\n%[2]v", path, unified), nil
+}
@@ -37,3 +37,30 @@ func Test_replace(t *testing.T) {
 			ReplacePattern: "foo(:[y], :[x])",
 		}))
 }
+
+func TestReplaceDiff(t *testing.T) {
+	test := func(input string, cmd *Replace) string {
+		diff, err := cmd.Diff(context.Background(), "cmd/main.go", []byte(input))
+		if err != nil {
+			return err.Error()
+		}
+		return diff
+	}
+
+	cmd := &Replace{
+		SearchPattern:  &Regexp{Value: regexp.MustCompile(`ioutil\.(ReadFile|WriteFile)`)},
+		ReplacePattern: "os.$1",
+	}
+
+	autogold.Expect(`diff --git cmd/main.go cmd/main.go
+--- cmd/main.go
++++ cmd/main.go
+@@ -1,3 +1,3 @@
+ package main
+ 
+-var _ = ioutil.ReadFile
++var _ = os.ReadFile
+`).Equal(t, test("package main\n\nvar _ = ioutil.ReadFile\n", cmd))
+
+	autogold.Expect("").Equal(t, test("package main\n", cmd))
+}
@@ -12,11 +12,21 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
+// The delivery schedules of email and Slack webhook actions. Actions with an
+// hourly or daily delivery schedule send a digest of all trigger events of the
+// past hour or day instead of a notification for every trigger event.
+const (
+	DeliveryScheduleImmediate = "IMMEDIATE"
+	DeliveryScheduleHourly    = "HOURLY"
+	DeliveryScheduleDaily     = "DAILY"
+)
+
 type ActionJob struct {
 	ID           int32
 	Email        *int64
 	Webhook      *int64
 	SlackWebhook *int64
+	IssueAction  *int64
 	TriggerEvent int32
 
 	// Fields demanded by any dbworker.
@@ -38,6 +48,8 @@ type ActionJobMetadata struct {
 	Description string
 	MonitorID   int64
 	Results     []*result.CommitMatch
+	FileResults []*result.FileMatch
+	OwnerID     int32
 	OwnerName   string
 
 	// The query with after: filter.
@@ -51,6 +63,7 @@ var ActionJobColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_action_jobs.email"),
 	sqlf.Sprintf("cm_action_jobs.webhook"),
 	sqlf.Sprintf("cm_action_jobs.slack_webhook"),
+	sqlf.Sprintf("cm_action_jobs.issue_action"),
 	sqlf.Sprintf("cm_action_jobs.trigger_event"),
 	sqlf.Sprintf("cm_action_jobs.state"),
 	sqlf.Sprintf("cm_action_jobs.failure_message"),
@@ -82,6 +95,10 @@ type ListActionJobsOpts struct {
 	// the given slack webhook action. Refers to cm_slack_webhooks(id)
 	SlackWebhookID *int
 
+	// IssueActionID, if set, will filter to only actions jobs that are
+	// executing the given issue action. Refers to cm_issue_actions(id)
+	IssueActionID *int
+
 	// First, if defined, limits the operation to only the first n results
 	First *int
 
@@ -105,6 +122,9 @@ func (o ListActionJobsOpts) Conds() *sqlf.Query {
 	if o.SlackWebhookID != nil {
 		conds = append(conds, sqlf.Sprintf("slack_webhook = %s", *o.SlackWebhookID))
 	}
+	if o.IssueActionID != nil {
+		conds = append(conds, sqlf.Sprintf("issue_action = %s", *o.IssueActionID))
+	}
 	if o.After != nil {
 		conds = append(conds, sqlf.Sprintf("id > %s", *o.After))
 	}
@@ -192,26 +212,74 @@ WITH due_emails AS (
 	SELECT DISTINCT slack_webhook as id FROM cm_action_jobs
 	WHERE state = 'queued'
 		OR state = 'processing'
+), due_issue_actions AS (
+	SELECT id
+	FROM cm_issue_actions
+	WHERE monitor = %s
+		AND enabled = true
+	EXCEPT
+	SELECT DISTINCT issue_action as id FROM cm_action_jobs
+	WHERE state = 'queued'
+		OR state = 'processing'
 )
-INSERT INTO cm_action_jobs (email, webhook, slack_webhook, trigger_event)
-SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_emails
+INSERT INTO cm_action_jobs (email, webhook, slack_webhook, issue_action, trigger_event, process_after, digest_first_trigger_event, digest_last_trigger_event)
+SELECT due_emails.id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer, %s, %s, %s
+FROM due_emails
+JOIN cm_emails ON cm_emails.id = due_emails.id
+UNION
+SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer, CAST(NULL AS TIMESTAMP WITH TIME ZONE), CAST(NULL AS INTEGER), CAST(NULL AS INTEGER) from due_webhooks
 UNION
-SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer from due_webhooks
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), due_slack_webhooks.id, CAST(NULL AS BIGINT), %s::integer, %s, %s, %s
+FROM due_slack_webhooks
+JOIN cm_slack_webhooks ON cm_slack_webhooks.id = due_slack_webhooks.id
 UNION
-SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer from due_slack_webhooks
-ORDER BY 1, 2, 3
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer, CAST(NULL AS TIMESTAMP WITH TIME ZONE), CAST(NULL AS INTEGER), CAST(NULL AS INTEGER) from due_issue_actions
+ORDER BY 1, 2, 3, 4
 RETURNING %s
 `
 
+// digestProcessAfterFmtStr is the time at which the action job of an action
+// with the delivery schedule in the given column is processed. Digests are sent
+// at the start of the next hour or day, and the action jobs of immediate
+// actions are processed right away.
+const digestProcessAfterFmtStr = `
+CASE %s
+	WHEN 'HOURLY' THEN date_trunc('hour', %s::timestamp with time zone) + interval '1 hour'
+	WHEN 'DAILY' THEN date_trunc('day', %s::timestamp with time zone) + interval '1 day'
+END
+`
+
+// immediateTriggerEventFmtStr is the trigger event delivered by the action job
+// of an immediate action with the delivery schedule in the given column. It is
+// recorded as the delivery watermark of the action job, so that the first
+// digest after a switch from an immediate delivery schedule doesn't include
+// trigger events that were already sent.
+const immediateTriggerEventFmtStr = `
+CASE %s WHEN 'IMMEDIATE' THEN %s::integer END
+`
+
+// EnqueueActionJobsForMonitor enqueues an action job for each enabled action of
+// the monitor that doesn't have a queued or processing action job yet. The
+// action jobs of digest actions are processed at the start of the next hour or
+// day, so trigger events until then are batched into the same action job.
 func (s *codeMonitorStore) EnqueueActionJobsForMonitor(ctx context.Context, monitorID int64, triggerJobID int32) ([]*ActionJob, error) {
+	now := s.Now()
 	q := sqlf.Sprintf(
 		enqueueActionEmailFmtStr,
 		monitorID,
 		monitorID,
 		monitorID,
+		monitorID,
 		triggerJobID,
+		sqlf.Sprintf(digestProcessAfterFmtStr, sqlf.Sprintf("cm_emails.delivery_schedule"), now, now),
+		sqlf.Sprintf(immediateTriggerEventFmtStr, sqlf.Sprintf("cm_emails.delivery_schedule"), triggerJobID),
+		sqlf.Sprintf(immediateTriggerEventFmtStr, sqlf.Sprintf("cm_emails.delivery_schedule"), triggerJobID),
 		triggerJobID,
 		triggerJobID,
+		sqlf.Sprintf(digestProcessAfterFmtStr, sqlf.Sprintf("cm_slack_webhooks.delivery_schedule"), now, now),
+		sqlf.Sprintf(immediateTriggerEventFmtStr, sqlf.Sprintf("cm_slack_webhooks.delivery_schedule"), triggerJobID),
+		sqlf.Sprintf(immediateTriggerEventFmtStr, sqlf.Sprintf("cm_slack_webhooks.delivery_schedule"), triggerJobID),
+		triggerJobID,
 		sqlf.Join(ActionJobColumns, ","),
 	)
 	rows, err := s.Query(ctx, q)
@@ -228,6 +296,8 @@ SELECT
 	ctj.query_string,
 	cm.id AS monitorID,
 	ctj.search_results,
+	ctj.file_results,
+	cm.namespace_user_id,
 	CASE WHEN LENGTH(users.display_name) > 0 THEN users.display_name ELSE users.username END
 FROM cm_action_jobs caj
 INNER JOIN cm_trigger_jobs ctj on caj.trigger_event = ctj.id
@@ -240,18 +310,172 @@ WHERE caj.id = %s
 // GetActionJobMetada returns the set of fields needed to execute all action jobs
 func (s *codeMonitorStore) GetActionJobMetadata(ctx context.Context, jobID int32) (*ActionJobMetadata, error) {
 	row := s.Store.QueryRow(ctx, sqlf.Sprintf(getActionJobMetadataFmtStr, jobID))
-	var resultsJSON []byte
+	var resultsJSON, fileResultsJSON []byte
 	m := &ActionJobMetadata{}
-	err := row.Scan(&m.Description, &m.Query, &m.MonitorID, &resultsJSON, &m.OwnerName)
+	err := row.Scan(&m.Description, &m.Query, &m.MonitorID, &resultsJSON, &fileResultsJSON, &m.OwnerID, &m.OwnerName)
 	if err != nil {
 		return nil, err
 	}
 	if err := json.Unmarshal(resultsJSON, &m.Results); err != nil {
 		return nil, err
 	}
+	m.FileResults, err = unmarshalFileResults(fileResultsJSON)
+	if err != nil {
+		return nil, err
+	}
 	return m, nil
 }
 
+// maxDigestTriggerEvents is the maximum number of trigger events whose results
+// are included in a single digest. The results of later trigger events are sent
+// with the next digest of the action.
+const maxDigestTriggerEvents = 100
+
+const lockDigestEmailFmtStr = `
+SELECT id FROM cm_emails
+WHERE id = (SELECT email FROM cm_action_jobs WHERE id = %s)
+FOR UPDATE
+`
+
+const lockDigestSlackWebhookFmtStr = `
+SELECT id FROM cm_slack_webhooks
+WHERE id = (SELECT slack_webhook FROM cm_action_jobs WHERE id = %s)
+FOR UPDATE
+`
+
+// digestJobFmtStr selects the action job of a digest along with the query of
+// its monitor and the last time the delivery schedule of its action changed.
+const digestJobFmtStr = `
+SELECT
+	caj.id,
+	caj.email,
+	caj.slack_webhook,
+	caj.trigger_event,
+	caj.digest_first_trigger_event,
+	caj.digest_last_trigger_event,
+	ctj.query,
+	COALESCE(ce.delivery_schedule_changed_at, csw.delivery_schedule_changed_at) AS delivery_schedule_changed_at
+FROM cm_action_jobs caj
+INNER JOIN cm_trigger_jobs ctj ON ctj.id = caj.trigger_event
+LEFT JOIN cm_emails ce ON ce.id = caj.email
+LEFT JOIN cm_slack_webhooks csw ON csw.id = caj.slack_webhook
+WHERE caj.id = %s
+`
+
+// digestTriggerEventCond matches the trigger events ctj that can be included in
+// the digest of the action job selected by digestJobFmtStr as job: the trigger
+// event of the action job, and the completed trigger events that weren't
+// recorded while the monitor was snoozed or before the delivery schedule of the
+// action last changed.
+const digestTriggerEventCond = `
+ctj.query = job.query
+AND (
+	ctj.id = job.trigger_event
+	OR (
+		ctj.state = 'completed'
+		AND NOT ctj.snoozed
+		AND (job.delivery_schedule_changed_at IS NULL OR ctj.finished_at >= job.delivery_schedule_changed_at)
+	)
+)
+`
+
+// claimDigestTriggerEventsFmtStr stores the range of trigger events whose
+// results are included in the digest of an action job. The range starts after
+// the last trigger event delivered by a previous action job of the same action,
+// which is either the end of the range of a previous digest or the trigger
+// event of an immediate action job, or at the trigger event of the action job
+// if there is none. The range of an action job is only claimed once, so that
+// retries send the same digest.
+const claimDigestTriggerEventsFmtStr = `
+WITH job AS (` + digestJobFmtStr + `), previous AS (
+	SELECT MAX(caj.digest_last_trigger_event) AS last_trigger_event
+	FROM cm_action_jobs caj, job
+	WHERE caj.id <> job.id
+		AND (caj.email = job.email OR caj.slack_webhook = job.slack_webhook)
+), claimed AS (
+	SELECT ctj.id
+	FROM cm_trigger_jobs ctj, job, previous
+	WHERE ctj.id >= COALESCE(previous.last_trigger_event + 1, job.trigger_event)
+		AND ` + digestTriggerEventCond + `
+	ORDER BY ctj.id ASC
+	LIMIT %s
+)
+UPDATE cm_action_jobs
+SET
+	digest_first_trigger_event = (SELECT MIN(id) FROM claimed),
+	digest_last_trigger_event = (SELECT MAX(id) FROM claimed)
+WHERE id = %s
+	AND digest_last_trigger_event IS NULL
+`
+
+const listDigestResultsFmtStr = `
+WITH job AS (` + digestJobFmtStr + `)
+SELECT
+	ctj.search_results,
+	ctj.file_results
+FROM cm_trigger_jobs ctj, job
+WHERE ctj.id BETWEEN job.digest_first_trigger_event AND job.digest_last_trigger_event
+	AND ` + digestTriggerEventCond + `
+ORDER BY ctj.id ASC
+`
+
+// GetActionJobDigestMetadata returns the same fields as GetActionJobMetadata,
+// but with the results of the completed trigger events of the monitor since
+// the last digest of the action. It is used for the action jobs of digest
+// actions, which are enqueued for the first trigger event of a digest.
+//
+// The trigger events are claimed for the action job while holding a lock on
+// the action, so that no two digests of an action contain the same results.
+func (s *codeMonitorStore) GetActionJobDigestMetadata(ctx context.Context, jobID int32) (_ *ActionJobMetadata, err error) {
+	txBase, err := s.Store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = txBase.Done(err) }()
+	tx := &codeMonitorStore{Store: txBase, now: s.now}
+
+	m, err := tx.GetActionJobMetadata(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, q := range []string{lockDigestEmailFmtStr, lockDigestSlackWebhookFmtStr} {
+		if err := tx.Exec(ctx, sqlf.Sprintf(q, jobID)); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Exec(ctx, sqlf.Sprintf(claimDigestTriggerEventsFmtStr, jobID, maxDigestTriggerEvents, jobID)); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, sqlf.Sprintf(listDigestResultsFmtStr, jobID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	m.Results, m.FileResults = nil, nil
+	for rows.Next() {
+		var resultsJSON, fileResultsJSON []byte
+		if err := rows.Scan(&resultsJSON, &fileResultsJSON); err != nil {
+			return nil, err
+		}
+		var results []*result.CommitMatch
+		if len(resultsJSON) > 0 {
+			if err := json.Unmarshal(resultsJSON, &results); err != nil {
+				return nil, err
+			}
+		}
+		fileResults, err := unmarshalFileResults(fileResultsJSON)
+		if err != nil {
+			return nil, err
+		}
+		m.Results = append(m.Results, results...)
+		m.FileResults = append(m.FileResults, fileResults...)
+	}
+	return m, rows.Err()
+}
+
 const actionJobForIDFmtStr = `
 SELECT %s -- ActionJobColumns
 FROM cm_action_jobs
@@ -283,6 +507,7 @@ func ScanActionJob(row dbutil.Scanner) (*ActionJob, error) {
 		&aj.Email,
 		&aj.Webhook,
 		&aj.SlackWebhook,
+		&aj.IssueAction,
 		&aj.TriggerEvent,
 		&aj.State,
 		&aj.FailureMessage,
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -41,7 +42,7 @@ func TestEnqueueActionEmailsForQueryIDInt64QueryByRecordID(t *testing.T) {
 
 func TestGetActionJobMetadata(t *testing.T) {
 	ctx, db, s := newTestStore(t)
-	userName, _, userCTX := newTestUser(ctx, t, db)
+	userName, userID, userCTX := newTestUser(ctx, t, db)
 	fixtures := s.insertTestMonitor(userCTX, t)
 
 	triggerJobs, err := s.EnqueueQueryTriggerJobs(ctx)
@@ -68,6 +69,7 @@ func TestGetActionJobMetadata(t *testing.T) {
 		Query:       wantQuery,
 		Results:     wantResults,
 		MonitorID:   fixtures.monitor.ID,
+		OwnerID:     userID,
 		OwnerName:   userName,
 	}
 	require.Equal(t, want, got)
@@ -96,3 +98,245 @@ func TestScanActionJob(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, int(actionJobID), job.RecordID())
 }
+
+func TestEnqueueDigestActionJobs(t *testing.T) {
+	ctx, db, s := newTestStore(t)
+	_, _, userCTX := newTestUser(ctx, t, db)
+	fixtures := s.insertTestMonitor(userCTX, t)
+
+	slackWebhook, err := s.CreateSlackWebhookAction(userCTX, fixtures.monitor.ID, true, false, "https://example.com", DeliveryScheduleHourly)
+	require.NoError(t, err)
+
+	triggerJobs, err := s.EnqueueQueryTriggerJobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, triggerJobs, 1)
+
+	actionJobs, err := s.EnqueueActionJobsForMonitor(ctx, fixtures.monitor.ID, triggerJobs[0].ID)
+	require.NoError(t, err)
+	require.Len(t, actionJobs, 3)
+
+	// The email actions are sent immediately, the slack webhook at the start of
+	// the next hour.
+	require.Nil(t, actionJobs[0].ProcessAfter)
+	require.Nil(t, actionJobs[1].ProcessAfter)
+	require.Equal(t, &slackWebhook.ID, actionJobs[2].SlackWebhook)
+	require.NotNil(t, actionJobs[2].ProcessAfter)
+	require.True(t, s.Now().Truncate(time.Hour).Add(time.Hour).Equal(*actionJobs[2].ProcessAfter))
+
+	// No new digest is enqueued while one is queued.
+	actionJobs, err = s.EnqueueActionJobsForMonitor(ctx, fixtures.monitor.ID, triggerJobs[0].ID)
+	require.NoError(t, err)
+	require.Empty(t, actionJobs)
+}
+
+func TestGetActionJobDigestMetadata(t *testing.T) {
+	ctx, db, s := newTestStore(t)
+	_, _, userCTX := newTestUser(ctx, t, db)
+	fixtures := s.insertTestMonitor(userCTX, t)
+	err := s.Exec(ctx, sqlf.Sprintf("UPDATE cm_emails SET delivery_schedule = 'DAILY'"))
+	require.NoError(t, err)
+
+	triggerJobs, err := s.EnqueueQueryTriggerJobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, triggerJobs, 1)
+	triggerJobID := triggerJobs[0].ID
+
+	err = s.UpdateTriggerJobWithResults(ctx, triggerJobID, testQuery, make([]*result.CommitMatch, 2))
+	require.NoError(t, err)
+
+	actionJobs, err := s.EnqueueActionJobsForMonitor(ctx, fixtures.monitor.ID, triggerJobID)
+	require.NoError(t, err)
+	require.Len(t, actionJobs, 2)
+
+	// Trigger events of the monitor that complete before the digest is sent are
+	// included in it.
+	var laterTriggerJobID int32
+	err = s.QueryRow(ctx, sqlf.Sprintf(
+		"INSERT INTO cm_trigger_jobs (query, state, search_results) VALUES (%s, 'completed', '[]'::jsonb) RETURNING id",
+		fixtures.query.ID,
+	)).Scan(&laterTriggerJobID)
+	require.NoError(t, err)
+	err = s.UpdateTriggerJobWithResults(ctx, laterTriggerJobID, testQuery, make([]*result.CommitMatch, 3))
+	require.NoError(t, err)
+
+	got, err := s.GetActionJobDigestMetadata(ctx, actionJobs[0].ID)
+	require.NoError(t, err)
+	require.Len(t, got.Results, 5)
+	require.Equal(t, fixtures.monitor.ID, got.MonitorID)
+
+	// Retries of the action job send the same digest, even if more trigger
+	// events have completed since.
+	insertCompletedTriggerJob := func(numResults int) int32 {
+		var id int32
+		err := s.QueryRow(ctx, sqlf.Sprintf(
+			"INSERT INTO cm_trigger_jobs (query, state, search_results) VALUES (%s, 'completed', '[]'::jsonb) RETURNING id",
+			fixtures.query.ID,
+		)).Scan(&id)
+		require.NoError(t, err)
+		err = s.UpdateTriggerJobWithResults(ctx, id, testQuery, make([]*result.CommitMatch, numResults))
+		require.NoError(t, err)
+		return id
+	}
+	nextTriggerJobID := insertCompletedTriggerJob(7)
+
+	got, err = s.GetActionJobDigestMetadata(ctx, actionJobs[0].ID)
+	require.NoError(t, err)
+	require.Len(t, got.Results, 5)
+
+	// The next digest of the action only contains the trigger events that
+	// weren't part of the previous digest.
+	err = s.Exec(ctx, sqlf.Sprintf("UPDATE cm_action_jobs SET state = 'completed'"))
+	require.NoError(t, err)
+	nextActionJobs, err := s.EnqueueActionJobsForMonitor(ctx, fixtures.monitor.ID, nextTriggerJobID)
+	require.NoError(t, err)
+	require.Len(t, nextActionJobs, 2)
+	require.Equal(t, actionJobs[0].Email, nextActionJobs[0].Email)
+
+	got, err = s.GetActionJobDigestMetadata(ctx, nextActionJobs[0].ID)
+	require.NoError(t, err)
+	require.Len(t, got.Results, 7)
+}
+
+func TestGetActionJobDigestMetadataLimit(t *testing.T) {
+	ctx, db, s := newTestStore(t)
+	_, _, userCTX := newTestUser(ctx, t, db)
+	fixtures := s.insertTestMonitor(userCTX, t)
+	err := s.Exec(ctx, sqlf.Sprintf("UPDATE cm_emails SET delivery_schedule = 'DAILY'"))
+	require.NoError(t, err)
+
+	triggerJobs, err := s.EnqueueQueryTriggerJobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, triggerJobs, 1)
+	triggerJobID := triggerJobs[0].ID
+
+	err = s.UpdateTriggerJobWithResults(ctx, triggerJobID, testQuery, make([]*result.CommitMatch, 1))
+	require.NoError(t, err)
+
+	actionJobs, err := s.EnqueueActionJobsForMonitor(ctx, fixtures.monitor.ID, triggerJobID)
+	require.NoError(t, err)
+	require.Len(t, actionJobs, 2)
+
+	err = s.Exec(ctx, sqlf.Sprintf(
+		"INSERT INTO cm_trigger_jobs (query, state, search_results) SELECT %s, 'completed', '[{}]'::jsonb FROM generate_series(1, %s)",
+		fixtures.query.ID,
+		maxDigestTriggerEvents,
+	))
+	require.NoError(t, err)
+
+	// A digest contains the results of at most maxDigestTriggerEvents trigger
+	// events, including the trigger event of the action job.
+	got, err := s.GetActionJobDigestMetadata(ctx, actionJobs[0].ID)
+	require.NoError(t, err)
+	require.Len(t, got.Results, maxDigestTriggerEvents)
+}
+
+// insertCompletedTriggerJob inserts a completed trigger event of the test
+// monitor with the given number of results that finished at the given time.
+func (s *codeMonitorStore) insertCompletedTriggerJob(t *testing.T, fixtures *testFixtures, numResults int, finishedAt time.Time) int32 {
+	t.Helper()
+
+	var id int32
+	err := s.QueryRow(context.Background(), sqlf.Sprintf(
+		"INSERT INTO cm_trigger_jobs (query, state, search_results, finished_at) VALUES (%s, 'completed', '[]'::jsonb, %s) RETURNING id",
+		fixtures.query.ID,
+		finishedAt,
+	)).Scan(&id)
+	require.NoError(t, err)
+	err = s.UpdateTriggerJobWithResults(context.Background(), id, testQuery, make([]*result.CommitMatch, numResults))
+	require.NoError(t, err)
+	return id
+}
+
+func TestGetActionJobDigestMetadataSnoozed(t *testing.T) {
+	ctx, db, s := newTestStore(t)
+	_, _, userCTX := newTestUser(ctx, t, db)
+	fixtures := s.insertTestMonitor(userCTX, t)
+	err := s.Exec(ctx, sqlf.Sprintf("UPDATE cm_emails SET delivery_schedule = 'DAILY'"))
+	require.NoError(t, err)
+
+	digest := func(triggerJobID int32) int {
+		t.Helper()
+		actionJobs, err := s.EnqueueActionJobsForMonitor(ctx, fixtures.monitor.ID, triggerJobID)
+		require.NoError(t, err)
+		require.Len(t, actionJobs, 2)
+		got, err := s.GetActionJobDigestMetadata(ctx, actionJobs[0].ID)
+		require.NoError(t, err)
+		err = s.Exec(ctx, sqlf.Sprintf("UPDATE cm_action_jobs SET state = 'completed'"))
+		require.NoError(t, err)
+		return len(got.Results)
+	}
+
+	require.Equal(t, 1, digest(s.insertCompletedTriggerJob(t, fixtures, 1, s.Now())))
+
+	// The trigger events recorded while the monitor is snoozed are not sent
+	// with the first digest after the snooze ends.
+	for _, numResults := range []int{2, 3} {
+		err := s.MarkTriggerJobSnoozed(ctx, s.insertCompletedTriggerJob(t, fixtures, numResults, s.Now()))
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 5, digest(s.insertCompletedTriggerJob(t, fixtures, 5, s.Now())))
+}
+
+func TestGetActionJobDigestMetadataScheduleChange(t *testing.T) {
+	ctx, db, s := newTestStore(t)
+	_, _, userCTX := newTestUser(ctx, t, db)
+	fixtures := s.insertTestMonitor(userCTX, t)
+
+	now := s.Now()
+	s.now = func() time.Time { return now }
+	setSchedule := func(deliverySchedule string) {
+		t.Helper()
+		_, err := s.UpdateEmailAction(userCTX, fixtures.emails[0].ID, &EmailActionArgs{
+			Enabled:          true,
+			Priority:         "NORMAL",
+			Header:           "test header 1",
+			DeliverySchedule: deliverySchedule,
+		})
+		require.NoError(t, err)
+	}
+	enqueue := func(triggerJobID int32) *ActionJob {
+		t.Helper()
+		actionJobs, err := s.EnqueueActionJobsForMonitor(ctx, fixtures.monitor.ID, triggerJobID)
+		require.NoError(t, err)
+		require.Len(t, actionJobs, 2)
+		require.Equal(t, fixtures.emails[0].ID, *actionJobs[0].Email)
+		return actionJobs[0]
+	}
+	complete := func() {
+		t.Helper()
+		err := s.Exec(ctx, sqlf.Sprintf("UPDATE cm_action_jobs SET state = 'completed'"))
+		require.NoError(t, err)
+	}
+
+	setSchedule(DeliveryScheduleDaily)
+	now = now.Add(time.Hour)
+	actionJob := enqueue(s.insertCompletedTriggerJob(t, fixtures, 1, now))
+	got, err := s.GetActionJobDigestMetadata(ctx, actionJob.ID)
+	require.NoError(t, err)
+	require.Len(t, got.Results, 1)
+	complete()
+
+	// Trigger events are sent immediately after a switch to an immediate
+	// delivery schedule.
+	now = now.Add(time.Hour)
+	setSchedule(DeliveryScheduleImmediate)
+	now = now.Add(time.Hour)
+	enqueue(s.insertCompletedTriggerJob(t, fixtures, 2, now))
+	complete()
+
+	// This trigger event is recorded while an immediate action job is still
+	// queued, so no action job is enqueued for it.
+	s.insertCompletedTriggerJob(t, fixtures, 3, now)
+
+	// The first digest after switching back to a daily delivery schedule
+	// neither includes the trigger event that was sent immediately, nor the
+	// trigger events recorded before the switch.
+	now = now.Add(time.Hour)
+	setSchedule(DeliveryScheduleDaily)
+	now = now.Add(time.Hour)
+	actionJob = enqueue(s.insertCompletedTriggerJob(t, fixtures, 4, now))
+	got, err = s.GetActionJobDigestMetadata(ctx, actionJob.ID)
+	require.NoError(t, err)
+	require.Len(t, got.Results, 4)
+}
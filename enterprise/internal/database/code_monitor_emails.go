@@ -18,10 +18,12 @@ type EmailAction struct {
 	Priority       string
 	Header         string
 	IncludeResults bool
-	CreatedBy      int32
-	CreatedAt      time.Time
-	ChangedBy      int32
-	ChangedAt      time.Time
+	// DeliverySchedule is one of the DeliverySchedule* constants.
+	DeliverySchedule string
+	CreatedBy        int32
+	CreatedAt        time.Time
+	ChangedBy        int32
+	ChangedAt        time.Time
 }
 
 const updateActionEmailFmtStr = `
@@ -30,6 +32,8 @@ SET enabled = %s,
     include_results = %s,
 	priority = %s,
 	header = %s,
+	delivery_schedule = %s,
+	delivery_schedule_changed_at = CASE WHEN delivery_schedule = %s THEN delivery_schedule_changed_at ELSE %s END,
 	changed_by = %s,
 	changed_at = %s
 WHERE
@@ -47,6 +51,8 @@ type EmailActionArgs struct {
 	IncludeResults bool
 	Priority       string
 	Header         string
+	// DeliverySchedule is one of the DeliverySchedule* constants.
+	DeliverySchedule string
 }
 
 func (s *codeMonitorStore) UpdateEmailAction(ctx context.Context, id int64, args *EmailActionArgs) (*EmailAction, error) {
@@ -57,6 +63,9 @@ func (s *codeMonitorStore) UpdateEmailAction(ctx context.Context, id int64, args
 		args.IncludeResults,
 		args.Priority,
 		args.Header,
+		args.DeliverySchedule,
+		args.DeliverySchedule,
+		s.Now(),
 		a.UID,
 		s.Now(),
 		id,
@@ -70,8 +79,8 @@ func (s *codeMonitorStore) UpdateEmailAction(ctx context.Context, id int64, args
 
 const createActionEmailFmtStr = `
 INSERT INTO cm_emails
-(monitor, enabled, include_results, priority, header, created_by, created_at, changed_by, changed_at)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, enabled, include_results, priority, header, delivery_schedule, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
@@ -85,6 +94,7 @@ func (s *codeMonitorStore) CreateEmailAction(ctx context.Context, monitorID int6
 		args.IncludeResults,
 		args.Priority,
 		args.Header,
+		args.DeliverySchedule,
 		a.UID,
 		now,
 		a.UID,
@@ -202,6 +212,7 @@ var emailsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_emails.priority"),
 	sqlf.Sprintf("cm_emails.header"),
 	sqlf.Sprintf("cm_emails.include_results"),
+	sqlf.Sprintf("cm_emails.delivery_schedule"),
 	sqlf.Sprintf("cm_emails.created_by"),
 	sqlf.Sprintf("cm_emails.created_at"),
 	sqlf.Sprintf("cm_emails.changed_by"),
@@ -231,6 +242,7 @@ func scanEmail(scanner dbutil.Scanner) (*EmailAction, error) {
 		&m.Priority,
 		&m.Header,
 		&m.IncludeResults,
+		&m.DeliverySchedule,
 		&m.CreatedBy,
 		&m.CreatedAt,
 		&m.ChangedBy,
@@ -18,19 +18,22 @@ func TestUpdateEmail(t *testing.T) {
 	_ = s.insertTestMonitor(ctx2, t) // user2 also has monitors
 
 	ea, err := s.CreateEmailAction(ctx1, fixtures.monitor.ID, &EmailActionArgs{
-		Priority: "NORMAL",
+		Priority:         "NORMAL",
+		DeliverySchedule: DeliveryScheduleImmediate,
 	})
 	require.NoError(t, err)
 
 	// User1 can update it
 	_, err = s.UpdateEmailAction(ctx1, ea.ID, &EmailActionArgs{
-		Priority: "CRITICAL",
+		Priority:         "CRITICAL",
+		DeliverySchedule: DeliveryScheduleImmediate,
 	})
 	require.NoError(t, err)
 
 	// User2 cannot update it
 	_, err = s.UpdateEmailAction(ctx2, ea.ID, &EmailActionArgs{
-		Priority: "NORMAL",
+		Priority:         "NORMAL",
+		DeliverySchedule: DeliveryScheduleImmediate,
 	})
 	require.Error(t, err)
 
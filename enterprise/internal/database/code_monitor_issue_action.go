@@ -0,0 +1,249 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// IssueAction is a code monitor action that files an issue on the code host of
+// a repository. All events of a monitor are tracked in the same issue: the first
+// event opens it, and later events comment on it.
+type IssueAction struct {
+	ID             int64
+	Monitor        int64
+	Enabled        bool
+	IncludeResults bool
+	RepoID         api.RepoID
+
+	// IssueID and IssueURL identify the issue that tracks the events of the
+	// monitor. They are nil until the first issue is filed.
+	IssueID  *string
+	IssueURL *string
+
+	CreatedBy int32
+	CreatedAt time.Time
+	ChangedBy int32
+	ChangedAt time.Time
+}
+
+const updateIssueActionQuery = `
+UPDATE cm_issue_actions
+SET enabled = %s,
+	include_results = %s,
+	issue_id = CASE WHEN repo_id = %s THEN issue_id ELSE NULL END,
+	issue_url = CASE WHEN repo_id = %s THEN issue_url ELSE NULL END,
+	repo_id = %s,
+	changed_by = %s,
+	changed_at = %s
+WHERE
+	id = %s
+	AND EXISTS (
+		SELECT 1 FROM cm_monitors
+		WHERE cm_monitors.id = cm_issue_actions.monitor
+			AND cm_monitors.namespace_user_id = %s
+	)
+RETURNING %s;
+`
+
+// UpdateIssueAction updates an issue action. If the repository changes, the
+// tracking issue is reset so that the next event opens an issue in the new
+// repository.
+func (s *codeMonitorStore) UpdateIssueAction(ctx context.Context, id int64, enabled, includeResults bool, repoID api.RepoID) (*IssueAction, error) {
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		updateIssueActionQuery,
+		enabled,
+		includeResults,
+		repoID,
+		repoID,
+		repoID,
+		a.UID,
+		s.Now(),
+		id,
+		a.UID,
+		sqlf.Join(issueActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanIssueAction(row)
+}
+
+const updateIssueActionTrackingIssueQuery = `
+UPDATE cm_issue_actions
+SET issue_id = %s,
+	issue_url = %s
+WHERE id = %s
+RETURNING %s;
+`
+
+// UpdateIssueActionTrackingIssue records the issue that tracks the events of the
+// monitor of an issue action.
+func (s *codeMonitorStore) UpdateIssueActionTrackingIssue(ctx context.Context, id int64, issueID, issueURL string) (*IssueAction, error) {
+	q := sqlf.Sprintf(
+		updateIssueActionTrackingIssueQuery,
+		issueID,
+		issueURL,
+		id,
+		sqlf.Join(issueActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanIssueAction(row)
+}
+
+const createIssueActionQuery = `
+INSERT INTO cm_issue_actions
+(monitor, enabled, include_results, repo_id, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) CreateIssueAction(ctx context.Context, monitorID int64, enabled, includeResults bool, repoID api.RepoID) (*IssueAction, error) {
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		createIssueActionQuery,
+		monitorID,
+		enabled,
+		includeResults,
+		repoID,
+		a.UID,
+		now,
+		a.UID,
+		now,
+		sqlf.Join(issueActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanIssueAction(row)
+}
+
+const deleteIssueActionQuery = `
+DELETE FROM cm_issue_actions
+WHERE id in (%s)
+	AND MONITOR = %s
+`
+
+func (s *codeMonitorStore) DeleteIssueActions(ctx context.Context, monitorID int64, issueActionIDs ...int64) error {
+	if len(issueActionIDs) == 0 {
+		return nil
+	}
+
+	deleteIDs := make([]*sqlf.Query, 0, len(issueActionIDs))
+	for _, ids := range issueActionIDs {
+		deleteIDs = append(deleteIDs, sqlf.Sprintf("%d", ids))
+	}
+	q := sqlf.Sprintf(
+		deleteIssueActionQuery,
+		sqlf.Join(deleteIDs, ","),
+		monitorID,
+	)
+
+	return s.Exec(ctx, q)
+}
+
+const countIssueActionsQuery = `
+SELECT COUNT(*)
+FROM cm_issue_actions
+WHERE monitor = %s;
+`
+
+func (s *codeMonitorStore) CountIssueActions(ctx context.Context, monitorID int64) (int, error) {
+	var count int
+	err := s.QueryRow(ctx, sqlf.Sprintf(countIssueActionsQuery, monitorID)).Scan(&count)
+	return count, err
+}
+
+const getIssueActionQuery = `
+SELECT %s -- IssueActionColumns
+FROM cm_issue_actions
+WHERE id = %s
+`
+
+func (s *codeMonitorStore) GetIssueAction(ctx context.Context, id int64) (*IssueAction, error) {
+	q := sqlf.Sprintf(
+		getIssueActionQuery,
+		sqlf.Join(issueActionColumns, ","),
+		id,
+	)
+	row := s.QueryRow(ctx, q)
+	return scanIssueAction(row)
+}
+
+const listIssueActionsQuery = `
+SELECT %s -- IssueActionColumns
+FROM cm_issue_actions
+WHERE %s
+ORDER BY id ASC
+LIMIT %s;
+`
+
+func (s *codeMonitorStore) ListIssueActions(ctx context.Context, opts ListActionsOpts) ([]*IssueAction, error) {
+	q := sqlf.Sprintf(
+		listIssueActionsQuery,
+		sqlf.Join(issueActionColumns, ","),
+		opts.Conds(),
+		opts.Limit(),
+	)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanIssueActions(rows)
+}
+
+// issueActionColumns is the set of columns in the cm_issue_actions table
+// This must be kept in sync with scanIssueAction
+var issueActionColumns = []*sqlf.Query{
+	sqlf.Sprintf("cm_issue_actions.id"),
+	sqlf.Sprintf("cm_issue_actions.monitor"),
+	sqlf.Sprintf("cm_issue_actions.enabled"),
+	sqlf.Sprintf("cm_issue_actions.include_results"),
+	sqlf.Sprintf("cm_issue_actions.repo_id"),
+	sqlf.Sprintf("cm_issue_actions.issue_id"),
+	sqlf.Sprintf("cm_issue_actions.issue_url"),
+	sqlf.Sprintf("cm_issue_actions.created_by"),
+	sqlf.Sprintf("cm_issue_actions.created_at"),
+	sqlf.Sprintf("cm_issue_actions.changed_by"),
+	sqlf.Sprintf("cm_issue_actions.changed_at"),
+}
+
+func scanIssueActions(rows *sql.Rows) ([]*IssueAction, error) {
+	var as []*IssueAction
+	for rows.Next() {
+		a, err := scanIssueAction(rows)
+		if err != nil {
+			return nil, err
+		}
+		as = append(as, a)
+	}
+	return as, rows.Err()
+}
+
+// scanIssueAction scans an IssueAction from a *sql.Row or *sql.Rows.
+// It must be kept in sync with issueActionColumns.
+func scanIssueAction(scanner dbutil.Scanner) (*IssueAction, error) {
+	var a IssueAction
+	err := scanner.Scan(
+		&a.ID,
+		&a.Monitor,
+		&a.Enabled,
+		&a.IncludeResults,
+		&a.RepoID,
+		&a.IssueID,
+		&a.IssueURL,
+		&a.CreatedBy,
+		&a.CreatedAt,
+		&a.ChangedBy,
+		&a.ChangedAt,
+	)
+	return &a, err
+}
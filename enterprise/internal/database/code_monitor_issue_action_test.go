@@ -0,0 +1,136 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCodeMonitorStoreIssueActions(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+
+	insertTestRepo := func(t *testing.T, db database.DB, name string) api.RepoID {
+		t.Helper()
+		err := db.Repos().Create(ctx, &types.Repo{Name: api.RepoName(name)})
+		require.NoError(t, err)
+		r, err := db.Repos().GetByName(ctx, api.RepoName(name))
+		require.NoError(t, err)
+		return r.ID
+	}
+
+	t.Run("CreateThenGet", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+		repoID := insertTestRepo(t, db, "github.com/test/issues")
+
+		action, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, true, false, repoID)
+		require.NoError(t, err)
+		require.Nil(t, action.IssueID)
+
+		got, err := s.GetIssueAction(ctx, action.ID)
+		require.NoError(t, err)
+		require.Equal(t, action, got)
+	})
+
+	t.Run("TrackingIssue", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+		repoID := insertTestRepo(t, db, "github.com/test/issues")
+		otherRepoID := insertTestRepo(t, db, "github.com/test/other")
+
+		action, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, true, false, repoID)
+		require.NoError(t, err)
+
+		tracked, err := s.UpdateIssueActionTrackingIssue(ctx, action.ID, "I_1", "https://github.com/test/issues/issues/1")
+		require.NoError(t, err)
+		require.Equal(t, "I_1", *tracked.IssueID)
+		require.Equal(t, "https://github.com/test/issues/issues/1", *tracked.IssueURL)
+
+		// Updating other fields keeps the tracking issue.
+		updated, err := s.UpdateIssueAction(ctx, action.ID, false, true, repoID)
+		require.NoError(t, err)
+		require.False(t, updated.Enabled)
+		require.True(t, updated.IncludeResults)
+		require.Equal(t, tracked.IssueID, updated.IssueID)
+
+		// Changing the repository resets the tracking issue.
+		updated, err = s.UpdateIssueAction(ctx, action.ID, false, true, otherRepoID)
+		require.NoError(t, err)
+		require.Equal(t, otherRepoID, updated.RepoID)
+		require.Nil(t, updated.IssueID)
+		require.Nil(t, updated.IssueURL)
+	})
+
+	t.Run("CreateDeleteCountList", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+		repoID := insertTestRepo(t, db, "github.com/test/issues")
+
+		action1, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, true, false, repoID)
+		require.NoError(t, err)
+		_, err = s.CreateIssueAction(ctx, fixtures.monitor.ID, true, false, repoID)
+		require.NoError(t, err)
+
+		count, err := s.CountIssueActions(ctx, fixtures.monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		err = s.DeleteIssueActions(ctx, fixtures.monitor.ID, action1.ID)
+		require.NoError(t, err)
+
+		_, err = s.GetIssueAction(ctx, action1.ID)
+		require.Error(t, err)
+
+		actions, err := s.ListIssueActions(ctx, ListActionsOpts{MonitorID: &fixtures.monitor.ID})
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+	})
+
+	t.Run("Update permissions", func(t *testing.T) {
+		ctx, db, s := newTestStore(t)
+		uid1 := insertTestUser(ctx, t, db, "u1", false)
+		ctx1 := actor.WithActor(ctx, actor.FromUser(uid1))
+		uid2 := insertTestUser(ctx, t, db, "u2", false)
+		ctx2 := actor.WithActor(ctx, actor.FromUser(uid2))
+		fixtures := s.insertTestMonitor(ctx1, t)
+		_ = s.insertTestMonitor(ctx2, t)
+		repoID := insertTestRepo(t, db, "github.com/test/issues")
+
+		ia, err := s.CreateIssueAction(ctx1, fixtures.monitor.ID, true, true, repoID)
+		require.NoError(t, err)
+
+		// User1 can update it
+		_, err = s.UpdateIssueAction(ctx1, ia.ID, false, true, repoID)
+		require.NoError(t, err)
+
+		// User2 cannot update it
+		_, err = s.UpdateIssueAction(ctx2, ia.ID, true, true, repoID)
+		require.Error(t, err)
+
+		ia, err = s.GetIssueAction(ctx1, ia.ID)
+		require.NoError(t, err)
+		require.False(t, ia.Enabled)
+	})
+}
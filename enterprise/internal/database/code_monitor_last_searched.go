@@ -56,3 +56,58 @@ func (s *codeMonitorStore) GetLastSearched(ctx context.Context, monitorID int64,
 	}
 	return commitOIDs, err
 }
+
+func (s *codeMonitorStore) UpsertLastSearchedMatches(ctx context.Context, monitorID int64, repoID api.RepoID, commitOIDs, matchKeys []string) error {
+	rawQuery := `
+	INSERT INTO cm_last_searched (monitor_id, repo_id, commit_oids, match_keys)
+	VALUES (%s, %s, %s, %s)
+	ON CONFLICT (monitor_id, repo_id) DO UPDATE
+	SET commit_oids = %s,
+		match_keys = %s
+	`
+
+	// Appease non-null constraint on columns
+	if commitOIDs == nil {
+		commitOIDs = []string{}
+	}
+	if matchKeys == nil {
+		matchKeys = []string{}
+	}
+	q := sqlf.Sprintf(
+		rawQuery,
+		monitorID,
+		int64(repoID),
+		pq.StringArray(commitOIDs),
+		pq.StringArray(matchKeys),
+		pq.StringArray(commitOIDs),
+		pq.StringArray(matchKeys),
+	)
+	return s.Exec(ctx, q)
+}
+
+func (s *codeMonitorStore) ListLastSearchedMatches(ctx context.Context, monitorID int64) (map[api.RepoID][]string, error) {
+	rawQuery := `
+	SELECT repo_id, match_keys
+	FROM cm_last_searched
+	WHERE monitor_id = %s
+	`
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(rawQuery, monitorID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matchKeys := make(map[api.RepoID][]string)
+	for rows.Next() {
+		var (
+			repoID int64
+			keys   []string
+		)
+		if err := rows.Scan(&repoID, (*pq.StringArray)(&keys)); err != nil {
+			return nil, err
+		}
+		matchKeys[api.RepoID(repoID)] = keys
+	}
+	return matchKeys, rows.Err()
+}
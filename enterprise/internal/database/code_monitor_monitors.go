@@ -20,6 +20,15 @@ type Monitor struct {
 	Description string
 	Enabled     bool
 	UserID      int32
+
+	// SnoozedUntil, if set, is the time until which no actions are run for
+	// the trigger events of the monitor.
+	SnoozedUntil *time.Time
+}
+
+// Snoozed returns whether the monitor is snoozed at the given time.
+func (m *Monitor) Snoozed(now time.Time) bool {
+	return m.SnoozedUntil != nil && now.Before(*m.SnoozedUntil)
 }
 
 // monitorColumns are the columns needed to fill out a Monitor.
@@ -33,6 +42,7 @@ var monitorColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_monitors.description"),
 	sqlf.Sprintf("cm_monitors.enabled"),
 	sqlf.Sprintf("cm_monitors.namespace_user_id"),
+	sqlf.Sprintf("cm_monitors.snoozed_until"),
 }
 
 type MonitorArgs struct {
@@ -127,6 +137,35 @@ func (s *codeMonitorStore) UpdateMonitorEnabled(ctx context.Context, id int64, e
 	return scanMonitor(row)
 }
 
+const snoozeCodeMonitorFmtStr = `
+UPDATE cm_monitors
+SET snoozed_until = %s,
+	changed_by = %s,
+	changed_at = %s
+WHERE
+	id = %s
+	AND namespace_user_id = %s
+RETURNING %s -- monitorColumns
+`
+
+// SnoozeMonitor sets the time until which no actions are run for the trigger
+// events of the monitor. A nil until unsnoozes the monitor.
+func (s *codeMonitorStore) SnoozeMonitor(ctx context.Context, id int64, until *time.Time) (*Monitor, error) {
+	actorUID := actor.FromContext(ctx).UID
+	q := sqlf.Sprintf(
+		snoozeCodeMonitorFmtStr,
+		until,
+		actorUID,
+		s.Now(),
+		id,
+		actorUID,
+		sqlf.Join(monitorColumns, ", "),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanMonitor(row)
+}
+
 const deleteMonitorFmtStr = `
 DELETE FROM cm_monitors
 WHERE id = %s
@@ -239,6 +278,7 @@ func scanMonitor(scanner dbutil.Scanner) (*Monitor, error) {
 		&m.Description,
 		&m.Enabled,
 		&m.UserID,
+		&m.SnoozedUntil,
 	)
 	return m, err
 }
@@ -17,6 +17,8 @@ type SlackWebhookAction struct {
 	Enabled        bool
 	URL            string
 	IncludeResults bool
+	// DeliverySchedule is one of the DeliverySchedule* constants.
+	DeliverySchedule string
 
 	CreatedBy int32
 	CreatedAt time.Time
@@ -29,6 +31,8 @@ UPDATE cm_slack_webhooks
 SET enabled = %s,
 	include_results = %s,
 	url = %s,
+	delivery_schedule = %s,
+	delivery_schedule_changed_at = CASE WHEN delivery_schedule = %s THEN delivery_schedule_changed_at ELSE %s END,
 	changed_by = %s,
 	changed_at = %s
 WHERE
@@ -41,13 +45,16 @@ WHERE
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) UpdateSlackWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url string) (*SlackWebhookAction, error) {
+func (s *codeMonitorStore) UpdateSlackWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url, deliverySchedule string) (*SlackWebhookAction, error) {
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
 		updateSlackWebhookActionQuery,
 		enabled,
 		includeResults,
 		url,
+		deliverySchedule,
+		deliverySchedule,
+		s.Now(),
 		a.UID,
 		s.Now(),
 		id,
@@ -61,12 +68,12 @@ func (s *codeMonitorStore) UpdateSlackWebhookAction(ctx context.Context, id int6
 
 const createSlackWebhookActionQuery = `
 INSERT INTO cm_slack_webhooks
-(monitor, enabled, include_results, url, created_by, created_at, changed_by, changed_at)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, enabled, include_results, url, delivery_schedule, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) CreateSlackWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*SlackWebhookAction, error) {
+func (s *codeMonitorStore) CreateSlackWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url, deliverySchedule string) (*SlackWebhookAction, error) {
 	now := s.Now()
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
@@ -75,6 +82,7 @@ func (s *codeMonitorStore) CreateSlackWebhookAction(ctx context.Context, monitor
 		enabled,
 		includeResults,
 		url,
+		deliverySchedule,
 		a.UID,
 		now,
 		a.UID,
@@ -169,6 +177,7 @@ var slackWebhookActionColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_slack_webhooks.enabled"),
 	sqlf.Sprintf("cm_slack_webhooks.url"),
 	sqlf.Sprintf("cm_slack_webhooks.include_results"),
+	sqlf.Sprintf("cm_slack_webhooks.delivery_schedule"),
 	sqlf.Sprintf("cm_slack_webhooks.created_by"),
 	sqlf.Sprintf("cm_slack_webhooks.created_at"),
 	sqlf.Sprintf("cm_slack_webhooks.changed_by"),
@@ -197,6 +206,7 @@ func scanSlackWebhookAction(scanner dbutil.Scanner) (*SlackWebhookAction, error)
 		&w.Enabled,
 		&w.URL,
 		&w.IncludeResults,
+		&w.DeliverySchedule,
 		&w.CreatedBy,
 		&w.CreatedAt,
 		&w.ChangedBy,
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -25,16 +26,18 @@ func (s *codeMonitorStore) insertTestMonitor(ctx context.Context, t *testing.T)
 
 	actions := []*EmailActionArgs{
 		{
-			Enabled:        true,
-			IncludeResults: false,
-			Priority:       "NORMAL",
-			Header:         "test header 1",
+			Enabled:          true,
+			IncludeResults:   false,
+			Priority:         "NORMAL",
+			DeliverySchedule: DeliveryScheduleImmediate,
+			Header:           "test header 1",
 		},
 		{
-			Enabled:        true,
-			IncludeResults: false,
-			Priority:       "CRITICAL",
-			Header:         "test header 2",
+			Enabled:          true,
+			IncludeResults:   false,
+			Priority:         "CRITICAL",
+			DeliverySchedule: DeliveryScheduleImmediate,
+			Header:           "test header 2",
 		},
 	}
 	// Create monitor.
@@ -53,10 +56,11 @@ func (s *codeMonitorStore) insertTestMonitor(ctx context.Context, t *testing.T)
 
 	for i, a := range actions {
 		fixtures.emails[i], err = s.CreateEmailAction(ctx, fixtures.monitor.ID, &EmailActionArgs{
-			Enabled:        a.Enabled,
-			IncludeResults: a.IncludeResults,
-			Priority:       a.Priority,
-			Header:         a.Header,
+			Enabled:          a.Enabled,
+			IncludeResults:   a.IncludeResults,
+			Priority:         a.Priority,
+			Header:           a.Header,
+			DeliverySchedule: a.DeliverySchedule,
 		})
 		require.NoError(t, err)
 
@@ -89,3 +93,26 @@ func populateCodeMonitorFixtures(t *testing.T, db EnterpriseDB) codeMonitorTestF
 	require.NoError(t, err)
 	return codeMonitorTestFixtures{User: u, Monitor: m, Query: q, Repo: r}
 }
+
+func TestSnoozeMonitor(t *testing.T) {
+	ctx, db, s := newTestStore(t)
+	_, _, ctx1 := newTestUser(ctx, t, db)
+	uid2 := insertTestUser(ctx, t, db, "u2", false)
+	ctx2 := actor.WithActor(ctx, actor.FromUser(uid2))
+	fixtures := s.insertTestMonitor(ctx1, t)
+	require.False(t, fixtures.monitor.Snoozed(s.Now()))
+
+	// User2 cannot snooze it
+	until := s.Now().Add(time.Hour)
+	_, err := s.SnoozeMonitor(ctx2, fixtures.monitor.ID, &until)
+	require.Error(t, err)
+
+	m, err := s.SnoozeMonitor(ctx1, fixtures.monitor.ID, &until)
+	require.NoError(t, err)
+	require.True(t, m.Snoozed(s.Now()))
+	require.False(t, m.Snoozed(until))
+
+	m, err = s.SnoozeMonitor(ctx1, fixtures.monitor.ID, nil)
+	require.NoError(t, err)
+	require.Nil(t, m.SnoozedUntil)
+}
@@ -8,8 +8,10 @@ import (
 
 	"github.com/keegancsmith/sqlf"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 type TriggerJob struct {
@@ -21,6 +23,10 @@ type TriggerJob struct {
 
 	SearchResults []*result.CommitMatch
 
+	// FileResults are the new file and symbol matches found by code monitors
+	// that search file contents or symbols rather than commits.
+	FileResults []*result.FileMatch
+
 	// Fields demanded for any dbworker.
 	State          string
 	FailureMessage *string
@@ -85,6 +91,37 @@ func (s *codeMonitorStore) UpdateTriggerJobWithResults(ctx context.Context, trig
 	return s.Store.Exec(ctx, sqlf.Sprintf(logSearchFmtStr, queryString, resultsJSON, triggerJobID))
 }
 
+const logFileSearchFmtStr = `
+UPDATE cm_trigger_jobs
+SET query_string = %s,
+    search_results = '[]'::jsonb,
+    file_results = %s
+WHERE id = %s
+`
+
+// UpdateTriggerJobWithFileResults records the query and the new file and symbol
+// matches found by a trigger job.
+func (s *codeMonitorStore) UpdateTriggerJobWithFileResults(ctx context.Context, triggerJobID int32, queryString string, results []*result.FileMatch) error {
+	resultsJSON, err := marshalFileResults(results)
+	if err != nil {
+		return err
+	}
+	return s.Store.Exec(ctx, sqlf.Sprintf(logFileSearchFmtStr, queryString, resultsJSON, triggerJobID))
+}
+
+const markTriggerJobSnoozedFmtStr = `
+UPDATE cm_trigger_jobs
+SET snoozed = TRUE
+WHERE id = %s
+`
+
+// MarkTriggerJobSnoozed records that the monitor of a trigger job was snoozed
+// when its results were found, so that they are never sent, not even with a
+// later digest.
+func (s *codeMonitorStore) MarkTriggerJobSnoozed(ctx context.Context, triggerJobID int32) error {
+	return s.Store.Exec(ctx, sqlf.Sprintf(markTriggerJobSnoozedFmtStr, triggerJobID))
+}
+
 const deleteOldJobLogsFmtStr = `
 DELETE FROM cm_trigger_jobs
 WHERE finished_at < (NOW() - (%s * '1 day'::interval));
@@ -146,7 +183,7 @@ func (s *codeMonitorStore) ListQueryTriggerJobs(ctx context.Context, opts ListTr
 const totalCountEventsForQueryIDInt64FmtStr = `
 SELECT COUNT(*)
 FROM cm_trigger_jobs
-WHERE ((state = 'completed' AND jsonb_array_length(search_results) > 0) OR (state != 'completed'))
+WHERE ((state = 'completed' AND (jsonb_array_length(search_results) > 0 OR jsonb_array_length(file_results) > 0)) OR (state != 'completed'))
 AND query = %s
 `
 
@@ -173,13 +210,14 @@ func scanTriggerJobs(rows *sql.Rows) ([]*TriggerJob, error) {
 }
 
 func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
-	var resultsJSON []byte
+	var resultsJSON, fileResultsJSON []byte
 	m := &TriggerJob{}
 	err := scanner.Scan(
 		&m.ID,
 		&m.Query,
 		&m.QueryString,
 		&resultsJSON,
+		&fileResultsJSON,
 		&m.State,
 		&m.FailureMessage,
 		&m.StartedAt,
@@ -199,6 +237,11 @@ func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
 		}
 	}
 
+	m.FileResults, err = unmarshalFileResults(fileResultsJSON)
+	if err != nil {
+		return nil, err
+	}
+
 	return m, nil
 }
 
@@ -207,6 +250,7 @@ var TriggerJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_trigger_jobs.query"),
 	sqlf.Sprintf("cm_trigger_jobs.query_string"),
 	sqlf.Sprintf("cm_trigger_jobs.search_results"),
+	sqlf.Sprintf("cm_trigger_jobs.file_results"),
 	sqlf.Sprintf("cm_trigger_jobs.state"),
 	sqlf.Sprintf("cm_trigger_jobs.failure_message"),
 	sqlf.Sprintf("cm_trigger_jobs.started_at"),
@@ -216,3 +260,66 @@ var TriggerJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_trigger_jobs.num_failures"),
 	sqlf.Sprintf("cm_trigger_jobs.log_contents"),
 }
+
+// fileResult is the stored form of a file or symbol match. result.FileMatch does
+// not serialize its repository, commit, or symbols, all of which are needed to
+// render the match in the actions of a code monitor.
+type fileResult struct {
+	RepoID       api.RepoID
+	RepoName     api.RepoName
+	CommitID     api.CommitID
+	Path         string
+	ChunkMatches result.ChunkMatches
+	Symbols      []result.Symbol
+	LimitHit     bool
+}
+
+func marshalFileResults(fms []*result.FileMatch) ([]byte, error) {
+	// Store an empty array rather than null, which jsonb_array_length rejects
+	results := make([]fileResult, 0, len(fms))
+	for _, fm := range fms {
+		symbols := make([]result.Symbol, 0, len(fm.Symbols))
+		for _, sym := range fm.Symbols {
+			symbols = append(symbols, sym.Symbol)
+		}
+		results = append(results, fileResult{
+			RepoID:       fm.Repo.ID,
+			RepoName:     fm.Repo.Name,
+			CommitID:     fm.CommitID,
+			Path:         fm.Path,
+			ChunkMatches: fm.ChunkMatches,
+			Symbols:      symbols,
+			LimitHit:     fm.LimitHit,
+		})
+	}
+	return json.Marshal(results)
+}
+
+func unmarshalFileResults(data []byte) ([]*result.FileMatch, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var results []fileResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, err
+	}
+
+	fms := make([]*result.FileMatch, 0, len(results))
+	for _, r := range results {
+		fm := &result.FileMatch{
+			File: result.File{
+				Repo:     types.MinimalRepo{ID: r.RepoID, Name: r.RepoName},
+				CommitID: r.CommitID,
+				Path:     r.Path,
+			},
+			ChunkMatches: r.ChunkMatches,
+			LimitHit:     r.LimitHit,
+		}
+		for _, sym := range r.Symbols {
+			fm.Symbols = append(fm.Symbols, &result.SymbolMatch{Symbol: sym, File: &fm.File})
+		}
+		fms = append(fms, fm)
+	}
+	return fms, nil
+}
@@ -34,6 +34,7 @@ type CodeMonitorStore interface {
 	CreateMonitor(ctx context.Context, args MonitorArgs) (*Monitor, error)
 	UpdateMonitor(ctx context.Context, id int64, args MonitorArgs) (*Monitor, error)
 	UpdateMonitorEnabled(ctx context.Context, id int64, enabled bool) (*Monitor, error)
+	SnoozeMonitor(ctx context.Context, id int64, until *time.Time) (*Monitor, error)
 	DeleteMonitor(ctx context.Context, id int64) error
 	GetMonitor(ctx context.Context, monitorID int64) (*Monitor, error)
 	ListMonitors(context.Context, ListMonitorsOpts) ([]*Monitor, error)
@@ -50,6 +51,8 @@ type CodeMonitorStore interface {
 	CountQueryTriggerJobs(ctx context.Context, queryID int64) (int32, error)
 
 	UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, results []*result.CommitMatch) error
+	UpdateTriggerJobWithFileResults(ctx context.Context, triggerJobID int32, queryString string, results []*result.FileMatch) error
+	MarkTriggerJobSnoozed(ctx context.Context, triggerJobID int32) error
 	DeleteOldTriggerJobs(ctx context.Context, retentionInDays int) error
 
 	UpdateEmailAction(_ context.Context, id int64, _ *EmailActionArgs) (*EmailAction, error)
@@ -65,13 +68,21 @@ type CodeMonitorStore interface {
 	GetWebhookAction(ctx context.Context, id int64) (*WebhookAction, error)
 	ListWebhookActions(context.Context, ListActionsOpts) ([]*WebhookAction, error)
 
-	UpdateSlackWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url string) (*SlackWebhookAction, error)
-	CreateSlackWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*SlackWebhookAction, error)
+	UpdateSlackWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url, deliverySchedule string) (*SlackWebhookAction, error)
+	CreateSlackWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url, deliverySchedule string) (*SlackWebhookAction, error)
 	DeleteSlackWebhookActions(ctx context.Context, monitorID int64, ids ...int64) error
 	CountSlackWebhookActions(ctx context.Context, monitorID int64) (int, error)
 	GetSlackWebhookAction(ctx context.Context, id int64) (*SlackWebhookAction, error)
 	ListSlackWebhookActions(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)
 
+	UpdateIssueAction(_ context.Context, id int64, enabled, includeResults bool, repoID api.RepoID) (*IssueAction, error)
+	UpdateIssueActionTrackingIssue(_ context.Context, id int64, issueID, issueURL string) (*IssueAction, error)
+	CreateIssueAction(ctx context.Context, monitorID int64, enabled, includeResults bool, repoID api.RepoID) (*IssueAction, error)
+	DeleteIssueActions(ctx context.Context, monitorID int64, ids ...int64) error
+	CountIssueActions(ctx context.Context, monitorID int64) (int, error)
+	GetIssueAction(ctx context.Context, id int64) (*IssueAction, error)
+	ListIssueActions(context.Context, ListActionsOpts) ([]*IssueAction, error)
+
 	CreateRecipient(ctx context.Context, emailID int64, userID, orgID *int32) (*Recipient, error)
 	DeleteRecipients(ctx context.Context, emailID int64) error
 	ListRecipients(context.Context, ListRecipientsOpts) ([]*Recipient, error)
@@ -80,6 +91,7 @@ type CodeMonitorStore interface {
 	ListActionJobs(context.Context, ListActionJobsOpts) ([]*ActionJob, error)
 	CountActionJobs(context.Context, ListActionJobsOpts) (int, error)
 	GetActionJobMetadata(ctx context.Context, jobID int32) (*ActionJobMetadata, error)
+	GetActionJobDigestMetadata(ctx context.Context, jobID int32) (*ActionJobMetadata, error)
 	GetActionJob(ctx context.Context, jobID int32) (*ActionJob, error)
 	EnqueueActionJobsForMonitor(ctx context.Context, monitorID int64, triggerJob int32) ([]*ActionJob, error)
 
@@ -90,6 +102,12 @@ type CodeMonitorStore interface {
 	HasAnyLastSearched(ctx context.Context, monitorID int64) (bool, error)
 	UpsertLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID, lastSearched []string) error
 	GetLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error)
+
+	// UpsertLastSearchedMatches and ListLastSearchedMatches store and return the
+	// snapshot of file and symbol matches per repo that code monitors of file and
+	// symbol queries compare against to find new matches.
+	UpsertLastSearchedMatches(ctx context.Context, monitorID int64, repoID api.RepoID, commitOIDs, matchKeys []string) error
+	ListLastSearchedMatches(ctx context.Context, monitorID int64) (map[api.RepoID][]string, error)
 }
 
 // codeMonitorStore exposes methods to read and write codemonitors domain models
@@ -179,16 +197,18 @@ func (s *TestStore) InsertTestMonitor(ctx context.Context, t *testing.T) (*Monit
 
 	actions := []*EmailActionArgs{
 		{
-			Enabled:        true,
-			IncludeResults: false,
-			Priority:       "NORMAL",
-			Header:         "test header 1",
+			Enabled:          true,
+			IncludeResults:   false,
+			Priority:         "NORMAL",
+			DeliverySchedule: DeliveryScheduleImmediate,
+			Header:           "test header 1",
 		},
 		{
-			Enabled:        true,
-			IncludeResults: false,
-			Priority:       "CRITICAL",
-			Header:         "test header 2",
+			Enabled:          true,
+			IncludeResults:   false,
+			Priority:         "CRITICAL",
+			DeliverySchedule: DeliveryScheduleImmediate,
+			Header:           "test header 2",
 		},
 	}
 
@@ -211,10 +231,11 @@ func (s *TestStore) InsertTestMonitor(ctx context.Context, t *testing.T) (*Monit
 
 	for _, a := range actions {
 		e, err := s.CreateEmailAction(ctx, m.ID, &EmailActionArgs{
-			Enabled:        a.Enabled,
-			IncludeResults: a.IncludeResults,
-			Priority:       a.Priority,
-			Header:         a.Header,
+			Enabled:          a.Enabled,
+			IncludeResults:   a.IncludeResults,
+			Priority:         a.Priority,
+			Header:           a.Header,
+			DeliverySchedule: a.DeliverySchedule,
 		})
 		if err != nil {
 			return nil, err
@@ -35,6 +35,9 @@ type MockCodeMonitorStore struct {
 	// CountActionJobsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountActionJobs.
 	CountActionJobsFunc *CodeMonitorStoreCountActionJobsFunc
+	// CountIssueActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method CountIssueActions.
+	CountIssueActionsFunc *CodeMonitorStoreCountIssueActionsFunc
 	// CountMonitorsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountMonitors.
 	CountMonitorsFunc *CodeMonitorStoreCountMonitorsFunc
@@ -53,6 +56,9 @@ type MockCodeMonitorStore struct {
 	// CreateEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateEmailAction.
 	CreateEmailActionFunc *CodeMonitorStoreCreateEmailActionFunc
+	// CreateIssueActionFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateIssueAction.
+	CreateIssueActionFunc *CodeMonitorStoreCreateIssueActionFunc
 	// CreateMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateMonitor.
 	CreateMonitorFunc *CodeMonitorStoreCreateMonitorFunc
@@ -71,6 +77,9 @@ type MockCodeMonitorStore struct {
 	// DeleteEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteEmailActions.
 	DeleteEmailActionsFunc *CodeMonitorStoreDeleteEmailActionsFunc
+	// DeleteIssueActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteIssueActions.
+	DeleteIssueActionsFunc *CodeMonitorStoreDeleteIssueActionsFunc
 	// DeleteMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteMonitor.
 	DeleteMonitorFunc *CodeMonitorStoreDeleteMonitorFunc
@@ -103,12 +112,19 @@ type MockCodeMonitorStore struct {
 	// GetActionJobFunc is an instance of a mock function object controlling
 	// the behavior of the method GetActionJob.
 	GetActionJobFunc *CodeMonitorStoreGetActionJobFunc
+	// GetActionJobDigestMetadataFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetActionJobDigestMetadata.
+	GetActionJobDigestMetadataFunc *CodeMonitorStoreGetActionJobDigestMetadataFunc
 	// GetActionJobMetadataFunc is an instance of a mock function object
 	// controlling the behavior of the method GetActionJobMetadata.
 	GetActionJobMetadataFunc *CodeMonitorStoreGetActionJobMetadataFunc
 	// GetEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetEmailAction.
 	GetEmailActionFunc *CodeMonitorStoreGetEmailActionFunc
+	// GetIssueActionFunc is an instance of a mock function object
+	// controlling the behavior of the method GetIssueAction.
+	GetIssueActionFunc *CodeMonitorStoreGetIssueActionFunc
 	// GetLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method GetLastSearched.
 	GetLastSearchedFunc *CodeMonitorStoreGetLastSearchedFunc
@@ -140,6 +156,12 @@ type MockCodeMonitorStore struct {
 	// ListEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListEmailActions.
 	ListEmailActionsFunc *CodeMonitorStoreListEmailActionsFunc
+	// ListIssueActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListIssueActions.
+	ListIssueActionsFunc *CodeMonitorStoreListIssueActionsFunc
+	// ListLastSearchedMatchesFunc is an instance of a mock function object
+	// controlling the behavior of the method ListLastSearchedMatches.
+	ListLastSearchedMatchesFunc *CodeMonitorStoreListLastSearchedMatchesFunc
 	// ListMonitorsFunc is an instance of a mock function object controlling
 	// the behavior of the method ListMonitors.
 	ListMonitorsFunc *CodeMonitorStoreListMonitorsFunc
@@ -155,6 +177,9 @@ type MockCodeMonitorStore struct {
 	// ListWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListWebhookActions.
 	ListWebhookActionsFunc *CodeMonitorStoreListWebhookActionsFunc
+	// MarkTriggerJobSnoozedFunc is an instance of a mock function object
+	// controlling the behavior of the method MarkTriggerJobSnoozed.
+	MarkTriggerJobSnoozedFunc *CodeMonitorStoreMarkTriggerJobSnoozedFunc
 	// NowFunc is an instance of a mock function object controlling the
 	// behavior of the method Now.
 	NowFunc *CodeMonitorStoreNowFunc
@@ -165,12 +190,22 @@ type MockCodeMonitorStore struct {
 	// SetQueryTriggerNextRunFunc is an instance of a mock function object
 	// controlling the behavior of the method SetQueryTriggerNextRun.
 	SetQueryTriggerNextRunFunc *CodeMonitorStoreSetQueryTriggerNextRunFunc
+	// SnoozeMonitorFunc is an instance of a mock function object
+	// controlling the behavior of the method SnoozeMonitor.
+	SnoozeMonitorFunc *CodeMonitorStoreSnoozeMonitorFunc
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *CodeMonitorStoreTransactFunc
 	// UpdateEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateEmailAction.
 	UpdateEmailActionFunc *CodeMonitorStoreUpdateEmailActionFunc
+	// UpdateIssueActionFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateIssueAction.
+	UpdateIssueActionFunc *CodeMonitorStoreUpdateIssueActionFunc
+	// UpdateIssueActionTrackingIssueFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UpdateIssueActionTrackingIssue.
+	UpdateIssueActionTrackingIssueFunc *CodeMonitorStoreUpdateIssueActionTrackingIssueFunc
 	// UpdateMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateMonitor.
 	UpdateMonitorFunc *CodeMonitorStoreUpdateMonitorFunc
@@ -183,6 +218,10 @@ type MockCodeMonitorStore struct {
 	// UpdateSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateSlackWebhookAction.
 	UpdateSlackWebhookActionFunc *CodeMonitorStoreUpdateSlackWebhookActionFunc
+	// UpdateTriggerJobWithFileResultsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UpdateTriggerJobWithFileResults.
+	UpdateTriggerJobWithFileResultsFunc *CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc
 	// UpdateTriggerJobWithResultsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// UpdateTriggerJobWithResults.
@@ -193,6 +232,10 @@ type MockCodeMonitorStore struct {
 	// UpsertLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method UpsertLastSearched.
 	UpsertLastSearchedFunc *CodeMonitorStoreUpsertLastSearchedFunc
+	// UpsertLastSearchedMatchesFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UpsertLastSearchedMatches.
+	UpsertLastSearchedMatchesFunc *CodeMonitorStoreUpsertLastSearchedMatchesFunc
 }
 
 // NewMockCodeMonitorStore creates a new mock of the CodeMonitorStore
@@ -210,6 +253,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CountIssueActionsFunc: &CodeMonitorStoreCountIssueActionsFunc{
+			defaultHook: func(context.Context, int64) (r0 int, r1 error) {
+				return
+			},
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: func(context.Context, int32) (r0 int32, r1 error) {
 				return
@@ -240,6 +288,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CreateIssueActionFunc: &CodeMonitorStoreCreateIssueActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, api.RepoID) (r0 *IssueAction, r1 error) {
+				return
+			},
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: func(context.Context, MonitorArgs) (r0 *Monitor, r1 error) {
 				return
@@ -256,7 +309,7 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		CreateSlackWebhookActionFunc: &CodeMonitorStoreCreateSlackWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *SlackWebhookAction, r1 error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, string) (r0 *SlackWebhookAction, r1 error) {
 				return
 			},
 		},
@@ -270,6 +323,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		DeleteIssueActionsFunc: &CodeMonitorStoreDeleteIssueActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
+				return
+			},
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: func(context.Context, int64) (r0 error) {
 				return
@@ -320,6 +378,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetActionJobDigestMetadataFunc: &CodeMonitorStoreGetActionJobDigestMetadataFunc{
+			defaultHook: func(context.Context, int32) (r0 *ActionJobMetadata, r1 error) {
+				return
+			},
+		},
 		GetActionJobMetadataFunc: &CodeMonitorStoreGetActionJobMetadataFunc{
 			defaultHook: func(context.Context, int32) (r0 *ActionJobMetadata, r1 error) {
 				return
@@ -330,6 +393,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetIssueActionFunc: &CodeMonitorStoreGetIssueActionFunc{
+			defaultHook: func(context.Context, int64) (r0 *IssueAction, r1 error) {
+				return
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) (r0 []string, r1 error) {
 				return
@@ -380,6 +448,16 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		ListIssueActionsFunc: &CodeMonitorStoreListIssueActionsFunc{
+			defaultHook: func(context.Context, ListActionsOpts) (r0 []*IssueAction, r1 error) {
+				return
+			},
+		},
+		ListLastSearchedMatchesFunc: &CodeMonitorStoreListLastSearchedMatchesFunc{
+			defaultHook: func(context.Context, int64) (r0 map[api.RepoID][]string, r1 error) {
+				return
+			},
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: func(context.Context, ListMonitorsOpts) (r0 []*Monitor, r1 error) {
 				return
@@ -405,6 +483,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		MarkTriggerJobSnoozedFunc: &CodeMonitorStoreMarkTriggerJobSnoozedFunc{
+			defaultHook: func(context.Context, int32) (r0 error) {
+				return
+			},
+		},
 		NowFunc: &CodeMonitorStoreNowFunc{
 			defaultHook: func() (r0 time.Time) {
 				return
@@ -420,6 +503,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		SnoozeMonitorFunc: &CodeMonitorStoreSnoozeMonitorFunc{
+			defaultHook: func(context.Context, int64, *time.Time) (r0 *Monitor, r1 error) {
+				return
+			},
+		},
 		TransactFunc: &CodeMonitorStoreTransactFunc{
 			defaultHook: func(context.Context) (r0 CodeMonitorStore, r1 error) {
 				return
@@ -430,6 +518,16 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpdateIssueActionFunc: &CodeMonitorStoreUpdateIssueActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, api.RepoID) (r0 *IssueAction, r1 error) {
+				return
+			},
+		},
+		UpdateIssueActionTrackingIssueFunc: &CodeMonitorStoreUpdateIssueActionTrackingIssueFunc{
+			defaultHook: func(context.Context, int64, string, string) (r0 *IssueAction, r1 error) {
+				return
+			},
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: func(context.Context, int64, MonitorArgs) (r0 *Monitor, r1 error) {
 				return
@@ -446,7 +544,12 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		UpdateSlackWebhookActionFunc: &CodeMonitorStoreUpdateSlackWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *SlackWebhookAction, r1 error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, string) (r0 *SlackWebhookAction, r1 error) {
+				return
+			},
+		},
+		UpdateTriggerJobWithFileResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc{
+			defaultHook: func(context.Context, int32, string, []*result.FileMatch) (r0 error) {
 				return
 			},
 		},
@@ -465,6 +568,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpsertLastSearchedMatchesFunc: &CodeMonitorStoreUpsertLastSearchedMatchesFunc{
+			defaultHook: func(context.Context, int64, api.RepoID, []string, []string) (r0 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -482,6 +590,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CountActionJobs")
 			},
 		},
+		CountIssueActionsFunc: &CodeMonitorStoreCountIssueActionsFunc{
+			defaultHook: func(context.Context, int64) (int, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CountIssueActions")
+			},
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: func(context.Context, int32) (int32, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CountMonitors")
@@ -512,6 +625,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateEmailAction")
 			},
 		},
+		CreateIssueActionFunc: &CodeMonitorStoreCreateIssueActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CreateIssueAction")
+			},
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: func(context.Context, MonitorArgs) (*Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateMonitor")
@@ -528,7 +646,7 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		CreateSlackWebhookActionFunc: &CodeMonitorStoreCreateSlackWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, string) (*SlackWebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateSlackWebhookAction")
 			},
 		},
@@ -542,6 +660,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteEmailActions")
 			},
 		},
+		DeleteIssueActionsFunc: &CodeMonitorStoreDeleteIssueActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteIssueActions")
+			},
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: func(context.Context, int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteMonitor")
@@ -592,6 +715,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetActionJob")
 			},
 		},
+		GetActionJobDigestMetadataFunc: &CodeMonitorStoreGetActionJobDigestMetadataFunc{
+			defaultHook: func(context.Context, int32) (*ActionJobMetadata, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetActionJobDigestMetadata")
+			},
+		},
 		GetActionJobMetadataFunc: &CodeMonitorStoreGetActionJobMetadataFunc{
 			defaultHook: func(context.Context, int32) (*ActionJobMetadata, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetActionJobMetadata")
@@ -602,6 +730,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetEmailAction")
 			},
 		},
+		GetIssueActionFunc: &CodeMonitorStoreGetIssueActionFunc{
+			defaultHook: func(context.Context, int64) (*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetIssueAction")
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) ([]string, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetLastSearched")
@@ -652,6 +785,16 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ListEmailActions")
 			},
 		},
+		ListIssueActionsFunc: &CodeMonitorStoreListIssueActionsFunc{
+			defaultHook: func(context.Context, ListActionsOpts) ([]*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListIssueActions")
+			},
+		},
+		ListLastSearchedMatchesFunc: &CodeMonitorStoreListLastSearchedMatchesFunc{
+			defaultHook: func(context.Context, int64) (map[api.RepoID][]string, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListLastSearchedMatches")
+			},
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.ListMonitors")
@@ -677,6 +820,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ListWebhookActions")
 			},
 		},
+		MarkTriggerJobSnoozedFunc: &CodeMonitorStoreMarkTriggerJobSnoozedFunc{
+			defaultHook: func(context.Context, int32) error {
+				panic("unexpected invocation of MockCodeMonitorStore.MarkTriggerJobSnoozed")
+			},
+		},
 		NowFunc: &CodeMonitorStoreNowFunc{
 			defaultHook: func() time.Time {
 				panic("unexpected invocation of MockCodeMonitorStore.Now")
@@ -692,6 +840,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.SetQueryTriggerNextRun")
 			},
 		},
+		SnoozeMonitorFunc: &CodeMonitorStoreSnoozeMonitorFunc{
+			defaultHook: func(context.Context, int64, *time.Time) (*Monitor, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.SnoozeMonitor")
+			},
+		},
 		TransactFunc: &CodeMonitorStoreTransactFunc{
 			defaultHook: func(context.Context) (CodeMonitorStore, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.Transact")
@@ -702,6 +855,16 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateEmailAction")
 			},
 		},
+		UpdateIssueActionFunc: &CodeMonitorStoreUpdateIssueActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateIssueAction")
+			},
+		},
+		UpdateIssueActionTrackingIssueFunc: &CodeMonitorStoreUpdateIssueActionTrackingIssueFunc{
+			defaultHook: func(context.Context, int64, string, string) (*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateIssueActionTrackingIssue")
+			},
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: func(context.Context, int64, MonitorArgs) (*Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateMonitor")
@@ -718,10 +881,15 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		UpdateSlackWebhookActionFunc: &CodeMonitorStoreUpdateSlackWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, string) (*SlackWebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateSlackWebhookAction")
 			},
 		},
+		UpdateTriggerJobWithFileResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc{
+			defaultHook: func(context.Context, int32, string, []*result.FileMatch) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithFileResults")
+			},
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithResults")
@@ -737,6 +905,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastSearched")
 			},
 		},
+		UpsertLastSearchedMatchesFunc: &CodeMonitorStoreUpsertLastSearchedMatchesFunc{
+			defaultHook: func(context.Context, int64, api.RepoID, []string, []string) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastSearchedMatches")
+			},
+		},
 	}
 }
 
@@ -751,6 +924,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		CountActionJobsFunc: &CodeMonitorStoreCountActionJobsFunc{
 			defaultHook: i.CountActionJobs,
 		},
+		CountIssueActionsFunc: &CodeMonitorStoreCountIssueActionsFunc{
+			defaultHook: i.CountIssueActions,
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: i.CountMonitors,
 		},
@@ -769,6 +945,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		CreateEmailActionFunc: &CodeMonitorStoreCreateEmailActionFunc{
 			defaultHook: i.CreateEmailAction,
 		},
+		CreateIssueActionFunc: &CodeMonitorStoreCreateIssueActionFunc{
+			defaultHook: i.CreateIssueAction,
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: i.CreateMonitor,
 		},
@@ -787,6 +966,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		DeleteEmailActionsFunc: &CodeMonitorStoreDeleteEmailActionsFunc{
 			defaultHook: i.DeleteEmailActions,
 		},
+		DeleteIssueActionsFunc: &CodeMonitorStoreDeleteIssueActionsFunc{
+			defaultHook: i.DeleteIssueActions,
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: i.DeleteMonitor,
 		},
@@ -817,12 +999,18 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		GetActionJobFunc: &CodeMonitorStoreGetActionJobFunc{
 			defaultHook: i.GetActionJob,
 		},
+		GetActionJobDigestMetadataFunc: &CodeMonitorStoreGetActionJobDigestMetadataFunc{
+			defaultHook: i.GetActionJobDigestMetadata,
+		},
 		GetActionJobMetadataFunc: &CodeMonitorStoreGetActionJobMetadataFunc{
 			defaultHook: i.GetActionJobMetadata,
 		},
 		GetEmailActionFunc: &CodeMonitorStoreGetEmailActionFunc{
 			defaultHook: i.GetEmailAction,
 		},
+		GetIssueActionFunc: &CodeMonitorStoreGetIssueActionFunc{
+			defaultHook: i.GetIssueAction,
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: i.GetLastSearched,
 		},
@@ -853,6 +1041,12 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		ListEmailActionsFunc: &CodeMonitorStoreListEmailActionsFunc{
 			defaultHook: i.ListEmailActions,
 		},
+		ListIssueActionsFunc: &CodeMonitorStoreListIssueActionsFunc{
+			defaultHook: i.ListIssueActions,
+		},
+		ListLastSearchedMatchesFunc: &CodeMonitorStoreListLastSearchedMatchesFunc{
+			defaultHook: i.ListLastSearchedMatches,
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: i.ListMonitors,
 		},
@@ -868,6 +1062,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		ListWebhookActionsFunc: &CodeMonitorStoreListWebhookActionsFunc{
 			defaultHook: i.ListWebhookActions,
 		},
+		MarkTriggerJobSnoozedFunc: &CodeMonitorStoreMarkTriggerJobSnoozedFunc{
+			defaultHook: i.MarkTriggerJobSnoozed,
+		},
 		NowFunc: &CodeMonitorStoreNowFunc{
 			defaultHook: i.Now,
 		},
@@ -877,12 +1074,21 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		SetQueryTriggerNextRunFunc: &CodeMonitorStoreSetQueryTriggerNextRunFunc{
 			defaultHook: i.SetQueryTriggerNextRun,
 		},
+		SnoozeMonitorFunc: &CodeMonitorStoreSnoozeMonitorFunc{
+			defaultHook: i.SnoozeMonitor,
+		},
 		TransactFunc: &CodeMonitorStoreTransactFunc{
 			defaultHook: i.Transact,
 		},
 		UpdateEmailActionFunc: &CodeMonitorStoreUpdateEmailActionFunc{
 			defaultHook: i.UpdateEmailAction,
 		},
+		UpdateIssueActionFunc: &CodeMonitorStoreUpdateIssueActionFunc{
+			defaultHook: i.UpdateIssueAction,
+		},
+		UpdateIssueActionTrackingIssueFunc: &CodeMonitorStoreUpdateIssueActionTrackingIssueFunc{
+			defaultHook: i.UpdateIssueActionTrackingIssue,
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: i.UpdateMonitor,
 		},
@@ -895,6 +1101,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		UpdateSlackWebhookActionFunc: &CodeMonitorStoreUpdateSlackWebhookActionFunc{
 			defaultHook: i.UpdateSlackWebhookAction,
 		},
+		UpdateTriggerJobWithFileResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc{
+			defaultHook: i.UpdateTriggerJobWithFileResults,
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: i.UpdateTriggerJobWithResults,
 		},
@@ -904,6 +1113,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: i.UpsertLastSearched,
 		},
+		UpsertLastSearchedMatchesFunc: &CodeMonitorStoreUpsertLastSearchedMatchesFunc{
+			defaultHook: i.UpsertLastSearchedMatches,
+		},
 	}
 }
 
@@ -1117,6 +1329,117 @@ func (c CodeMonitorStoreCountActionJobsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreCountIssueActionsFunc describes the behavior when the
+// CountIssueActions method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreCountIssueActionsFunc struct {
+	defaultHook func(context.Context, int64) (int, error)
+	hooks       []func(context.Context, int64) (int, error)
+	history     []CodeMonitorStoreCountIssueActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// CountIssueActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CountIssueActions(v0 context.Context, v1 int64) (int, error) {
+	r0, r1 := m.CountIssueActionsFunc.nextHook()(v0, v1)
+	m.CountIssueActionsFunc.appendCall(CodeMonitorStoreCountIssueActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CountIssueActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreCountIssueActionsFunc) SetDefaultHook(hook func(context.Context, int64) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountIssueActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreCountIssueActionsFunc) PushHook(hook func(context.Context, int64) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCountIssueActionsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCountIssueActionsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCountIssueActionsFunc) nextHook() func(context.Context, int64) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCountIssueActionsFunc) appendCall(r0 CodeMonitorStoreCountIssueActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreCountIssueActionsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreCountIssueActionsFunc) History() []CodeMonitorStoreCountIssueActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCountIssueActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCountIssueActionsFuncCall is an object that describes an
+// invocation of method CountIssueActions on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreCountIssueActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCountIssueActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCountIssueActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreCountMonitorsFunc describes the behavior when the
 // CountMonitors method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -1787,36 +2110,37 @@ func (c CodeMonitorStoreCreateEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreCreateMonitorFunc describes the behavior when the
-// CreateMonitor method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreCreateIssueActionFunc describes the behavior when the
+// CreateIssueAction method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreCreateMonitorFunc struct {
-	defaultHook func(context.Context, MonitorArgs) (*Monitor, error)
-	hooks       []func(context.Context, MonitorArgs) (*Monitor, error)
-	history     []CodeMonitorStoreCreateMonitorFuncCall
+type CodeMonitorStoreCreateIssueActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)
+	hooks       []func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)
+	history     []CodeMonitorStoreCreateIssueActionFuncCall
 	mutex       sync.Mutex
 }
 
-// CreateMonitor delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) CreateMonitor(v0 context.Context, v1 MonitorArgs) (*Monitor, error) {
-	r0, r1 := m.CreateMonitorFunc.nextHook()(v0, v1)
-	m.CreateMonitorFunc.appendCall(CodeMonitorStoreCreateMonitorFuncCall{v0, v1, r0, r1})
+// CreateIssueAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateIssueAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 api.RepoID) (*IssueAction, error) {
+	r0, r1 := m.CreateIssueActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.CreateIssueActionFunc.appendCall(CodeMonitorStoreCreateIssueActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the CreateMonitor method
-// of the parent MockCodeMonitorStore instance is invoked and the hook queue
-// is empty.
-func (f *CodeMonitorStoreCreateMonitorFunc) SetDefaultHook(hook func(context.Context, MonitorArgs) (*Monitor, error)) {
+// SetDefaultHook sets function that is called when the CreateIssueAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreCreateIssueActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CreateMonitor method of the parent MockCodeMonitorStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *CodeMonitorStoreCreateMonitorFunc) PushHook(hook func(context.Context, MonitorArgs) (*Monitor, error)) {
+// CreateIssueAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreCreateIssueActionFunc) PushHook(hook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1824,20 +2148,20 @@ func (f *CodeMonitorStoreCreateMonitorFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreCreateMonitorFunc) SetDefaultReturn(r0 *Monitor, r1 error) {
-	f.SetDefaultHook(func(context.Context, MonitorArgs) (*Monitor, error) {
+func (f *CodeMonitorStoreCreateIssueActionFunc) SetDefaultReturn(r0 *IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreCreateMonitorFunc) PushReturn(r0 *Monitor, r1 error) {
-	f.PushHook(func(context.Context, MonitorArgs) (*Monitor, error) {
+func (f *CodeMonitorStoreCreateIssueActionFunc) PushReturn(r0 *IssueAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreCreateMonitorFunc) nextHook() func(context.Context, MonitorArgs) (*Monitor, error) {
+func (f *CodeMonitorStoreCreateIssueActionFunc) nextHook() func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1850,36 +2174,45 @@ func (f *CodeMonitorStoreCreateMonitorFunc) nextHook() func(context.Context, Mon
 	return hook
 }
 
-func (f *CodeMonitorStoreCreateMonitorFunc) appendCall(r0 CodeMonitorStoreCreateMonitorFuncCall) {
+func (f *CodeMonitorStoreCreateIssueActionFunc) appendCall(r0 CodeMonitorStoreCreateIssueActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreCreateMonitorFuncCall
+// History returns a sequence of CodeMonitorStoreCreateIssueActionFuncCall
 // objects describing the invocations of this function.
-func (f *CodeMonitorStoreCreateMonitorFunc) History() []CodeMonitorStoreCreateMonitorFuncCall {
+func (f *CodeMonitorStoreCreateIssueActionFunc) History() []CodeMonitorStoreCreateIssueActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreCreateMonitorFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreCreateIssueActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreCreateMonitorFuncCall is an object that describes an
-// invocation of method CreateMonitor on an instance of
+// CodeMonitorStoreCreateIssueActionFuncCall is an object that describes an
+// invocation of method CreateIssueAction on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreCreateMonitorFuncCall struct {
+type CodeMonitorStoreCreateIssueActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 MonitorArgs
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 api.RepoID
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *Monitor
+	Result0 *IssueAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -1887,39 +2220,149 @@ type CodeMonitorStoreCreateMonitorFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreCreateMonitorFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c CodeMonitorStoreCreateIssueActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreCreateMonitorFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreCreateIssueActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreCreateQueryTriggerFunc describes the behavior when the
-// CreateQueryTrigger method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreCreateMonitorFunc describes the behavior when the
+// CreateMonitor method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreCreateQueryTriggerFunc struct {
-	defaultHook func(context.Context, int64, string) (*QueryTrigger, error)
-	hooks       []func(context.Context, int64, string) (*QueryTrigger, error)
-	history     []CodeMonitorStoreCreateQueryTriggerFuncCall
+type CodeMonitorStoreCreateMonitorFunc struct {
+	defaultHook func(context.Context, MonitorArgs) (*Monitor, error)
+	hooks       []func(context.Context, MonitorArgs) (*Monitor, error)
+	history     []CodeMonitorStoreCreateMonitorFuncCall
 	mutex       sync.Mutex
 }
 
-// CreateQueryTrigger delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) CreateQueryTrigger(v0 context.Context, v1 int64, v2 string) (*QueryTrigger, error) {
-	r0, r1 := m.CreateQueryTriggerFunc.nextHook()(v0, v1, v2)
-	m.CreateQueryTriggerFunc.appendCall(CodeMonitorStoreCreateQueryTriggerFuncCall{v0, v1, v2, r0, r1})
+// CreateMonitor delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateMonitor(v0 context.Context, v1 MonitorArgs) (*Monitor, error) {
+	r0, r1 := m.CreateMonitorFunc.nextHook()(v0, v1)
+	m.CreateMonitorFunc.appendCall(CodeMonitorStoreCreateMonitorFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the CreateQueryTrigger
-// method of the parent MockCodeMonitorStore instance is invoked and the
-// hook queue is empty.
-func (f *CodeMonitorStoreCreateQueryTriggerFunc) SetDefaultHook(hook func(context.Context, int64, string) (*QueryTrigger, error)) {
-	f.defaultHook = hook
+// SetDefaultHook sets function that is called when the CreateMonitor method
+// of the parent MockCodeMonitorStore instance is invoked and the hook queue
+// is empty.
+func (f *CodeMonitorStoreCreateMonitorFunc) SetDefaultHook(hook func(context.Context, MonitorArgs) (*Monitor, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateMonitor method of the parent MockCodeMonitorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeMonitorStoreCreateMonitorFunc) PushHook(hook func(context.Context, MonitorArgs) (*Monitor, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCreateMonitorFunc) SetDefaultReturn(r0 *Monitor, r1 error) {
+	f.SetDefaultHook(func(context.Context, MonitorArgs) (*Monitor, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCreateMonitorFunc) PushReturn(r0 *Monitor, r1 error) {
+	f.PushHook(func(context.Context, MonitorArgs) (*Monitor, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCreateMonitorFunc) nextHook() func(context.Context, MonitorArgs) (*Monitor, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCreateMonitorFunc) appendCall(r0 CodeMonitorStoreCreateMonitorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreCreateMonitorFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreCreateMonitorFunc) History() []CodeMonitorStoreCreateMonitorFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCreateMonitorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCreateMonitorFuncCall is an object that describes an
+// invocation of method CreateMonitor on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreCreateMonitorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 MonitorArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *Monitor
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCreateMonitorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCreateMonitorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreCreateQueryTriggerFunc describes the behavior when the
+// CreateQueryTrigger method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreCreateQueryTriggerFunc struct {
+	defaultHook func(context.Context, int64, string) (*QueryTrigger, error)
+	hooks       []func(context.Context, int64, string) (*QueryTrigger, error)
+	history     []CodeMonitorStoreCreateQueryTriggerFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateQueryTrigger delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateQueryTrigger(v0 context.Context, v1 int64, v2 string) (*QueryTrigger, error) {
+	r0, r1 := m.CreateQueryTriggerFunc.nextHook()(v0, v1, v2)
+	m.CreateQueryTriggerFunc.appendCall(CodeMonitorStoreCreateQueryTriggerFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateQueryTrigger
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreCreateQueryTriggerFunc) SetDefaultHook(hook func(context.Context, int64, string) (*QueryTrigger, error)) {
+	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
@@ -2132,24 +2575,24 @@ func (c CodeMonitorStoreCreateRecipientFuncCall) Results() []interface{} {
 // the CreateSlackWebhookAction method of the parent MockCodeMonitorStore
 // instance is invoked.
 type CodeMonitorStoreCreateSlackWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)
+	defaultHook func(context.Context, int64, bool, bool, string, string) (*SlackWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string, string) (*SlackWebhookAction, error)
 	history     []CodeMonitorStoreCreateSlackWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
 // CreateSlackWebhookAction delegates to the next hook function in the queue
 // and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) CreateSlackWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*SlackWebhookAction, error) {
-	r0, r1 := m.CreateSlackWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.CreateSlackWebhookActionFunc.appendCall(CodeMonitorStoreCreateSlackWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+func (m *MockCodeMonitorStore) CreateSlackWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string, v5 string) (*SlackWebhookAction, error) {
+	r0, r1 := m.CreateSlackWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.CreateSlackWebhookActionFunc.appendCall(CodeMonitorStoreCreateSlackWebhookActionFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
 // CreateSlackWebhookAction method of the parent MockCodeMonitorStore
 // instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string, string) (*SlackWebhookAction, error)) {
 	f.defaultHook = hook
 }
 
@@ -2158,7 +2601,7 @@ func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) SetDefaultHook(hook func(
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string, string) (*SlackWebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2167,19 +2610,19 @@ func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) PushHook(hook func(contex
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) SetDefaultReturn(r0 *SlackWebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string, string) (*SlackWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) PushReturn(r0 *SlackWebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string, string) (*SlackWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string, string) (*SlackWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2229,6 +2672,9 @@ type CodeMonitorStoreCreateSlackWebhookActionFuncCall struct {
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *SlackWebhookAction
@@ -2240,7 +2686,7 @@ type CodeMonitorStoreCreateSlackWebhookActionFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c CodeMonitorStoreCreateSlackWebhookActionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
@@ -2480,6 +2926,124 @@ func (c CodeMonitorStoreDeleteEmailActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreDeleteIssueActionsFunc describes the behavior when the
+// DeleteIssueActions method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreDeleteIssueActionsFunc struct {
+	defaultHook func(context.Context, int64, ...int64) error
+	hooks       []func(context.Context, int64, ...int64) error
+	history     []CodeMonitorStoreDeleteIssueActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteIssueActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteIssueActions(v0 context.Context, v1 int64, v2 ...int64) error {
+	r0 := m.DeleteIssueActionsFunc.nextHook()(v0, v1, v2...)
+	m.DeleteIssueActionsFunc.appendCall(CodeMonitorStoreDeleteIssueActionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteIssueActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteIssueActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) nextHook() func(context.Context, int64, ...int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) appendCall(r0 CodeMonitorStoreDeleteIssueActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreDeleteIssueActionsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) History() []CodeMonitorStoreDeleteIssueActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteIssueActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteIssueActionsFuncCall is an object that describes an
+// invocation of method DeleteIssueActions on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreDeleteIssueActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg2 []int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c CodeMonitorStoreDeleteIssueActionsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg2 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteIssueActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreDeleteMonitorFunc describes the behavior when the
 // DeleteMonitor method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -3582,37 +4146,37 @@ func (c CodeMonitorStoreGetActionJobFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreGetActionJobMetadataFunc describes the behavior when the
-// GetActionJobMetadata method of the parent MockCodeMonitorStore instance
-// is invoked.
-type CodeMonitorStoreGetActionJobMetadataFunc struct {
+// CodeMonitorStoreGetActionJobDigestMetadataFunc describes the behavior
+// when the GetActionJobDigestMetadata method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreGetActionJobDigestMetadataFunc struct {
 	defaultHook func(context.Context, int32) (*ActionJobMetadata, error)
 	hooks       []func(context.Context, int32) (*ActionJobMetadata, error)
-	history     []CodeMonitorStoreGetActionJobMetadataFuncCall
+	history     []CodeMonitorStoreGetActionJobDigestMetadataFuncCall
 	mutex       sync.Mutex
 }
 
-// GetActionJobMetadata delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) GetActionJobMetadata(v0 context.Context, v1 int32) (*ActionJobMetadata, error) {
-	r0, r1 := m.GetActionJobMetadataFunc.nextHook()(v0, v1)
-	m.GetActionJobMetadataFunc.appendCall(CodeMonitorStoreGetActionJobMetadataFuncCall{v0, v1, r0, r1})
+// GetActionJobDigestMetadata delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetActionJobDigestMetadata(v0 context.Context, v1 int32) (*ActionJobMetadata, error) {
+	r0, r1 := m.GetActionJobDigestMetadataFunc.nextHook()(v0, v1)
+	m.GetActionJobDigestMetadataFunc.appendCall(CodeMonitorStoreGetActionJobDigestMetadataFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetActionJobMetadata
-// method of the parent MockCodeMonitorStore instance is invoked and the
-// hook queue is empty.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) SetDefaultHook(hook func(context.Context, int32) (*ActionJobMetadata, error)) {
+// SetDefaultHook sets function that is called when the
+// GetActionJobDigestMetadata method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreGetActionJobDigestMetadataFunc) SetDefaultHook(hook func(context.Context, int32) (*ActionJobMetadata, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetActionJobMetadata method of the parent MockCodeMonitorStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushHook(hook func(context.Context, int32) (*ActionJobMetadata, error)) {
+// GetActionJobDigestMetadata method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreGetActionJobDigestMetadataFunc) PushHook(hook func(context.Context, int32) (*ActionJobMetadata, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3620,20 +4184,20 @@ func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushHook(hook func(context.Co
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) SetDefaultReturn(r0 *ActionJobMetadata, r1 error) {
+func (f *CodeMonitorStoreGetActionJobDigestMetadataFunc) SetDefaultReturn(r0 *ActionJobMetadata, r1 error) {
 	f.SetDefaultHook(func(context.Context, int32) (*ActionJobMetadata, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushReturn(r0 *ActionJobMetadata, r1 error) {
+func (f *CodeMonitorStoreGetActionJobDigestMetadataFunc) PushReturn(r0 *ActionJobMetadata, r1 error) {
 	f.PushHook(func(context.Context, int32) (*ActionJobMetadata, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) nextHook() func(context.Context, int32) (*ActionJobMetadata, error) {
+func (f *CodeMonitorStoreGetActionJobDigestMetadataFunc) nextHook() func(context.Context, int32) (*ActionJobMetadata, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3646,28 +4210,28 @@ func (f *CodeMonitorStoreGetActionJobMetadataFunc) nextHook() func(context.Conte
 	return hook
 }
 
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) appendCall(r0 CodeMonitorStoreGetActionJobMetadataFuncCall) {
+func (f *CodeMonitorStoreGetActionJobDigestMetadataFunc) appendCall(r0 CodeMonitorStoreGetActionJobDigestMetadataFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// CodeMonitorStoreGetActionJobMetadataFuncCall objects describing the
+// CodeMonitorStoreGetActionJobDigestMetadataFuncCall objects describing the
 // invocations of this function.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) History() []CodeMonitorStoreGetActionJobMetadataFuncCall {
+func (f *CodeMonitorStoreGetActionJobDigestMetadataFunc) History() []CodeMonitorStoreGetActionJobDigestMetadataFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreGetActionJobMetadataFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreGetActionJobDigestMetadataFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreGetActionJobMetadataFuncCall is an object that describes
-// an invocation of method GetActionJobMetadata on an instance of
-// MockCodeMonitorStore.
-type CodeMonitorStoreGetActionJobMetadataFuncCall struct {
+// CodeMonitorStoreGetActionJobDigestMetadataFuncCall is an object that
+// describes an invocation of method GetActionJobDigestMetadata on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreGetActionJobDigestMetadataFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -3684,21 +4248,133 @@ type CodeMonitorStoreGetActionJobMetadataFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreGetActionJobDigestMetadataFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreGetActionJobDigestMetadataFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreGetEmailActionFunc describes the behavior when the
-// GetEmailAction method of the parent MockCodeMonitorStore instance is
-// invoked.
-type CodeMonitorStoreGetEmailActionFunc struct {
-	defaultHook func(context.Context, int64) (*EmailAction, error)
+// CodeMonitorStoreGetActionJobMetadataFunc describes the behavior when the
+// GetActionJobMetadata method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreGetActionJobMetadataFunc struct {
+	defaultHook func(context.Context, int32) (*ActionJobMetadata, error)
+	hooks       []func(context.Context, int32) (*ActionJobMetadata, error)
+	history     []CodeMonitorStoreGetActionJobMetadataFuncCall
+	mutex       sync.Mutex
+}
+
+// GetActionJobMetadata delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetActionJobMetadata(v0 context.Context, v1 int32) (*ActionJobMetadata, error) {
+	r0, r1 := m.GetActionJobMetadataFunc.nextHook()(v0, v1)
+	m.GetActionJobMetadataFunc.appendCall(CodeMonitorStoreGetActionJobMetadataFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetActionJobMetadata
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) SetDefaultHook(hook func(context.Context, int32) (*ActionJobMetadata, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetActionJobMetadata method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushHook(hook func(context.Context, int32) (*ActionJobMetadata, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) SetDefaultReturn(r0 *ActionJobMetadata, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*ActionJobMetadata, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushReturn(r0 *ActionJobMetadata, r1 error) {
+	f.PushHook(func(context.Context, int32) (*ActionJobMetadata, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) nextHook() func(context.Context, int32) (*ActionJobMetadata, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) appendCall(r0 CodeMonitorStoreGetActionJobMetadataFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreGetActionJobMetadataFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) History() []CodeMonitorStoreGetActionJobMetadataFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetActionJobMetadataFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetActionJobMetadataFuncCall is an object that describes
+// an invocation of method GetActionJobMetadata on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetActionJobMetadataFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *ActionJobMetadata
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreGetEmailActionFunc describes the behavior when the
+// GetEmailAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreGetEmailActionFunc struct {
+	defaultHook func(context.Context, int64) (*EmailAction, error)
 	hooks       []func(context.Context, int64) (*EmailAction, error)
 	history     []CodeMonitorStoreGetEmailActionFuncCall
 	mutex       sync.Mutex
@@ -3804,6 +4480,116 @@ func (c CodeMonitorStoreGetEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreGetIssueActionFunc describes the behavior when the
+// GetIssueAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreGetIssueActionFunc struct {
+	defaultHook func(context.Context, int64) (*IssueAction, error)
+	hooks       []func(context.Context, int64) (*IssueAction, error)
+	history     []CodeMonitorStoreGetIssueActionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetIssueAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetIssueAction(v0 context.Context, v1 int64) (*IssueAction, error) {
+	r0, r1 := m.GetIssueActionFunc.nextHook()(v0, v1)
+	m.GetIssueActionFunc.appendCall(CodeMonitorStoreGetIssueActionFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetIssueAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreGetIssueActionFunc) SetDefaultHook(hook func(context.Context, int64) (*IssueAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetIssueAction method of the parent MockCodeMonitorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeMonitorStoreGetIssueActionFunc) PushHook(hook func(context.Context, int64) (*IssueAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetIssueActionFunc) SetDefaultReturn(r0 *IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*IssueAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetIssueActionFunc) PushReturn(r0 *IssueAction, r1 error) {
+	f.PushHook(func(context.Context, int64) (*IssueAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetIssueActionFunc) nextHook() func(context.Context, int64) (*IssueAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetIssueActionFunc) appendCall(r0 CodeMonitorStoreGetIssueActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreGetIssueActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreGetIssueActionFunc) History() []CodeMonitorStoreGetIssueActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetIssueActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetIssueActionFuncCall is an object that describes an
+// invocation of method GetIssueAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetIssueActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *IssueAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetIssueActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetIssueActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreGetLastSearchedFunc describes the behavior when the
 // GetLastSearched method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -4904,36 +5690,37 @@ func (c CodeMonitorStoreListEmailActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreListMonitorsFunc describes the behavior when the
-// ListMonitors method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreListIssueActionsFunc describes the behavior when the
+// ListIssueActions method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreListMonitorsFunc struct {
-	defaultHook func(context.Context, ListMonitorsOpts) ([]*Monitor, error)
-	hooks       []func(context.Context, ListMonitorsOpts) ([]*Monitor, error)
-	history     []CodeMonitorStoreListMonitorsFuncCall
+type CodeMonitorStoreListIssueActionsFunc struct {
+	defaultHook func(context.Context, ListActionsOpts) ([]*IssueAction, error)
+	hooks       []func(context.Context, ListActionsOpts) ([]*IssueAction, error)
+	history     []CodeMonitorStoreListIssueActionsFuncCall
 	mutex       sync.Mutex
 }
 
-// ListMonitors delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) ListMonitors(v0 context.Context, v1 ListMonitorsOpts) ([]*Monitor, error) {
-	r0, r1 := m.ListMonitorsFunc.nextHook()(v0, v1)
-	m.ListMonitorsFunc.appendCall(CodeMonitorStoreListMonitorsFuncCall{v0, v1, r0, r1})
+// ListIssueActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListIssueActions(v0 context.Context, v1 ListActionsOpts) ([]*IssueAction, error) {
+	r0, r1 := m.ListIssueActionsFunc.nextHook()(v0, v1)
+	m.ListIssueActionsFunc.appendCall(CodeMonitorStoreListIssueActionsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ListMonitors method
-// of the parent MockCodeMonitorStore instance is invoked and the hook queue
-// is empty.
-func (f *CodeMonitorStoreListMonitorsFunc) SetDefaultHook(hook func(context.Context, ListMonitorsOpts) ([]*Monitor, error)) {
+// SetDefaultHook sets function that is called when the ListIssueActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreListIssueActionsFunc) SetDefaultHook(hook func(context.Context, ListActionsOpts) ([]*IssueAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListMonitors method of the parent MockCodeMonitorStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *CodeMonitorStoreListMonitorsFunc) PushHook(hook func(context.Context, ListMonitorsOpts) ([]*Monitor, error)) {
+// ListIssueActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreListIssueActionsFunc) PushHook(hook func(context.Context, ListActionsOpts) ([]*IssueAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4941,20 +5728,20 @@ func (f *CodeMonitorStoreListMonitorsFunc) PushHook(hook func(context.Context, L
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreListMonitorsFunc) SetDefaultReturn(r0 []*Monitor, r1 error) {
-	f.SetDefaultHook(func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
+func (f *CodeMonitorStoreListIssueActionsFunc) SetDefaultReturn(r0 []*IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListActionsOpts) ([]*IssueAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreListMonitorsFunc) PushReturn(r0 []*Monitor, r1 error) {
-	f.PushHook(func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
+func (f *CodeMonitorStoreListIssueActionsFunc) PushReturn(r0 []*IssueAction, r1 error) {
+	f.PushHook(func(context.Context, ListActionsOpts) ([]*IssueAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreListMonitorsFunc) nextHook() func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
+func (f *CodeMonitorStoreListIssueActionsFunc) nextHook() func(context.Context, ListActionsOpts) ([]*IssueAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4967,35 +5754,36 @@ func (f *CodeMonitorStoreListMonitorsFunc) nextHook() func(context.Context, List
 	return hook
 }
 
-func (f *CodeMonitorStoreListMonitorsFunc) appendCall(r0 CodeMonitorStoreListMonitorsFuncCall) {
+func (f *CodeMonitorStoreListIssueActionsFunc) appendCall(r0 CodeMonitorStoreListIssueActionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreListMonitorsFuncCall
+// History returns a sequence of CodeMonitorStoreListIssueActionsFuncCall
 // objects describing the invocations of this function.
-func (f *CodeMonitorStoreListMonitorsFunc) History() []CodeMonitorStoreListMonitorsFuncCall {
+func (f *CodeMonitorStoreListIssueActionsFunc) History() []CodeMonitorStoreListIssueActionsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreListMonitorsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreListIssueActionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreListMonitorsFuncCall is an object that describes an
-// invocation of method ListMonitors on an instance of MockCodeMonitorStore.
-type CodeMonitorStoreListMonitorsFuncCall struct {
+// CodeMonitorStoreListIssueActionsFuncCall is an object that describes an
+// invocation of method ListIssueActions on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreListIssueActionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 ListMonitorsOpts
+	Arg1 ListActionsOpts
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*Monitor
+	Result0 []*IssueAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -5003,35 +5791,256 @@ type CodeMonitorStoreListMonitorsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreListMonitorsFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreListIssueActionsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreListMonitorsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreListIssueActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreListQueryTriggerJobsFunc describes the behavior when the
-// ListQueryTriggerJobs method of the parent MockCodeMonitorStore instance
-// is invoked.
-type CodeMonitorStoreListQueryTriggerJobsFunc struct {
-	defaultHook func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)
-	hooks       []func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)
-	history     []CodeMonitorStoreListQueryTriggerJobsFuncCall
+// CodeMonitorStoreListLastSearchedMatchesFunc describes the behavior when
+// the ListLastSearchedMatches method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreListLastSearchedMatchesFunc struct {
+	defaultHook func(context.Context, int64) (map[api.RepoID][]string, error)
+	hooks       []func(context.Context, int64) (map[api.RepoID][]string, error)
+	history     []CodeMonitorStoreListLastSearchedMatchesFuncCall
 	mutex       sync.Mutex
 }
 
-// ListQueryTriggerJobs delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) ListQueryTriggerJobs(v0 context.Context, v1 ListTriggerJobsOpts) ([]*TriggerJob, error) {
-	r0, r1 := m.ListQueryTriggerJobsFunc.nextHook()(v0, v1)
-	m.ListQueryTriggerJobsFunc.appendCall(CodeMonitorStoreListQueryTriggerJobsFuncCall{v0, v1, r0, r1})
+// ListLastSearchedMatches delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListLastSearchedMatches(v0 context.Context, v1 int64) (map[api.RepoID][]string, error) {
+	r0, r1 := m.ListLastSearchedMatchesFunc.nextHook()(v0, v1)
+	m.ListLastSearchedMatchesFunc.appendCall(CodeMonitorStoreListLastSearchedMatchesFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ListQueryTriggerJobs
+// SetDefaultHook sets function that is called when the
+// ListLastSearchedMatches method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreListLastSearchedMatchesFunc) SetDefaultHook(hook func(context.Context, int64) (map[api.RepoID][]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListLastSearchedMatches method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreListLastSearchedMatchesFunc) PushHook(hook func(context.Context, int64) (map[api.RepoID][]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListLastSearchedMatchesFunc) SetDefaultReturn(r0 map[api.RepoID][]string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (map[api.RepoID][]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListLastSearchedMatchesFunc) PushReturn(r0 map[api.RepoID][]string, r1 error) {
+	f.PushHook(func(context.Context, int64) (map[api.RepoID][]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListLastSearchedMatchesFunc) nextHook() func(context.Context, int64) (map[api.RepoID][]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListLastSearchedMatchesFunc) appendCall(r0 CodeMonitorStoreListLastSearchedMatchesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreListLastSearchedMatchesFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreListLastSearchedMatchesFunc) History() []CodeMonitorStoreListLastSearchedMatchesFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListLastSearchedMatchesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListLastSearchedMatchesFuncCall is an object that
+// describes an invocation of method ListLastSearchedMatches on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreListLastSearchedMatchesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoID][]string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListLastSearchedMatchesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListLastSearchedMatchesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreListMonitorsFunc describes the behavior when the
+// ListMonitors method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreListMonitorsFunc struct {
+	defaultHook func(context.Context, ListMonitorsOpts) ([]*Monitor, error)
+	hooks       []func(context.Context, ListMonitorsOpts) ([]*Monitor, error)
+	history     []CodeMonitorStoreListMonitorsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListMonitors delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListMonitors(v0 context.Context, v1 ListMonitorsOpts) ([]*Monitor, error) {
+	r0, r1 := m.ListMonitorsFunc.nextHook()(v0, v1)
+	m.ListMonitorsFunc.appendCall(CodeMonitorStoreListMonitorsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListMonitors method
+// of the parent MockCodeMonitorStore instance is invoked and the hook queue
+// is empty.
+func (f *CodeMonitorStoreListMonitorsFunc) SetDefaultHook(hook func(context.Context, ListMonitorsOpts) ([]*Monitor, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListMonitors method of the parent MockCodeMonitorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeMonitorStoreListMonitorsFunc) PushHook(hook func(context.Context, ListMonitorsOpts) ([]*Monitor, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListMonitorsFunc) SetDefaultReturn(r0 []*Monitor, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListMonitorsFunc) PushReturn(r0 []*Monitor, r1 error) {
+	f.PushHook(func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListMonitorsFunc) nextHook() func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListMonitorsFunc) appendCall(r0 CodeMonitorStoreListMonitorsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreListMonitorsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreListMonitorsFunc) History() []CodeMonitorStoreListMonitorsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListMonitorsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListMonitorsFuncCall is an object that describes an
+// invocation of method ListMonitors on an instance of MockCodeMonitorStore.
+type CodeMonitorStoreListMonitorsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 ListMonitorsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*Monitor
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListMonitorsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListMonitorsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreListQueryTriggerJobsFunc describes the behavior when the
+// ListQueryTriggerJobs method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreListQueryTriggerJobsFunc struct {
+	defaultHook func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)
+	hooks       []func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)
+	history     []CodeMonitorStoreListQueryTriggerJobsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListQueryTriggerJobs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListQueryTriggerJobs(v0 context.Context, v1 ListTriggerJobsOpts) ([]*TriggerJob, error) {
+	r0, r1 := m.ListQueryTriggerJobsFunc.nextHook()(v0, v1)
+	m.ListQueryTriggerJobsFunc.appendCall(CodeMonitorStoreListQueryTriggerJobsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListQueryTriggerJobs
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
 func (f *CodeMonitorStoreListQueryTriggerJobsFunc) SetDefaultHook(hook func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)) {
@@ -5458,35 +6467,37 @@ func (c CodeMonitorStoreListWebhookActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreNowFunc describes the behavior when the Now method of the
-// parent MockCodeMonitorStore instance is invoked.
-type CodeMonitorStoreNowFunc struct {
-	defaultHook func() time.Time
-	hooks       []func() time.Time
-	history     []CodeMonitorStoreNowFuncCall
+// CodeMonitorStoreMarkTriggerJobSnoozedFunc describes the behavior when the
+// MarkTriggerJobSnoozed method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreMarkTriggerJobSnoozedFunc struct {
+	defaultHook func(context.Context, int32) error
+	hooks       []func(context.Context, int32) error
+	history     []CodeMonitorStoreMarkTriggerJobSnoozedFuncCall
 	mutex       sync.Mutex
 }
 
-// Now delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) Now() time.Time {
-	r0 := m.NowFunc.nextHook()()
-	m.NowFunc.appendCall(CodeMonitorStoreNowFuncCall{r0})
+// MarkTriggerJobSnoozed delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) MarkTriggerJobSnoozed(v0 context.Context, v1 int32) error {
+	r0 := m.MarkTriggerJobSnoozedFunc.nextHook()(v0, v1)
+	m.MarkTriggerJobSnoozedFunc.appendCall(CodeMonitorStoreMarkTriggerJobSnoozedFuncCall{v0, v1, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the Now method of the
-// parent MockCodeMonitorStore instance is invoked and the hook queue is
-// empty.
-func (f *CodeMonitorStoreNowFunc) SetDefaultHook(hook func() time.Time) {
+// SetDefaultHook sets function that is called when the
+// MarkTriggerJobSnoozed method of the parent MockCodeMonitorStore instance
+// is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreMarkTriggerJobSnoozedFunc) SetDefaultHook(hook func(context.Context, int32) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Now method of the parent MockCodeMonitorStore instance invokes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *CodeMonitorStoreNowFunc) PushHook(hook func() time.Time) {
+// MarkTriggerJobSnoozed method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreMarkTriggerJobSnoozedFunc) PushHook(hook func(context.Context, int32) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -5494,20 +6505,20 @@ func (f *CodeMonitorStoreNowFunc) PushHook(hook func() time.Time) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreNowFunc) SetDefaultReturn(r0 time.Time) {
-	f.SetDefaultHook(func() time.Time {
+func (f *CodeMonitorStoreMarkTriggerJobSnoozedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreNowFunc) PushReturn(r0 time.Time) {
-	f.PushHook(func() time.Time {
+func (f *CodeMonitorStoreMarkTriggerJobSnoozedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32) error {
 		return r0
 	})
 }
 
-func (f *CodeMonitorStoreNowFunc) nextHook() func() time.Time {
+func (f *CodeMonitorStoreMarkTriggerJobSnoozedFunc) nextHook() func(context.Context, int32) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -5520,29 +6531,136 @@ func (f *CodeMonitorStoreNowFunc) nextHook() func() time.Time {
 	return hook
 }
 
-func (f *CodeMonitorStoreNowFunc) appendCall(r0 CodeMonitorStoreNowFuncCall) {
+func (f *CodeMonitorStoreMarkTriggerJobSnoozedFunc) appendCall(r0 CodeMonitorStoreMarkTriggerJobSnoozedFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreNowFuncCall objects
-// describing the invocations of this function.
-func (f *CodeMonitorStoreNowFunc) History() []CodeMonitorStoreNowFuncCall {
+// History returns a sequence of
+// CodeMonitorStoreMarkTriggerJobSnoozedFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreMarkTriggerJobSnoozedFunc) History() []CodeMonitorStoreMarkTriggerJobSnoozedFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreNowFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreMarkTriggerJobSnoozedFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreNowFuncCall is an object that describes an invocation of
-// method Now on an instance of MockCodeMonitorStore.
-type CodeMonitorStoreNowFuncCall struct {
+// CodeMonitorStoreMarkTriggerJobSnoozedFuncCall is an object that describes
+// an invocation of method MarkTriggerJobSnoozed on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreMarkTriggerJobSnoozedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 time.Time
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreMarkTriggerJobSnoozedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreMarkTriggerJobSnoozedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreNowFunc describes the behavior when the Now method of the
+// parent MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreNowFunc struct {
+	defaultHook func() time.Time
+	hooks       []func() time.Time
+	history     []CodeMonitorStoreNowFuncCall
+	mutex       sync.Mutex
+}
+
+// Now delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) Now() time.Time {
+	r0 := m.NowFunc.nextHook()()
+	m.NowFunc.appendCall(CodeMonitorStoreNowFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Now method of the
+// parent MockCodeMonitorStore instance is invoked and the hook queue is
+// empty.
+func (f *CodeMonitorStoreNowFunc) SetDefaultHook(hook func() time.Time) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Now method of the parent MockCodeMonitorStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *CodeMonitorStoreNowFunc) PushHook(hook func() time.Time) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreNowFunc) SetDefaultReturn(r0 time.Time) {
+	f.SetDefaultHook(func() time.Time {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreNowFunc) PushReturn(r0 time.Time) {
+	f.PushHook(func() time.Time {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreNowFunc) nextHook() func() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreNowFunc) appendCall(r0 CodeMonitorStoreNowFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreNowFuncCall objects
+// describing the invocations of this function.
+func (f *CodeMonitorStoreNowFunc) History() []CodeMonitorStoreNowFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreNowFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreNowFuncCall is an object that describes an invocation of
+// method Now on an instance of MockCodeMonitorStore.
+type CodeMonitorStoreNowFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 time.Time
 }
 
 // Args returns an interface slice containing the arguments of this
@@ -5781,6 +6899,119 @@ func (c CodeMonitorStoreSetQueryTriggerNextRunFuncCall) Results() []interface{}
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreSnoozeMonitorFunc describes the behavior when the
+// SnoozeMonitor method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreSnoozeMonitorFunc struct {
+	defaultHook func(context.Context, int64, *time.Time) (*Monitor, error)
+	hooks       []func(context.Context, int64, *time.Time) (*Monitor, error)
+	history     []CodeMonitorStoreSnoozeMonitorFuncCall
+	mutex       sync.Mutex
+}
+
+// SnoozeMonitor delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) SnoozeMonitor(v0 context.Context, v1 int64, v2 *time.Time) (*Monitor, error) {
+	r0, r1 := m.SnoozeMonitorFunc.nextHook()(v0, v1, v2)
+	m.SnoozeMonitorFunc.appendCall(CodeMonitorStoreSnoozeMonitorFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the SnoozeMonitor method
+// of the parent MockCodeMonitorStore instance is invoked and the hook queue
+// is empty.
+func (f *CodeMonitorStoreSnoozeMonitorFunc) SetDefaultHook(hook func(context.Context, int64, *time.Time) (*Monitor, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SnoozeMonitor method of the parent MockCodeMonitorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeMonitorStoreSnoozeMonitorFunc) PushHook(hook func(context.Context, int64, *time.Time) (*Monitor, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreSnoozeMonitorFunc) SetDefaultReturn(r0 *Monitor, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, *time.Time) (*Monitor, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreSnoozeMonitorFunc) PushReturn(r0 *Monitor, r1 error) {
+	f.PushHook(func(context.Context, int64, *time.Time) (*Monitor, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreSnoozeMonitorFunc) nextHook() func(context.Context, int64, *time.Time) (*Monitor, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreSnoozeMonitorFunc) appendCall(r0 CodeMonitorStoreSnoozeMonitorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreSnoozeMonitorFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreSnoozeMonitorFunc) History() []CodeMonitorStoreSnoozeMonitorFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreSnoozeMonitorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreSnoozeMonitorFuncCall is an object that describes an
+// invocation of method SnoozeMonitor on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreSnoozeMonitorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *Monitor
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreSnoozeMonitorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreSnoozeMonitorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreTransactFunc describes the behavior when the Transact
 // method of the parent MockCodeMonitorStore instance is invoked.
 type CodeMonitorStoreTransactFunc struct {
@@ -5853,22 +7084,256 @@ func (f *CodeMonitorStoreTransactFunc) appendCall(r0 CodeMonitorStoreTransactFun
 // describing the invocations of this function.
 func (f *CodeMonitorStoreTransactFunc) History() []CodeMonitorStoreTransactFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreTransactFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreTransactFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreTransactFuncCall is an object that describes an
+// invocation of method Transact on an instance of MockCodeMonitorStore.
+type CodeMonitorStoreTransactFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 CodeMonitorStore
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreUpdateEmailActionFunc describes the behavior when the
+// UpdateEmailAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreUpdateEmailActionFunc struct {
+	defaultHook func(context.Context, int64, *EmailActionArgs) (*EmailAction, error)
+	hooks       []func(context.Context, int64, *EmailActionArgs) (*EmailAction, error)
+	history     []CodeMonitorStoreUpdateEmailActionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateEmailAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateEmailAction(v0 context.Context, v1 int64, v2 *EmailActionArgs) (*EmailAction, error) {
+	r0, r1 := m.UpdateEmailActionFunc.nextHook()(v0, v1, v2)
+	m.UpdateEmailActionFunc.appendCall(CodeMonitorStoreUpdateEmailActionFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UpdateEmailAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreUpdateEmailActionFunc) SetDefaultHook(hook func(context.Context, int64, *EmailActionArgs) (*EmailAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateEmailAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreUpdateEmailActionFunc) PushHook(hook func(context.Context, int64, *EmailActionArgs) (*EmailAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateEmailActionFunc) SetDefaultReturn(r0 *EmailAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, *EmailActionArgs) (*EmailAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateEmailActionFunc) PushReturn(r0 *EmailAction, r1 error) {
+	f.PushHook(func(context.Context, int64, *EmailActionArgs) (*EmailAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreUpdateEmailActionFunc) nextHook() func(context.Context, int64, *EmailActionArgs) (*EmailAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateEmailActionFunc) appendCall(r0 CodeMonitorStoreUpdateEmailActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreUpdateEmailActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreUpdateEmailActionFunc) History() []CodeMonitorStoreUpdateEmailActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateEmailActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateEmailActionFuncCall is an object that describes an
+// invocation of method UpdateEmailAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreUpdateEmailActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *EmailActionArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *EmailAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateEmailActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateEmailActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreUpdateIssueActionFunc describes the behavior when the
+// UpdateIssueAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreUpdateIssueActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)
+	hooks       []func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)
+	history     []CodeMonitorStoreUpdateIssueActionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateIssueAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateIssueAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 api.RepoID) (*IssueAction, error) {
+	r0, r1 := m.UpdateIssueActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateIssueActionFunc.appendCall(CodeMonitorStoreUpdateIssueActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UpdateIssueAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateIssueAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) PushHook(hook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) SetDefaultReturn(r0 *IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) PushReturn(r0 *IssueAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreUpdateIssueActionFunc) nextHook() func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateIssueActionFunc) appendCall(r0 CodeMonitorStoreUpdateIssueActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreUpdateIssueActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) History() []CodeMonitorStoreUpdateIssueActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateIssueActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreTransactFuncCall is an object that describes an
-// invocation of method Transact on an instance of MockCodeMonitorStore.
-type CodeMonitorStoreTransactFuncCall struct {
+// CodeMonitorStoreUpdateIssueActionFuncCall is an object that describes an
+// invocation of method UpdateIssueAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreUpdateIssueActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 api.RepoID
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 CodeMonitorStore
+	Result0 *IssueAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -5876,47 +7341,47 @@ type CodeMonitorStoreTransactFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreTransactFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c CodeMonitorStoreUpdateIssueActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreTransactFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreUpdateIssueActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreUpdateEmailActionFunc describes the behavior when the
-// UpdateEmailAction method of the parent MockCodeMonitorStore instance is
-// invoked.
-type CodeMonitorStoreUpdateEmailActionFunc struct {
-	defaultHook func(context.Context, int64, *EmailActionArgs) (*EmailAction, error)
-	hooks       []func(context.Context, int64, *EmailActionArgs) (*EmailAction, error)
-	history     []CodeMonitorStoreUpdateEmailActionFuncCall
+// CodeMonitorStoreUpdateIssueActionTrackingIssueFunc describes the behavior
+// when the UpdateIssueActionTrackingIssue method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreUpdateIssueActionTrackingIssueFunc struct {
+	defaultHook func(context.Context, int64, string, string) (*IssueAction, error)
+	hooks       []func(context.Context, int64, string, string) (*IssueAction, error)
+	history     []CodeMonitorStoreUpdateIssueActionTrackingIssueFuncCall
 	mutex       sync.Mutex
 }
 
-// UpdateEmailAction delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateEmailAction(v0 context.Context, v1 int64, v2 *EmailActionArgs) (*EmailAction, error) {
-	r0, r1 := m.UpdateEmailActionFunc.nextHook()(v0, v1, v2)
-	m.UpdateEmailActionFunc.appendCall(CodeMonitorStoreUpdateEmailActionFuncCall{v0, v1, v2, r0, r1})
+// UpdateIssueActionTrackingIssue delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateIssueActionTrackingIssue(v0 context.Context, v1 int64, v2 string, v3 string) (*IssueAction, error) {
+	r0, r1 := m.UpdateIssueActionTrackingIssueFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateIssueActionTrackingIssueFunc.appendCall(CodeMonitorStoreUpdateIssueActionTrackingIssueFuncCall{v0, v1, v2, v3, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the UpdateEmailAction
-// method of the parent MockCodeMonitorStore instance is invoked and the
-// hook queue is empty.
-func (f *CodeMonitorStoreUpdateEmailActionFunc) SetDefaultHook(hook func(context.Context, int64, *EmailActionArgs) (*EmailAction, error)) {
+// SetDefaultHook sets function that is called when the
+// UpdateIssueActionTrackingIssue method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpdateIssueActionTrackingIssueFunc) SetDefaultHook(hook func(context.Context, int64, string, string) (*IssueAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// UpdateEmailAction method of the parent MockCodeMonitorStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *CodeMonitorStoreUpdateEmailActionFunc) PushHook(hook func(context.Context, int64, *EmailActionArgs) (*EmailAction, error)) {
+// UpdateIssueActionTrackingIssue method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpdateIssueActionTrackingIssueFunc) PushHook(hook func(context.Context, int64, string, string) (*IssueAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -5924,20 +7389,20 @@ func (f *CodeMonitorStoreUpdateEmailActionFunc) PushHook(hook func(context.Conte
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreUpdateEmailActionFunc) SetDefaultReturn(r0 *EmailAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, *EmailActionArgs) (*EmailAction, error) {
+func (f *CodeMonitorStoreUpdateIssueActionTrackingIssueFunc) SetDefaultReturn(r0 *IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, string, string) (*IssueAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreUpdateEmailActionFunc) PushReturn(r0 *EmailAction, r1 error) {
-	f.PushHook(func(context.Context, int64, *EmailActionArgs) (*EmailAction, error) {
+func (f *CodeMonitorStoreUpdateIssueActionTrackingIssueFunc) PushReturn(r0 *IssueAction, r1 error) {
+	f.PushHook(func(context.Context, int64, string, string) (*IssueAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreUpdateEmailActionFunc) nextHook() func(context.Context, int64, *EmailActionArgs) (*EmailAction, error) {
+func (f *CodeMonitorStoreUpdateIssueActionTrackingIssueFunc) nextHook() func(context.Context, int64, string, string) (*IssueAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -5950,27 +7415,28 @@ func (f *CodeMonitorStoreUpdateEmailActionFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *CodeMonitorStoreUpdateEmailActionFunc) appendCall(r0 CodeMonitorStoreUpdateEmailActionFuncCall) {
+func (f *CodeMonitorStoreUpdateIssueActionTrackingIssueFunc) appendCall(r0 CodeMonitorStoreUpdateIssueActionTrackingIssueFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreUpdateEmailActionFuncCall
-// objects describing the invocations of this function.
-func (f *CodeMonitorStoreUpdateEmailActionFunc) History() []CodeMonitorStoreUpdateEmailActionFuncCall {
+// History returns a sequence of
+// CodeMonitorStoreUpdateIssueActionTrackingIssueFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreUpdateIssueActionTrackingIssueFunc) History() []CodeMonitorStoreUpdateIssueActionTrackingIssueFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreUpdateEmailActionFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreUpdateIssueActionTrackingIssueFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreUpdateEmailActionFuncCall is an object that describes an
-// invocation of method UpdateEmailAction on an instance of
-// MockCodeMonitorStore.
-type CodeMonitorStoreUpdateEmailActionFuncCall struct {
+// CodeMonitorStoreUpdateIssueActionTrackingIssueFuncCall is an object that
+// describes an invocation of method UpdateIssueActionTrackingIssue on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreUpdateIssueActionTrackingIssueFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -5979,10 +7445,13 @@ type CodeMonitorStoreUpdateEmailActionFuncCall struct {
 	Arg1 int64
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 *EmailActionArgs
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *EmailAction
+	Result0 *IssueAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -5990,13 +7459,13 @@ type CodeMonitorStoreUpdateEmailActionFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreUpdateEmailActionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c CodeMonitorStoreUpdateIssueActionTrackingIssueFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreUpdateEmailActionFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreUpdateIssueActionTrackingIssueFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -6343,24 +7812,24 @@ func (c CodeMonitorStoreUpdateQueryTriggerFuncCall) Results() []interface{} {
 // the UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
 // instance is invoked.
 type CodeMonitorStoreUpdateSlackWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)
+	defaultHook func(context.Context, int64, bool, bool, string, string) (*SlackWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string, string) (*SlackWebhookAction, error)
 	history     []CodeMonitorStoreUpdateSlackWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
 // UpdateSlackWebhookAction delegates to the next hook function in the queue
 // and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateSlackWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*SlackWebhookAction, error) {
-	r0, r1 := m.UpdateSlackWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.UpdateSlackWebhookActionFunc.appendCall(CodeMonitorStoreUpdateSlackWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+func (m *MockCodeMonitorStore) UpdateSlackWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string, v5 string) (*SlackWebhookAction, error) {
+	r0, r1 := m.UpdateSlackWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.UpdateSlackWebhookActionFunc.appendCall(CodeMonitorStoreUpdateSlackWebhookActionFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
 // UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
 // instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string, string) (*SlackWebhookAction, error)) {
 	f.defaultHook = hook
 }
 
@@ -6369,7 +7838,7 @@ func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultHook(hook func(
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string, string) (*SlackWebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -6378,19 +7847,19 @@ func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushHook(hook func(contex
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultReturn(r0 *SlackWebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string, string) (*SlackWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushReturn(r0 *SlackWebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string, string) (*SlackWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*SlackWebhookAction, error) {
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string, string) (*SlackWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -6440,6 +7909,9 @@ type CodeMonitorStoreUpdateSlackWebhookActionFuncCall struct {
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *SlackWebhookAction
@@ -6451,7 +7923,7 @@ type CodeMonitorStoreUpdateSlackWebhookActionFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
@@ -6460,6 +7932,121 @@ func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc describes the
+// behavior when the UpdateTriggerJobWithFileResults method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc struct {
+	defaultHook func(context.Context, int32, string, []*result.FileMatch) error
+	hooks       []func(context.Context, int32, string, []*result.FileMatch) error
+	history     []CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateTriggerJobWithFileResults delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateTriggerJobWithFileResults(v0 context.Context, v1 int32, v2 string, v3 []*result.FileMatch) error {
+	r0 := m.UpdateTriggerJobWithFileResultsFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateTriggerJobWithFileResultsFunc.appendCall(CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateTriggerJobWithFileResults method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc) SetDefaultHook(hook func(context.Context, int32, string, []*result.FileMatch) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateTriggerJobWithFileResults method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc) PushHook(hook func(context.Context, int32, string, []*result.FileMatch) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string, []*result.FileMatch) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string, []*result.FileMatch) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc) nextHook() func(context.Context, int32, string, []*result.FileMatch) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc) appendCall(r0 CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall objects
+// describing the invocations of this function.
+func (f *CodeMonitorStoreUpdateTriggerJobWithFileResultsFunc) History() []CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall is an object that
+// describes an invocation of method UpdateTriggerJobWithFileResults on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []*result.FileMatch
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateTriggerJobWithFileResultsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreUpdateTriggerJobWithResultsFunc describes the behavior
 // when the UpdateTriggerJobWithResults method of the parent
 // MockCodeMonitorStore instance is invoked.
@@ -6809,6 +8396,124 @@ func (c CodeMonitorStoreUpsertLastSearchedFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreUpsertLastSearchedMatchesFunc describes the behavior when
+// the UpsertLastSearchedMatches method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreUpsertLastSearchedMatchesFunc struct {
+	defaultHook func(context.Context, int64, api.RepoID, []string, []string) error
+	hooks       []func(context.Context, int64, api.RepoID, []string, []string) error
+	history     []CodeMonitorStoreUpsertLastSearchedMatchesFuncCall
+	mutex       sync.Mutex
+}
+
+// UpsertLastSearchedMatches delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpsertLastSearchedMatches(v0 context.Context, v1 int64, v2 api.RepoID, v3 []string, v4 []string) error {
+	r0 := m.UpsertLastSearchedMatchesFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpsertLastSearchedMatchesFunc.appendCall(CodeMonitorStoreUpsertLastSearchedMatchesFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpsertLastSearchedMatches method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpsertLastSearchedMatchesFunc) SetDefaultHook(hook func(context.Context, int64, api.RepoID, []string, []string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpsertLastSearchedMatches method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpsertLastSearchedMatchesFunc) PushHook(hook func(context.Context, int64, api.RepoID, []string, []string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpsertLastSearchedMatchesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, api.RepoID, []string, []string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpsertLastSearchedMatchesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, api.RepoID, []string, []string) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpsertLastSearchedMatchesFunc) nextHook() func(context.Context, int64, api.RepoID, []string, []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpsertLastSearchedMatchesFunc) appendCall(r0 CodeMonitorStoreUpsertLastSearchedMatchesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpsertLastSearchedMatchesFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreUpsertLastSearchedMatchesFunc) History() []CodeMonitorStoreUpsertLastSearchedMatchesFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpsertLastSearchedMatchesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpsertLastSearchedMatchesFuncCall is an object that
+// describes an invocation of method UpsertLastSearchedMatches on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreUpsertLastSearchedMatchesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoID
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpsertLastSearchedMatchesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpsertLastSearchedMatchesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockCodeownersStore is a mock implementation of the CodeownersStore
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/internal/database) used for
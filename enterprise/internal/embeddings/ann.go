@@ -0,0 +1,195 @@
+package embeddings
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/sourcegraph/conc"
+)
+
+// ANNIndexMinRows is the minimum number of rows for which an approximate nearest neighbor index
+// is built. Searching smaller indexes exhaustively is fast enough.
+const ANNIndexMinRows = 100_000
+
+// IVFIndex is an inverted file index used for approximate nearest neighbor search. The rows of
+// an embedding index are clustered around centroids with k-means, and each row is assigned to
+// the list of its closest centroid. A search only scores the rows in the lists of the centroids
+// closest to the query.
+type IVFIndex struct {
+	// Centroids contains the normalized centroid of each list, one after another.
+	Centroids []float32
+	// Lists contains the indices of the rows assigned to each centroid.
+	Lists [][]int32
+}
+
+type IVFOptions struct {
+	// NumLists is the number of centroids. Defaults to the square root of the number of rows.
+	NumLists int
+	// NumIterations is the number of k-means iterations used to find the centroids. Defaults to 10.
+	NumIterations int
+	// NumTrainingRowsPerList is the number of sampled rows per list the centroids are computed
+	// from. Defaults to 64.
+	NumTrainingRowsPerList int
+	// Seed seeds the sampling of training rows and of the initial centroids.
+	Seed int64
+}
+
+// BuildIVFIndex clusters the rows of the index and returns an IVF index for them.
+// IMPORTANT: The vectors in the embedding index have to be normalized.
+func BuildIVFIndex(index *EmbeddingIndex, opts IVFOptions, workerOptions WorkerOptions) *IVFIndex {
+	numRows := len(index.RowMetadata)
+	if numRows == 0 {
+		return &IVFIndex{Centroids: []float32{}, Lists: [][]int32{}}
+	}
+
+	numLists := opts.NumLists
+	if numLists <= 0 {
+		numLists = int(math.Sqrt(float64(numRows)))
+	}
+	numLists = max(1, min(numLists, numRows))
+	numIterations := opts.NumIterations
+	if numIterations <= 0 {
+		numIterations = 10
+	}
+	numTrainingRowsPerList := opts.NumTrainingRowsPerList
+	if numTrainingRowsPerList <= 0 {
+		numTrainingRowsPerList = 64
+	}
+
+	prng := rand.New(rand.NewSource(opts.Seed))
+	permutation := prng.Perm(numRows)
+	trainingRows := make([]int32, 0, min(numRows, numLists*numTrainingRowsPerList))
+	for _, row := range permutation[:cap(trainingRows)] {
+		trainingRows = append(trainingRows, int32(row))
+	}
+
+	// The initial centroids are randomly sampled rows.
+	dimension := index.ColumnDimension
+	centroids := make([]float32, 0, numLists*dimension)
+	for _, row := range trainingRows[:numLists] {
+		centroids = append(centroids, index.row(int(row))...)
+	}
+
+	for iteration := 0; iteration < numIterations; iteration++ {
+		assignments := index.assignToCentroids(centroids, trainingRows, workerOptions)
+
+		sums := make([]float32, len(centroids))
+		counts := make([]int, numLists)
+		for i, row := range trainingRows {
+			list := assignments[i]
+			counts[list]++
+			sum := sums[list*dimension : (list+1)*dimension]
+			for j, value := range index.row(int(row)) {
+				sum[j] += value
+			}
+		}
+
+		for list := 0; list < numLists; list++ {
+			// Keep the previous centroid of empty lists.
+			if counts[list] == 0 {
+				continue
+			}
+			sum := sums[list*dimension : (list+1)*dimension]
+			if normalize(sum) {
+				copy(centroids[list*dimension:(list+1)*dimension], sum)
+			}
+		}
+	}
+
+	allRows := make([]int32, numRows)
+	for i := range allRows {
+		allRows[i] = int32(i)
+	}
+	lists := make([][]int32, numLists)
+	for row, list := range index.assignToCentroids(centroids, allRows, workerOptions) {
+		lists[list] = append(lists[list], int32(row))
+	}
+	for list := range lists {
+		if lists[list] == nil {
+			lists[list] = []int32{}
+		}
+	}
+
+	return &IVFIndex{Centroids: centroids, Lists: lists}
+}
+
+// assignToCentroids returns the index of the closest centroid for each of the given rows.
+func (index *EmbeddingIndex) assignToCentroids(centroids []float32, rows []int32, workerOptions WorkerOptions) []int {
+	dimension := index.ColumnDimension
+	numCentroids := len(centroids) / dimension
+	assignments := make([]int, len(rows))
+
+	assign := func(partialRows partialRows) {
+		for i := partialRows.start; i < partialRows.end; i++ {
+			row := index.row(int(rows[i]))
+			closest, closestSimilarity := 0, float32(math.Inf(-1))
+			for c := 0; c < numCentroids; c++ {
+				similarity := CosineSimilarity(centroids[c*dimension:(c+1)*dimension], row)
+				if similarity > closestSimilarity {
+					closest, closestSimilarity = c, similarity
+				}
+			}
+			assignments[i] = closest
+		}
+	}
+
+	rowsPerWorker := splitRows(len(rows), max(1, workerOptions.NumWorkers), workerOptions.MinRowsToSplit)
+	var wg conc.WaitGroup
+	for _, partialRows := range rowsPerWorker {
+		partialRows := partialRows
+		wg.Go(func() { assign(partialRows) })
+	}
+	wg.Wait()
+
+	return assignments
+}
+
+// candidateRows returns the rows in the lists of the numProbes centroids closest to the query.
+func (ivf *IVFIndex) candidateRows(query []float32, numProbes int) []int32 {
+	numLists := len(ivf.Lists)
+	if numLists == 0 {
+		return []int32{}
+	}
+	dimension := len(ivf.Centroids) / numLists
+
+	type scoredList struct {
+		list       int
+		similarity float32
+	}
+	scoredLists := make([]scoredList, numLists)
+	for list := 0; list < numLists; list++ {
+		scoredLists[list] = scoredList{list, CosineSimilarity(ivf.Centroids[list*dimension:(list+1)*dimension], query)}
+	}
+	sort.Slice(scoredLists, func(i, j int) bool { return scoredLists[i].similarity > scoredLists[j].similarity })
+
+	numCandidates := 0
+	for _, scored := range scoredLists[:min(numProbes, numLists)] {
+		numCandidates += len(ivf.Lists[scored.list])
+	}
+	candidates := make([]int32, 0, numCandidates)
+	for _, scored := range scoredLists[:min(numProbes, numLists)] {
+		candidates = append(candidates, ivf.Lists[scored.list]...)
+	}
+	return candidates
+}
+
+func (index *EmbeddingIndex) row(i int) []float32 {
+	return index.Embeddings[i*index.ColumnDimension : (i+1)*index.ColumnDimension]
+}
+
+// normalize scales the vector to unit length. It returns false if the vector has no length.
+func normalize(vector []float32) bool {
+	var sumOfSquares float64
+	for _, value := range vector {
+		sumOfSquares += float64(value) * float64(value)
+	}
+	if sumOfSquares == 0 {
+		return false
+	}
+	norm := float32(math.Sqrt(sumOfSquares))
+	for i := range vector {
+		vector[i] /= norm
+	}
+	return true
+}
@@ -0,0 +1,224 @@
+package embeddings
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEmbeddingIndex(embeddings []float32, columnDimension int) *EmbeddingIndex {
+	index := &EmbeddingIndex{
+		Embeddings:      embeddings,
+		ColumnDimension: columnDimension,
+		RowMetadata:     make([]RepoEmbeddingRowMetadata, len(embeddings)/columnDimension),
+	}
+	for i := range index.RowMetadata {
+		index.RowMetadata[i] = RepoEmbeddingRowMetadata{FileName: fmt.Sprintf("%d", i)}
+	}
+	return index
+}
+
+// getClusteredEmbeddings returns normalized embeddings that are scattered around numClusters random centers.
+func getClusteredEmbeddings(prng *rand.Rand, numRows int, numClusters int, columnDimension int) []float32 {
+	centers := make([][]float32, numClusters)
+	for i := range centers {
+		centers[i] = make([]float32, columnDimension)
+		for j := range centers[i] {
+			centers[i][j] = float32(prng.NormFloat64())
+		}
+	}
+
+	embeddings := make([]float32, 0, numRows*columnDimension)
+	for i := 0; i < numRows; i++ {
+		row := make([]float32, columnDimension)
+		center := centers[prng.Intn(numClusters)]
+		for j := range row {
+			row[j] = center[j] + 0.5*float32(prng.NormFloat64())
+		}
+		normalize(row)
+		embeddings = append(embeddings, row...)
+	}
+	return embeddings
+}
+
+func searchResultFileNames(results []EmbeddingSearchResult) []string {
+	fileNames := make([]string, 0, len(results))
+	for _, result := range results {
+		fileNames = append(fileNames, result.FileName)
+	}
+	return fileNames
+}
+
+// recall returns the fraction of the expected results that were found.
+func recall(want, got []EmbeddingSearchResult) float64 {
+	found := map[string]struct{}{}
+	for _, fileName := range searchResultFileNames(got) {
+		found[fileName] = struct{}{}
+	}
+	numFound := 0
+	for _, fileName := range searchResultFileNames(want) {
+		if _, ok := found[fileName]; ok {
+			numFound++
+		}
+	}
+	return float64(numFound) / float64(len(want))
+}
+
+func TestBuildIVFIndex(t *testing.T) {
+	index := newTestEmbeddingIndex(embeddings, 3)
+
+	for _, numWorkers := range []int{1, 4} {
+		ivf := BuildIVFIndex(index, IVFOptions{NumLists: 4}, WorkerOptions{NumWorkers: numWorkers})
+		require.Len(t, ivf.Centroids, 4*3)
+		require.Len(t, ivf.Lists, 4)
+
+		// Each row is in exactly one list.
+		rows := []int{}
+		for _, list := range ivf.Lists {
+			for _, row := range list {
+				rows = append(rows, int(row))
+			}
+		}
+		sort.Ints(rows)
+		wantRows := make([]int, len(index.RowMetadata))
+		for i := range wantRows {
+			wantRows[i] = i
+		}
+		require.Equal(t, wantRows, rows)
+	}
+
+	t.Run("empty index", func(t *testing.T) {
+		ivf := BuildIVFIndex(&EmbeddingIndex{ColumnDimension: 3}, IVFOptions{}, WorkerOptions{})
+		require.Empty(t, ivf.Lists)
+	})
+}
+
+func TestANNSimilaritySearch(t *testing.T) {
+	index := newTestEmbeddingIndex(embeddings, 3)
+	index.ANN = BuildIVFIndex(index, IVFOptions{NumLists: 4}, WorkerOptions{NumWorkers: 1})
+
+	for q := 0; q < 3; q++ {
+		query := queries[q*3 : (q+1)*3]
+		exactResults := index.SimilaritySearch(query, 4, WorkerOptions{NumWorkers: 1}, SearchOptions{})
+
+		t.Run(fmt.Sprintf("probing all lists is exact query=%d", q), func(t *testing.T) {
+			results := index.SimilaritySearch(query, 4, WorkerOptions{NumWorkers: 1}, SearchOptions{ANNProbes: 4})
+			require.Equal(t, exactResults, results)
+		})
+
+		t.Run(fmt.Sprintf("probing some lists only returns rows from the probed lists query=%d", q), func(t *testing.T) {
+			candidates := map[string]struct{}{}
+			for _, row := range index.ANN.candidateRows(query, 2) {
+				candidates[fmt.Sprintf("%d", row)] = struct{}{}
+			}
+
+			for _, numWorkers := range []int{1, 3} {
+				results := index.SimilaritySearch(query, 4, WorkerOptions{NumWorkers: numWorkers}, SearchOptions{ANNProbes: 2})
+				require.LessOrEqual(t, len(results), 4)
+				for _, result := range results {
+					if result.FileName == "" {
+						// Fewer candidates than requested results.
+						continue
+					}
+					require.Contains(t, candidates, result.FileName)
+				}
+			}
+		})
+	}
+}
+
+func TestANNSimilaritySearchRecall(t *testing.T) {
+	prng := rand.New(rand.NewSource(0))
+	columnDimension := 16
+	index := newTestEmbeddingIndex(getClusteredEmbeddings(prng, 5000, 50, columnDimension), columnDimension)
+	index.ANN = BuildIVFIndex(index, IVFOptions{}, WorkerOptions{NumWorkers: 4})
+	require.Len(t, index.ANN.Lists, 70)
+
+	numQueries, numResults := 20, 10
+	queries := getClusteredEmbeddings(prng, numQueries, 50, columnDimension)
+
+	previousRecall := 0.0
+	for _, numProbes := range []int{1, 8, 32} {
+		totalRecall := 0.0
+		for q := 0; q < numQueries; q++ {
+			query := queries[q*columnDimension : (q+1)*columnDimension]
+			exactResults := index.SimilaritySearch(query, numResults, WorkerOptions{NumWorkers: 1}, SearchOptions{})
+			annResults := index.SimilaritySearch(query, numResults, WorkerOptions{NumWorkers: 1}, SearchOptions{ANNProbes: numProbes})
+			totalRecall += recall(exactResults, annResults)
+		}
+		averageRecall := totalRecall / float64(numQueries)
+		// Probing more lists cannot decrease recall.
+		require.GreaterOrEqual(t, averageRecall, previousRecall, "numProbes=%d", numProbes)
+		previousRecall = averageRecall
+	}
+	require.GreaterOrEqual(t, previousRecall, 0.95)
+}
+
+// BenchmarkANNSimilaritySearch compares the latency of the exhaustive similarity search with searches of the ANN
+// index with a varying number of probed lists, and reports the recall of each ANN search.
+//
+// Clustered embeddings with queries from the same clusters are the best case for the ANN index. It is also
+// benchmarked on uniformly random embeddings and queries, like BenchmarkSimilaritySearch, and on the
+// embeddings and queries generated by testdata/generate_similarity_search_test_data.py.
+func BenchmarkANNSimilaritySearch(b *testing.B) {
+	workerOptions := WorkerOptions{NumWorkers: 8}
+
+	b.Run("data=clustered", func(b *testing.B) {
+		prng := rand.New(rand.NewSource(0))
+		numRows, numQueries, columnDimension := 200_000, 10, 1536
+		index := newTestEmbeddingIndex(getClusteredEmbeddings(prng, numRows, 1000, columnDimension), columnDimension)
+		queries := getClusteredEmbeddings(prng, numQueries, 1000, columnDimension)
+		benchmarkANNSimilaritySearch(b, index, IVFOptions{}, queries, 100, []int{0, 1, 4, 16, 64}, workerOptions)
+	})
+
+	b.Run("data=random", func(b *testing.B) {
+		prng := rand.New(rand.NewSource(0))
+		numRows, numQueries, columnDimension := 200_000, 10, 1536
+		index := newTestEmbeddingIndex(getNormalizedRandomEmbeddings(prng, numRows, columnDimension), columnDimension)
+		queries := getNormalizedRandomEmbeddings(prng, numQueries, columnDimension)
+		benchmarkANNSimilaritySearch(b, index, IVFOptions{}, queries, 100, []int{0, 1, 4, 16, 64}, workerOptions)
+	})
+
+	b.Run("data=testdata", func(b *testing.B) {
+		index := newTestEmbeddingIndex(embeddings, 3)
+		benchmarkANNSimilaritySearch(b, index, IVFOptions{NumLists: 4}, queries, 4, []int{0, 1, 2}, workerOptions)
+	})
+}
+
+func benchmarkANNSimilaritySearch(b *testing.B, index *EmbeddingIndex, ivfOptions IVFOptions, queries []float32, numResults int, numProbesValues []int, workerOptions WorkerOptions) {
+	columnDimension := index.ColumnDimension
+	numQueries := len(queries) / columnDimension
+	index.ANN = BuildIVFIndex(index, ivfOptions, workerOptions)
+
+	exactResults := make([][]EmbeddingSearchResult, numQueries)
+	for q := range exactResults {
+		exactResults[q] = index.SimilaritySearch(queries[q*columnDimension:(q+1)*columnDimension], numResults, workerOptions, SearchOptions{})
+	}
+
+	b.ResetTimer()
+
+	// Zero probes is the exhaustive search.
+	for _, numProbes := range numProbesValues {
+		b.Run(fmt.Sprintf("numProbes=%d", numProbes), func(b *testing.B) {
+			totalRecall := 0.0
+			for n := 0; n < b.N; n++ {
+				q := n % numQueries
+				results := index.SimilaritySearch(queries[q*columnDimension:(q+1)*columnDimension], numResults, workerOptions, SearchOptions{ANNProbes: numProbes})
+				totalRecall += recall(exactResults[q], results)
+			}
+			b.ReportMetric(totalRecall/float64(b.N), "recall")
+		})
+	}
+}
+
+// getNormalizedRandomEmbeddings returns getRandomEmbeddings normalized to unit length, as the ANN index expects.
+func getNormalizedRandomEmbeddings(prng *rand.Rand, numRows int, columnDimension int) []float32 {
+	embeddings := getRandomEmbeddings(prng, numRows*columnDimension)
+	for i := 0; i < numRows; i++ {
+		normalize(embeddings[i*columnDimension : (i+1)*columnDimension])
+	}
+	return embeddings
+}
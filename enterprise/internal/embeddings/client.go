@@ -54,6 +54,9 @@ type EmbeddingsSearchParameters struct {
 	UseDocumentRanks bool `json:"useDocumentRanks"`
 	// If set to "True", EmbeddingSearchResult.Debug will contain useful information about scoring.
 	Debug bool `json:"debug"`
+	// ANNProbes is the number of clusters of the approximate nearest neighbor index to search.
+	// If 0, the embeddings.annProbes site configuration is used. If -1, every row is searched.
+	ANNProbes int `json:"annProbes,omitempty"`
 }
 
 type IsContextRequiredForChatQueryParameters struct {
@@ -2,6 +2,7 @@ package embed
 
 import (
 	"context"
+	"runtime"
 
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -37,18 +38,7 @@ func EmbedRepo(
 	readFile readFile,
 	getDocumentRanks ranksGetter,
 ) (*embeddings.RepoEmbeddingIndex, error) {
-	codeFileNames, textFileNames := []string{}, []string{}
-	for _, fileName := range fileNames {
-		if isExcludedFilePath(fileName, excludedFilePathPatterns) {
-			continue
-		}
-
-		if isValidTextFile(fileName) {
-			textFileNames = append(textFileNames, fileName)
-		} else {
-			codeFileNames = append(codeFileNames, fileName)
-		}
-	}
+	codeFileNames, textFileNames := splitCodeAndTextFiles(fileNames, excludedFilePathPatterns)
 
 	ranks, err := getDocumentRanks(ctx, string(repoName))
 	if err != nil {
@@ -65,9 +55,142 @@ func EmbedRepo(
 		return nil, err
 	}
 
+	buildANNIndex(&codeIndex)
+	buildANNIndex(&textIndex)
+
 	return &embeddings.RepoEmbeddingIndex{RepoName: repoName, Revision: revision, CodeIndex: codeIndex, TextIndex: textIndex}, nil
 }
 
+// UpdateRepoEmbeddingIndex updates the embeddings index of a previous revision of a repository to the
+// given revision. Rows of files that were modified or deleted since the previous revision are
+// dropped, and only the modified files are embedded again. The ranks of all rows are recomputed,
+// since the ranks of unmodified files may have changed as well.
+//
+// modifiedFileNames are the added and modified files that should be embedded, and
+// deletedFileNames are the files that were deleted or renamed, or should otherwise no longer be
+// part of the index.
+func UpdateRepoEmbeddingIndex(
+	ctx context.Context,
+	previousIndex *embeddings.RepoEmbeddingIndex,
+	revision api.CommitID,
+	modifiedFileNames []string,
+	deletedFileNames []string,
+	excludedFilePathPatterns []*paths.GlobPattern,
+	client EmbeddingsClient,
+	splitOptions split.SplitOptions,
+	readFile readFile,
+	getDocumentRanks ranksGetter,
+) (*embeddings.RepoEmbeddingIndex, error) {
+	dropFileNames := make(map[string]struct{}, len(modifiedFileNames)+len(deletedFileNames))
+	for _, fileName := range modifiedFileNames {
+		dropFileNames[fileName] = struct{}{}
+	}
+	for _, fileName := range deletedFileNames {
+		dropFileNames[fileName] = struct{}{}
+	}
+	// Rows of files that are excluded by the current patterns are dropped as well, so that
+	// changes of the excluded patterns apply to unmodified files.
+	dropRow := func(fileName string) bool {
+		_, ok := dropFileNames[fileName]
+		return ok || isExcludedFilePath(fileName, excludedFilePathPatterns)
+	}
+
+	codeFileNames, textFileNames := splitCodeAndTextFiles(modifiedFileNames, excludedFilePathPatterns)
+
+	ranks, err := getDocumentRanks(ctx, string(previousIndex.RepoName))
+	if err != nil {
+		return nil, err
+	}
+
+	updateIndex := func(previous embeddings.EmbeddingIndex, fileNames []string, maxEmbeddingVectors int) (embeddings.EmbeddingIndex, error) {
+		kept := filterEmbeddingIndexRows(previous, dropRow)
+		remainingEmbeddingVectors := maxEmbeddingVectors - len(kept.RowMetadata)
+		if remainingEmbeddingVectors < 0 {
+			remainingEmbeddingVectors = 0
+		}
+		modified, err := embedFiles(fileNames, client, splitOptions, readFile, remainingEmbeddingVectors, ranks)
+		if err != nil {
+			return embeddings.EmbeddingIndex{}, err
+		}
+		if kept.ColumnDimension != modified.ColumnDimension && len(kept.RowMetadata) > 0 {
+			return embeddings.EmbeddingIndex{}, errors.Newf("cannot update embeddings with %d dimensions with embeddings with %d dimensions", kept.ColumnDimension, modified.ColumnDimension)
+		}
+		return mergeEmbeddingIndexes(kept, modified, ranks), nil
+	}
+
+	codeIndex, err := updateIndex(previousIndex.CodeIndex, codeFileNames, MAX_CODE_EMBEDDING_VECTORS)
+	if err != nil {
+		return nil, err
+	}
+
+	textIndex, err := updateIndex(previousIndex.TextIndex, textFileNames, MAX_TEXT_EMBEDDING_VECTORS)
+	if err != nil {
+		return nil, err
+	}
+
+	// The rows changed, so the ANN index of the previous index cannot be reused.
+	buildANNIndex(&codeIndex)
+	buildANNIndex(&textIndex)
+
+	return &embeddings.RepoEmbeddingIndex{RepoName: previousIndex.RepoName, Revision: revision, CodeIndex: codeIndex, TextIndex: textIndex}, nil
+}
+
+// buildANNIndex builds an approximate nearest neighbor index for large embedding indexes.
+func buildANNIndex(index *embeddings.EmbeddingIndex) {
+	if len(index.RowMetadata) < embeddings.ANNIndexMinRows {
+		return
+	}
+	index.ANN = embeddings.BuildIVFIndex(index, embeddings.IVFOptions{}, embeddings.WorkerOptions{NumWorkers: runtime.GOMAXPROCS(0)})
+}
+
+// splitCodeAndTextFiles separates the file names into code files and text files, skipping excluded files.
+func splitCodeAndTextFiles(fileNames []string, excludedFilePathPatterns []*paths.GlobPattern) (codeFileNames, textFileNames []string) {
+	codeFileNames, textFileNames = []string{}, []string{}
+	for _, fileName := range fileNames {
+		if isExcludedFilePath(fileName, excludedFilePathPatterns) {
+			continue
+		}
+
+		if isValidTextFile(fileName) {
+			textFileNames = append(textFileNames, fileName)
+		} else {
+			codeFileNames = append(codeFileNames, fileName)
+		}
+	}
+	return codeFileNames, textFileNames
+}
+
+// filterEmbeddingIndexRows returns a copy of the index without the rows of the files for which drop returns true.
+func filterEmbeddingIndexRows(index embeddings.EmbeddingIndex, drop func(fileName string) bool) embeddings.EmbeddingIndex {
+	filtered := embeddings.EmbeddingIndex{
+		Embeddings:      make([]float32, 0, len(index.Embeddings)),
+		RowMetadata:     make([]embeddings.RepoEmbeddingRowMetadata, 0, len(index.RowMetadata)),
+		ColumnDimension: index.ColumnDimension,
+	}
+	for i, row := range index.RowMetadata {
+		if drop(row.FileName) {
+			continue
+		}
+		filtered.RowMetadata = append(filtered.RowMetadata, row)
+		filtered.Embeddings = append(filtered.Embeddings, index.Embeddings[i*index.ColumnDimension:(i+1)*index.ColumnDimension]...)
+	}
+	return filtered
+}
+
+// mergeEmbeddingIndexes appends the rows of b to the rows of a, and recomputes the ranks of all rows.
+func mergeEmbeddingIndexes(a, b embeddings.EmbeddingIndex, repoPathRanks types.RepoPathRanks) embeddings.EmbeddingIndex {
+	merged := embeddings.EmbeddingIndex{
+		Embeddings:      append(a.Embeddings, b.Embeddings...),
+		RowMetadata:     append(a.RowMetadata, b.RowMetadata...),
+		ColumnDimension: b.ColumnDimension,
+	}
+	merged.Ranks = make([]float32, 0, len(merged.RowMetadata))
+	for _, row := range merged.RowMetadata {
+		merged.Ranks = append(merged.Ranks, float32(repoPathRanks.Paths[row.FileName]))
+	}
+	return merged
+}
+
 func createEmptyEmbeddingIndex(columnDimension int) embeddings.EmbeddingIndex {
 	return embeddings.EmbeddingIndex{
 		Embeddings:      []float32{},
@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/embeddings"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/embeddings/split"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 )
@@ -127,6 +128,83 @@ func TestEmbedRepo(t *testing.T) {
 	})
 }
 
+func TestUpdateRepoEmbeddingIndex(t *testing.T) {
+	ctx := context.Background()
+	repoName := api.RepoName("repo/name")
+	splitOptions := split.SplitOptions{ChunkTokensThreshold: 8}
+	excludedGlobPatterns := GetDefaultExcludedFilePathPatterns()
+
+	mockFiles := map[string][]byte{
+		// 2 embedding chunks each (based on split options above)
+		"a.go": mockFile(strings.Repeat("a", 32), "", strings.Repeat("b", 32)),
+		"b.go": mockFile(strings.Repeat("c", 32), "", strings.Repeat("d", 32)),
+		"c.md": mockFile("# "+strings.Repeat("a", 32), "", "## "+strings.Repeat("b", 32)),
+	}
+	readFile := func(fileName string) ([]byte, error) {
+		content, ok := mockFiles[fileName]
+		if !ok {
+			return nil, errors.Newf("file %s not found", fileName)
+		}
+		return content, nil
+	}
+	mockRanks := map[string]float64{"a.go": 0.1, "b.go": 0.2, "c.md": 0.3}
+	getDocumentRanks := func(ctx context.Context, repoName string) (types.RepoPathRanks, error) {
+		return types.RepoPathRanks{Paths: mockRanks}, nil
+	}
+
+	client := &countingEmbeddingsClient{}
+	previousIndex, err := EmbedRepo(ctx, repoName, "rev1", []string{"a.go", "b.go", "c.md"}, excludedGlobPatterns, client, splitOptions, readFile, getDocumentRanks)
+	require.NoError(t, err)
+	require.Len(t, previousIndex.CodeIndex.RowMetadata, 4)
+	require.Len(t, previousIndex.TextIndex.RowMetadata, 2)
+
+	fileNames := func(index embeddings.EmbeddingIndex) []string {
+		names := make([]string, 0, len(index.RowMetadata))
+		for _, row := range index.RowMetadata {
+			names = append(names, row.FileName)
+		}
+		return names
+	}
+
+	// a.go was modified, c.md was deleted and d.go was added.
+	mockFiles["a.go"] = mockFile(strings.Repeat("e", 32))
+	mockFiles["d.go"] = mockFile(strings.Repeat("f", 32), "", strings.Repeat("g", 32))
+	mockRanks["b.go"] = 0.5
+	client = &countingEmbeddingsClient{}
+	index, err := UpdateRepoEmbeddingIndex(ctx, previousIndex, "rev2", []string{"a.go", "d.go"}, []string{"c.md"}, excludedGlobPatterns, client, splitOptions, readFile, getDocumentRanks)
+	require.NoError(t, err)
+
+	require.Equal(t, api.CommitID("rev2"), index.Revision)
+	require.Equal(t, repoName, index.RepoName)
+	// Only the chunks of the modified files were embedded.
+	require.Equal(t, 3, client.embeddedTexts)
+	require.Equal(t, []string{"b.go", "b.go", "a.go", "d.go", "d.go"}, fileNames(index.CodeIndex))
+	require.Len(t, index.CodeIndex.Embeddings, 15)
+	// The ranks of unmodified files are recomputed as well.
+	require.Equal(t, []float32{0.5, 0.5, 0.1, 0, 0}, index.CodeIndex.Ranks)
+	require.Empty(t, index.TextIndex.RowMetadata)
+	require.Empty(t, index.TextIndex.Embeddings)
+	require.Empty(t, index.TextIndex.Ranks)
+
+	t.Run("excluded files are dropped", func(t *testing.T) {
+		excluded := append(GetDefaultExcludedFilePathPatterns(), CompileGlobPatterns([]string{"b.go"})...)
+		index, err := UpdateRepoEmbeddingIndex(ctx, index, "rev3", nil, nil, excluded, client, splitOptions, readFile, getDocumentRanks)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a.go", "d.go", "d.go"}, fileNames(index.CodeIndex))
+		require.Len(t, index.CodeIndex.Embeddings, 9)
+	})
+}
+
+type countingEmbeddingsClient struct {
+	mockEmbeddingsClient
+	embeddedTexts int
+}
+
+func (c *countingEmbeddingsClient) GetEmbeddingsWithRetries(texts []string, maxRetries int) ([]float32, error) {
+	c.embeddedTexts += len(texts)
+	return c.mockEmbeddingsClient.GetEmbeddingsWithRetries(texts, maxRetries)
+}
+
 func NewMockEmbeddingsClient() EmbeddingsClient {
 	return &mockEmbeddingsClient{}
 }
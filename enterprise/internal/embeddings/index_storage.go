@@ -124,6 +124,20 @@ func encodeRepoEmbeddingIndex(enc *gob.Encoder, rei *RepoEmbeddingIndex, chunkSi
 		}
 	}
 
+	// The ANN indexes are encoded after both embedding indexes, so that indexes encoded before
+	// ANN indexes existed can still be decoded.
+	for _, ei := range []EmbeddingIndex{rei.CodeIndex, rei.TextIndex} {
+		if err := enc.Encode(ei.ANN != nil); err != nil {
+			return err
+		}
+		if ei.ANN == nil {
+			continue
+		}
+		if err := enc.Encode(ei.ANN); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -166,5 +180,22 @@ func decodeRepoEmbeddingIndex(dec *gob.Decoder) (*RepoEmbeddingIndex, error) {
 		}
 	}
 
+	for i, ei := range []*EmbeddingIndex{&rei.CodeIndex, &rei.TextIndex} {
+		var hasANN bool
+		if err := dec.Decode(&hasANN); err != nil {
+			// Indexes encoded before ANN indexes existed end after the embeddings.
+			if i == 0 && err == io.EOF {
+				return rei, nil
+			}
+			return nil, err
+		}
+		if !hasANN {
+			continue
+		}
+		if err := dec.Decode(&ei.ANN); err != nil {
+			return nil, err
+		}
+	}
+
 	return rei, nil
 }
@@ -128,6 +128,38 @@ func TestRepoEmbeddingIndexStorage(t *testing.T) {
 	require.Equal(t, index, downloadedIndex)
 }
 
+func TestRepoEmbeddingIndexStorageWithANNIndex(t *testing.T) {
+	index := &RepoEmbeddingIndex{
+		RepoName: api.RepoName("repo"),
+		Revision: api.CommitID("commit"),
+		CodeIndex: EmbeddingIndex{
+			Embeddings:      []float32{0.0, 0.1, 0.2, 0.3, 0.4, 0.5},
+			ColumnDimension: 3,
+			RowMetadata:     []RepoEmbeddingRowMetadata{{FileName: "a.go", StartLine: 0, EndLine: 1}, {FileName: "b.go", StartLine: 0, EndLine: 1}},
+		},
+		TextIndex: EmbeddingIndex{
+			Embeddings:      []float32{1.0, 2.1, 3.2},
+			ColumnDimension: 3,
+			RowMetadata:     []RepoEmbeddingRowMetadata{{FileName: "b.py", StartLine: 0, EndLine: 1}},
+		},
+	}
+	index.CodeIndex.ANN = &IVFIndex{
+		Centroids: []float32{0.0, 0.1, 0.2, 0.3, 0.4, 0.5},
+		Lists:     [][]int32{{0}, {1}},
+	}
+
+	ctx := context.Background()
+	uploadStore := newMockUploadStore()
+
+	err := UploadRepoEmbeddingIndex(ctx, uploadStore, "index", index)
+	require.NoError(t, err)
+
+	downloadedIndex, err := DownloadRepoEmbeddingIndex(ctx, uploadStore, "index")
+	require.NoError(t, err)
+
+	require.Equal(t, index, downloadedIndex)
+}
+
 func TestRepoEmbeddingVersionMismatch(t *testing.T) {
 	index := &RepoEmbeddingIndex{
 		RepoName: api.RepoName("repo"),
@@ -82,6 +82,8 @@ type WorkerOptions struct {
 }
 
 // SimilaritySearch finds the `nResults` most similar rows to a query vector. It uses the cosine similarity metric.
+// If the index has an approximate nearest neighbor index and opts.ANNProbes is set, only the rows in the
+// closest lists of the ANN index are searched.
 // IMPORTANT: The vectors in the embedding index have to be normalized for similarity search to work correctly.
 func (index *EmbeddingIndex) SimilaritySearch(query []float32, numResults int, workerOptions WorkerOptions, opts SearchOptions) []EmbeddingSearchResult {
 	if numResults == 0 {
@@ -89,6 +91,12 @@ func (index *EmbeddingIndex) SimilaritySearch(query []float32, numResults int, w
 	}
 
 	numRows := len(index.RowMetadata)
+	// candidateRows are the rows to search. If nil, all rows are searched.
+	var candidateRows []int32
+	if index.ANN != nil && opts.ANNProbes > 0 && opts.ANNProbes < len(index.ANN.Lists) {
+		candidateRows = index.ANN.candidateRows(query, opts.ANNProbes)
+		numRows = len(candidateRows)
+	}
 	// Cannot request more results than there are rows.
 	numResults = min(numRows, numResults)
 	// We need at least 1 worker.
@@ -104,13 +112,13 @@ func (index *EmbeddingIndex) SimilaritySearch(query []float32, numResults int, w
 			// Capture the loop variable value so we can use it in the closure below.
 			workerIdx := workerIdx
 			wg.Go(func() {
-				heaps[workerIdx] = index.partialSimilaritySearch(query, numResults, rowsPerWorker[workerIdx], opts)
+				heaps[workerIdx] = index.partialSimilaritySearch(query, numResults, rowsPerWorker[workerIdx], candidateRows, opts)
 			})
 		}
 		wg.Wait()
 	} else {
 		// Run the similarity search directly when we have a single worker to eliminate the concurrency overhead.
-		heaps[0] = index.partialSimilaritySearch(query, numResults, rowsPerWorker[0], opts)
+		heaps[0] = index.partialSimilaritySearch(query, numResults, rowsPerWorker[0], candidateRows, opts)
 	}
 
 	// Collect all heap neighbors from workers into a single array.
@@ -136,26 +144,35 @@ func (index *EmbeddingIndex) SimilaritySearch(query []float32, numResults int, w
 	return results
 }
 
-func (index *EmbeddingIndex) partialSimilaritySearch(query []float32, numResults int, partialRows partialRows, opts SearchOptions) *nearestNeighborsHeap {
+// partialSimilaritySearch searches the rows in the given range. If candidateRows is not nil, the range refers to
+// positions in candidateRows instead of rows of the index.
+func (index *EmbeddingIndex) partialSimilaritySearch(query []float32, numResults int, partialRows partialRows, candidateRows []int32, opts SearchOptions) *nearestNeighborsHeap {
 	nRows := partialRows.end - partialRows.start
 	if nRows <= 0 {
 		return nil
 	}
 	numResults = min(nRows, numResults)
 
+	row := func(i int) int {
+		if candidateRows == nil {
+			return i
+		}
+		return int(candidateRows[i])
+	}
+
 	nnHeap := newNearestNeighborsHeap()
 	for i := partialRows.start; i < partialRows.start+numResults; i++ {
-		score, debugInfo := index.score(query, i, opts)
-		heap.Push(nnHeap, nearestNeighbor{index: i, score: score, debug: debugInfo})
+		score, debugInfo := index.score(query, row(i), opts)
+		heap.Push(nnHeap, nearestNeighbor{index: row(i), score: score, debug: debugInfo})
 	}
 
 	for i := partialRows.start + numResults; i < partialRows.end; i++ {
-		score, debugInfo := index.score(query, i, opts)
+		score, debugInfo := index.score(query, row(i), opts)
 		// Add row if it has greater similarity than the smallest similarity in the heap.
 		// This way we ensure keep a set of the highest similarities in the heap.
 		if score > nnHeap.Peek().score {
 			heap.Pop(nnHeap)
-			heap.Push(nnHeap, nearestNeighbor{index: i, score: score, debug: debugInfo})
+			heap.Push(nnHeap, nearestNeighbor{index: row(i), score: score, debug: debugInfo})
 		}
 	}
 
@@ -227,4 +244,8 @@ func max(a, b int) int {
 type SearchOptions struct {
 	Debug            bool
 	UseDocumentRanks bool
+	// ANNProbes is the number of lists of the approximate nearest neighbor index that are searched.
+	// Searching more lists improves recall at the cost of latency. If zero, or if the index has no
+	// ANN index, all rows are searched.
+	ANNProbes int
 }
@@ -9,6 +9,9 @@ type EmbeddingIndex struct {
 	ColumnDimension int
 	RowMetadata     []RepoEmbeddingRowMetadata
 	Ranks           []float32
+	// ANN is an optional approximate nearest neighbor index of the rows. It is only built for
+	// large indexes.
+	ANN *IVFIndex
 }
 
 type RepoEmbeddingRowMetadata struct {
@@ -9,11 +9,12 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/querybuilder"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
-	"github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
+	streamapi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming/client"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -85,6 +86,33 @@ func countAuthor(r result.Match) (map[MatchKey]int, error) {
 	return nil, nil
 }
 
+// OwnersFunc returns the owners of a file in a commit of a repository.
+type OwnersFunc func(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID, path string) ([]string, error)
+
+// CountOwnersFunc returns a count func that attributes the results in a file to each of the owners of the file.
+// Results in files without owners are not counted.
+func CountOwnersFunc(ctx context.Context, owners OwnersFunc) AggregationCountFunc {
+	return func(r result.Match) (map[MatchKey]int, error) {
+		match, ok := r.(*result.FileMatch)
+		if !ok {
+			return nil, nil
+		}
+		fileOwners, err := owners(ctx, match.Repo.Name, match.Repo.ID, match.CommitID, match.Path)
+		if err != nil {
+			return nil, errors.Wrap(err, "resolving owners")
+		}
+		matches := make(map[MatchKey]int, len(fileOwners))
+		for _, owner := range fileOwners {
+			matches[MatchKey{
+				RepoID: int32(r.RepoName().ID),
+				Repo:   string(r.RepoName().Name),
+				Group:  owner,
+			}] = r.ResultCount()
+		}
+		return matches, nil
+	}
+}
+
 func countCaptureGroupsFunc(querystring string) (AggregationCountFunc, error) {
 	pattern, err := getCasedPattern(querystring)
 	if err != nil {
@@ -195,7 +223,7 @@ type searchAggregationResults struct {
 
 func (r *searchAggregationResults) ShardTimeoutOccurred() bool {
 	for _, skip := range r.progress.Current().Skipped {
-		if skip.Reason == api.ShardTimeout {
+		if skip.Reason == streamapi.ShardTimeout {
 			return true
 		}
 	}
@@ -224,12 +252,12 @@ func (r *searchAggregationResults) Send(event streaming.SearchEvent) {
 			return
 		default:
 			groups, err := r.countFunc(match)
-			for groupKey, count := range groups {
+			if err != nil {
 				// delegate error handling to the passed in tabulator
-				if err != nil {
-					r.tabulator(nil, err)
-					continue
-				}
+				r.tabulator(nil, err)
+				continue
+			}
+			for groupKey, count := range groups {
 				current := combined[groupKey]
 				combined[groupKey] = current + count
 			}
@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	internaltypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func newTestSearchResultsAggregator(ctx context.Context, tabulator AggregationTabulator, countFunc AggregationCountFunc) SearchResultsAggregator {
@@ -438,6 +439,77 @@ func TestPathAggregation(t *testing.T) {
 	}
 }
 
+func TestOwnerAggregation(t *testing.T) {
+	owners := func(_ context.Context, _ api.RepoName, _ api.RepoID, _ api.CommitID, path string) ([]string, error) {
+		switch path {
+		case "file.go":
+			return []string{"@backend"}, nil
+		case "shared.go":
+			return []string{"@backend", "@frontend"}, nil
+		case "broken.go":
+			return nil, errors.New("CODEOWNERS unavailable")
+		}
+		return nil, nil
+	}
+
+	testCases := []struct {
+		name        string
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+		wantErrors  int
+	}{
+		{
+			name:        "No results",
+			searchEvent: streaming.SearchEvent{},
+			want:        autogold.Expect(map[string]int{}),
+		},
+		{
+			name: "no owner for commit or repo",
+			searchEvent: streaming.SearchEvent{
+				Results: []result.Match{
+					commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+					repoMatch("myRepo", 1),
+				},
+			},
+			want: autogold.Expect(map[string]int{}),
+		},
+		{
+			name: "results attributed to each owner",
+			searchEvent: streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "file.go", 1, "a", "b"),
+					contentMatch("myRepo2", "shared.go", 2, "a"),
+					symbolMatch("myRepo", "shared.go", 1, "c", "d"),
+					pathMatch("myRepo", "unowned.go", 1),
+				},
+			},
+			want: autogold.Expect(map[string]int{"@backend": 5, "@frontend": 3}),
+		},
+		{
+			name: "errors are reported to the tabulator",
+			searchEvent: streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "broken.go", 1, "a"),
+					contentMatch("myRepo", "file.go", 1, "a"),
+				},
+			},
+			want:       autogold.Expect(map[string]int{"@backend": 1}),
+			wantErrors: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, CountOwnersFunc(context.Background(), owners))
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+			if len(aggregator.errors) != tc.wantErrors {
+				t.Errorf("expected %d errors, got %v", tc.wantErrors, aggregator.errors)
+			}
+		})
+	}
+}
+
 func TestCaptureGroupAggregation(t *testing.T) {
 	longCaptureGroup := "111111111|222222222|333333333|444444444|555555555|666666666|777777777|888888888|999999999|000000000|"
 	testCases := []struct {
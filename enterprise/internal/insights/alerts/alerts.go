@@ -0,0 +1,125 @@
+// Package alerts evaluates alert rules on code insight series and delivers notifications when they fire.
+package alerts
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+// Values is the value of a series at a single point in time, keyed by repository name.
+type Values map[string]float64
+
+// Total returns the sum of the values across all repositories.
+func (v Values) Total() float64 {
+	var total float64
+	for _, value := range v {
+		total += value
+	}
+	return total
+}
+
+// Event describes an alert that fired.
+type Event struct {
+	Alert  types.InsightSeriesAlert
+	Series *types.InsightSeries
+	Time   time.Time
+
+	// Value is the current total value of the series.
+	Value float64
+
+	// PreviousValue and PercentChange are only set for PERCENT_CHANGE alerts.
+	PreviousValue *float64
+	PercentChange *float64
+
+	// NewRepositories is only set for NEW_REPOSITORY alerts.
+	NewRepositories []string
+}
+
+// evaluate determines whether the condition of an alert holds given the current value of the series, the
+// previous snapshot (nil if there is none) and the total value of the series WindowDays ago (nil if unknown).
+//
+// The returned event is non-nil if a notification should be sent. THRESHOLD and PERCENT_CHANGE alerts only
+// notify when their condition starts to hold, so a series that stays above a threshold does not notify on every
+// snapshot. NEW_REPOSITORY alerts compare consecutive snapshots, so they notify whenever their condition holds.
+func evaluate(alert types.InsightSeriesAlert, series *types.InsightSeries, recordTime time.Time, previous, current Values, baseline *float64) (holds bool, event *Event) {
+	newEvent := func() *Event {
+		return &Event{Alert: alert, Series: series, Time: recordTime, Value: current.Total()}
+	}
+
+	switch alert.Kind {
+	case types.ThresholdAlert:
+		holds = current.Total() > alert.Threshold
+		if holds && !alert.Firing {
+			event = newEvent()
+		}
+
+	case types.PercentChangeAlert:
+		if baseline == nil || *baseline == 0 {
+			return false, nil
+		}
+		change := (current.Total() - *baseline) / *baseline * 100
+		holds = math.Abs(change) >= alert.Threshold
+		if holds && !alert.Firing {
+			event = newEvent()
+			event.PreviousValue = baseline
+			event.PercentChange = &change
+		}
+
+	case types.NewRepositoryAlert:
+		if previous == nil {
+			return false, nil
+		}
+		var repos []string
+		for repo, value := range current {
+			if value > 0 && previous[repo] <= 0 {
+				repos = append(repos, repo)
+			}
+		}
+		sort.Strings(repos)
+		holds = len(repos) > 0
+		if holds {
+			event = newEvent()
+			event.NewRepositories = repos
+		}
+	}
+
+	return holds, event
+}
+
+// Summary returns a one line, human readable description of why the alert fired.
+func (e *Event) Summary() string {
+	switch e.Alert.Kind {
+	case types.ThresholdAlert:
+		return fmt.Sprintf("rose above %s (current value: %s)", formatValue(e.Alert.Threshold), formatValue(e.Value))
+	case types.PercentChangeAlert:
+		return fmt.Sprintf("changed by %+.1f%% over the past %s (from %s to %s)",
+			derefFloat(e.PercentChange), pluralize(e.Alert.WindowDays, "day", "days"), formatValue(derefFloat(e.PreviousValue)), formatValue(e.Value))
+	case types.NewRepositoryAlert:
+		return fmt.Sprintf("has results in %s for the first time: %s",
+			pluralize(len(e.NewRepositories), "new repository", "new repositories"), strings.Join(e.NewRepositories, ", "))
+	}
+	return fmt.Sprintf("fired (current value: %s)", formatValue(e.Value))
+}
+
+func formatValue(v float64) string {
+	return fmt.Sprintf("%g", v)
+}
+
+func derefFloat(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
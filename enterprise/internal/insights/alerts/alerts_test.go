@@ -0,0 +1,137 @@
+package alerts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold/v2"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestEvaluate(t *testing.T) {
+	series := &types.InsightSeries{ID: 1, SeriesID: "series1", Query: "deprecatedAPI"}
+	recordTime := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+	baseline := func(v float64) *float64 { return &v }
+
+	testCases := []struct {
+		name     string
+		alert    types.InsightSeriesAlert
+		previous Values
+		current  Values
+		baseline *float64
+
+		wantHolds   bool
+		wantSummary string // empty if no notification is expected
+	}{
+		{
+			name:        "threshold crossed",
+			alert:       types.InsightSeriesAlert{Kind: types.ThresholdAlert, Threshold: 10},
+			current:     Values{"a": 6, "b": 5},
+			wantHolds:   true,
+			wantSummary: "rose above 10 (current value: 11)",
+		},
+		{
+			name:      "threshold still exceeded",
+			alert:     types.InsightSeriesAlert{Kind: types.ThresholdAlert, Threshold: 10, Firing: true},
+			current:   Values{"a": 11},
+			wantHolds: true,
+		},
+		{
+			name:    "threshold not exceeded",
+			alert:   types.InsightSeriesAlert{Kind: types.ThresholdAlert, Threshold: 10, Firing: true},
+			current: Values{"a": 10},
+		},
+		{
+			name:        "percent increase",
+			alert:       types.InsightSeriesAlert{Kind: types.PercentChangeAlert, Threshold: 20, WindowDays: 7},
+			current:     Values{"a": 15},
+			baseline:    baseline(10),
+			wantHolds:   true,
+			wantSummary: "changed by +50.0% over the past 7 days (from 10 to 15)",
+		},
+		{
+			name:        "percent decrease",
+			alert:       types.InsightSeriesAlert{Kind: types.PercentChangeAlert, Threshold: 20, WindowDays: 1},
+			current:     Values{"a": 5},
+			baseline:    baseline(10),
+			wantHolds:   true,
+			wantSummary: "changed by -50.0% over the past 1 day (from 10 to 5)",
+		},
+		{
+			name:     "percent change below threshold",
+			alert:    types.InsightSeriesAlert{Kind: types.PercentChangeAlert, Threshold: 20, WindowDays: 7},
+			current:  Values{"a": 11},
+			baseline: baseline(10),
+		},
+		{
+			name:    "percent change without baseline",
+			alert:   types.InsightSeriesAlert{Kind: types.PercentChangeAlert, Threshold: 20, WindowDays: 7},
+			current: Values{"a": 11},
+		},
+		{
+			name:     "percent change from zero",
+			alert:    types.InsightSeriesAlert{Kind: types.PercentChangeAlert, Threshold: 20, WindowDays: 7},
+			current:  Values{"a": 11},
+			baseline: baseline(0),
+		},
+		{
+			name:        "new repositories",
+			alert:       types.InsightSeriesAlert{Kind: types.NewRepositoryAlert, Firing: true},
+			previous:    Values{"a": 1, "b": 0},
+			current:     Values{"a": 1, "b": 2, "c": 3, "d": 0},
+			wantHolds:   true,
+			wantSummary: "has results in 2 new repositories for the first time: b, c",
+		},
+		{
+			name:     "no new repositories",
+			alert:    types.InsightSeriesAlert{Kind: types.NewRepositoryAlert},
+			previous: Values{"a": 1, "b": 2},
+			current:  Values{"a": 3},
+		},
+		{
+			name:    "first snapshot",
+			alert:   types.InsightSeriesAlert{Kind: types.NewRepositoryAlert},
+			current: Values{"a": 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			holds, event := evaluate(tc.alert, series, recordTime, tc.previous, tc.current, tc.baseline)
+			if holds != tc.wantHolds {
+				t.Errorf("unexpected holds: want %v, got %v", tc.wantHolds, holds)
+			}
+			if tc.wantSummary == "" {
+				if event != nil {
+					t.Fatalf("unexpected event: %s", event.Summary())
+				}
+				return
+			}
+			if event == nil {
+				t.Fatal("expected an event")
+			}
+			if summary := event.Summary(); summary != tc.wantSummary {
+				t.Errorf("unexpected summary:\nwant %q\ngot  %q", tc.wantSummary, summary)
+			}
+		})
+	}
+}
+
+func TestWebhookPayload(t *testing.T) {
+	series := &types.InsightSeries{ID: 1, SeriesID: "series1", Query: "deprecatedAPI"}
+	_, event := evaluate(
+		types.InsightSeriesAlert{ID: 5, Kind: types.NewRepositoryAlert},
+		series,
+		time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+		Values{"github.com/a/a": 1},
+		Values{"github.com/a/a": 1, "github.com/b/b": 2},
+		nil,
+	)
+
+	payload, err := marshalWebhookPayload(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Expect(`{"alert_id":5,"series_id":"series1","query":"deprecatedAPI","kind":"NEW_REPOSITORY","threshold":0,"time":"2023-04-01T00:00:00Z","value":3,"new_repositories":["github.com/b/b"],"summary":"has results in 1 new repository for the first time: github.com/b/b"}`).Equal(t, string(payload))
+}
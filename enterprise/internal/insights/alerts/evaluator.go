@@ -0,0 +1,135 @@
+package alerts
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Evaluator evaluates the alert rules of a series each time a new snapshot of it is recorded.
+type Evaluator struct {
+	alertStore *store.AlertStore
+	permStore  permStore
+	notifier   notifier
+	logger     log.Logger
+}
+
+type permStore interface {
+	GetUnauthorizedRepoIDs(ctx context.Context) ([]api.RepoID, error)
+}
+
+type notifier interface {
+	Notify(ctx context.Context, event *Event) error
+}
+
+// NewEvaluator returns an Evaluator that reads alert rules and series values from the given alert store, and
+// delivers notifications using the main application database.
+func NewEvaluator(logger log.Logger, db database.DB, alertStore *store.AlertStore) *Evaluator {
+	return &Evaluator{
+		alertStore: alertStore,
+		permStore:  store.NewInsightPermissionStore(db),
+		notifier:   &dbNotifier{db: db},
+		logger:     logger,
+	}
+}
+
+// Pending holds the alert rules of a series along with the snapshot of the series that is about to be replaced.
+type Pending struct {
+	evaluator *Evaluator
+	series    *types.InsightSeries
+	alerts    []types.InsightSeriesAlert
+	previous  Values
+}
+
+// Prepare loads the alert rules of a series and its current snapshot. It must be called before a new snapshot
+// replaces the current one, and returns nil if the series has no alert rules.
+func (e *Evaluator) Prepare(ctx context.Context, series *types.InsightSeries) (*Pending, error) {
+	alerts, err := e.alertStore.ListAlerts(ctx, store.ListAlertsArgs{SeriesIDs: []int{series.ID}})
+	if err != nil {
+		return nil, errors.Wrap(err, "ListAlerts")
+	}
+	if len(alerts) == 0 {
+		return nil, nil
+	}
+
+	previous, err := e.alertStore.SnapshotValues(ctx, series.SeriesID, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "SnapshotValues")
+	}
+	if len(previous) == 0 {
+		// There is nothing to compare against on the first snapshot of a series.
+		previous = nil
+	}
+
+	return &Pending{
+		evaluator: e,
+		series:    series,
+		alerts:    alerts,
+		previous:  previous,
+	}, nil
+}
+
+// Evaluate evaluates the alert rules against the snapshot recorded at recordTime and notifies about the ones
+// that fired. A failure to evaluate or deliver one alert does not prevent the others from being processed.
+func (p *Pending) Evaluate(ctx context.Context, recordTime time.Time) (err error) {
+	alertStore := p.evaluator.alertStore
+
+	for _, alert := range p.alerts {
+		// 🚨 SECURITY: Notifications include the value of the series and NEW_REPOSITORY notifications list
+		// repository names, so alerts are only evaluated on the repositories the owner of the alert has access to.
+		denylist, permErr := p.evaluator.permStore.GetUnauthorizedRepoIDs(actor.WithActor(ctx, actor.FromUser(int32(alert.UserID))))
+		if permErr != nil {
+			err = errors.Append(err, errors.Wrapf(permErr, "GetUnauthorizedRepoIDs for alert %d", alert.ID))
+			continue
+		}
+
+		values, valuesErr := alertStore.SnapshotValues(ctx, p.series.SeriesID, denylist)
+		if valuesErr != nil {
+			err = errors.Append(err, errors.Wrapf(valuesErr, "SnapshotValues for alert %d", alert.ID))
+			continue
+		}
+
+		var baseline *float64
+		if alert.Kind == types.PercentChangeAlert {
+			total, ok, totalErr := alertStore.TotalValueAt(ctx, p.series.SeriesID, recordTime.AddDate(0, 0, -alert.WindowDays), denylist)
+			if totalErr != nil {
+				err = errors.Append(err, errors.Wrapf(totalErr, "TotalValueAt for alert %d", alert.ID))
+				continue
+			}
+			if ok {
+				baseline = &total
+			}
+		}
+
+		holds, event := evaluate(alert, p.series, recordTime, p.previous, values, baseline)
+
+		var firedAt *time.Time
+		if event != nil {
+			p.evaluator.logger.Debug("insights alert fired",
+				log.Int("alertId", alert.ID),
+				log.String("seriesId", p.series.SeriesID),
+				log.String("kind", string(alert.Kind)))
+
+			if notifyErr := p.evaluator.notifier.Notify(ctx, event); notifyErr != nil {
+				// Leave the firing state untouched so delivery is attempted again on the next snapshot.
+				err = errors.Append(err, errors.Wrapf(notifyErr, "Notify for alert %d", alert.ID))
+				continue
+			}
+			firedAt = &recordTime
+		}
+
+		if setErr := alertStore.SetAlertFiring(ctx, alert.ID, holds, firedAt); setErr != nil {
+			err = errors.Append(err, errors.Wrapf(setErr, "SetAlertFiring for alert %d", alert.ID))
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,259 @@
+package alerts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+type fakePermStore struct {
+	unauthorized map[int32][]api.RepoID
+}
+
+func (f *fakePermStore) GetUnauthorizedRepoIDs(ctx context.Context) ([]api.RepoID, error) {
+	return f.unauthorized[actor.FromContext(ctx).UID], nil
+}
+
+type fakeNotifier struct {
+	events []*Event
+}
+
+func (f *fakeNotifier) Notify(_ context.Context, event *Event) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+func TestEvaluatorNewRepositoryPermissions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Microsecond).UTC()
+
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	insightStore := store.NewInsightStore(insightsDB)
+	seriesStore := store.New(insightsDB, store.NewInsightPermissionStore(postgres))
+	alertStore := store.NewAlertStore(insightsDB)
+
+	series, err := insightStore.CreateSeries(ctx, types.InsightSeries{
+		SeriesID:           "series1",
+		Query:              "query1",
+		CreatedAt:          now,
+		OldestHistoricalAt: now,
+		LastRecordedAt:     now,
+		NextRecordingAfter: now,
+		LastSnapshotAt:     now,
+		NextSnapshotAfter:  now,
+		BackfillQueuedAt:   now,
+		SampleIntervalUnit: string(types.Month),
+		GenerationMethod:   types.Search,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The owner of the first alert has access to every repository, the owner of the second one doesn't have
+	// access to github.com/c/c.
+	for _, userID := range []int{1, 2} {
+		if _, err := alertStore.CreateAlert(ctx, types.InsightSeriesAlert{
+			SeriesID: series.ID,
+			UserID:   userID,
+			Kind:     types.NewRepositoryAlert,
+			Email:    true,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	recordSnapshot := func(values map[string]float64) {
+		t.Helper()
+		if err := seriesStore.DeleteSnapshots(ctx, &series); err != nil {
+			t.Fatal(err)
+		}
+		repoIDs := map[string]api.RepoID{"github.com/a/a": 1, "github.com/b/b": 2, "github.com/c/c": 3}
+		var args []store.RecordSeriesPointArgs
+		for name, value := range values {
+			name, repoID := name, repoIDs[name]
+			args = append(args, store.RecordSeriesPointArgs{
+				SeriesID:    series.SeriesID,
+				Point:       store.SeriesPoint{Time: now, Value: value},
+				RepoName:    &name,
+				RepoID:      &repoID,
+				PersistMode: store.SnapshotMode,
+			})
+		}
+		if err := seriesStore.RecordSeriesPoints(ctx, args); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	notifier := &fakeNotifier{}
+	evaluator := &Evaluator{
+		alertStore: alertStore,
+		permStore:  &fakePermStore{unauthorized: map[int32][]api.RepoID{2: {3}}},
+		notifier:   notifier,
+		logger:     logger,
+	}
+
+	recordSnapshot(map[string]float64{"github.com/a/a": 1})
+	pending, err := evaluator.Prepare(ctx, &series)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recordSnapshot(map[string]float64{"github.com/a/a": 1, "github.com/b/b": 2, "github.com/c/c": 3})
+	if err := pending.Evaluate(ctx, now); err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[int][]string)
+	for _, event := range notifier.events {
+		got[event.Alert.UserID] = event.NewRepositories
+	}
+	want := map[int][]string{
+		1: {"github.com/b/b", "github.com/c/c"},
+		2: {"github.com/b/b"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected new repositories (-want +got):\n%s", diff)
+	}
+
+	// A repository the owner can't access doesn't fire an alert on its own.
+	notifier.events = nil
+	pending.previous = Values{"github.com/a/a": 1, "github.com/b/b": 2}
+	if err := pending.Evaluate(ctx, now); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifier.events) != 1 || notifier.events[0].Alert.UserID != 1 {
+		t.Fatalf("expected a single notification for the owner with access, got %d", len(notifier.events))
+	}
+	if diff := cmp.Diff([]string{"github.com/c/c"}, notifier.events[0].NewRepositories); diff != "" {
+		t.Errorf("unexpected new repositories (-want +got):\n%s", diff)
+	}
+}
+
+func TestEvaluatorValuePermissions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Microsecond).UTC()
+	lastWeek := now.AddDate(0, 0, -7)
+
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	insightStore := store.NewInsightStore(insightsDB)
+	seriesStore := store.New(insightsDB, store.NewInsightPermissionStore(postgres))
+	alertStore := store.NewAlertStore(insightsDB)
+
+	series, err := insightStore.CreateSeries(ctx, types.InsightSeries{
+		SeriesID:           "series1",
+		Query:              "query1",
+		CreatedAt:          now,
+		OldestHistoricalAt: now,
+		LastRecordedAt:     now,
+		NextRecordingAfter: now,
+		LastSnapshotAt:     now,
+		NextSnapshotAfter:  now,
+		BackfillQueuedAt:   now,
+		SampleIntervalUnit: string(types.Month),
+		GenerationMethod:   types.Search,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// User 1 has access to every repository, user 2 doesn't have access to github.com/c/c.
+	var alerts []types.InsightSeriesAlert
+	for _, userID := range []int{1, 2} {
+		for _, alert := range []types.InsightSeriesAlert{
+			{Kind: types.ThresholdAlert, Threshold: 5},
+			{Kind: types.PercentChangeAlert, Threshold: 50, WindowDays: 7},
+		} {
+			alert.SeriesID = series.ID
+			alert.UserID = userID
+			alert.Email = true
+			created, err := alertStore.CreateAlert(ctx, alert)
+			if err != nil {
+				t.Fatal(err)
+			}
+			alerts = append(alerts, created)
+		}
+	}
+
+	repoIDs := map[string]api.RepoID{"github.com/a/a": 1, "github.com/b/b": 2, "github.com/c/c": 3}
+	points := func(at time.Time, mode store.PersistMode, values map[string]float64) []store.RecordSeriesPointArgs {
+		var args []store.RecordSeriesPointArgs
+		for name, value := range values {
+			name, repoID := name, repoIDs[name]
+			args = append(args, store.RecordSeriesPointArgs{
+				SeriesID:    series.SeriesID,
+				Point:       store.SeriesPoint{Time: at, Value: value},
+				RepoName:    &name,
+				RepoID:      &repoID,
+				PersistMode: mode,
+			})
+		}
+		return args
+	}
+
+	// The repository user 2 can't access accounts for the whole change over the past week, and pushes the
+	// total above the threshold.
+	args := points(lastWeek, store.RecordMode, map[string]float64{"github.com/a/a": 2, "github.com/b/b": 2, "github.com/c/c": 1})
+	args = append(args, points(now, store.SnapshotMode, map[string]float64{"github.com/a/a": 2, "github.com/b/b": 2, "github.com/c/c": 10})...)
+	if err := seriesStore.RecordSeriesPoints(ctx, args); err != nil {
+		t.Fatal(err)
+	}
+
+	notifier := &fakeNotifier{}
+	evaluator := &Evaluator{
+		alertStore: alertStore,
+		permStore:  &fakePermStore{unauthorized: map[int32][]api.RepoID{2: {3}}},
+		notifier:   notifier,
+		logger:     logger,
+	}
+	pending := &Pending{evaluator: evaluator, series: &series, alerts: alerts}
+	if err := pending.Evaluate(ctx, now); err != nil {
+		t.Fatal(err)
+	}
+
+	type fired struct {
+		UserID int
+		Kind   types.AlertKind
+		Value  float64
+	}
+	var got []fired
+	for _, event := range notifier.events {
+		got = append(got, fired{UserID: event.Alert.UserID, Kind: event.Alert.Kind, Value: event.Value})
+	}
+	want := []fired{
+		{UserID: 1, Kind: types.ThresholdAlert, Value: 14},
+		{UserID: 1, Kind: types.PercentChangeAlert, Value: 14},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected notifications (-want +got):\n%s", diff)
+	}
+
+	firing, err := alertStore.ListAlerts(ctx, store.ListAlertsArgs{UserID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, alert := range firing {
+		if alert.Firing {
+			t.Errorf("expected %s alert of user 2 not to be firing", alert.Kind)
+		}
+	}
+}
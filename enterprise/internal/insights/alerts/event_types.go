@@ -0,0 +1,12 @@
+package alerts
+
+import "github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
+
+const SeriesAlertEventType = "code_insights:series_alert"
+
+func init() {
+	outbound.RegisterEventType(outbound.EventType{
+		Key:         SeriesAlertEventType,
+		Description: "sent when an alert on a code insight series fires",
+	})
+}
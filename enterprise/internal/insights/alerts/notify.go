@@ -0,0 +1,156 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// dbNotifier delivers alert notifications through every channel enabled on the alert.
+type dbNotifier struct {
+	db database.DB
+}
+
+func (n *dbNotifier) Notify(ctx context.Context, event *Event) (err error) {
+	if event.Alert.Email {
+		if emailErr := sendEmail(ctx, n.db, event); emailErr != nil {
+			err = errors.Append(err, errors.Wrap(emailErr, "email"))
+		}
+	}
+	if event.Alert.SlackWebhookURL != nil && *event.Alert.SlackWebhookURL != "" {
+		if slackErr := slack.PostWebhookCustomHTTPContext(ctx, *event.Alert.SlackWebhookURL, httpcli.ExternalClient, slackPayload(event)); slackErr != nil {
+			err = errors.Append(err, errors.Wrap(slackErr, "slack"))
+		}
+	}
+	if event.Alert.OutboundWebhook {
+		if webhookErr := enqueueWebhook(ctx, n.db, event); webhookErr != nil {
+			err = errors.Append(err, errors.Wrap(webhookErr, "outbound webhook"))
+		}
+	}
+	return err
+}
+
+type templateData struct {
+	Query     string
+	Summary   string
+	SearchURL string
+}
+
+func newTemplateData(event *Event) templateData {
+	return templateData{
+		Query:     event.Series.Query,
+		Summary:   event.Summary(),
+		SearchURL: searchURL(event.Series.Query),
+	}
+}
+
+func searchURL(query string) string {
+	u, err := url.Parse(conf.ExternalURL())
+	if err != nil {
+		return ""
+	}
+	u = u.JoinPath("search")
+	u.RawQuery = url.Values{"q": []string{query}, "utm_source": []string{"code-insights-alert"}}.Encode()
+	return u.String()
+}
+
+var alertEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Sourcegraph code insight alert: {{.Query}}`,
+	Text: `
+The code insight series "{{.Query}}" {{.Summary}}.
+
+View the current matches: {{.SearchURL}}
+`,
+	HTML: `
+<p>The code insight series <code>{{.Query}}</code> {{.Summary}}.</p>
+
+<p><a href="{{.SearchURL}}">View the current matches</a></p>
+`,
+})
+
+func sendEmail(ctx context.Context, db database.DB, event *Event) error {
+	userID := int32(event.Alert.UserID)
+	email, verified, err := db.UserEmails().GetPrimaryEmail(ctx, userID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Errorf("unable to send email to user ID %d with unknown email address", userID)
+		}
+		return errors.Wrapf(err, "GetPrimaryEmail for userID=%d", userID)
+	}
+	if !verified {
+		return errors.Newf("unable to send email to user ID %d's unverified primary email address", userID)
+	}
+
+	return internalapi.Client.SendEmail(ctx, "code-insights-alert", txtypes.Message{
+		To:       []string{email},
+		Template: alertEmailTemplates,
+		Data:     newTemplateData(event),
+	})
+}
+
+func slackPayload(event *Event) *slack.WebhookMessage {
+	data := newTemplateData(event)
+	text := fmt.Sprintf("Code insight series `%s` %s.", data.Query, data.Summary)
+	if data.SearchURL != "" {
+		text += fmt.Sprintf(" <%s|View the current matches>", data.SearchURL)
+	}
+	return &slack.WebhookMessage{Text: text}
+}
+
+// webhookPayload is the payload of the SeriesAlertEventType outbound webhook.
+type webhookPayload struct {
+	AlertID         int             `json:"alert_id"`
+	SeriesID        string          `json:"series_id"`
+	Query           string          `json:"query"`
+	Kind            types.AlertKind `json:"kind"`
+	Threshold       float64         `json:"threshold"`
+	WindowDays      int             `json:"window_days,omitempty"`
+	Time            time.Time       `json:"time"`
+	Value           float64         `json:"value"`
+	PreviousValue   *float64        `json:"previous_value,omitempty"`
+	PercentChange   *float64        `json:"percent_change,omitempty"`
+	NewRepositories []string        `json:"new_repositories,omitempty"`
+	Summary         string          `json:"summary"`
+}
+
+func marshalWebhookPayload(event *Event) ([]byte, error) {
+	return json.Marshal(webhookPayload{
+		AlertID:         event.Alert.ID,
+		SeriesID:        event.Series.SeriesID,
+		Query:           event.Series.Query,
+		Kind:            event.Alert.Kind,
+		Threshold:       event.Alert.Threshold,
+		WindowDays:      event.Alert.WindowDays,
+		Time:            event.Time.UTC(),
+		Value:           event.Value,
+		PreviousValue:   event.PreviousValue,
+		PercentChange:   event.PercentChange,
+		NewRepositories: event.NewRepositories,
+		Summary:         event.Summary(),
+	})
+}
+
+func enqueueWebhook(ctx context.Context, db database.DB, event *Event) error {
+	payload, err := marshalWebhookPayload(event)
+	if err != nil {
+		return errors.Wrap(err, "marshalling webhook payload")
+	}
+	svc := outbound.NewOutboundWebhookService(db, keyring.Default().OutboundWebhookKey)
+	return svc.Enqueue(ctx, SeriesAlertEventType, nil, payload)
+}
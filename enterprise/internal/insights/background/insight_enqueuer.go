@@ -153,7 +153,7 @@ func (ie *InsightEnqueuer) EnqueueSingle(
 		return errors.Wrapf(err, "GlobalQuery series_id:%s", seriesID)
 	}
 	finalQuery = modifiedQuery.String()
-	if series.GroupBy != nil {
+	if series.GroupBy != nil && querybuilder.MapType(*series.GroupBy).IsCompute() {
 		computeQuery, err := querybuilder.ComputeInsightCommandQuery(modifiedQuery, querybuilder.MapType(*series.GroupBy))
 		if err != nil {
 			return errors.Wrapf(err, "ComputeInsightCommandQuery series_id:%s", seriesID)
@@ -9,18 +9,20 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/discovery"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/ownership"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/streaming"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
-func GetSearchHandlers() map[types.GenerationMethod]InsightsHandler {
+func GetSearchHandlers(db database.DB) map[types.GenerationMethod]InsightsHandler {
 	searchStream := func(ctx context.Context, query string) (*streaming.TabulationResult, error) {
 		tr, ctx := trace.New(ctx, "CodeInsightsSearch", "searchStream")
 		defer tr.Finish()
@@ -56,8 +58,27 @@ func GetSearchHandlers() map[types.GenerationMethod]InsightsHandler {
 		return streamResults, nil
 	}
 
+	fileSearchStream := func(ctx context.Context, query string) (*streaming.FileTabulationResult, error) {
+		tr, ctx := trace.New(ctx, "CodeInsightsSearch", "fileSearchStream")
+		defer tr.Finish()
+
+		decoder, streamResults := streaming.FileTabulationDecoder()
+		err := streaming.Search(ctx, query, nil, decoder)
+		if err != nil {
+			return nil, errors.Wrap(err, "streaming.Search")
+		}
+		tr.AddEvent("search results", attribute.Int("count", streamResults.TotalCount), attribute.Bool("timeout", streamResults.DidTimeout), attribute.Int("file_count", len(streamResults.FileCounts)))
+		return streamResults, nil
+	}
+
+	// The attributor caches the CODEOWNERS rulesets of every commit it sees, so each job uses its own.
+	newOwnersFunc := func() ownersFunc {
+		return ownership.NewAttributor(gitserver.NewClient(), db).Owners
+	}
+
 	return map[types.GenerationMethod]InsightsHandler{
 		types.MappingCompute: makeMappingComputeHandler(computeTextExtraSearch),
+		types.MappingOwners:  makeOwnerHandler(fileSearchStream, newOwnersFunc),
 		types.SearchCompute:  makeComputeHandler(computeSearchStream),
 		types.Search:         makeSearchHandler(searchStream),
 	}
@@ -89,6 +110,10 @@ func toRecording(record *SearchJob, value float64, recordTime time.Time, repoNam
 
 type streamComputeProvider func(context.Context, string) (*streaming.ComputeTabulationResult, error)
 type streamSearchProvider func(context.Context, string) (*streaming.TabulationResult, error)
+type streamFileSearchProvider func(context.Context, string) (*streaming.FileTabulationResult, error)
+
+// ownersFunc returns the owners of a file in a commit of a repository.
+type ownersFunc func(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID, path string) ([]string, error)
 
 func generateComputeRecordingsStream(ctx context.Context, job *SearchJob, recordTime time.Time, provider streamComputeProvider, logger log.Logger) (_ []store.RecordSeriesPointArgs, err error) {
 	streamResults, err := provider(ctx, job.SearchQuery)
@@ -172,6 +197,71 @@ func generateSearchRecordingsStream(ctx context.Context, job *SearchJob, recordT
 	return recordings, nil
 }
 
+// generateOwnerRecordingsStream attributes the results of a search to the owners of the files they were found in, recording
+// one point per repository and owner. Results in files without owners are not recorded, and results in files with several
+// owners count towards each of them.
+func generateOwnerRecordingsStream(ctx context.Context, job *SearchJob, recordTime time.Time, provider streamFileSearchProvider, owners ownersFunc, logger log.Logger) ([]store.RecordSeriesPointArgs, error) {
+	tabulationResult, err := provider(ctx, job.SearchQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	tr := *tabulationResult
+	if len(tr.SkippedReasons) > 0 {
+		logger.Error("search encountered skipped events", log.String("seriesID", job.SeriesID), log.String("reasons", fmt.Sprintf("%v", tr.SkippedReasons)), log.String("query", job.SearchQuery))
+	}
+	if len(tr.Errors) > 0 {
+		return nil, classifiedError(tr.Errors, types.Search)
+	}
+	if tr.DidTimeout {
+		return nil, SearchTimeoutError
+	}
+	if len(tr.Alerts) > 0 {
+		return nil, errors.Errorf("streaming search: alerts: %v", tr.Alerts)
+	}
+
+	type repoOwner struct {
+		repoName string
+		repoID   api.RepoID
+		owner    string
+	}
+	counts := make(map[repoOwner]int)
+	checker := authz.DefaultSubRepoPermsChecker
+	excludedRepos := make(map[api.RepoID]bool)
+
+	for _, match := range tr.FileCounts {
+		// sub-repo permissions filtering. If the repo supports it, then it should be excluded from search results
+		repoID := api.RepoID(match.RepositoryID)
+		excluded, checked := excludedRepos[repoID]
+		if !checked {
+			subRepoEnabled, subRepoErr := authz.SubRepoEnabledForRepoID(ctx, checker, repoID)
+			if subRepoErr != nil {
+				logger.Error("sub-repo permissions check errored", log.String("seriesID", job.SeriesID), log.String("repo", match.RepositoryName), log.Error(subRepoErr))
+			}
+			excluded = subRepoEnabled || subRepoErr != nil
+			excludedRepos[repoID] = excluded
+		}
+		if excluded {
+			continue
+		}
+
+		fileOwners, err := owners(ctx, api.RepoName(match.RepositoryName), repoID, api.CommitID(match.Commit), match.Path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving owners of %s in %s", match.Path, match.RepositoryName)
+		}
+		for _, owner := range fileOwners {
+			counts[repoOwner{repoName: match.RepositoryName, repoID: repoID, owner: owner}] += match.MatchCount
+		}
+	}
+
+	var recordings []store.RecordSeriesPointArgs
+	for key, count := range counts {
+		owner := key.owner
+		recordings = append(recordings, toRecording(job, float64(count), recordTime, key.repoName, key.repoID, &owner)...)
+	}
+	return recordings, nil
+}
+
 func makeSearchHandler(provider streamSearchProvider) InsightsHandler {
 	return func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error) {
 		recordings, err := generateSearchRecordingsStream(ctx, job, recordTime, provider, log.Scoped("SearchRecordingsGenerator", ""))
@@ -205,6 +295,16 @@ func makeMappingComputeHandler(provider streamComputeProvider) InsightsHandler {
 	}
 }
 
+func makeOwnerHandler(provider streamFileSearchProvider, newOwnersFunc func() ownersFunc) InsightsHandler {
+	return func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error) {
+		recordings, err := generateOwnerRecordingsStream(ctx, job, recordTime, provider, newOwnersFunc(), log.Scoped("OwnerRecordingsGenerator", ""))
+		if err != nil {
+			return nil, errors.Wrapf(err, "ownerHandler")
+		}
+		return recordings, nil
+	}
+}
+
 func (r *workHandler) persistRecordings(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordings []store.RecordSeriesPointArgs, recordTime time.Time) (err error) {
 	tx, err := r.insightsStore.Transact(ctx)
 	if err != nil {
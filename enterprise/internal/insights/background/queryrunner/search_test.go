@@ -684,6 +684,76 @@ func TestGenerateSearchRecordingsStream(t *testing.T) {
 	})
 }
 
+func TestGenerateOwnerRecordingsStream(t *testing.T) {
+	date := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	job := SearchJob{
+		SeriesID:        "testseries1",
+		SearchQuery:     "searchit",
+		RecordTime:      &date,
+		PersistMode:     "record",
+		DependentFrames: nil,
+	}
+
+	mocked := func(context.Context, string) (*streaming.FileTabulationResult, error) {
+		return &streaming.FileTabulationResult{
+			FileCounts: []*streaming.FileMatch{
+				{RepositoryID: 11, RepositoryName: "github.com/sourcegraph/sourcegraph", Commit: "abc", Path: "cmd/main.go", MatchCount: 5},
+				{RepositoryID: 11, RepositoryName: "github.com/sourcegraph/sourcegraph", Commit: "abc", Path: "client/index.ts", MatchCount: 2},
+				{RepositoryID: 11, RepositoryName: "github.com/sourcegraph/sourcegraph", Commit: "abc", Path: "README.md", MatchCount: 1},
+				{RepositoryID: 22, RepositoryName: "github.com/sourcegraph/handbook", Commit: "def", Path: "lib/util.go", MatchCount: 3},
+			},
+			TotalCount: 11,
+		}, nil
+	}
+	owners := func(_ context.Context, _ api.RepoName, _ api.RepoID, _ api.CommitID, path string) ([]string, error) {
+		switch {
+		case strings.HasSuffix(path, ".go"):
+			return []string{"@backend"}, nil
+		case strings.HasSuffix(path, ".ts"):
+			return []string{"@backend", "@frontend"}, nil
+		}
+		return nil, nil
+	}
+
+	t.Run("attributes results to file owners", func(t *testing.T) {
+		recordings, err := generateOwnerRecordingsStream(context.Background(), &job, date, mocked, owners, logtest.Scoped(t))
+		if err != nil {
+			t.Error(err)
+		}
+		autogold.Expect([]string{
+			"github.com/sourcegraph/handbook 22 2021-12-01 00:00:00 +0000 UTC @backend 3.000000",
+			"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC @backend 7.000000",
+			"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC @frontend 2.000000",
+		}).Equal(t, stringify(recordings))
+	})
+
+	t.Run("excludes repositories with sub-repo permissions", func(t *testing.T) {
+		checker := authz.NewMockSubRepoPermissionChecker()
+		checker.EnabledFunc.SetDefaultReturn(true)
+		checker.EnabledForRepoIDFunc.SetDefaultHook(func(ctx context.Context, id api.RepoID) (bool, error) {
+			return id == 11, nil
+		})
+		authz.DefaultSubRepoPermsChecker = checker
+		t.Cleanup(func() { authz.DefaultSubRepoPermsChecker = nil })
+
+		recordings, err := generateOwnerRecordingsStream(context.Background(), &job, date, mocked, owners, logtest.Scoped(t))
+		if err != nil {
+			t.Error(err)
+		}
+		autogold.Expect([]string{"github.com/sourcegraph/handbook 22 2021-12-01 00:00:00 +0000 UTC @backend 3.000000"}).Equal(t, stringify(recordings))
+	})
+
+	t.Run("fails when owners cannot be resolved", func(t *testing.T) {
+		failing := func(context.Context, api.RepoName, api.RepoID, api.CommitID, string) ([]string, error) {
+			return nil, errors.New("gitserver unavailable")
+		}
+		_, err := generateOwnerRecordingsStream(context.Background(), &job, date, mocked, failing, logtest.Scoped(t))
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
 func TestFilterRecordsingsByRepo(t *testing.T) {
 	date := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
 	repo1 := &dbtypes.Repo{ID: 1, Name: "repo1"}
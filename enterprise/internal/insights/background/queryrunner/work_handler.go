@@ -9,6 +9,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/alerts"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/discovery"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
@@ -29,6 +30,7 @@ type workHandler struct {
 	insightsStore   *store.Store
 	repoStore       discovery.RepoStore
 	metadadataStore *store.InsightStore
+	alertEvaluator  *alerts.Evaluator
 	limiter         *ratelimit.InstrumentedLimiter
 	logger          log.Logger
 
@@ -142,7 +144,27 @@ func (r *workHandler) Handle(ctx context.Context, logger log.Logger, record *Job
 		return err
 	}
 
-	return r.persistRecordings(ctx, &job.SearchJob, series, recordings, recordTime)
+	// Alerts are evaluated against snapshots, which always reflect the most recent state of a series. The
+	// previous snapshot is replaced when the new one is persisted, so it has to be loaded first.
+	var pendingAlerts *alerts.Pending
+	if r.alertEvaluator != nil && store.PersistMode(job.PersistMode) == store.SnapshotMode {
+		pendingAlerts, err = r.alertEvaluator.Prepare(ctx, series)
+		if err != nil {
+			return errors.Wrap(err, "alertEvaluator.Prepare")
+		}
+	}
+
+	if err := r.persistRecordings(ctx, &job.SearchJob, series, recordings, recordTime); err != nil {
+		return err
+	}
+
+	if pendingAlerts != nil {
+		// Alert failures are logged rather than returned, retrying the job would record the snapshot again.
+		if alertErr := pendingAlerts.Evaluate(ctx, recordTime); alertErr != nil {
+			logger.Error("insights alert evaluation failed", log.String("seriesId", series.SeriesID), log.Error(alertErr))
+		}
+	}
+	return nil
 }
 
 func TranslateIncompleteReasons(err error) store.IncompleteReason {
@@ -0,0 +1,54 @@
+package derived
+
+import (
+	"sort"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Validate checks that every series referenced by the expression is one of the given labels.
+func (e *Expression) Validate(labels []string) error {
+	known := make(map[string]struct{}, len(labels))
+	for _, label := range labels {
+		known[label] = struct{}{}
+	}
+	for _, reference := range e.references {
+		if _, ok := known[reference]; !ok {
+			return errors.Newf("expression references unknown series %q", reference)
+		}
+	}
+	return nil
+}
+
+// Compute evaluates the expression at every time for which all referenced series have a point, using the
+// points of each series keyed by label. Times at which the expression is undefined are omitted.
+func Compute(seriesID string, expr *Expression, points map[string][]store.SeriesPoint) []store.SeriesPoint {
+	valuesAt := make(map[time.Time]map[string]float64)
+	for _, reference := range expr.references {
+		for _, point := range points[reference] {
+			at := point.Time.UTC()
+			if valuesAt[at] == nil {
+				valuesAt[at] = make(map[string]float64, len(expr.references))
+			}
+			valuesAt[at][reference] += point.Value
+		}
+	}
+
+	computed := make([]store.SeriesPoint, 0, len(valuesAt))
+	for at, values := range valuesAt {
+		if len(values) != len(expr.references) {
+			continue
+		}
+		value, ok := expr.Evaluate(values)
+		if !ok {
+			continue
+		}
+		computed = append(computed, store.SeriesPoint{SeriesID: seriesID, Time: at, Value: value})
+	}
+	sort.Slice(computed, func(i, j int) bool {
+		return computed[i].Time.Before(computed[j].Time)
+	})
+	return computed
+}
@@ -0,0 +1,56 @@
+package derived
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+)
+
+func TestValidate(t *testing.T) {
+	expr, err := Parse("migrated / (migrated + legacy)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := expr.Validate([]string{"legacy", "migrated"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	want := `expression references unknown series "legacy"`
+	if err := expr.Validate([]string{"migrated"}); err == nil || err.Error() != want {
+		t.Errorf("expected error %q, got %v", want, err)
+	}
+}
+
+func TestCompute(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, 4, d, 0, 0, 0, 0, time.UTC) }
+	expr, err := Parse("migrated / (migrated + legacy) * 100")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := Compute("derived-1", expr, map[string][]store.SeriesPoint{
+		"migrated": {
+			{Time: day(1), Value: 0},
+			{Time: day(2), Value: 5},
+			{Time: day(3), Value: 15},
+			{Time: day(4), Value: 20},
+		},
+		"legacy": {
+			// No point on day 4, so the expression is only computed for the first three days.
+			{Time: day(3), Value: 5},
+			{Time: day(2), Value: 15},
+			{Time: day(1), Value: 0},
+		},
+	})
+
+	// Day 1 is omitted because it divides by zero.
+	want := []store.SeriesPoint{
+		{SeriesID: "derived-1", Time: day(2), Value: 25},
+		{SeriesID: "derived-1", Time: day(3), Value: 75},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected points (-want +got):\n%s", diff)
+	}
+}
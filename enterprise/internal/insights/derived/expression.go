@@ -0,0 +1,307 @@
+// Package derived implements insight series that are computed at read time from an arithmetic expression
+// over the other series of the same insight view, for example `migrated / (migrated + legacy) * 100`.
+package derived
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Expression is a parsed derived series expression. Expressions support numbers, the binary operators
+// +, -, * and /, unary minus and parentheses. Any other name refers to the series with that label in the
+// same insight view. Labels that are not made of letters, digits and underscores must be double quoted,
+// for example `"legacy API" / total`.
+type Expression struct {
+	source     string
+	root       node
+	references []string
+}
+
+// Parse parses the given expression.
+func Parse(source string) (*Expression, error) {
+	p := &parser{source: source, referenced: map[string]struct{}{}}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenEOF {
+		return nil, errors.New("expression is empty")
+	}
+	root, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, p.unexpected()
+	}
+	if len(p.references) == 0 {
+		return nil, errors.New("expression must reference at least one series")
+	}
+	return &Expression{source: source, root: root, references: p.references}, nil
+}
+
+// String returns the expression as it was parsed.
+func (e *Expression) String() string { return e.source }
+
+// References returns the labels of the series referenced by the expression, in order of first use.
+func (e *Expression) References() []string { return e.references }
+
+// Evaluate evaluates the expression for the given series values keyed by label. The second return value
+// is false if the result is undefined, because a referenced value is missing or a division by zero occurs.
+func (e *Expression) Evaluate(values map[string]float64) (float64, bool) {
+	return e.root.eval(values)
+}
+
+type node interface {
+	eval(values map[string]float64) (float64, bool)
+}
+
+type numberNode float64
+
+func (n numberNode) eval(map[string]float64) (float64, bool) { return float64(n), true }
+
+type referenceNode string
+
+func (n referenceNode) eval(values map[string]float64) (float64, bool) {
+	v, ok := values[string(n)]
+	return v, ok
+}
+
+type negateNode struct{ operand node }
+
+func (n negateNode) eval(values map[string]float64) (float64, bool) {
+	v, ok := n.operand.eval(values)
+	return -v, ok
+}
+
+type binaryNode struct {
+	op          byte
+	left, right node
+}
+
+func (n binaryNode) eval(values map[string]float64) (float64, bool) {
+	l, ok := n.left.eval(values)
+	if !ok {
+		return 0, false
+	}
+	r, ok := n.right.eval(values)
+	if !ok {
+		return 0, false
+	}
+	switch n.op {
+	case '+':
+		return l + r, true
+	case '-':
+		return l - r, true
+	case '*':
+		return l * r, true
+	case '/':
+		if r == 0 {
+			return 0, false
+		}
+		return l / r, true
+	}
+	return 0, false
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenName
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value float64
+	pos   int
+}
+
+// parser is a recursive descent parser for the grammar:
+//
+//	sum     = product { ("+" | "-") product }
+//	product = unary { ("*" | "/") unary }
+//	unary   = "-" unary | primary
+//	primary = number | name | quoted | "(" sum ")"
+type parser struct {
+	source string
+	pos    int
+	tok    token
+
+	references []string
+	referenced map[string]struct{}
+}
+
+func (p *parser) parseSum() (node, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokenOperator && (p.tok.text == "+" || p.tok.text == "-") {
+		op := p.tok.text[0]
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseProduct() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokenOperator && (p.tok.text == "*" || p.tok.text == "/") {
+		op := p.tok.text[0]
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.tok.kind == tokenOperator && p.tok.text == "-" {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return negateNode{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokenNumber:
+		return numberNode(tok.value), p.next()
+	case tokenName:
+		if _, ok := p.referenced[tok.text]; !ok {
+			p.referenced[tok.text] = struct{}{}
+			p.references = append(p.references, tok.text)
+		}
+		return referenceNode(tok.text), p.next()
+	case tokenLeftParen:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		inner, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokenRightParen {
+			return nil, p.unexpected()
+		}
+		return inner, p.next()
+	}
+	return nil, p.unexpected()
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return errors.New("unexpected end of expression")
+	}
+	return errors.Newf("unexpected %q at position %d", p.tok.text, p.tok.pos+1)
+}
+
+// next advances p.tok to the next token in the source.
+func (p *parser) next() error {
+	for p.pos < len(p.source) && (p.source[p.pos] == ' ' || p.source[p.pos] == '\t' || p.source[p.pos] == '\n') {
+		p.pos++
+	}
+	start := p.pos
+	if start >= len(p.source) {
+		p.tok = token{kind: tokenEOF, pos: start}
+		return nil
+	}
+
+	switch c := p.source[start]; {
+	case c == '+' || c == '-' || c == '*' || c == '/':
+		p.pos++
+		p.tok = token{kind: tokenOperator, text: string(c), pos: start}
+	case c == '(':
+		p.pos++
+		p.tok = token{kind: tokenLeftParen, text: "(", pos: start}
+	case c == ')':
+		p.pos++
+		p.tok = token{kind: tokenRightParen, text: ")", pos: start}
+	case c == '"':
+		return p.scanQuoted()
+	case c == '.' || ('0' <= c && c <= '9'):
+		for p.pos < len(p.source) && (p.source[p.pos] == '.' || ('0' <= p.source[p.pos] && p.source[p.pos] <= '9')) {
+			p.pos++
+		}
+		text := p.source[start:p.pos]
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return errors.Newf("invalid number %q at position %d", text, start+1)
+		}
+		p.tok = token{kind: tokenNumber, text: text, value: value, pos: start}
+	default:
+		for p.pos < len(p.source) {
+			r, size := utf8.DecodeRuneInString(p.source[p.pos:])
+			if !isNameRune(r) {
+				break
+			}
+			p.pos += size
+		}
+		if p.pos == start {
+			r, _ := utf8.DecodeRuneInString(p.source[start:])
+			return errors.Newf("unexpected %q at position %d", string(r), start+1)
+		}
+		p.tok = token{kind: tokenName, text: p.source[start:p.pos], pos: start}
+	}
+	return nil
+}
+
+// scanQuoted scans a double quoted series label in which \" and \\ are the only escape sequences.
+func (p *parser) scanQuoted() error {
+	start := p.pos
+	p.pos++
+	var label strings.Builder
+	for p.pos < len(p.source) {
+		c := p.source[p.pos]
+		switch {
+		case c == '"':
+			p.pos++
+			if label.Len() == 0 {
+				return errors.Newf("empty series label at position %d", start+1)
+			}
+			p.tok = token{kind: tokenName, text: label.String(), pos: start}
+			return nil
+		case c == '\\' && p.pos+1 < len(p.source) && (p.source[p.pos+1] == '"' || p.source[p.pos+1] == '\\'):
+			label.WriteByte(p.source[p.pos+1])
+			p.pos += 2
+		default:
+			label.WriteByte(c)
+			p.pos++
+		}
+	}
+	return errors.Newf("unterminated series label starting at position %d", start+1)
+}
+
+func isNameRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,61 @@
+package derived
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	values := map[string]float64{"migrated": 30, "legacy": 10, "legacy API": 5, `say "hi"`: 2}
+
+	testCases := []struct {
+		expression     string
+		wantValue      float64
+		wantUndefined  bool
+		wantReferences []string
+		wantErr        string
+	}{
+		{expression: "migrated / (migrated + legacy) * 100", wantValue: 75, wantReferences: []string{"migrated", "legacy"}},
+		{expression: "migrated - legacy * 2", wantValue: 10, wantReferences: []string{"migrated", "legacy"}},
+		{expression: "(migrated - legacy) * 2", wantValue: 40, wantReferences: []string{"migrated", "legacy"}},
+		{expression: "-legacy + 1.5", wantValue: -8.5, wantReferences: []string{"legacy"}},
+		{expression: `"legacy API" / legacy`, wantValue: 0.5, wantReferences: []string{"legacy API", "legacy"}},
+		{expression: `"say \"hi\"" * 3`, wantValue: 6, wantReferences: []string{`say "hi"`}},
+		{expression: "migrated / (legacy - 10)", wantUndefined: true, wantReferences: []string{"migrated", "legacy"}},
+		{expression: "unknown + 1", wantUndefined: true, wantReferences: []string{"unknown"}},
+		{expression: "", wantErr: "expression is empty"},
+		{expression: "1 + 2", wantErr: "expression must reference at least one series"},
+		{expression: "migrated +", wantErr: "unexpected end of expression"},
+		{expression: "(migrated + legacy", wantErr: "unexpected end of expression"},
+		{expression: "migrated legacy", wantErr: `unexpected "legacy" at position 10`},
+		{expression: "migrated % legacy", wantErr: `unexpected "%" at position 10`},
+		{expression: "migrated * 1.2.3", wantErr: `invalid number "1.2.3" at position 12`},
+		{expression: `"legacy API / 2`, wantErr: "unterminated series label starting at position 1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			expr, err := Parse(tc.expression)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("expected error %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.wantReferences, expr.References()); diff != "" {
+				t.Errorf("unexpected references (-want +got):\n%s", diff)
+			}
+			value, ok := expr.Evaluate(values)
+			if ok == tc.wantUndefined {
+				t.Fatalf("unexpected defined result: want %v, got %v", !tc.wantUndefined, ok)
+			}
+			if ok && value != tc.wantValue {
+				t.Errorf("unexpected value: want %v, got %v", tc.wantValue, value)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Package ownership attributes code insights search results to the code owners of the files they
+// were found in.
+package ownership
+
+import (
+	"context"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners/v1"
+	ownsearch "github.com/sourcegraph/sourcegraph/enterprise/internal/own/search"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Attributor resolves the owners of files from the CODEOWNERS file of their repository, or from the
+// CODEOWNERS file ingested for it.
+type Attributor struct {
+	rules ownsearch.RulesCache
+}
+
+// NewAttributor returns an Attributor. Rulesets are cached for the lifetime of the Attributor, so
+// a new one should be created for each search that is attributed.
+func NewAttributor(gs gitserver.Client, db database.DB) *Attributor {
+	return &Attributor{rules: ownsearch.NewRulesCache(gs, db)}
+}
+
+// Owners returns the owners of the file at path in the given commit of a repository. Files that
+// are not matched by any rule have no owners.
+func (a *Attributor) Owners(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID, path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	ruleset, err := a.rules.GetFromCacheOrFetch(ctx, repoName, repoID, commitID)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetFromCacheOrFetch")
+	}
+	return OwnerNames(ruleset.Match(path).GetOwner()), nil
+}
+
+// OwnerNames returns the names results are attributed to for the given owners: the handle prefixed
+// with @, or the email for owners without a handle. These are the values accepted by the
+// file:has.owner search predicate.
+func OwnerNames(owners []*codeownerspb.Owner) []string {
+	names := make([]string, 0, len(owners))
+	seen := make(map[string]struct{}, len(owners))
+	for _, owner := range owners {
+		var name string
+		if handle := owner.GetHandle(); handle != "" {
+			name = "@" + handle
+		} else if email := owner.GetEmail(); email != "" {
+			name = email
+		} else {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,66 @@
+package ownership
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	codeownerspb "github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners/v1"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+func TestOwnerNames(t *testing.T) {
+	owners := []*codeownerspb.Owner{
+		{Handle: "sourcegraph/search"},
+		{Email: "alice@example.com"},
+		{Handle: "bob", Email: "bob@example.com"},
+		{},
+		{Handle: "sourcegraph/search"},
+	}
+	want := []string{"@sourcegraph/search", "alice@example.com", "@bob"}
+	if diff := cmp.Diff(want, OwnerNames(owners)); diff != "" {
+		t.Errorf("unexpected owner names (-want +got):\n%s", diff)
+	}
+}
+
+func TestAttributorOwners(t *testing.T) {
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, file string) ([]byte, error) {
+		if file != "CODEOWNERS" {
+			return nil, fs.ErrNotExist
+		}
+		return []byte("*.go @sourcegraph/backend\n/client/ @sourcegraph/frontend alice@example.com\n"), nil
+	})
+	codeownersStore := edb.NewMockCodeownersStore()
+	codeownersStore.GetCodeownersForRepoFunc.SetDefaultReturn(nil, nil)
+	db := edb.NewMockEnterpriseDB()
+	db.CodeownersFunc.SetDefaultReturn(codeownersStore)
+
+	attributor := NewAttributor(gitserverClient, db)
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "cmd/main.go", want: []string{"@sourcegraph/backend"}},
+		{path: "client/web/index.ts", want: []string{"@sourcegraph/frontend", "alice@example.com"}},
+		{path: "README.md", want: []string{}},
+		{path: "", want: nil},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			got, err := attributor.Owners(context.Background(), "github.com/sourcegraph/sourcegraph", 1, "abc", test.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("unexpected owners (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -266,7 +266,7 @@ func makeHistoricalSearchJobFunc(logger log.Logger, commitClient GitCommitClient
 			return
 		}
 		newQueryStr = modifiedQuery.String()
-		if bctx.series.GroupBy != nil {
+		if bctx.series.GroupBy != nil && querybuilder.MapType(*bctx.series.GroupBy).IsCompute() {
 			computeQuery, computeErr := querybuilder.ComputeInsightCommandQuery(modifiedQuery, querybuilder.MapType(*bctx.series.GroupBy))
 			if computeErr != nil {
 				err = errors.Append(err, errors.Wrap(err, "ComputeInsightCommandQuery"))
@@ -10,11 +10,13 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/compression"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/ownership"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/querybuilder"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/streaming"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/timeseries"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	gitserverclient "github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -171,3 +173,49 @@ func computeTabulationResultToGroupedResults(result *streaming.ComputeTabulation
 	}
 	return grouped
 }
+
+// NewOwnerExecutor returns an executor that previews series broken down by code owner. It samples the same
+// points in time as a capture group preview, but groups the results by the owners of the files they were
+// found in.
+func NewOwnerExecutor(postgres database.DB, clock func() time.Time) *CaptureGroupExecutor {
+	executor := NewCaptureGroupExecutor(postgres, clock)
+	attributor := ownership.NewAttributor(gitserverclient.NewClient(), postgres)
+	executor.computeSearch = func(ctx context.Context, query string) ([]GroupedResults, error) {
+		return streamOwners(ctx, query, attributor)
+	}
+	executor.logger = log.Scoped("OwnerExecutor", "")
+	return executor
+}
+
+func streamOwners(ctx context.Context, query string, attributor *ownership.Attributor) ([]GroupedResults, error) {
+	decoder, streamResults := streaming.FileTabulationDecoder()
+	err := streaming.Search(ctx, query, nil, decoder)
+	if err != nil {
+		return nil, err
+	}
+	if len(streamResults.Errors) > 0 {
+		return nil, errors.Errorf("streaming search: errors: %v", streamResults.Errors)
+	}
+	if len(streamResults.Alerts) > 0 {
+		return nil, errors.Errorf("streaming search: alerts: %v", streamResults.Alerts)
+	}
+
+	counts := make(map[string]int)
+	for _, file := range streamResults.FileCounts {
+		owners, err := attributor.Owners(ctx, api.RepoName(file.RepositoryName), api.RepoID(file.RepositoryID), api.CommitID(file.Commit), file.Path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving owners of %s in %s", file.Path, file.RepositoryName)
+		}
+		for _, owner := range owners {
+			counts[owner] += file.MatchCount
+		}
+	}
+	grouped := make([]GroupedResults, 0, len(counts))
+	for owner, count := range counts {
+		grouped = append(grouped, GroupedResults{
+			Value: owner,
+			Count: count,
+		})
+	}
+	return grouped, nil
+}
@@ -268,8 +268,16 @@ const (
 	Path   MapType = "path"
 	Author MapType = "author"
 	Date   MapType = "date"
+	// Owner groups results by the code owners of the files they were found in. It is not a compute map type: series
+	// grouped by owner run regular searches and the insights worker attributes each match to the owners of its file.
+	Owner MapType = "owner"
 )
 
+// IsCompute returns true if series grouped by this map type are recorded by running compute queries.
+func (m MapType) IsCompute() bool {
+	return m != Owner
+}
+
 // This is the compute command that corresponds to the execution for Code Insights.
 const insightsComputeCommand = "output.extra"
 
@@ -395,6 +403,27 @@ func AddFileFilter(query BasicQuery, file string) (BasicQuery, error) {
 	return addFilterSimple(query, searchquery.FieldFile, file)
 }
 
+// AddOwnerFilter restricts the query to the files owned by the given owner with the file:has.owner predicate.
+func AddOwnerFilter(query BasicQuery, owner string) (BasicQuery, error) {
+	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
+	if err != nil {
+		return "", err
+	}
+
+	mutatedQuery := searchquery.MapPlan(plan, func(basic searchquery.Basic) searchquery.Basic {
+		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+1)
+		modified = append(modified, basic.Parameters...)
+		modified = append(modified, searchquery.Parameter{
+			Field:      searchquery.FieldFile,
+			Value:      fmt.Sprintf("has.owner(%s)", owner),
+			Negated:    false,
+			Annotation: searchquery.Annotation{},
+		})
+		return basic.MapParameters(modified)
+	})
+	return BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), nil
+}
+
 func buildFilterText(raw string) string {
 	quoted := regexp.QuoteMeta(raw)
 	if strings.Contains(raw, " ") {
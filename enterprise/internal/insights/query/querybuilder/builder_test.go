@@ -625,6 +625,44 @@ func Test_addFileFilter(t *testing.T) {
 	}
 }
 
+func Test_addOwnerFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		owner string
+		want  autogold.Value
+	}{
+		{
+			name:  "owner handle",
+			input: "myquery repo:supergreat",
+			owner: "@sourcegraph/search",
+			want:  autogold.Expect(BasicQuery("repo:supergreat file:has.owner(@sourcegraph/search) myquery")),
+		},
+		{
+			name:  "owner email",
+			input: "myquery",
+			owner: "alice@example.com",
+			want:  autogold.Expect(BasicQuery("file:has.owner(alice@example.com) myquery")),
+		},
+		{
+			name:  "compound query adding owner",
+			input: "(myquery repo:supergreat) or (big repo:asdf)",
+			owner: "@alice",
+			want:  autogold.Expect(BasicQuery("(repo:supergreat file:has.owner(@alice) myquery OR repo:asdf file:has.owner(@alice) big)")),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := AddOwnerFilter(BasicQuery(test.input), test.owner)
+			if err != nil {
+				test.want.Equal(t, err.Error())
+			} else {
+				test.want.Equal(t, got)
+			}
+		})
+	}
+}
+
 func TestRepositoryScopeQuery(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -32,6 +32,21 @@ type TabulationResult struct {
 	TotalCount int
 }
 
+// FileMatch is the number of results found in a file.
+type FileMatch struct {
+	RepositoryID   int32
+	RepositoryName string
+	Commit         string
+	Path           string
+	MatchCount     int
+}
+
+type FileTabulationResult struct {
+	StreamDecoderEvents
+	FileCounts []*FileMatch
+	TotalCount int
+}
+
 type RepoResult struct {
 	StreamDecoderEvents
 	Repos []itypes.MinimalRepo
@@ -125,6 +140,65 @@ func TabulationDecoder() (streamhttp.FrontendStreamDecoder, *TabulationResult) {
 	}, tr
 }
 
+// FileTabulationDecoder will tabulate the result counts per file. Repository and commit matches are not
+// associated with a file and are skipped.
+func FileTabulationDecoder() (streamhttp.FrontendStreamDecoder, *FileTabulationResult) {
+	tr := &FileTabulationResult{}
+
+	type fileKey struct {
+		repo, commit, path string
+	}
+	files := make(map[fileKey]*FileMatch)
+	addCount := func(repo string, repoID int32, commit, path string, count int) {
+		tr.TotalCount += count
+		key := fileKey{repo: repo, commit: commit, path: path}
+		if forFile, ok := files[key]; ok {
+			forFile.MatchCount += count
+			return
+		}
+		forFile := &FileMatch{
+			RepositoryID:   repoID,
+			RepositoryName: repo,
+			Commit:         commit,
+			Path:           path,
+			MatchCount:     count,
+		}
+		files[key] = forFile
+		tr.FileCounts = append(tr.FileCounts, forFile)
+	}
+
+	return streamhttp.FrontendStreamDecoder{
+		OnProgress: tr.onProgress,
+		OnMatches: func(matches []streamhttp.EventMatch) {
+			for _, match := range matches {
+				switch match := match.(type) {
+				case *streamhttp.EventContentMatch:
+					count := 0
+					for _, chunkMatch := range match.ChunkMatches {
+						count += len(chunkMatch.Ranges)
+					}
+					addCount(match.Repository, match.RepositoryID, match.Commit, match.Path, count)
+				case *streamhttp.EventPathMatch:
+					addCount(match.Repository, match.RepositoryID, match.Commit, match.Path, 1)
+				case *streamhttp.EventSymbolMatch:
+					addCount(match.Repository, match.RepositoryID, match.Commit, match.Path, len(match.Symbols))
+				}
+			}
+		},
+		OnAlert: func(ea *streamhttp.EventAlert) {
+			if ea.Title == "No repositories found" {
+				// If we hit a case where we don't find a repository we don't want to error, just
+				// complete our search.
+			} else {
+				tr.Alerts = append(tr.Alerts, fmt.Sprintf("%s: %s", ea.Title, ea.Description))
+			}
+		},
+		OnError: func(eventError *streamhttp.EventError) {
+			tr.Errors = append(tr.Errors, eventError.Message)
+		},
+	}, tr
+}
+
 // ComputeMatch is our internal representation of a match retrieved from a Compute Streaming Search.
 // It is internally different from the `ComputeMatch` returned by the Compute GraphQL query but they
 // serve the same end goal.
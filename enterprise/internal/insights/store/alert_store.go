@@ -0,0 +1,251 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// AlertStore manages alert rules on insight series, and exposes the series values they are evaluated against.
+type AlertStore struct {
+	*basestore.Store
+	Now func() time.Time
+}
+
+// NewAlertStore returns a new AlertStore backed by the given Postgres db.
+func NewAlertStore(db edb.InsightsDB) *AlertStore {
+	return &AlertStore{Store: basestore.NewWithHandle(db.Handle()), Now: time.Now}
+}
+
+// With creates a new AlertStore with the given basestore.Shareable store as the underlying basestore.Store.
+// Needed to implement the basestore.Store interface
+func (s *AlertStore) With(other basestore.ShareableStore) *AlertStore {
+	return &AlertStore{Store: s.Store.With(other), Now: s.Now}
+}
+
+func (s *AlertStore) Transact(ctx context.Context) (*AlertStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &AlertStore{Store: txBase, Now: s.Now}, err
+}
+
+// CreateAlert inserts a new alert rule and returns it.
+func (s *AlertStore) CreateAlert(ctx context.Context, alert types.InsightSeriesAlert) (types.InsightSeriesAlert, error) {
+	q := sqlf.Sprintf(
+		createAlertSql,
+		alert.SeriesID,
+		alert.UserID,
+		alert.Kind,
+		alert.Threshold,
+		alert.WindowDays,
+		alert.Email,
+		alert.SlackWebhookURL,
+		alert.OutboundWebhook,
+		s.Now(),
+		sqlf.Join(alertColumns, ", "),
+	)
+	alerts, err := scanAlerts(s.Query(ctx, q))
+	if err != nil {
+		return types.InsightSeriesAlert{}, errors.Wrap(err, "CreateAlert")
+	}
+	if len(alerts) == 0 {
+		return types.InsightSeriesAlert{}, errors.New("CreateAlert: no alert returned")
+	}
+	return alerts[0], nil
+}
+
+const createAlertSql = `
+INSERT INTO insight_series_alerts (series_id, user_id, kind, threshold, window_days, email, slack_webhook_url, outbound_webhook, created_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+// ListAlertsArgs contains query predicates for fetching alert rules. Any provided values will be included as
+// query arguments.
+type ListAlertsArgs struct {
+	ID        int
+	SeriesIDs []int
+	UserID    int
+}
+
+// ListAlerts returns all alert rules matching the given arguments.
+func (s *AlertStore) ListAlerts(ctx context.Context, args ListAlertsArgs) ([]types.InsightSeriesAlert, error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if args.ID > 0 {
+		preds = append(preds, sqlf.Sprintf("id = %s", args.ID))
+	}
+	if len(args.SeriesIDs) > 0 {
+		elems := make([]*sqlf.Query, 0, len(args.SeriesIDs))
+		for _, id := range args.SeriesIDs {
+			elems = append(elems, sqlf.Sprintf("%s", id))
+		}
+		preds = append(preds, sqlf.Sprintf("series_id IN (%s)", sqlf.Join(elems, ",")))
+	}
+	if args.UserID > 0 {
+		preds = append(preds, sqlf.Sprintf("user_id = %s", args.UserID))
+	}
+	q := sqlf.Sprintf(listAlertsSql, sqlf.Join(alertColumns, ", "), sqlf.Join(preds, "AND"))
+	return scanAlerts(s.Query(ctx, q))
+}
+
+const listAlertsSql = `
+SELECT %s FROM insight_series_alerts
+WHERE %s
+ORDER BY id
+`
+
+// DeleteAlert removes the alert rule with the given id.
+func (s *AlertStore) DeleteAlert(ctx context.Context, id int) error {
+	if err := s.Exec(ctx, sqlf.Sprintf(deleteAlertSql, id)); err != nil {
+		return errors.Wrapf(err, "failed to delete alert with id: %d", id)
+	}
+	return nil
+}
+
+const deleteAlertSql = `
+DELETE FROM insight_series_alerts WHERE id = %s
+`
+
+// SetAlertFiring records the result of evaluating an alert. firedAt should only be set when a notification was
+// sent for the alert.
+func (s *AlertStore) SetAlertFiring(ctx context.Context, id int, firing bool, firedAt *time.Time) error {
+	return s.Exec(ctx, sqlf.Sprintf(setAlertFiringSql, firing, firedAt, id))
+}
+
+const setAlertFiringSql = `
+UPDATE insight_series_alerts
+SET firing = %s, last_fired_at = COALESCE(%s, last_fired_at)
+WHERE id = %s
+`
+
+// SnapshotValues returns the value of the current snapshot of a series, keyed by repository name. Points of the
+// excluded repositories are left out. The returned map is empty if the series has no snapshot.
+func (s *AlertStore) SnapshotValues(ctx context.Context, seriesID string, excludedRepoIDs []api.RepoID) (map[string]float64, error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("sp.series_id = %s", seriesID)}
+	if len(excludedRepoIDs) > 0 {
+		preds = append(preds, excludedReposPredicate("sp.repo_id", excludedRepoIDs))
+	}
+
+	values := make(map[string]float64)
+	err := s.query(ctx, sqlf.Sprintf(snapshotValuesSql, sqlf.Join(preds, "AND")), func(sc scanner) error {
+		var repoName string
+		var value float64
+		if err := sc.Scan(&repoName, &value); err != nil {
+			return err
+		}
+		values[repoName] = value
+		return nil
+	})
+	return values, err
+}
+
+const snapshotValuesSql = `
+SELECT COALESCE(rn.name, ''), SUM(sp.value)
+FROM series_points_snapshots sp
+LEFT JOIN repo_names rn ON sp.repo_name_id = rn.id
+WHERE %s
+GROUP BY rn.name
+`
+
+// TotalValueAt returns the total value of the most recent recording of a series at or before the given time.
+// Points of the excluded repositories are left out of the total. The returned boolean is false if the series has
+// no such recording.
+func (s *AlertStore) TotalValueAt(ctx context.Context, seriesID string, at time.Time, excludedRepoIDs []api.RepoID) (float64, bool, error) {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("series_id = %s", seriesID),
+		sqlf.Sprintf("time = (SELECT MAX(time) FROM series_points WHERE series_id = %s AND time <= %s)", seriesID, at.UTC()),
+	}
+	if len(excludedRepoIDs) > 0 {
+		preds = append(preds, excludedReposPredicate("repo_id", excludedRepoIDs))
+	}
+
+	var recorded bool
+	var total *float64
+	err := s.query(ctx, sqlf.Sprintf(totalValueAtSql, seriesID, at.UTC(), sqlf.Join(preds, "AND")), func(sc scanner) error {
+		return sc.Scan(&recorded, &total)
+	})
+	if err != nil || !recorded {
+		return 0, false, err
+	}
+	if total == nil {
+		// Every point of the recording belongs to an excluded repository.
+		return 0, true, nil
+	}
+	return *total, true, nil
+}
+
+const totalValueAtSql = `
+SELECT
+	EXISTS (SELECT 1 FROM series_points WHERE series_id = %s AND time <= %s),
+	(SELECT SUM(value) FROM series_points WHERE %s)
+`
+
+// excludedReposPredicate returns a predicate that leaves out the rows of the given repositories. The column may
+// be null for points that aren't associated with a repository, which are kept.
+func excludedReposPredicate(column string, repoIDs []api.RepoID) *sqlf.Query {
+	excluded := make([]*sqlf.Query, 0, len(repoIDs))
+	for _, repoID := range repoIDs {
+		excluded = append(excluded, sqlf.Sprintf("%d", repoID))
+	}
+	return sqlf.Sprintf("("+column+" IS NULL OR "+column+" NOT IN (%s))", sqlf.Join(excluded, ","))
+}
+
+func (s *AlertStore) query(ctx context.Context, q *sqlf.Query, sc scanFunc) error {
+	rows, err := s.Store.Query(ctx, q)
+	if err != nil {
+		return err
+	}
+	return scanAll(rows, sc)
+}
+
+var alertColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("series_id"),
+	sqlf.Sprintf("user_id"),
+	sqlf.Sprintf("kind"),
+	sqlf.Sprintf("threshold"),
+	sqlf.Sprintf("window_days"),
+	sqlf.Sprintf("email"),
+	sqlf.Sprintf("slack_webhook_url"),
+	sqlf.Sprintf("outbound_webhook"),
+	sqlf.Sprintf("firing"),
+	sqlf.Sprintf("last_fired_at"),
+	sqlf.Sprintf("created_at"),
+}
+
+func scanAlerts(rows *sql.Rows, queryErr error) (_ []types.InsightSeriesAlert, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var results []types.InsightSeriesAlert
+	for rows.Next() {
+		var temp types.InsightSeriesAlert
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.SeriesID,
+			&temp.UserID,
+			&temp.Kind,
+			&temp.Threshold,
+			&temp.WindowDays,
+			&temp.Email,
+			&temp.SlackWebhookURL,
+			&temp.OutboundWebhook,
+			&temp.Firing,
+			&temp.LastFiredAt,
+			&temp.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, temp)
+	}
+	return results, nil
+}
@@ -0,0 +1,194 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestAlertStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Microsecond).UTC()
+
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	insightStore := NewInsightStore(insightsDB)
+	seriesStore := New(insightsDB, NewInsightPermissionStore(postgres))
+	alertStore := NewAlertStore(insightsDB)
+	alertStore.Now = func() time.Time { return now }
+
+	series, err := insightStore.CreateSeries(ctx, types.InsightSeries{
+		SeriesID:           "series1",
+		Query:              "query1",
+		CreatedAt:          now,
+		OldestHistoricalAt: now,
+		LastRecordedAt:     now,
+		NextRecordingAfter: now,
+		LastSnapshotAt:     now,
+		NextSnapshotAfter:  now,
+		BackfillQueuedAt:   now,
+		SampleIntervalUnit: string(types.Month),
+		GenerationMethod:   types.Search,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	webhookURL := "https://hooks.slack.com/services/abc"
+	alert, err := alertStore.CreateAlert(ctx, types.InsightSeriesAlert{
+		SeriesID:        series.ID,
+		UserID:          1,
+		Kind:            types.ThresholdAlert,
+		Threshold:       10,
+		Email:           true,
+		SlackWebhookURL: &webhookURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := types.InsightSeriesAlert{
+		ID:              alert.ID,
+		SeriesID:        series.ID,
+		UserID:          1,
+		Kind:            types.ThresholdAlert,
+		Threshold:       10,
+		Email:           true,
+		SlackWebhookURL: &webhookURL,
+		CreatedAt:       now,
+	}
+	if diff := cmp.Diff(want, alert); diff != "" {
+		t.Fatalf("unexpected alert (-want +got):\n%s", diff)
+	}
+
+	t.Run("list", func(t *testing.T) {
+		for _, args := range []ListAlertsArgs{
+			{ID: alert.ID},
+			{SeriesIDs: []int{series.ID}},
+			{UserID: 1},
+		} {
+			got, err := alertStore.ListAlerts(ctx, args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff([]types.InsightSeriesAlert{want}, got); diff != "" {
+				t.Errorf("unexpected alerts for %+v (-want +got):\n%s", args, diff)
+			}
+		}
+
+		got, err := alertStore.ListAlerts(ctx, ListAlertsArgs{UserID: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 0 {
+			t.Errorf("expected no alerts for another user, got %d", len(got))
+		}
+	})
+
+	t.Run("set firing", func(t *testing.T) {
+		if err := alertStore.SetAlertFiring(ctx, alert.ID, true, &now); err != nil {
+			t.Fatal(err)
+		}
+		// Clearing the firing state keeps the time the alert last fired.
+		if err := alertStore.SetAlertFiring(ctx, alert.ID, false, nil); err != nil {
+			t.Fatal(err)
+		}
+		got, err := alertStore.ListAlerts(ctx, ListAlertsArgs{ID: alert.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got[0].Firing {
+			t.Error("expected alert not to be firing")
+		}
+		if got[0].LastFiredAt == nil || !got[0].LastFiredAt.Equal(now) {
+			t.Errorf("unexpected last fired at: %v", got[0].LastFiredAt)
+		}
+	})
+
+	t.Run("series values", func(t *testing.T) {
+		repo := func(name string, id api.RepoID) (*string, *api.RepoID) { return &name, &id }
+		repoA, repoAID := repo("github.com/a/a", 1)
+		repoB, repoBID := repo("github.com/b/b", 2)
+		lastMonth := now.AddDate(0, -1, 0)
+
+		if err := seriesStore.RecordSeriesPoints(ctx, []RecordSeriesPointArgs{
+			{SeriesID: "series1", Point: SeriesPoint{Time: lastMonth, Value: 3}, RepoName: repoA, RepoID: repoAID, PersistMode: RecordMode},
+			{SeriesID: "series1", Point: SeriesPoint{Time: lastMonth, Value: 4}, RepoName: repoB, RepoID: repoBID, PersistMode: RecordMode},
+			{SeriesID: "series1", Point: SeriesPoint{Time: now, Value: 5}, RepoName: repoA, RepoID: repoAID, PersistMode: SnapshotMode},
+			{SeriesID: "series1", Point: SeriesPoint{Time: now, Value: 6}, RepoName: repoB, RepoID: repoBID, PersistMode: SnapshotMode},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		values, err := alertStore.SnapshotValues(ctx, "series1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(map[string]float64{"github.com/a/a": 5, "github.com/b/b": 6}, values); diff != "" {
+			t.Errorf("unexpected snapshot values (-want +got):\n%s", diff)
+		}
+
+		values, err = alertStore.SnapshotValues(ctx, "series1", []api.RepoID{*repoBID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(map[string]float64{"github.com/a/a": 5}, values); diff != "" {
+			t.Errorf("unexpected snapshot values excluding %s (-want +got):\n%s", *repoB, diff)
+		}
+
+		total, ok, err := alertStore.TotalValueAt(ctx, "series1", now, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok || total != 7 {
+			t.Errorf("unexpected total value: %v, %v", total, ok)
+		}
+
+		total, ok, err = alertStore.TotalValueAt(ctx, "series1", now, []api.RepoID{*repoBID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok || total != 3 {
+			t.Errorf("unexpected total value excluding %s: %v, %v", *repoB, total, ok)
+		}
+
+		total, ok, err = alertStore.TotalValueAt(ctx, "series1", now, []api.RepoID{*repoAID, *repoBID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok || total != 0 {
+			t.Errorf("unexpected total value excluding every repository: %v, %v", total, ok)
+		}
+
+		if _, ok, err := alertStore.TotalValueAt(ctx, "series1", lastMonth.Add(-time.Hour), nil); err != nil {
+			t.Fatal(err)
+		} else if ok {
+			t.Error("expected no value before the first recording")
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := alertStore.DeleteAlert(ctx, alert.ID); err != nil {
+			t.Fatal(err)
+		}
+		got, err := alertStore.ListAlerts(ctx, ListAlertsArgs{ID: alert.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 0 {
+			t.Errorf("expected alert to be deleted, got %d", len(got))
+		}
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/timeseries"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -633,6 +634,63 @@ func (s *InsightStore) UpdateViewSeries(ctx context.Context, seriesId string, vi
 	return s.Exec(ctx, sqlf.Sprintf(updateInsightViewSeries, metadata.Label, metadata.Stroke, seriesId, viewId))
 }
 
+// GetDerivedSeries returns the derived series of the given insight view in the order they were created.
+func (s *InsightStore) GetDerivedSeries(ctx context.Context, viewID int) ([]types.DerivedInsightSeries, error) {
+	return scanDerivedSeries(s.Query(ctx, sqlf.Sprintf(getDerivedSeriesSql, viewID)))
+}
+
+// ReplaceDerivedSeries replaces all derived series of the given insight view with the given set. Derived
+// series that are not provided are removed.
+func (s *InsightStore) ReplaceDerivedSeries(ctx context.Context, viewID int, series []types.DerivedInsightSeries) (err error) {
+	if viewID == 0 {
+		return errors.New("unable to set derived series invalid insight view id")
+	}
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(deleteDerivedSeriesSql, viewID)); err != nil {
+		return errors.Wrap(err, "deleting derived series")
+	}
+	for _, derived := range series {
+		if err := tx.Exec(ctx, sqlf.Sprintf(createDerivedSeriesSql, viewID, derived.SeriesID, derived.Label, derived.Stroke, derived.Expression)); err != nil {
+			return errors.Wrap(err, "creating derived series")
+		}
+	}
+	return nil
+}
+
+var scanDerivedSeries = basestore.NewSliceScanner(func(s dbutil.Scanner) (types.DerivedInsightSeries, error) {
+	var derived types.DerivedInsightSeries
+	err := s.Scan(
+		&derived.ID,
+		&derived.InsightViewID,
+		&derived.SeriesID,
+		&derived.Label,
+		&derived.Stroke,
+		&derived.Expression,
+	)
+	return derived, err
+})
+
+const getDerivedSeriesSql = `
+SELECT id, insight_view_id, series_id, label, stroke, expression
+FROM insight_view_derived_series
+WHERE insight_view_id = %s
+ORDER BY id
+`
+
+const deleteDerivedSeriesSql = `
+DELETE FROM insight_view_derived_series WHERE insight_view_id = %s
+`
+
+const createDerivedSeriesSql = `
+INSERT INTO insight_view_derived_series (insight_view_id, series_id, label, stroke, expression)
+VALUES (%s, %s, %s, %s, %s)
+`
+
 func (s *InsightStore) AddViewGrants(ctx context.Context, view types.InsightView, grants []InsightViewGrant) error {
 	if view.ID == 0 {
 		return errors.New("unable to grant view permissions invalid insight view id")
@@ -1545,6 +1545,67 @@ func TestUpdateViewSeries(t *testing.T) {
 	})
 }
 
+func TestReplaceDerivedSeries(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	ctx := context.Background()
+
+	store := NewInsightStore(insightsDB)
+
+	view, err := store.CreateView(ctx, types.InsightView{
+		Title:            "my view",
+		UniqueID:         "1234567",
+		PresentationType: types.Line,
+	}, []InsightViewGrant{GlobalGrant()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.ReplaceDerivedSeries(ctx, view.ID, []types.DerivedInsightSeries{
+		{SeriesID: "derived-1", Label: "progress", Stroke: "blue", Expression: "migrated / (migrated + legacy) * 100"},
+		{SeriesID: "derived-2", Label: "total", Expression: "migrated + legacy"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.GetDerivedSeries(ctx, view.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 derived series, got %d", len(got))
+	}
+	autogold.Expect("progress").Equal(t, got[0].Label)
+	autogold.Expect("migrated / (migrated + legacy) * 100").Equal(t, got[0].Expression)
+	autogold.Expect("").Equal(t, got[1].Stroke)
+
+	err = store.ReplaceDerivedSeries(ctx, view.ID, []types.DerivedInsightSeries{
+		{SeriesID: "derived-2", Label: "sum", Expression: "migrated + legacy"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = store.GetDerivedSeries(ctx, view.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 derived series, got %d", len(got))
+	}
+	autogold.Expect("sum").Equal(t, got[0].Label)
+
+	if err := store.DeleteViewByUniqueID(ctx, view.UniqueID); err != nil {
+		t.Fatal(err)
+	}
+	got, err = store.GetDerivedSeries(ctx, view.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected derived series to be deleted with the view, got %d", len(got))
+	}
+}
+
 func TestDeleteView(t *testing.T) {
 	logger := logtest.Scoped(t)
 	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
@@ -882,6 +882,7 @@ const (
 // It should only be used for code insight data exporting.
 type SeriesPointForExport struct {
 	InsightViewTitle string
+	SeriesID         string
 	SeriesLabel      string
 	SeriesQuery      string
 	RecordingTime    time.Time
@@ -894,15 +895,32 @@ type ExportOpts struct {
 	InsightViewUniqueID string
 	IncludeRepoRegex    []string
 	ExcludeRepoRegex    []string
+	// OrderBySeries orders points by series, repository and capture before recording time, so that the
+	// points of each of them are contiguous. By default points are ordered by recording time.
+	OrderBySeries bool
 }
 
-func (s *Store) GetAllDataForInsightViewID(ctx context.Context, opts ExportOpts) (_ []SeriesPointForExport, err error) {
+func (s *Store) GetAllDataForInsightViewID(ctx context.Context, opts ExportOpts) ([]SeriesPointForExport, error) {
+	var results []SeriesPointForExport
+	err := s.StreamDataForInsightViewID(ctx, opts, func(point SeriesPointForExport) error {
+		results = append(results, point)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// StreamDataForInsightViewID calls onPoint for each exported point of the insight view, archived points
+// included, without holding all of them in memory.
+func (s *Store) StreamDataForInsightViewID(ctx context.Context, opts ExportOpts, onPoint func(SeriesPointForExport) error) error {
 	// 🚨 SECURITY: this function will only be called if the insight with the given insightViewId is visible given
 	// this user context. This is similar to how `SeriesPoints` works.
 	// We enforce repo permissions here as we store repository data at this level.
 	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
 	if err != nil {
-		return nil, errors.Wrap(err, "GetUnauthorizedRepoIDs")
+		return errors.Wrap(err, "GetUnauthorizedRepoIDs")
 	}
 	excludedRepoIDs := make([]*sqlf.Query, 0)
 	for _, repoID := range denylist {
@@ -937,17 +955,11 @@ func (s *Store) GetAllDataForInsightViewID(ctx context.Context, opts ExportOpts)
 		preds = append(preds, sqlf.Sprintf("true"))
 	}
 
-	tx, err := s.Transact(ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer func() { err = tx.Done(err) }()
-
-	var results []SeriesPointForExport
 	exportScanner := func(sc scanner) error {
 		var tmp SeriesPointForExport
-		if err = sc.Scan(
+		if err := sc.Scan(
 			&tmp.InsightViewTitle,
+			&tmp.SeriesID,
 			&tmp.SeriesLabel,
 			&tmp.SeriesQuery,
 			&tmp.RecordingTime,
@@ -961,26 +973,38 @@ func (s *Store) GetAllDataForInsightViewID(ctx context.Context, opts ExportOpts)
 		if tmp.Capture != nil {
 			tmp.SeriesLabel = *tmp.Capture
 		}
-		results = append(results, tmp)
-		return nil
+		return onPoint(tmp)
 	}
 
 	formattedPreds := sqlf.Join(preds, "AND")
-	// start with the oldest archived points and add them to the results
-	if err := tx.query(ctx, sqlf.Sprintf(exportCodeInsightsDataSql, quote(recordingTimesTableArchive), quote(recordingTableArchive), opts.InsightViewUniqueID, formattedPreds), exportScanner); err != nil {
-		return nil, errors.Wrap(err, "fetching archived code insights data")
-	}
-	// then add live points
-	// we join both series points tables
-	if err := tx.query(ctx, sqlf.Sprintf(exportCodeInsightsDataSql, quote(recordingTimesTable), quote("(select * from series_points union all select * from series_points_snapshots)"), opts.InsightViewUniqueID, formattedPreds), exportScanner); err != nil {
-		return nil, errors.Wrap(err, "fetching code insights data")
+	orderBy := sqlf.Sprintf("title, recording_time, label, capture")
+	if opts.OrderBySeries {
+		orderBy = sqlf.Sprintf("series_id, repo_name, capture, recording_time")
+	}
+	// archived points are read together with the live points, which are stored in both series points tables
+	q := sqlf.Sprintf(
+		exportCodeInsightsDataSql,
+		sqlf.Sprintf(exportCodeInsightsPointsSql, quote(recordingTimesTableArchive), quote(recordingTableArchive), opts.InsightViewUniqueID, formattedPreds),
+		sqlf.Sprintf(exportCodeInsightsPointsSql, quote(recordingTimesTable), quote("(select * from series_points union all select * from series_points_snapshots)"), opts.InsightViewUniqueID, formattedPreds),
+		orderBy,
+	)
+	if err := s.query(ctx, q, exportScanner); err != nil {
+		return errors.Wrap(err, "fetching code insights data")
 	}
-
-	return results, nil
+	return nil
 }
 
 const exportCodeInsightsDataSql = `
-select iv.title, ivs.label, i.query, isrt.recording_time, rn.name, coalesce(sp.value, 0) as value, sp.capture 
+select title, series_id, label, query, recording_time, repo_name, value, capture from (
+	%s
+	union all
+	%s
+) points
+order by %s;
+`
+
+const exportCodeInsightsPointsSql = `
+select iv.title, i.series_id, ivs.label, i.query, isrt.recording_time, rn.name as repo_name, coalesce(sp.value, 0) as value, sp.capture
 from %s isrt
     join insight_series i on i.id = isrt.insight_series_id
     join insight_view_series ivs ON i.id = ivs.insight_series_id
@@ -988,5 +1012,4 @@ from %s isrt
     left outer join %s sp on sp.series_id = i.series_id and sp.time = isrt.recording_time
     left outer join repo_names rn on sp.repo_name_id = rn.id
 	where iv.unique_id = %s and %s
-    order by iv.title, isrt.recording_time, ivs.label, sp.capture;
 `
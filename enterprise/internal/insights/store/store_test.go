@@ -1159,6 +1159,23 @@ SELECT recording_time,
 			t.Errorf("expected 0 results due to filtering, got %d", len(got))
 		}
 	})
+	t.Run("streams points ordered by series", func(t *testing.T) {
+		var got []SeriesPointForExport
+		err := seriesStore.StreamDataForInsightViewID(ctx, ExportOpts{InsightViewUniqueID: view.UniqueID, OrderBySeries: true}, func(point SeriesPointForExport) error {
+			got = append(got, point)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(recordingTimes.RecordingTimes) {
+			t.Fatalf("expected %d got %d series points for export", len(recordingTimes.RecordingTimes), len(got))
+		}
+		for i, rt := range recordingTimes.RecordingTimes {
+			autogold.Expect("series1").Equal(t, got[i].SeriesID)
+			autogold.Expect(rt.Timestamp).Equal(t, got[i].RecordingTime.UTC())
+		}
+	})
 	t.Run("adds empty entry for no series points data", func(t *testing.T) {
 		// add new recording time
 		extraTime := newTime.Add(time.Hour).UTC()
@@ -59,6 +59,17 @@ type Insight struct {
 	SeriesOptions    SeriesDisplayOptions
 }
 
+// DerivedInsightSeries is a series of an insight view that is computed at read time by evaluating an
+// arithmetic expression over the other series of the same view.
+type DerivedInsightSeries struct {
+	ID            int
+	InsightViewID int
+	SeriesID      string
+	Label         string
+	Stroke        string
+	Expression    string
+}
+
 type InsightViewFilters struct {
 	IncludeRepoRegex *string
 	ExcludeRepoRegex *string
@@ -113,6 +124,37 @@ type InsightSeries struct {
 	RepositoryCriteria         *string
 }
 
+// InsightSeriesAlert is an alert rule that is evaluated against a data series each time a new snapshot of the
+// series is recorded.
+type InsightSeriesAlert struct {
+	ID              int
+	SeriesID        int // references insight_series(id)
+	UserID          int
+	Kind            AlertKind
+	Threshold       float64
+	WindowDays      int
+	Email           bool
+	SlackWebhookURL *string
+	OutboundWebhook bool
+	Firing          bool
+	LastFiredAt     *time.Time
+	CreatedAt       time.Time
+}
+
+// AlertKind describes the condition an InsightSeriesAlert watches for.
+type AlertKind string
+
+const (
+	// ThresholdAlert fires when the total value of a series rises above the threshold.
+	ThresholdAlert AlertKind = "THRESHOLD"
+	// PercentChangeAlert fires when the total value of a series changes by more than the threshold percentage
+	// compared to its value WindowDays ago.
+	PercentChangeAlert AlertKind = "PERCENT_CHANGE"
+	// NewRepositoryAlert fires when a repository that had no results in the previous snapshot has results
+	// in the current one.
+	NewRepositoryAlert AlertKind = "NEW_REPOSITORY"
+)
+
 type IntervalUnit string
 
 const (
@@ -131,6 +173,7 @@ const (
 	SearchCompute  GenerationMethod = "search-compute"
 	LanguageStats  GenerationMethod = "language-stats"
 	MappingCompute GenerationMethod = "mapping-compute"
+	MappingOwners  GenerationMethod = "mapping-owners"
 )
 
 type Dashboard struct {
@@ -213,9 +256,10 @@ const (
 	PATH_AGGREGATION_MODE          SearchAggregationMode = "PATH"
 	AUTHOR_AGGREGATION_MODE        SearchAggregationMode = "AUTHOR"
 	CAPTURE_GROUP_AGGREGATION_MODE SearchAggregationMode = "CAPTURE_GROUP"
+	OWNER_AGGREGATION_MODE         SearchAggregationMode = "OWNER"
 )
 
-var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE}
+var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE, OWNER_AGGREGATION_MODE}
 
 type AggregationNotAvailableReasonType string
 
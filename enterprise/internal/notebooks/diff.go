@@ -0,0 +1,123 @@
+package notebooks
+
+import "reflect"
+
+type NotebookBlockDiffKind string
+
+const (
+	NotebookBlockAdded     NotebookBlockDiffKind = "ADDED"
+	NotebookBlockRemoved   NotebookBlockDiffKind = "REMOVED"
+	NotebookBlockModified  NotebookBlockDiffKind = "MODIFIED"
+	NotebookBlockUnchanged NotebookBlockDiffKind = "UNCHANGED"
+)
+
+// NotebookBlockDiff describes the change of a single block between two revisions of a notebook.
+// From is nil for added blocks and To is nil for removed blocks.
+type NotebookBlockDiff struct {
+	BlockID string
+	Kind    NotebookBlockDiffKind
+	From    *NotebookBlock
+	To      *NotebookBlock
+	// Moved is true if the block is in both revisions, but its position relative to the other
+	// blocks in both revisions has changed.
+	Moved bool
+}
+
+// DiffNotebookBlocks returns a block-level diff between two lists of blocks. Blocks are matched by
+// their ID. The diff follows the order of the blocks in `to`, and removed blocks are placed after
+// the block that preceded them in `from`.
+func DiffNotebookBlocks(from, to NotebookBlocks) []NotebookBlockDiff {
+	fromByID := make(map[string]*NotebookBlock, len(from))
+	for i := range from {
+		fromByID[from[i].ID] = &from[i]
+	}
+	toByID := make(map[string]*NotebookBlock, len(to))
+	for i := range to {
+		toByID[to[i].ID] = &to[i]
+	}
+
+	// Blocks that are in both revisions, in the order of each revision.
+	var fromCommon, toCommon []string
+	for _, block := range from {
+		if _, ok := toByID[block.ID]; ok {
+			fromCommon = append(fromCommon, block.ID)
+		}
+	}
+	for _, block := range to {
+		if _, ok := fromByID[block.ID]; ok {
+			toCommon = append(toCommon, block.ID)
+		}
+	}
+	inPlace := longestCommonSubsequence(fromCommon, toCommon)
+
+	// Group the removed blocks by the closest preceding block of `from` that is kept.
+	removedAfter := map[string][]*NotebookBlock{}
+	previousKeptID := ""
+	for i := range from {
+		if _, ok := toByID[from[i].ID]; ok {
+			previousKeptID = from[i].ID
+			continue
+		}
+		removedAfter[previousKeptID] = append(removedAfter[previousKeptID], &from[i])
+	}
+
+	diff := make([]NotebookBlockDiff, 0, len(from)+len(to))
+	appendRemoved := func(afterID string) {
+		for _, block := range removedAfter[afterID] {
+			diff = append(diff, NotebookBlockDiff{BlockID: block.ID, Kind: NotebookBlockRemoved, From: block})
+		}
+	}
+
+	appendRemoved("")
+	for i := range to {
+		toBlock := &to[i]
+		fromBlock, ok := fromByID[toBlock.ID]
+		if !ok {
+			diff = append(diff, NotebookBlockDiff{BlockID: toBlock.ID, Kind: NotebookBlockAdded, To: toBlock})
+			continue
+		}
+
+		kind := NotebookBlockUnchanged
+		if !reflect.DeepEqual(*fromBlock, *toBlock) {
+			kind = NotebookBlockModified
+		}
+		_, notMoved := inPlace[toBlock.ID]
+		diff = append(diff, NotebookBlockDiff{BlockID: toBlock.ID, Kind: kind, From: fromBlock, To: toBlock, Moved: !notMoved})
+		appendRemoved(toBlock.ID)
+	}
+	return diff
+}
+
+// longestCommonSubsequence returns the set of IDs in a longest common subsequence of a and b.
+func longestCommonSubsequence(a, b []string) map[string]struct{} {
+	// lengths[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	common := make(map[string]struct{}, lengths[0][0])
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		if a[i] == b[j] {
+			common[a[i]] = struct{}{}
+			i++
+			j++
+		} else if lengths[i+1][j] >= lengths[i][j+1] {
+			i++
+		} else {
+			j++
+		}
+	}
+	return common
+}
@@ -0,0 +1,82 @@
+package notebooks
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffNotebookBlocks(t *testing.T) {
+	markdownBlock := func(id, text string) NotebookBlock {
+		return NotebookBlock{ID: id, Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: text}}
+	}
+
+	type blockDiff struct {
+		BlockID string
+		Kind    NotebookBlockDiffKind
+		Moved   bool
+	}
+
+	tests := []struct {
+		name string
+		from NotebookBlocks
+		to   NotebookBlocks
+		want []blockDiff
+	}{
+		{
+			name: "unchanged",
+			from: NotebookBlocks{markdownBlock("1", "a"), markdownBlock("2", "b")},
+			to:   NotebookBlocks{markdownBlock("1", "a"), markdownBlock("2", "b")},
+			want: []blockDiff{{"1", NotebookBlockUnchanged, false}, {"2", NotebookBlockUnchanged, false}},
+		},
+		{
+			name: "added, removed and modified",
+			from: NotebookBlocks{markdownBlock("1", "a"), markdownBlock("2", "b"), markdownBlock("3", "c")},
+			to:   NotebookBlocks{markdownBlock("1", "a"), markdownBlock("3", "d"), markdownBlock("4", "e")},
+			want: []blockDiff{
+				{"1", NotebookBlockUnchanged, false},
+				{"2", NotebookBlockRemoved, false},
+				{"3", NotebookBlockModified, false},
+				{"4", NotebookBlockAdded, false},
+			},
+		},
+		{
+			name: "removed first block",
+			from: NotebookBlocks{markdownBlock("1", "a"), markdownBlock("2", "b")},
+			to:   NotebookBlocks{markdownBlock("2", "b")},
+			want: []blockDiff{{"1", NotebookBlockRemoved, false}, {"2", NotebookBlockUnchanged, false}},
+		},
+		{
+			name: "moved block",
+			from: NotebookBlocks{markdownBlock("1", "a"), markdownBlock("2", "b"), markdownBlock("3", "c")},
+			to:   NotebookBlocks{markdownBlock("2", "b"), markdownBlock("3", "c"), markdownBlock("1", "z")},
+			want: []blockDiff{
+				{"2", NotebookBlockUnchanged, false},
+				{"3", NotebookBlockUnchanged, false},
+				{"1", NotebookBlockModified, true},
+			},
+		},
+		{
+			name: "block type changed",
+			from: NotebookBlocks{markdownBlock("1", "a")},
+			to:   NotebookBlocks{{ID: "1", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: "a"}}},
+			want: []blockDiff{{"1", NotebookBlockModified, false}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := DiffNotebookBlocks(tt.from, tt.to)
+			got := make([]blockDiff, 0, len(diff))
+			for _, d := range diff {
+				if (d.Kind == NotebookBlockAdded) != (d.From == nil) || (d.Kind == NotebookBlockRemoved) != (d.To == nil) {
+					t.Fatalf("unexpected blocks for %s block %s: from %v, to %v", d.Kind, d.BlockID, d.From, d.To)
+				}
+				got = append(got, blockDiff{d.BlockID, d.Kind, d.Moved})
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("wrong diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
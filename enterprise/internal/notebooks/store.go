@@ -20,6 +20,12 @@ import (
 
 var ErrNotebookNotFound = errors.New("notebook not found")
 var ErrNotebookStarNotFound = errors.New("notebook star not found")
+var ErrNotebookBlockSnapshotNotFound = errors.New("notebook block snapshot not found")
+var ErrNotebookRevisionNotFound = errors.New("notebook revision not found")
+
+// ErrNotebookVersionConflict is returned when a notebook update is based on a version of the
+// notebook that is no longer the current version.
+var ErrNotebookVersionConflict = errors.New("notebook was updated since it was last read")
 
 type NotebooksOrderByOption uint8
 
@@ -40,6 +46,11 @@ type ListNotebookStarsPageOptions struct {
 	After int64
 }
 
+type ListNotebookRevisionsPageOptions struct {
+	First int32
+	After int64
+}
+
 type ListNotebooksOptions struct {
 	Query             string
 	CreatorUserID     int32
@@ -81,6 +92,13 @@ type NotebooksStore interface {
 	DeleteNotebookStar(ctx context.Context, notebookID int64, userID int32) error
 	ListNotebookStars(ctx context.Context, pageOpts ListNotebookStarsPageOptions, notebookID int64) ([]*NotebookStar, error)
 	CountNotebookStars(ctx context.Context, notebookID int64) (int64, error)
+
+	GetNotebookBlockSnapshot(ctx context.Context, notebookID int64, blockID string) (*NotebookBlockSnapshot, error)
+	UpsertNotebookBlockSnapshot(ctx context.Context, snapshot *NotebookBlockSnapshot) (*NotebookBlockSnapshot, error)
+
+	GetNotebookRevision(ctx context.Context, notebookID int64, version int32) (*NotebookRevision, error)
+	ListNotebookRevisions(ctx context.Context, pageOpts ListNotebookRevisionsPageOptions, notebookID int64) ([]*NotebookRevision, error)
+	CountNotebookRevisions(ctx context.Context, notebookID int64) (int64, error)
 }
 
 type notebooksStore struct {
@@ -117,6 +135,7 @@ var notebookColumns = []*sqlf.Query{
 	sqlf.Sprintf("notebooks.namespace_org_id"),
 	sqlf.Sprintf("notebooks.created_at"),
 	sqlf.Sprintf("notebooks.updated_at"),
+	sqlf.Sprintf("notebooks.version"),
 }
 
 func notebooksPermissionsCondition(ctx context.Context) *sqlf.Query {
@@ -196,6 +215,7 @@ func scanNotebook(scanner dbutil.Scanner) (*Notebook, error) {
 		&dbutil.NullInt32{N: &n.NamespaceOrgID},
 		&n.CreatedAt,
 		&n.UpdatedAt,
+		&n.Version,
 	)
 	if err != nil {
 		return nil, err
@@ -337,12 +357,19 @@ INSERT INTO notebooks (title, blocks, public, creator_user_id, updater_user_id,
 RETURNING %s
 `
 
-func (s *notebooksStore) CreateNotebook(ctx context.Context, n *Notebook) (*Notebook, error) {
-	err := validateNotebookBlocks(n.Blocks)
+func (s *notebooksStore) CreateNotebook(ctx context.Context, n *Notebook) (_ *Notebook, err error) {
+	err = validateNotebookBlocks(n.Blocks)
 	if err != nil {
 		return nil, err
 	}
-	row := s.QueryRow(
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	row := tx.QueryRow(
 		ctx,
 		sqlf.Sprintf(
 			insertNotebookFmtStr,
@@ -356,7 +383,14 @@ func (s *notebooksStore) CreateNotebook(ctx context.Context, n *Notebook) (*Note
 			sqlf.Join(notebookColumns, ","),
 		),
 	)
-	return scanNotebook(row)
+	createdNotebook, err := scanNotebook(row)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.insertNotebookRevision(ctx, createdNotebook); err != nil {
+		return nil, err
+	}
+	return createdNotebook, nil
 }
 
 const deleteNotebookFmtStr = `DELETE FROM notebooks WHERE id = %d`
@@ -375,18 +409,32 @@ SET
 	updater_user_id = %d,
 	namespace_user_id = %d,
 	namespace_org_id = %d,
-	updated_at = now()
-WHERE id = %d
+	updated_at = now(),
+	version = version + 1
+WHERE id = %d AND version = %d
 RETURNING %s
 `
 
+const notebookExistsFmtStr = `SELECT EXISTS (SELECT 1 FROM notebooks WHERE id = %d)`
+
+// UpdateNotebook updates the notebook and records the updated title and blocks as a new revision.
+// The update is rejected with ErrNotebookVersionConflict if n.Version is not the current version
+// of the notebook.
+//
 // 🚨 SECURITY: The caller must ensure that the actor has permission to update the notebook.
-func (s *notebooksStore) UpdateNotebook(ctx context.Context, n *Notebook) (*Notebook, error) {
-	err := validateNotebookBlocks(n.Blocks)
+func (s *notebooksStore) UpdateNotebook(ctx context.Context, n *Notebook) (_ *Notebook, err error) {
+	err = validateNotebookBlocks(n.Blocks)
 	if err != nil {
 		return nil, err
 	}
-	row := s.QueryRow(
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	row := tx.QueryRow(
 		ctx,
 		sqlf.Sprintf(
 			updateNotebookFmtStr,
@@ -397,10 +445,27 @@ func (s *notebooksStore) UpdateNotebook(ctx context.Context, n *Notebook) (*Note
 			dbutil.NullInt32Column(n.NamespaceUserID),
 			dbutil.NullInt32Column(n.NamespaceOrgID),
 			n.ID,
+			n.Version,
 			sqlf.Join(notebookColumns, ","),
 		),
 	)
-	return scanNotebook(row)
+	updatedNotebook, err := scanNotebook(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		exists, _, err := basestore.ScanFirstBool(tx.Query(ctx, sqlf.Sprintf(notebookExistsFmtStr, n.ID)))
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, ErrNotebookNotFound
+		}
+		return nil, ErrNotebookVersionConflict
+	} else if err != nil {
+		return nil, err
+	}
+	if err := tx.insertNotebookRevision(ctx, updatedNotebook); err != nil {
+		return nil, err
+	}
+	return updatedNotebook, nil
 }
 
 func scanNotebookStar(scanner dbutil.Scanner) (*NotebookStar, error) {
@@ -479,3 +544,157 @@ func (s *notebooksStore) CountNotebookStars(ctx context.Context, notebookID int6
 	}
 	return count, nil
 }
+
+func scanNotebookBlockSnapshot(scanner dbutil.Scanner) (*NotebookBlockSnapshot, error) {
+	snapshot := &NotebookBlockSnapshot{}
+	err := scanner.Scan(
+		&snapshot.NotebookID,
+		&snapshot.BlockID,
+		&snapshot.InputHash,
+		&snapshot.Output,
+		&dbutil.NullInt32{N: &snapshot.CreatorUserID},
+		&snapshot.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+const getNotebookBlockSnapshotFmtStr = `
+SELECT notebook_id, block_id, input_hash, output, creator_user_id, created_at
+FROM notebook_block_snapshots
+WHERE notebook_id = %d AND block_id = %s
+`
+
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access the notebook.
+func (s *notebooksStore) GetNotebookBlockSnapshot(ctx context.Context, notebookID int64, blockID string) (*NotebookBlockSnapshot, error) {
+	row := s.QueryRow(ctx, sqlf.Sprintf(getNotebookBlockSnapshotFmtStr, notebookID, blockID))
+	snapshot, err := scanNotebookBlockSnapshot(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotebookBlockSnapshotNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+const upsertNotebookBlockSnapshotFmtStr = `
+INSERT INTO notebook_block_snapshots (notebook_id, block_id, input_hash, output, creator_user_id)
+VALUES (%d, %s, %s, %s, %s)
+ON CONFLICT (notebook_id, block_id) DO UPDATE SET
+	input_hash = EXCLUDED.input_hash,
+	output = EXCLUDED.output,
+	creator_user_id = EXCLUDED.creator_user_id,
+	created_at = NOW()
+RETURNING notebook_id, block_id, input_hash, output, creator_user_id, created_at
+`
+
+// 🚨 SECURITY: The caller must ensure that the actor has permission to update the notebook.
+func (s *notebooksStore) UpsertNotebookBlockSnapshot(ctx context.Context, snapshot *NotebookBlockSnapshot) (*NotebookBlockSnapshot, error) {
+	row := s.QueryRow(ctx, sqlf.Sprintf(
+		upsertNotebookBlockSnapshotFmtStr,
+		snapshot.NotebookID,
+		snapshot.BlockID,
+		snapshot.InputHash,
+		[]byte(snapshot.Output),
+		dbutil.NullInt32Column(snapshot.CreatorUserID),
+	))
+	return scanNotebookBlockSnapshot(row)
+}
+
+const notebookRevisionColumnsFmtStr = `notebook_id, version, title, blocks, author_user_id, created_at`
+
+func scanNotebookRevision(scanner dbutil.Scanner) (*NotebookRevision, error) {
+	revision := &NotebookRevision{}
+	err := scanner.Scan(
+		&revision.NotebookID,
+		&revision.Version,
+		&revision.Title,
+		&revision.Blocks,
+		&dbutil.NullInt32{N: &revision.AuthorUserID},
+		&revision.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+const insertNotebookRevisionFmtStr = `
+INSERT INTO notebook_revisions (notebook_id, version, title, blocks, author_user_id, created_at)
+VALUES (%d, %d, %s, %s, %s, %s)
+`
+
+// insertNotebookRevision records the current title and blocks of the notebook as the revision of
+// its current version. The author of the revision is the last updater of the notebook.
+func (s *notebooksStore) insertNotebookRevision(ctx context.Context, n *Notebook) error {
+	return s.Exec(ctx, sqlf.Sprintf(
+		insertNotebookRevisionFmtStr,
+		n.ID,
+		n.Version,
+		n.Title,
+		n.Blocks,
+		dbutil.NullInt32Column(n.UpdaterUserID),
+		n.UpdatedAt,
+	))
+}
+
+const getNotebookRevisionFmtStr = `
+SELECT ` + notebookRevisionColumnsFmtStr + `
+FROM notebook_revisions
+WHERE notebook_id = %d AND version = %d
+`
+
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access the notebook.
+func (s *notebooksStore) GetNotebookRevision(ctx context.Context, notebookID int64, version int32) (*NotebookRevision, error) {
+	row := s.QueryRow(ctx, sqlf.Sprintf(getNotebookRevisionFmtStr, notebookID, version))
+	revision, err := scanNotebookRevision(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotebookRevisionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+const listNotebookRevisionsFmtStr = `
+SELECT ` + notebookRevisionColumnsFmtStr + `
+FROM notebook_revisions
+WHERE notebook_id = %d
+ORDER BY version DESC
+LIMIT %d
+OFFSET %d
+`
+
+// ListNotebookRevisions returns the revisions of the notebook, newest first.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access the notebook.
+func (s *notebooksStore) ListNotebookRevisions(ctx context.Context, pageOpts ListNotebookRevisionsPageOptions, notebookID int64) ([]*NotebookRevision, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listNotebookRevisionsFmtStr, notebookID, pageOpts.First, pageOpts.After))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var revisions []*NotebookRevision
+	for rows.Next() {
+		revision, err := scanNotebookRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+const countNotebookRevisionsFmtStr = `SELECT COUNT(*) FROM notebook_revisions WHERE notebook_id = %d`
+
+// 🚨 SECURITY: The caller must ensure that the actor has permission to access the notebook.
+func (s *notebooksStore) CountNotebookRevisions(ctx context.Context, notebookID int64) (int64, error) {
+	var count int64
+	err := s.QueryRow(ctx, sqlf.Sprintf(countNotebookRevisionsFmtStr, notebookID)).Scan(&count)
+	if err != nil {
+		return -1, err
+	}
+	return count, nil
+}
@@ -2,6 +2,7 @@ package notebooks
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -109,6 +110,7 @@ func TestUpdateNotebook(t *testing.T) {
 
 	// Ignore updatedAt change
 	wantUpdatedNotebook.UpdatedAt = gotUpdatedNotebook.UpdatedAt
+	wantUpdatedNotebook.Version = 2
 
 	if !reflect.DeepEqual(wantUpdatedNotebook, gotUpdatedNotebook) {
 		t.Fatalf("wanted %+v updated notebook, got %+v", wantUpdatedNotebook, gotUpdatedNotebook)
@@ -679,3 +681,156 @@ func TestCreatingAndDeletingNotebookStars(t *testing.T) {
 		t.Errorf("expected non-nil error, got nil")
 	}
 }
+
+func TestUpsertingNotebookBlockSnapshots(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	internalCtx := actor.WithInternalActor(context.Background())
+	u := db.Users()
+	n := Notebooks(db)
+
+	user, err := u.Create(internalCtx, database.NewUser{Username: "u1", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	blocks := NotebookBlocks{
+		{ID: "1", Type: NotebookComputeBlockType, ComputeInput: &NotebookComputeBlockInput{Expression: "content:output(a -> b)"}},
+	}
+	createdNotebooks, err := createNotebooks(internalCtx, n, []*Notebook{
+		notebookByUser(&Notebook{Title: "Notebook", Blocks: blocks, Public: true}, user.ID),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	notebook := createdNotebooks[0]
+
+	_, err = n.GetNotebookBlockSnapshot(internalCtx, notebook.ID, "1")
+	if !errors.Is(err, ErrNotebookBlockSnapshotNotFound) {
+		t.Fatalf("expected snapshot not found error, got %+v", err)
+	}
+
+	for _, output := range []string{`{"results":[]}`, `{"results":[{"value":"b"}]}`} {
+		_, err = n.UpsertNotebookBlockSnapshot(internalCtx, &NotebookBlockSnapshot{
+			NotebookID:    notebook.ID,
+			BlockID:       "1",
+			InputHash:     "hash",
+			Output:        []byte(output),
+			CreatorUserID: user.ID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshot, err := n.GetNotebookBlockSnapshot(internalCtx, notebook.ID, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.InputHash != "hash" || string(snapshot.Output) != `{"results": [{"value": "b"}]}` || snapshot.CreatorUserID != user.ID {
+		t.Fatalf("unexpected snapshot %+v", snapshot)
+	}
+
+	// Snapshots are deleted with their notebook.
+	if err := n.DeleteNotebook(internalCtx, notebook.ID); err != nil {
+		t.Fatal(err)
+	}
+	_, err = n.GetNotebookBlockSnapshot(internalCtx, notebook.ID, "1")
+	if !errors.Is(err, ErrNotebookBlockSnapshotNotFound) {
+		t.Fatalf("expected snapshot not found error, got %+v", err)
+	}
+}
+
+func TestNotebookRevisions(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	internalCtx := actor.WithInternalActor(context.Background())
+	u := db.Users()
+	n := Notebooks(db)
+
+	user1, err := u.Create(internalCtx, database.NewUser{Username: "u1", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	user2, err := u.Create(internalCtx, database.NewUser{Username: "u2", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	blocks := NotebookBlocks{{ID: "1", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{"# Title"}}}
+	createdNotebooks, err := createNotebooks(internalCtx, n, []*Notebook{
+		notebookByUser(&Notebook{Title: "Notebook", Blocks: blocks, Public: true}, user1.ID),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	notebook := createdNotebooks[0]
+	if notebook.Version != 1 {
+		t.Fatalf("expected created notebook to have version 1, got %d", notebook.Version)
+	}
+
+	// Both users start editing the first version of the notebook.
+	user1Edit := *notebook
+	user1Edit.Title = "Notebook by u1"
+	user2Edit := *notebook
+	user2Edit.Title = "Notebook by u2"
+	user2Edit.UpdaterUserID = user2.ID
+
+	updatedNotebook, err := n.UpdateNotebook(internalCtx, &user2Edit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updatedNotebook.Version != 2 {
+		t.Fatalf("expected updated notebook to have version 2, got %d", updatedNotebook.Version)
+	}
+
+	// The edit of the first user is based on a stale version.
+	_, err = n.UpdateNotebook(internalCtx, &user1Edit)
+	if !errors.Is(err, ErrNotebookVersionConflict) {
+		t.Fatalf("expected version conflict error, got %+v", err)
+	}
+
+	_, err = n.UpdateNotebook(internalCtx, &Notebook{ID: notebook.ID + 100, Version: 1, Blocks: NotebookBlocks{}})
+	if !errors.Is(err, ErrNotebookNotFound) {
+		t.Fatalf("expected notebook not found error, got %+v", err)
+	}
+
+	count, err := n.CountNotebookRevisions(internalCtx, notebook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 revisions, got %d", count)
+	}
+
+	revisions, err := n.ListNotebookRevisions(internalCtx, ListNotebookRevisionsPageOptions{First: 10}, notebook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotRevisions := make([]string, 0, len(revisions))
+	for _, revision := range revisions {
+		gotRevisions = append(gotRevisions, fmt.Sprintf("%d:%s:%d", revision.Version, revision.Title, revision.AuthorUserID))
+	}
+	wantRevisions := []string{
+		fmt.Sprintf("2:Notebook by u2:%d", user2.ID),
+		fmt.Sprintf("1:Notebook:%d", user1.ID),
+	}
+	if !reflect.DeepEqual(wantRevisions, gotRevisions) {
+		t.Fatalf("wanted %v revisions, got %v", wantRevisions, gotRevisions)
+	}
+
+	revision, err := n.GetNotebookRevision(internalCtx, notebook.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(blocks, revision.Blocks) {
+		t.Fatalf("wanted %v revision blocks, got %v", blocks, revision.Blocks)
+	}
+
+	_, err = n.GetNotebookRevision(internalCtx, notebook.ID, 3)
+	if !errors.Is(err, ErrNotebookRevisionNotFound) {
+		t.Fatalf("expected revision not found error, got %+v", err)
+	}
+}
@@ -1,6 +1,9 @@
 package notebooks
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"time"
 )
 
@@ -11,8 +14,18 @@ const (
 	NotebookMarkdownBlockType NotebookBlockType = "md"
 	NotebookFileBlockType     NotebookBlockType = "file"
 	NotebookSymbolBlockType   NotebookBlockType = "symbol"
+
+	NotebookComputeBlockType    NotebookBlockType = "compute"
+	NotebookInsightBlockType    NotebookBlockType = "insight"
+	NotebookReferencesBlockType NotebookBlockType = "references"
 )
 
+// IsExecutable returns true for block types that are executed on the server. The output of an
+// executable block can be stored as a snapshot of the block.
+func (t NotebookBlockType) IsExecutable() bool {
+	return t == NotebookComputeBlockType || t == NotebookInsightBlockType || t == NotebookReferencesBlockType
+}
+
 type NotebookQueryBlockInput struct {
 	Text string `json:"text"`
 }
@@ -46,13 +59,53 @@ type NotebookSymbolBlockInput struct {
 	SymbolKind          string  `json:"symbolKind"`
 }
 
+type NotebookComputeBlockInput struct {
+	// Expression is a compute query, e.g. `content:output((\w+) -> $1) lang:go`.
+	Expression string `json:"expression"`
+}
+
+type NotebookInsightBlockInput struct {
+	// Query is the search query whose results are aggregated.
+	Query       string `json:"query"`
+	PatternType string `json:"patternType"`
+	// Mode is the search aggregation mode, e.g. REPO or CAPTURE_GROUP. If empty, the default mode
+	// for the query is used.
+	Mode string `json:"mode,omitempty"`
+}
+
+type NotebookReferencesBlockInput struct {
+	RepositoryName string  `json:"repositoryName"`
+	FilePath       string  `json:"filePath"`
+	Revision       *string `json:"revision,omitempty"`
+	// Line is the 0-based line of the symbol.
+	Line int32 `json:"line"`
+	// Character is the 0-based character offset of the symbol on the line.
+	Character  int32  `json:"character"`
+	SymbolName string `json:"symbolName"`
+}
+
 type NotebookBlock struct {
-	ID            string                      `json:"id"`
-	Type          NotebookBlockType           `json:"type"`
-	QueryInput    *NotebookQueryBlockInput    `json:"queryInput,omitempty"`
-	MarkdownInput *NotebookMarkdownBlockInput `json:"markdownInput,omitempty"`
-	FileInput     *NotebookFileBlockInput     `json:"fileInput,omitempty"`
-	SymbolInput   *NotebookSymbolBlockInput   `json:"symbolInput,omitempty"`
+	ID              string                        `json:"id"`
+	Type            NotebookBlockType             `json:"type"`
+	QueryInput      *NotebookQueryBlockInput      `json:"queryInput,omitempty"`
+	MarkdownInput   *NotebookMarkdownBlockInput   `json:"markdownInput,omitempty"`
+	FileInput       *NotebookFileBlockInput       `json:"fileInput,omitempty"`
+	SymbolInput     *NotebookSymbolBlockInput     `json:"symbolInput,omitempty"`
+	ComputeInput    *NotebookComputeBlockInput    `json:"computeInput,omitempty"`
+	InsightInput    *NotebookInsightBlockInput    `json:"insightInput,omitempty"`
+	ReferencesInput *NotebookReferencesBlockInput `json:"referencesInput,omitempty"`
+}
+
+// InputHash returns a hash of the type and inputs of the block, which identifies the inputs a
+// snapshot of the block was executed with.
+func (b NotebookBlock) InputHash() (string, error) {
+	b.ID = ""
+	input, err := json.Marshal(b)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(input)
+	return hex.EncodeToString(hash[:]), nil
 }
 
 type NotebookBlocks []NotebookBlock
@@ -68,6 +121,32 @@ type Notebook struct {
 	NamespaceOrgID  int32 // if non-zero, the owner is this organization. NamespaceUserID/NamespaceOrgID are mutually exclusive.
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
+	// Version is incremented on every update. An update is only applied if the version of the
+	// notebook it is based on is still the current version.
+	Version int32
+}
+
+// NotebookRevision is an immutable copy of the title and blocks of a notebook at a version. A
+// revision is created when the notebook is created and on every update.
+type NotebookRevision struct {
+	NotebookID   int64
+	Version      int32
+	Title        string
+	Blocks       NotebookBlocks
+	AuthorUserID int32
+	CreatedAt    time.Time
+}
+
+// NotebookBlockSnapshot is the stored output of an executable block. A hash of the block input is
+// stored along with the output: once the block is edited the snapshot no longer describes it and
+// is not shown.
+type NotebookBlockSnapshot struct {
+	NotebookID    int64
+	BlockID       string
+	InputHash     string
+	Output        json.RawMessage
+	CreatorUserID int32
+	CreatedAt     time.Time
 }
 
 type NotebookStar struct {
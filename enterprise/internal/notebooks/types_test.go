@@ -29,6 +29,18 @@ func TestNotebookBlockMarshalling(t *testing.T) {
 			block: NotebookBlock{ID: "id1", Type: NotebookFileBlockType, FileInput: &fileBlockInput},
 			want:  autogold.Expect(`{"id":"id1","type":"file","fileInput":{"repositoryName":"sourcegraph/sourcegraph","filePath":"a/b.ts","revision":"main","lineRange":{"startLine":1,"endLine":10}}}`),
 		},
+		{
+			block: NotebookBlock{ID: "id1", Type: NotebookComputeBlockType, ComputeInput: &NotebookComputeBlockInput{Expression: "content:output((\\w+) -> $1)"}},
+			want:  autogold.Expect(`{"id":"id1","type":"compute","computeInput":{"expression":"content:output((\\w+) -\u003e $1)"}}`),
+		},
+		{
+			block: NotebookBlock{ID: "id1", Type: NotebookInsightBlockType, InsightInput: &NotebookInsightBlockInput{Query: "TODO", PatternType: "literal", Mode: "REPO"}},
+			want:  autogold.Expect(`{"id":"id1","type":"insight","insightInput":{"query":"TODO","patternType":"literal","mode":"REPO"}}`),
+		},
+		{
+			block: NotebookBlock{ID: "id1", Type: NotebookReferencesBlockType, ReferencesInput: &NotebookReferencesBlockInput{RepositoryName: "sourcegraph/sourcegraph", FilePath: "a/b.go", Line: 10, Character: 5, SymbolName: "Foo"}},
+			want:  autogold.Expect(`{"id":"id1","type":"references","referencesInput":{"repositoryName":"sourcegraph/sourcegraph","filePath":"a/b.go","line":10,"character":5,"symbolName":"Foo"}}`),
+		},
 	}
 
 	for _, tt := range tests {
@@ -73,3 +85,25 @@ func TestNotebookBlockUnmarshalling(t *testing.T) {
 		tt.want.Equal(t, block)
 	}
 }
+
+func TestNotebookBlockInputHash(t *testing.T) {
+	hash := func(block NotebookBlock) string {
+		t.Helper()
+		h, err := block.InputHash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+
+	block := NotebookBlock{ID: "id1", Type: NotebookComputeBlockType, ComputeInput: &NotebookComputeBlockInput{Expression: "content:output(a -> b)"}}
+	moved := NotebookBlock{ID: "id2", Type: NotebookComputeBlockType, ComputeInput: &NotebookComputeBlockInput{Expression: "content:output(a -> b)"}}
+	edited := NotebookBlock{ID: "id1", Type: NotebookComputeBlockType, ComputeInput: &NotebookComputeBlockInput{Expression: "content:output(a -> c)"}}
+
+	if hash(block) != hash(moved) {
+		t.Error("expected the input hash to not depend on the block ID")
+	}
+	if hash(block) == hash(edited) {
+		t.Error("expected the input hash to change when the block input changes")
+	}
+}
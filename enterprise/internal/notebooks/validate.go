@@ -1,12 +1,30 @@
 package notebooks
 
-import "github.com/sourcegraph/sourcegraph/lib/errors"
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// insightBlockAggregationModes are the search aggregation modes an insight block can use. The
+// empty mode selects the default mode of the query.
+var insightBlockAggregationModes = map[string]struct{}{
+	"":              {},
+	"REPO":          {},
+	"PATH":          {},
+	"AUTHOR":        {},
+	"CAPTURE_GROUP": {},
+	"OWNER":         {},
+}
 
 func validateNotebookBlock(block NotebookBlock) error {
 	if block.Type != NotebookQueryBlockType &&
 		block.Type != NotebookMarkdownBlockType &&
 		block.Type != NotebookFileBlockType &&
-		block.Type != NotebookSymbolBlockType {
+		block.Type != NotebookSymbolBlockType &&
+		block.Type != NotebookComputeBlockType &&
+		block.Type != NotebookInsightBlockType &&
+		block.Type != NotebookReferencesBlockType {
 		return errors.Errorf("invalid block type: %s", string(block.Type))
 	}
 
@@ -18,12 +36,41 @@ func validateNotebookBlock(block NotebookBlock) error {
 		return errors.Errorf("invalid file block with id: %s", block.ID)
 	} else if block.Type == NotebookSymbolBlockType && block.SymbolInput == nil {
 		return errors.Errorf("invalid symbol block with id: %s", block.ID)
+	} else if block.Type == NotebookComputeBlockType && block.ComputeInput == nil {
+		return errors.Errorf("invalid compute block with id: %s", block.ID)
+	} else if block.Type == NotebookInsightBlockType && block.InsightInput == nil {
+		return errors.Errorf("invalid insight block with id: %s", block.ID)
+	} else if block.Type == NotebookReferencesBlockType && block.ReferencesInput == nil {
+		return errors.Errorf("invalid references block with id: %s", block.ID)
 	}
 
 	if block.Type == NotebookSymbolBlockType && block.SymbolInput != nil && block.SymbolInput.LineContext < 0 {
 		return errors.Errorf("symbol block line context cannot be negative, block id: %s", block.ID)
 	}
 
+	if block.Type == NotebookComputeBlockType && strings.TrimSpace(block.ComputeInput.Expression) == "" {
+		return errors.Errorf("compute block expression cannot be empty, block id: %s", block.ID)
+	}
+
+	if block.Type == NotebookInsightBlockType {
+		if strings.TrimSpace(block.InsightInput.Query) == "" {
+			return errors.Errorf("insight block query cannot be empty, block id: %s", block.ID)
+		}
+		if _, ok := insightBlockAggregationModes[block.InsightInput.Mode]; !ok {
+			return errors.Errorf("invalid insight block aggregation mode %q, block id: %s", block.InsightInput.Mode, block.ID)
+		}
+	}
+
+	if block.Type == NotebookReferencesBlockType {
+		input := block.ReferencesInput
+		if input.RepositoryName == "" || input.FilePath == "" {
+			return errors.Errorf("references block requires a repository and a file path, block id: %s", block.ID)
+		}
+		if input.Line < 0 || input.Character < 0 {
+			return errors.Errorf("references block position cannot be negative, block id: %s", block.ID)
+		}
+	}
+
 	return nil
 }
 
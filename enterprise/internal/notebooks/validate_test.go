@@ -22,6 +22,24 @@ func TestNotebookBlocksValidation(t *testing.T) {
 		{blocks: NotebookBlocks{
 			{ID: "id1", SymbolInput: &NotebookSymbolBlockInput{LineContext: -10}, Type: NotebookSymbolBlockType},
 		}, wantErr: "symbol block line context cannot be negative, block id: id1"},
+		{blocks: NotebookBlocks{{ID: "id1", Type: NotebookComputeBlockType}}, wantErr: "invalid compute block with id: id1"},
+		{blocks: NotebookBlocks{
+			{ID: "id1", Type: NotebookComputeBlockType, ComputeInput: &NotebookComputeBlockInput{Expression: " "}},
+		}, wantErr: "compute block expression cannot be empty, block id: id1"},
+		{blocks: NotebookBlocks{{ID: "id1", Type: NotebookInsightBlockType}}, wantErr: "invalid insight block with id: id1"},
+		{blocks: NotebookBlocks{
+			{ID: "id1", Type: NotebookInsightBlockType, InsightInput: &NotebookInsightBlockInput{Query: "", PatternType: "literal"}},
+		}, wantErr: "insight block query cannot be empty, block id: id1"},
+		{blocks: NotebookBlocks{
+			{ID: "id1", Type: NotebookInsightBlockType, InsightInput: &NotebookInsightBlockInput{Query: "a", PatternType: "literal", Mode: "LANGUAGE"}},
+		}, wantErr: "invalid insight block aggregation mode \"LANGUAGE\", block id: id1"},
+		{blocks: NotebookBlocks{{ID: "id1", Type: NotebookReferencesBlockType}}, wantErr: "invalid references block with id: id1"},
+		{blocks: NotebookBlocks{
+			{ID: "id1", Type: NotebookReferencesBlockType, ReferencesInput: &NotebookReferencesBlockInput{FilePath: "main.go"}},
+		}, wantErr: "references block requires a repository and a file path, block id: id1"},
+		{blocks: NotebookBlocks{
+			{ID: "id1", Type: NotebookReferencesBlockType, ReferencesInput: &NotebookReferencesBlockInput{RepositoryName: "a", FilePath: "main.go", Line: -1}},
+		}, wantErr: "references block position cannot be negative, block id: id1"},
 	}
 
 	for _, tt := range tests {
@@ -2,16 +2,23 @@ package search
 
 import (
 	ownsearch "github.com/sourcegraph/sourcegraph/enterprise/internal/own/search"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/search/symbol"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
 )
 
-func NewEnterpriseSearchJobs() jobutil.EnterpriseJobs {
-	return &enterpriseJobs{}
+// NewEnterpriseSearchJobs returns the enterprise search jobs. The given symbol
+// ranker may be nil, in which case symbol search results are not reordered.
+func NewEnterpriseSearchJobs(symbolRanker symbol.SymbolRanker) jobutil.EnterpriseJobs {
+	return &enterpriseJobs{
+		symbolRanker: symbolRanker,
+	}
 }
 
-type enterpriseJobs struct{}
+type enterpriseJobs struct {
+	symbolRanker symbol.SymbolRanker
+}
 
 func (e *enterpriseJobs) FileHasOwnerJob(child job.Job, features *search.Features, includeOwners, excludeOwners []string) job.Job {
 	return ownsearch.NewFileHasOwnersJob(child, features, includeOwners, excludeOwners)
@@ -20,3 +27,7 @@ func (e *enterpriseJobs) FileHasOwnerJob(child job.Job, features *search.Feature
 func (e *enterpriseJobs) SelectFileOwnerJob(child job.Job, features *search.Features) job.Job {
 	return ownsearch.NewSelectOwnersJob(child, features)
 }
+
+func (e *enterpriseJobs) SymbolRankingJob(child job.Job) job.Job {
+	return symbol.NewRankingJob(child, e.symbolRanker)
+}
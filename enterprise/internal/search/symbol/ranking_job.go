@@ -0,0 +1,271 @@
+package symbol
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// SymbolRanker returns the reference counts of the symbols defined in a repository.
+type SymbolRanker interface {
+	GetSymbolRanks(ctx context.Context, repoName api.RepoName) (types.RepoSymbolRanks, error)
+}
+
+// NewRankingJob wraps the given job so that the symbol matches it streams are ordered
+// by how often they are referenced, as computed by the code intelligence ranking
+// pipeline. Matches are ranked in batches of up to rankingBatchSize matches as they
+// are streamed, so that results keep streaming and a limit above this job still
+// stops the search early; symbols without any known references keep their original
+// relative order.
+func NewRankingJob(child job.Job, ranker SymbolRanker) job.Job {
+	if ranker == nil || !conf.CodeIntelRankingDocumentReferenceCountsEnabled() {
+		return child
+	}
+
+	return &rankingJob{
+		child:  child,
+		ranker: ranker,
+	}
+}
+
+const (
+	// rankingBatchSize is the number of matches that are ranked together.
+	rankingBatchSize = 100
+
+	// rankingMaxDelay is the longest time a match is held back to be ranked with the
+	// matches that follow it.
+	rankingMaxDelay = 200 * time.Millisecond
+)
+
+type rankingJob struct {
+	child  job.Job
+	ranker SymbolRanker
+}
+
+func (j *rankingJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	ranks := newRanksCache(j.ranker, clients.Logger)
+	rankingStream := newRankingStream(stream, rankingBatchSize, rankingMaxDelay, func(matches []result.Match) {
+		sortMatchesByRank(matches, func(repoName api.RepoName) types.RepoSymbolRanks {
+			return ranks.get(ctx, repoName)
+		})
+	})
+	defer rankingStream.Done()
+
+	return j.child.Run(ctx, clients, rankingStream)
+}
+
+func (j *rankingJob) Name() string {
+	return "SymbolRankingJob"
+}
+
+func (j *rankingJob) Fields(job.Verbosity) []otlog.Field {
+	return nil
+}
+
+func (j *rankingJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *rankingJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+// rankingStream buffers the matches sent to it and forwards them to its parent in
+// ranked batches. A batch is forwarded once it holds batchSize matches, or maxDelay
+// after its first match was buffered. Stats are forwarded immediately. Done must be
+// called once no more events will be sent to flush the last batch.
+type rankingStream struct {
+	parent    streaming.Sender
+	batchSize int
+	maxDelay  time.Duration
+	rank      func([]result.Match)
+
+	mu    sync.Mutex
+	batch result.Matches
+	timer *time.Timer
+}
+
+func newRankingStream(parent streaming.Sender, batchSize int, maxDelay time.Duration, rank func([]result.Match)) *rankingStream {
+	return &rankingStream{
+		parent:    parent,
+		batchSize: batchSize,
+		maxDelay:  maxDelay,
+		rank:      rank,
+	}
+}
+
+func (s *rankingStream) Send(event streaming.SearchEvent) {
+	if !event.Stats.Zero() {
+		s.parent.Send(streaming.SearchEvent{Stats: event.Stats})
+	}
+	if len(event.Results) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	s.batch = append(s.batch, event.Results...)
+	var full result.Matches
+	if len(s.batch) >= s.batchSize {
+		full = s.takeBatch()
+	} else if s.timer == nil {
+		s.timer = time.AfterFunc(s.maxDelay, func() {
+			s.mu.Lock()
+			batch := s.takeBatch()
+			s.mu.Unlock()
+			s.send(batch)
+		})
+	}
+	s.mu.Unlock()
+
+	// Ranks are fetched without holding the lock, so a slow fetch does not block
+	// concurrent senders.
+	s.send(full)
+}
+
+// Done flushes the buffered matches and cancels any scheduled flush.
+func (s *rankingStream) Done() {
+	s.mu.Lock()
+	batch := s.takeBatch()
+	s.mu.Unlock()
+	s.send(batch)
+}
+
+// takeBatch returns the buffered matches and resets the buffer. The caller must hold
+// the lock on the stream.
+func (s *rankingStream) takeBatch() result.Matches {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	batch := s.batch
+	s.batch = nil
+	return batch
+}
+
+func (s *rankingStream) send(batch result.Matches) {
+	if len(batch) == 0 {
+		return
+	}
+	s.rank(batch)
+	s.parent.Send(streaming.SearchEvent{Results: batch})
+}
+
+// sortMatchesByRank orders the symbols of each file match by descending rank, then
+// orders the matches themselves by the rank of their highest ranked symbol. Both sorts
+// are stable so that the order of the underlying search backend breaks ties.
+func sortMatchesByRank(matches []result.Match, ranksForRepo func(repoName api.RepoName) types.RepoSymbolRanks) {
+	type rankedMatch struct {
+		match result.Match
+		rank  float64
+	}
+
+	ranked := make([]rankedMatch, 0, len(matches))
+	hasRanks := false
+	for _, match := range matches {
+		ranked = append(ranked, rankedMatch{match: match, rank: rankFileMatch(match, ranksForRepo)})
+		hasRanks = hasRanks || ranked[len(ranked)-1].rank > 0
+	}
+	if !hasRanks {
+		return
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].rank > ranked[j].rank })
+	for i, r := range ranked {
+		matches[i] = r.match
+	}
+}
+
+// rankFileMatch orders the symbols of the given match by descending rank and returns
+// the rank of its highest ranked symbol. Non-symbol matches have a rank of zero.
+func rankFileMatch(match result.Match, ranksForRepo func(repoName api.RepoName) types.RepoSymbolRanks) float64 {
+	fm, ok := match.(*result.FileMatch)
+	if !ok || len(fm.Symbols) == 0 {
+		return 0
+	}
+
+	ranks := ranksForRepo(fm.Repo.Name)
+	if len(ranks.Paths[fm.Path]) == 0 {
+		return 0
+	}
+
+	symbolRanks := make([]float64, len(fm.Symbols))
+	for i, symbol := range fm.Symbols {
+		symbolRanks[i] = ranks.Rank(fm.Path, symbol.Symbol.Name)
+	}
+	sort.Stable(symbolsByRank{symbols: fm.Symbols, ranks: symbolRanks})
+
+	return symbolRanks[0]
+}
+
+type symbolsByRank struct {
+	symbols []*result.SymbolMatch
+	ranks   []float64
+}
+
+func (s symbolsByRank) Len() int           { return len(s.symbols) }
+func (s symbolsByRank) Less(i, j int) bool { return s.ranks[i] > s.ranks[j] }
+func (s symbolsByRank) Swap(i, j int) {
+	s.symbols[i], s.symbols[j] = s.symbols[j], s.symbols[i]
+	s.ranks[i], s.ranks[j] = s.ranks[j], s.ranks[i]
+}
+
+// ranksCache memoizes the symbol ranks of each repository for the duration of a
+// single search. Failures to fetch ranks are logged and treated as the absence of
+// ranking data, as ranking should never fail a search.
+type ranksCache struct {
+	ranker SymbolRanker
+	logger log.Logger
+
+	mu    sync.Mutex
+	ranks map[api.RepoName]types.RepoSymbolRanks
+}
+
+func newRanksCache(ranker SymbolRanker, logger log.Logger) *ranksCache {
+	if logger == nil {
+		logger = log.Scoped("symbolRanking", "")
+	}
+
+	return &ranksCache{
+		ranker: ranker,
+		logger: logger,
+		ranks:  map[api.RepoName]types.RepoSymbolRanks{},
+	}
+}
+
+func (c *ranksCache) get(ctx context.Context, repoName api.RepoName) types.RepoSymbolRanks {
+	c.mu.Lock()
+	ranks, ok := c.ranks[repoName]
+	c.mu.Unlock()
+	if ok {
+		return ranks
+	}
+
+	// The lock is not held while fetching so that a slow fetch does not block lookups
+	// of other repositories. Concurrent lookups of the same repository may fetch twice.
+	ranks, err := c.ranker.GetSymbolRanks(ctx, repoName)
+	if err != nil {
+		c.logger.Warn("failed to get symbol ranks", log.String("repo", string(repoName)), log.Error(err))
+	}
+
+	c.mu.Lock()
+	c.ranks[repoName] = ranks
+	c.mu.Unlock()
+	return ranks
+}
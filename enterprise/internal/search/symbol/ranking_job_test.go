@@ -0,0 +1,215 @@
+package symbol
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	citypes "github.com/sourcegraph/sourcegraph/internal/codeintel/types"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func fileMatch(repo, path string, symbols ...string) *result.FileMatch {
+	file := result.File{Repo: types.MinimalRepo{Name: api.RepoName(repo)}, Path: path}
+	fm := &result.FileMatch{File: file}
+	for _, name := range symbols {
+		fm.Symbols = append(fm.Symbols, &result.SymbolMatch{File: &fm.File, Symbol: result.Symbol{Name: name}})
+	}
+	return fm
+}
+
+type symbolRankerFunc func(ctx context.Context, repoName api.RepoName) (citypes.RepoSymbolRanks, error)
+
+func (f symbolRankerFunc) GetSymbolRanks(ctx context.Context, repoName api.RepoName) (citypes.RepoSymbolRanks, error) {
+	return f(ctx, repoName)
+}
+
+func TestRankingJob(t *testing.T) {
+	ranks := map[api.RepoName]citypes.RepoSymbolRanks{
+		"github.com/foo/client": {Paths: map[string]map[string]float64{
+			"client.go": {"Client": 8},
+		}},
+	}
+	ranker := symbolRankerFunc(func(_ context.Context, repoName api.RepoName) (citypes.RepoSymbolRanks, error) {
+		return ranks[repoName], nil
+	})
+
+	child := mockjob.NewMockJob()
+	child.RunFunc.SetDefaultHook(func(ctx context.Context, clients job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		// The highest ranked match is only sent in the second event
+		s.Send(streaming.SearchEvent{Results: result.Matches{fileMatch("github.com/foo/other", "client.go", "Client")}})
+		s.Send(streaming.SearchEvent{Results: result.Matches{fileMatch("github.com/foo/client", "client.go", "Client")}})
+		return nil, nil
+	})
+
+	var events []streaming.SearchEvent
+	stream := streaming.StreamFunc(func(event streaming.SearchEvent) { events = append(events, event) })
+
+	j := &rankingJob{child: child, ranker: ranker}
+	if _, err := j.Run(context.Background(), job.RuntimeClients{}, stream); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("unexpected number of events. want=%d have=%d", 1, len(events))
+	}
+	var repoNames []api.RepoName
+	for _, match := range events[0].Results {
+		repoNames = append(repoNames, match.RepoName().Name)
+	}
+	if diff := cmp.Diff([]api.RepoName{"github.com/foo/client", "github.com/foo/other"}, repoNames); diff != "" {
+		t.Errorf("unexpected order (-want +got):\n%s", diff)
+	}
+}
+
+func TestRankingJobLimit(t *testing.T) {
+	ranker := symbolRankerFunc(func(context.Context, api.RepoName) (citypes.RepoSymbolRanks, error) {
+		return citypes.RepoSymbolRanks{}, nil
+	})
+
+	sent := 0
+	child := mockjob.NewMockJob()
+	child.RunFunc.SetDefaultHook(func(ctx context.Context, clients job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		for ; sent < 100*rankingBatchSize; sent++ {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			s.Send(streaming.SearchEvent{Results: result.Matches{fileMatch("github.com/foo/bar", fmt.Sprintf("%d.go", sent), "Client")}})
+		}
+		return nil, nil
+	})
+
+	limit := rankingBatchSize + rankingBatchSize/2
+	agg := streaming.NewAggregatingStream()
+	j := jobutil.NewLimitJob(limit, &rankingJob{child: child, ranker: ranker})
+	if _, err := j.Run(context.Background(), job.RuntimeClients{}, agg); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if sent != 2*rankingBatchSize {
+		t.Errorf("expected the child to stop once the limit is reached. want=%d sent=%d", 2*rankingBatchSize, sent)
+	}
+	if len(agg.Results) != limit {
+		t.Errorf("unexpected number of results. want=%d have=%d", limit, len(agg.Results))
+	}
+}
+
+func TestRankingStream(t *testing.T) {
+	rank := func(matches []result.Match) {
+		sortMatchesByRank(matches, func(repoName api.RepoName) citypes.RepoSymbolRanks {
+			return citypes.RepoSymbolRanks{Paths: map[string]map[string]float64{
+				"ranked.go": {"Client": 1},
+			}}
+		})
+	}
+	paths := func(event streaming.SearchEvent) (paths []string) {
+		for _, match := range event.Results {
+			paths = append(paths, match.(*result.FileMatch).Path)
+		}
+		return paths
+	}
+
+	t.Run("full batches are ranked and sent immediately", func(t *testing.T) {
+		var events []streaming.SearchEvent
+		s := newRankingStream(streaming.StreamFunc(func(event streaming.SearchEvent) { events = append(events, event) }), 2, time.Hour, rank)
+		s.Send(streaming.SearchEvent{Results: result.Matches{fileMatch("github.com/foo/bar", "unranked.go", "Client")}})
+		s.Send(streaming.SearchEvent{Stats: streaming.Stats{IsLimitHit: true}})
+		s.Send(streaming.SearchEvent{Results: result.Matches{fileMatch("github.com/foo/bar", "ranked.go", "Client")}})
+		s.Send(streaming.SearchEvent{Results: result.Matches{fileMatch("github.com/foo/bar", "last.go", "Client")}})
+
+		if len(events) != 2 {
+			t.Fatalf("unexpected number of events. want=%d have=%d", 2, len(events))
+		}
+		if !events[0].Stats.IsLimitHit || len(events[0].Results) != 0 {
+			t.Errorf("expected stats to be sent immediately, got %+v", events[0])
+		}
+		if diff := cmp.Diff([]string{"ranked.go", "unranked.go"}, paths(events[1])); diff != "" {
+			t.Errorf("unexpected order (-want +got):\n%s", diff)
+		}
+
+		s.Done()
+		if len(events) != 3 {
+			t.Fatalf("unexpected number of events. want=%d have=%d", 3, len(events))
+		}
+		if diff := cmp.Diff([]string{"last.go"}, paths(events[2])); diff != "" {
+			t.Errorf("unexpected matches (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("partial batches are sent after the max delay", func(t *testing.T) {
+		events := make(chan streaming.SearchEvent, 1)
+		s := newRankingStream(streaming.StreamFunc(func(event streaming.SearchEvent) { events <- event }), 100, time.Millisecond, rank)
+		defer s.Done()
+		s.Send(streaming.SearchEvent{Results: result.Matches{fileMatch("github.com/foo/bar", "unranked.go", "Client")}})
+
+		select {
+		case event := <-events:
+			if diff := cmp.Diff([]string{"unranked.go"}, paths(event)); diff != "" {
+				t.Errorf("unexpected matches (-want +got):\n%s", diff)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("expected the partial batch to be sent")
+		}
+	})
+}
+
+func TestSortMatchesByRank(t *testing.T) {
+	ranks := map[api.RepoName]citypes.RepoSymbolRanks{
+		"github.com/foo/client": {Paths: map[string]map[string]float64{
+			"client.go": {"Client": 8, "Do": 3},
+		}},
+		"github.com/foo/other": {Paths: map[string]map[string]float64{
+			"testutil/client.go": {"Client": 1},
+		}},
+	}
+	ranksForRepo := func(repoName api.RepoName) citypes.RepoSymbolRanks {
+		return ranks[repoName]
+	}
+
+	matches := []result.Match{
+		fileMatch("github.com/foo/other", "testutil/client.go", "Client"),
+		&result.RepoMatch{Name: "github.com/foo/bar"},
+		fileMatch("github.com/foo/unranked", "client.go", "Client"),
+		fileMatch("github.com/foo/client", "client.go", "NewClient", "Do", "Client"),
+	}
+	sortMatchesByRank(matches, ranksForRepo)
+
+	type summary struct {
+		Repo    api.RepoName
+		Path    string
+		Symbols []string
+	}
+	summarize := func(matches []result.Match) (summaries []summary) {
+		for _, match := range matches {
+			s := summary{Repo: match.RepoName().Name}
+			if fm, ok := match.(*result.FileMatch); ok {
+				s.Path = fm.Path
+				for _, symbol := range fm.Symbols {
+					s.Symbols = append(s.Symbols, symbol.Symbol.Name)
+				}
+			}
+			summaries = append(summaries, s)
+		}
+		return summaries
+	}
+
+	expected := []summary{
+		{Repo: "github.com/foo/client", Path: "client.go", Symbols: []string{"Client", "Do", "NewClient"}},
+		{Repo: "github.com/foo/other", Path: "testutil/client.go", Symbols: []string{"Client"}},
+		{Repo: "github.com/foo/bar"},
+		{Repo: "github.com/foo/unranked", Path: "client.go", Symbols: []string{"Client"}},
+	}
+	if diff := cmp.Diff(expected, summarize(matches)); diff != "" {
+		t.Errorf("unexpected order (-want +got):\n%s", diff)
+	}
+}
@@ -109,6 +109,10 @@ func (r *Resolver) VulnerabilityMatchesCountByRepository(ctx context.Context, ar
 	return r.sentinelRootResolver.VulnerabilityMatchesCountByRepository(ctx, args)
 }
 
+func (r *Resolver) SBOM(ctx context.Context, args SBOMArgs) (_ *string, err error) {
+	return r.sentinelRootResolver.SBOM(ctx, args)
+}
+
 func (r *Resolver) IndexerKeys(ctx context.Context, opts *IndexerKeyQueryArgs) (_ []string, err error) {
 	return r.uploadsRootResolver.IndexerKeys(ctx, opts)
 }
@@ -18,6 +18,9 @@ type SentinelServiceResolver interface {
 	VulnerabilityMatchByID(ctx context.Context, id graphql.ID) (_ VulnerabilityMatchResolver, err error)
 	VulnerabilityMatchesSummaryCounts(ctx context.Context) (VulnerabilityMatchesSummaryCountResolver, error)
 	VulnerabilityMatchesCountByRepository(ctx context.Context, args GetVulnerabilityMatchCountByRepositoryArgs) (VulnerabilityMatchCountByRepositoryConnectionResolver, error)
+
+	// Software bill of materials
+	SBOM(ctx context.Context, args SBOMArgs) (*string, error)
 }
 
 type (
@@ -27,11 +30,18 @@ type (
 	VulnerabilityMatchCountByRepositoryConnectionResolver = PagedConnectionWithTotalCountResolver[VulnerabilityMatchCountByRepositoryResolver]
 )
 
+type SBOMArgs struct {
+	Repository graphql.ID
+	Commit     string
+	Format     string
+}
+
 type GetVulnerabilityMatchesArgs struct {
 	PagedConnectionArgs
 	Severity       *string
 	Language       *string
 	RepositoryName *string
+	Reachability   *string
 }
 
 type VulnerabilityResolver interface {
@@ -74,6 +84,17 @@ type VulnerabilityMatchResolver interface {
 	Vulnerability(ctx context.Context) (VulnerabilityResolver, error)
 	AffectedPackage(ctx context.Context) (VulnerabilityAffectedPackageResolver, error)
 	PreciseIndex(ctx context.Context) (PreciseIndexResolver, error)
+	Reachability() string
+	CallSites() []VulnerabilityCallSiteResolver
+}
+
+type VulnerabilityCallSiteResolver interface {
+	Path() string
+	Symbol() string
+	StartLine() int32
+	StartCharacter() int32
+	EndLine() int32
+	EndCharacter() int32
 }
 
 type VulnerabilityMatchesSummaryCountResolver interface {
@@ -11,3 +11,20 @@ type RepoPathRanks struct {
 	// over all repositories.
 	Paths map[string]float64 `json:"paths"`
 }
+
+// RepoSymbolRanks are used to order symbol search results when a repository has
+// precise reference counts.
+type RepoSymbolRanks struct {
+	// Paths are a map from path name to a map from the unqualified name of a symbol
+	// defined in that path to its normalized reference count. Normalized counts equal
+	// log_2({number of reference records referencing the symbol} + 1), where records are
+	// considered over all repositories. Each reference record holds a batch of the references
+	// of a single document, so this approximates the number of referencing documents.
+	Paths map[string]map[string]float64 `json:"paths"`
+}
+
+// Rank returns the normalized reference count of the symbol with the given name
+// defined in the given path, or zero if the symbol is not known.
+func (r RepoSymbolRanks) Rank(path, name string) float64 {
+	return r.Paths[path][name]
+}
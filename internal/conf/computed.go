@@ -298,6 +298,15 @@ func EmbeddingsEnabled() bool {
 	return embeddingsConfig != nil && embeddingsConfig.Enabled
 }
 
+// EmbeddingsANNProbes returns the number of clusters of the approximate nearest neighbor index of
+// embedding indexes to search. 0 and -1 mean that every row is searched, which is the default.
+func EmbeddingsANNProbes() int {
+	if embeddingsConfig := Get().Embeddings; embeddingsConfig != nil {
+		return embeddingsConfig.AnnProbes
+	}
+	return 0
+}
+
 func ProductResearchPageEnabled() bool {
 	if enabled := Get().ProductResearchPageEnabled; enabled != nil {
 		return *enabled
@@ -40,6 +40,7 @@ type Client interface {
 	GetPullRequestStatuses(ctx context.Context, args PullRequestCommonArgs) ([]PullRequestBuildStatus, error)
 	UpdatePullRequest(ctx context.Context, args PullRequestCommonArgs, input PullRequestUpdateInput) (PullRequest, error)
 	CreatePullRequestCommentThread(ctx context.Context, args PullRequestCommonArgs, input PullRequestCommentInput) (PullRequestCommentResponse, error)
+	AddPullRequestReviewers(ctx context.Context, args PullRequestCommonArgs, reviewerIDs []string) ([]Reviewer, error)
 	CompletePullRequest(ctx context.Context, args PullRequestCommonArgs, input PullRequestCompleteInput) (PullRequest, error)
 	GetRepo(ctx context.Context, args OrgProjectRepoArgs) (Repository, error)
 	ListRepositoriesByProjectOrOrg(ctx context.Context, args ListRepositoriesByProjectOrOrgArgs) ([]Repository, error)
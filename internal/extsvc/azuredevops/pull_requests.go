@@ -137,6 +137,36 @@ func (c *client) CreatePullRequestCommentThread(ctx context.Context, args PullRe
 	return pr, nil
 }
 
+// AddPullRequestReviewers adds the identities with the given IDs as reviewers
+// of the specified PR, returns the added reviewers.
+func (c *client) AddPullRequestReviewers(ctx context.Context, args PullRequestCommonArgs, reviewerIDs []string) ([]Reviewer, error) {
+	reqURL := url.URL{Path: fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%s/reviewers", args.Org, args.Project, args.RepoNameOrID, args.PullRequestID)}
+
+	input := make([]Reviewer, 0, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		input = append(input, Reviewer{ID: id})
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling request")
+	}
+
+	req, err := http.NewRequest("POST", reqURL.String(), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Value []Reviewer `json:"value"`
+	}
+	if _, err = c.do(ctx, req, "", &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Value, nil
+}
+
 // CompletePullRequest completes(merges) the specified PR, returns the updated PR.
 func (c *client) CompletePullRequest(ctx context.Context, args PullRequestCommonArgs, input PullRequestCompleteInput) (PullRequest, error) {
 	reqURL := url.URL{Path: fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%s", args.Org, args.Project, args.RepoNameOrID, args.PullRequestID)}
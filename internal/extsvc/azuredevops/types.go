@@ -26,6 +26,10 @@ var (
 	PullRequestMergeStrategyRebase        PullRequestMergeStrategy = "rebase"
 	PullRequestMergeStrategyRebaseMerge   PullRequestMergeStrategy = "rebaseMerge"
 	PullRequestMergeStrategyNoFastForward PullRequestMergeStrategy = "notFastForward"
+
+	// PullRequestMergeStatusConflicts is the MergeStatus of a pull request
+	// whose source branch conflicts with its target branch.
+	PullRequestMergeStatusConflicts = "conflicts"
 )
 
 type Org struct {
@@ -1231,6 +1231,10 @@ type PullRequest struct {
 		} `json:"self"`
 	} `json:"links"`
 
+	// Properties holds the result of the last merge check of the pull
+	// request, if Bitbucket Server has run one.
+	Properties *PullRequestProperties `json:"properties,omitempty"`
+
 	Activities   []*Activity     `json:"activities,omitempty"`
 	Commits      []*Commit       `json:"commits,omitempty"`
 	CommitStatus []*CommitStatus `json:"commit_status,omitempty"`
@@ -1239,6 +1243,18 @@ type PullRequest struct {
 	BuildStatuses []*BuildStatus `json:"buildstatuses,omitempty"`
 }
 
+// PullRequestProperties are the computed properties of a pull request.
+type PullRequestProperties struct {
+	MergeResult *MergeResult `json:"mergeResult,omitempty"`
+}
+
+// MergeResult is the outcome of merging a pull request into its target
+// branch: CLEAN, CONFLICTED or UNKNOWN.
+type MergeResult struct {
+	Outcome string `json:"outcome"`
+	Current bool   `json:"current"`
+}
+
 // PullRequestAuthor is the author of a pull request.
 type PullRequestAuthor struct {
 	User     *User  `json:"user"`
@@ -1599,6 +1615,34 @@ func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest) error {
 	return nil
 }
 
+// AddPullRequestReviewer adds the user with the given name as a reviewer of
+// the pull request.
+func (c *Client) AddPullRequestReviewer(ctx context.Context, pr *PullRequest, username string) error {
+	if pr.ToRef.Repository.Slug == "" {
+		return errors.New("repository slug empty")
+	}
+
+	if pr.ToRef.Repository.Project.Key == "" {
+		return errors.New("project key empty")
+	}
+
+	path := fmt.Sprintf(
+		"rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/participants",
+		pr.ToRef.Repository.Project.Key,
+		pr.ToRef.Repository.Slug,
+		pr.ID,
+	)
+
+	payload := map[string]any{
+		"user": map[string]string{"name": username},
+		"role": "REVIEWER",
+	}
+
+	var resp *Participant
+	_, err := c.send(ctx, "POST", path, nil, &payload, &resp)
+	return err
+}
+
 func (c *Client) GetVersion(ctx context.Context) (string, error) {
 	var v struct {
 		Version     string
@@ -0,0 +1,284 @@
+//nolint:bodyclose // Body is closed in Client.Do, but the response is still returned to provide access to the headers
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ChangeStatus is the status of a Gerrit change.
+type ChangeStatus string
+
+const (
+	ChangeStatusNew       ChangeStatus = "NEW"
+	ChangeStatusMerged    ChangeStatus = "MERGED"
+	ChangeStatusAbandoned ChangeStatus = "ABANDONED"
+)
+
+// Well-known labels that Gerrit projects use to vote on changes.
+const (
+	LabelCodeReview = "Code-Review"
+	LabelVerified   = "Verified"
+)
+
+// Change is a Gerrit change, as returned by the changes REST API as a
+// ChangeInfo entity.
+type Change struct {
+	ID              string                  `json:"id"`
+	Project         string                  `json:"project"`
+	Branch          string                  `json:"branch"`
+	Topic           string                  `json:"topic,omitempty"`
+	ChangeID        string                  `json:"change_id"`
+	Subject         string                  `json:"subject"`
+	Status          ChangeStatus            `json:"status"`
+	Created         Timestamp               `json:"created"`
+	Updated         Timestamp               `json:"updated"`
+	Submitted       *Timestamp              `json:"submitted,omitempty"`
+	WorkInProgress  bool                    `json:"work_in_progress,omitempty"`
+	Owner           Account                 `json:"owner"`
+	Labels          map[string]ChangeLabel  `json:"labels,omitempty"`
+	CurrentRevision string                  `json:"current_revision,omitempty"`
+	Revisions       map[string]RevisionInfo `json:"revisions,omitempty"`
+	Number          int                     `json:"_number"`
+	Insertions      int32                   `json:"insertions"`
+	Deletions       int32                   `json:"deletions"`
+	// Mergeable is only reported by Gerrit instances that compute the
+	// mergeability of open changes, and is nil otherwise.
+	Mergeable *bool `json:"mergeable,omitempty"`
+}
+
+// CurrentCommit returns the commit of the current patch set of the change, if
+// the change was requested with the CURRENT_COMMIT option.
+func (c *Change) CurrentCommit() *CommitInfo {
+	if rev, ok := c.Revisions[c.CurrentRevision]; ok {
+		return rev.Commit
+	}
+	return nil
+}
+
+// ChangeLabel holds the votes cast on a single label of a change.
+type ChangeLabel struct {
+	Approved     *Account   `json:"approved,omitempty"`
+	Rejected     *Account   `json:"rejected,omitempty"`
+	Recommended  *Account   `json:"recommended,omitempty"`
+	Disliked     *Account   `json:"disliked,omitempty"`
+	Optional     bool       `json:"optional,omitempty"`
+	All          []Approval `json:"all,omitempty"`
+	DefaultValue int        `json:"default_value,omitempty"`
+}
+
+// Approval is a vote of a single account on a label of a change.
+type Approval struct {
+	Account
+	Value int        `json:"value"`
+	Date  *Timestamp `json:"date,omitempty"`
+}
+
+// Reviewer is a vote on a change, flattened together with the label it was
+// cast on. Batch changes tracks each of these as a changeset event.
+type Reviewer struct {
+	Approval
+	Label string `json:"label"`
+}
+
+// Reviewers returns the votes cast on the given labels of the change. Accounts
+// that were added as reviewers but did not vote are skipped.
+func (c *Change) Reviewers(labels ...string) []Reviewer {
+	var reviewers []Reviewer
+	for _, label := range labels {
+		for _, approval := range c.Labels[label].All {
+			if approval.Value == 0 && approval.Date == nil {
+				continue
+			}
+			reviewers = append(reviewers, Reviewer{Approval: approval, Label: label})
+		}
+	}
+	return reviewers
+}
+
+// RevisionInfo describes a patch set of a change.
+type RevisionInfo struct {
+	Number int         `json:"_number"`
+	Ref    string      `json:"ref"`
+	Commit *CommitInfo `json:"commit,omitempty"`
+}
+
+// CommitInfo describes the commit of a patch set.
+type CommitInfo struct {
+	Commit  string       `json:"commit,omitempty"`
+	Parents []CommitInfo `json:"parents,omitempty"`
+	Subject string       `json:"subject"`
+	Message string       `json:"message,omitempty"`
+}
+
+// timestampLayout is the format Gerrit uses for all timestamps in its REST
+// API. Timestamps are always in UTC.
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+// Timestamp is a time.Time that is (un)marshaled in the format of the Gerrit
+// REST API.
+type Timestamp struct {
+	time.Time
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte(`""`), nil
+	}
+	return json.Marshal(t.UTC().Format(timestampLayout))
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	parsed, err := time.ParseInLocation("2006-01-02 15:04:05.999999999", s, time.UTC)
+	if err != nil {
+		return errors.Wrapf(err, "parsing Gerrit timestamp %q", s)
+	}
+	t.Time = parsed
+	return nil
+}
+
+// changeOptions are the additional fields requested for every change, so that
+// batch changes has access to the votes and the current commit.
+var changeOptions = []string{"DETAILED_LABELS", "DETAILED_ACCOUNTS", "CURRENT_REVISION", "CURRENT_COMMIT"}
+
+// ChangeIdentifier returns the identifier of the change with the given
+// Change-Id in the given project and destination branch, as accepted by all
+// changes endpoints.
+func ChangeIdentifier(project, branch, changeID string) string {
+	return strings.Join([]string{project, strings.TrimPrefix(branch, "refs/heads/"), changeID}, "~")
+}
+
+// GetChange fetches the change with the given identifier, which is either the
+// numeric change number or a ChangeIdentifier.
+func (c *Client) GetChange(ctx context.Context, changeID string) (*Change, error) {
+	query := make(url.Values)
+	for _, o := range changeOptions {
+		query.Add("o", o)
+	}
+
+	req, err := http.NewRequest("GET", changePath(changeID, "")+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if _, err = c.do(ctx, req, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// AbandonChange abandons the change.
+func (c *Client) AbandonChange(ctx context.Context, changeID string) (*Change, error) {
+	return c.postChange(ctx, changeID, "abandon", nil)
+}
+
+// RestoreChange restores an abandoned change.
+func (c *Client) RestoreChange(ctx context.Context, changeID string) (*Change, error) {
+	return c.postChange(ctx, changeID, "restore", nil)
+}
+
+// SubmitChange submits the change, merging it into its destination branch.
+func (c *Client) SubmitChange(ctx context.Context, changeID string) (*Change, error) {
+	return c.postChange(ctx, changeID, "submit", nil)
+}
+
+// MoveChange moves the change to a different destination branch.
+func (c *Client) MoveChange(ctx context.Context, changeID, branch string) (*Change, error) {
+	return c.postChange(ctx, changeID, "move", map[string]string{
+		"destination_branch": strings.TrimPrefix(branch, "refs/heads/"),
+	})
+}
+
+// SetWorkInProgress marks the change as work in progress.
+func (c *Client) SetWorkInProgress(ctx context.Context, changeID string) error {
+	return c.postChangeNoContent(ctx, changeID, "wip", nil)
+}
+
+// SetReadyForReview marks a work in progress change as ready for review.
+func (c *Client) SetReadyForReview(ctx context.Context, changeID string) error {
+	return c.postChangeNoContent(ctx, changeID, "ready", nil)
+}
+
+// SetCommitMessage creates a new patch set of the change with the given commit
+// message. The message must retain the Change-Id trailer of the change.
+func (c *Client) SetCommitMessage(ctx context.Context, changeID, message string) error {
+	req, err := newJSONRequest("PUT", changePath(changeID, "message"), map[string]string{"message": message})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// WriteReviewComment posts a top-level comment on the current patch set of the
+// change, without voting on any label.
+func (c *Client) WriteReviewComment(ctx context.Context, changeID, message string) error {
+	return c.postChangeNoContent(ctx, changeID, "revisions/current/review", map[string]string{"message": message})
+}
+
+func (c *Client) postChange(ctx context.Context, changeID, action string, body any) (*Change, error) {
+	req, err := newJSONRequest("POST", changePath(changeID, action), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if _, err = c.do(ctx, req, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (c *Client) postChangeNoContent(ctx context.Context, changeID, action string, body any) error {
+	req, err := newJSONRequest("POST", changePath(changeID, action), body)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+func changePath(changeID, action string) string {
+	p := "a/changes/" + url.PathEscape(changeID)
+	if action != "" {
+		p += "/" + action
+	}
+	return p
+}
+
+func newJSONRequest(method, urlStr string, body any) (*http.Request, error) {
+	if body == nil {
+		return http.NewRequest(method, urlStr, nil)
+	}
+
+	bs, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, urlStr, bytes.NewReader(bs))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	return req, nil
+}
@@ -0,0 +1,210 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+const testChange = `)]}'
+{
+  "id": "foo%2Fbar~main~I8473b95934b5732ac55d26311a706c9c2bde9940",
+  "project": "foo/bar",
+  "branch": "main",
+  "topic": "batch/update-deps",
+  "change_id": "I8473b95934b5732ac55d26311a706c9c2bde9940",
+  "subject": "Update dependencies",
+  "status": "NEW",
+  "created": "2023-04-12 09:59:32.126000000",
+  "updated": "2023-04-13 10:01:02.000000000",
+  "work_in_progress": true,
+  "owner": {"_account_id": 1000096, "name": "John Doe", "username": "jdoe"},
+  "labels": {
+    "Code-Review": {
+      "all": [
+        {"_account_id": 1000097, "username": "jroe", "value": 2, "date": "2023-04-13 10:00:00.000000000"},
+        {"_account_id": 1000098, "username": "nobody", "value": 0}
+      ]
+    },
+    "Verified": {
+      "all": [
+        {"_account_id": 1000099, "username": "ci", "value": -1, "date": "2023-04-13 10:01:00.000000000"}
+      ]
+    }
+  },
+  "current_revision": "184ebe53805e102605d11f6b143486d15c23a09c",
+  "revisions": {
+    "184ebe53805e102605d11f6b143486d15c23a09c": {
+      "_number": 2,
+      "ref": "refs/changes/34/1234/2",
+      "commit": {
+        "parents": [{"commit": "1eee2c9d8f352483781e772f35dc586a69ff5646", "subject": "Parent"}],
+        "subject": "Update dependencies",
+        "message": "Update dependencies\n\nChange-Id: I8473b95934b5732ac55d26311a706c9c2bde9940\n"
+      }
+    }
+  },
+  "_number": 1234
+}`
+
+func TestClient_GetChange(t *testing.T) {
+	cli := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if want := "/a/changes/foo%2Fbar~main~I8473b95934b5732ac55d26311a706c9c2bde9940"; r.URL.EscapedPath() != want {
+			t.Errorf("unexpected path: want %q, have %q", want, r.URL.EscapedPath())
+		}
+		if diff := cmp.Diff(changeOptions, r.URL.Query()["o"]); diff != "" {
+			t.Errorf("unexpected options (-want +got):\n%s", diff)
+		}
+		_, _ = io.WriteString(w, testChange)
+	})
+
+	change, err := cli.GetChange(context.Background(), ChangeIdentifier("foo/bar", "refs/heads/main", "I8473b95934b5732ac55d26311a706c9c2bde9940"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if change.Number != 1234 || change.Status != ChangeStatusNew || !change.WorkInProgress {
+		t.Errorf("unexpected change: %+v", change)
+	}
+	if want := time.Date(2023, 4, 12, 9, 59, 32, 126000000, time.UTC); !change.Created.Equal(want) {
+		t.Errorf("unexpected created timestamp: want %s, have %s", want, change.Created)
+	}
+	if commit := change.CurrentCommit(); commit == nil || commit.Parents[0].Commit != "1eee2c9d8f352483781e772f35dc586a69ff5646" {
+		t.Errorf("unexpected current commit: %+v", commit)
+	}
+
+	type vote struct {
+		Username string
+		Label    string
+		Value    int
+	}
+	var votes []vote
+	for _, r := range change.Reviewers(LabelCodeReview, LabelVerified) {
+		votes = append(votes, vote{Username: r.Username, Label: r.Label, Value: r.Value})
+	}
+	wantVotes := []vote{
+		{Username: "jroe", Label: LabelCodeReview, Value: 2},
+		{Username: "ci", Label: LabelVerified, Value: -1},
+	}
+	if diff := cmp.Diff(wantVotes, votes); diff != "" {
+		t.Errorf("unexpected votes (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_GetChange_NotFound(t *testing.T) {
+	cli := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not found: 1234", http.StatusNotFound)
+	})
+
+	_, err := cli.GetChange(context.Background(), "1234")
+	if !errcode.IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestClient_ChangeActions(t *testing.T) {
+	type request struct {
+		Method string
+		Path   string
+		Body   map[string]string
+	}
+	var requests []request
+
+	cli := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		req := request{Method: r.Method, Path: r.URL.Path}
+		if r.ContentLength > 0 {
+			if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+				t.Fatal(err)
+			}
+		}
+		requests = append(requests, req)
+
+		switch r.URL.Path {
+		case "/a/changes/1234/wip", "/a/changes/1234/ready":
+			w.WriteHeader(http.StatusOK)
+		case "/a/changes/1234/message":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_, _ = io.WriteString(w, testChange)
+		}
+	})
+
+	ctx := context.Background()
+	if _, err := cli.AbandonChange(ctx, "1234"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cli.MoveChange(ctx, "1234", "refs/heads/release"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.SetWorkInProgress(ctx, "1234"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.SetReadyForReview(ctx, "1234"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.SetCommitMessage(ctx, "1234", "New message"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.WriteReviewComment(ctx, "1234", "Hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []request{
+		{Method: "POST", Path: "/a/changes/1234/abandon"},
+		{Method: "POST", Path: "/a/changes/1234/move", Body: map[string]string{"destination_branch": "release"}},
+		{Method: "POST", Path: "/a/changes/1234/wip"},
+		{Method: "POST", Path: "/a/changes/1234/ready"},
+		{Method: "PUT", Path: "/a/changes/1234/message", Body: map[string]string{"message": "New message"}},
+		{Method: "POST", Path: "/a/changes/1234/revisions/current/review", Body: map[string]string{"message": "Hello"}},
+	}
+	if diff := cmp.Diff(want, requests); diff != "" {
+		t.Errorf("unexpected requests (-want +got):\n%s", diff)
+	}
+}
+
+func TestTimestamp_RoundTrip(t *testing.T) {
+	want := Timestamp{time.Date(2023, 4, 12, 9, 59, 32, 126000000, time.UTC)}
+
+	bs, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bs) != `"2023-04-12 09:59:32.126000000"` {
+		t.Errorf("unexpected encoding: %s", bs)
+	}
+
+	var have Timestamp
+	if err := json.Unmarshal(bs, &have); err != nil {
+		t.Fatal(err)
+	}
+	if !have.Equal(want.Time) {
+		t.Errorf("unexpected timestamp: want %s, have %s", want, have)
+	}
+}
+
+func newTestServerClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cli, err := NewClient("urn", u, &AccountCredentials{}, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cli
+}
@@ -185,6 +185,7 @@ type PullRequest struct {
 	TimelineItems  []TimelineItem
 	Commits        struct{ Nodes []CommitWithChecks }
 	IsDraft        bool
+	Mergeable      string // MERGEABLE, CONFLICTING or UNKNOWN
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
@@ -957,6 +958,34 @@ func (c *V4Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest
 	return c.requestGraphQL(ctx, createPullRequestCommentMutation, input, &result)
 }
 
+const requestPullRequestReviewsMutation = `
+mutation RequestPullRequestReviews($input: RequestReviewsInput!) {
+  requestReviews(input: $input) {
+    pullRequest { id }
+  }
+}
+`
+
+// RequestPullRequestReviews requests reviews of the PullRequest on Github from
+// the users with the given node IDs, in addition to the reviewers that have
+// already been requested.
+func (c *V4Client) RequestPullRequestReviews(ctx context.Context, pr *PullRequest, userIDs []string) error {
+	var result struct {
+		RequestReviews struct {
+			PullRequest struct {
+				ID string
+			} `json:"pullRequest"`
+		} `json:"requestReviews"`
+	}
+
+	input := map[string]any{"input": struct {
+		PullRequestID string   `json:"pullRequestId"`
+		UserIDs       []string `json:"userIds"`
+		Union         bool     `json:"union"`
+	}{PullRequestID: pr.ID, UserIDs: userIDs, Union: true}}
+	return c.requestGraphQL(ctx, requestPullRequestReviewsMutation, input, &result)
+}
+
 const mergePullRequestMutation = `
 mutation MergePullRequest($input: MergePullRequestInput!) {
   mergePullRequest(input: $input) {
@@ -1013,6 +1042,83 @@ func (c *V4Client) MergePullRequest(ctx context.Context, pr *PullRequest, squash
 	return nil
 }
 
+// Issue is a GitHub issue.
+type Issue struct {
+	ID     string
+	Number int64
+	Title  string
+	URL    string
+	Closed bool
+}
+
+const createIssueMutation = `
+mutation CreateIssue($input: CreateIssueInput!) {
+  createIssue(input: $input) {
+    issue { id, number, title, url, closed }
+  }
+}
+`
+
+// CreateIssue creates an issue in the repository with the given node ID on
+// GitHub.
+func (c *V4Client) CreateIssue(ctx context.Context, repositoryID, title, body string) (*Issue, error) {
+	var result struct {
+		CreateIssue struct {
+			Issue *Issue `json:"issue"`
+		} `json:"createIssue"`
+	}
+
+	input := map[string]any{"input": struct {
+		RepositoryID string `json:"repositoryId"`
+		Title        string `json:"title"`
+		Body         string `json:"body"`
+	}{RepositoryID: repositoryID, Title: title, Body: body}}
+	if err := c.requestGraphQL(ctx, createIssueMutation, input, &result); err != nil {
+		return nil, err
+	}
+	return result.CreateIssue.Issue, nil
+}
+
+const getIssueQuery = `
+query GetIssue($id: ID!) {
+  node(id: $id) {
+    ... on Issue { id, number, title, url, closed }
+  }
+}
+`
+
+// GetIssue loads the issue with the given node ID from GitHub.
+func (c *V4Client) GetIssue(ctx context.Context, id string) (*Issue, error) {
+	var result struct {
+		Node *Issue `json:"node"`
+	}
+
+	if err := c.requestGraphQL(ctx, getIssueQuery, map[string]any{"id": id}, &result); err != nil {
+		return nil, err
+	}
+	if result.Node == nil || result.Node.ID == "" {
+		return nil, ErrIssueNotFound
+	}
+	return result.Node, nil
+}
+
+// CreateIssueComment creates a comment on the Issue on GitHub.
+func (c *V4Client) CreateIssueComment(ctx context.Context, issue *Issue, body string) error {
+	var result struct {
+		AddComment struct {
+			Subject struct {
+				ID string
+			} `json:"subject"`
+		} `json:"addComment"`
+	}
+
+	input := map[string]any{"input": struct {
+		SubjectID string `json:"subjectId"`
+		Body      string `json:"body"`
+	}{SubjectID: issue.ID, Body: body}}
+	return c.requestGraphQL(ctx, createPullRequestCommentMutation, input, &result)
+}
+
 func (c *V4Client) loadRemainingTimelineItems(ctx context.Context, prID string, pageInfo PageInfo) (items []TimelineItem, err error) {
 	version := c.determineGitHubVersion(ctx)
 	timelineItemTypes, err := timelineItemTypes(version)
@@ -1400,6 +1506,7 @@ fragment pr on PullRequest {
   baseRefOid
   headRefName
   baseRefName
+  mergeable
   %s
   author {
     ...actor
@@ -1626,7 +1733,7 @@ func (e OrgNotFoundError) NotFound() bool { return true }
 // response error, or HTTP 404.
 func IsNotFound(err error) bool {
 	if errors.HasType(err, &RepoNotFoundError{}) || errors.HasType(err, &OrgNotFoundError{}) || errors.HasType(err, ErrPullRequestNotFound(0)) ||
-		HTTPErrorCode(err) == http.StatusNotFound {
+		errors.Is(err, ErrIssueNotFound) || HTTPErrorCode(err) == http.StatusNotFound {
 		return true
 	}
 
@@ -1695,6 +1802,9 @@ func (e ErrPullRequestNotFound) Error() string {
 	return fmt.Sprintf("GitHub pull request not found: %d", e)
 }
 
+// ErrIssueNotFound is when the requested GitHub issue doesn't exist.
+var ErrIssueNotFound = errors.New("GitHub issue not found")
+
 // ErrRepoArchived is returned when a mutation is performed on an archived
 // repo.
 type ErrRepoArchived struct{}
@@ -421,12 +421,16 @@ func HTTPErrorCode(err error) int {
 func IsNotFound(err error) bool {
 	return errors.HasType(err, &ProjectNotFoundError{}) ||
 		errors.Is(err, ErrMergeRequestNotFound) ||
+		errors.Is(err, ErrIssueNotFound) ||
 		HTTPErrorCode(err) == http.StatusNotFound
 }
 
 // ErrMergeRequestNotFound is when the requested GitLab merge request is not found.
 var ErrMergeRequestNotFound = errors.New("GitLab merge request not found")
 
+// ErrIssueNotFound is when the requested GitLab issue is not found.
+var ErrIssueNotFound = errors.New("GitLab issue not found")
+
 // ErrProjectNotFound is when the requested GitLab project is not found.
 var ErrProjectNotFound = &ProjectNotFoundError{}
 
@@ -0,0 +1,104 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestCreateIssue(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{}
+
+	t.Run("error status code", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusInternalServerError}
+
+		issue, err := client.CreateIssue(ctx, project, CreateIssueOpts{Title: "title"})
+		if issue != nil {
+			t.Errorf("unexpected non-nil issue: %+v", issue)
+		}
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `{"iid":42,"state":"opened","web_url":"https://gitlab.com/a/b/-/issues/42"}`,
+		}
+
+		issue, err := client.CreateIssue(ctx, project, CreateIssueOpts{Title: "title"})
+		if err != nil {
+			t.Fatalf("unexpected non-nil error: %+v", err)
+		}
+		want := &Issue{IID: 42, State: IssueStateOpened, WebURL: "https://gitlab.com/a/b/-/issues/42"}
+		if diff := cmp.Diff(want, issue); diff != "" {
+			t.Errorf("unexpected issue: %s", diff)
+		}
+	})
+}
+
+func TestGetIssue(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{}
+
+	t.Run("not found", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusNotFound}
+
+		issue, err := client.GetIssue(ctx, project, 1)
+		if issue != nil {
+			t.Errorf("unexpected non-nil issue: %+v", issue)
+		}
+		if !errors.Is(err, ErrIssueNotFound) {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `{"iid":42,"state":"closed"}`,
+		}
+
+		issue, err := client.GetIssue(ctx, project, 42)
+		if err != nil {
+			t.Fatalf("unexpected non-nil error: %+v", err)
+		}
+		if diff := cmp.Diff(&Issue{IID: 42, State: IssueStateClosed}, issue); diff != "" {
+			t.Errorf("unexpected issue: %s", diff)
+		}
+	})
+}
+
+func TestCreateIssueNote(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{}
+	issue := &Issue{IID: 42}
+
+	t.Run("error status code", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusNotFound}
+
+		if err := client.CreateIssueNote(ctx, project, issue, "comment"); err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `{"id":1}`,
+		}
+
+		if err := client.CreateIssueNote(ctx, project, issue, "comment"); err != nil {
+			t.Errorf("unexpected non-nil error: %+v", err)
+		}
+	})
+}
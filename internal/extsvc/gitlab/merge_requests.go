@@ -46,6 +46,7 @@ type MergeRequest struct {
 	WebURL                 string            `json:"web_url"`
 	WorkInProgress         bool              `json:"work_in_progress"`
 	Draft                  bool              `json:"draft"`
+	HasConflicts           bool              `json:"has_conflicts"`
 	// We only get a partial User object back from the REST API. For example, it lacks
 	// `Email` and `Identities`. If we need more, we need to issue an additional API
 	// request. Otherwise, we should use a different type here.
@@ -218,6 +219,8 @@ type UpdateMergeRequestOpts struct {
 	Title        string                       `json:"title,omitempty"`
 	Description  string                       `json:"description,omitempty"`
 	StateEvent   UpdateMergeRequestStateEvent `json:"state_event,omitempty"`
+	// ReviewerIDs replaces the reviewers of the merge request, if set.
+	ReviewerIDs []int32 `json:"reviewer_ids,omitempty"`
 }
 
 type UpdateMergeRequestStateEvent string
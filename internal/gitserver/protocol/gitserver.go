@@ -404,6 +404,11 @@ type CreateCommitFromPatchRequest struct {
 	Patch []byte
 	// TargetRef is the ref that will be created for this patch
 	TargetRef string
+	// PushRef is the ref the commit will be pushed to on the code host. If
+	// nil, TargetRef is pushed. Code hosts like Gerrit, which create changes
+	// from pushes to special refs, use it to push somewhere other than the
+	// ref created locally.
+	PushRef *string
 	// If set to true and the TargetRef already exists, an unique number will be appended to the end (ie TargetRef-{#}). The generated ref will be returned.
 	UniqueRef bool
 	// CommitInfo is the information that will be used when creating the commit from a patch
@@ -14,6 +14,7 @@ import (
 type EnterpriseJobs interface {
 	FileHasOwnerJob(child job.Job, features *search.Features, includeOwners, excludeOwners []string) job.Job
 	SelectFileOwnerJob(child job.Job, features *search.Features) job.Job
+	SymbolRankingJob(child job.Job) job.Job
 }
 
 func NewUnimplementedEnterpriseJobs() EnterpriseJobs {
@@ -30,6 +31,12 @@ func (e *enterpriseJobs) SelectFileOwnerJob(child job.Job, features *search.Feat
 	return NewUnimplementedJob("`select:file.owners` searches are not available on this instance")
 }
 
+// SymbolRankingJob returns the child unchanged, as symbol ranking relies on
+// precise code intelligence data.
+func (e *enterpriseJobs) SymbolRankingJob(child job.Job) job.Job {
+	return child
+}
+
 func NewUnimplementedJob(msg string) *UnimplementedJob {
 	return &UnimplementedJob{msg: msg}
 }
@@ -81,11 +81,13 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic, enterpriseJobs Enterprise
 		b.Pattern = query.Operator{Operands: newNodes, Kind: query.And}
 	}
 
+	var isSymbolSearch bool
 	{
 		// This block generates jobs that can be built directly from
 		// a basic query rather than first being expanded into
 		// flat queries.
 		resultTypes := computeResultTypes(b, inputs.PatternType)
+		isSymbolSearch = resultTypes.Has(result.TypeSymbol)
 		fileMatchLimit := int32(computeFileMatchLimit(b, inputs.Protocol))
 		selector, _ := filter.SelectPathFromString(b.FindValue(query.FieldSelect)) // Invariant: select is validated
 		repoOptions := toRepoOptions(b, inputs.UserSettings)
@@ -190,6 +192,12 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic, enterpriseJobs Enterprise
 		}
 	}
 
+	{ // Order symbol matches by how often they are referenced
+		if isSymbolSearch {
+			basicJob = enterpriseJobs.SymbolRankingJob(basicJob)
+		}
+	}
+
 	{ // Apply subrepo permissions checks
 		checker := authz.DefaultSubRepoPermsChecker
 		if authz.SubRepoEnabled(checker) {
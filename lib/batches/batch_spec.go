@@ -2,6 +2,7 @@ package batches
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/lib/batches/env"
@@ -37,8 +38,16 @@ type BatchSpec struct {
 	TransformChanges  *TransformChanges        `json:"transformChanges,omitempty" yaml:"transformChanges,omitempty"`
 	ImportChangesets  []ImportChangeset        `json:"importChangesets,omitempty" yaml:"importChangesets"`
 	ChangesetTemplate *ChangesetTemplate       `json:"changesetTemplate,omitempty" yaml:"changesetTemplate"`
+	AutoRebase        bool                     `json:"autoRebase,omitempty" yaml:"autoRebase,omitempty"`
+	AutoMerge         *AutoMergePolicy         `json:"autoMerge,omitempty" yaml:"autoMerge,omitempty"`
+	OrderingGroups    []OrderingGroup          `json:"orderingGroups,omitempty" yaml:"orderingGroups,omitempty"`
+	Reviewers         string                   `json:"reviewers,omitempty" yaml:"reviewers,omitempty"`
 }
 
+// ReviewersFromOwnership requests reviews of changesets from the code owners of
+// the files they change.
+const ReviewersFromOwnership = "fromOwnership"
+
 type ChangesetTemplate struct {
 	Title     string                       `json:"title,omitempty" yaml:"title"`
 	Body      string                       `json:"body,omitempty" yaml:"body"`
@@ -128,6 +137,36 @@ type Group struct {
 	Repository string `json:"repository,omitempty" yaml:"repository"`
 }
 
+type AutoMergePolicy struct {
+	CheckState       string           `json:"checkState,omitempty" yaml:"checkState"`
+	MinApprovals     int              `json:"minApprovals,omitempty" yaml:"minApprovals"`
+	Window           *AutoMergeWindow `json:"window,omitempty" yaml:"window"`
+	MaxMergesPerHour int              `json:"maxMergesPerHour,omitempty" yaml:"maxMergesPerHour"`
+	Squash           bool             `json:"squash,omitempty" yaml:"squash"`
+}
+
+type AutoMergeWindow struct {
+	Days  []string `json:"days,omitempty" yaml:"days"`
+	Start string   `json:"start,omitempty" yaml:"start"`
+	End   string   `json:"end,omitempty" yaml:"end"`
+}
+
+type OrderingGroup struct {
+	Repositories []string `json:"repositories,omitempty" yaml:"repositories"`
+	Hold         string   `json:"hold,omitempty" yaml:"hold"`
+}
+
+const (
+	OrderingGroupHoldPublication = "publication"
+	OrderingGroupHoldMerge       = "merge"
+)
+
+// HoldsPublication returns true if the changesets in the group must not be
+// published until the changesets of the groups before it have been merged.
+func (g OrderingGroup) HoldsPublication() bool {
+	return g.Hold != OrderingGroupHoldMerge
+}
+
 type Mount struct {
 	Mountpoint string `json:"mountpoint" yaml:"mountpoint"`
 	Path       string `json:"path" yaml:"path"`
@@ -176,11 +215,32 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 		}
 	}
 
+	for i, group := range spec.OrderingGroups {
+		for _, pattern := range group.Repositories {
+			if _, err := path.Match(pattern, ""); err != nil {
+				errs = errors.Append(errs, NewValidationError(errors.Newf("ordering group %d contains invalid repository pattern %q", i+1, pattern)))
+			}
+		}
+	}
+
 	return &spec, errs
 }
 
 const invalidMountCharacters = ","
 
+// OrderingGroupFor returns the index of the first ordering group that contains
+// the repository with the given name, or -1 if no group contains it.
+func (spec *BatchSpec) OrderingGroupFor(repo string) int {
+	for i, group := range spec.OrderingGroups {
+		for _, pattern := range group.Repositories {
+			if ok, _ := path.Match(pattern, repo); ok {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
 func (on *OnQueryOrRepository) String() string {
 	if on.RepositoriesMatchingQuery != "" {
 		return on.RepositoriesMatchingQuery
@@ -3,6 +3,7 @@ package batches
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -210,6 +211,53 @@ changesetTemplate:
 		_, err := ParseBatchSpec([]byte(spec))
 		assert.Equal(t, "step 1 mount mountpoint contains invalid characters", err.Error())
 	})
+
+	t.Run("ordering group contains invalid pattern", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+orderingGroups:
+  - repositories:
+      - github.com/sourcegraph/[lib
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Equal(t, `ordering group 1 contains invalid repository pattern "github.com/sourcegraph/[lib"`, err.Error())
+	})
+
+	t.Run("reviewers from ownership", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+reviewers: fromOwnership
+`
+		have, err := ParseBatchSpec([]byte(spec))
+		assert.Nil(t, err)
+		assert.Equal(t, ReviewersFromOwnership, have.Reviewers)
+
+		_, err = ParseBatchSpec([]byte(strings.Replace(spec, "fromOwnership", "everyone", 1)))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestBatchSpec_OrderingGroupFor(t *testing.T) {
+	spec := &BatchSpec{
+		OrderingGroups: []OrderingGroup{
+			{Repositories: []string{"github.com/sourcegraph/lib"}},
+			{Repositories: []string{"github.com/sourcegraph/*"}, Hold: OrderingGroupHoldMerge},
+		},
+	}
+
+	for repo, want := range map[string]int{
+		"github.com/sourcegraph/lib":         0,
+		"github.com/sourcegraph/sourcegraph": 1,
+		"github.com/sourcegraph/lib/nested":  -1,
+		"gitlab.com/sourcegraph/lib":         -1,
+	} {
+		assert.Equal(t, want, spec.OrderingGroupFor(repo), repo)
+	}
+
+	assert.True(t, spec.OrderingGroups[0].HoldsPublication())
+	assert.False(t, spec.OrderingGroups[1].HoldsPublication())
 }
 
 func TestOnQueryOrRepository_Branches(t *testing.T) {
@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/base64"
@@ -150,6 +151,115 @@ func KeyForWorkspace(batchChangeAttributes *template.BatchChangeAttributes, r ba
 	}
 }
 
+// StepKey implements the Keyer interface for the result of a single step in a
+// repository workspace. Unlike CacheKey, it is content-addressed: it doesn't
+// depend on the other steps of the batch spec or on the commit the workspace
+// is based on, but only on the step itself, its inputs, the files it can see
+// and the changes of the steps before it. That allows results to be reused
+// across batch specs and users, as long as nothing the step depends on
+// changed.
+type StepKey struct {
+	// Repository is the repository of the workspace. Its BaseRev is ignored,
+	// since TreeHash captures the files the step can see.
+	Repository         batches.Repository
+	Path               string
+	OnlyFetchWorkspace bool
+	// TreeHash is the hash of the git tree of the workspace path if only the
+	// workspace is fetched, and of the root tree of the repository otherwise.
+	TreeHash string
+	Step     batches.Step
+	// PreviousResult is the result of the step before Step, which is the zero
+	// value for the first step.
+	PreviousResult execution.AfterStepResult
+	// BatchChangeAttributes are only part of the key if Step references them in
+	// a template, so that renaming a batch change doesn't invalidate the cache.
+	BatchChangeAttributes *template.BatchChangeAttributes
+
+	// Ignore from serialization.
+	MetadataRetriever MetadataRetriever `json:"-"`
+	// Ignore from serialization.
+	GlobalEnv []string `json:"-"`
+}
+
+// Key converts the key into a string form that can be used to uniquely identify
+// the cache key in a more concise form than the entire step.
+func (key StepKey) Key() (string, error) {
+	envs, err := resolveStepsEnvironment(key.GlobalEnv, []batches.Step{key.Step})
+	if err != nil {
+		return "", err
+	}
+
+	var metadata []MountMetadata
+	if key.MetadataRetriever != nil {
+		if metadata, err = key.MetadataRetriever.Get([]batches.Step{key.Step}); err != nil {
+			return "", err
+		}
+	}
+
+	repo := key.Repository
+	repo.BaseRev = ""
+	repo.FileMatches = append([]string(nil), repo.FileMatches...)
+	sort.Strings(repo.FileMatches)
+
+	// The previous diff and output can be large, so we only include their
+	// hashes. The output is part of the key since templates can reference it
+	// as previous_step.stdout and previous_step.stderr.
+	previousDiff := sha256.Sum256(key.PreviousResult.Diff)
+	previousStdout := sha256.Sum256([]byte(key.PreviousResult.Stdout))
+	previousStderr := sha256.Sum256([]byte(key.PreviousResult.Stderr))
+	previousOutputs := key.PreviousResult.Outputs
+	if len(previousOutputs) == 0 {
+		previousOutputs = nil
+	}
+
+	var batchChange *template.BatchChangeAttributes
+	rawStep, err := json.Marshal(key.Step)
+	if err != nil {
+		return "", err
+	}
+	if bytes.Contains(rawStep, []byte("batch_change.")) {
+		batchChange = key.BatchChangeAttributes
+	}
+
+	raw, err := json.Marshal(struct {
+		Repository            batches.Repository
+		Path                  string
+		OnlyFetchWorkspace    bool
+		TreeHash              string
+		Step                  batches.Step
+		Environment           map[string]string
+		MountsMetadata        []MountMetadata `json:",omitempty"`
+		PreviousDiff          []byte
+		PreviousStdout        []byte
+		PreviousStderr        []byte
+		PreviousOutputs       map[string]any
+		BatchChangeAttributes *template.BatchChangeAttributes `json:",omitempty"`
+	}{
+		Repository:            repo,
+		Path:                  key.Path,
+		OnlyFetchWorkspace:    key.OnlyFetchWorkspace,
+		TreeHash:              key.TreeHash,
+		Step:                  key.Step,
+		Environment:           envs[0],
+		MountsMetadata:        metadata,
+		PreviousDiff:          previousDiff[:],
+		PreviousStdout:        previousStdout[:],
+		PreviousStderr:        previousStderr[:],
+		PreviousOutputs:       previousOutputs,
+		BatchChangeAttributes: batchChange,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(raw)
+	return "step-" + base64.RawURLEncoding.EncodeToString(hash[:16]), nil
+}
+
+func (key StepKey) Slug() string {
+	return SlugForRepo(key.Repository.Name, key.Repository.BaseRev)
+}
+
 // ChangesetSpecsFromCache takes the execution.Result and generates all changeset specs from it.
 func ChangesetSpecsFromCache(spec *batches.BatchSpec, r batches.Repository, result execution.AfterStepResult, path string, binaryDiffs bool, fallbackAuthor *batches.ChangesetSpecAuthor) ([]*batches.ChangesetSpec, error) {
 	if len(result.Diff) == 0 {
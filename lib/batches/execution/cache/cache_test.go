@@ -10,6 +10,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/batches/env"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -195,3 +196,92 @@ type testM struct {
 func (t testM) Get(steps []batches.Step) ([]MountMetadata, error) {
 	return t.m, t.err
 }
+
+func TestStepKey_Key(t *testing.T) {
+	base := StepKey{
+		Repository: repo,
+		TreeHash:   "d3adb33f",
+		Step:       batches.Step{Run: "foo"},
+		BatchChangeAttributes: &template.BatchChangeAttributes{
+			Name: "my-batch-change",
+		},
+	}
+
+	key := func(t *testing.T, k StepKey) string {
+		t.Helper()
+		raw, err := k.Key()
+		require.NoError(t, err)
+		return raw
+	}
+	baseKey := key(t, base)
+
+	t.Run("unchanged", func(t *testing.T) {
+		for name, modify := range map[string]func(k *StepKey){
+			"commit": func(k *StepKey) {
+				k.Repository.BaseRev = "0th3r-c0mmit"
+			},
+			"unreferenced batch change attributes": func(k *StepKey) {
+				k.BatchChangeAttributes = &template.BatchChangeAttributes{Name: "renamed"}
+			},
+			"empty previous outputs": func(k *StepKey) {
+				k.PreviousResult.Outputs = map[string]any{}
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				k := base
+				modify(&k)
+				assert.Equal(t, baseKey, key(t, k))
+			})
+		}
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		for name, modify := range map[string]func(k *StepKey){
+			"tree hash": func(k *StepKey) {
+				k.TreeHash = "c0ff33"
+			},
+			"step": func(k *StepKey) {
+				k.Step = batches.Step{Run: "bar"}
+			},
+			"path": func(k *StepKey) {
+				k.Path = "sub/dir"
+			},
+			"previous diff": func(k *StepKey) {
+				k.PreviousResult.Diff = []byte("diff")
+			},
+			"previous outputs": func(k *StepKey) {
+				k.PreviousResult.Outputs = map[string]any{"foo": "bar"}
+			},
+			"previous stdout": func(k *StepKey) {
+				k.PreviousResult.Stdout = "hello"
+			},
+			"previous stderr": func(k *StepKey) {
+				k.PreviousResult.Stderr = "warning"
+			},
+			"global env": func(k *StepKey) {
+				var stepEnv env.Environment
+				require.NoError(t, json.Unmarshal([]byte(`["SOME_ENV"]`), &stepEnv))
+				k.Step.Env = stepEnv
+				k.GlobalEnv = []string{"SOME_ENV=FOO"}
+			},
+			"mount metadata": func(k *StepKey) {
+				k.MetadataRetriever = testM{m: []MountMetadata{{Path: "/foo/bar", Size: 100}}}
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				k := base
+				modify(&k)
+				assert.NotEqual(t, baseKey, key(t, k))
+			})
+		}
+	})
+
+	t.Run("referenced batch change attributes", func(t *testing.T) {
+		k := base
+		k.Step = batches.Step{Run: "echo ${{ batch_change.name }}"}
+		before := key(t, k)
+
+		k.BatchChangeAttributes = &template.BatchChangeAttributes{Name: "renamed"}
+		assert.NotEqual(t, before, key(t, k))
+	})
+}
@@ -306,6 +306,100 @@ const BatchSpecJSON = `{
         }
       }
     },
+    "autoRebase": {
+      "type": "boolean",
+      "description": "Whether published changesets that conflict with their base branch are automatically rebased. The steps of the changeset's workspace are re-executed against the latest commit of the base branch and the result is force-pushed to the changeset. Only supported for batch changes that are executed server-side.",
+      "default": false
+    },
+    "autoMerge": {
+      "type": "object",
+      "description": "A policy that is evaluated continuously against the open changesets of the batch change. Changesets that satisfy every criterion of the policy are merged automatically.",
+      "additionalProperties": false,
+      "properties": {
+        "checkState": {
+          "type": "string",
+          "description": "The state the checks on a changeset must be in for it to be merged. ` + "`" + `passed` + "`" + ` requires all checks to have passed, ` + "`" + `any` + "`" + ` ignores checks.",
+          "enum": ["passed", "any"],
+          "default": "passed"
+        },
+        "minApprovals": {
+          "type": "integer",
+          "description": "The minimum number of approving reviews a changeset must have, and no outstanding requests for changes, for it to be merged.",
+          "minimum": 0,
+          "default": 0
+        },
+        "window": {
+          "type": "object",
+          "description": "The window of time in which changesets may be merged, in UTC. If omitted, changesets may be merged at any time.",
+          "additionalProperties": false,
+          "properties": {
+            "days": {
+              "type": "array",
+              "description": "Day(s) on which changesets may be merged. If omitted, changesets may be merged on all days of the week.",
+              "items": {
+                "type": "string",
+                "pattern": "^([mM]on(day)?|[tT]ue(s|sday)?|[wW]ed(nesday)?|[tT]hu(r|rs|rsday)?|[fF]ri(day)?|[sS]at(urday)?|[sS]un(day)?)$"
+              }
+            },
+            "start": {
+              "type": "string",
+              "description": "The time of day from which changesets may be merged.",
+              "pattern": "^[0-9]?[0-9]:[0-9]{2}$"
+            },
+            "end": {
+              "type": "string",
+              "description": "The time of day until which changesets may be merged.",
+              "pattern": "^[0-9]?[0-9]:[0-9]{2}$"
+            }
+          },
+          "dependencies": {
+            "start": ["end"],
+            "end": ["start"]
+          }
+        },
+        "maxMergesPerHour": {
+          "type": "integer",
+          "description": "The maximum number of changesets that are merged per hour on each code host, counting the merges of all batch changes on it. If omitted, the number of merges is not limited.",
+          "minimum": 1
+        },
+        "squash": {
+          "type": "boolean",
+          "description": "Whether to squash the commits of a changeset when merging it.",
+          "default": false
+        }
+      }
+    },
+    "orderingGroups": {
+      "type": "array",
+      "description": "Groups of repositories whose changesets must land in order. The changesets in the repositories of a group are held until all changesets in the repositories of the groups before it have been merged. Changesets in repositories that aren't part of any group are not held.",
+      "items": {
+        "title": "OrderingGroup",
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["repositories"],
+        "properties": {
+          "repositories": {
+            "type": "array",
+            "description": "The names of the repositories in the group. Names may contain ` + "`" + `*` + "`" + ` wildcards, which match any sequence of characters other than ` + "`" + `/` + "`" + `. A repository belongs to the first group that contains it.",
+            "items": {
+              "type": "string"
+            },
+            "minItems": 1
+          },
+          "hold": {
+            "type": "string",
+            "description": "What is held until the changesets of the groups before this one have been merged. ` + "`" + `publication` + "`" + ` doesn't publish the changesets of the group. ` + "`" + `merge` + "`" + ` publishes them, but doesn't merge them automatically.",
+            "enum": ["publication", "merge"],
+            "default": "publication"
+          }
+        }
+      }
+    },
+    "reviewers": {
+      "type": "string",
+      "description": "Where the reviewers requested on published changesets come from. ` + "`" + `fromOwnership` + "`" + ` requests reviews from the code owners of the files changed by each changeset who have an account on its code host. Only supported on GitHub, GitLab, Bitbucket Server and Azure DevOps.",
+      "enum": ["fromOwnership"]
+    },
     "changesetTemplate": {
       "type": "object",
       "description": "A template describing how to create (and update) changesets with the file changes produced by the command steps.",
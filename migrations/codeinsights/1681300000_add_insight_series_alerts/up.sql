@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS insight_series_alerts
+(
+    id                SERIAL CONSTRAINT insight_series_alerts_pk PRIMARY KEY,
+    series_id         INT                      NOT NULL,
+    user_id           INT                      NOT NULL,
+    kind              TEXT                     NOT NULL,
+    threshold         DOUBLE PRECISION         NOT NULL DEFAULT 0,
+    window_days       INT                      NOT NULL DEFAULT 0,
+    email             BOOLEAN                  NOT NULL DEFAULT FALSE,
+    slack_webhook_url TEXT,
+    outbound_webhook  BOOLEAN                  NOT NULL DEFAULT FALSE,
+    firing            BOOLEAN                  NOT NULL DEFAULT FALSE,
+    last_fired_at     TIMESTAMP WITH TIME ZONE,
+    created_at        TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT insight_series_alerts_series_id_fk
+        FOREIGN KEY (series_id) REFERENCES insight_series (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS insight_series_alerts_series_id_idx
+    ON insight_series_alerts (series_id);
+
+COMMENT ON TABLE insight_series_alerts IS 'Alert rules evaluated against a series each time a new snapshot is recorded.';
+COMMENT ON COLUMN insight_series_alerts.user_id IS 'User ID that created the alert and receives email notifications.';
+COMMENT ON COLUMN insight_series_alerts.kind IS 'One of THRESHOLD, PERCENT_CHANGE or NEW_REPOSITORY.';
+COMMENT ON COLUMN insight_series_alerts.threshold IS 'The value a THRESHOLD alert fires above, or the percentage a PERCENT_CHANGE alert fires beyond.';
+COMMENT ON COLUMN insight_series_alerts.window_days IS 'The number of days a PERCENT_CHANGE alert compares the current value against.';
+COMMENT ON COLUMN insight_series_alerts.firing IS 'Whether the alert condition held at the last evaluation. Alerts only notify when this changes from false to true.';
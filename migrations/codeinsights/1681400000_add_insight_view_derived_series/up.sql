@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS insight_view_derived_series
+(
+    id              SERIAL CONSTRAINT insight_view_derived_series_pk PRIMARY KEY,
+    insight_view_id INT  NOT NULL,
+    series_id       TEXT NOT NULL,
+    label           TEXT NOT NULL,
+    stroke          TEXT NOT NULL DEFAULT '',
+    expression      TEXT NOT NULL,
+
+    CONSTRAINT insight_view_derived_series_insight_view_id_fk
+        FOREIGN KEY (insight_view_id) REFERENCES insight_view (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS insight_view_derived_series_series_id_idx
+    ON insight_view_derived_series (series_id);
+
+CREATE INDEX IF NOT EXISTS insight_view_derived_series_insight_view_id_idx
+    ON insight_view_derived_series (insight_view_id);
+
+COMMENT ON TABLE insight_view_derived_series IS 'Series of an insight view that are computed at read time from the other series of the same view.';
+COMMENT ON COLUMN insight_view_derived_series.series_id IS 'Globally unique identifier for this derived series that is externally referencable.';
+COMMENT ON COLUMN insight_view_derived_series.expression IS 'Arithmetic expression over the labels of the other series in the view, e.g. migrated / (migrated + legacy) * 100.';
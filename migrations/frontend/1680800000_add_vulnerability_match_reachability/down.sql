@@ -0,0 +1,3 @@
+ALTER TABLE vulnerability_matches DROP COLUMN IF EXISTS reachable;
+ALTER TABLE vulnerability_matches DROP COLUMN IF EXISTS reachability_checked_at;
+ALTER TABLE vulnerability_matches DROP COLUMN IF EXISTS call_sites;
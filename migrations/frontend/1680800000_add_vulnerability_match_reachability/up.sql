@@ -0,0 +1,6 @@
+ALTER TABLE vulnerability_matches ADD COLUMN IF NOT EXISTS reachable BOOLEAN;
+ALTER TABLE vulnerability_matches ADD COLUMN IF NOT EXISTS reachability_checked_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE vulnerability_matches ADD COLUMN IF NOT EXISTS call_sites JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+COMMENT ON COLUMN vulnerability_matches.reachable IS 'Whether the upload references any of the symbols affected by the vulnerability. Null if reachability has not yet been determined.';
+COMMENT ON COLUMN vulnerability_matches.call_sites IS 'A sample of locations within the upload referencing one of the symbols affected by the vulnerability.';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS codeintel_symbol_ranks;
+DROP TABLE IF EXISTS codeintel_ranking_symbol_counts_inputs;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS codeintel_ranking_symbol_counts_inputs (
+    id BIGSERIAL PRIMARY KEY,
+    repository_id INTEGER NOT NULL,
+    document_path TEXT NOT NULL,
+    symbol_name TEXT NOT NULL,
+    count INTEGER NOT NULL,
+    graph_key TEXT NOT NULL,
+    processed BOOLEAN NOT NULL DEFAULT false
+);
+
+CREATE INDEX IF NOT EXISTS codeintel_ranking_symbol_counts_inputs_graph_key_id ON codeintel_ranking_symbol_counts_inputs USING btree (graph_key, id);
+CREATE INDEX IF NOT EXISTS codeintel_ranking_symbol_counts_inputs_graph_key_repository_id_id ON codeintel_ranking_symbol_counts_inputs USING btree (graph_key, repository_id, id) WHERE NOT processed;
+
+CREATE TABLE IF NOT EXISTS codeintel_symbol_ranks (
+    id BIGSERIAL PRIMARY KEY,
+    repository_id INTEGER NOT NULL,
+    document_path TEXT NOT NULL,
+    symbol_name TEXT NOT NULL,
+    count INTEGER NOT NULL,
+    graph_key TEXT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS codeintel_symbol_ranks_repository_id_document_path_symbol_name ON codeintel_symbol_ranks USING btree (repository_id, document_path, symbol_name);
+CREATE INDEX IF NOT EXISTS codeintel_symbol_ranks_graph_key ON codeintel_symbol_ranks USING btree (graph_key);
+
+COMMENT ON COLUMN codeintel_symbol_ranks.symbol_name IS 'The SCIP symbol name of the definition.';
+COMMENT ON COLUMN codeintel_symbol_ranks.count IS 'The number of reference records (across all indexed repositories) that reference the definition. Each record holds a batch of the references of a single document.';
@@ -0,0 +1,2 @@
+ALTER TABLE cm_last_searched DROP COLUMN IF EXISTS match_keys;
+ALTER TABLE cm_trigger_jobs DROP COLUMN IF EXISTS file_results;
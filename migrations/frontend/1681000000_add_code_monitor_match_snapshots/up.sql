@@ -0,0 +1,5 @@
+ALTER TABLE cm_last_searched ADD COLUMN IF NOT EXISTS match_keys TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE cm_trigger_jobs ADD COLUMN IF NOT EXISTS file_results JSONB;
+
+COMMENT ON COLUMN cm_last_searched.match_keys IS 'For code monitors of file and symbol matches, the keys of the matches that were found in the repository on the last run';
+COMMENT ON COLUMN cm_trigger_jobs.file_results IS 'For code monitors of file and symbol matches, the new file and symbol matches that were found';
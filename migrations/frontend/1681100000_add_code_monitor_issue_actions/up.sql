@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS cm_issue_actions (
+    id BIGSERIAL PRIMARY KEY,
+    monitor BIGINT NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL,
+    include_results BOOLEAN NOT NULL DEFAULT false,
+    repo_id INTEGER NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    issue_id TEXT,
+    issue_url TEXT,
+    created_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    changed_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS cm_issue_actions_monitor ON cm_issue_actions USING btree (monitor);
+
+COMMENT ON TABLE cm_issue_actions IS 'Issue actions configured on code monitors';
+COMMENT ON COLUMN cm_issue_actions.monitor IS 'The code monitor that the action is defined on';
+COMMENT ON COLUMN cm_issue_actions.repo_id IS 'The repository on the code host the issue is filed in';
+COMMENT ON COLUMN cm_issue_actions.issue_id IS 'The code host ID of the issue that tracks the results of the code monitor. Null until the first issue is filed';
+COMMENT ON COLUMN cm_issue_actions.issue_url IS 'The URL of the issue that tracks the results of the code monitor';
+
+ALTER TABLE cm_action_jobs ADD COLUMN IF NOT EXISTS issue_action BIGINT REFERENCES cm_issue_actions(id) ON DELETE CASCADE;
+
+COMMENT ON COLUMN cm_action_jobs.issue_action IS 'The ID of the cm_issue_actions action to execute if this is an issue job. Mutually exclusive with email, webhook, and slack_webhook';
+
+ALTER TABLE cm_action_jobs DROP CONSTRAINT IF EXISTS cm_action_jobs_only_one_action_type;
+ALTER TABLE cm_action_jobs ADD CONSTRAINT cm_action_jobs_only_one_action_type CHECK ((
+    CASE WHEN email IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN slack_webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN issue_action IS NULL THEN 0 ELSE 1 END
+) = 1);
+
+COMMENT ON CONSTRAINT cm_action_jobs_only_one_action_type ON cm_action_jobs IS 'Constrains that each queued code monitor action has exactly one action type';
@@ -0,0 +1,6 @@
+ALTER TABLE cm_monitors DROP COLUMN IF EXISTS snoozed_until;
+
+ALTER TABLE cm_slack_webhooks DROP COLUMN IF EXISTS delivery_schedule;
+ALTER TABLE cm_emails DROP COLUMN IF EXISTS delivery_schedule;
+
+DROP TYPE IF EXISTS cm_delivery_schedule;
@@ -0,0 +1,17 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'cm_delivery_schedule') THEN
+        CREATE TYPE cm_delivery_schedule AS ENUM ('IMMEDIATE', 'HOURLY', 'DAILY');
+    END IF;
+END
+$$;
+
+ALTER TABLE cm_emails ADD COLUMN IF NOT EXISTS delivery_schedule cm_delivery_schedule NOT NULL DEFAULT 'IMMEDIATE';
+ALTER TABLE cm_slack_webhooks ADD COLUMN IF NOT EXISTS delivery_schedule cm_delivery_schedule NOT NULL DEFAULT 'IMMEDIATE';
+
+COMMENT ON COLUMN cm_emails.delivery_schedule IS 'Whether the action is sent for every trigger event, or as an hourly or daily digest of the trigger events';
+COMMENT ON COLUMN cm_slack_webhooks.delivery_schedule IS 'Whether the action is sent for every trigger event, or as an hourly or daily digest of the trigger events';
+
+ALTER TABLE cm_monitors ADD COLUMN IF NOT EXISTS snoozed_until TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN cm_monitors.snoozed_until IS 'If set, no actions are run for trigger events of the code monitor until this time';
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS notebook_block_snapshots (
+    notebook_id bigint NOT NULL REFERENCES notebooks(id) ON DELETE CASCADE DEFERRABLE,
+    block_id text NOT NULL,
+    input_hash text NOT NULL,
+    output jsonb NOT NULL,
+    creator_user_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (notebook_id, block_id)
+);
+
+COMMENT ON TABLE notebook_block_snapshots IS 'The stored output of executable notebook blocks, shown when a notebook is viewed.';
+COMMENT ON COLUMN notebook_block_snapshots.input_hash IS 'Hash of the block type and inputs the output was computed from. The snapshot is ignored once the block is edited.';
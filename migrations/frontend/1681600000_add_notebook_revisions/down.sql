@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS notebook_revisions;
+
+ALTER TABLE notebooks DROP COLUMN IF EXISTS version;
@@ -0,0 +1,20 @@
+ALTER TABLE notebooks ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN notebooks.version IS 'Incremented on every update of the notebook. Updates based on an older version are rejected.';
+
+CREATE TABLE IF NOT EXISTS notebook_revisions (
+    notebook_id bigint NOT NULL REFERENCES notebooks(id) ON DELETE CASCADE DEFERRABLE,
+    version integer NOT NULL,
+    title text NOT NULL,
+    blocks jsonb NOT NULL,
+    author_user_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (notebook_id, version)
+);
+
+COMMENT ON TABLE notebook_revisions IS 'Immutable history of the title and blocks of notebooks. A revision is created for every version of a notebook.';
+
+INSERT INTO notebook_revisions (notebook_id, version, title, blocks, author_user_id, created_at)
+SELECT id, version, title, blocks, COALESCE(updater_user_id, creator_user_id), updated_at
+FROM notebooks
+ON CONFLICT DO NOTHING;
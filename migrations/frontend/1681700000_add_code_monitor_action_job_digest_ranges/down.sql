@@ -0,0 +1,2 @@
+ALTER TABLE cm_action_jobs DROP COLUMN IF EXISTS digest_last_trigger_event;
+ALTER TABLE cm_action_jobs DROP COLUMN IF EXISTS digest_first_trigger_event;
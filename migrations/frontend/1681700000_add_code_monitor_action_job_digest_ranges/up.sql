@@ -0,0 +1,5 @@
+ALTER TABLE cm_action_jobs ADD COLUMN IF NOT EXISTS digest_first_trigger_event integer;
+ALTER TABLE cm_action_jobs ADD COLUMN IF NOT EXISTS digest_last_trigger_event integer;
+
+COMMENT ON COLUMN cm_action_jobs.digest_first_trigger_event IS 'For the action jobs of digest actions, the first trigger event whose results are included in the digest';
+COMMENT ON COLUMN cm_action_jobs.digest_last_trigger_event IS 'For the action jobs of digest actions, the last trigger event whose results are included in the digest. Later digests of the same action start after it.';
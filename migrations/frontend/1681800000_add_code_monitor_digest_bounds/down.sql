@@ -0,0 +1,5 @@
+COMMENT ON COLUMN cm_action_jobs.digest_last_trigger_event IS 'For the action jobs of digest actions, the last trigger event whose results are included in the digest. Later digests of the same action start after it.';
+
+ALTER TABLE cm_slack_webhooks DROP COLUMN IF EXISTS delivery_schedule_changed_at;
+ALTER TABLE cm_emails DROP COLUMN IF EXISTS delivery_schedule_changed_at;
+ALTER TABLE cm_trigger_jobs DROP COLUMN IF EXISTS snoozed;
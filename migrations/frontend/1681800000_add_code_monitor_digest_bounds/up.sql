@@ -0,0 +1,8 @@
+ALTER TABLE cm_trigger_jobs ADD COLUMN IF NOT EXISTS snoozed boolean NOT NULL DEFAULT FALSE;
+ALTER TABLE cm_emails ADD COLUMN IF NOT EXISTS delivery_schedule_changed_at timestamp with time zone;
+ALTER TABLE cm_slack_webhooks ADD COLUMN IF NOT EXISTS delivery_schedule_changed_at timestamp with time zone;
+
+COMMENT ON COLUMN cm_trigger_jobs.snoozed IS 'Whether the monitor was snoozed when the trigger event was recorded. The results of snoozed trigger events are never sent.';
+COMMENT ON COLUMN cm_emails.delivery_schedule_changed_at IS 'The last time the delivery schedule of the action was changed. Digests only include trigger events recorded since.';
+COMMENT ON COLUMN cm_slack_webhooks.delivery_schedule_changed_at IS 'The last time the delivery schedule of the action was changed. Digests only include trigger events recorded since.';
+COMMENT ON COLUMN cm_action_jobs.digest_last_trigger_event IS 'The last trigger event delivered by the action job: the last trigger event whose results are included in a digest, or the trigger event of an immediate email or Slack action. Later digests of the same action start after it.';
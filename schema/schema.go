@@ -205,6 +205,20 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"azureDevOps", "bitbucketcloud", "builtin", "gerrit", "github", "gitlab", "http-header", "openidconnect", "saml"})
 }
 
+// AutoMerge description: A policy that is evaluated continuously against the open changesets of the batch change. Changesets that satisfy every criterion of the policy are merged automatically.
+type AutoMerge struct {
+	// CheckState description: The state the checks on a changeset must be in for it to be merged. `passed` requires all checks to have passed, `any` ignores checks.
+	CheckState string `json:"checkState,omitempty"`
+	// MaxMergesPerHour description: The maximum number of changesets of the batch change that are merged per hour on each code host. If omitted, the number of merges is not limited.
+	MaxMergesPerHour int `json:"maxMergesPerHour,omitempty"`
+	// MinApprovals description: The minimum number of approving reviews a changeset must have, and no outstanding requests for changes, for it to be merged.
+	MinApprovals int `json:"minApprovals,omitempty"`
+	// Squash description: Whether to squash the commits of a changeset when merging it.
+	Squash bool `json:"squash,omitempty"`
+	// Window description: The window of time in which changesets may be merged, in UTC. If omitted, changesets may be merged at any time.
+	Window *Window `json:"window,omitempty"`
+}
+
 // AzureDevOpsAuthProvider description: Azure auth provider for dev.azure.com
 type AzureDevOpsAuthProvider struct {
 	// AllowOrgs description: Restricts new logins and signups (if allowSignup is true) to members of these Azure DevOps organizations only. Existing sessions won't be invalidated. Leave empty or unset for no org restrictions.
@@ -266,8 +280,32 @@ type BatchChangeRolloutWindow struct {
 	Start string `json:"start,omitempty"`
 }
 
+// BatchChangesGuardrails description: Policies that every batch change must comply with. They are checked when a batch spec is applied, and again before a changeset is published. Operations that violate a policy fail and are recorded in the audit log.
+type BatchChangesGuardrails struct {
+	// DraftFirstRepositories description: Glob patterns of repository names whose changesets must be published as drafts before they can be published for review.
+	DraftFirstRepositories []string `json:"draftFirstRepositories,omitempty"`
+	// ForbiddenPaths description: Glob patterns of file paths that changesets must not change. `*` matches any sequence of characters other than `/`, `**` matches any sequence of characters.
+	ForbiddenPaths []string `json:"forbiddenPaths,omitempty"`
+	// MaxChangesets description: The maximum number of changesets a batch change can have.
+	MaxChangesets int `json:"maxChangesets,omitempty"`
+	// PublicationWindows description: Windows during which changesets can be published. If omitted, changesets can be published at any time. All days and times are handled in UTC.
+	PublicationWindows []*BatchChangesPublicationWindow `json:"publicationWindows,omitempty"`
+}
+type BatchChangesPublicationWindow struct {
+	// Days description: Day(s) the window applies to. If omitted, this rule applies to all days of the week.
+	Days []string `json:"days,omitempty"`
+	// End description: Window end time. If omitted, no time window is applied to the day(s) that match this rule.
+	End string `json:"end,omitempty"`
+	// Start description: Window start time. If omitted, no time window is applied to the day(s) that match this rule.
+	Start string `json:"start,omitempty"`
+}
+
 // BatchSpec description: A batch specification, which describes the batch change and what kinds of changes to make (or what existing changesets to track).
 type BatchSpec struct {
+	// AutoMerge description: A policy that is evaluated continuously against the open changesets of the batch change. Changesets that satisfy every criterion of the policy are merged automatically.
+	AutoMerge *AutoMerge `json:"autoMerge,omitempty"`
+	// AutoRebase description: Whether published changesets that conflict with their base branch are automatically rebased. The steps of the changeset's workspace are re-executed against the latest commit of the base branch and the result is force-pushed to the changeset. Only supported for batch changes that are executed server-side.
+	AutoRebase bool `json:"autoRebase,omitempty"`
 	// ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 	ChangesetTemplate *ChangesetTemplate `json:"changesetTemplate,omitempty"`
 	// Description description: The description of the batch change.
@@ -278,6 +316,10 @@ type BatchSpec struct {
 	Name string `json:"name"`
 	// On description: The set of repositories (and branches) to run the batch change on, specified as a list of search queries (that match repositories) and/or specific repositories.
 	On []any `json:"on,omitempty"`
+	// OrderingGroups description: Groups of repositories whose changesets must land in order. The changesets in the repositories of a group are held until all changesets in the repositories of the groups before it have been merged. Changesets in repositories that aren't part of any group are not held.
+	OrderingGroups []*OrderingGroup `json:"orderingGroups,omitempty"`
+	// Reviewers description: Where the reviewers requested on published changesets come from. `fromOwnership` requests reviews from the code owners of the files changed by each changeset who have an account on its code host. Only supported on GitHub, GitLab, Bitbucket Server and Azure DevOps.
+	Reviewers string `json:"reviewers,omitempty"`
 	// Steps description: The sequence of commands to run (for each repository branch matched in the `on` property) to produce the workspace changes that will be included in the batch change.
 	Steps []*Step `json:"steps,omitempty"`
 	// TransformChanges description: Optional transformations to apply to the changes produced in each repository.
@@ -613,6 +655,8 @@ type EmailTemplates struct {
 type Embeddings struct {
 	// AccessToken description: The access token used to authenticate with the external embedding API service.
 	AccessToken string `json:"accessToken"`
+	// AnnProbes description: The number of clusters of the approximate nearest neighbor index that are searched for each query. Embedding indexes with at least 100,000 rows have an approximate nearest neighbor index that splits their rows into about the square root of the number of rows clusters. Searching more clusters increases recall and latency. Defaults to 0, which searches every row, so approximate search is only used when set to a positive number. -1 also searches every row.
+	AnnProbes int `json:"annProbes,omitempty"`
 	// Dimensions description: The dimensionality of the embedding vectors.
 	Dimensions int `json:"dimensions"`
 	// Enabled description: Toggles whether embedding service is enabled.
@@ -1618,6 +1662,12 @@ type OpenTelemetry struct {
 	// Endpoint description: OpenTelemetry tracing collector endpoint. By default, Sourcegraph's "/-/debug/otlp" endpoint forwards data to the configured collector backend.
 	Endpoint string `json:"endpoint,omitempty"`
 }
+type OrderingGroup struct {
+	// Hold description: What is held until the changesets of the groups before this one have been merged. `publication` doesn't publish the changesets of the group. `merge` publishes them, but doesn't merge them automatically.
+	Hold string `json:"hold,omitempty"`
+	// Repositories description: The names of the repositories in the group. Names may contain `*` wildcards, which match any sequence of characters other than `/`. A repository belongs to the first group that contains it.
+	Repositories []string `json:"repositories"`
+}
 
 // OrganizationInvitations description: Configuration for organization invitations.
 type OrganizationInvitations struct {
@@ -2367,6 +2417,8 @@ type SiteConfiguration struct {
 	BatchChangesEnabled *bool `json:"batchChanges.enabled,omitempty"`
 	// BatchChangesEnforceForks description: When enabled, all branches created by batch changes will be pushed to forks of the original repository.
 	BatchChangesEnforceForks bool `json:"batchChanges.enforceForks,omitempty"`
+	// BatchChangesGuardrails description: Policies that every batch change must comply with. They are checked when a batch spec is applied, and again before a changeset is published. Operations that violate a policy fail and are recorded in the audit log.
+	BatchChangesGuardrails *BatchChangesGuardrails `json:"batchChanges.guardrails,omitempty"`
 	// BatchChangesRestrictToAdmins description: When enabled, only site admins can create and apply batch changes.
 	BatchChangesRestrictToAdmins *bool `json:"batchChanges.restrictToAdmins,omitempty"`
 	// BatchChangesRolloutWindows description: Specifies specific windows, which can have associated rate limits, to be used when reconciling published changesets (creating or updating). All days and times are handled in UTC.
@@ -2622,6 +2674,7 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "batchChanges.disableWebhooksWarning")
 	delete(m, "batchChanges.enabled")
 	delete(m, "batchChanges.enforceForks")
+	delete(m, "batchChanges.guardrails")
 	delete(m, "batchChanges.restrictToAdmins")
 	delete(m, "batchChanges.rolloutWindows")
 	delete(m, "branding")
@@ -2835,6 +2888,16 @@ type Webhooks struct {
 	Secret string `json:"secret,omitempty"`
 }
 
+// Window description: The window of time in which changesets may be merged, in UTC. If omitted, changesets may be merged at any time.
+type Window struct {
+	// Days description: Day(s) on which changesets may be merged. If omitted, changesets may be merged on all days of the week.
+	Days []string `json:"days,omitempty"`
+	// End description: The time of day until which changesets may be merged.
+	End string `json:"end,omitempty"`
+	// Start description: The time of day from which changesets may be merged.
+	Start string `json:"start,omitempty"`
+}
+
 // WorkspaceConfiguration description: Configuration for how to setup workspaces in repositories
 type WorkspaceConfiguration struct {
 	// In description: The repositories in which to apply the workspace configuration. Supports globbing.